@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/benidevo/vega/internal/ai"
+	"github.com/benidevo/vega/internal/ai/eval"
+	"github.com/benidevo/vega/internal/common/logger"
+	"github.com/benidevo/vega/internal/config"
+)
+
+// Exit codes of the eval command.
+const (
+	evalPassed = 0
+	evalFailed = 1
+	evalError  = 2
+)
+
+// runEval implements `vega eval`: it runs the AI services over a directory of
+// fixtures with the configured provider and writes a quality report.
+func runEval(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("eval", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	fixtureDir := flags.String("fixtures", "./data/eval", "directory containing profiles/*.json and jobs/*.json")
+	format := flags.String("format", eval.FormatMarkdown, "report format: markdown or json")
+	output := flags.String("output", "", "write the report to this file instead of stdout")
+	taskList := flags.String("tasks", "", "comma-separated tasks to run: match, cover_letter, cv (default all)")
+	provider := flags.String("provider", "", "AI provider to evaluate, overriding AI_PROVIDER and AI_PROVIDERS")
+	timeout := flags.Duration("timeout", 30*time.Minute, "maximum duration of the whole run")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: vega eval [flags]")
+		fmt.Fprintln(stderr, "\nRuns job matching, cover letter and CV generation over fixture profiles and jobs")
+		fmt.Fprintln(stderr, "and reports schema, score, banned phrase and length checks.")
+		fmt.Fprintln(stderr, "Exits with status 1 when any check fails.\n\nFlags:")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return evalError
+	}
+
+	tasks, err := eval.ParseTasks(*taskList)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return evalError
+	}
+	if *format != eval.FormatJSON && *format != eval.FormatMarkdown {
+		fmt.Fprintf(stderr, "unknown format '%s'\n", *format)
+		return evalError
+	}
+
+	cfg := config.NewSettings()
+	if *provider != "" {
+		cfg.AIProvider = *provider
+		cfg.AIProviders = nil
+	}
+	// Logs go to stderr so they never mix with a report written to stdout.
+	logger.Initialize(false, cfg.LogLevel)
+
+	fixtures, err := eval.LoadFixtures(*fixtureDir)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return evalError
+	}
+
+	service, err := ai.Setup(&cfg)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return evalError
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	report := eval.NewRunner(service, eval.DefaultTargets(), tasks...).Run(ctx, fixtures)
+
+	out := stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return evalError
+		}
+		defer file.Close()
+		out = file
+	}
+	if err := report.Write(out, *format); err != nil {
+		fmt.Fprintln(stderr, err)
+		return evalError
+	}
+
+	if report.HasFailures() {
+		return evalFailed
+	}
+	return evalPassed
+}
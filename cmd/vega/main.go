@@ -2,12 +2,17 @@ package main
 
 import (
 	"log"
+	"os"
 
 	"github.com/benidevo/vega/internal/config"
 	"github.com/benidevo/vega/internal/vega"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "eval" {
+		os.Exit(runEval(os.Args[2:], os.Stdout, os.Stderr))
+	}
+
 	cfg := config.NewSettings()
 	app := vega.New(cfg)
 
@@ -25,9 +25,14 @@ const (
 	ScoreThresholdPartial   = 50
 
 	// Service operation names for logging
-	OperationJobMatch      = "job_match_analysis"
-	OperationCoverLetter   = "cover_letter_generation"
-	OperationMatchAnalysis = "match_analysis"
+	OperationJobMatch       = "job_match_analysis"
+	OperationCoverLetter    = "cover_letter_generation"
+	OperationMatchAnalysis  = "match_analysis"
+	OperationInterviewPrep  = "interview_prep_generation"
+	OperationEmail          = "email_generation"
+	OperationLearningPlan   = "learning_plan_generation"
+	OperationJobExtraction  = "job_extraction"
+	OperationSectionRewrite = "section_rewrite"
 
 	// Error context types
 	ErrorTypeAIServiceUnavailable = "ai_service_unavailable"
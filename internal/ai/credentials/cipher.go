@@ -0,0 +1,67 @@
+package credentials
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+)
+
+// Cipher encrypts API keys at rest with AES-256-GCM. The key is derived from
+// a server secret, so rotating the secret makes stored keys unreadable.
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+// NewCipher creates a cipher keyed by the SHA-256 digest of secret.
+func NewCipher(secret string) (*Cipher, error) {
+	if secret == "" {
+		return nil, ErrMissingSecret
+	}
+
+	key := sha256.Sum256([]byte(secret))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Cipher{aead: aead}, nil
+}
+
+// Encrypt returns the base64 encoded nonce and ciphertext of plaintext. The
+// ciphertext is bound to additionalData, which Decrypt must be given again.
+func (c *Cipher) Encrypt(plaintext string, additionalData []byte) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", WrapError(ErrEncryptionFailed, err)
+	}
+
+	sealed := c.aead.Seal(nonce, nonce, []byte(plaintext), additionalData)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt reverses Encrypt. It fails unless additionalData matches the data
+// the plaintext was encrypted with.
+func (c *Cipher) Decrypt(encoded string, additionalData []byte) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", WrapError(ErrDecryptionFailed, err)
+	}
+
+	nonceSize := c.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return "", WrapError(ErrDecryptionFailed, errors.New("ciphertext too short"))
+	}
+
+	plaintext, err := c.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], additionalData)
+	if err != nil {
+		return "", WrapError(ErrDecryptionFailed, err)
+	}
+	return string(plaintext), nil
+}
@@ -0,0 +1,52 @@
+package credentials
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCipher_RoundTrip(t *testing.T) {
+	c, err := NewCipher("server-secret")
+	require.NoError(t, err)
+
+	encrypted, err := c.Encrypt("sk-test-1234", []byte("7:openai"))
+	require.NoError(t, err)
+	assert.NotContains(t, encrypted, "sk-test-1234")
+
+	again, err := c.Encrypt("sk-test-1234", []byte("7:openai"))
+	require.NoError(t, err)
+	assert.NotEqual(t, encrypted, again, "each encryption uses a fresh nonce")
+
+	decrypted, err := c.Decrypt(encrypted, []byte("7:openai"))
+	require.NoError(t, err)
+	assert.Equal(t, "sk-test-1234", decrypted)
+}
+
+func TestCipher_Errors(t *testing.T) {
+	_, err := NewCipher("")
+	assert.ErrorIs(t, err, ErrMissingSecret)
+
+	c, err := NewCipher("server-secret")
+	require.NoError(t, err)
+	encrypted, err := c.Encrypt("sk-test-1234", []byte("7:openai"))
+	require.NoError(t, err)
+
+	_, err = c.Decrypt(encrypted, []byte("8:openai"))
+	assert.ErrorIs(t, err, ErrDecryptionFailed, "bound to the user it was encrypted for")
+
+	_, err = c.Decrypt(encrypted, []byte("7:gemini"))
+	assert.ErrorIs(t, err, ErrDecryptionFailed, "bound to the provider it was encrypted for")
+
+	rotated, err := NewCipher("new-secret")
+	require.NoError(t, err)
+	_, err = rotated.Decrypt(encrypted, []byte("7:openai"))
+	assert.ErrorIs(t, err, ErrDecryptionFailed)
+
+	_, err = c.Decrypt("not base64!", nil)
+	assert.ErrorIs(t, err, ErrDecryptionFailed)
+
+	_, err = c.Decrypt("c2hvcnQ=", nil)
+	assert.ErrorIs(t, err, ErrDecryptionFailed)
+}
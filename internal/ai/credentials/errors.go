@@ -0,0 +1,20 @@
+package credentials
+
+import (
+	commonerrors "github.com/benidevo/vega/internal/common/errors"
+)
+
+var (
+	ErrCredentialNotFound  = commonerrors.New("no AI provider key saved")
+	ErrUnsupportedProvider = commonerrors.New("unsupported AI provider")
+	ErrEmptyAPIKey         = commonerrors.New("API key is required")
+	ErrMissingSecret       = commonerrors.New("credentials encryption key is not configured")
+	ErrEncryptionFailed    = commonerrors.New("failed to encrypt API key")
+	ErrDecryptionFailed    = commonerrors.New("failed to decrypt API key")
+	ErrVerificationFailed  = commonerrors.New("API key was rejected by the provider")
+)
+
+// WrapError wraps the given innerErr with the provided sentinelErr.
+func WrapError(sentinelErr, innerErr error) error {
+	return commonerrors.WrapError(sentinelErr, innerErr)
+}
@@ -0,0 +1,41 @@
+package credentials
+
+import "time"
+
+const (
+	ProviderGemini = "gemini"
+	ProviderOpenAI = "openai"
+)
+
+// SupportedProviders lists the providers users can bring their own key for.
+var SupportedProviders = []string{ProviderGemini, ProviderOpenAI}
+
+// Credential is a user's own AI provider key as stored in the
+// user_ai_credentials table. EncryptedKey is never shown to the user.
+type Credential struct {
+	UserID       int       `json:"user_id"`
+	Provider     string    `json:"provider"`
+	EncryptedKey string    `json:"-"`
+	KeyHint      string    `json:"key_hint"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// IsSupportedProvider reports whether users can store a key for the provider.
+func IsSupportedProvider(provider string) bool {
+	for _, p := range SupportedProviders {
+		if p == provider {
+			return true
+		}
+	}
+	return false
+}
+
+// keyHint returns the last four characters of an API key so users can tell
+// stored keys apart without revealing them.
+func keyHint(apiKey string) string {
+	if len(apiKey) <= 4 {
+		return ""
+	}
+	return apiKey[len(apiKey)-4:]
+}
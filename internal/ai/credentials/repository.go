@@ -0,0 +1,76 @@
+package credentials
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// Repository defines data access for per-user AI provider credentials.
+type Repository interface {
+	Get(ctx context.Context, userID int) (*Credential, error)
+	Upsert(ctx context.Context, credential *Credential) error
+	Delete(ctx context.Context, userID int) error
+}
+
+// repository implements the Repository interface
+type repository struct {
+	db *sql.DB
+}
+
+// NewRepository creates a new credentials repository
+func NewRepository(db *sql.DB) Repository {
+	return &repository{db: db}
+}
+
+// Get returns the user's credential, or ErrCredentialNotFound
+func (r *repository) Get(ctx context.Context, userID int) (*Credential, error) {
+	query := `
+		SELECT user_id, provider, encrypted_key, key_hint, created_at, updated_at
+		FROM user_ai_credentials
+		WHERE user_id = ?
+	`
+
+	var c Credential
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&c.UserID, &c.Provider, &c.EncryptedKey, &c.KeyHint, &c.CreatedAt, &c.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCredentialNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AI credentials: %w", err)
+	}
+	return &c, nil
+}
+
+// Upsert stores the user's credential, replacing any existing one
+func (r *repository) Upsert(ctx context.Context, credential *Credential) error {
+	query := `
+		INSERT INTO user_ai_credentials (user_id, provider, encrypted_key, key_hint)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(user_id) DO UPDATE SET
+			provider = excluded.provider,
+			encrypted_key = excluded.encrypted_key,
+			key_hint = excluded.key_hint,
+			updated_at = CURRENT_TIMESTAMP
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		credential.UserID, credential.Provider, credential.EncryptedKey, credential.KeyHint,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save AI credentials: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the user's credential, if any
+func (r *repository) Delete(ctx context.Context, userID int) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM user_ai_credentials WHERE user_id = ?`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete AI credentials: %w", err)
+	}
+	return nil
+}
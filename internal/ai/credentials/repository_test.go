@@ -0,0 +1,78 @@
+package credentials
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Get(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		setup       func(mock sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			name: "should_return_credential",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT user_id, provider, encrypted_key, key_hint, created_at, updated_at").
+					WithArgs(7).
+					WillReturnRows(sqlmock.NewRows([]string{"user_id", "provider", "encrypted_key", "key_hint", "created_at", "updated_at"}).
+						AddRow(7, "gemini", "ciphertext", "1234", now, now))
+			},
+		},
+		{
+			name: "should_return_not_found_when_missing",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT user_id").WithArgs(7).WillReturnError(sql.ErrNoRows)
+			},
+			expectedErr: ErrCredentialNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			tt.setup(mock)
+
+			credential, err := NewRepository(db).Get(context.Background(), 7)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, credential)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "gemini", credential.Provider)
+				assert.Equal(t, "1234", credential.KeyHint)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRepository_UpsertAndDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO user_ai_credentials").
+		WithArgs(7, "openai", "ciphertext", "abcd").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM user_ai_credentials").
+		WithArgs(7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := NewRepository(db)
+	require.NoError(t, repo.Upsert(context.Background(), &Credential{UserID: 7, Provider: "openai", EncryptedKey: "ciphertext", KeyHint: "abcd"}))
+	require.NoError(t, repo.Delete(context.Background(), 7))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,131 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/benidevo/vega/internal/common/logger"
+)
+
+// Verifier checks that an API key is accepted by the provider.
+type Verifier func(ctx context.Context, provider, apiKey string) error
+
+// Service manages users' own AI provider keys. Keys are encrypted before
+// they are stored and only decrypted to build a provider.
+type Service struct {
+	repo   Repository
+	cipher *Cipher
+	verify Verifier
+	log    *logger.PrivacyLogger
+}
+
+// NewService creates a new credentials service. verify may be nil, in which
+// case keys are stored without being checked.
+func NewService(repo Repository, cipher *Cipher, verify Verifier) *Service {
+	return &Service{
+		repo:   repo,
+		cipher: cipher,
+		verify: verify,
+		log:    logger.GetPrivacyLogger("ai_credentials"),
+	}
+}
+
+// Get returns the user's stored credential without the key, or
+// ErrCredentialNotFound.
+func (s *Service) Get(ctx context.Context, userID int) (*Credential, error) {
+	return s.repo.Get(ctx, userID)
+}
+
+// Save verifies and stores the user's key for the provider, replacing any
+// existing key.
+func (s *Service) Save(ctx context.Context, userID int, provider, apiKey string) (*Credential, error) {
+	provider = strings.TrimSpace(provider)
+	apiKey = strings.TrimSpace(apiKey)
+
+	if !IsSupportedProvider(provider) {
+		return nil, WrapError(ErrUnsupportedProvider, fmt.Errorf("provider '%s'", provider))
+	}
+	if apiKey == "" {
+		return nil, ErrEmptyAPIKey
+	}
+
+	if err := s.check(ctx, provider, apiKey); err != nil {
+		return nil, err
+	}
+
+	encrypted, err := s.cipher.Encrypt(apiKey, keyBinding(userID, provider))
+	if err != nil {
+		return nil, err
+	}
+
+	credential := &Credential{
+		UserID:       userID,
+		Provider:     provider,
+		EncryptedKey: encrypted,
+		KeyHint:      keyHint(apiKey),
+	}
+	if err := s.repo.Upsert(ctx, credential); err != nil {
+		return nil, err
+	}
+
+	s.log.Info().Int("user_id", userID).Str("provider", provider).Msg("Saved user AI provider key")
+	return s.repo.Get(ctx, userID)
+}
+
+// Remove deletes the user's key. Removing a missing key is not an error.
+func (s *Service) Remove(ctx context.Context, userID int) error {
+	if err := s.repo.Delete(ctx, userID); err != nil {
+		return err
+	}
+
+	s.log.Info().Int("user_id", userID).Msg("Removed user AI provider key")
+	return nil
+}
+
+// APIKey returns the user's provider and decrypted key.
+func (s *Service) APIKey(ctx context.Context, userID int) (string, string, error) {
+	credential, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		return "", "", err
+	}
+
+	apiKey, err := s.cipher.Decrypt(credential.EncryptedKey, keyBinding(credential.UserID, credential.Provider))
+	if err != nil {
+		return "", "", err
+	}
+	return credential.Provider, apiKey, nil
+}
+
+// HasOwnKey reports whether the user has a usable key of their own. Keys
+// that can no longer be decrypted, for example after the server secret was
+// rotated, do not count.
+func (s *Service) HasOwnKey(ctx context.Context, userID int) bool {
+	_, _, err := s.APIKey(ctx, userID)
+	return err == nil
+}
+
+// Test checks the user's stored key against the provider.
+func (s *Service) Test(ctx context.Context, userID int) error {
+	provider, apiKey, err := s.APIKey(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return s.check(ctx, provider, apiKey)
+}
+
+func (s *Service) check(ctx context.Context, provider, apiKey string) error {
+	if s.verify == nil {
+		return nil
+	}
+	if err := s.verify(ctx, provider, apiKey); err != nil {
+		return WrapError(ErrVerificationFailed, err)
+	}
+	return nil
+}
+
+// keyBinding is the additional data a stored key is encrypted with, so a key
+// copied to another user or provider fails to decrypt.
+func keyBinding(userID int, provider string) []byte {
+	return []byte(fmt.Sprintf("%d:%s", userID, provider))
+}
@@ -0,0 +1,126 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryRepository is an in-memory Repository for tests.
+type memoryRepository struct {
+	credentials map[int]Credential
+}
+
+func (m *memoryRepository) Get(ctx context.Context, userID int) (*Credential, error) {
+	c, ok := m.credentials[userID]
+	if !ok {
+		return nil, ErrCredentialNotFound
+	}
+	return &c, nil
+}
+
+func (m *memoryRepository) Upsert(ctx context.Context, credential *Credential) error {
+	m.credentials[credential.UserID] = *credential
+	return nil
+}
+
+func (m *memoryRepository) Delete(ctx context.Context, userID int) error {
+	delete(m.credentials, userID)
+	return nil
+}
+
+func newTestService(t *testing.T, verify Verifier) (*Service, *memoryRepository) {
+	t.Helper()
+	c, err := NewCipher("server-secret")
+	require.NoError(t, err)
+	repo := &memoryRepository{credentials: map[int]Credential{}}
+	return NewService(repo, c, verify), repo
+}
+
+func TestService_Save(t *testing.T) {
+	var verified []string
+	service, repo := newTestService(t, func(ctx context.Context, provider, apiKey string) error {
+		verified = append(verified, provider+":"+apiKey)
+		return nil
+	})
+
+	credential, err := service.Save(context.Background(), 7, "gemini", "  AIza-secret-9876 ")
+	require.NoError(t, err)
+
+	assert.Equal(t, "9876", credential.KeyHint)
+	assert.Equal(t, []string{"gemini:AIza-secret-9876"}, verified)
+	assert.NotContains(t, repo.credentials[7].EncryptedKey, "AIza-secret-9876")
+
+	provider, apiKey, err := service.APIKey(context.Background(), 7)
+	require.NoError(t, err)
+	assert.Equal(t, "gemini", provider)
+	assert.Equal(t, "AIza-secret-9876", apiKey)
+	assert.True(t, service.HasOwnKey(context.Background(), 7))
+}
+
+func TestService_SaveValidation(t *testing.T) {
+	service, repo := newTestService(t, func(ctx context.Context, provider, apiKey string) error {
+		return errors.New("401 unauthorized")
+	})
+
+	_, err := service.Save(context.Background(), 7, "ollama", "key")
+	assert.ErrorIs(t, err, ErrUnsupportedProvider)
+
+	_, err = service.Save(context.Background(), 7, "gemini", "   ")
+	assert.ErrorIs(t, err, ErrEmptyAPIKey)
+
+	_, err = service.Save(context.Background(), 7, "gemini", "bad-key")
+	assert.ErrorIs(t, err, ErrVerificationFailed)
+
+	assert.Empty(t, repo.credentials)
+}
+
+func TestService_TestAndRemove(t *testing.T) {
+	rejected := false
+	service, _ := newTestService(t, func(ctx context.Context, provider, apiKey string) error {
+		if rejected {
+			return errors.New("key revoked")
+		}
+		return nil
+	})
+	ctx := context.Background()
+
+	assert.ErrorIs(t, service.Test(ctx, 7), ErrCredentialNotFound)
+	assert.False(t, service.HasOwnKey(ctx, 7))
+
+	_, err := service.Save(ctx, 7, "openai", "sk-abcdefgh")
+	require.NoError(t, err)
+	assert.NoError(t, service.Test(ctx, 7))
+
+	rejected = true
+	assert.ErrorIs(t, service.Test(ctx, 7), ErrVerificationFailed)
+
+	require.NoError(t, service.Remove(ctx, 7))
+	assert.False(t, service.HasOwnKey(ctx, 7))
+}
+
+func TestService_HasOwnKeyIgnoresUnreadableKeys(t *testing.T) {
+	service, repo := newTestService(t, nil)
+	repo.credentials[7] = Credential{UserID: 7, Provider: "gemini", EncryptedKey: "written-with-old-secret"}
+
+	assert.False(t, service.HasOwnKey(context.Background(), 7))
+}
+
+func TestService_KeysAreBoundToTheirUser(t *testing.T) {
+	service, repo := newTestService(t, nil)
+	ctx := context.Background()
+
+	_, err := service.Save(ctx, 7, "gemini", "AIza-secret-9876")
+	require.NoError(t, err)
+
+	copied := repo.credentials[7]
+	copied.UserID = 8
+	repo.credentials[8] = copied
+
+	_, _, err = service.APIKey(ctx, 8)
+	assert.ErrorIs(t, err, ErrDecryptionFailed)
+	assert.False(t, service.HasOwnKey(ctx, 8))
+}
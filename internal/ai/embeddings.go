@@ -0,0 +1,76 @@
+package ai
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/benidevo/vega/internal/ai/embeddings"
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/llm/gemini"
+	"github.com/benidevo/vega/internal/ai/llm/local"
+	"github.com/benidevo/vega/internal/ai/llm/ollama"
+	"github.com/benidevo/vega/internal/ai/llm/openai"
+	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/benidevo/vega/internal/common/logger"
+	"github.com/benidevo/vega/internal/config"
+)
+
+// EmbeddingProviderLocal embeds text on the server without any provider.
+const EmbeddingProviderLocal = "local"
+
+// SetupEmbeddings creates the service behind instant similarity scores, using
+// the embedding provider chosen by cfg.AIEmbeddingProvider. A provider that
+// can't be created falls back to local embeddings, so similarity scores never
+// depend on the AI service being available.
+func SetupEmbeddings(db *sql.DB, cfg *config.Settings) *embeddings.Service {
+	embedder, err := createEmbedder(cfg)
+	if err != nil {
+		log := logger.GetPrivacyLogger("ai_setup")
+		log.Warn().
+			Str("provider", cfg.AIEmbeddingProvider).
+			Err(err).
+			Msg("Embedding provider initialization failed, using local embeddings")
+		embedder = nil
+	}
+	return embeddings.NewService(embeddings.NewRepository(db), embedder)
+}
+
+func createEmbedder(cfg *config.Settings) (llm.Embedder, error) {
+	switch cfg.AIEmbeddingProvider {
+	case "", EmbeddingProviderLocal:
+		return local.New(0), nil
+	case ProviderGemini:
+		if cfg.GeminiAPIKey == "" {
+			return nil, models.WrapError(models.ErrMissingAPIKey, fmt.Errorf("GEMINI_API_KEY is required for Gemini embeddings"))
+		}
+
+		embedder, err := gemini.New(context.Background(), gemini.NewConfig(cfg))
+		if err != nil {
+			return nil, models.WrapError(models.ErrProviderInitFailed, err)
+		}
+		return embedder, nil
+	case ProviderOpenAI:
+		if cfg.OpenAIBaseURL == "" {
+			return nil, models.WrapError(models.ErrProviderInitFailed, fmt.Errorf("OPENAI_BASE_URL is required for OpenAI-compatible embeddings"))
+		}
+
+		embedder, err := openai.New(openai.NewConfig(cfg))
+		if err != nil {
+			return nil, models.WrapError(models.ErrProviderInitFailed, err)
+		}
+		return embedder, nil
+	case ProviderOllama:
+		if cfg.OllamaBaseURL == "" {
+			return nil, models.WrapError(models.ErrProviderInitFailed, fmt.Errorf("OLLAMA_BASE_URL is required for Ollama embeddings"))
+		}
+
+		embedder, err := ollama.New(ollama.NewConfig(cfg))
+		if err != nil {
+			return nil, models.WrapError(models.ErrProviderInitFailed, err)
+		}
+		return embedder, nil
+	default:
+		return nil, models.WrapError(models.ErrUnsupportedProvider, fmt.Errorf("embedding provider '%s' is not supported", cfg.AIEmbeddingProvider))
+	}
+}
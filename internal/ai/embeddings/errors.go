@@ -0,0 +1,15 @@
+package embeddings
+
+import (
+	commonerrors "github.com/benidevo/vega/internal/common/errors"
+)
+
+var (
+	ErrEmbeddingFailed = commonerrors.New("failed to compute embeddings")
+	ErrInvalidVector   = commonerrors.New("stored embedding vector is invalid")
+)
+
+// WrapError wraps the given innerErr with the provided sentinelErr.
+func WrapError(sentinelErr, innerErr error) error {
+	return commonerrors.WrapError(sentinelErr, innerErr)
+}
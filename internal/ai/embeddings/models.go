@@ -0,0 +1,81 @@
+package embeddings
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+)
+
+// Kind is the type of thing an embedding vector describes.
+type Kind string
+
+const (
+	KindJob     Kind = "job"
+	KindProfile Kind = "profile"
+)
+
+// ProfileID is the subject ID of a user's profile; a user has one profile.
+const ProfileID = 0
+
+// Subject is a piece of text to embed, identified by its kind and ID.
+type Subject struct {
+	Kind Kind
+	ID   int
+	Text string
+}
+
+// StoredVector is an embedding vector with the hash of the text it was
+// computed from.
+type StoredVector struct {
+	ID          int
+	ContentHash string
+	Vector      []float32
+}
+
+// Cosine returns the cosine similarity of two vectors, from -1 to 1. Vectors
+// of different sizes or without length have a similarity of 0.
+func Cosine(a, b []float32) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// contentHash identifies the text a vector was computed from.
+func contentHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:16])
+}
+
+// encodeVector stores a vector as little-endian float32 values.
+func encodeVector(vector []float32) []byte {
+	data := make([]byte, 4*len(vector))
+	for i, v := range vector {
+		binary.LittleEndian.PutUint32(data[4*i:], math.Float32bits(v))
+	}
+	return data
+}
+
+// decodeVector reads a vector stored by encodeVector.
+func decodeVector(data []byte) ([]float32, error) {
+	if len(data)%4 != 0 {
+		return nil, ErrInvalidVector
+	}
+
+	vector := make([]float32, len(data)/4)
+	for i := range vector {
+		vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[4*i:]))
+	}
+	return vector, nil
+}
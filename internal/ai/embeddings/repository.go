@@ -0,0 +1,98 @@
+package embeddings
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Repository defines data access for stored embedding vectors.
+type Repository interface {
+	GetVectors(ctx context.Context, userID int, kind Kind, model string, ids []int) (map[int]StoredVector, error)
+	SaveVectors(ctx context.Context, userID int, kind Kind, model string, vectors []StoredVector) error
+}
+
+// repository implements the Repository interface
+type repository struct {
+	db *sql.DB
+}
+
+// NewRepository creates a new embedding vector repository
+func NewRepository(db *sql.DB) Repository {
+	return &repository{db: db}
+}
+
+// GetVectors returns the user's stored vectors of the given kind and model,
+// keyed by subject ID. Subjects without a vector are left out.
+func (r *repository) GetVectors(ctx context.Context, userID int, kind Kind, model string, ids []int) (map[int]StoredVector, error) {
+	vectors := make(map[int]StoredVector)
+	if len(ids) == 0 {
+		return vectors, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(ids)), ",")
+	query := fmt.Sprintf(`
+		SELECT subject_id, content_hash, vector
+		FROM embeddings
+		WHERE user_id = ? AND subject_type = ? AND model = ? AND subject_id IN (%s)
+	`, placeholders)
+
+	args := []any{userID, string(kind), model}
+	for _, id := range ids {
+		args = append(args, id)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get embeddings: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var stored StoredVector
+		var data []byte
+		if err := rows.Scan(&stored.ID, &stored.ContentHash, &data); err != nil {
+			return nil, fmt.Errorf("failed to scan embedding: %w", err)
+		}
+
+		// A vector that can't be read is recomputed like a missing one
+		if stored.Vector, err = decodeVector(data); err != nil {
+			continue
+		}
+		vectors[stored.ID] = stored
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read embeddings: %w", err)
+	}
+	return vectors, nil
+}
+
+// SaveVectors stores the given vectors in one transaction, replacing earlier
+// vectors of the same subjects and model
+func (r *repository) SaveVectors(ctx context.Context, userID int, kind Kind, model string, vectors []StoredVector) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO embeddings (user_id, subject_type, subject_id, model, content_hash, vector, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(user_id, subject_type, subject_id, model) DO UPDATE SET
+			content_hash = excluded.content_hash,
+			vector = excluded.vector,
+			updated_at = CURRENT_TIMESTAMP
+	`
+	for _, stored := range vectors {
+		if _, err := tx.ExecContext(ctx, query, userID, string(kind), stored.ID, model, stored.ContentHash, encodeVector(stored.Vector)); err != nil {
+			return fmt.Errorf("failed to save embedding: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit embeddings: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package embeddings
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_GetVectors(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT subject_id, content_hash, vector FROM embeddings").
+		WithArgs(4, "job", "local/hash-512-v1", 1, 2, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"subject_id", "content_hash", "vector"}).
+			AddRow(1, "abc", encodeVector([]float32{0.6, 0.8})).
+			AddRow(2, "def", []byte{1, 2, 3}))
+
+	vectors, err := NewRepository(db).GetVectors(context.Background(), 4, KindJob, "local/hash-512-v1", []int{1, 2, 3})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[int]StoredVector{
+		1: {ID: 1, ContentHash: "abc", Vector: []float32{0.6, 0.8}},
+	}, vectors, "unreadable vectors should be left out")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_SaveVectors(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	vector := []float32{1, 0}
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO embeddings").
+		WithArgs(4, "profile", ProfileID, "local/hash-512-v1", "abc", encodeVector(vector)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = NewRepository(db).SaveVectors(context.Background(), 4, KindProfile, "local/hash-512-v1", []StoredVector{
+		{ID: ProfileID, ContentHash: "abc", Vector: vector},
+	})
+
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,173 @@
+package embeddings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/llm/local"
+	"github.com/benidevo/vega/internal/common/logger"
+)
+
+const (
+	// maxTextLength keeps embedded text within the input limits of the
+	// embedding models; the start of a job description or profile says the
+	// most about it.
+	maxTextLength = 8000
+
+	// batchSize is the number of texts sent to the embedder at once.
+	batchSize = 64
+)
+
+// Service computes similarity scores from embedding vectors. Vectors are
+// stored per user and only recomputed when the embedded text changes. When
+// the configured embedder fails, the local embedder is used instead so that
+// scores are always available.
+type Service struct {
+	repo     Repository
+	embedder llm.Embedder
+	fallback llm.Embedder
+	log      *logger.PrivacyLogger
+}
+
+// NewService creates a new embeddings service. A nil embedder uses the local
+// embedder only.
+func NewService(repo Repository, embedder llm.Embedder) *Service {
+	fallback := local.New(0)
+	if embedder == nil {
+		embedder = fallback
+	}
+	return &Service{
+		repo:     repo,
+		embedder: embedder,
+		fallback: fallback,
+		log:      logger.GetPrivacyLogger("ai_embeddings"),
+	}
+}
+
+// Similarities returns the cosine similarity of the target to each candidate,
+// keyed by candidate ID.
+func (s *Service) Similarities(ctx context.Context, userID int, target Subject, candidates []Subject) (map[int]float64, error) {
+	scores := make(map[int]float64, len(candidates))
+	if len(candidates) == 0 {
+		return scores, nil
+	}
+
+	vectors, err := s.vectors(ctx, userID, append([]Subject{target}, candidates...))
+	if err != nil {
+		return nil, err
+	}
+
+	for i, candidate := range candidates {
+		scores[candidate.ID] = Cosine(vectors[0], vectors[i+1])
+	}
+	return scores, nil
+}
+
+// Refresh computes and stores the vectors of subjects whose text changed.
+func (s *Service) Refresh(ctx context.Context, userID int, subjects ...Subject) error {
+	_, err := s.vectors(ctx, userID, subjects)
+	return err
+}
+
+// vectors returns a vector for each subject using the configured embedder,
+// falling back to the local embedder for the whole set if it fails.
+func (s *Service) vectors(ctx context.Context, userID int, subjects []Subject) ([][]float32, error) {
+	vectors, err := s.vectorsWith(ctx, s.embedder, userID, subjects)
+	if err == nil || s.embedder == s.fallback || errors.Is(err, context.Canceled) {
+		return vectors, err
+	}
+
+	s.log.Warn().
+		Str("model", s.embedder.EmbeddingModel()).
+		Err(err).
+		Msg("Embedding failed, using local embeddings")
+
+	return s.vectorsWith(ctx, s.fallback, userID, subjects)
+}
+
+// vectorsWith loads the stored vectors of the subjects and embeds the ones
+// that are missing or were computed from different text.
+func (s *Service) vectorsWith(ctx context.Context, embedder llm.Embedder, userID int, subjects []Subject) ([][]float32, error) {
+	model := embedder.EmbeddingModel()
+
+	idsByKind := make(map[Kind][]int)
+	for _, subject := range subjects {
+		idsByKind[subject.Kind] = append(idsByKind[subject.Kind], subject.ID)
+	}
+	stored := make(map[Kind]map[int]StoredVector, len(idsByKind))
+	for kind, ids := range idsByKind {
+		vectors, err := s.repo.GetVectors(ctx, userID, kind, model, ids)
+		if err != nil {
+			return nil, WrapError(ErrEmbeddingFailed, err)
+		}
+		stored[kind] = vectors
+	}
+
+	result := make([][]float32, len(subjects))
+	hashes := make([]string, len(subjects))
+	var missing []int
+	for i, subject := range subjects {
+		hashes[i] = contentHash(subject.Text)
+		if vector, ok := stored[subject.Kind][subject.ID]; ok && vector.ContentHash == hashes[i] {
+			result[i] = vector.Vector
+			continue
+		}
+		missing = append(missing, i)
+	}
+
+	for start := 0; start < len(missing); start += batchSize {
+		batch := missing[start:min(start+batchSize, len(missing))]
+
+		texts := make([]string, len(batch))
+		for j, i := range batch {
+			texts[j] = truncate(subjects[i].Text)
+		}
+		vectors, err := embedder.Embed(ctx, texts)
+		if err != nil {
+			return nil, WrapError(ErrEmbeddingFailed, err)
+		}
+		if len(vectors) != len(texts) {
+			return nil, WrapError(ErrEmbeddingFailed, fmt.Errorf("got %d vectors for %d texts", len(vectors), len(texts)))
+		}
+
+		updates := make(map[Kind][]StoredVector)
+		for j, i := range batch {
+			result[i] = vectors[j]
+			subject := subjects[i]
+			updates[subject.Kind] = append(updates[subject.Kind], StoredVector{
+				ID:          subject.ID,
+				ContentHash: hashes[i],
+				Vector:      vectors[j],
+			})
+		}
+
+		// The vectors can still be used when saving them fails; they are
+		// computed again next time.
+		for kind, vectors := range updates {
+			if err := s.repo.SaveVectors(ctx, userID, kind, model, vectors); err != nil {
+				s.log.Warn().
+					Str("model", model).
+					Str("kind", string(kind)).
+					Err(err).
+					Msg("Failed to save embeddings")
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// truncate cuts text to maxTextLength bytes without splitting a character.
+func truncate(text string) string {
+	if len(text) <= maxTextLength {
+		return text
+	}
+	end := maxTextLength
+	for end > 0 && !utf8.RuneStart(text[end]) {
+		end--
+	}
+	return text[:end]
+}
@@ -0,0 +1,129 @@
+package embeddings
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryRepository struct {
+	vectors map[string]map[int]StoredVector
+	saves   int
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{vectors: make(map[string]map[int]StoredVector)}
+}
+
+func (r *memoryRepository) GetVectors(_ context.Context, _ int, kind Kind, model string, ids []int) (map[int]StoredVector, error) {
+	result := make(map[int]StoredVector)
+	for _, id := range ids {
+		if vector, ok := r.vectors[string(kind)+model][id]; ok {
+			result[id] = vector
+		}
+	}
+	return result, nil
+}
+
+func (r *memoryRepository) SaveVectors(_ context.Context, _ int, kind Kind, model string, vectors []StoredVector) error {
+	key := string(kind) + model
+	if r.vectors[key] == nil {
+		r.vectors[key] = make(map[int]StoredVector)
+	}
+	for _, vector := range vectors {
+		r.vectors[key][vector.ID] = vector
+		r.saves++
+	}
+	return nil
+}
+
+// fakeEmbedder embeds text as a vector of its first two bytes
+type fakeEmbedder struct {
+	calls int
+	texts int
+	err   error
+}
+
+func (e *fakeEmbedder) EmbeddingModel() string { return "fake" }
+
+func (e *fakeEmbedder) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	e.calls++
+	if e.err != nil {
+		return nil, e.err
+	}
+	e.texts += len(texts)
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i] = []float32{float32(text[0]), float32(text[1])}
+	}
+	return vectors, nil
+}
+
+func TestService_Similarities(t *testing.T) {
+	repo := newMemoryRepository()
+	embedder := &fakeEmbedder{}
+	service := NewService(repo, embedder)
+	ctx := context.Background()
+
+	profile := Subject{Kind: KindProfile, ID: ProfileID, Text: "\x03\x04"}
+	jobs := []Subject{
+		{Kind: KindJob, ID: 1, Text: "\x03\x04"},
+		{Kind: KindJob, ID: 2, Text: "\x04\x00"},
+	}
+
+	scores, err := service.Similarities(ctx, 1, profile, jobs)
+	require.NoError(t, err)
+	assert.InDelta(t, 1.0, scores[1], 1e-6)
+	assert.InDelta(t, 0.6, scores[2], 1e-6)
+	assert.Equal(t, 3, embedder.texts)
+	assert.Equal(t, 3, repo.saves)
+
+	t.Run("should reuse stored vectors while the text is unchanged", func(t *testing.T) {
+		_, err := service.Similarities(ctx, 1, profile, jobs)
+		require.NoError(t, err)
+		assert.Equal(t, 3, embedder.texts)
+	})
+
+	t.Run("should embed changed text again", func(t *testing.T) {
+		changed := Subject{Kind: KindProfile, ID: ProfileID, Text: "\x04\x00"}
+		scores, err := service.Similarities(ctx, 1, changed, jobs)
+		require.NoError(t, err)
+		assert.InDelta(t, 1.0, scores[2], 1e-6)
+		assert.Equal(t, 4, embedder.texts)
+	})
+}
+
+func TestService_FallsBackToLocalEmbeddings(t *testing.T) {
+	repo := newMemoryRepository()
+	embedder := &fakeEmbedder{err: errors.New("provider unavailable")}
+	service := NewService(repo, embedder)
+
+	scores, err := service.Similarities(context.Background(), 1,
+		Subject{Kind: KindProfile, ID: ProfileID, Text: "Go engineer with Kubernetes experience"},
+		[]Subject{
+			{Kind: KindJob, ID: 1, Text: "Go engineer to run our Kubernetes platform"},
+			{Kind: KindJob, ID: 2, Text: "Pastry chef for a French bakery"},
+		})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, embedder.calls)
+	assert.Greater(t, scores[1], scores[2])
+	assert.Len(t, repo.vectors["profilelocal/hash-512-v1"], 1, "local vectors should be stored under the local model")
+}
+
+func TestCosine(t *testing.T) {
+	assert.InDelta(t, 1.0, Cosine([]float32{1, 2}, []float32{2, 4}), 1e-9)
+	assert.InDelta(t, 0.0, Cosine([]float32{1, 0}, []float32{0, 1}), 1e-9)
+	assert.Zero(t, Cosine([]float32{1, 2}, []float32{1}))
+	assert.Zero(t, Cosine([]float32{0, 0}, []float32{1, 1}))
+}
+
+func TestTruncate(t *testing.T) {
+	text := string(make([]byte, maxTextLength-1)) + "é"
+	truncated := truncate(text)
+	assert.Len(t, truncated, maxTextLength-1, "should not split a character")
+	assert.Equal(t, "short", truncate("short"))
+}
@@ -0,0 +1,65 @@
+package ai
+
+import (
+	"testing"
+
+	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/benidevo/vega/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateEmbedder(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    *config.Settings
+		model     string
+		errorType error
+	}{
+		{
+			name:   "local by default",
+			config: &config.Settings{},
+			model:  "local/hash-512-v1",
+		},
+		{
+			name: "OpenAI-compatible embeddings",
+			config: &config.Settings{
+				AIEmbeddingProvider: ProviderOpenAI,
+				OpenAIBaseURL:       "http://localhost:8000/v1",
+				AIEmbeddingModel:    "bge-small",
+			},
+			model: "openai/bge-small",
+		},
+		{
+			name: "Ollama embeddings use the default model",
+			config: &config.Settings{
+				AIEmbeddingProvider: ProviderOllama,
+				OllamaBaseURL:       "http://localhost:11434",
+			},
+			model: "ollama/nomic-embed-text",
+		},
+		{
+			name:      "missing Gemini API key",
+			config:    &config.Settings{AIEmbeddingProvider: ProviderGemini},
+			errorType: models.ErrMissingAPIKey,
+		},
+		{
+			name:      "unsupported provider",
+			config:    &config.Settings{AIEmbeddingProvider: "unknown"},
+			errorType: models.ErrUnsupportedProvider,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			embedder, err := createEmbedder(tt.config)
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.model, embedder.EmbeddingModel())
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package eval
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/benidevo/vega/internal/ai/llm/structured"
+	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/benidevo/vega/internal/ai/prompts"
+)
+
+// Check names used in reports.
+const (
+	CheckSchema        = "schema"
+	CheckScoreRange    = "score_range"
+	CheckScoreBand     = "score_band"
+	CheckBannedPhrases = "banned_phrases"
+	CheckLength        = "length"
+)
+
+// Check is the outcome of a single quality check on a generated result.
+type Check struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Range is an inclusive range of counts.
+type Range struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// Contains reports whether n falls inside the range.
+func (r Range) Contains(n int) bool {
+	return n >= r.Min && n <= r.Max
+}
+
+// String returns the range as "min-max".
+func (r Range) String() string {
+	return fmt.Sprintf("%d-%d", r.Min, r.Max)
+}
+
+// Targets are the length targets generated documents are checked against.
+type Targets struct {
+	CoverLetterWords   Range `json:"cover_letter_words"`
+	CVSummarySentences Range `json:"cv_summary_sentences"`
+	MatchScore         Range `json:"match_score"`
+}
+
+// DefaultTargets returns the targets the built-in prompts ask the model for.
+func DefaultTargets() Targets {
+	cfg := structured.NewConfig()
+	return Targets{
+		CoverLetterWords:   parseRange(cfg.DefaultWordRange, Range{Min: 150, Max: 250}),
+		CVSummarySentences: Range{Min: 2, Max: 3},
+		MatchScore:         Range{Min: cfg.MinMatchScore, Max: cfg.MaxMatchScore},
+	}
+}
+
+// parseRange parses "min-max", returning fallback when s is malformed.
+func parseRange(s string, fallback Range) Range {
+	minText, maxText, ok := strings.Cut(s, "-")
+	if !ok {
+		return fallback
+	}
+	minValue, err := strconv.Atoi(strings.TrimSpace(minText))
+	if err != nil {
+		return fallback
+	}
+	maxValue, err := strconv.Atoi(strings.TrimSpace(maxText))
+	if err != nil || maxValue < minValue {
+		return fallback
+	}
+	return Range{Min: minValue, Max: maxValue}
+}
+
+var bannedPhrasePatterns = compileBannedPhrases(prompts.BannedAIPhrases)
+
+type bannedPhrase struct {
+	phrase  string
+	pattern *regexp.Regexp
+}
+
+// compileBannedPhrases builds whole-word matchers from the quoted,
+// comma-separated phrase list the prompts use.
+func compileBannedPhrases(list string) []bannedPhrase {
+	var phrases []bannedPhrase
+	for _, item := range strings.Split(list, ",") {
+		phrase := strings.Trim(strings.TrimSpace(item), "'")
+		if phrase == "" {
+			continue
+		}
+		phrases = append(phrases, bannedPhrase{
+			phrase:  phrase,
+			pattern: regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(phrase) + `\b`),
+		})
+	}
+	return phrases
+}
+
+// BannedPhrases returns the banned phrases found in texts, in list order.
+func BannedPhrases(texts ...string) []string {
+	text := strings.Join(texts, "\n")
+
+	var found []string
+	for _, banned := range bannedPhrasePatterns {
+		if banned.pattern.MatchString(text) {
+			found = append(found, banned.phrase)
+		}
+	}
+	return found
+}
+
+// CountWords returns the number of whitespace-separated words in text.
+func CountWords(text string) int {
+	return len(strings.Fields(text))
+}
+
+var sentenceEnd = regexp.MustCompile(`[.!?]+(\s|$)`)
+
+// CountSentences returns the number of sentences in text. Trailing text
+// without terminal punctuation counts as a sentence.
+func CountSentences(text string) int {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0
+	}
+	count := len(sentenceEnd.FindAllStringIndex(text, -1))
+	if !strings.ContainsAny(text[len(text)-1:], ".!?") {
+		count++
+	}
+	return count
+}
+
+func bannedPhrasesCheck(texts ...string) Check {
+	found := BannedPhrases(texts...)
+	if len(found) == 0 {
+		return Check{Name: CheckBannedPhrases, Passed: true}
+	}
+	return Check{Name: CheckBannedPhrases, Detail: "found " + strings.Join(found, ", ")}
+}
+
+func rangeCheck(name, unit string, value int, target Range) Check {
+	return Check{Name: name, Passed: target.Contains(value), Detail: fmt.Sprintf("%d %s (target %s)", value, unit, target)}
+}
+
+// checkMatchResult checks a match result's structure, score and wording.
+// band is nil when the fixtures do not expect a score for the pair.
+func checkMatchResult(result *models.MatchResult, targets Targets, band *ScoreBand) []Check {
+	schema := Check{Name: CheckSchema, Passed: true}
+	switch {
+	case strings.TrimSpace(result.Feedback) == "":
+		schema = Check{Name: CheckSchema, Detail: "feedback is empty"}
+	case len(result.Strengths) == 0:
+		schema = Check{Name: CheckSchema, Detail: "no strengths listed"}
+	case len(result.Weaknesses) == 0:
+		schema = Check{Name: CheckSchema, Detail: "no weaknesses listed"}
+	}
+
+	checks := []Check{
+		schema,
+		rangeCheck(CheckScoreRange, "points", result.MatchScore, targets.MatchScore),
+	}
+	if band != nil {
+		checks = append(checks, Check{
+			Name:   CheckScoreBand,
+			Passed: band.Contains(result.MatchScore),
+			Detail: fmt.Sprintf("score %d (expected %s)", result.MatchScore, band),
+		})
+	}
+
+	texts := append([]string{result.Feedback}, result.Strengths...)
+	texts = append(texts, result.Weaknesses...)
+	texts = append(texts, result.Highlights...)
+	return append(checks, bannedPhrasesCheck(texts...))
+}
+
+// checkCoverLetter checks a cover letter's structure, length and wording.
+func checkCoverLetter(letter *models.CoverLetter, targets Targets) []Check {
+	schema := Check{Name: CheckSchema, Passed: true}
+	switch letter.Format {
+	case models.CoverLetterTypeHtml, models.CoverLetterTypeMarkdown, models.CoverLetterTypePlainText:
+		if strings.TrimSpace(letter.Content) == "" {
+			schema = Check{Name: CheckSchema, Detail: "content is empty"}
+		}
+	default:
+		schema = Check{Name: CheckSchema, Detail: fmt.Sprintf("unknown format '%s'", letter.Format)}
+	}
+
+	return []Check{
+		schema,
+		rangeCheck(CheckLength, "words", CountWords(letter.Content), targets.CoverLetterWords),
+		bannedPhrasesCheck(letter.Content),
+	}
+}
+
+// checkGeneratedCV checks a generated CV's structure, summary length and wording.
+func checkGeneratedCV(cv *models.GeneratedCV, targets Targets) []Check {
+	schema := Check{Name: CheckSchema, Passed: true}
+	switch {
+	case !cv.IsValid:
+		schema = Check{Name: CheckSchema, Detail: "CV is marked invalid: " + cv.Reason}
+	case strings.TrimSpace(cv.PersonalInfo.FirstName) == "" || strings.TrimSpace(cv.PersonalInfo.LastName) == "":
+		schema = Check{Name: CheckSchema, Detail: "applicant name is missing"}
+	case len(cv.WorkExperience) == 0:
+		schema = Check{Name: CheckSchema, Detail: "no work experience"}
+	}
+
+	texts := []string{cv.PersonalInfo.Summary}
+	for _, exp := range cv.WorkExperience {
+		texts = append(texts, exp.Description)
+	}
+
+	return []Check{
+		schema,
+		rangeCheck(CheckLength, "summary sentences", CountSentences(cv.PersonalInfo.Summary), targets.CVSummarySentences),
+		bannedPhrasesCheck(texts...),
+	}
+}
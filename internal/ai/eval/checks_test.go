@@ -0,0 +1,149 @@
+package eval
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBannedPhrases(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{
+			name:     "should_find_phrases_case_insensitively",
+			text:     "I Leverage my skills and follow best practices.",
+			expected: []string{"leverage", "best practices"},
+		},
+		{
+			name: "should_ignore_phrases_inside_other_words",
+			text: "I am leveraged into a robustness review.",
+		},
+		{
+			name: "should_find_nothing_in_plain_text",
+			text: "I built the billing service and ran it in production.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, BannedPhrases(tt.text))
+		})
+	}
+}
+
+func TestCountSentences(t *testing.T) {
+	assert.Equal(t, 0, CountSentences("  "))
+	assert.Equal(t, 1, CountSentences("One sentence without a full stop"))
+	assert.Equal(t, 2, CountSentences("First one. Second one!"))
+	assert.Equal(t, 3, CountSentences("Shipped v1.2 in March. Really? Yes"))
+}
+
+func TestDefaultTargets(t *testing.T) {
+	targets := DefaultTargets()
+
+	assert.Equal(t, Range{Min: 150, Max: 250}, targets.CoverLetterWords)
+	assert.Equal(t, Range{Min: 0, Max: 100}, targets.MatchScore)
+}
+
+func TestParseRange(t *testing.T) {
+	fallback := Range{Min: 1, Max: 2}
+
+	assert.Equal(t, Range{Min: 200, Max: 300}, parseRange("200 - 300", fallback))
+	assert.Equal(t, fallback, parseRange("300", fallback))
+	assert.Equal(t, fallback, parseRange("300-200", fallback))
+}
+
+func TestCheckMatchResult(t *testing.T) {
+	result := &models.MatchResult{
+		MatchScore: 85,
+		Strengths:  []string{"Five years of Go"},
+		Weaknesses: []string{"No Kafka experience"},
+		Feedback:   "A solid fit with one gap.",
+	}
+
+	t.Run("should_pass_result_within_band", func(t *testing.T) {
+		checks := checkMatchResult(result, DefaultTargets(), &ScoreBand{Min: 80, Max: 100})
+
+		assert.Equal(t, []string{CheckSchema, CheckScoreRange, CheckScoreBand, CheckBannedPhrases}, checkNames(checks))
+		for _, check := range checks {
+			assert.True(t, check.Passed, check.Name)
+		}
+	})
+
+	t.Run("should_fail_result_outside_band", func(t *testing.T) {
+		checks := checkMatchResult(result, DefaultTargets(), &ScoreBand{Min: 0, Max: 30})
+
+		assert.False(t, findCheck(checks, CheckScoreBand).Passed)
+		assert.Equal(t, "score 85 (expected 0-30)", findCheck(checks, CheckScoreBand).Detail)
+	})
+
+	t.Run("should_skip_band_when_not_expected", func(t *testing.T) {
+		checks := checkMatchResult(result, DefaultTargets(), nil)
+
+		assert.NotContains(t, checkNames(checks), CheckScoreBand)
+	})
+
+	t.Run("should_fail_out_of_range_score_and_empty_feedback", func(t *testing.T) {
+		checks := checkMatchResult(&models.MatchResult{MatchScore: 120, Strengths: []string{"Robust code"}}, DefaultTargets(), nil)
+
+		assert.False(t, findCheck(checks, CheckSchema).Passed)
+		assert.False(t, findCheck(checks, CheckScoreRange).Passed)
+		assert.False(t, findCheck(checks, CheckBannedPhrases).Passed)
+	})
+}
+
+func TestCheckCoverLetter(t *testing.T) {
+	t.Run("should_pass_letter_within_length", func(t *testing.T) {
+		letter := &models.CoverLetter{Format: models.CoverLetterTypePlainText, Content: strings.Repeat("word ", 200)}
+
+		for _, check := range checkCoverLetter(letter, DefaultTargets()) {
+			assert.True(t, check.Passed, check.Name)
+		}
+	})
+
+	t.Run("should_fail_short_letter_with_unknown_format", func(t *testing.T) {
+		letter := &models.CoverLetter{Format: "pdf", Content: "Too short."}
+		checks := checkCoverLetter(letter, DefaultTargets())
+
+		assert.False(t, findCheck(checks, CheckSchema).Passed)
+		assert.False(t, findCheck(checks, CheckLength).Passed)
+		assert.Equal(t, "2 words (target 150-250)", findCheck(checks, CheckLength).Detail)
+	})
+}
+
+func TestCheckGeneratedCV(t *testing.T) {
+	cv := &models.GeneratedCV{CVParsingResult: models.CVParsingResult{
+		IsValid:        true,
+		PersonalInfo:   models.PersonalInfo{FirstName: "Sarah", LastName: "Johnson", Summary: "Backend engineer. Builds payment systems."},
+		WorkExperience: []models.WorkExperience{{Company: "Ledgerly", Description: "• Spearheaded the billing rewrite"}},
+	}}
+
+	checks := checkGeneratedCV(cv, DefaultTargets())
+
+	assert.True(t, findCheck(checks, CheckSchema).Passed)
+	assert.True(t, findCheck(checks, CheckLength).Passed)
+	assert.False(t, findCheck(checks, CheckBannedPhrases).Passed)
+	assert.Equal(t, "found spearheaded", findCheck(checks, CheckBannedPhrases).Detail)
+}
+
+func checkNames(checks []Check) []string {
+	names := make([]string, 0, len(checks))
+	for _, check := range checks {
+		names = append(names, check.Name)
+	}
+	return names
+}
+
+func findCheck(checks []Check, name string) Check {
+	for _, check := range checks {
+		if check.Name == name {
+			return check
+		}
+	}
+	return Check{}
+}
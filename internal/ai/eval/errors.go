@@ -0,0 +1,17 @@
+package eval
+
+import (
+	commonerrors "github.com/benidevo/vega/internal/common/errors"
+)
+
+var (
+	ErrFixturesNotFound = commonerrors.New("evaluation fixtures not found")
+	ErrInvalidFixture   = commonerrors.New("invalid evaluation fixture")
+	ErrUnknownTask      = commonerrors.New("unknown evaluation task")
+	ErrUnknownFormat    = commonerrors.New("unknown report format")
+)
+
+// WrapError wraps the given innerErr with the provided sentinelErr.
+func WrapError(sentinelErr, innerErr error) error {
+	return commonerrors.WrapError(sentinelErr, innerErr)
+}
@@ -0,0 +1,140 @@
+package eval
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Profile is an applicant fixture, read from profiles/<id>.json.
+type Profile struct {
+	ID           string   `json:"-"`
+	Name         string   `json:"name"`
+	Profile      string   `json:"profile"`
+	CVText       string   `json:"cv_text,omitempty"`
+	Skills       []string `json:"skills,omitempty"`
+	ExtraContext string   `json:"extra_context,omitempty"`
+}
+
+// Job is a job description fixture, read from jobs/<id>.json. ExpectedScores
+// maps profile IDs to the match score band the pair should fall into.
+type Job struct {
+	ID             string               `json:"-"`
+	Title          string               `json:"title"`
+	Company        string               `json:"company,omitempty"`
+	Description    string               `json:"description"`
+	ExpectedScores map[string]ScoreBand `json:"expected_scores,omitempty"`
+}
+
+// ScoreBand is an inclusive range of acceptable match scores.
+type ScoreBand struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// Contains reports whether score falls inside the band.
+func (b ScoreBand) Contains(score int) bool {
+	return score >= b.Min && score <= b.Max
+}
+
+// String returns the band as "min-max".
+func (b ScoreBand) String() string {
+	return fmt.Sprintf("%d-%d", b.Min, b.Max)
+}
+
+// Fixtures holds the profiles and jobs every evaluation case is built from.
+// Each profile is evaluated against each job.
+type Fixtures struct {
+	Profiles []Profile
+	Jobs     []Job
+}
+
+// LoadFixtures reads profiles/*.json and jobs/*.json from dir, ordered by ID.
+func LoadFixtures(dir string) (*Fixtures, error) {
+	var fixtures Fixtures
+
+	err := loadDir(filepath.Join(dir, "profiles"), func(id string, data []byte) error {
+		profile := Profile{ID: id}
+		if err := json.Unmarshal(data, &profile); err != nil {
+			return err
+		}
+		if strings.TrimSpace(profile.Name) == "" {
+			return fmt.Errorf("name is required")
+		}
+		if strings.TrimSpace(profile.Profile) == "" && strings.TrimSpace(profile.CVText) == "" {
+			return fmt.Errorf("profile or cv_text is required")
+		}
+		fixtures.Profiles = append(fixtures.Profiles, profile)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = loadDir(filepath.Join(dir, "jobs"), func(id string, data []byte) error {
+		job := Job{ID: id}
+		if err := json.Unmarshal(data, &job); err != nil {
+			return err
+		}
+		if strings.TrimSpace(job.Description) == "" {
+			return fmt.Errorf("description is required")
+		}
+		for profileID, band := range job.ExpectedScores {
+			if band.Min < 0 || band.Max > 100 || band.Min > band.Max {
+				return fmt.Errorf("expected score band %s for '%s' must lie within 0-100", band, profileID)
+			}
+		}
+		fixtures.Jobs = append(fixtures.Jobs, job)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, job := range fixtures.Jobs {
+		for profileID := range job.ExpectedScores {
+			if !fixtures.hasProfile(profileID) {
+				return nil, WrapError(ErrInvalidFixture, fmt.Errorf("job '%s' expects a score for unknown profile '%s'", job.ID, profileID))
+			}
+		}
+	}
+
+	return &fixtures, nil
+}
+
+func (f *Fixtures) hasProfile(id string) bool {
+	for _, profile := range f.Profiles {
+		if profile.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// loadDir calls parse for every *.json file in dir in name order, with the
+// file name without its extension as the fixture ID.
+func loadDir(dir string, parse func(id string, data []byte) error) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return WrapError(ErrFixturesNotFound, err)
+	}
+	if len(paths) == 0 {
+		return WrapError(ErrFixturesNotFound, fmt.Errorf("no *.json files in %s", dir))
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return WrapError(ErrInvalidFixture, fmt.Errorf("%s: %w", path, err))
+		}
+		id := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		if err := parse(id, data); err != nil {
+			return WrapError(ErrInvalidFixture, fmt.Errorf("%s: %w", path, err))
+		}
+	}
+	return nil
+}
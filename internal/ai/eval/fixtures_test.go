@@ -0,0 +1,74 @@
+package eval
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadFixtures(t *testing.T) {
+	t.Run("should_load_sample_fixtures", func(t *testing.T) {
+		fixtures, err := LoadFixtures("testdata")
+
+		require.NoError(t, err)
+		require.Len(t, fixtures.Profiles, 2)
+		require.Len(t, fixtures.Jobs, 2)
+		assert.Equal(t, "backend-engineer", fixtures.Profiles[0].ID)
+		assert.Equal(t, "brand-designer", fixtures.Jobs[0].ID)
+		assert.Equal(t, ScoreBand{Min: 75, Max: 100}, fixtures.Jobs[1].ExpectedScores["backend-engineer"])
+	})
+
+	t.Run("should_fail_when_directory_is_empty", func(t *testing.T) {
+		_, err := LoadFixtures(t.TempDir())
+
+		assert.ErrorIs(t, err, ErrFixturesNotFound)
+	})
+
+	tests := []struct {
+		name    string
+		profile string
+		job     string
+	}{
+		{
+			name:    "should_reject_profile_without_name",
+			profile: `{"profile": "Engineer"}`,
+			job:     `{"description": "A job"}`,
+		},
+		{
+			name:    "should_reject_job_without_description",
+			profile: `{"name": "Sam", "profile": "Engineer"}`,
+			job:     `{"title": "Engineer"}`,
+		},
+		{
+			name:    "should_reject_invalid_band",
+			profile: `{"name": "Sam", "profile": "Engineer"}`,
+			job:     `{"description": "A job", "expected_scores": {"sam": {"min": 80, "max": 20}}}`,
+		},
+		{
+			name:    "should_reject_band_for_unknown_profile",
+			profile: `{"name": "Sam", "profile": "Engineer"}`,
+			job:     `{"description": "A job", "expected_scores": {"alex": {"min": 10, "max": 20}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFixture(t, filepath.Join(dir, "profiles", "sam.json"), tt.profile)
+			writeFixture(t, filepath.Join(dir, "jobs", "job.json"), tt.job)
+
+			_, err := LoadFixtures(dir)
+
+			assert.ErrorIs(t, err, ErrInvalidFixture)
+		})
+	}
+}
+
+func writeFixture(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
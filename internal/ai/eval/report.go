@@ -0,0 +1,189 @@
+package eval
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Report formats.
+const (
+	FormatJSON     = "json"
+	FormatMarkdown = "markdown"
+)
+
+// Case is the evaluation of one task for a profile and job pair.
+type Case struct {
+	Task          Task       `json:"task"`
+	ProfileID     string     `json:"profile"`
+	JobID         string     `json:"job"`
+	Passed        bool       `json:"passed"`
+	Score         *int       `json:"score,omitempty"`
+	ExpectedScore *ScoreBand `json:"expected_score,omitempty"`
+	PromptVersion string     `json:"prompt_version,omitempty"`
+	DurationMs    int64      `json:"duration_ms"`
+	Error         string     `json:"error,omitempty"`
+	Checks        []Check    `json:"checks"`
+}
+
+// CheckSummary counts the outcomes of one named check across cases.
+type CheckSummary struct {
+	Passed int `json:"passed"`
+	Failed int `json:"failed"`
+}
+
+// Summary aggregates a report's cases.
+type Summary struct {
+	Cases  int                     `json:"cases"`
+	Passed int                     `json:"passed"`
+	Failed int                     `json:"failed"`
+	Checks map[string]CheckSummary `json:"checks"`
+	// MeanBandDistance is the mean number of points match scores fell outside
+	// their expected bands, over cases with an expected band.
+	MeanBandDistance float64 `json:"mean_band_distance"`
+}
+
+// Report is the result of an evaluation run.
+type Report struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	Targets     Targets   `json:"targets"`
+	Summary     Summary   `json:"summary"`
+	Cases       []Case    `json:"cases"`
+}
+
+// HasFailures reports whether any case failed a check.
+func (r *Report) HasFailures() bool {
+	return r.Summary.Failed > 0
+}
+
+// finish computes the summary from the cases.
+func (r *Report) finish() {
+	summary := Summary{Cases: len(r.Cases), Checks: map[string]CheckSummary{}}
+
+	var banded, distance int
+	for _, c := range r.Cases {
+		if c.Passed {
+			summary.Passed++
+		} else {
+			summary.Failed++
+		}
+
+		for _, check := range c.Checks {
+			tally := summary.Checks[check.Name]
+			if check.Passed {
+				tally.Passed++
+			} else {
+				tally.Failed++
+			}
+			summary.Checks[check.Name] = tally
+		}
+
+		if c.Score != nil && c.ExpectedScore != nil {
+			banded++
+			distance += bandDistance(*c.Score, *c.ExpectedScore)
+		}
+	}
+	if banded > 0 {
+		summary.MeanBandDistance = float64(distance) / float64(banded)
+	}
+
+	r.Summary = summary
+}
+
+// bandDistance returns how many points score lies outside band.
+func bandDistance(score int, band ScoreBand) int {
+	switch {
+	case score < band.Min:
+		return band.Min - score
+	case score > band.Max:
+		return score - band.Max
+	default:
+		return 0
+	}
+}
+
+// Write writes the report to w in the given format.
+func (r *Report) Write(w io.Writer, format string) error {
+	switch format {
+	case FormatJSON:
+		return r.WriteJSON(w)
+	case FormatMarkdown:
+		return r.WriteMarkdown(w)
+	default:
+		return WrapError(ErrUnknownFormat, fmt.Errorf("'%s' is not one of %s, %s", format, FormatJSON, FormatMarkdown))
+	}
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteMarkdown writes the report as a Markdown document.
+func (r *Report) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Evaluation Report\n\n")
+	fmt.Fprintf(&b, "Generated %s\n\n", r.GeneratedAt.Format(time.RFC3339))
+	fmt.Fprintf(&b, "**%d of %d cases passed.**", r.Summary.Passed, r.Summary.Cases)
+	if r.Summary.MeanBandDistance > 0 {
+		fmt.Fprintf(&b, " Match scores fell %.1f points outside their expected bands on average.", r.Summary.MeanBandDistance)
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString("## Checks\n\n")
+	b.WriteString("| Check | Passed | Failed |\n|---|---|---|\n")
+	names := make([]string, 0, len(r.Summary.Checks))
+	for name := range r.Summary.Checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		tally := r.Summary.Checks[name]
+		fmt.Fprintf(&b, "| %s | %d | %d |\n", name, tally.Passed, tally.Failed)
+	}
+
+	b.WriteString("\n## Cases\n\n")
+	b.WriteString("| Task | Profile | Job | Score | Expected | Result |\n|---|---|---|---|---|---|\n")
+	for _, c := range r.Cases {
+		score, expected := "-", "-"
+		if c.Score != nil {
+			score = fmt.Sprintf("%d", *c.Score)
+		}
+		if c.ExpectedScore != nil {
+			expected = c.ExpectedScore.String()
+		}
+		result := "pass"
+		if !c.Passed {
+			result = "**fail**"
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s |\n", c.Task, c.ProfileID, c.JobID, score, expected, result)
+	}
+
+	var failures []string
+	for _, c := range r.Cases {
+		for _, check := range c.Checks {
+			if !check.Passed {
+				failures = append(failures, fmt.Sprintf("- %s / %s / %s: `%s` %s", c.Task, c.ProfileID, c.JobID, check.Name, markdownEscape(check.Detail)))
+			}
+		}
+	}
+	if len(failures) > 0 {
+		b.WriteString("\n## Failures\n\n")
+		b.WriteString(strings.Join(failures, "\n"))
+		b.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownEscape keeps details on one line so they do not break the list.
+func markdownEscape(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
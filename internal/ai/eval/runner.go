@@ -0,0 +1,141 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/benidevo/vega/internal/ai"
+	"github.com/benidevo/vega/internal/ai/models"
+)
+
+// Task names a generation service under evaluation.
+type Task string
+
+const (
+	TaskMatch       Task = "match"
+	TaskCoverLetter Task = "cover_letter"
+	TaskCV          Task = "cv"
+)
+
+// AllTasks returns every task in the order cases are run.
+func AllTasks() []Task {
+	return []Task{TaskMatch, TaskCoverLetter, TaskCV}
+}
+
+// ParseTasks parses a comma-separated task list. An empty list selects all tasks.
+func ParseTasks(list string) ([]Task, error) {
+	if strings.TrimSpace(list) == "" {
+		return AllTasks(), nil
+	}
+
+	var tasks []Task
+	for _, name := range strings.Split(list, ",") {
+		task := Task(strings.TrimSpace(name))
+		switch task {
+		case TaskMatch, TaskCoverLetter, TaskCV:
+			tasks = append(tasks, task)
+		default:
+			return nil, WrapError(ErrUnknownTask, fmt.Errorf("'%s' is not one of match, cover_letter, cv", task))
+		}
+	}
+	return tasks, nil
+}
+
+// Runner evaluates the AI services over fixtures. It works with whichever
+// llm.Provider the service was built with, including the replay provider.
+type Runner struct {
+	service *ai.AIService
+	targets Targets
+	tasks   []Task
+}
+
+// NewRunner creates a runner for the given tasks; no tasks means all of them.
+func NewRunner(service *ai.AIService, targets Targets, tasks ...Task) *Runner {
+	if len(tasks) == 0 {
+		tasks = AllTasks()
+	}
+	return &Runner{service: service, targets: targets, tasks: tasks}
+}
+
+// Run evaluates every task for every profile and job pair.
+func (r *Runner) Run(ctx context.Context, fixtures *Fixtures) *Report {
+	report := &Report{
+		GeneratedAt: time.Now().UTC(),
+		Targets:     r.targets,
+	}
+
+	for _, job := range fixtures.Jobs {
+		for _, profile := range fixtures.Profiles {
+			for _, task := range r.tasks {
+				if err := ctx.Err(); err != nil {
+					report.finish()
+					return report
+				}
+				report.Cases = append(report.Cases, r.runCase(ctx, task, profile, job))
+			}
+		}
+	}
+
+	report.finish()
+	return report
+}
+
+func (r *Runner) runCase(ctx context.Context, task Task, profile Profile, job Job) Case {
+	c := Case{Task: task, ProfileID: profile.ID, JobID: job.ID}
+	req := models.Request{
+		ApplicantName:    profile.Name,
+		ApplicantProfile: profile.Profile,
+		JobDescription:   job.Description,
+		ExtraContext:     profile.ExtraContext,
+		CVText:           profile.CVText,
+		Skills:           profile.Skills,
+	}
+
+	start := time.Now()
+	var err error
+	switch task {
+	case TaskMatch:
+		var result *models.MatchResult
+		if result, err = r.service.JobMatcher.AnalyzeMatch(ctx, req); err == nil {
+			score := result.MatchScore
+			c.Score = &score
+			c.PromptVersion = result.PromptVersion
+			var band *ScoreBand
+			if expected, ok := job.ExpectedScores[profile.ID]; ok {
+				band = &expected
+				c.ExpectedScore = band
+			}
+			c.Checks = checkMatchResult(result, r.targets, band)
+		}
+	case TaskCoverLetter:
+		var letter *models.CoverLetter
+		if letter, err = r.service.CoverLetterGenerator.GenerateCoverLetter(ctx, req); err == nil {
+			c.PromptVersion = letter.PromptVersion
+			c.Checks = checkCoverLetter(letter, r.targets)
+		}
+	case TaskCV:
+		var cv *models.GeneratedCV
+		if cv, err = r.service.CVGenerator.GenerateCV(ctx, req, 0, job.Title); err == nil {
+			c.PromptVersion = cv.PromptVersion
+			c.Checks = checkGeneratedCV(cv, r.targets)
+		}
+	}
+	c.DurationMs = time.Since(start).Milliseconds()
+
+	if err != nil {
+		// A failed generation is reported as a schema failure: the service
+		// rejects responses it cannot parse or validate.
+		c.Error = err.Error()
+		c.Checks = []Check{{Name: CheckSchema, Detail: err.Error()}}
+	}
+
+	c.Passed = true
+	for _, check := range c.Checks {
+		if !check.Passed {
+			c.Passed = false
+		}
+	}
+	return c
+}
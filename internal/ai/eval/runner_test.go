@@ -0,0 +1,118 @@
+package eval
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/benidevo/vega/internal/ai"
+	"github.com/benidevo/vega/internal/ai/llm/replay"
+	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/benidevo/vega/internal/ai/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTasks(t *testing.T) {
+	tasks, err := ParseTasks("")
+	require.NoError(t, err)
+	assert.Equal(t, AllTasks(), tasks)
+
+	tasks, err = ParseTasks("match, cv")
+	require.NoError(t, err)
+	assert.Equal(t, []Task{TaskMatch, TaskCV}, tasks)
+
+	_, err = ParseTasks("match,summary")
+	assert.ErrorIs(t, err, ErrUnknownTask)
+}
+
+func TestRunner_Run(t *testing.T) {
+	fixtures, err := LoadFixtures("testdata")
+	require.NoError(t, err)
+
+	t.Run("should_evaluate_every_pair_with_synthetic_provider", func(t *testing.T) {
+		provider, err := replay.New(&replay.Config{Mode: replay.ModeSynthetic}, nil)
+		require.NoError(t, err)
+
+		report := NewRunner(ai.NewAIService(provider), DefaultTargets()).Run(context.Background(), fixtures)
+
+		require.Len(t, report.Cases, len(fixtures.Profiles)*len(fixtures.Jobs)*len(AllTasks()))
+		assert.Equal(t, len(report.Cases), report.Summary.Cases)
+		assert.Equal(t, report.Summary.Cases, report.Summary.Passed+report.Summary.Failed)
+		for _, c := range report.Cases {
+			assert.Empty(t, c.Error)
+			assert.True(t, findCheck(c.Checks, CheckSchema).Passed, "%s/%s/%s", c.Task, c.ProfileID, c.JobID)
+			if c.Task == TaskMatch {
+				require.NotNil(t, c.Score)
+				require.NotNil(t, c.ExpectedScore)
+			}
+		}
+	})
+
+	t.Run("should_report_provider_errors_as_schema_failures", func(t *testing.T) {
+		provider := &testutil.MockProvider{}
+		provider.SetupJobAnalysisMock(models.MatchResult{}, assert.AnError)
+
+		report := NewRunner(ai.NewAIService(provider), DefaultTargets(), TaskMatch).Run(context.Background(), fixtures)
+
+		require.NotEmpty(t, report.Cases)
+		assert.True(t, report.HasFailures())
+		assert.Equal(t, 0, report.Summary.Passed)
+		assert.Equal(t, CheckSummary{Failed: len(report.Cases)}, report.Summary.Checks[CheckSchema])
+		assert.NotEmpty(t, report.Cases[0].Error)
+	})
+
+	t.Run("should_measure_distance_from_expected_bands", func(t *testing.T) {
+		provider := &testutil.MockProvider{}
+		provider.SetupJobAnalysisMock(models.MatchResult{
+			MatchScore: 50,
+			Strengths:  []string{"Relevant background"},
+			Weaknesses: []string{"Some gaps"},
+			Feedback:   "Partly matches the role.",
+		}, nil)
+
+		report := NewRunner(ai.NewAIService(provider), DefaultTargets(), TaskMatch).Run(context.Background(), fixtures)
+
+		// Every pair's band is either 0-30 or starts at 70 or 75, so a score
+		// of 50 misses each by 20 or 25 points.
+		assert.InDelta(t, 21.25, report.Summary.MeanBandDistance, 0.001)
+		assert.Equal(t, CheckSummary{Failed: 4}, report.Summary.Checks[CheckScoreBand])
+	})
+}
+
+func TestReport_Write(t *testing.T) {
+	score := 40
+	report := &Report{Cases: []Case{
+		{Task: TaskMatch, ProfileID: "p", JobID: "j", Passed: true, Score: &score, Checks: []Check{{Name: CheckSchema, Passed: true}}},
+		{Task: TaskCoverLetter, ProfileID: "p", JobID: "j", Checks: []Check{{Name: CheckLength, Detail: "12 words\n(target 150-250)"}}},
+	}}
+	report.finish()
+
+	t.Run("should_write_markdown", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, report.Write(&buf, FormatMarkdown))
+
+		out := buf.String()
+		assert.Contains(t, out, "**1 of 2 cases passed.**")
+		assert.Contains(t, out, "| match | p | j | 40 | - | pass |")
+		assert.Contains(t, out, "- cover_letter / p / j: `length` 12 words (target 150-250)")
+	})
+
+	t.Run("should_write_json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, report.Write(&buf, FormatJSON))
+
+		var decoded Report
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		assert.Equal(t, report.Summary, decoded.Summary)
+		assert.Len(t, decoded.Cases, 2)
+	})
+
+	t.Run("should_reject_unknown_format", func(t *testing.T) {
+		err := report.Write(&strings.Builder{}, "pdf")
+
+		assert.ErrorIs(t, err, ErrUnknownFormat)
+	})
+}
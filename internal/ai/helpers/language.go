@@ -0,0 +1,69 @@
+package helpers
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Common words of each supported language, keyed by ISO 639-1 code. Words
+// that are everyday words in several of them ("de", "a", "in") are left out;
+// the few that remain shared count towards each language they belong to.
+var languageStopwords = map[string][]string{
+	"en": {"the", "and", "with", "you", "for", "our", "will", "are", "have", "this", "we", "your", "of", "to", "experience"},
+	"de": {"und", "der", "die", "das", "mit", "für", "wir", "sie", "ist", "nicht", "auf", "eine", "ein", "bei", "erfahrung", "heute"},
+	"es": {"el", "los", "las", "del", "con", "para", "por", "una", "que", "nuestro", "experiencia", "años", "actualidad"},
+	"fr": {"le", "les", "des", "du", "et", "avec", "pour", "nous", "vous", "une", "est", "dans", "expérience", "aujourd'hui"},
+	"it": {"il", "gli", "della", "delle", "con", "per", "che", "una", "sono", "nel", "esperienza", "anni", "attualmente"},
+	"nl": {"het", "een", "van", "met", "voor", "wij", "jij", "zijn", "ervaring", "bij", "heden"},
+	"pl": {"w", "z", "na", "się", "oraz", "jest", "dla", "nie", "do", "doświadczenie", "obecnie"},
+	"pt": {"o", "os", "da", "do", "das", "dos", "com", "para", "uma", "você", "não", "experiência", "atual"},
+}
+
+// Detection needs at least this many stopword hits, and the best language
+// must beat the runner-up by this factor, before a guess is made.
+const (
+	minLanguageHits    = 3
+	languageHitsMargin = 1.5
+)
+
+// stopwordLanguages maps each stopword to the languages it belongs to.
+var stopwordLanguages = func() map[string][]string {
+	index := make(map[string][]string)
+	for code, words := range languageStopwords {
+		for _, word := range words {
+			index[word] = append(index[word], code)
+		}
+	}
+	return index
+}()
+
+// DetectLanguage guesses the language of a CV or job description by counting
+// common words, and returns its ISO 639-1 code. It returns an empty string
+// when the text is too short or too mixed to tell.
+func DetectLanguage(text string) string {
+	hits := make(map[string]int)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	for _, word := range words {
+		for _, code := range stopwordLanguages[word] {
+			hits[code]++
+		}
+	}
+
+	best, bestHits, runnerUp := "", 0, 0
+	for code, count := range hits {
+		switch {
+		case count > bestHits || (count == bestHits && code < best):
+			runnerUp = max(runnerUp, bestHits)
+			best, bestHits = code, count
+		case count > runnerUp:
+			runnerUp = count
+		}
+	}
+
+	if bestHits < minLanguageHits || float64(bestHits) < float64(runnerUp)*languageHitsMargin {
+		return ""
+	}
+	return best
+}
@@ -0,0 +1,32 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"english", "We are looking for a backend engineer with experience in Go. You will work with our platform team and have ownership of the API.", "en"},
+		{"german", "Wir suchen eine Softwareentwicklerin mit Erfahrung in Go. Sie arbeiten mit unserem Team und sind für die Plattform verantwortlich.", "de"},
+		{"spanish", "Buscamos una desarrolladora con experiencia en Go para nuestro equipo. Trabajarás con los equipos de producto y las áreas de datos.", "es"},
+		{"french", "Nous recherchons une développeuse avec une expérience en Go. Vous travaillerez avec les équipes produit et des partenaires dans le monde.", "fr"},
+		{"italian", "Cerchiamo una sviluppatrice con esperienza in Go per il team della piattaforma. Lavorerai con gli ingegneri che sono nel gruppo prodotto.", "it"},
+		{"dutch", "Wij zoeken een ontwikkelaar met ervaring in Go voor het platformteam. Je werkt bij een van de snelst groeiende bedrijven.", "nl"},
+		{"polish", "Szukamy programisty z doświadczeniem w Go. Praca w zespole oraz na rzecz klientów, dla których jest to ważne.", "pl"},
+		{"portuguese", "Procuramos uma pessoa com experiência em Go para o time de plataforma. Você vai trabalhar com os times de produto e dados.", "pt"},
+		{"too_short", "Go developer", ""},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, DetectLanguage(tt.text))
+		})
+	}
+}
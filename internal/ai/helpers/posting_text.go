@@ -0,0 +1,113 @@
+package helpers
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	htmlTagPattern    = regexp.MustCompile(`(?i)<\s*/?\s*(html|head|body|div|p|br|span|ul|ol|li|h[1-6]|section|article|main|a|table|meta|script)\b`)
+	spacesPattern     = regexp.MustCompile(`[ \t\f\v\r]+`)
+	blankLinesPattern = regexp.MustCompile(`\n\s*\n+`)
+)
+
+// Elements whose content is never part of a job posting.
+var skippedElements = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Noscript: true,
+	atom.Template: true,
+	atom.Svg:      true,
+	atom.Head:     true,
+	atom.Nav:      true,
+	atom.Footer:   true,
+	atom.Form:     true,
+	atom.Iframe:   true,
+	atom.Button:   true,
+}
+
+// Elements that start a new line of text.
+var blockElements = map[atom.Atom]bool{
+	atom.Address: true, atom.Article: true, atom.Aside: true, atom.Blockquote: true,
+	atom.Br: true, atom.Dd: true, atom.Div: true, atom.Dl: true, atom.Dt: true,
+	atom.Fieldset: true, atom.Figure: true, atom.H1: true, atom.H2: true, atom.H3: true,
+	atom.H4: true, atom.H5: true, atom.H6: true, atom.Header: true, atom.Hr: true,
+	atom.Li: true, atom.Main: true, atom.Ol: true, atom.P: true, atom.Pre: true,
+	atom.Section: true, atom.Table: true, atom.Td: true, atom.Th: true, atom.Tr: true,
+	atom.Ul: true,
+}
+
+// PostingText returns the readable text of a job posting. Plain text is only
+// tidied up; HTML, such as a saved job page, is reduced to its visible text
+// with one line per block element. Absolute link targets are kept next to the
+// link text so application URLs survive the conversion.
+func PostingText(raw string) string {
+	if !htmlTagPattern.MatchString(raw) {
+		return tidyText(raw)
+	}
+
+	doc, err := html.Parse(strings.NewReader(raw))
+	if err != nil {
+		return tidyText(raw)
+	}
+
+	var b strings.Builder
+	writeNodeText(&b, doc)
+	return tidyText(b.String())
+}
+
+func writeNodeText(b *strings.Builder, n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		b.WriteString(n.Data)
+		return
+	case html.CommentNode, html.DoctypeNode:
+		return
+	case html.ElementNode:
+		if skippedElements[n.DataAtom] {
+			return
+		}
+	}
+
+	block := n.Type == html.ElementNode && blockElements[n.DataAtom]
+	if block {
+		b.WriteString("\n")
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		writeNodeText(b, child)
+	}
+	if n.DataAtom == atom.A {
+		if href := attr(n, "href"); strings.HasPrefix(href, "http://") || strings.HasPrefix(href, "https://") {
+			b.WriteString(" (" + href + ")")
+		}
+	}
+	if block {
+		b.WriteString("\n")
+	}
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return strings.TrimSpace(a.Val)
+		}
+	}
+	return ""
+}
+
+// tidyText collapses runs of spaces and blank lines and trims every line.
+func tidyText(text string) string {
+	text = strings.ReplaceAll(text, "\u00a0", " ")
+	text = spacesPattern.ReplaceAllString(text, " ")
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	text = strings.Join(lines, "\n")
+
+	return strings.TrimSpace(blankLinesPattern.ReplaceAllString(text, "\n\n"))
+}
@@ -0,0 +1,37 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostingText(t *testing.T) {
+	t.Run("should_tidy_plain_text", func(t *testing.T) {
+		text := PostingText("  Senior Go Engineer  \n\n\n\tAcme Ltd   is hiring \n")
+
+		assert.Equal(t, "Senior Go Engineer\n\nAcme Ltd is hiring", text)
+	})
+
+	t.Run("should_keep_plain_text_with_angle_brackets", func(t *testing.T) {
+		assert.Equal(t, "Salary <£80k, 5+ years", PostingText("Salary <£80k, 5+ years"))
+	})
+
+	t.Run("should_reduce_html_to_visible_text", func(t *testing.T) {
+		page := `<!DOCTYPE html>
+<html><head><title>Jobs</title><style>h1 { color: red }</style></head>
+<body>
+  <nav><a href="/">Home</a></nav>
+  <h1>Senior&nbsp;Go Engineer</h1>
+  <div class="company">Acme Ltd</div>
+  <ul><li>Go</li><li>Kubernetes</li></ul>
+  <script>track()</script>
+  <p>Apply <a href="https://acme.example/apply/42">here</a> or <a href="/relative">there</a>.</p>
+  <footer>© Acme</footer>
+</body></html>`
+
+		text := PostingText(page)
+
+		assert.Equal(t, "Senior Go Engineer\n\nAcme Ltd\n\nGo\n\nKubernetes\n\nApply here (https://acme.example/apply/42) or there.", text)
+	})
+}
@@ -0,0 +1,32 @@
+package cached
+
+import (
+	"time"
+
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/config"
+)
+
+// Config holds the configuration for the response cache.
+type Config struct {
+	// TTLs is how long responses are cached for each response type. Types
+	// without a positive TTL are never cached.
+	TTLs map[llm.ResponseType]time.Duration
+}
+
+// NewConfig creates a new Config from the application settings.
+func NewConfig(cfg *config.Settings) *Config {
+	return &Config{
+		TTLs: map[llm.ResponseType]time.Duration{
+			llm.ResponseTypeMatchResult:    cfg.AICacheTTLJobAnalysis,
+			llm.ResponseTypeCoverLetter:    cfg.AICacheTTLCoverLetter,
+			llm.ResponseTypeCVParsing:      cfg.AICacheTTLCVParsing,
+			llm.ResponseTypeCV:             cfg.AICacheTTLCVGeneration,
+			llm.ResponseTypeInterviewPrep:  cfg.AICacheTTLInterviewPrep,
+			llm.ResponseTypeEmail:          cfg.AICacheTTLEmail,
+			llm.ResponseTypeLearningPlan:   cfg.AICacheTTLLearningPlan,
+			llm.ResponseTypeJobExtraction:  cfg.AICacheTTLJobExtraction,
+			llm.ResponseTypeSectionRewrite: cfg.AICacheTTLSectionRewrite,
+		},
+	}
+}
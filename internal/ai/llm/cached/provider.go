@@ -0,0 +1,192 @@
+package cached
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/llm/structured"
+	"github.com/benidevo/vega/internal/cache"
+	"github.com/benidevo/vega/internal/common/logger"
+)
+
+// keyPrefix namespaces cached responses so they can be purged together.
+const keyPrefix = "ai:response:"
+
+// ModelResolver returns the model that serves the given task type.
+type ModelResolver func(taskType string) string
+
+// Provider wraps an llm.Provider and serves repeated requests from cache.
+type Provider struct {
+	next  llm.Provider
+	store cache.Cache
+	cfg   *Config
+	model ModelResolver
+	tasks *structured.Config
+	log   *logger.PrivacyLogger
+}
+
+// New creates a caching provider around next. model identifies the model
+// behind each task type so that switching models does not serve stale
+// responses.
+func New(next llm.Provider, store cache.Cache, cfg *Config, model ModelResolver) *Provider {
+	return &Provider{
+		next:  next,
+		store: store,
+		cfg:   cfg,
+		model: model,
+		tasks: structured.NewConfig(),
+		log:   logger.GetPrivacyLogger("ai_cache"),
+	}
+}
+
+// entry is the cached form of a response. Output holds the JSON encoded
+// response data, which is parsed again on read.
+type entry struct {
+	Output           json.RawMessage `json:"output"`
+	Tokens           int             `json:"tokens"`
+	PromptTokens     int             `json:"prompt_tokens"`
+	CompletionTokens int             `json:"completion_tokens"`
+	Metadata         map[string]any  `json:"metadata,omitempty"`
+	CachedAt         time.Time       `json:"cached_at"`
+}
+
+// Generate implements the Provider interface.
+func (p *Provider) Generate(ctx context.Context, request llm.GenerateRequest) (llm.GenerateResponse, error) {
+	return p.generate(ctx, request, nil)
+}
+
+// GenerateStream streams from the wrapped provider on a cache miss. Cache
+// hits are returned whole without invoking onChunk.
+func (p *Provider) GenerateStream(ctx context.Context, request llm.GenerateRequest, onChunk llm.StreamHandler) (llm.GenerateResponse, error) {
+	return p.generate(ctx, request, onChunk)
+}
+
+// ValidateModels passes model validation through to the wrapped provider.
+func (p *Provider) ValidateModels(ctx context.Context) error {
+	if validator, ok := p.next.(interface{ ValidateModels(context.Context) error }); ok {
+		return validator.ValidateModels(ctx)
+	}
+	return nil
+}
+
+func (p *Provider) generate(ctx context.Context, request llm.GenerateRequest, onChunk llm.StreamHandler) (llm.GenerateResponse, error) {
+	ttl := p.cfg.TTLs[request.ResponseType]
+	if ttl <= 0 {
+		return p.call(ctx, request, onChunk)
+	}
+
+	key, err := p.cacheKey(request)
+	if err != nil {
+		return p.call(ctx, request, onChunk)
+	}
+
+	if !llm.IsForceRefresh(ctx) {
+		if resp, ok := p.lookup(ctx, request.ResponseType, key); ok {
+			llm.MarkCacheHit(ctx)
+			return resp, nil
+		}
+	}
+
+	resp, err := p.call(ctx, request, onChunk)
+	if err != nil {
+		return llm.GenerateResponse{}, err
+	}
+
+	p.save(ctx, key, resp, ttl)
+	resp.Metadata = withCacheHit(resp.Metadata, false)
+	return resp, nil
+}
+
+func (p *Provider) call(ctx context.Context, request llm.GenerateRequest, onChunk llm.StreamHandler) (llm.GenerateResponse, error) {
+	if onChunk != nil {
+		return llm.GenerateStream(ctx, p.next, request, onChunk)
+	}
+	return p.next.Generate(ctx, request)
+}
+
+// cacheKey hashes the rendered prompt, model, temperature and response type.
+func (p *Provider) cacheKey(request llm.GenerateRequest) (string, error) {
+	task, err := p.tasks.BuildTask(request.ResponseType, request.Prompt)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	for _, part := range []string{
+		string(request.ResponseType),
+		p.model(task.TaskType.String()),
+		fmt.Sprintf("%g", task.Temperature),
+		task.SystemInstruction,
+		task.UserPrompt,
+	} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return keyPrefix + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (p *Provider) lookup(ctx context.Context, responseType llm.ResponseType, key string) (llm.GenerateResponse, bool) {
+	start := time.Now()
+
+	var cached entry
+	if err := p.store.Get(ctx, key, &cached); err != nil {
+		if !errors.Is(err, cache.ErrCacheMiss) {
+			p.log.Warn().Err(err).Str("response_type", string(responseType)).Msg("Failed to read cached AI response")
+		}
+		return llm.GenerateResponse{}, false
+	}
+
+	data, err := p.tasks.Parse(responseType, string(cached.Output))
+	if err != nil {
+		p.log.Warn().Err(err).Str("response_type", string(responseType)).Msg("Discarding invalid cached AI response")
+		_ = p.store.Delete(ctx, key)
+		return llm.GenerateResponse{}, false
+	}
+
+	metadata := withCacheHit(cached.Metadata, true)
+	metadata["cached_at"] = cached.CachedAt
+
+	return llm.GenerateResponse{
+		Data:             data,
+		Duration:         time.Since(start),
+		Tokens:           cached.Tokens,
+		PromptTokens:     cached.PromptTokens,
+		CompletionTokens: cached.CompletionTokens,
+		Metadata:         metadata,
+	}, true
+}
+
+// save caches a fresh response. Failures are logged; the caller still
+// receives the response.
+func (p *Provider) save(ctx context.Context, key string, resp llm.GenerateResponse, ttl time.Duration) {
+	output, err := json.Marshal(resp.Data)
+	if err == nil {
+		err = p.store.Set(ctx, key, entry{
+			Output:           output,
+			Tokens:           resp.Tokens,
+			PromptTokens:     resp.PromptTokens,
+			CompletionTokens: resp.CompletionTokens,
+			Metadata:         resp.Metadata,
+			CachedAt:         time.Now().UTC(),
+		}, ttl)
+	}
+	if err != nil {
+		p.log.Warn().Err(err).Msg("Failed to cache AI response")
+	}
+}
+
+// withCacheHit returns a copy of metadata with the cache_hit flag set.
+func withCacheHit(metadata map[string]any, hit bool) map[string]any {
+	result := make(map[string]any, len(metadata)+2)
+	for k, v := range metadata {
+		result[k] = v
+	}
+	result["cache_hit"] = hit
+	return result
+}
@@ -0,0 +1,240 @@
+package cached
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/benidevo/vega/internal/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubProvider struct {
+	data   any
+	chunks []string
+	calls  int
+}
+
+func (s *stubProvider) Generate(ctx context.Context, req llm.GenerateRequest) (llm.GenerateResponse, error) {
+	s.calls++
+	return llm.GenerateResponse{
+		Data:     s.data,
+		Tokens:   42,
+		Metadata: map[string]any{"model": "stub-model"},
+	}, nil
+}
+
+func (s *stubProvider) GenerateStream(ctx context.Context, req llm.GenerateRequest, onChunk llm.StreamHandler) (llm.GenerateResponse, error) {
+	for _, chunk := range s.chunks {
+		if err := onChunk(chunk); err != nil {
+			return llm.GenerateResponse{}, err
+		}
+	}
+	return s.Generate(ctx, req)
+}
+
+// memoryCache is a minimal in-memory cache.Cache for tests.
+type memoryCache struct {
+	entries map[string][]byte
+	ttls    map[string]time.Duration
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{entries: map[string][]byte{}, ttls: map[string]time.Duration{}}
+}
+
+func (m *memoryCache) Get(ctx context.Context, key string, value any) error {
+	data, ok := m.entries[key]
+	if !ok {
+		return cache.ErrCacheMiss
+	}
+	return json.Unmarshal(data, value)
+}
+
+func (m *memoryCache) Set(ctx context.Context, key string, value any, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	m.entries[key] = data
+	m.ttls[key] = ttl
+	return nil
+}
+
+func (m *memoryCache) Delete(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		delete(m.entries, key)
+	}
+	return nil
+}
+
+func (m *memoryCache) DeletePattern(ctx context.Context, pattern string) error {
+	prefix := strings.TrimSuffix(pattern, "*")
+	for key := range m.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(m.entries, key)
+		}
+	}
+	return nil
+}
+
+func (m *memoryCache) Exists(ctx context.Context, key string) (bool, error) {
+	_, ok := m.entries[key]
+	return ok, nil
+}
+
+func (m *memoryCache) Close() error { return nil }
+
+func matchRequest(description string) llm.GenerateRequest {
+	prompt := models.NewPrompt("Analyze", models.Request{
+		ApplicantName:    "Jane Doe",
+		ApplicantProfile: "Backend engineer",
+		JobDescription:   description,
+	}, false)
+	return llm.GenerateRequest{Prompt: *prompt, ResponseType: llm.ResponseTypeMatchResult}
+}
+
+func testConfig() *Config {
+	return &Config{TTLs: map[llm.ResponseType]time.Duration{
+		llm.ResponseTypeMatchResult: time.Hour,
+		llm.ResponseTypeCoverLetter: 30 * time.Minute,
+	}}
+}
+
+func fixedModel(model string) ModelResolver {
+	return func(string) string { return model }
+}
+
+var testMatch = models.MatchResult{
+	MatchScore: 80,
+	Strengths:  []string{"Go"},
+	Weaknesses: []string{"Rust"},
+	Highlights: []string{"APIs"},
+	Feedback:   "Good fit",
+}
+
+func TestProvider_CachesResponses(t *testing.T) {
+	store := newMemoryCache()
+	next := &stubProvider{data: testMatch}
+	provider := New(next, store, testConfig(), fixedModel("gemini/flash"))
+	ctx := context.Background()
+
+	first, err := provider.Generate(ctx, matchRequest("Go engineer"))
+	require.NoError(t, err)
+	assert.Equal(t, false, first.Metadata["cache_hit"])
+
+	hitCtx, status := llm.WithCacheStatus(ctx)
+	second, err := provider.Generate(hitCtx, matchRequest("Go engineer"))
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, next.calls)
+	assert.Equal(t, first.Data, second.Data)
+	assert.Equal(t, 42, second.Tokens)
+	assert.Equal(t, true, second.Metadata["cache_hit"])
+	assert.Equal(t, "stub-model", second.Metadata["model"])
+	assert.True(t, status.Hit())
+
+	for key, ttl := range store.ttls {
+		assert.True(t, strings.HasPrefix(key, keyPrefix))
+		assert.Equal(t, time.Hour, ttl)
+	}
+}
+
+func TestProvider_KeyIncludesPromptAndModel(t *testing.T) {
+	store := newMemoryCache()
+	next := &stubProvider{data: testMatch}
+	ctx := context.Background()
+
+	_, err := New(next, store, testConfig(), fixedModel("gemini/flash")).Generate(ctx, matchRequest("Go engineer"))
+	require.NoError(t, err)
+
+	_, err = New(next, store, testConfig(), fixedModel("gemini/flash")).Generate(ctx, matchRequest("Rust engineer"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, next.calls)
+
+	_, err = New(next, store, testConfig(), fixedModel("ollama/llama3")).Generate(ctx, matchRequest("Go engineer"))
+	require.NoError(t, err)
+	assert.Equal(t, 3, next.calls)
+
+	request := matchRequest("Go engineer")
+	request.Prompt.SetTemperature(0.9)
+	_, err = New(next, store, testConfig(), fixedModel("gemini/flash")).Generate(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, 4, next.calls)
+}
+
+func TestProvider_ForceRefresh(t *testing.T) {
+	store := newMemoryCache()
+	next := &stubProvider{data: testMatch}
+	provider := New(next, store, testConfig(), fixedModel("gemini/flash"))
+
+	_, err := provider.Generate(context.Background(), matchRequest("Go engineer"))
+	require.NoError(t, err)
+
+	ctx, status := llm.WithCacheStatus(llm.WithForceRefresh(context.Background()))
+	resp, err := provider.Generate(ctx, matchRequest("Go engineer"))
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, next.calls)
+	assert.Equal(t, false, resp.Metadata["cache_hit"])
+	assert.False(t, status.Hit())
+}
+
+func TestProvider_SkipsTypesWithoutTTL(t *testing.T) {
+	store := newMemoryCache()
+	next := &stubProvider{data: models.CVParsingResult{IsValid: true, PersonalInfo: models.PersonalInfo{FirstName: "Jane"}}}
+	provider := New(next, store, testConfig(), fixedModel("gemini/flash"))
+	request := llm.GenerateRequest{Prompt: *models.NewCVParsingPrompt("cv"), ResponseType: llm.ResponseTypeCVParsing}
+
+	for i := 0; i < 2; i++ {
+		_, err := provider.Generate(context.Background(), request)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, next.calls)
+	assert.Empty(t, store.entries)
+}
+
+func TestProvider_DiscardsInvalidEntries(t *testing.T) {
+	store := newMemoryCache()
+	next := &stubProvider{data: models.CoverLetter{Content: "Dear team"}}
+	provider := New(next, store, testConfig(), fixedModel("gemini/flash"))
+	request := llm.GenerateRequest{Prompt: matchRequest("Go engineer").Prompt, ResponseType: llm.ResponseTypeCoverLetter}
+
+	key, err := provider.cacheKey(request)
+	require.NoError(t, err)
+	require.NoError(t, store.Set(context.Background(), key, entry{Output: json.RawMessage(`{"content":""}`)}, time.Minute))
+
+	resp, err := provider.Generate(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, 1, next.calls)
+	assert.Equal(t, "Dear team", resp.Data.(models.CoverLetter).Content)
+}
+
+func TestProvider_GenerateStream(t *testing.T) {
+	store := newMemoryCache()
+	next := &stubProvider{data: testMatch, chunks: []string{`{"matchScore":`, `80}`}}
+	provider := New(next, store, testConfig(), fixedModel("gemini/flash"))
+
+	var chunks []string
+	onChunk := func(chunk string) error {
+		chunks = append(chunks, chunk)
+		return nil
+	}
+
+	_, err := provider.GenerateStream(context.Background(), matchRequest("Go engineer"), onChunk)
+	require.NoError(t, err)
+	assert.Equal(t, next.chunks, chunks)
+
+	chunks = nil
+	resp, err := provider.GenerateStream(context.Background(), matchRequest("Go engineer"), onChunk)
+	require.NoError(t, err)
+	assert.Empty(t, chunks, "cache hits are delivered as a single final response")
+	assert.Equal(t, true, resp.Metadata["cache_hit"])
+	assert.Equal(t, 1, next.calls)
+}
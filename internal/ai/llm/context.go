@@ -0,0 +1,50 @@
+package llm
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+type contextKey string
+
+const (
+	forceRefreshKey contextKey = "llmForceRefresh"
+	cacheStatusKey  contextKey = "llmCacheStatus"
+)
+
+// WithForceRefresh returns a context whose requests bypass cached responses.
+// Fresh responses still replace the cached entry.
+func WithForceRefresh(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forceRefreshKey, true)
+}
+
+// IsForceRefresh reports whether cached responses must be bypassed.
+func IsForceRefresh(ctx context.Context) bool {
+	force, _ := ctx.Value(forceRefreshKey).(bool)
+	return force
+}
+
+// CacheStatus reports whether a response generated under a context was
+// served from cache.
+type CacheStatus struct {
+	hit atomic.Bool
+}
+
+// WithCacheStatus returns a context that collects cache hits into the
+// returned CacheStatus.
+func WithCacheStatus(ctx context.Context) (context.Context, *CacheStatus) {
+	status := &CacheStatus{}
+	return context.WithValue(ctx, cacheStatusKey, status), status
+}
+
+// MarkCacheHit records a cache hit on the context's CacheStatus, if any.
+func MarkCacheHit(ctx context.Context) {
+	if status, ok := ctx.Value(cacheStatusKey).(*CacheStatus); ok {
+		status.hit.Store(true)
+	}
+}
+
+// Hit reports whether a cached response was served.
+func (s *CacheStatus) Hit() bool {
+	return s != nil && s.hit.Load()
+}
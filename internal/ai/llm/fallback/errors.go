@@ -0,0 +1,16 @@
+package fallback
+
+import (
+	commonerrors "github.com/benidevo/vega/internal/common/errors"
+)
+
+var (
+	ErrNoProviders         = commonerrors.New("no AI providers configured")
+	ErrAllProvidersFailed  = commonerrors.New("all AI providers failed")
+	ErrNoProviderAvailable = commonerrors.New("no AI provider available")
+)
+
+// WrapError wraps the given innerErr with the provided sentinelErr.
+func WrapError(sentinelErr, innerErr error) error {
+	return commonerrors.WrapError(sentinelErr, innerErr)
+}
@@ -0,0 +1,165 @@
+package fallback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/common/logger"
+)
+
+// Member is a named provider in a fallback chain.
+type Member struct {
+	Name     string
+	Provider llm.Provider
+}
+
+// Options configures the fallback provider.
+type Options struct {
+	Breaker BreakerConfig
+	// ShouldFailover reports whether an error warrants trying the next provider.
+	// Errors for which it returns false are returned to the caller immediately.
+	ShouldFailover func(error) bool
+}
+
+type member struct {
+	name     string
+	provider llm.Provider
+	breaker  *CircuitBreaker
+}
+
+// Provider is an llm.Provider that tries an ordered list of providers,
+// skipping those whose circuit breaker is open and failing over to the next
+// one on retryable errors.
+type Provider struct {
+	members        []*member
+	shouldFailover func(error) bool
+	log            *logger.PrivacyLogger
+}
+
+// New creates a fallback provider over the given members, in priority order.
+func New(members []Member, opts Options) (*Provider, error) {
+	if len(members) == 0 {
+		return nil, ErrNoProviders
+	}
+
+	shouldFailover := opts.ShouldFailover
+	if shouldFailover == nil {
+		shouldFailover = func(error) bool { return true }
+	}
+
+	p := &Provider{
+		shouldFailover: shouldFailover,
+		log:            logger.GetPrivacyLogger("ai_fallback"),
+	}
+	for _, m := range members {
+		p.members = append(p.members, &member{
+			name:     m.Name,
+			provider: m.Provider,
+			breaker:  NewCircuitBreaker(opts.Breaker),
+		})
+	}
+
+	return p, nil
+}
+
+// Generate implements the Provider interface. The name of the provider that
+// served the request is recorded in the response metadata under "provider".
+func (p *Provider) Generate(ctx context.Context, request llm.GenerateRequest) (llm.GenerateResponse, error) {
+	return p.generate(ctx, request, nil)
+}
+
+// GenerateStream implements the llm.StreamingProvider interface. Members that
+// cannot stream return their result in one piece. Once a member has emitted
+// output the chain no longer fails over, since the caller has already
+// received partial content.
+func (p *Provider) GenerateStream(ctx context.Context, request llm.GenerateRequest, onChunk llm.StreamHandler) (llm.GenerateResponse, error) {
+	return p.generate(ctx, request, onChunk)
+}
+
+func (p *Provider) generate(ctx context.Context, request llm.GenerateRequest, onChunk llm.StreamHandler) (llm.GenerateResponse, error) {
+	var lastErr error
+	attempted := make([]string, 0, len(p.members))
+
+	for _, m := range p.members {
+		if !m.breaker.Allow() {
+			p.log.Debug().
+				Str("provider", m.name).
+				Str("response_type", string(request.ResponseType)).
+				Msg("Skipping provider with open circuit")
+			continue
+		}
+
+		attempted = append(attempted, m.name)
+		var resp llm.GenerateResponse
+		var err error
+		emitted := false
+		if onChunk == nil {
+			resp, err = m.provider.Generate(ctx, request)
+		} else {
+			resp, err = llm.GenerateStream(ctx, m.provider, request, func(chunk string) error {
+				emitted = true
+				return onChunk(chunk)
+			})
+		}
+		if err == nil {
+			m.breaker.RecordSuccess()
+			if resp.Metadata == nil {
+				resp.Metadata = make(map[string]any)
+			}
+			resp.Metadata["provider"] = m.name
+			resp.Metadata["fallback"] = len(attempted) > 1
+			resp.Metadata["attempted_providers"] = attempted
+			return resp, nil
+		}
+
+		if ctx.Err() != nil || !p.shouldFailover(err) {
+			m.breaker.Release()
+			return llm.GenerateResponse{}, err
+		}
+
+		m.breaker.RecordFailure()
+		if emitted {
+			return llm.GenerateResponse{}, err
+		}
+		p.log.Warn().
+			Err(err).
+			Str("provider", m.name).
+			Str("response_type", string(request.ResponseType)).
+			Str("circuit_state", m.breaker.State().String()).
+			Msg("AI provider failed, trying next provider")
+		lastErr = err
+	}
+
+	if lastErr == nil {
+		return llm.GenerateResponse{}, WrapError(ErrNoProviderAvailable, fmt.Errorf("all %d providers have open circuits", len(p.members)))
+	}
+	return llm.GenerateResponse{}, WrapError(ErrAllProvidersFailed, lastErr)
+}
+
+// States returns the current circuit breaker state of each provider by name.
+func (p *Provider) States() map[string]BreakerState {
+	states := make(map[string]BreakerState, len(p.members))
+	for _, m := range p.members {
+		states[m.name] = m.breaker.State()
+	}
+	return states
+}
+
+// ValidateModels validates the models of every member that supports it.
+func (p *Provider) ValidateModels(ctx context.Context) error {
+	var errs []error
+	for _, m := range p.members {
+		validator, ok := m.provider.(interface {
+			ValidateModels(ctx context.Context) error
+		})
+		if !ok {
+			continue
+		}
+		if err := validator.ValidateModels(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", m.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
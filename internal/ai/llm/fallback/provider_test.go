@@ -0,0 +1,216 @@
+package fallback
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTransient = errors.New("503 service unavailable")
+
+type stubProvider struct {
+	err   error
+	calls int
+}
+
+func (s *stubProvider) Generate(ctx context.Context, req llm.GenerateRequest) (llm.GenerateResponse, error) {
+	s.calls++
+	if s.err != nil {
+		return llm.GenerateResponse{}, s.err
+	}
+	return llm.GenerateResponse{
+		Data:     models.CoverLetter{Content: "ok"},
+		Metadata: map[string]any{"model": "stub"},
+	}, nil
+}
+
+// streamingStub emits its chunks before failing with err, if set.
+type streamingStub struct {
+	stubProvider
+	chunks []string
+}
+
+func (s *streamingStub) GenerateStream(ctx context.Context, req llm.GenerateRequest, onChunk llm.StreamHandler) (llm.GenerateResponse, error) {
+	for _, chunk := range s.chunks {
+		if err := onChunk(chunk); err != nil {
+			return llm.GenerateResponse{}, err
+		}
+	}
+	return s.Generate(ctx, req)
+}
+
+func isTransient(err error) bool {
+	return errors.Is(err, errTransient)
+}
+
+func newTestProvider(t *testing.T, members ...Member) *Provider {
+	t.Helper()
+	p, err := New(members, Options{
+		Breaker:        BreakerConfig{FailureThreshold: 2, OpenTimeout: time.Minute},
+		ShouldFailover: isTransient,
+	})
+	require.NoError(t, err)
+	return p
+}
+
+func TestProvider_Generate(t *testing.T) {
+	request := llm.GenerateRequest{ResponseType: llm.ResponseTypeCoverLetter}
+
+	t.Run("primary serves request", func(t *testing.T) {
+		primary, secondary := &stubProvider{}, &stubProvider{}
+		p := newTestProvider(t, Member{"gemini", primary}, Member{"ollama", secondary})
+
+		resp, err := p.Generate(context.Background(), request)
+
+		require.NoError(t, err)
+		assert.Equal(t, "gemini", resp.Metadata["provider"])
+		assert.Equal(t, false, resp.Metadata["fallback"])
+		assert.Equal(t, "stub", resp.Metadata["model"])
+		assert.Equal(t, 0, secondary.calls)
+	})
+
+	t.Run("fails over on retryable error", func(t *testing.T) {
+		primary, secondary := &stubProvider{err: errTransient}, &stubProvider{}
+		p := newTestProvider(t, Member{"gemini", primary}, Member{"ollama", secondary})
+
+		resp, err := p.Generate(context.Background(), request)
+
+		require.NoError(t, err)
+		assert.Equal(t, "ollama", resp.Metadata["provider"])
+		assert.Equal(t, true, resp.Metadata["fallback"])
+		assert.Equal(t, []string{"gemini", "ollama"}, resp.Metadata["attempted_providers"])
+	})
+
+	t.Run("does not fail over on non-retryable error", func(t *testing.T) {
+		invalid := errors.New("invalid document")
+		primary, secondary := &stubProvider{err: invalid}, &stubProvider{}
+		p := newTestProvider(t, Member{"gemini", primary}, Member{"ollama", secondary})
+
+		_, err := p.Generate(context.Background(), request)
+
+		assert.Equal(t, invalid, err)
+		assert.Equal(t, 0, secondary.calls)
+		assert.Equal(t, StateClosed, p.States()["gemini"])
+	})
+
+	t.Run("open circuit skips provider", func(t *testing.T) {
+		primary, secondary := &stubProvider{err: errTransient}, &stubProvider{}
+		p := newTestProvider(t, Member{"gemini", primary}, Member{"ollama", secondary})
+
+		for i := 0; i < 3; i++ {
+			_, err := p.Generate(context.Background(), request)
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, 2, primary.calls, "primary should be skipped once its circuit opens")
+		assert.Equal(t, StateOpen, p.States()["gemini"])
+	})
+
+	t.Run("all providers failing", func(t *testing.T) {
+		p := newTestProvider(t,
+			Member{"gemini", &stubProvider{err: errTransient}},
+			Member{"ollama", &stubProvider{err: errTransient}},
+		)
+
+		_, err := p.Generate(context.Background(), request)
+		assert.ErrorIs(t, err, ErrAllProvidersFailed)
+
+		_, err = p.Generate(context.Background(), request)
+		assert.ErrorIs(t, err, ErrAllProvidersFailed)
+
+		_, err = p.Generate(context.Background(), request)
+		assert.ErrorIs(t, err, ErrNoProviderAvailable)
+	})
+
+	t.Run("no members", func(t *testing.T) {
+		p, err := New(nil, Options{})
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrNoProviders)
+	})
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	b := NewCircuitBreaker(BreakerConfig{FailureThreshold: 2, OpenTimeout: 10 * time.Second})
+	b.now = func() time.Time { return now }
+
+	assert.True(t, b.Allow())
+	b.RecordFailure()
+	assert.Equal(t, StateClosed, b.State())
+
+	b.RecordFailure()
+	assert.Equal(t, StateOpen, b.State())
+	assert.False(t, b.Allow())
+
+	now = now.Add(11 * time.Second)
+	assert.Equal(t, StateHalfOpen, b.State())
+	assert.True(t, b.Allow(), "first request after timeout is a trial")
+	assert.False(t, b.Allow(), "only one trial request at a time")
+
+	b.RecordFailure()
+	assert.Equal(t, StateOpen, b.State(), "failed trial reopens the circuit")
+
+	now = now.Add(11 * time.Second)
+	assert.True(t, b.Allow())
+	b.RecordSuccess()
+	assert.Equal(t, StateClosed, b.State())
+	assert.True(t, b.Allow())
+}
+
+func TestProvider_GenerateStream(t *testing.T) {
+	request := llm.GenerateRequest{ResponseType: llm.ResponseTypeCoverLetter}
+
+	collect := func(chunks *[]string) llm.StreamHandler {
+		return func(chunk string) error {
+			*chunks = append(*chunks, chunk)
+			return nil
+		}
+	}
+
+	t.Run("fails over before any output", func(t *testing.T) {
+		secondary := &streamingStub{chunks: []string{"a", "b"}}
+		p := newTestProvider(t,
+			Member{"gemini", &streamingStub{stubProvider: stubProvider{err: errTransient}}},
+			Member{"ollama", secondary},
+		)
+
+		var chunks []string
+		resp, err := p.GenerateStream(context.Background(), request, collect(&chunks))
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, chunks)
+		assert.Equal(t, "ollama", resp.Metadata["provider"])
+	})
+
+	t.Run("does not fail over after output started", func(t *testing.T) {
+		secondary := &streamingStub{}
+		p := newTestProvider(t,
+			Member{"gemini", &streamingStub{stubProvider: stubProvider{err: errTransient}, chunks: []string{"partial"}}},
+			Member{"ollama", secondary},
+		)
+
+		var chunks []string
+		_, err := p.GenerateStream(context.Background(), request, collect(&chunks))
+
+		assert.ErrorIs(t, err, errTransient)
+		assert.Equal(t, []string{"partial"}, chunks)
+		assert.Equal(t, 0, secondary.calls)
+	})
+
+	t.Run("non-streaming member returns single result", func(t *testing.T) {
+		p := newTestProvider(t, Member{"gemini", &stubProvider{}})
+
+		var chunks []string
+		resp, err := p.GenerateStream(context.Background(), request, collect(&chunks))
+
+		require.NoError(t, err)
+		assert.Empty(t, chunks)
+		assert.Equal(t, "ok", resp.Data.(models.CoverLetter).Content)
+	})
+}
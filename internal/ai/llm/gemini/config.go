@@ -30,24 +30,13 @@ type Config struct {
 	// Response configuration
 	ResponseMIMEType string
 
-	// Cover letter configuration
-	DefaultWordRange string
-
-	// Match score configuration
-	MinMatchScore int
-	MaxMatchScore int
-
-	// Default values for fallbacks
-	DefaultStrengthsMsg string
-	DefaultWeaknessMsg  string
-	DefaultHighlightMsg string
-	DefaultFeedbackMsg  string
-
 	// Advanced generation parameters
-	MaxOutputTokens   int32
-	TopP              *float32
-	TopK              *float32
-	SystemInstruction string
+	MaxOutputTokens int32
+	TopP            *float32
+	TopK            *float32
+
+	// EmbeddingModel is used when the provider serves embeddings
+	EmbeddingModel string
 }
 
 // NewConfig creates a new Config instance with the provided API key and optional parameters.
@@ -71,31 +60,29 @@ func NewConfig(cfg *config.Settings) *Config {
 		// Response configuration
 		ResponseMIMEType: "application/json",
 
-		// Cover letter configuration
-		DefaultWordRange: "150-250",
+		// Advanced generation parameters
+		MaxOutputTokens: 6000,
+		TopP:            floatPtr(0.9),
+		TopK:            floatPtr(40),
 
-		// Match score configuration
-		MinMatchScore: 0,
-		MaxMatchScore: 100,
+		EmbeddingModel: embeddingModel(cfg),
+	}
+}
 
-		// Default fallback messages
-		DefaultStrengthsMsg: "No specific strengths identified",
-		DefaultWeaknessMsg:  "No specific weaknesses identified",
-		DefaultHighlightMsg: "No specific highlights identified",
-		DefaultFeedbackMsg:  "Unable to provide detailed feedback at this time.",
+// DefaultEmbeddingModel is used when AI_EMBEDDING_MODEL is not set
+const DefaultEmbeddingModel = "text-embedding-004"
 
-		// Advanced generation parameters
-		MaxOutputTokens:   6000,
-		TopP:              floatPtr(0.9),
-		TopK:              floatPtr(40),
-		SystemInstruction: "You are a professional career advisor and expert writer. Always provide helpful, accurate, and constructive feedback. IMPORTANT: For job matching, use experience-based evaluation - candidates with 2+ years experience should be evaluated primarily on work history and practical skills, with education as secondary. Entry-level candidates (<2 years) should be evaluated with education carrying more weight. BE MODERATELY LENIENT: Value similar and transferable skills, not just exact matches. Award modest bonuses for related technologies and cross-domain experience. When responding with JSON, output ONLY valid JSON without any preamble, explanation, or additional text. Do not include phrases like 'Here is the JSON' or any other text before or after the JSON object.",
+func embeddingModel(cfg *config.Settings) string {
+	if cfg.AIEmbeddingModel != "" {
+		return cfg.AIEmbeddingModel
 	}
+	return DefaultEmbeddingModel
 }
 
 // GetModelForTask returns the appropriate model for the given task type
 func (c *Config) GetModelForTask(taskType string) string {
 	switch models.AITaskType(taskType) {
-	case models.TaskTypeCVParsing:
+	case models.TaskTypeCVParsing, models.TaskTypeJobExtraction:
 		if c.ModelCVParsing != "" {
 			return c.ModelCVParsing
 		}
@@ -103,11 +90,11 @@ func (c *Config) GetModelForTask(taskType string) string {
 		if c.ModelJobAnalysis != "" {
 			return c.ModelJobAnalysis
 		}
-	case models.TaskTypeCoverLetter:
+	case models.TaskTypeCoverLetter, models.TaskTypeEmail, models.TaskTypeLearningPlan, models.TaskTypeSectionRewrite:
 		if c.ModelCoverLetter != "" {
 			return c.ModelCoverLetter
 		}
-	case models.TaskTypeCVGeneration:
+	case models.TaskTypeCVGeneration, models.TaskTypeInterviewPrep:
 		if c.ModelCoverLetter != "" {
 			return c.ModelCoverLetter
 		}
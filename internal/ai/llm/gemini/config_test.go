@@ -104,10 +104,7 @@ func TestNewConfig(t *testing.T) {
 	assert.Equal(t, 3, result.MaxRetries)
 	assert.Equal(t, 1, result.BaseRetryDelay)
 	assert.Equal(t, 30, result.MaxRetryDelay)
-	assert.Equal(t, 0, result.MinMatchScore)
-	assert.Equal(t, 100, result.MaxMatchScore)
 	assert.Equal(t, "application/json", result.ResponseMIMEType)
-	assert.Equal(t, "150-250", result.DefaultWordRange)
 
 	// Check that float pointers are set correctly
 	assert.Equal(t, int32(6000), result.MaxOutputTokens)
@@ -0,0 +1,51 @@
+package gemini
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/genai"
+)
+
+// EmbeddingModel implements the llm.Embedder interface.
+func (g *Gemini) EmbeddingModel() string {
+	return "gemini/" + g.cfg.EmbeddingModel
+}
+
+// Embed implements the llm.Embedder interface using the embedContent API.
+func (g *Gemini) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return [][]float32{}, nil
+	}
+
+	contents := make([]*genai.Content, len(texts))
+	for i, text := range texts {
+		contents[i] = genai.NewContentFromText(text, genai.RoleUser)
+	}
+
+	var resp *genai.EmbedContentResponse
+	_, err := g.executeWithRetry(ctx, func() (string, error) {
+		var callErr error
+		resp, callErr = g.client.Models.EmbedContent(ctx, g.cfg.EmbeddingModel, contents, nil)
+		if callErr != nil {
+			return "", fmt.Errorf("embed content error: %w", callErr)
+		}
+		return "", nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp == nil || len(resp.Embeddings) != len(texts) {
+		return nil, ErrEmptyResponse
+	}
+
+	vectors := make([][]float32, len(texts))
+	for i, embedding := range resp.Embeddings {
+		if embedding == nil || len(embedding.Values) == 0 {
+			return nil, ErrEmptyResponse
+		}
+		vectors[i] = embedding.Values
+	}
+	return vectors, nil
+}
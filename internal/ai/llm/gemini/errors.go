@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	commonerrors "github.com/benidevo/vega/internal/common/errors"
+	"google.golang.org/genai"
 )
 
 var (
@@ -25,11 +26,18 @@ var (
 	// Generation errors
 	ErrCoverLetterGenFailed = commonerrors.New("cover letter generation failed")
 	ErrCVGenFailed          = commonerrors.New("CV generation failed")
+	ErrCVParsingFailed      = commonerrors.New("CV parsing failed")
 	ErrMatchAnalysisFailed  = commonerrors.New("job match analysis failed")
+	ErrInterviewPrepFailed  = commonerrors.New("interview preparation failed")
+	ErrEmailGenFailed       = commonerrors.New("email generation failed")
+	ErrLearningPlanFailed   = commonerrors.New("learning plan generation failed")
+	ErrJobExtractionFailed  = commonerrors.New("job extraction failed")
+	ErrSectionRewriteFailed = commonerrors.New("section rewrite failed")
 
 	// Technical/Infrastructure errors
 	ErrClientInitFailed   = commonerrors.New("failed to initialize Gemini client")
 	ErrMaxRetriesExceeded = commonerrors.New("maximum retry attempts exceeded")
+	ErrStreamInterrupted  = commonerrors.New("stream interrupted after output started")
 )
 
 // WrapError wraps the given innerErr with the provided sentinelErr.
@@ -72,29 +80,54 @@ func NewGeminiError(code int, message string, err error) *GeminiError {
 
 // IsRetryableError determines whether the provided error is considered retryable.
 //
-// It checks if the error is a GeminiError with specific HTTP status codes (429, 500, 502, 503, 504)
-// that typically warrant a retry. Also, it checks for sentinel errors such as
-// ErrServiceUnavailable, ErrRateLimitExceeded, and ErrRequestTimeout, which also indicate
-// retryable conditions. Returns true if the error is retryable, false otherwise.
+// It checks if the error is a GeminiError or genai.APIError with specific HTTP status
+// codes (429, 500, 502, 503, 504) that typically warrant a retry. Also, it checks for
+// sentinel errors such as ErrServiceUnavailable, ErrRateLimitExceeded, and
+// ErrRequestTimeout, which also indicate retryable conditions. Errors wrapped with
+// WrapError are inspected down to their inner error, except for ErrStreamInterrupted
+// since retrying would repeat output already delivered. Returns true if the error is
+// retryable, false otherwise.
 func IsRetryableError(err error) bool {
 	if err == nil {
 		return false
 	}
 
 	var geminiErr *GeminiError
-	if errors.As(err, &geminiErr) {
-		switch geminiErr.Code {
-		case http.StatusTooManyRequests,
-			http.StatusInternalServerError,
-			http.StatusBadGateway,
-			http.StatusServiceUnavailable,
-			http.StatusGatewayTimeout:
-			return true
-		}
+	if errors.As(err, &geminiErr) && isRetryableStatus(geminiErr.Code) {
+		return true
+	}
+
+	var apiErr genai.APIError
+	if errors.As(err, &apiErr) && isRetryableStatus(apiErr.Code) {
+		return true
 	}
 
 	sentinelErr := GetSentinelError(err)
-	return sentinelErr == ErrServiceUnavailable ||
+	if sentinelErr == ErrServiceUnavailable ||
 		sentinelErr == ErrRateLimitExceeded ||
-		sentinelErr == ErrRequestTimeout
+		sentinelErr == ErrRequestTimeout {
+		return true
+	}
+	if sentinelErr == ErrStreamInterrupted {
+		return false
+	}
+
+	var wrapped *commonerrors.RepositoryError
+	if errors.As(err, &wrapped) && wrapped.InnerError != nil {
+		return IsRetryableError(wrapped.InnerError)
+	}
+
+	return false
+}
+
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
 }
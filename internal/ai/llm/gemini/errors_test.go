@@ -2,9 +2,11 @@ package gemini
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/genai"
 )
 
 func TestGeminiError_Error(t *testing.T) {
@@ -181,6 +183,26 @@ func TestIsRetryableError(t *testing.T) {
 			err:      NewGeminiError(400, "Bad request", nil),
 			expected: false,
 		},
+		{
+			name:     "genai API error 429 is retryable",
+			err:      fmt.Errorf("generate content error: %w", genai.APIError{Code: 429, Status: "RESOURCE_EXHAUSTED"}),
+			expected: true,
+		},
+		{
+			name:     "genai API error 400 is not retryable",
+			err:      fmt.Errorf("generate content error: %w", genai.APIError{Code: 400, Status: "INVALID_ARGUMENT"}),
+			expected: false,
+		},
+		{
+			name:     "exhausted retries inside generation error are retryable",
+			err:      WrapError(ErrCoverLetterGenFailed, WrapError(ErrMaxRetriesExceeded, NewGeminiError(503, "Service unavailable", nil))),
+			expected: true,
+		},
+		{
+			name:     "generation error wrapping bad request is not retryable",
+			err:      WrapError(ErrCoverLetterGenFailed, WrapError(ErrMaxRetriesExceeded, NewGeminiError(400, "Bad request", nil))),
+			expected: false,
+		},
 		{
 			name:     "generic error is not retryable",
 			err:      errors.New("some random error"),
@@ -0,0 +1,70 @@
+package gemini
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/genai"
+
+	"github.com/benidevo/vega/internal/ai/llm"
+)
+
+// GenerateStream implements the llm.StreamingProvider interface.
+// Cover letters and CVs are streamed; other response types are short and are
+// generated in a single call.
+func (g *Gemini) GenerateStream(ctx context.Context, request llm.GenerateRequest, onChunk llm.StreamHandler) (llm.GenerateResponse, error) {
+	start := time.Now()
+
+	switch request.ResponseType {
+	case llm.ResponseTypeCoverLetter:
+		return g.streamStructured(ctx, request, ErrCoverLetterGenFailed, onChunk, start)
+	case llm.ResponseTypeCV:
+		return g.streamStructured(ctx, request, ErrCVGenFailed, onChunk, start)
+	default:
+		return g.Generate(ctx, request)
+	}
+}
+
+// streamContent streams a generation and returns the concatenated text.
+// Usage metadata is taken from the last chunk that reports it.
+func (g *Gemini) streamContent(ctx context.Context, model, prompt string, config *genai.GenerateContentConfig, onChunk llm.StreamHandler) (string, tokenUsage, error) {
+	var usage tokenUsage
+	result, err := g.executeWithRetry(ctx, func() (string, error) {
+		var content strings.Builder
+		for resp, err := range g.client.Models.GenerateContentStream(ctx, model, genai.Text(prompt), config) {
+			if err != nil {
+				// Partial output has already been delivered, so retrying
+				// would duplicate it
+				if content.Len() > 0 {
+					return "", WrapError(ErrStreamInterrupted, err)
+				}
+				return "", fmt.Errorf("generate content error: %w", err)
+			}
+			if resp.UsageMetadata != nil {
+				usage = tokenUsageFromResponse(resp)
+			}
+			if len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil {
+				continue
+			}
+
+			for _, part := range resp.Candidates[0].Content.Parts {
+				if part.Text == "" || part.Thought {
+					continue
+				}
+				content.WriteString(part.Text)
+				if err := onChunk(part.Text); err != nil {
+					return "", WrapError(ErrStreamInterrupted, err)
+				}
+			}
+		}
+
+		if content.Len() == 0 {
+			return "", fmt.Errorf("no content in response")
+		}
+		return content.String(), nil
+	})
+
+	return result, usage, err
+}
@@ -0,0 +1,226 @@
+package gemini
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/genai"
+
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/llm/structured"
+)
+
+// generateStructured generates a response for a task whose prompt, schema and
+// parsing are shared with the other providers through the structured
+// package. A failed call is reported as failedErr.
+func (g *Gemini) generateStructured(ctx context.Context, request llm.GenerateRequest, failedErr error, start time.Time) (llm.GenerateResponse, error) {
+	task, err := g.tasks.BuildTask(request.ResponseType, request.Prompt)
+	if err != nil {
+		return llm.GenerateResponse{}, err
+	}
+
+	model := g.cfg.GetModelForTask(task.TaskType.String())
+	config := g.contentConfig(&task)
+
+	var usage tokenUsage
+	result, err := g.executeWithRetry(ctx, func() (string, error) {
+		resp, err := g.client.Models.GenerateContent(ctx, model, genai.Text(task.UserPrompt), config)
+		if err != nil {
+			return "", fmt.Errorf("generate content error: %w", err)
+		}
+		usage = tokenUsageFromResponse(resp)
+
+		return responseText(resp)
+	})
+	if err != nil {
+		return llm.GenerateResponse{}, WrapError(failedErr, err)
+	}
+
+	return g.structuredResponse(request.ResponseType, task, model, result, usage, start)
+}
+
+// streamStructured is generateStructured with the output passed to onChunk
+// as it arrives.
+func (g *Gemini) streamStructured(ctx context.Context, request llm.GenerateRequest, failedErr error, onChunk llm.StreamHandler, start time.Time) (llm.GenerateResponse, error) {
+	task, err := g.tasks.BuildTask(request.ResponseType, request.Prompt)
+	if err != nil {
+		return llm.GenerateResponse{}, err
+	}
+
+	model := g.cfg.GetModelForTask(task.TaskType.String())
+	result, usage, err := g.streamContent(ctx, model, task.UserPrompt, g.contentConfig(&task), onChunk)
+	if err != nil {
+		return llm.GenerateResponse{}, WrapError(failedErr, err)
+	}
+
+	response, err := g.structuredResponse(request.ResponseType, task, model, result, usage, start)
+	if err != nil {
+		return llm.GenerateResponse{}, err
+	}
+	response.Metadata["streamed"] = true
+	return response, nil
+}
+
+// contentConfig returns the generation settings of a task
+func (g *Gemini) contentConfig(task *structured.Task) *genai.GenerateContentConfig {
+	return &genai.GenerateContentConfig{
+		Temperature:       &task.Temperature,
+		ResponseMIMEType:  g.cfg.ResponseMIMEType,
+		ResponseSchema:    toGenaiSchema(task.Schema),
+		MaxOutputTokens:   g.cfg.MaxOutputTokens,
+		TopP:              g.cfg.TopP,
+		TopK:              g.cfg.TopK,
+		SystemInstruction: textContent(task.SystemInstruction),
+	}
+}
+
+// structuredResponse parses the output of a task into a response
+func (g *Gemini) structuredResponse(responseType llm.ResponseType, task structured.Task, model, result string, usage tokenUsage, start time.Time) (llm.GenerateResponse, error) {
+	data, err := g.tasks.Parse(responseType, result)
+	if err != nil {
+		return llm.GenerateResponse{}, err
+	}
+
+	return llm.GenerateResponse{
+		Data:             data,
+		Duration:         time.Since(start),
+		Tokens:           usage.total,
+		PromptTokens:     usage.prompt,
+		CompletionTokens: usage.completion,
+		Metadata: map[string]any{
+			"temperature": task.Temperature,
+			"enhanced":    task.Enhanced,
+			"model":       model,
+			"task_type":   task.TaskType.String(),
+		},
+	}, nil
+}
+
+// responseText joins the text parts of the first candidate of a response
+func responseText(resp *genai.GenerateContentResponse) (string, error) {
+	if len(resp.Candidates) == 0 {
+		return "", fmt.Errorf("no candidates in response")
+	}
+
+	candidate := resp.Candidates[0]
+	if candidate.Content == nil || len(candidate.Content.Parts) == 0 {
+		return "", fmt.Errorf("no content in response candidate")
+	}
+
+	var text strings.Builder
+	for _, part := range candidate.Content.Parts {
+		text.WriteString(part.Text)
+	}
+	return text.String(), nil
+}
+
+// textContent wraps a system instruction for a request, or returns nil for
+// an empty one
+func textContent(text string) *genai.Content {
+	if text == "" {
+		return nil
+	}
+	return genai.Text(text)[0]
+}
+
+// toGenaiSchema converts a shared JSON schema to Gemini's schema type.
+//
+// Gemini orders properties alphabetically unless told otherwise, so required
+// properties come first, in the order the schema lists them, followed by the
+// rest. Gemini rejects empty enum values, so an enum allowing the empty
+// string is listed in the description instead.
+func toGenaiSchema(schema structured.Schema) *genai.Schema {
+	if schema == nil {
+		return nil
+	}
+
+	result := &genai.Schema{}
+	if value, ok := schema["type"].(string); ok {
+		result.Type = genai.Type(strings.ToUpper(value))
+	}
+	if value, ok := schema["description"].(string); ok {
+		result.Description = value
+	}
+	if value, ok := schema["required"].([]string); ok {
+		result.Required = value
+	}
+	if value, ok := schema["minimum"]; ok {
+		result.Minimum = schemaNumber(value)
+	}
+	if value, ok := schema["maximum"]; ok {
+		result.Maximum = schemaNumber(value)
+	}
+	if items, ok := schema["items"].(structured.Schema); ok {
+		result.Items = toGenaiSchema(items)
+	}
+
+	if enum, ok := schema["enum"].([]string); ok {
+		var values []string
+		allowsEmpty := false
+		for _, value := range enum {
+			if value == "" {
+				allowsEmpty = true
+				continue
+			}
+			values = append(values, value)
+		}
+		if allowsEmpty {
+			result.Description = strings.TrimSpace(result.Description + ": " + strings.Join(values, ", "))
+		} else {
+			result.Enum = values
+		}
+	}
+
+	if properties, ok := schema["properties"].(structured.Schema); ok {
+		result.Properties = make(map[string]*genai.Schema, len(properties))
+		for name, property := range properties {
+			if property, ok := property.(structured.Schema); ok {
+				result.Properties[name] = toGenaiSchema(property)
+			}
+		}
+		result.PropertyOrdering = propertyOrdering(result.Properties, result.Required)
+	}
+
+	return result
+}
+
+// propertyOrdering lists the required properties in order, then the others
+// alphabetically
+func propertyOrdering(properties map[string]*genai.Schema, required []string) []string {
+	ordering := make([]string, 0, len(properties))
+	seen := make(map[string]bool, len(properties))
+	for _, name := range required {
+		if _, ok := properties[name]; ok && !seen[name] {
+			ordering = append(ordering, name)
+			seen[name] = true
+		}
+	}
+
+	var rest []string
+	for name := range properties {
+		if !seen[name] {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	return append(ordering, rest...)
+}
+
+// schemaNumber converts a numeric schema bound to the pointer Gemini expects
+func schemaNumber(value any) *float64 {
+	var number float64
+	switch v := value.(type) {
+	case int:
+		number = float64(v)
+	case float64:
+		number = v
+	case float32:
+		number = float64(v)
+	default:
+		return nil
+	}
+	return &number
+}
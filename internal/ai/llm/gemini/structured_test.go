@@ -0,0 +1,91 @@
+package gemini
+
+import (
+	"testing"
+
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/llm/structured"
+	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genai"
+)
+
+func TestToGenaiSchema(t *testing.T) {
+	schema := toGenaiSchema(structured.Schema{
+		"type": "object",
+		"properties": structured.Schema{
+			"reason": structured.Schema{"type": "string", "description": "Why"},
+			"score":  structured.Schema{"type": "integer", "minimum": 0, "maximum": 100},
+			"kind":   structured.Schema{"type": "string", "enum": []string{"a", "b"}},
+			"level":  structured.Schema{"type": "string", "description": "Level, empty if not stated", "enum": []string{"junior", "senior", ""}},
+			"tags":   structured.Schema{"type": "array", "items": structured.Schema{"type": "string"}},
+		},
+		"required": []string{"score", "kind"},
+	})
+
+	assert.Equal(t, genai.TypeObject, schema.Type)
+	assert.Equal(t, []string{"score", "kind"}, schema.Required)
+	assert.Equal(t, []string{"score", "kind", "level", "reason", "tags"}, schema.PropertyOrdering)
+
+	score := schema.Properties["score"]
+	assert.Equal(t, genai.TypeInteger, score.Type)
+	require.NotNil(t, score.Maximum)
+	assert.Equal(t, 100.0, *score.Maximum)
+
+	assert.Equal(t, []string{"a", "b"}, schema.Properties["kind"].Enum)
+	assert.Empty(t, schema.Properties["level"].Enum)
+	assert.Equal(t, "Level, empty if not stated: junior, senior", schema.Properties["level"].Description)
+	assert.Equal(t, genai.TypeString, schema.Properties["tags"].Items.Type)
+}
+
+func TestToGenaiSchema_SharedSchemas(t *testing.T) {
+	tasks := structured.NewConfig()
+
+	for _, responseType := range []llm.ResponseType{
+		llm.ResponseTypeCoverLetter,
+		llm.ResponseTypeMatchResult,
+		llm.ResponseTypeCVParsing,
+		llm.ResponseTypeCV,
+		llm.ResponseTypeInterviewPrep,
+		llm.ResponseTypeEmail,
+		llm.ResponseTypeLearningPlan,
+		llm.ResponseTypeJobExtraction,
+		llm.ResponseTypeSectionRewrite,
+	} {
+		t.Run(string(responseType), func(t *testing.T) {
+			task, err := tasks.BuildTask(responseType, models.Prompt{})
+			require.NoError(t, err)
+
+			schema := toGenaiSchema(task.Schema)
+			assert.Equal(t, genai.TypeObject, schema.Type)
+			assert.Len(t, schema.PropertyOrdering, len(schema.Properties))
+			for _, name := range schema.Required {
+				assert.Contains(t, schema.Properties, name)
+			}
+		})
+	}
+}
+
+func TestToGenaiSchema_CVSchemas(t *testing.T) {
+	tasks := structured.NewConfig()
+
+	parsing := toGenaiSchema(tasks.CVParsingSchema())
+	assert.Equal(t, genai.TypeBoolean, parsing.Properties["isValid"].Type)
+	assert.Equal(t, "isValid", parsing.PropertyOrdering[0])
+	personalInfo := parsing.Properties["personalInfo"]
+	assert.Equal(t, genai.TypeObject, personalInfo.Type)
+	assert.Contains(t, personalInfo.Properties, "firstName")
+	assert.Contains(t, personalInfo.Properties, "email")
+
+	generation := toGenaiSchema(tasks.CVGenerationSchema())
+	workExperience := generation.Properties["workExperience"]
+	assert.Equal(t, genai.TypeArray, workExperience.Type)
+	require.NotNil(t, workExperience.Items)
+	assert.Equal(t, genai.TypeObject, workExperience.Items.Type)
+	assert.Contains(t, workExperience.Items.Properties, "location")
+
+	match := toGenaiSchema(tasks.MatchAnalysisSchema())
+	assert.Equal(t, genai.TypeInteger, match.Properties["matchScore"].Type)
+	assert.Equal(t, []string{"matchScore", "strengths", "weaknesses", "highlights", "feedback"}, match.PropertyOrdering)
+}
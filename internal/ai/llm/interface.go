@@ -14,6 +14,38 @@ type Provider interface {
 	Generate(ctx context.Context, request GenerateRequest) (GenerateResponse, error)
 }
 
+// StreamHandler receives raw output text as the provider produces it.
+// Returning an error aborts the generation.
+type StreamHandler func(chunk string) error
+
+// StreamingProvider is implemented by providers that can emit their output
+// incrementally. The final response is parsed and returned exactly as from
+// Generate once the stream completes.
+type StreamingProvider interface {
+	Provider
+	GenerateStream(ctx context.Context, request GenerateRequest, onChunk StreamHandler) (GenerateResponse, error)
+}
+
+// GenerateStream streams the request when the provider supports it. Otherwise
+// it falls back to a single Generate call and onChunk is never invoked.
+func GenerateStream(ctx context.Context, provider Provider, request GenerateRequest, onChunk StreamHandler) (GenerateResponse, error) {
+	if streamer, ok := provider.(StreamingProvider); ok {
+		return streamer.GenerateStream(ctx, request, onChunk)
+	}
+	return provider.Generate(ctx, request)
+}
+
+// Embedder is implemented by providers that can turn text into embedding
+// vectors for similarity search. It is optional; providers without it are
+// replaced by the local embedder.
+type Embedder interface {
+	// Embed returns one vector per text, in the same order.
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+	// EmbeddingModel names the provider and model behind the vectors. Vectors
+	// are only comparable when they come from the same embedding model.
+	EmbeddingModel() string
+}
+
 // GenerateRequest encapsulates all LLM request parameters
 type GenerateRequest struct {
 	Prompt       models.Prompt
@@ -25,16 +57,23 @@ type GenerateRequest struct {
 type ResponseType string
 
 const (
-	ResponseTypeCoverLetter ResponseType = "cover_letter"
-	ResponseTypeMatchResult ResponseType = "match_result"
-	ResponseTypeCVParsing   ResponseType = "cv_parsing"
-	ResponseTypeCV          ResponseType = "cv_generation"
+	ResponseTypeCoverLetter    ResponseType = "cover_letter"
+	ResponseTypeMatchResult    ResponseType = "match_result"
+	ResponseTypeCVParsing      ResponseType = "cv_parsing"
+	ResponseTypeCV             ResponseType = "cv_generation"
+	ResponseTypeInterviewPrep  ResponseType = "interview_prep"
+	ResponseTypeEmail          ResponseType = "email"
+	ResponseTypeLearningPlan   ResponseType = "learning_plan"
+	ResponseTypeJobExtraction  ResponseType = "job_extraction"
+	ResponseTypeSectionRewrite ResponseType = "section_rewrite"
 )
 
 // GenerateResponse wraps the LLM response with metadata
 type GenerateResponse struct {
-	Data     any // Will be CoverLetter, MatchResult, etc
-	Tokens   int
-	Duration time.Duration
-	Metadata map[string]any
+	Data             any // Will be CoverLetter, MatchResult, etc
+	Tokens           int // Total tokens reported by the provider
+	PromptTokens     int
+	CompletionTokens int
+	Duration         time.Duration
+	Metadata         map[string]any
 }
@@ -0,0 +1,125 @@
+package local
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// DefaultDimensions is the vector size of the local embedder.
+const DefaultDimensions = 512
+
+// bigramWeight scales word pairs relative to single words, so that phrases
+// such as "machine learning" count without drowning out the words themselves.
+const bigramWeight = 0.5
+
+// stopWords are left out of the vectors because they appear in almost every
+// job description and profile.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "for": true, "from": true, "has": true, "have": true,
+	"in": true, "is": true, "it": true, "its": true, "of": true, "on": true,
+	"or": true, "our": true, "that": true, "the": true, "their": true, "this": true,
+	"to": true, "we": true, "will": true, "with": true, "you": true, "your": true,
+}
+
+// Embedder turns text into vectors on the server without any provider, by
+// hashing words and word pairs into a fixed number of buckets. It is much
+// weaker than a trained embedding model but is fast, deterministic and works
+// offline.
+type Embedder struct {
+	dimensions int
+}
+
+// New creates a local embedder producing vectors of the given size. A size of
+// zero or less uses DefaultDimensions.
+func New(dimensions int) *Embedder {
+	if dimensions <= 0 {
+		dimensions = DefaultDimensions
+	}
+	return &Embedder{dimensions: dimensions}
+}
+
+// EmbeddingModel implements the llm.Embedder interface.
+func (e *Embedder) EmbeddingModel() string {
+	return fmt.Sprintf("local/hash-%d-v1", e.dimensions)
+}
+
+// Embed implements the llm.Embedder interface.
+func (e *Embedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		vectors[i] = e.embed(text)
+	}
+	return vectors, nil
+}
+
+// embed returns the L2-normalised, log-scaled term frequencies of the words
+// and word pairs in text.
+func (e *Embedder) embed(text string) []float32 {
+	counts := make(map[string]float64)
+	words := tokenize(text)
+	for i, word := range words {
+		counts[word]++
+		if i > 0 {
+			counts[words[i-1]+" "+word] += bigramWeight
+		}
+	}
+
+	vector := make([]float64, e.dimensions)
+	for term, count := range counts {
+		h := fnv.New64a()
+		h.Write([]byte(term))
+		sum := h.Sum64()
+
+		// The top bit decides the sign so that collisions cancel out on
+		// average instead of piling up.
+		weight := 1 + math.Log(count)
+		if sum>>63 == 1 {
+			weight = -weight
+		}
+		vector[sum%uint64(e.dimensions)] += weight
+	}
+
+	var norm float64
+	for _, v := range vector {
+		norm += v * v
+	}
+	norm = math.Sqrt(norm)
+
+	result := make([]float32, e.dimensions)
+	if norm == 0 {
+		return result
+	}
+	for i, v := range vector {
+		result[i] = float32(v / norm)
+	}
+	return result
+}
+
+// tokenize splits text into lower-case words, keeping characters such as "+"
+// and "#" that are part of skill names like C++ and C#.
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#'
+	})
+
+	words := fields[:0]
+	for _, field := range fields {
+		field = strings.TrimLeft(field, "+#")
+		if field == "" || stopWords[field] {
+			continue
+		}
+		if len(field) < 2 && field != "c" && field != "r" {
+			continue
+		}
+		words = append(words, field)
+	}
+	return words
+}
@@ -0,0 +1,55 @@
+package local
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func cosine(a, b []float32) float64 {
+	var dot float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+	}
+	return dot
+}
+
+func TestEmbedder_Embed(t *testing.T) {
+	embedder := New(0)
+	assert.Equal(t, "local/hash-512-v1", embedder.EmbeddingModel())
+
+	vectors, err := embedder.Embed(context.Background(), []string{
+		"Senior Go engineer building Kubernetes platform services",
+		"Backend engineer: Go, Kubernetes and Postgres platform work",
+		"Pastry chef for a busy French bakery",
+		"",
+	})
+	require.NoError(t, err)
+	require.Len(t, vectors, 4)
+
+	var norm float64
+	for _, v := range vectors[0] {
+		norm += float64(v) * float64(v)
+	}
+	assert.InDelta(t, 1, math.Sqrt(norm), 1e-6, "vectors should be normalised")
+
+	related := cosine(vectors[0], vectors[1])
+	unrelated := cosine(vectors[0], vectors[2])
+	assert.Greater(t, related, unrelated)
+	assert.Greater(t, related, 0.3)
+	assert.Zero(t, cosine(vectors[0], vectors[3]), "empty text should embed to a zero vector")
+
+	again, err := embedder.Embed(context.Background(), []string{"Senior Go engineer building Kubernetes platform services"})
+	require.NoError(t, err)
+	assert.Equal(t, vectors[0], again[0], "embeddings should be deterministic")
+}
+
+func TestTokenize(t *testing.T) {
+	assert.Equal(t,
+		[]string{"c++", "c#", "go", "r", "developers", "node", "js"},
+		tokenize("C++, C# and Go (or R) developers; Node.js"),
+	)
+}
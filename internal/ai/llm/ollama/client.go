@@ -0,0 +1,285 @@
+package ollama
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/llm/structured"
+)
+
+// Ollama is a client for a local or remote Ollama server. Structured output is
+// enforced through the JSON schema passed in the request's format field.
+type Ollama struct {
+	httpClient *http.Client
+	cfg        *Config
+	tasks      *structured.Config
+}
+
+// New creates a new Ollama client from the given configuration.
+func New(cfg *Config) (*Ollama, error) {
+	if cfg.BaseURL == "" {
+		return nil, ErrMissingBaseURL
+	}
+
+	return &Ollama{
+		httpClient: &http.Client{Timeout: cfg.RequestTimeout},
+		cfg:        cfg,
+		tasks:      structured.NewConfig(),
+	}, nil
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatOptions struct {
+	Temperature float32  `json:"temperature"`
+	TopP        *float32 `json:"top_p,omitempty"`
+	NumPredict  int      `json:"num_predict,omitempty"`
+}
+
+type chatRequest struct {
+	Model     string            `json:"model"`
+	Messages  []chatMessage     `json:"messages"`
+	Stream    bool              `json:"stream"`
+	Format    structured.Schema `json:"format"`
+	Options   chatOptions       `json:"options"`
+	KeepAlive string            `json:"keep_alive,omitempty"`
+}
+
+type chatResponse struct {
+	Model           string      `json:"model"`
+	Message         chatMessage `json:"message"`
+	Done            bool        `json:"done"`
+	PromptEvalCount int         `json:"prompt_eval_count"`
+	EvalCount       int         `json:"eval_count"`
+}
+
+type tagsResponse struct {
+	Models []struct {
+		Name  string `json:"name"`
+		Model string `json:"model"`
+	} `json:"models"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Generate implements the Provider interface for the Ollama client.
+func (o *Ollama) Generate(ctx context.Context, request llm.GenerateRequest) (llm.GenerateResponse, error) {
+	start := time.Now()
+
+	task, err := o.tasks.BuildTask(request.ResponseType, request.Prompt)
+	if err != nil {
+		return llm.GenerateResponse{}, err
+	}
+
+	model := o.cfg.GetModelForTask(task.TaskType.String())
+	body := o.buildChatRequest(task, model, false)
+
+	var resp chatResponse
+	err = o.executeWithRetry(ctx, func() error {
+		return o.doJSON(ctx, http.MethodPost, "/api/chat", body, &resp)
+	})
+	if err != nil {
+		return llm.GenerateResponse{}, err
+	}
+
+	if resp.Message.Content == "" {
+		return llm.GenerateResponse{}, ErrEmptyResponse
+	}
+
+	data, err := o.tasks.Parse(request.ResponseType, resp.Message.Content)
+	if err != nil {
+		return llm.GenerateResponse{}, err
+	}
+
+	return llm.GenerateResponse{
+		Data:             data,
+		Duration:         time.Since(start),
+		Tokens:           resp.PromptEvalCount + resp.EvalCount,
+		PromptTokens:     resp.PromptEvalCount,
+		CompletionTokens: resp.EvalCount,
+		Metadata:         taskMetadata(task, model),
+	}, nil
+}
+
+func (o *Ollama) buildChatRequest(task structured.Task, model string, stream bool) chatRequest {
+	return chatRequest{
+		Model: model,
+		Messages: []chatMessage{
+			{Role: "system", Content: task.SystemInstruction},
+			{Role: "user", Content: task.UserPrompt},
+		},
+		Stream: stream,
+		Format: task.Schema,
+		Options: chatOptions{
+			Temperature: task.Temperature,
+			TopP:        o.cfg.TopP,
+			NumPredict:  o.cfg.MaxOutputTokens,
+		},
+		KeepAlive: o.cfg.KeepAlive,
+	}
+}
+
+func taskMetadata(task structured.Task, model string) map[string]any {
+	return map[string]any{
+		"temperature": task.Temperature,
+		"enhanced":    task.Enhanced,
+		"model":       model,
+		"task_type":   task.TaskType.String(),
+	}
+}
+
+// ListModels returns the names of the models installed on the Ollama server.
+func (o *Ollama) ListModels(ctx context.Context) ([]string, error) {
+	var resp tagsResponse
+	if err := o.doJSON(ctx, http.MethodGet, "/api/tags", nil, &resp); err != nil {
+		return nil, WrapError(ErrListModelsFailed, err)
+	}
+
+	names := make([]string, 0, len(resp.Models))
+	for _, m := range resp.Models {
+		names = append(names, m.Name)
+	}
+	return names, nil
+}
+
+// ValidateModels checks that every configured per-task model is installed.
+// It returns ErrModelNotInstalled listing the missing models, if any.
+func (o *Ollama) ValidateModels(ctx context.Context) error {
+	installed, err := o.ListModels(ctx)
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	for _, model := range o.cfg.ConfiguredModels() {
+		if !isInstalled(model, installed) {
+			missing = append(missing, model)
+		}
+	}
+
+	if len(missing) > 0 {
+		return WrapError(ErrModelNotInstalled, fmt.Errorf("missing %s (installed: %s)", strings.Join(missing, ", "), strings.Join(installed, ", ")))
+	}
+	return nil
+}
+
+// isInstalled matches a configured model against installed names, treating
+// an untagged name as the ":latest" tag the way the Ollama CLI does.
+func isInstalled(model string, installed []string) bool {
+	if !strings.Contains(model, ":") {
+		model += ":latest"
+	}
+	for _, name := range installed {
+		if name == model {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *Ollama) doJSON(ctx context.Context, method, path string, body any, out any) error {
+	httpResp, err := o.do(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return WrapError(structured.ErrResponseParseFailed, err)
+	}
+	return nil
+}
+
+// do sends the request and returns the response once a successful status has
+// been received. The caller must close the body.
+func (o *Ollama) do(ctx context.Context, method, path string, body any) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, o.cfg.BaseURL+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	httpResp, err := o.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		defer httpResp.Body.Close()
+		message := http.StatusText(httpResp.StatusCode)
+		respBody, _ := io.ReadAll(httpResp.Body)
+		var errResp errorResponse
+		if json.Unmarshal(respBody, &errResp) == nil && errResp.Error != "" {
+			message = errResp.Error
+		}
+		return nil, &APIError{StatusCode: httpResp.StatusCode, Message: message}
+	}
+
+	return httpResp, nil
+}
+
+func (o *Ollama) executeWithRetry(ctx context.Context, operation func() error) error {
+	maxRetries := o.cfg.MaxRetries
+	baseDelay := time.Duration(o.cfg.BaseRetryDelay) * time.Second
+
+	var lastErr error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			delay := time.Duration(math.Pow(2, float64(attempt-1))) * baseDelay
+			maxDelay := time.Duration(o.cfg.MaxRetryDelay) * time.Second
+			if delay > maxDelay {
+				delay = maxDelay
+			}
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+
+		err := operation()
+		if err == nil {
+			return nil
+		}
+
+		lastErr = err
+		if !IsRetryableError(err) || attempt == maxRetries {
+			break
+		}
+	}
+
+	if IsRetryableError(lastErr) {
+		return WrapError(ErrMaxRetriesExceeded, lastErr)
+	}
+	return WrapError(ErrRequestFailed, lastErr)
+}
@@ -0,0 +1,196 @@
+package ollama
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T, installed []string, content string, captured *chatRequest) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/tags", func(w http.ResponseWriter, r *http.Request) {
+		list := make([]map[string]string, 0, len(installed))
+		for _, name := range installed {
+			list = append(list, map[string]string{"name": name, "model": name})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"models": list})
+	})
+	mux.HandleFunc("/api/chat", func(w http.ResponseWriter, r *http.Request) {
+		if captured != nil {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(captured))
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"model":             "llama3.1:8b",
+			"message":           map[string]string{"role": "assistant", "content": content},
+			"done":              true,
+			"prompt_eval_count": 120,
+			"eval_count":        30,
+		})
+	})
+	return httptest.NewServer(mux)
+}
+
+func newTestClient(t *testing.T, baseURL string) *Ollama {
+	t.Helper()
+	client, err := New(&Config{
+		BaseURL:        baseURL,
+		Model:          "llama3.1:8b",
+		ModelCVParsing: "qwen2.5",
+	})
+	require.NoError(t, err)
+	return client
+}
+
+func TestOllama_Generate(t *testing.T) {
+	t.Run("match result uses format schema", func(t *testing.T) {
+		var captured chatRequest
+		server := newTestServer(t, nil, `{"matchScore": 64, "strengths": ["Go"], "weaknesses": ["K8s"], "highlights": ["OSS"], "feedback": "Solid"}`, &captured)
+		defer server.Close()
+
+		client := newTestClient(t, server.URL)
+		prompt := models.NewPrompt("Analyze", models.Request{ApplicantName: "Jane", ApplicantProfile: "Go dev", JobDescription: "Backend"}, false)
+		resp, err := client.Generate(context.Background(), llm.GenerateRequest{Prompt: *prompt, ResponseType: llm.ResponseTypeMatchResult})
+
+		require.NoError(t, err)
+		result, ok := resp.Data.(models.MatchResult)
+		require.True(t, ok)
+		assert.Equal(t, 64, result.MatchScore)
+		assert.Equal(t, 150, resp.Tokens)
+
+		assert.False(t, captured.Stream)
+		assert.Equal(t, "llama3.1:8b", captured.Model)
+		assert.Equal(t, "object", captured.Format["type"])
+		assert.Contains(t, captured.Format["required"], "matchScore")
+		assert.InDelta(t, 0.2, captured.Options.Temperature, 0.001)
+	})
+
+	t.Run("cv parsing uses task model", func(t *testing.T) {
+		var captured chatRequest
+		server := newTestServer(t, nil, `{"isValid": true, "personalInfo": {"firstName": "Jane", "lastName": "Doe"}}`, &captured)
+		defer server.Close()
+
+		client := newTestClient(t, server.URL)
+		resp, err := client.Generate(context.Background(), llm.GenerateRequest{
+			Prompt:       *models.NewCVParsingPrompt("Jane Doe, engineer"),
+			ResponseType: llm.ResponseTypeCVParsing,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "qwen2.5", captured.Model)
+		assert.Equal(t, "Doe", resp.Data.(models.CVParsingResult).PersonalInfo.LastName)
+	})
+
+	t.Run("model not found is not retried", func(t *testing.T) {
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": "model 'llama3.1:8b' not found"}`))
+		}))
+		defer server.Close()
+
+		client := newTestClient(t, server.URL)
+		client.cfg.MaxRetries = 2
+		prompt := models.NewPrompt("Write", models.Request{ApplicantName: "Jane", ApplicantProfile: "Go dev", JobDescription: "Backend"}, false)
+		_, err := client.Generate(context.Background(), llm.GenerateRequest{Prompt: *prompt, ResponseType: llm.ResponseTypeCoverLetter})
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrRequestFailed)
+		assert.Contains(t, err.Error(), "not found")
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestOllama_ValidateModels(t *testing.T) {
+	tests := []struct {
+		name        string
+		installed   []string
+		expectError bool
+		missing     string
+	}{
+		{
+			name:      "all models installed",
+			installed: []string{"llama3.1:8b", "qwen2.5:latest"},
+		},
+		{
+			name:        "task model missing",
+			installed:   []string{"llama3.1:8b"},
+			expectError: true,
+			missing:     "qwen2.5",
+		},
+		{
+			name:        "nothing installed",
+			installed:   nil,
+			expectError: true,
+			missing:     "llama3.1:8b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, tt.installed, "", nil)
+			defer server.Close()
+
+			err := newTestClient(t, server.URL).ValidateModels(context.Background())
+
+			if tt.expectError {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrModelNotInstalled)
+				assert.Contains(t, err.Error(), tt.missing)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("server unreachable", func(t *testing.T) {
+		server := newTestServer(t, nil, "", nil)
+		server.Close()
+
+		err := newTestClient(t, server.URL).ValidateModels(context.Background())
+		assert.ErrorIs(t, err, ErrListModelsFailed)
+	})
+}
+
+func TestOllama_GenerateStream(t *testing.T) {
+	var captured chatRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&captured))
+		for _, line := range []string{
+			`{"message":{"role":"assistant","content":"{\"content\": \"Dear "},"done":false}`,
+			`{"message":{"role":"assistant","content":"Team\"}"},"done":false}`,
+			`{"message":{"role":"assistant","content":""},"done":true,"prompt_eval_count":40,"eval_count":12}`,
+		} {
+			_, _ = w.Write([]byte(line + "\n"))
+		}
+	}))
+	defer server.Close()
+
+	var chunks []string
+	resp, err := newTestClient(t, server.URL).GenerateStream(context.Background(), llm.GenerateRequest{
+		Prompt: *models.NewPrompt("Write a cover letter", models.Request{
+			ApplicantName:    "Jane Doe",
+			ApplicantProfile: "Go developer",
+			JobDescription:   "Backend role",
+		}, false),
+		ResponseType: llm.ResponseTypeCoverLetter,
+	}, func(chunk string) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.True(t, captured.Stream)
+	assert.Equal(t, []string{`{"content": "Dear `, `Team"}`}, chunks)
+	assert.Equal(t, "Dear Team", resp.Data.(models.CoverLetter).Content)
+	assert.Equal(t, 52, resp.Tokens)
+	assert.Equal(t, 12, resp.CompletionTokens)
+}
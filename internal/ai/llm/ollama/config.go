@@ -0,0 +1,113 @@
+package ollama
+
+import (
+	"strings"
+	"time"
+
+	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/benidevo/vega/internal/config"
+)
+
+// Config holds the configuration for the Ollama LLM client.
+type Config struct {
+	// BaseURL is the Ollama server address, e.g. "http://localhost:11434".
+	BaseURL string
+	// Model is the default model used when no task-specific model is configured.
+	Model string
+	// Task-specific models
+	ModelCVParsing   string
+	ModelJobAnalysis string
+	ModelCoverLetter string
+
+	// Retry configuration
+	MaxRetries     int
+	BaseRetryDelay int // seconds
+	MaxRetryDelay  int // seconds
+
+	// RequestTimeout bounds a single HTTP request. Local inference can be slow.
+	RequestTimeout time.Duration
+
+	// Advanced generation parameters
+	MaxOutputTokens int
+	TopP            *float32
+	KeepAlive       string
+
+	// EmbeddingModel is used when the provider serves embeddings
+	EmbeddingModel string
+}
+
+// NewConfig creates a new Config from the application settings.
+func NewConfig(cfg *config.Settings) *Config {
+	return &Config{
+		BaseURL:          strings.TrimRight(cfg.OllamaBaseURL, "/"),
+		Model:            cfg.OllamaModel,
+		ModelCVParsing:   cfg.OllamaModelCVParsing,
+		ModelJobAnalysis: cfg.OllamaModelJobAnalysis,
+		ModelCoverLetter: cfg.OllamaModelCoverLetter,
+
+		MaxRetries:     2,
+		BaseRetryDelay: 1,
+		MaxRetryDelay:  10,
+
+		RequestTimeout: 5 * time.Minute,
+
+		MaxOutputTokens: 6000,
+		TopP:            floatPtr(0.9),
+		KeepAlive:       "10m",
+
+		EmbeddingModel: embeddingModel(cfg),
+	}
+}
+
+// DefaultEmbeddingModel is used when AI_EMBEDDING_MODEL is not set
+const DefaultEmbeddingModel = "nomic-embed-text"
+
+func embeddingModel(cfg *config.Settings) string {
+	if cfg.AIEmbeddingModel != "" {
+		return cfg.AIEmbeddingModel
+	}
+	return DefaultEmbeddingModel
+}
+
+// GetModelForTask returns the appropriate model for the given task type
+func (c *Config) GetModelForTask(taskType string) string {
+	switch models.AITaskType(taskType) {
+	case models.TaskTypeCVParsing, models.TaskTypeJobExtraction:
+		if c.ModelCVParsing != "" {
+			return c.ModelCVParsing
+		}
+	case models.TaskTypeJobAnalysis, models.TaskTypeMatchResult:
+		if c.ModelJobAnalysis != "" {
+			return c.ModelJobAnalysis
+		}
+	case models.TaskTypeCoverLetter, models.TaskTypeCVGeneration, models.TaskTypeInterviewPrep, models.TaskTypeEmail, models.TaskTypeLearningPlan, models.TaskTypeSectionRewrite:
+		if c.ModelCoverLetter != "" {
+			return c.ModelCoverLetter
+		}
+	}
+
+	return c.Model
+}
+
+// ConfiguredModels returns the distinct models referenced by the configuration.
+func (c *Config) ConfiguredModels() []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, task := range []models.AITaskType{
+		models.TaskTypeCVParsing,
+		models.TaskTypeJobAnalysis,
+		models.TaskTypeCoverLetter,
+	} {
+		model := c.GetModelForTask(task.String())
+		if model == "" || seen[model] {
+			continue
+		}
+		seen[model] = true
+		result = append(result, model)
+	}
+	return result
+}
+
+func floatPtr(f float32) *float32 {
+	return &f
+}
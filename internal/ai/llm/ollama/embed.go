@@ -0,0 +1,43 @@
+package ollama
+
+import (
+	"context"
+	"net/http"
+)
+
+type embedRequest struct {
+	Model     string   `json:"model"`
+	Input     []string `json:"input"`
+	KeepAlive string   `json:"keep_alive,omitempty"`
+}
+
+type embedResponse struct {
+	Embeddings [][]float32 `json:"embeddings"`
+}
+
+// EmbeddingModel implements the llm.Embedder interface.
+func (o *Ollama) EmbeddingModel() string {
+	return "ollama/" + o.cfg.EmbeddingModel
+}
+
+// Embed implements the llm.Embedder interface using the /api/embed endpoint.
+func (o *Ollama) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return [][]float32{}, nil
+	}
+
+	body := embedRequest{Model: o.cfg.EmbeddingModel, Input: texts, KeepAlive: o.cfg.KeepAlive}
+
+	var resp embedResponse
+	err := o.executeWithRetry(ctx, func() error {
+		return o.doJSON(ctx, http.MethodPost, "/api/embed", body, &resp)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Embeddings) != len(texts) {
+		return nil, ErrEmptyResponse
+	}
+	return resp.Embeddings, nil
+}
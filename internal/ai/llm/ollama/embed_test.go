@@ -0,0 +1,39 @@
+package ollama
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOllama_Embed(t *testing.T) {
+	var captured embedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/embed", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&captured))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"embeddings":[[0.5,0.5],[1,0]]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	client.cfg.EmbeddingModel = "nomic-embed-text"
+
+	vectors, err := client.Embed(context.Background(), []string{"first", "second"})
+
+	require.NoError(t, err)
+	assert.Equal(t, [][]float32{{0.5, 0.5}, {1, 0}}, vectors)
+	assert.Equal(t, "nomic-embed-text", captured.Model)
+	assert.Equal(t, "ollama/nomic-embed-text", client.EmbeddingModel())
+
+	t.Run("fails when a vector is missing", func(t *testing.T) {
+		_, err := client.Embed(context.Background(), []string{"first", "second", "third"})
+		assert.ErrorIs(t, err, ErrEmptyResponse)
+	})
+}
@@ -0,0 +1,94 @@
+package ollama
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/llm/structured"
+)
+
+// GenerateStream implements the llm.StreamingProvider interface using
+// Ollama's newline-delimited JSON streaming. Connection failures are retried
+// like Generate; once output has started arriving the stream is not restarted.
+func (o *Ollama) GenerateStream(ctx context.Context, request llm.GenerateRequest, onChunk llm.StreamHandler) (llm.GenerateResponse, error) {
+	start := time.Now()
+
+	task, err := o.tasks.BuildTask(request.ResponseType, request.Prompt)
+	if err != nil {
+		return llm.GenerateResponse{}, err
+	}
+
+	model := o.cfg.GetModelForTask(task.TaskType.String())
+	body := o.buildChatRequest(task, model, true)
+
+	var final chatResponse
+	var content strings.Builder
+	err = o.executeWithRetry(ctx, func() error {
+		httpResp, err := o.do(ctx, http.MethodPost, "/api/chat", body)
+		if err != nil {
+			return err
+		}
+		defer httpResp.Body.Close()
+
+		scanner := bufio.NewScanner(httpResp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+
+			var event chatResponse
+			if err := json.Unmarshal([]byte(line), &event); err != nil {
+				return WrapError(structured.ErrResponseParseFailed, err)
+			}
+			if event.Message.Content != "" {
+				content.WriteString(event.Message.Content)
+				if err := onChunk(event.Message.Content); err != nil {
+					return WrapError(ErrStreamInterrupted, err)
+				}
+			}
+			if event.Done {
+				final = event
+				break
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			// Partial output has already been delivered, so retrying would
+			// duplicate it
+			if content.Len() > 0 {
+				return WrapError(ErrStreamInterrupted, err)
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return llm.GenerateResponse{}, err
+	}
+
+	if content.Len() == 0 {
+		return llm.GenerateResponse{}, ErrEmptyResponse
+	}
+
+	data, err := o.tasks.Parse(request.ResponseType, content.String())
+	if err != nil {
+		return llm.GenerateResponse{}, err
+	}
+
+	metadata := taskMetadata(task, model)
+	metadata["streamed"] = true
+	return llm.GenerateResponse{
+		Data:             data,
+		Duration:         time.Since(start),
+		Tokens:           final.PromptEvalCount + final.EvalCount,
+		PromptTokens:     final.PromptEvalCount,
+		CompletionTokens: final.EvalCount,
+		Metadata:         metadata,
+	}, nil
+}
@@ -0,0 +1,275 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/llm/structured"
+)
+
+// OpenAI is a client for any server implementing the OpenAI
+// /v1/chat/completions API, including vLLM and llama.cpp.
+type OpenAI struct {
+	httpClient *http.Client
+	cfg        *Config
+	tasks      *structured.Config
+}
+
+// New creates a new OpenAI-compatible client from the given configuration.
+func New(cfg *Config) (*OpenAI, error) {
+	if cfg.BaseURL == "" {
+		return nil, ErrMissingBaseURL
+	}
+
+	return &OpenAI{
+		httpClient: &http.Client{Timeout: cfg.RequestTimeout},
+		cfg:        cfg,
+		tasks:      structured.NewConfig(),
+	}, nil
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type jsonSchemaFormat struct {
+	Name   string            `json:"name"`
+	Schema structured.Schema `json:"schema"`
+	Strict bool              `json:"strict"`
+}
+
+type responseFormat struct {
+	Type       string           `json:"type"`
+	JSONSchema jsonSchemaFormat `json:"json_schema"`
+}
+
+type chatRequest struct {
+	Model          string         `json:"model"`
+	Messages       []chatMessage  `json:"messages"`
+	Temperature    float32        `json:"temperature"`
+	TopP           *float32       `json:"top_p,omitempty"`
+	MaxTokens      int            `json:"max_tokens,omitempty"`
+	ResponseFormat responseFormat `json:"response_format"`
+	Stream         bool           `json:"stream,omitempty"`
+	StreamOptions  *streamOptions `json:"stream_options,omitempty"`
+}
+
+type chatResponse struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Message      chatMessage `json:"message"`
+		FinishReason string      `json:"finish_reason"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+		TotalTokens      int `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+type errorResponse struct {
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Generate implements the Provider interface for OpenAI-compatible endpoints.
+func (o *OpenAI) Generate(ctx context.Context, request llm.GenerateRequest) (llm.GenerateResponse, error) {
+	start := time.Now()
+
+	task, err := o.tasks.BuildTask(request.ResponseType, request.Prompt)
+	if err != nil {
+		return llm.GenerateResponse{}, err
+	}
+
+	model := o.cfg.GetModelForTask(task.TaskType.String())
+	body := o.buildChatRequest(task, model)
+
+	var resp chatResponse
+	err = o.executeWithRetry(ctx, func() error {
+		var callErr error
+		resp, callErr = o.createChatCompletion(ctx, body)
+		return callErr
+	})
+	if err != nil {
+		return llm.GenerateResponse{}, err
+	}
+
+	if len(resp.Choices) == 0 || resp.Choices[0].Message.Content == "" {
+		return llm.GenerateResponse{}, ErrEmptyResponse
+	}
+
+	data, err := o.tasks.Parse(request.ResponseType, resp.Choices[0].Message.Content)
+	if err != nil {
+		return llm.GenerateResponse{}, err
+	}
+
+	return llm.GenerateResponse{
+		Data:             data,
+		Duration:         time.Since(start),
+		Tokens:           resp.Usage.TotalTokens,
+		PromptTokens:     resp.Usage.PromptTokens,
+		CompletionTokens: resp.Usage.CompletionTokens,
+		Metadata:         taskMetadata(task, model),
+	}, nil
+}
+
+func (o *OpenAI) buildChatRequest(task structured.Task, model string) chatRequest {
+	return chatRequest{
+		Model: model,
+		Messages: []chatMessage{
+			{Role: "system", Content: task.SystemInstruction},
+			{Role: "user", Content: task.UserPrompt},
+		},
+		Temperature: task.Temperature,
+		TopP:        o.cfg.TopP,
+		MaxTokens:   o.cfg.MaxOutputTokens,
+		ResponseFormat: responseFormat{
+			Type: "json_schema",
+			JSONSchema: jsonSchemaFormat{
+				Name:   task.SchemaName,
+				Schema: structured.StrictSchema(task.Schema),
+				Strict: true,
+			},
+		},
+	}
+}
+
+func taskMetadata(task structured.Task, model string) map[string]any {
+	return map[string]any{
+		"temperature": task.Temperature,
+		"enhanced":    task.Enhanced,
+		"model":       model,
+		"task_type":   task.TaskType.String(),
+	}
+}
+
+func (o *OpenAI) createChatCompletion(ctx context.Context, body chatRequest) (chatResponse, error) {
+	httpResp, err := o.postChatCompletion(ctx, body)
+	if err != nil {
+		return chatResponse{}, err
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return chatResponse{}, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var resp chatResponse
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return chatResponse{}, WrapError(structured.ErrResponseParseFailed, err)
+	}
+
+	return resp, nil
+}
+
+// postChatCompletion sends the request and returns the response once a
+// successful status has been received. The caller must close the body.
+func (o *OpenAI) postChatCompletion(ctx context.Context, body chatRequest) (*http.Response, error) {
+	return o.post(ctx, "/chat/completions", body)
+}
+
+// post sends body as JSON to path and returns the response once a successful
+// status has been received. The caller must close the body.
+func (o *OpenAI) post(ctx context.Context, path string, body any) (*http.Response, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.cfg.BaseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if o.cfg.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+o.cfg.APIKey)
+	}
+
+	httpResp, err := o.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		defer httpResp.Body.Close()
+		message := http.StatusText(httpResp.StatusCode)
+		respBody, _ := io.ReadAll(httpResp.Body)
+		var errResp errorResponse
+		if json.Unmarshal(respBody, &errResp) == nil && errResp.Error.Message != "" {
+			message = errResp.Error.Message
+		}
+		return nil, &APIError{StatusCode: httpResp.StatusCode, Message: message}
+	}
+
+	return httpResp, nil
+}
+
+// VerifyAPIKey checks that the configured API key is accepted by listing the
+// available models.
+func (o *OpenAI) VerifyAPIKey(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.cfg.BaseURL+"/models", nil)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	if o.cfg.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+o.cfg.APIKey)
+	}
+
+	httpResp, err := o.httpClient.Do(req)
+	if err != nil {
+		return WrapError(ErrRequestFailed, err)
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		return WrapError(ErrRequestFailed, &APIError{StatusCode: httpResp.StatusCode, Message: http.StatusText(httpResp.StatusCode)})
+	}
+	return nil
+}
+
+func (o *OpenAI) executeWithRetry(ctx context.Context, operation func() error) error {
+	maxRetries := o.cfg.MaxRetries
+	baseDelay := time.Duration(o.cfg.BaseRetryDelay) * time.Second
+
+	var lastErr error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			delay := time.Duration(math.Pow(2, float64(attempt-1))) * baseDelay
+			maxDelay := time.Duration(o.cfg.MaxRetryDelay) * time.Second
+			if delay > maxDelay {
+				delay = maxDelay
+			}
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+
+		err := operation()
+		if err == nil {
+			return nil
+		}
+
+		lastErr = err
+		if !IsRetryableError(err) || attempt == maxRetries {
+			break
+		}
+	}
+
+	if IsRetryableError(lastErr) {
+		return WrapError(ErrMaxRetriesExceeded, lastErr)
+	}
+	return WrapError(ErrRequestFailed, lastErr)
+}
@@ -0,0 +1,350 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T, content string, captured *chatRequest) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+		assert.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+
+		if captured != nil {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(captured))
+		}
+
+		resp := map[string]any{
+			"model": "local-model",
+			"choices": []map[string]any{
+				{"message": map[string]string{"role": "assistant", "content": content}, "finish_reason": "stop"},
+			},
+			"usage": map[string]int{"prompt_tokens": 100, "completion_tokens": 50, "total_tokens": 150},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+func newTestClient(t *testing.T, baseURL string) *OpenAI {
+	t.Helper()
+	client, err := New(&Config{
+		BaseURL:          baseURL + "/v1",
+		APIKey:           "test-key",
+		Model:            "default-model",
+		ModelJobAnalysis: "analysis-model",
+		MaxRetries:       2,
+	})
+	require.NoError(t, err)
+	return client
+}
+
+func testPrompt() models.Prompt {
+	return *models.NewPrompt("Write a cover letter", models.Request{
+		ApplicantName:    "Jane Doe",
+		ApplicantProfile: "Senior Go developer",
+		JobDescription:   "Backend engineer",
+	}, false)
+}
+
+// assertStrictSchema checks that every object of a schema, as received by the
+// server, lists all of its properties as required and allows no others.
+func assertStrictSchema(t *testing.T, schema map[string]any) {
+	t.Helper()
+
+	if items, ok := schema["items"].(map[string]any); ok {
+		assertStrictSchema(t, items)
+	}
+
+	properties, ok := schema["properties"].(map[string]any)
+	if !ok {
+		return
+	}
+	assert.Equal(t, false, schema["additionalProperties"])
+	required, _ := schema["required"].([]any)
+	assert.Len(t, required, len(properties))
+	for _, name := range required {
+		assert.Contains(t, properties, name)
+	}
+	for _, property := range properties {
+		assertStrictSchema(t, property.(map[string]any))
+	}
+}
+
+func TestOpenAI_Generate(t *testing.T) {
+	tests := []struct {
+		name          string
+		responseType  llm.ResponseType
+		prompt        models.Prompt
+		content       string
+		expectedModel string
+		expectedName  string
+		assertData    func(t *testing.T, data any)
+	}{
+		{
+			name:          "cover letter",
+			responseType:  llm.ResponseTypeCoverLetter,
+			prompt:        testPrompt(),
+			content:       `{"content": "Dear Hiring Manager"}`,
+			expectedModel: "default-model",
+			expectedName:  "cover_letter",
+			assertData: func(t *testing.T, data any) {
+				letter, ok := data.(models.CoverLetter)
+				require.True(t, ok)
+				assert.Equal(t, "Dear Hiring Manager", letter.Content)
+				assert.Equal(t, models.CoverLetterTypePlainText, letter.Format)
+			},
+		},
+		{
+			name:          "match result",
+			responseType:  llm.ResponseTypeMatchResult,
+			prompt:        testPrompt(),
+			content:       `{"matchScore": 82, "strengths": ["Go"], "weaknesses": [], "highlights": ["Lead"], "feedback": "Good fit"}`,
+			expectedModel: "analysis-model",
+			expectedName:  "match_result",
+			assertData: func(t *testing.T, data any) {
+				result, ok := data.(models.MatchResult)
+				require.True(t, ok)
+				assert.Equal(t, 82, result.MatchScore)
+				assert.Equal(t, []string{"No specific weaknesses identified"}, result.Weaknesses)
+			},
+		},
+		{
+			name:          "cv parsing",
+			responseType:  llm.ResponseTypeCVParsing,
+			prompt:        *models.NewCVParsingPrompt("Jane Doe\nSoftware Engineer"),
+			content:       `{"isValid": true, "personalInfo": {"firstName": "Jane", "lastName": "Doe"}}`,
+			expectedModel: "default-model",
+			expectedName:  "cv_parsing",
+			assertData: func(t *testing.T, data any) {
+				result, ok := data.(models.CVParsingResult)
+				require.True(t, ok)
+				assert.Equal(t, "Jane", result.PersonalInfo.FirstName)
+				assert.NotNil(t, result.Skills)
+			},
+		},
+		{
+			name:          "cv generation",
+			responseType:  llm.ResponseTypeCV,
+			prompt:        testPrompt(),
+			content:       "Here you go: {\"personalInfo\": {\"firstName\": \"Jane\"}, \"skills\": [\"Go\"]}",
+			expectedModel: "default-model",
+			expectedName:  "cv_generation",
+			assertData: func(t *testing.T, data any) {
+				result, ok := data.(models.CVParsingResult)
+				require.True(t, ok)
+				assert.True(t, result.IsValid)
+				assert.Equal(t, []string{"Go"}, result.Skills)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var captured chatRequest
+			server := newTestServer(t, tt.content, &captured)
+			defer server.Close()
+
+			client := newTestClient(t, server.URL)
+			resp, err := client.Generate(context.Background(), llm.GenerateRequest{
+				Prompt:       tt.prompt,
+				ResponseType: tt.responseType,
+			})
+
+			require.NoError(t, err)
+			tt.assertData(t, resp.Data)
+			assert.Equal(t, 150, resp.Tokens)
+			assert.Equal(t, tt.expectedModel, resp.Metadata["model"])
+
+			assert.Equal(t, tt.expectedModel, captured.Model)
+			assert.Equal(t, "json_schema", captured.ResponseFormat.Type)
+			assert.Equal(t, tt.expectedName, captured.ResponseFormat.JSONSchema.Name)
+			assert.Equal(t, "object", captured.ResponseFormat.JSONSchema.Schema["type"])
+			assert.True(t, captured.ResponseFormat.JSONSchema.Strict)
+			assertStrictSchema(t, captured.ResponseFormat.JSONSchema.Schema)
+			require.Len(t, captured.Messages, 2)
+			assert.Equal(t, "system", captured.Messages[0].Role)
+			assert.NotEmpty(t, captured.Messages[1].Content)
+		})
+	}
+}
+
+func TestOpenAI_Generate_Errors(t *testing.T) {
+	t.Run("retries transient errors then succeeds", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"choices": []map[string]any{{"message": map[string]string{"content": `{"content": "Hello"}`}}},
+			})
+		}))
+		defer server.Close()
+
+		client := newTestClient(t, server.URL)
+		resp, err := client.Generate(context.Background(), llm.GenerateRequest{
+			Prompt:       testPrompt(),
+			ResponseType: llm.ResponseTypeCoverLetter,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Hello", resp.Data.(models.CoverLetter).Content)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("exhausted retries are reported as retryable", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		client := newTestClient(t, server.URL)
+		_, err := client.Generate(context.Background(), llm.GenerateRequest{
+			Prompt:       testPrompt(),
+			ResponseType: llm.ResponseTypeCoverLetter,
+		})
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrMaxRetriesExceeded)
+		assert.True(t, IsRetryableError(err))
+	})
+
+	t.Run("client errors are not retried", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error": {"message": "invalid api key"}}`))
+		}))
+		defer server.Close()
+
+		client := newTestClient(t, server.URL)
+		_, err := client.Generate(context.Background(), llm.GenerateRequest{
+			Prompt:       testPrompt(),
+			ResponseType: llm.ResponseTypeCoverLetter,
+		})
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrRequestFailed)
+		assert.Contains(t, err.Error(), "invalid api key")
+		assert.False(t, IsRetryableError(err))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("unsupported response type", func(t *testing.T) {
+		client := newTestClient(t, "http://127.0.0.1:1")
+		_, err := client.Generate(context.Background(), llm.GenerateRequest{
+			Prompt:       testPrompt(),
+			ResponseType: "unknown",
+		})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("missing base URL", func(t *testing.T) {
+		client, err := New(&Config{})
+		assert.Nil(t, client)
+		assert.ErrorIs(t, err, ErrMissingBaseURL)
+	})
+}
+
+func TestOpenAI_VerifyAPIKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/v1/models", r.URL.Path)
+		if r.Header.Get("Authorization") != "Bearer test-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": []}`))
+	}))
+	defer server.Close()
+
+	require.NoError(t, newTestClient(t, server.URL).VerifyAPIKey(context.Background()))
+
+	client, err := New(&Config{BaseURL: server.URL + "/v1", APIKey: "wrong-key"})
+	require.NoError(t, err)
+	err = client.VerifyAPIKey(context.Background())
+	assert.ErrorIs(t, err, ErrRequestFailed)
+	assert.Contains(t, err.Error(), "401")
+}
+
+func TestConfig_GetModelForTask(t *testing.T) {
+	cfg := &Config{Model: "default", ModelCVParsing: "parser", ModelCoverLetter: "writer"}
+
+	assert.Equal(t, "parser", cfg.GetModelForTask(models.TaskTypeCVParsing.String()))
+	assert.Equal(t, "default", cfg.GetModelForTask(models.TaskTypeJobAnalysis.String()))
+	assert.Equal(t, "writer", cfg.GetModelForTask(models.TaskTypeCoverLetter.String()))
+	assert.Equal(t, "writer", cfg.GetModelForTask(models.TaskTypeCVGeneration.String()))
+}
+
+func TestOpenAI_GenerateStream(t *testing.T) {
+	t.Run("streams deltas and parses the final result", func(t *testing.T) {
+		var captured chatRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&captured))
+			w.Header().Set("Content-Type", "text/event-stream")
+			for _, event := range []string{
+				`{"choices":[{"delta":{"content":"{\"content\": \"Dear "}}]}`,
+				`{"choices":[{"delta":{"content":"Hiring Manager\"}"}}]}`,
+				`{"choices":[],"usage":{"prompt_tokens":10,"completion_tokens":5,"total_tokens":15}}`,
+				`[DONE]`,
+			} {
+				_, _ = w.Write([]byte("data: " + event + "\n\n"))
+			}
+		}))
+		defer server.Close()
+
+		var chunks []string
+		client := newTestClient(t, server.URL)
+		resp, err := client.GenerateStream(context.Background(), llm.GenerateRequest{
+			Prompt:       testPrompt(),
+			ResponseType: llm.ResponseTypeCoverLetter,
+		}, func(chunk string) error {
+			chunks = append(chunks, chunk)
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.True(t, captured.Stream)
+		require.NotNil(t, captured.StreamOptions)
+		assert.True(t, captured.StreamOptions.IncludeUsage)
+		assert.Equal(t, []string{`{"content": "Dear `, `Hiring Manager"}`}, chunks)
+		assert.Equal(t, "Dear Hiring Manager", resp.Data.(models.CoverLetter).Content)
+		assert.Equal(t, 15, resp.Tokens)
+		assert.Equal(t, true, resp.Metadata["streamed"])
+	})
+
+	t.Run("handler errors abort without retry", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			_, _ = w.Write([]byte("data: {\"choices\":[{\"delta\":{\"content\":\"{\"}}]}\n\n"))
+		}))
+		defer server.Close()
+
+		client := newTestClient(t, server.URL)
+		_, err := client.GenerateStream(context.Background(), llm.GenerateRequest{
+			Prompt:       testPrompt(),
+			ResponseType: llm.ResponseTypeCoverLetter,
+		}, func(chunk string) error {
+			return assert.AnError
+		})
+
+		assert.ErrorIs(t, err, ErrRequestFailed)
+		assert.False(t, IsRetryableError(err))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+}
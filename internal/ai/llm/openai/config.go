@@ -0,0 +1,95 @@
+package openai
+
+import (
+	"strings"
+	"time"
+
+	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/benidevo/vega/internal/config"
+)
+
+// Config holds the configuration for an OpenAI-compatible chat completions client.
+type Config struct {
+	// BaseURL is the API root, e.g. "https://api.openai.com/v1" or "http://localhost:8000/v1".
+	BaseURL string
+	// APIKey is sent as a bearer token. It may be empty for local servers.
+	APIKey string
+	// Model is the default model used when no task-specific model is configured.
+	Model string
+	// Task-specific models
+	ModelCVParsing   string
+	ModelJobAnalysis string
+	ModelCoverLetter string
+
+	// Retry configuration
+	MaxRetries     int
+	BaseRetryDelay int // seconds
+	MaxRetryDelay  int // seconds
+
+	// RequestTimeout bounds a single HTTP request
+	RequestTimeout time.Duration
+
+	// Advanced generation parameters
+	MaxOutputTokens int
+	TopP            *float32
+
+	// EmbeddingModel is used when the provider serves embeddings
+	EmbeddingModel string
+}
+
+// NewConfig creates a new Config from the application settings.
+func NewConfig(cfg *config.Settings) *Config {
+	return &Config{
+		BaseURL:          strings.TrimRight(cfg.OpenAIBaseURL, "/"),
+		APIKey:           cfg.OpenAIAPIKey,
+		Model:            cfg.OpenAIModel,
+		ModelCVParsing:   cfg.OpenAIModelCVParsing,
+		ModelJobAnalysis: cfg.OpenAIModelJobAnalysis,
+		ModelCoverLetter: cfg.OpenAIModelCoverLetter,
+
+		MaxRetries:     3,
+		BaseRetryDelay: 1,
+		MaxRetryDelay:  30,
+
+		RequestTimeout: 120 * time.Second,
+
+		MaxOutputTokens: 6000,
+		TopP:            floatPtr(0.9),
+
+		EmbeddingModel: embeddingModel(cfg),
+	}
+}
+
+// DefaultEmbeddingModel is used when AI_EMBEDDING_MODEL is not set
+const DefaultEmbeddingModel = "text-embedding-3-small"
+
+func embeddingModel(cfg *config.Settings) string {
+	if cfg.AIEmbeddingModel != "" {
+		return cfg.AIEmbeddingModel
+	}
+	return DefaultEmbeddingModel
+}
+
+// GetModelForTask returns the appropriate model for the given task type
+func (c *Config) GetModelForTask(taskType string) string {
+	switch models.AITaskType(taskType) {
+	case models.TaskTypeCVParsing, models.TaskTypeJobExtraction:
+		if c.ModelCVParsing != "" {
+			return c.ModelCVParsing
+		}
+	case models.TaskTypeJobAnalysis, models.TaskTypeMatchResult:
+		if c.ModelJobAnalysis != "" {
+			return c.ModelJobAnalysis
+		}
+	case models.TaskTypeCoverLetter, models.TaskTypeCVGeneration, models.TaskTypeInterviewPrep, models.TaskTypeEmail, models.TaskTypeLearningPlan, models.TaskTypeSectionRewrite:
+		if c.ModelCoverLetter != "" {
+			return c.ModelCoverLetter
+		}
+	}
+
+	return c.Model
+}
+
+func floatPtr(f float32) *float32 {
+	return &f
+}
@@ -0,0 +1,70 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/benidevo/vega/internal/ai/llm/structured"
+)
+
+type embeddingRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type embeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+// EmbeddingModel implements the llm.Embedder interface.
+func (o *OpenAI) EmbeddingModel() string {
+	return "openai/" + o.cfg.EmbeddingModel
+}
+
+// Embed implements the llm.Embedder interface using the /embeddings endpoint.
+func (o *OpenAI) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return [][]float32{}, nil
+	}
+
+	body := embeddingRequest{Model: o.cfg.EmbeddingModel, Input: texts}
+
+	var resp embeddingResponse
+	err := o.executeWithRetry(ctx, func() error {
+		httpResp, err := o.post(ctx, "/embeddings", body)
+		if err != nil {
+			return err
+		}
+		defer httpResp.Body.Close()
+
+		respBody, err := io.ReadAll(httpResp.Body)
+		if err != nil {
+			return fmt.Errorf("failed to read response: %w", err)
+		}
+		if err := json.Unmarshal(respBody, &resp); err != nil {
+			return WrapError(structured.ErrResponseParseFailed, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	vectors := make([][]float32, len(texts))
+	for _, item := range resp.Data {
+		if item.Index >= 0 && item.Index < len(vectors) {
+			vectors[item.Index] = item.Embedding
+		}
+	}
+	for _, vector := range vectors {
+		if len(vector) == 0 {
+			return nil, ErrEmptyResponse
+		}
+	}
+	return vectors, nil
+}
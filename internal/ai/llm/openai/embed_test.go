@@ -0,0 +1,36 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAI_Embed(t *testing.T) {
+	var captured embeddingRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/embeddings", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&captured))
+
+		// Results may come back in any order; the index says which input they belong to.
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":[{"index":1,"embedding":[0,1]},{"index":0,"embedding":[1,0]}]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	client.cfg.EmbeddingModel = "text-embedding-3-small"
+
+	vectors, err := client.Embed(context.Background(), []string{"first", "second"})
+
+	require.NoError(t, err)
+	assert.Equal(t, [][]float32{{1, 0}, {0, 1}}, vectors)
+	assert.Equal(t, "text-embedding-3-small", captured.Model)
+	assert.Equal(t, []string{"first", "second"}, captured.Input)
+	assert.Equal(t, "openai/text-embedding-3-small", client.EmbeddingModel())
+}
@@ -0,0 +1,61 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	commonerrors "github.com/benidevo/vega/internal/common/errors"
+)
+
+var (
+	ErrMissingBaseURL     = commonerrors.New("missing base URL for OpenAI-compatible provider")
+	ErrRequestFailed      = commonerrors.New("chat completion request failed")
+	ErrEmptyResponse      = commonerrors.New("empty response from chat completion endpoint")
+	ErrMaxRetriesExceeded = commonerrors.New("maximum retry attempts exceeded")
+	ErrStreamInterrupted  = commonerrors.New("stream interrupted after output started")
+)
+
+// WrapError wraps the given innerErr with the provided sentinelErr.
+func WrapError(sentinelErr, innerErr error) error {
+	return commonerrors.WrapError(sentinelErr, innerErr)
+}
+
+// APIError describes a non-2xx response from the chat completions endpoint.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("openai-compatible API error (status %d): %s", e.StatusCode, e.Message)
+}
+
+// IsRetryableError reports whether the error is a transient API failure
+// (rate limiting, server errors or network failures) worth retrying.
+func IsRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if commonerrors.GetSentinelError(err) == ErrMaxRetriesExceeded {
+		return true
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
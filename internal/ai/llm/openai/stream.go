@@ -0,0 +1,121 @@
+package openai
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/llm/structured"
+)
+
+type streamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+type chatStreamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+	Usage *struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+		TotalTokens      int `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+// GenerateStream implements the llm.StreamingProvider interface. Connection
+// failures are retried like Generate; once output has started arriving the
+// stream is not restarted.
+func (o *OpenAI) GenerateStream(ctx context.Context, request llm.GenerateRequest, onChunk llm.StreamHandler) (llm.GenerateResponse, error) {
+	start := time.Now()
+
+	task, err := o.tasks.BuildTask(request.ResponseType, request.Prompt)
+	if err != nil {
+		return llm.GenerateResponse{}, err
+	}
+
+	model := o.cfg.GetModelForTask(task.TaskType.String())
+	body := o.buildChatRequest(task, model)
+	body.Stream = true
+	body.StreamOptions = &streamOptions{IncludeUsage: true}
+
+	var final chatStreamChunk
+	var content strings.Builder
+	err = o.executeWithRetry(ctx, func() error {
+		httpResp, err := o.postChatCompletion(ctx, body)
+		if err != nil {
+			return err
+		}
+		defer httpResp.Body.Close()
+
+		scanner := bufio.NewScanner(httpResp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			data, ok := strings.CutPrefix(scanner.Text(), "data:")
+			if !ok {
+				continue
+			}
+			data = strings.TrimSpace(data)
+			if data == "[DONE]" {
+				break
+			}
+
+			var event chatStreamChunk
+			if err := json.Unmarshal([]byte(data), &event); err != nil {
+				return WrapError(structured.ErrResponseParseFailed, err)
+			}
+			if event.Usage != nil {
+				final.Usage = event.Usage
+			}
+			for _, choice := range event.Choices {
+				if choice.Delta.Content == "" {
+					continue
+				}
+				content.WriteString(choice.Delta.Content)
+				if err := onChunk(choice.Delta.Content); err != nil {
+					return WrapError(ErrStreamInterrupted, err)
+				}
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			// Partial output has already been delivered, so retrying would
+			// duplicate it
+			if content.Len() > 0 {
+				return WrapError(ErrStreamInterrupted, err)
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return llm.GenerateResponse{}, err
+	}
+
+	if content.Len() == 0 {
+		return llm.GenerateResponse{}, ErrEmptyResponse
+	}
+
+	data, err := o.tasks.Parse(request.ResponseType, content.String())
+	if err != nil {
+		return llm.GenerateResponse{}, err
+	}
+
+	metadata := taskMetadata(task, model)
+	metadata["streamed"] = true
+	resp := llm.GenerateResponse{
+		Data:     data,
+		Duration: time.Since(start),
+		Metadata: metadata,
+	}
+	if final.Usage != nil {
+		resp.Tokens = final.Usage.TotalTokens
+		resp.PromptTokens = final.Usage.PromptTokens
+		resp.CompletionTokens = final.Usage.CompletionTokens
+	}
+	return resp, nil
+}
@@ -0,0 +1,36 @@
+package replay
+
+import (
+	"github.com/benidevo/vega/internal/config"
+)
+
+// Mode selects how the replay provider answers requests.
+type Mode string
+
+const (
+	// ModeRecord forwards requests to the upstream provider and stores each
+	// response as a fixture.
+	ModeRecord Mode = "record"
+	// ModeReplay serves previously recorded fixtures and never calls upstream.
+	ModeReplay Mode = "replay"
+	// ModeSynthetic generates schema-valid fake responses without fixtures.
+	ModeSynthetic Mode = "synthetic"
+)
+
+// Config holds the configuration for the replay provider.
+type Config struct {
+	Mode Mode
+	// FixtureDir is the directory fixtures are written to and read from.
+	FixtureDir string
+	// Upstream is the name of the provider wrapped in record mode.
+	Upstream string
+}
+
+// NewConfig creates a new Config from the application settings.
+func NewConfig(cfg *config.Settings) *Config {
+	return &Config{
+		Mode:       Mode(cfg.AIReplayMode),
+		FixtureDir: cfg.AIReplayFixtureDir,
+		Upstream:   cfg.AIReplayUpstream,
+	}
+}
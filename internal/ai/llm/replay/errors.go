@@ -0,0 +1,19 @@
+package replay
+
+import (
+	commonerrors "github.com/benidevo/vega/internal/common/errors"
+)
+
+var (
+	ErrUnsupportedMode    = commonerrors.New("unsupported replay mode")
+	ErrMissingFixtureDir  = commonerrors.New("missing fixture directory for replay provider")
+	ErrMissingUpstream    = commonerrors.New("record mode requires an upstream provider")
+	ErrFixtureNotFound    = commonerrors.New("no recorded fixture for request")
+	ErrFixtureInvalid     = commonerrors.New("recorded fixture is invalid")
+	ErrFixtureWriteFailed = commonerrors.New("failed to write fixture")
+)
+
+// WrapError wraps the given innerErr with the provided sentinelErr.
+func WrapError(sentinelErr, innerErr error) error {
+	return commonerrors.WrapError(sentinelErr, innerErr)
+}
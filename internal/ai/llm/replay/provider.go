@@ -0,0 +1,253 @@
+package replay
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/llm/structured"
+	"github.com/benidevo/vega/internal/common/logger"
+)
+
+// promptDateFormat is the layout prompt templates use to embed today's date.
+const promptDateFormat = "January 2, 2006"
+
+// Provider records, replays or synthesizes LLM responses so the application
+// can run deterministically without network access.
+type Provider struct {
+	cfg      *Config
+	upstream llm.Provider
+	tasks    *structured.Config
+	now      func() time.Time
+	log      *logger.PrivacyLogger
+}
+
+// New creates a replay provider. upstream is only used, and required, in
+// record mode.
+func New(cfg *Config, upstream llm.Provider) (*Provider, error) {
+	switch cfg.Mode {
+	case ModeRecord:
+		if upstream == nil {
+			return nil, ErrMissingUpstream
+		}
+		if cfg.FixtureDir == "" {
+			return nil, ErrMissingFixtureDir
+		}
+	case ModeReplay:
+		if cfg.FixtureDir == "" {
+			return nil, ErrMissingFixtureDir
+		}
+	case ModeSynthetic:
+	default:
+		return nil, WrapError(ErrUnsupportedMode, fmt.Errorf("mode '%s'", cfg.Mode))
+	}
+
+	return &Provider{
+		cfg:      cfg,
+		upstream: upstream,
+		tasks:    structured.NewConfig(),
+		now:      time.Now,
+		log:      logger.GetPrivacyLogger("replay"),
+	}, nil
+}
+
+// fixture is the on-disk representation of a recorded request/response pair.
+type fixture struct {
+	Key              string           `json:"key"`
+	ResponseType     llm.ResponseType `json:"response_type"`
+	Request          fixtureRequest   `json:"request"`
+	Output           json.RawMessage  `json:"output"`
+	Tokens           int              `json:"tokens"`
+	PromptTokens     int              `json:"prompt_tokens"`
+	CompletionTokens int              `json:"completion_tokens"`
+	Metadata         map[string]any   `json:"metadata,omitempty"`
+	RecordedAt       time.Time        `json:"recorded_at"`
+}
+
+type fixtureRequest struct {
+	SystemInstruction string `json:"system_instruction"`
+	Prompt            string `json:"prompt"`
+}
+
+// Generate implements the Provider interface.
+func (p *Provider) Generate(ctx context.Context, request llm.GenerateRequest) (llm.GenerateResponse, error) {
+	return p.generate(ctx, request, nil)
+}
+
+// GenerateStream streams from the upstream provider in record mode. Replayed
+// and synthetic responses are returned whole without invoking onChunk.
+func (p *Provider) GenerateStream(ctx context.Context, request llm.GenerateRequest, onChunk llm.StreamHandler) (llm.GenerateResponse, error) {
+	return p.generate(ctx, request, onChunk)
+}
+
+func (p *Provider) generate(ctx context.Context, request llm.GenerateRequest, onChunk llm.StreamHandler) (llm.GenerateResponse, error) {
+	start := time.Now()
+
+	task, err := p.tasks.BuildTask(request.ResponseType, request.Prompt)
+	if err != nil {
+		return llm.GenerateResponse{}, err
+	}
+	key := p.fixtureKey(request.ResponseType, task)
+
+	switch p.cfg.Mode {
+	case ModeRecord:
+		return p.record(ctx, request, task, key, onChunk)
+	case ModeReplay:
+		return p.replay(request.ResponseType, key, start)
+	default:
+		return p.synthesize(request, task, key, start)
+	}
+}
+
+// fixtureKey hashes the rendered prompt and response type. Today's date is
+// masked first so fixtures stay valid on later days.
+func (p *Provider) fixtureKey(responseType llm.ResponseType, task structured.Task) string {
+	today := p.now().Format(promptDateFormat)
+
+	h := sha256.New()
+	h.Write([]byte(responseType))
+	h.Write([]byte{0})
+	h.Write([]byte(strings.ReplaceAll(task.SystemInstruction, today, "{{current_date}}")))
+	h.Write([]byte{0})
+	h.Write([]byte(strings.ReplaceAll(task.UserPrompt, today, "{{current_date}}")))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (p *Provider) fixturePath(responseType llm.ResponseType, key string) string {
+	return filepath.Join(p.cfg.FixtureDir, string(responseType), key+".json")
+}
+
+func (p *Provider) record(ctx context.Context, request llm.GenerateRequest, task structured.Task, key string, onChunk llm.StreamHandler) (llm.GenerateResponse, error) {
+	var resp llm.GenerateResponse
+	var err error
+	if onChunk != nil {
+		resp, err = llm.GenerateStream(ctx, p.upstream, request, onChunk)
+	} else {
+		resp, err = p.upstream.Generate(ctx, request)
+	}
+	if err != nil {
+		return llm.GenerateResponse{}, err
+	}
+
+	if err := p.writeFixture(request.ResponseType, task, key, resp); err != nil {
+		// The caller still gets the live response; only the fixture is lost.
+		p.log.Error().Err(err).
+			Str("response_type", string(request.ResponseType)).
+			Str("key", key).
+			Msg("Failed to record LLM fixture")
+	}
+	return resp, nil
+}
+
+func (p *Provider) writeFixture(responseType llm.ResponseType, task structured.Task, key string, resp llm.GenerateResponse) error {
+	output, err := json.Marshal(resp.Data)
+	if err != nil {
+		return WrapError(ErrFixtureWriteFailed, err)
+	}
+
+	data, err := json.MarshalIndent(fixture{
+		Key:          key,
+		ResponseType: responseType,
+		Request: fixtureRequest{
+			SystemInstruction: task.SystemInstruction,
+			Prompt:            task.UserPrompt,
+		},
+		Output:           output,
+		Tokens:           resp.Tokens,
+		PromptTokens:     resp.PromptTokens,
+		CompletionTokens: resp.CompletionTokens,
+		Metadata:         resp.Metadata,
+		RecordedAt:       p.now().UTC(),
+	}, "", "  ")
+	if err != nil {
+		return WrapError(ErrFixtureWriteFailed, err)
+	}
+
+	path := p.fixturePath(responseType, key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return WrapError(ErrFixtureWriteFailed, err)
+	}
+
+	// Write to a temporary file first so a replaying process never reads a
+	// partially written fixture.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return WrapError(ErrFixtureWriteFailed, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return WrapError(ErrFixtureWriteFailed, err)
+	}
+	return nil
+}
+
+func (p *Provider) replay(responseType llm.ResponseType, key string, start time.Time) (llm.GenerateResponse, error) {
+	path := p.fixturePath(responseType, key)
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return llm.GenerateResponse{}, WrapError(ErrFixtureNotFound, fmt.Errorf("%s/%s", responseType, key))
+		}
+		return llm.GenerateResponse{}, WrapError(ErrFixtureInvalid, err)
+	}
+
+	var f fixture
+	if err := json.Unmarshal(raw, &f); err != nil {
+		return llm.GenerateResponse{}, WrapError(ErrFixtureInvalid, fmt.Errorf("%s: %w", path, err))
+	}
+
+	data, err := p.tasks.Parse(responseType, string(f.Output))
+	if err != nil {
+		return llm.GenerateResponse{}, WrapError(ErrFixtureInvalid, fmt.Errorf("%s: %w", path, err))
+	}
+
+	metadata := make(map[string]any, len(f.Metadata)+2)
+	for k, v := range f.Metadata {
+		metadata[k] = v
+	}
+	metadata["replayed"] = true
+	metadata["fixture_key"] = key
+
+	return llm.GenerateResponse{
+		Data:             data,
+		Duration:         time.Since(start),
+		Tokens:           f.Tokens,
+		PromptTokens:     f.PromptTokens,
+		CompletionTokens: f.CompletionTokens,
+		Metadata:         metadata,
+	}, nil
+}
+
+func (p *Provider) synthesize(request llm.GenerateRequest, task structured.Task, key string, start time.Time) (llm.GenerateResponse, error) {
+	output, err := syntheticOutput(request.ResponseType, request.Prompt, newSeed(key))
+	if err != nil {
+		return llm.GenerateResponse{}, err
+	}
+
+	data, err := p.tasks.Parse(request.ResponseType, output)
+	if err != nil {
+		return llm.GenerateResponse{}, err
+	}
+
+	return llm.GenerateResponse{
+		Data:     data,
+		Duration: time.Since(start),
+		Metadata: map[string]any{
+			"temperature": task.Temperature,
+			"enhanced":    task.Enhanced,
+			"model":       "synthetic",
+			"task_type":   task.TaskType.String(),
+			"synthetic":   true,
+		},
+	}, nil
+}
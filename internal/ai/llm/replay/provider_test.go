@@ -0,0 +1,324 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/models"
+	commonerrors "github.com/benidevo/vega/internal/common/errors"
+	settingsmodels "github.com/benidevo/vega/internal/settings/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubUpstream struct {
+	data   any
+	err    error
+	chunks []string
+	calls  int
+}
+
+func (s *stubUpstream) Generate(ctx context.Context, req llm.GenerateRequest) (llm.GenerateResponse, error) {
+	s.calls++
+	if s.err != nil {
+		return llm.GenerateResponse{}, s.err
+	}
+	return llm.GenerateResponse{
+		Data:             s.data,
+		Tokens:           30,
+		PromptTokens:     20,
+		CompletionTokens: 10,
+		Metadata:         map[string]any{"model": "stub-model"},
+	}, nil
+}
+
+func (s *stubUpstream) GenerateStream(ctx context.Context, req llm.GenerateRequest, onChunk llm.StreamHandler) (llm.GenerateResponse, error) {
+	for _, chunk := range s.chunks {
+		if err := onChunk(chunk); err != nil {
+			return llm.GenerateResponse{}, err
+		}
+	}
+	return s.Generate(ctx, req)
+}
+
+func coverLetterRequest(description string) llm.GenerateRequest {
+	prompt := models.NewPrompt("Write a cover letter", models.Request{
+		ApplicantName:    "Jane Doe",
+		ApplicantProfile: "Backend engineer",
+		JobDescription:   description,
+	}, false)
+	return llm.GenerateRequest{Prompt: *prompt, ResponseType: llm.ResponseTypeCoverLetter}
+}
+
+func newTestProvider(t *testing.T, mode Mode, dir string, upstream llm.Provider) *Provider {
+	t.Helper()
+	p, err := New(&Config{Mode: mode, FixtureDir: dir}, upstream)
+	require.NoError(t, err)
+	return p
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      *Config
+		upstream llm.Provider
+		wantErr  error
+	}{
+		{name: "record", cfg: &Config{Mode: ModeRecord, FixtureDir: "fixtures"}, upstream: &stubUpstream{}},
+		{name: "record without upstream", cfg: &Config{Mode: ModeRecord, FixtureDir: "fixtures"}, wantErr: ErrMissingUpstream},
+		{name: "record without directory", cfg: &Config{Mode: ModeRecord}, upstream: &stubUpstream{}, wantErr: ErrMissingFixtureDir},
+		{name: "replay", cfg: &Config{Mode: ModeReplay, FixtureDir: "fixtures"}},
+		{name: "replay without directory", cfg: &Config{Mode: ModeReplay}, wantErr: ErrMissingFixtureDir},
+		{name: "synthetic", cfg: &Config{Mode: ModeSynthetic}},
+		{name: "unknown mode", cfg: &Config{Mode: "rewind"}, wantErr: ErrUnsupportedMode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := New(tt.cfg, tt.upstream)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, commonerrors.GetSentinelError(err))
+				assert.Nil(t, p)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, p)
+		})
+	}
+}
+
+func TestProvider_RecordThenReplay(t *testing.T) {
+	dir := t.TempDir()
+	upstream := &stubUpstream{data: models.CoverLetter{Format: models.CoverLetterTypePlainText, Content: "Dear team"}}
+	req := coverLetterRequest("Go engineer")
+
+	recorder := newTestProvider(t, ModeRecord, dir, upstream)
+	recorded, err := recorder.Generate(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 1, upstream.calls)
+
+	files, err := filepath.Glob(filepath.Join(dir, string(llm.ResponseTypeCoverLetter), "*.json"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	player := newTestProvider(t, ModeReplay, dir, nil)
+	replayed, err := player.Generate(context.Background(), req)
+	require.NoError(t, err)
+
+	assert.Equal(t, recorded.Data, replayed.Data)
+	assert.Equal(t, 30, replayed.Tokens)
+	assert.Equal(t, 20, replayed.PromptTokens)
+	assert.Equal(t, 10, replayed.CompletionTokens)
+	assert.Equal(t, "stub-model", replayed.Metadata["model"])
+	assert.Equal(t, true, replayed.Metadata["replayed"])
+	assert.Equal(t, 1, upstream.calls)
+}
+
+func TestProvider_RecordStream(t *testing.T) {
+	dir := t.TempDir()
+	upstream := &stubUpstream{
+		data:   models.CoverLetter{Format: models.CoverLetterTypePlainText, Content: "Dear team"},
+		chunks: []string{`{"content":`, `"Dear team"}`},
+	}
+	recorder := newTestProvider(t, ModeRecord, dir, upstream)
+
+	var chunks []string
+	_, err := recorder.GenerateStream(context.Background(), coverLetterRequest("Go engineer"), func(chunk string) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, upstream.chunks, chunks)
+
+	_, err = newTestProvider(t, ModeReplay, dir, nil).Generate(context.Background(), coverLetterRequest("Go engineer"))
+	assert.NoError(t, err)
+}
+
+func TestProvider_RecordUpstreamError(t *testing.T) {
+	dir := t.TempDir()
+	upstreamErr := errors.New("upstream down")
+	recorder := newTestProvider(t, ModeRecord, dir, &stubUpstream{err: upstreamErr})
+
+	_, err := recorder.Generate(context.Background(), coverLetterRequest("Go engineer"))
+	assert.ErrorIs(t, err, upstreamErr)
+
+	entries, _ := os.ReadDir(dir)
+	assert.Empty(t, entries)
+}
+
+func TestProvider_ReplayMissingFixture(t *testing.T) {
+	player := newTestProvider(t, ModeReplay, t.TempDir(), nil)
+
+	_, err := player.Generate(context.Background(), coverLetterRequest("Go engineer"))
+	assert.Equal(t, ErrFixtureNotFound, commonerrors.GetSentinelError(err))
+}
+
+func TestProvider_ReplayInvalidFixture(t *testing.T) {
+	dir := t.TempDir()
+	player := newTestProvider(t, ModeReplay, dir, nil)
+	req := coverLetterRequest("Go engineer")
+
+	task, err := player.tasks.BuildTask(req.ResponseType, req.Prompt)
+	require.NoError(t, err)
+	path := player.fixturePath(req.ResponseType, player.fixtureKey(req.ResponseType, task))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(`{"output": {"content": ""}}`), 0o644))
+
+	_, err = player.Generate(context.Background(), req)
+	assert.Equal(t, ErrFixtureInvalid, commonerrors.GetSentinelError(err))
+}
+
+func TestProvider_FixtureKey(t *testing.T) {
+	p := newTestProvider(t, ModeSynthetic, "", nil)
+	req := coverLetterRequest("Go engineer")
+	task, err := p.tasks.BuildTask(req.ResponseType, req.Prompt)
+	require.NoError(t, err)
+
+	t.Run("differs by response type and prompt", func(t *testing.T) {
+		other := coverLetterRequest("Rust engineer")
+		otherTask, err := p.tasks.BuildTask(other.ResponseType, other.Prompt)
+		require.NoError(t, err)
+
+		key := p.fixtureKey(req.ResponseType, task)
+		assert.NotEqual(t, key, p.fixtureKey(other.ResponseType, otherTask))
+		assert.NotEqual(t, key, p.fixtureKey(llm.ResponseTypeMatchResult, task))
+	})
+
+	t.Run("ignores the current date", func(t *testing.T) {
+		monday := time.Date(2025, time.March, 3, 9, 0, 0, 0, time.UTC)
+		tuesday := monday.AddDate(0, 0, 1)
+
+		dated := task
+		p.now = func() time.Time { return monday }
+		dated.UserPrompt = task.UserPrompt + "\nDate: " + monday.Format(promptDateFormat)
+		first := p.fixtureKey(req.ResponseType, dated)
+
+		p.now = func() time.Time { return tuesday }
+		dated.UserPrompt = task.UserPrompt + "\nDate: " + tuesday.Format(promptDateFormat)
+		assert.Equal(t, first, p.fixtureKey(req.ResponseType, dated))
+	})
+}
+
+func TestProvider_Synthetic(t *testing.T) {
+	p := newTestProvider(t, ModeSynthetic, "", nil)
+	ctx := context.Background()
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	profile := models.Request{
+		ApplicantName:  "Jane Doe",
+		JobDescription: "Go engineer",
+		WorkExperience: []settingsmodels.WorkExperience{{Company: "Acme", Title: "Engineer", StartDate: start, Current: true}},
+		Skills:         []string{"Go", "Postgres"},
+	}
+
+	t.Run("match result", func(t *testing.T) {
+		req := llm.GenerateRequest{Prompt: *models.NewPrompt("Analyze", profile, false), ResponseType: llm.ResponseTypeMatchResult}
+		first, err := p.Generate(ctx, req)
+		require.NoError(t, err)
+		second, err := p.Generate(ctx, req)
+		require.NoError(t, err)
+
+		result, ok := first.Data.(models.MatchResult)
+		require.True(t, ok)
+		assert.GreaterOrEqual(t, result.MatchScore, 0)
+		assert.LessOrEqual(t, result.MatchScore, 100)
+		assert.Len(t, result.Strengths, 3)
+		assert.Len(t, result.Weaknesses, 2)
+		assert.NotEmpty(t, result.Feedback)
+		assert.Equal(t, first.Data, second.Data)
+		assert.Equal(t, true, first.Metadata["synthetic"])
+	})
+
+	t.Run("cover letter", func(t *testing.T) {
+		resp, err := p.Generate(ctx, llm.GenerateRequest{Prompt: *models.NewPrompt("Write", profile, false), ResponseType: llm.ResponseTypeCoverLetter})
+		require.NoError(t, err)
+		letter, ok := resp.Data.(models.CoverLetter)
+		require.True(t, ok)
+		assert.Contains(t, letter.Content, "Jane Doe")
+	})
+
+	t.Run("cv parsing", func(t *testing.T) {
+		resp, err := p.Generate(ctx, llm.GenerateRequest{Prompt: *models.NewCVParsingPrompt("some cv"), ResponseType: llm.ResponseTypeCVParsing})
+		require.NoError(t, err)
+		cv, ok := resp.Data.(models.CVParsingResult)
+		require.True(t, ok)
+		assert.True(t, cv.IsValid)
+		assert.NotEmpty(t, cv.PersonalInfo.FirstName)
+	})
+
+	t.Run("generated cv uses profile", func(t *testing.T) {
+		resp, err := p.Generate(ctx, llm.GenerateRequest{Prompt: *models.NewPrompt("Generate", profile, false), ResponseType: llm.ResponseTypeCV})
+		require.NoError(t, err)
+		cv, ok := resp.Data.(models.CVParsingResult)
+		require.True(t, ok)
+		assert.Equal(t, "Jane", cv.PersonalInfo.FirstName)
+		assert.Equal(t, "Doe", cv.PersonalInfo.LastName)
+		require.Len(t, cv.WorkExperience, 1)
+		assert.Equal(t, "Acme", cv.WorkExperience[0].Company)
+		assert.Equal(t, "2020-01", cv.WorkExperience[0].StartDate)
+		assert.Equal(t, "Present", cv.WorkExperience[0].EndDate)
+		assert.Equal(t, []string{"Go", "Postgres"}, cv.Skills)
+	})
+
+	t.Run("interview prep cites work experience", func(t *testing.T) {
+		resp, err := p.Generate(ctx, llm.GenerateRequest{Prompt: *models.NewPrompt("Prepare", profile, true), ResponseType: llm.ResponseTypeInterviewPrep})
+		require.NoError(t, err)
+		prep, ok := resp.Data.(models.InterviewPrep)
+		require.True(t, ok)
+		require.Len(t, prep.Questions, 3)
+		assert.Equal(t, "Engineer at Acme", prep.Questions[0].Experience)
+		assert.NotEmpty(t, prep.Questions[0].Answer.Result)
+		assert.Len(t, prep.QuestionsToAsk, 3)
+		assert.Len(t, prep.GapsToRehearse, 2)
+	})
+
+	t.Run("email follows requested kind", func(t *testing.T) {
+		request := profile
+		request.Email = &models.EmailDetails{Kind: models.EmailKindThankYou, Tone: models.EmailToneFriendly, CompanyName: "Acme", JobTitle: "Engineer"}
+
+		resp, err := p.Generate(ctx, llm.GenerateRequest{Prompt: *models.NewPrompt("Write", request, true), ResponseType: llm.ResponseTypeEmail})
+		require.NoError(t, err)
+		email, ok := resp.Data.(models.Email)
+		require.True(t, ok)
+		assert.Equal(t, "Thank you for today", email.Subject)
+		assert.Contains(t, email.Body, "the Engineer role")
+	})
+
+	t.Run("learning plan covers each gap", func(t *testing.T) {
+		request := profile
+		request.SkillGaps = []models.SkillGapDetails{{Name: "Kubernetes", Occurrences: 3}, {Name: "Terraform", Occurrences: 1}}
+
+		resp, err := p.Generate(ctx, llm.GenerateRequest{Prompt: *models.NewPrompt("Plan", request, true), ResponseType: llm.ResponseTypeLearningPlan})
+		require.NoError(t, err)
+		plan, ok := resp.Data.(models.LearningPlan)
+		require.True(t, ok)
+		require.Len(t, plan.Gaps, 2)
+		assert.Equal(t, "Kubernetes", plan.Gaps[0].Gap)
+		assert.NotEmpty(t, plan.Gaps[1].Steps)
+	})
+
+	t.Run("job extraction uses the first line of the posting", func(t *testing.T) {
+		resp, err := p.Generate(ctx, llm.GenerateRequest{Prompt: *models.NewJobExtractionPrompt("\n Senior Go Engineer \nAcme is hiring."), ResponseType: llm.ResponseTypeJobExtraction})
+		require.NoError(t, err)
+		extraction, ok := resp.Data.(models.JobExtraction)
+		require.True(t, ok)
+		assert.Equal(t, "Senior Go Engineer", extraction.Title)
+		assert.Equal(t, "full_time", extraction.JobType)
+		assert.Equal(t, 0.0, extraction.Confidence[models.JobFieldSalary])
+	})
+	t.Run("section rewrite keeps the first sentences", func(t *testing.T) {
+		request := profile
+		request.Rewrite = &models.RewriteDetails{Section: models.SectionSummary, Text: "Built APIs. Led a team. Mentored juniors.", Instruction: "shorten"}
+
+		resp, err := p.Generate(ctx, llm.GenerateRequest{Prompt: *models.NewPrompt("Rewrite", request, true), ResponseType: llm.ResponseTypeSectionRewrite})
+		require.NoError(t, err)
+		rewrite, ok := resp.Data.(models.SectionRewrite)
+		require.True(t, ok)
+		assert.Equal(t, "Built APIs. Led a team.", rewrite.Content)
+	})
+}
@@ -0,0 +1,393 @@
+package replay
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/llm/structured"
+	"github.com/benidevo/vega/internal/ai/models"
+)
+
+var (
+	syntheticStrengths = []string{
+		"Relevant hands-on experience with the core technologies in the role",
+		"Track record of delivering projects end to end",
+		"Clear written communication demonstrated across past roles",
+		"Experience collaborating with cross-functional teams",
+		"Steady career progression with increasing responsibility",
+	}
+	syntheticWeaknesses = []string{
+		"Limited exposure to the specific industry domain",
+		"No direct evidence of people management experience",
+		"Some listed tools are not mentioned in the profile",
+		"Fewer years of experience than the role prefers",
+	}
+	syntheticHighlights = []string{
+		"Highlight the most recent role and its measurable outcomes",
+		"Emphasize experience with the primary tech stack",
+		"Mention collaboration with product and design",
+		"Reference any open source or side projects",
+	}
+	syntheticSkills = []string{"Go", "SQL", "Docker", "REST APIs", "Testing", "Git"}
+
+	syntheticQuestions = []string{
+		"Walk me through a project you're proud of.",
+		"Tell me about a time you disagreed with a teammate.",
+		"Describe a deadline you nearly missed and what you did.",
+		"What's a mistake you made at work and what did you learn?",
+		"How do you decide what to work on first?",
+	}
+	syntheticQuestionsToAsk = []string{
+		"What would success look like in the first six months?",
+		"What's the biggest challenge the team is facing right now?",
+		"How does the team decide what to build next?",
+		"What does the interview process look like from here?",
+	}
+)
+
+// newSeed derives a deterministic random source from a fixture key so the
+// same request always produces the same synthetic response.
+func newSeed(key string) *rand.Rand {
+	sum, err := hex.DecodeString(key)
+	if err != nil || len(sum) < 16 {
+		return rand.New(rand.NewPCG(0, 0))
+	}
+	return rand.New(rand.NewPCG(binary.BigEndian.Uint64(sum[:8]), binary.BigEndian.Uint64(sum[8:16])))
+}
+
+// syntheticOutput returns raw JSON for the response type in the same shape a
+// real model would produce, so it passes through the normal parser.
+func syntheticOutput(responseType llm.ResponseType, prompt models.Prompt, rng *rand.Rand) (string, error) {
+	var value any
+	switch responseType {
+	case llm.ResponseTypeMatchResult:
+		value = syntheticMatchResult(prompt, rng)
+	case llm.ResponseTypeCoverLetter:
+		value = syntheticCoverLetter(prompt)
+	case llm.ResponseTypeCVParsing:
+		value = syntheticParsedCV()
+	case llm.ResponseTypeJobExtraction:
+		value = syntheticJobExtraction(prompt)
+	case llm.ResponseTypeCV:
+		value = syntheticGeneratedCV(prompt)
+	case llm.ResponseTypeInterviewPrep:
+		value = syntheticInterviewPrep(prompt, rng)
+	case llm.ResponseTypeEmail:
+		value = syntheticEmail(prompt)
+	case llm.ResponseTypeLearningPlan:
+		value = syntheticLearningPlan(prompt)
+	case llm.ResponseTypeSectionRewrite:
+		value = syntheticSectionRewrite(prompt)
+	default:
+		return "", structured.WrapError(structured.ErrUnsupportedResponseType, fmt.Errorf("response type: %s", responseType))
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func syntheticMatchResult(prompt models.Prompt, rng *rand.Rand) models.MatchResult {
+	name := applicantName(prompt)
+	return models.MatchResult{
+		MatchScore: 40 + rng.IntN(56),
+		Strengths:  pick(syntheticStrengths, 3, rng),
+		Weaknesses: pick(syntheticWeaknesses, 2, rng),
+		Highlights: pick(syntheticHighlights, 3, rng),
+		Feedback: fmt.Sprintf("%s is a reasonable fit for this role. This analysis was generated "+
+			"in synthetic mode and does not reflect a real model evaluation.", name),
+	}
+}
+
+func syntheticCoverLetter(prompt models.Prompt) models.CoverLetter {
+	name := applicantName(prompt)
+	return models.CoverLetter{
+		Format: models.CoverLetterTypePlainText,
+		Content: "Dear Hiring Manager,\n\n" +
+			"I am excited to apply for this position. My background has prepared me to contribute " +
+			"from day one, and I am drawn to the team's focus on building reliable, well-crafted products.\n\n" +
+			"In my recent roles I have delivered features end to end, worked closely with colleagues " +
+			"across disciplines and taken ownership of quality. I would welcome the chance to bring " +
+			"that same approach to your team.\n\n" +
+			"Thank you for your time and consideration.\n\n" +
+			"Sincerely,\n" + name,
+	}
+}
+
+func syntheticParsedCV() models.CVParsingResult {
+	return models.CVParsingResult{
+		IsValid: true,
+		PersonalInfo: models.PersonalInfo{
+			FirstName: "Alex",
+			LastName:  "Morgan",
+			Email:     "alex.morgan@example.com",
+			Location:  "Remote",
+			Title:     "Software Engineer",
+			Summary:   "Software engineer with experience building web services and internal tools.",
+		},
+		WorkExperience: []models.WorkExperience{
+			{
+				Company:     "Example Corp",
+				Title:       "Software Engineer",
+				StartDate:   "2021-03",
+				EndDate:     "Present",
+				Description: "Built and maintained backend services and APIs.",
+			},
+			{
+				Company:     "Sample Labs",
+				Title:       "Junior Developer",
+				StartDate:   "2019-01",
+				EndDate:     "2021-02",
+				Description: "Developed internal dashboards and automated reporting.",
+			},
+		},
+		Education: []models.Education{
+			{
+				Institution:  "State University",
+				Degree:       "BSc",
+				FieldOfStudy: "Computer Science",
+				StartDate:    "2015",
+				EndDate:      "2019",
+			},
+		},
+		Skills: append([]string(nil), syntheticSkills...),
+	}
+}
+
+// syntheticGeneratedCV builds a CV from the profile data in the prompt,
+// falling back to placeholder content for empty sections.
+func syntheticGeneratedCV(prompt models.Prompt) models.CVParsingResult {
+	result := syntheticParsedCV()
+
+	if prompt.ApplicantName != "" {
+		first, last, _ := strings.Cut(strings.TrimSpace(prompt.ApplicantName), " ")
+		result.PersonalInfo.FirstName = first
+		result.PersonalInfo.LastName = last
+		result.PersonalInfo.Email = ""
+	}
+
+	if len(prompt.WorkExperience) > 0 {
+		result.WorkExperience = make([]models.WorkExperience, 0, len(prompt.WorkExperience))
+		for _, exp := range prompt.WorkExperience {
+			endDate := "Present"
+			if !exp.Current && exp.EndDate != nil {
+				endDate = exp.EndDate.Format("2006-01")
+			}
+			result.WorkExperience = append(result.WorkExperience, models.WorkExperience{
+				Company:     exp.Company,
+				Title:       exp.Title,
+				Location:    exp.Location,
+				StartDate:   exp.StartDate.Format("2006-01"),
+				EndDate:     endDate,
+				Description: exp.Description,
+			})
+		}
+	}
+
+	if len(prompt.Education) > 0 {
+		result.Education = make([]models.Education, 0, len(prompt.Education))
+		for _, edu := range prompt.Education {
+			var endDate string
+			if edu.EndDate != nil {
+				endDate = edu.EndDate.Format("2006-01")
+			}
+			result.Education = append(result.Education, models.Education{
+				Institution:  edu.Institution,
+				Degree:       edu.Degree,
+				FieldOfStudy: edu.FieldOfStudy,
+				StartDate:    edu.StartDate.Format("2006-01"),
+				EndDate:      endDate,
+			})
+		}
+	}
+
+	if len(prompt.Skills) > 0 {
+		result.Skills = prompt.Skills
+	}
+
+	return result
+}
+
+// syntheticInterviewPrep answers each question from one of the roles in the
+// prompt, cycling through them in order.
+func syntheticInterviewPrep(prompt models.Prompt, rng *rand.Rand) models.InterviewPrep {
+	questions := pick(syntheticQuestions, 3, rng)
+
+	prep := models.InterviewPrep{
+		Questions:      make([]models.InterviewQuestion, 0, len(questions)),
+		QuestionsToAsk: pick(syntheticQuestionsToAsk, 3, rng),
+		GapsToRehearse: pick(syntheticWeaknesses, 2, rng),
+	}
+
+	for i, question := range questions {
+		experience := "Most recent role"
+		if len(prompt.WorkExperience) > 0 {
+			exp := prompt.WorkExperience[i%len(prompt.WorkExperience)]
+			experience = fmt.Sprintf("%s at %s", exp.Title, exp.Company)
+		}
+
+		prep.Questions = append(prep.Questions, models.InterviewQuestion{
+			Question:   question,
+			Reason:     "A common question for this kind of role.",
+			Experience: experience,
+			Answer: models.STARAnswer{
+				Situation: fmt.Sprintf("As %s, the team had a project at risk.", experience),
+				Task:      "I needed to get it back on track without cutting corners.",
+				Action:    "I broke the work into smaller pieces and checked in with the team every day.",
+				Result:    "We shipped on time. This answer was generated in synthetic mode.",
+			},
+		})
+	}
+
+	return prep
+}
+
+// syntheticEmail writes a short email of the requested kind that names the
+// role and company when the prompt includes them.
+func syntheticEmail(prompt models.Prompt) models.Email {
+	role, company := "the role", "the team"
+	kind := models.EmailKindFollowUp
+	if prompt.Email != nil {
+		if prompt.Email.JobTitle != "" {
+			role = "the " + prompt.Email.JobTitle + " role"
+		}
+		if prompt.Email.CompanyName != "" {
+			company = prompt.Email.CompanyName
+		}
+		kind = prompt.Email.Kind
+	}
+
+	var subject, opening string
+	switch kind {
+	case models.EmailKindThankYou:
+		subject = "Thank you for today"
+		opening = fmt.Sprintf("Thank you for taking the time to talk with me about %s. I enjoyed learning more about the work at %s.", role, company)
+	case models.EmailKindNegotiation:
+		subject = "Following up on the offer"
+		opening = fmt.Sprintf("Thank you for the offer for %s. Before I accept, I'd like to talk through a few details of the package.", role)
+	default:
+		subject = "Checking in on my application"
+		opening = fmt.Sprintf("I applied for %s at %s and wanted to check in on where things stand.", role, company)
+	}
+
+	return models.Email{
+		Subject: subject,
+		Body: "Hi " + company + ",\n\n" + opening + "\n\n" +
+			"This email was generated in synthetic mode.\n\n" +
+			"Best regards,\n" + applicantName(prompt),
+	}
+}
+
+// syntheticLearningPlan writes a short plan for each gap in the prompt.
+func syntheticLearningPlan(prompt models.Prompt) models.LearningPlan {
+	gaps := prompt.SkillGaps
+	if len(gaps) == 0 {
+		gaps = []models.SkillGapDetails{{Name: "Core skills for the role"}}
+	}
+
+	plan := models.LearningPlan{Gaps: make([]models.GapPlan, 0, len(gaps))}
+	for _, gap := range gaps {
+		plan.Gaps = append(plan.Gaps, models.GapPlan{
+			Gap: gap.Name,
+			Why: fmt.Sprintf("%s came up in %d of the jobs you analysed.", gap.Name, gap.Occurrences),
+			Steps: []string{
+				fmt.Sprintf("Work through the official getting started guide for %s.", gap.Name),
+				fmt.Sprintf("Use %s in a small project of your own.", gap.Name),
+				"Write up what you built and what you would do differently.",
+			},
+			Projects: []string{fmt.Sprintf("A small project that uses %s end to end.", gap.Name)},
+			Phrasing: []string{fmt.Sprintf("Built a project using %s. This plan was generated in synthetic mode.", gap.Name)},
+		})
+	}
+	return plan
+}
+
+// syntheticJobExtraction takes the title from the first line of the posting
+// and fills the remaining fields with placeholder values.
+func syntheticJobExtraction(prompt models.Prompt) models.JobExtraction {
+	title := "Software Engineer"
+	for _, line := range strings.Split(prompt.PostingText, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			title = line
+			break
+		}
+	}
+	if len(title) > 80 {
+		title = title[:80]
+	}
+
+	return models.JobExtraction{
+		IsValid:        true,
+		Title:          title,
+		Company:        "Example Company",
+		Location:       "Remote",
+		JobType:        "full_time",
+		RequiredSkills: []string{"Communication", "Problem solving"},
+		Seniority:      "mid",
+		Confidence: map[string]float64{
+			models.JobFieldTitle:          0.9,
+			models.JobFieldCompany:        0.3,
+			models.JobFieldLocation:       0.3,
+			models.JobFieldJobType:        0.5,
+			models.JobFieldRequiredSkills: 0.3,
+			models.JobFieldSeniority:      0.3,
+		},
+	}
+}
+
+// syntheticSectionRewrite shortens the section to its first two lines or
+// sentences, which is enough to show a before and after comparison.
+func syntheticSectionRewrite(prompt models.Prompt) models.SectionRewrite {
+	if prompt.Rewrite == nil || strings.TrimSpace(prompt.Rewrite.Text) == "" {
+		return models.SectionRewrite{Content: "Rewritten in synthetic mode.", Changes: "Replaced the empty section."}
+	}
+
+	text := strings.TrimSpace(prompt.Rewrite.Text)
+	if prompt.Rewrite.Section == models.SectionSkills {
+		return models.SectionRewrite{Content: text, Changes: "Kept the skills as they were in synthetic mode."}
+	}
+
+	separator := "\n"
+	parts := strings.Split(text, separator)
+	if len(parts) < 2 {
+		separator = " "
+		parts = strings.SplitAfter(text, ". ")
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+		}
+	}
+	if len(parts) > 2 {
+		parts = parts[:2]
+	}
+
+	return models.SectionRewrite{
+		Content: strings.Join(parts, separator),
+		Changes: "Kept the first part of the section in synthetic mode.",
+	}
+}
+
+func applicantName(prompt models.Prompt) string {
+	if name := strings.TrimSpace(prompt.ApplicantName); name != "" {
+		return name
+	}
+	return "The applicant"
+}
+
+// pick returns n distinct items from options in a seed-dependent order.
+func pick(options []string, n int, rng *rand.Rand) []string {
+	if n > len(options) {
+		n = len(options)
+	}
+	picked := make([]string, 0, n)
+	for _, i := range rng.Perm(len(options))[:n] {
+		picked = append(picked, options[i])
+	}
+	return picked
+}
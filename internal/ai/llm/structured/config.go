@@ -0,0 +1,38 @@
+package structured
+
+// Config holds the provider-independent settings used when building prompts
+// and parsing structured responses.
+type Config struct {
+	// Cover letter configuration
+	DefaultWordRange string
+
+	// Match score configuration
+	MinMatchScore int
+	MaxMatchScore int
+
+	// Default values for fallbacks
+	DefaultStrengthsMsg string
+	DefaultWeaknessMsg  string
+	DefaultHighlightMsg string
+	DefaultFeedbackMsg  string
+
+	// SystemInstruction is the default system prompt for analysis and writing tasks
+	SystemInstruction string
+}
+
+// NewConfig returns a Config populated with the defaults shared by every provider.
+func NewConfig() *Config {
+	return &Config{
+		DefaultWordRange: "150-250",
+
+		MinMatchScore: 0,
+		MaxMatchScore: 100,
+
+		DefaultStrengthsMsg: "No specific strengths identified",
+		DefaultWeaknessMsg:  "No specific weaknesses identified",
+		DefaultHighlightMsg: "No specific highlights identified",
+		DefaultFeedbackMsg:  "Unable to provide detailed feedback at this time.",
+
+		SystemInstruction: defaultSystemInstruction,
+	}
+}
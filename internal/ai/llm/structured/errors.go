@@ -0,0 +1,16 @@
+package structured
+
+import (
+	commonerrors "github.com/benidevo/vega/internal/common/errors"
+)
+
+var (
+	ErrUnsupportedResponseType = commonerrors.New("unsupported response type")
+	ErrEmptyResponse           = commonerrors.New("empty response from model")
+	ErrResponseParseFailed     = commonerrors.New("failed to parse model response")
+)
+
+// WrapError wraps the given innerErr with the provided sentinelErr.
+func WrapError(sentinelErr, innerErr error) error {
+	return commonerrors.WrapError(sentinelErr, innerErr)
+}
@@ -0,0 +1,266 @@
+package structured
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/benidevo/vega/internal/ai/models"
+)
+
+// ParseMatchResult parses a match analysis response, clamping the score and
+// filling empty sections with default messages.
+func (c *Config) ParseMatchResult(jsonResponse string) (models.MatchResult, error) {
+	cleanJSON := ExtractJSON(jsonResponse)
+
+	var result models.MatchResult
+	if err := json.Unmarshal([]byte(cleanJSON), &result); err != nil {
+		return models.MatchResult{}, WrapError(ErrResponseParseFailed, err)
+	}
+
+	if result.MatchScore < c.MinMatchScore || result.MatchScore > c.MaxMatchScore {
+		result.MatchScore = c.MinMatchScore
+	}
+
+	if len(result.Strengths) == 0 {
+		result.Strengths = []string{c.DefaultStrengthsMsg}
+	}
+
+	if len(result.Weaknesses) == 0 {
+		result.Weaknesses = []string{c.DefaultWeaknessMsg}
+	}
+
+	if len(result.Highlights) == 0 {
+		result.Highlights = []string{c.DefaultHighlightMsg}
+	}
+
+	if result.Feedback == "" {
+		result.Feedback = c.DefaultFeedbackMsg
+	}
+
+	return result, nil
+}
+
+// ParseCoverLetter parses a cover letter response.
+func (c *Config) ParseCoverLetter(jsonResponse string) (models.CoverLetter, error) {
+	cleanJSON := ExtractJSON(jsonResponse)
+
+	var result models.CoverLetter
+	if err := json.Unmarshal([]byte(cleanJSON), &result); err != nil {
+		return models.CoverLetter{}, WrapError(ErrResponseParseFailed, err)
+	}
+
+	if result.Content == "" {
+		return models.CoverLetter{}, ErrEmptyResponse
+	}
+
+	result.Format = models.CoverLetterTypePlainText
+
+	return result, nil
+}
+
+// ParseEmail parses an application email response and rejects emails without
+// a subject or body.
+func (c *Config) ParseEmail(jsonResponse string) (models.Email, error) {
+	cleanJSON := ExtractJSON(jsonResponse)
+
+	var result models.Email
+	if err := json.Unmarshal([]byte(cleanJSON), &result); err != nil {
+		return models.Email{}, WrapError(ErrResponseParseFailed, err)
+	}
+
+	result.Subject = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(result.Subject), "Subject:"))
+	if result.Subject == "" || strings.TrimSpace(result.Body) == "" {
+		return models.Email{}, ErrEmptyResponse
+	}
+
+	return result, nil
+}
+
+// ParseCV parses a CV parsing response and rejects documents the model marked as invalid.
+func (c *Config) ParseCV(jsonResponse string) (models.CVParsingResult, error) {
+	cleanJSON := ExtractJSON(jsonResponse)
+
+	var result models.CVParsingResult
+	if err := json.Unmarshal([]byte(cleanJSON), &result); err != nil {
+		return models.CVParsingResult{}, WrapError(ErrResponseParseFailed, err)
+	}
+
+	if !result.IsValid {
+		reason := result.Reason
+		if reason == "" {
+			reason = "Document is not a valid CV/Resume"
+		}
+		return models.CVParsingResult{}, fmt.Errorf("invalid document: %s", reason)
+	}
+
+	if result.PersonalInfo.FirstName == "" && result.PersonalInfo.LastName == "" {
+		return models.CVParsingResult{}, fmt.Errorf("no name found in CV")
+	}
+
+	fillEmptyCVSections(&result)
+
+	return result, nil
+}
+
+// ParseGeneratedCV parses a CV generation response without strict validation.
+func (c *Config) ParseGeneratedCV(jsonResponse string) (models.CVParsingResult, error) {
+	cleanJSON := ExtractJSON(jsonResponse)
+
+	var result models.CVParsingResult
+	if err := json.Unmarshal([]byte(cleanJSON), &result); err != nil {
+		return models.CVParsingResult{}, WrapError(ErrResponseParseFailed, err)
+	}
+
+	// For generated CVs, assume it's valid
+	result.IsValid = true
+
+	fillEmptyCVSections(&result)
+
+	return result, nil
+}
+
+// ParseInterviewPrep parses an interview preparation response and rejects
+// packs without any questions.
+func (c *Config) ParseInterviewPrep(jsonResponse string) (models.InterviewPrep, error) {
+	cleanJSON := ExtractJSON(jsonResponse)
+
+	var result models.InterviewPrep
+	if err := json.Unmarshal([]byte(cleanJSON), &result); err != nil {
+		return models.InterviewPrep{}, WrapError(ErrResponseParseFailed, err)
+	}
+
+	if len(result.Questions) == 0 {
+		return models.InterviewPrep{}, ErrEmptyResponse
+	}
+
+	if result.QuestionsToAsk == nil {
+		result.QuestionsToAsk = []string{}
+	}
+	if result.GapsToRehearse == nil {
+		result.GapsToRehearse = []string{}
+	}
+
+	return result, nil
+}
+
+// ParseLearningPlan parses a learning plan response. Plans without a gap name
+// are dropped and missing lists are returned as empty slices.
+func (c *Config) ParseLearningPlan(jsonResponse string) (models.LearningPlan, error) {
+	cleanJSON := ExtractJSON(jsonResponse)
+
+	var result models.LearningPlan
+	if err := json.Unmarshal([]byte(cleanJSON), &result); err != nil {
+		return models.LearningPlan{}, WrapError(ErrResponseParseFailed, err)
+	}
+
+	gaps := make([]models.GapPlan, 0, len(result.Gaps))
+	for _, gap := range result.Gaps {
+		gap.Gap = strings.TrimSpace(gap.Gap)
+		if gap.Gap == "" {
+			continue
+		}
+		if gap.Steps == nil {
+			gap.Steps = []string{}
+		}
+		if gap.Projects == nil {
+			gap.Projects = []string{}
+		}
+		if gap.Phrasing == nil {
+			gap.Phrasing = []string{}
+		}
+		gaps = append(gaps, gap)
+	}
+	if len(gaps) == 0 {
+		return models.LearningPlan{}, ErrEmptyResponse
+	}
+	result.Gaps = gaps
+
+	return result, nil
+}
+
+// ParseJobExtraction parses a job extraction response, rejecting text the
+// model did not recognise as a job posting and normalising the fields.
+func (c *Config) ParseJobExtraction(jsonResponse string) (models.JobExtraction, error) {
+	cleanJSON := ExtractJSON(jsonResponse)
+
+	var result models.JobExtraction
+	if err := json.Unmarshal([]byte(cleanJSON), &result); err != nil {
+		return models.JobExtraction{}, WrapError(ErrResponseParseFailed, err)
+	}
+
+	if !result.IsValid {
+		reason := result.Reason
+		if reason == "" {
+			reason = "no job details found"
+		}
+		return models.JobExtraction{}, models.WrapError(models.ErrNotJobPosting, fmt.Errorf("%s", reason))
+	}
+
+	result.Normalize()
+	if result.Title == "" && result.Company == "" {
+		return models.JobExtraction{}, ErrEmptyResponse
+	}
+
+	return result, nil
+}
+
+// ParseSectionRewrite parses a section rewrite response and rejects rewrites
+// without content.
+func (c *Config) ParseSectionRewrite(jsonResponse string) (models.SectionRewrite, error) {
+	cleanJSON := ExtractJSON(jsonResponse)
+
+	var result models.SectionRewrite
+	if err := json.Unmarshal([]byte(cleanJSON), &result); err != nil {
+		return models.SectionRewrite{}, WrapError(ErrResponseParseFailed, err)
+	}
+
+	if strings.TrimSpace(result.Content) == "" {
+		return models.SectionRewrite{}, ErrEmptyResponse
+	}
+
+	return result, nil
+}
+
+func fillEmptyCVSections(result *models.CVParsingResult) {
+	if result.WorkExperience == nil {
+		result.WorkExperience = []models.WorkExperience{}
+	}
+	if result.Education == nil {
+		result.Education = []models.Education{}
+	}
+	if result.Skills == nil {
+		result.Skills = []string{}
+	}
+}
+
+// ExtractJSON attempts to extract JSON content from a response that may contain extra text
+func ExtractJSON(response string) string {
+	response = strings.TrimSpace(response)
+
+	startIdx := strings.Index(response, "{")
+	if startIdx == -1 {
+		return response
+	}
+
+	braceCount := 0
+	endIdx := -1
+
+	for i := startIdx; i < len(response) && endIdx == -1; i++ {
+		switch response[i] {
+		case '{':
+			braceCount++
+		case '}':
+			braceCount--
+			if braceCount == 0 {
+				endIdx = i
+			}
+		}
+	}
+
+	if endIdx == -1 {
+		return response
+	}
+
+	return response[startIdx : endIdx+1]
+}
@@ -0,0 +1,69 @@
+package structured
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxEscapeLen is the longest JSON string escape sequence (\uXXXX)
+const maxEscapeLen = 6
+
+// PartialStringField returns the decoded value of a string field from a JSON
+// document that may still be arriving, as far as it has been received. It is
+// used to preview streamed output before the full document can be parsed and
+// returns an empty string while the field has not started.
+func PartialStringField(raw, field string) string {
+	key := `"` + field + `"`
+	idx := strings.Index(raw, key)
+	if idx < 0 {
+		return ""
+	}
+
+	rest := strings.TrimLeft(raw[idx+len(key):], " \t\r\n")
+	rest, ok := strings.CutPrefix(rest, ":")
+	if !ok {
+		return ""
+	}
+	rest = strings.TrimLeft(rest, " \t\r\n")
+	rest, ok = strings.CutPrefix(rest, `"`)
+	if !ok {
+		return ""
+	}
+
+	value := rest
+	for i := 0; i < len(rest); i++ {
+		if rest[i] == '\\' {
+			i++
+			continue
+		}
+		if rest[i] == '"' {
+			value = rest[:i]
+			break
+		}
+	}
+	value = trimIncompleteRune(value)
+
+	// An escape sequence may be cut off at the end; drop it until the
+	// remainder decodes
+	for trim := 0; trim <= maxEscapeLen && trim <= len(value); trim++ {
+		var decoded string
+		if json.Unmarshal([]byte(`"`+value[:len(value)-trim]+`"`), &decoded) == nil {
+			return decoded
+		}
+	}
+	return ""
+}
+
+// trimIncompleteRune drops a multi-byte UTF-8 sequence cut off at the end of s.
+func trimIncompleteRune(s string) string {
+	for i := len(s) - 1; i >= 0 && i >= len(s)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(s[i]) {
+			if !utf8.FullRuneInString(s[i:]) {
+				return s[:i]
+			}
+			break
+		}
+	}
+	return s
+}
@@ -0,0 +1,295 @@
+package structured
+
+import (
+	"fmt"
+
+	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/benidevo/vega/internal/ai/prompts"
+)
+
+// Schema is a JSON Schema document expressed as nested maps so it can be
+// serialised directly into provider request bodies.
+type Schema map[string]any
+
+func stringProp(description string) Schema {
+	return Schema{"type": "string", "description": description}
+}
+
+func stringArrayProp(description string) Schema {
+	s := Schema{"type": "array", "items": Schema{"type": "string"}}
+	if description != "" {
+		s["description"] = description
+	}
+	return s
+}
+
+// MatchAnalysisSchema returns the JSON schema for job match analysis responses.
+func (c *Config) MatchAnalysisSchema() Schema {
+	return Schema{
+		"type": "object",
+		"properties": Schema{
+			"matchScore": Schema{
+				"type":        "integer",
+				"description": fmt.Sprintf("Match score from %d-%d", c.MinMatchScore, c.MaxMatchScore),
+				"minimum":     c.MinMatchScore,
+				"maximum":     c.MaxMatchScore,
+			},
+			"strengths":  stringArrayProp("List of candidate strengths"),
+			"weaknesses": stringArrayProp("List of areas for improvement"),
+			"highlights": stringArrayProp("List of standout qualifications"),
+			"feedback":   stringProp("Overall assessment and recommendations"),
+		},
+		"required": []string{"matchScore", "strengths", "weaknesses", "highlights", "feedback"},
+	}
+}
+
+// CoverLetterSchema returns the JSON schema for cover letter responses.
+func (c *Config) CoverLetterSchema() Schema {
+	return Schema{
+		"type": "object",
+		"properties": Schema{
+			"content": stringProp("The complete cover letter content"),
+		},
+		"required": []string{"content"},
+	}
+}
+
+// EmailSchema returns the JSON schema for application email responses.
+func (c *Config) EmailSchema() Schema {
+	return Schema{
+		"type": "object",
+		"properties": Schema{
+			"subject": stringProp("The email subject line"),
+			"body":    stringProp("The complete email body"),
+		},
+		"required": []string{"subject", "body"},
+	}
+}
+
+// SectionRewriteSchema returns the JSON schema for section rewrite responses.
+func (c *Config) SectionRewriteSchema() Schema {
+	return Schema{
+		"type": "object",
+		"properties": Schema{
+			"content": stringProp("The rewritten section"),
+			"changes": stringProp("One sentence describing what was changed"),
+		},
+		"required": []string{"content", "changes"},
+	}
+}
+
+// LearningPlanSchema returns the JSON schema for skill-gap learning plan responses.
+func (c *Config) LearningPlanSchema() Schema {
+	return Schema{
+		"type": "object",
+		"properties": Schema{
+			"gaps": Schema{
+				"type":        "array",
+				"description": "One plan per skill gap, in the order the gaps were given",
+				"items": Schema{
+					"type": "object",
+					"properties": Schema{
+						"gap":      stringProp("The skill gap, exactly as given"),
+						"why":      stringProp("Why the gap matters for the roles the candidate is targeting"),
+						"steps":    stringArrayProp("Concrete learning steps, in order"),
+						"projects": stringArrayProp("Small projects that would prove the skill"),
+						"phrasing": stringArrayProp("How to describe the new experience on a CV once the project is done"),
+					},
+					"required": []string{"gap", "steps", "projects", "phrasing"},
+				},
+			},
+		},
+		"required": []string{"gaps"},
+	}
+}
+
+// JobExtractionSchema returns the JSON schema for job extraction responses.
+func (c *Config) JobExtractionSchema() Schema {
+	confidence := Schema{}
+	for _, field := range models.JobExtractionFields {
+		confidence[field] = Schema{"type": "number", "minimum": 0, "maximum": 1}
+	}
+
+	return Schema{
+		"type": "object",
+		"properties": Schema{
+			"isValid":        Schema{"type": "boolean", "description": "Whether the text is a job posting"},
+			"reason":         stringProp("Reason for rejection if the text is not a job posting (only required when isValid is false)"),
+			"title":          stringProp("Job title without the company or location"),
+			"company":        stringProp("Hiring company name"),
+			"location":       stringProp("Job location, or 'Remote'"),
+			"jobType":        Schema{"type": "string", "enum": prompts.ExtractionJobTypes()},
+			"seniority":      Schema{"type": "string", "description": "Seniority level, empty if not stated", "enum": append(prompts.ExtractionSeniorities(), "")},
+			"requiredSkills": stringArrayProp("Required skills and technologies, most important first"),
+			"salaryMin":      Schema{"type": "integer", "description": "Lower bound of the salary range, 0 if not stated"},
+			"salaryMax":      Schema{"type": "integer", "description": "Upper bound of the salary range, 0 if not stated"},
+			"salaryCurrency": stringProp("ISO 4217 currency code of the salary"),
+			"salaryPeriod":   stringProp("Salary period: year, month, day or hour"),
+			"applicationUrl": stringProp("URL to apply for the job, only if present in the text"),
+			"confidence": Schema{
+				"type":        "object",
+				"description": "Confidence from 0 to 1 for each extracted field",
+				"properties":  confidence,
+			},
+		},
+		"required": []string{"isValid"},
+	}
+}
+
+// CVParsingSchema returns the JSON schema for CV parsing responses.
+func (c *Config) CVParsingSchema() Schema {
+	return Schema{
+		"type": "object",
+		"properties": Schema{
+			"isValid": Schema{"type": "boolean", "description": "Whether the document is a valid CV/Resume"},
+			"reason":  stringProp("Reason for rejection if document is not valid (only required when isValid is false)"),
+			"personalInfo": Schema{
+				"type": "object",
+				"properties": Schema{
+					"firstName": stringProp("First name"),
+					"lastName":  stringProp("Last name"),
+					"email":     stringProp("Email address"),
+					"phone":     stringProp("Phone number"),
+					"location":  stringProp("Location/Address"),
+					"title":     stringProp("Professional title or role"),
+				},
+				"required": []string{"firstName", "lastName"},
+			},
+			"workExperience": Schema{
+				"type": "array",
+				"items": Schema{
+					"type": "object",
+					"properties": Schema{
+						"company":     stringProp("Company name"),
+						"title":       stringProp("Job title"),
+						"location":    stringProp("Job location"),
+						"startDate":   stringProp("Start date (YYYY-MM or YYYY format)"),
+						"endDate":     stringProp("End date (YYYY-MM, YYYY, or 'Present')"),
+						"description": stringProp("Job description/responsibilities"),
+					},
+					"required": []string{"company", "title", "startDate"},
+				},
+			},
+			"education": Schema{
+				"type": "array",
+				"items": Schema{
+					"type": "object",
+					"properties": Schema{
+						"institution":  stringProp("Educational institution name"),
+						"degree":       stringProp("Degree type (e.g., BA, BS, Master)"),
+						"fieldOfStudy": stringProp("Field of study/major"),
+						"startDate":    stringProp("Start date (YYYY-MM or YYYY format)"),
+						"endDate":      stringProp("End date (YYYY-MM or YYYY format)"),
+					},
+					"required": []string{"institution", "degree", "startDate"},
+				},
+			},
+			"certifications": Schema{
+				"type": "array",
+				"items": Schema{
+					"type": "object",
+					"properties": Schema{
+						"name":          stringProp("Certification name"),
+						"issuingOrg":    stringProp("Issuing organization"),
+						"issueDate":     stringProp("Issue date (YYYY-MM or YYYY format)"),
+						"expiryDate":    stringProp("Expiry date (YYYY-MM or YYYY format)"),
+						"credentialId":  stringProp("Credential ID or certificate number"),
+						"credentialUrl": stringProp("URL to verify credential"),
+					},
+					"required": []string{"name", "issuingOrg", "issueDate"},
+				},
+			},
+			"skills": stringArrayProp("List of skills and technologies"),
+		},
+		"required": []string{"isValid"},
+	}
+}
+
+// CVGenerationSchema returns the JSON schema for generated CV responses.
+func (c *Config) CVGenerationSchema() Schema {
+	return Schema{
+		"type": "object",
+		"properties": Schema{
+			"isValid": Schema{"type": "boolean", "description": "Always true for generated CVs"},
+			"personalInfo": Schema{
+				"type": "object",
+				"properties": Schema{
+					"firstName": stringProp("First name EXACTLY as provided in USER PROFILE - do not fabricate"),
+					"lastName":  stringProp("Last name EXACTLY as provided in USER PROFILE - do not fabricate"),
+					"email":     stringProp("Email EXACTLY as provided in USER PROFILE - leave empty if not provided"),
+					"phone":     stringProp("Phone EXACTLY as provided in USER PROFILE - leave empty if not provided"),
+					"location":  stringProp("Location EXACTLY as provided in USER PROFILE - leave empty if not provided"),
+					"title":     stringProp("Professional title based on profile, can be tailored to match job"),
+				},
+			},
+			"workExperience": Schema{
+				"type":        "array",
+				"description": "ONLY work experiences from USER PROFILE - do not add fictional jobs",
+				"items": Schema{
+					"type": "object",
+					"properties": Schema{
+						"company":     stringProp("Company name EXACTLY as in USER PROFILE - do not fabricate"),
+						"title":       stringProp("Job title EXACTLY as in USER PROFILE - do not fabricate"),
+						"location":    stringProp("Job location (city, country) from USER PROFILE - MUST include if available in profile"),
+						"startDate":   stringProp("Start date from USER PROFILE (Month Year format, e.g., 'August 2023')"),
+						"endDate":     stringProp("End date from USER PROFILE (Month Year format, e.g., 'June 2024' or 'Present')"),
+						"description": stringProp("Multiple bullet points (4-5 for recent, 2-3 for older) starting with '• '. Each on new line. From USER PROFILE but tailored."),
+					},
+					"required": []string{"company", "title", "startDate", "description"},
+				},
+			},
+			"education": Schema{
+				"type":        "array",
+				"description": "ONLY education from USER PROFILE - do not add fictional schools",
+				"items": Schema{
+					"type": "object",
+					"properties": Schema{
+						"institution":  stringProp("School/University EXACTLY as in USER PROFILE - do not fabricate"),
+						"degree":       stringProp("Degree EXACTLY as in USER PROFILE - do not fabricate"),
+						"fieldOfStudy": stringProp("Field of study from USER PROFILE"),
+						"startDate":    stringProp("Start date from USER PROFILE (Month Year format, e.g., 'Sep 2014')"),
+						"endDate":      stringProp("End date from USER PROFILE (Month Year format, e.g., 'Jun 2018')"),
+					},
+					"required": []string{"institution", "degree"},
+				},
+			},
+			"skills": stringArrayProp("ONLY skills from USER PROFILE that are DIRECTLY RELEVANT to the job. Filter out unrelated technologies (e.g., no Java/Spring for Python jobs). Order by relevance."),
+		},
+		"required": []string{"isValid"},
+	}
+}
+
+// InterviewPrepSchema returns the JSON schema for interview preparation responses.
+func (c *Config) InterviewPrepSchema() Schema {
+	return Schema{
+		"type": "object",
+		"properties": Schema{
+			"questions": Schema{
+				"type":        "array",
+				"description": "Questions the candidate is likely to be asked, most likely first",
+				"items": Schema{
+					"type": "object",
+					"properties": Schema{
+						"question":   stringProp("The interview question"),
+						"reason":     stringProp("Why the interviewer is likely to ask it"),
+						"experience": stringProp("Role from the candidate's work experience the answer draws on, as 'Title at Company'"),
+						"answer": Schema{
+							"type": "object",
+							"properties": Schema{
+								"situation": stringProp("Situation: the context of the story"),
+								"task":      stringProp("Task: what the candidate had to achieve"),
+								"action":    stringProp("Action: what the candidate did"),
+								"result":    stringProp("Result: the outcome"),
+							},
+							"required": []string{"situation", "task", "action", "result"},
+						},
+					},
+					"required": []string{"question", "answer"},
+				},
+			},
+			"questionsToAsk": stringArrayProp("Questions the candidate could ask the interviewers"),
+			"gapsToRehearse": stringArrayProp("Gaps between the profile and the job to rehearse answers for"),
+		},
+		"required": []string{"questions", "questionsToAsk", "gapsToRehearse"},
+	}
+}
@@ -0,0 +1,77 @@
+package structured
+
+import "sort"
+
+// StrictSchema returns a copy of schema that meets the rules of OpenAI's
+// strict structured outputs: every object lists all of its properties as
+// required and allows no others. Properties the schema leaves optional may
+// be null instead, which decodes to the zero value the parsers already treat
+// as missing.
+func StrictSchema(schema Schema) Schema {
+	strict := make(Schema, len(schema))
+	for key, value := range schema {
+		strict[key] = value
+	}
+
+	if items, ok := schema["items"].(Schema); ok {
+		strict["items"] = StrictSchema(items)
+	}
+
+	properties, ok := schema["properties"].(Schema)
+	if !ok {
+		return strict
+	}
+
+	required, _ := schema["required"].([]string)
+	isRequired := make(map[string]bool, len(required))
+	for _, name := range required {
+		isRequired[name] = true
+	}
+
+	strictProperties := make(Schema, len(properties))
+	var optional []string
+	for name, value := range properties {
+		property, ok := value.(Schema)
+		if !ok {
+			strictProperties[name] = value
+			continue
+		}
+		property = StrictSchema(property)
+		if !isRequired[name] {
+			property = nullable(property)
+			optional = append(optional, name)
+		}
+		strictProperties[name] = property
+	}
+	sort.Strings(optional)
+
+	allRequired := make([]string, 0, len(properties))
+	for _, name := range required {
+		if _, ok := properties[name]; ok {
+			allRequired = append(allRequired, name)
+		}
+	}
+
+	strict["properties"] = strictProperties
+	strict["required"] = append(allRequired, optional...)
+	strict["additionalProperties"] = false
+	return strict
+}
+
+// nullable allows a property to be null as well as its own type
+func nullable(property Schema) Schema {
+	kind, ok := property["type"].(string)
+	if !ok {
+		return property
+	}
+	property["type"] = []string{kind, "null"}
+
+	if enum, ok := property["enum"].([]string); ok {
+		values := make([]any, 0, len(enum)+1)
+		for _, value := range enum {
+			values = append(values, value)
+		}
+		property["enum"] = append(values, nil)
+	}
+	return property
+}
@@ -0,0 +1,62 @@
+package structured
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrictSchema(t *testing.T) {
+	schema := Schema{
+		"type": "object",
+		"properties": Schema{
+			"title":  stringProp("Title"),
+			"level":  Schema{"type": "string", "enum": []string{"junior", "senior"}},
+			"skills": stringArrayProp("Skills"),
+			"roles": Schema{
+				"type": "array",
+				"items": Schema{
+					"type": "object",
+					"properties": Schema{
+						"company": stringProp("Company"),
+						"endDate": stringProp("End date"),
+					},
+					"required": []string{"company"},
+				},
+			},
+		},
+		"required": []string{"title"},
+	}
+
+	strict := StrictSchema(schema)
+
+	assert.Equal(t, false, strict["additionalProperties"])
+	assert.Equal(t, []string{"title", "level", "roles", "skills"}, strict["required"])
+
+	properties := strict["properties"].(Schema)
+	assert.Equal(t, "string", properties["title"].(Schema)["type"])
+	assert.Equal(t, []string{"string", "null"}, properties["level"].(Schema)["type"])
+	assert.Equal(t, []any{"junior", "senior", nil}, properties["level"].(Schema)["enum"])
+	assert.Equal(t, []string{"array", "null"}, properties["skills"].(Schema)["type"])
+
+	role := properties["roles"].(Schema)["items"].(Schema)
+	assert.Equal(t, false, role["additionalProperties"])
+	assert.Equal(t, []string{"company", "endDate"}, role["required"])
+	assert.Equal(t, []string{"string", "null"}, role["properties"].(Schema)["endDate"].(Schema)["type"])
+
+	assert.Equal(t, []string{"title"}, schema["required"], "the original schema is unchanged")
+	assert.NotContains(t, schema, "additionalProperties")
+	assert.Equal(t, "string", schema["properties"].(Schema)["level"].(Schema)["type"])
+}
+
+func TestStrictSchema_NullableFieldsParse(t *testing.T) {
+	cfg := NewConfig()
+
+	result, err := cfg.ParseCV(`{"isValid": true, "reason": null, "personalInfo": {"firstName": "Jane", "lastName": "Doe", "email": null, "phone": null, "location": null, "title": null}, "workExperience": null, "education": null, "certifications": null, "skills": null}`)
+	require.NoError(t, err)
+	assert.Equal(t, "Jane", result.PersonalInfo.FirstName)
+	assert.Empty(t, result.PersonalInfo.Email)
+	assert.NotNil(t, result.WorkExperience)
+	assert.NotNil(t, result.Skills)
+}
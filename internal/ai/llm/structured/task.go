@@ -0,0 +1,177 @@
+package structured
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/benidevo/vega/internal/ai/prompts"
+)
+
+// Task is a fully rendered, provider-independent description of a single
+// structured generation call.
+type Task struct {
+	TaskType          models.AITaskType
+	SchemaName        string
+	Schema            Schema
+	SystemInstruction string
+	UserPrompt        string
+	Temperature       float32
+	Enhanced          bool
+}
+
+// taskTypes maps each response type to the task type that serves it
+var taskTypes = map[llm.ResponseType]models.AITaskType{
+	llm.ResponseTypeCoverLetter:    models.TaskTypeCoverLetter,
+	llm.ResponseTypeMatchResult:    models.TaskTypeJobAnalysis,
+	llm.ResponseTypeCVParsing:      models.TaskTypeCVParsing,
+	llm.ResponseTypeJobExtraction:  models.TaskTypeJobExtraction,
+	llm.ResponseTypeCV:             models.TaskTypeCVGeneration,
+	llm.ResponseTypeInterviewPrep:  models.TaskTypeInterviewPrep,
+	llm.ResponseTypeEmail:          models.TaskTypeEmail,
+	llm.ResponseTypeLearningPlan:   models.TaskTypeLearningPlan,
+	llm.ResponseTypeSectionRewrite: models.TaskTypeSectionRewrite,
+}
+
+// TaskTypeFor returns the task type of the given response type, the same one
+// BuildTask uses.
+func TaskTypeFor(responseType llm.ResponseType) (models.AITaskType, bool) {
+	taskType, ok := taskTypes[responseType]
+	return taskType, ok
+}
+
+// BuildTask renders the prompt, system instruction, schema and temperature for
+// the given response type. The prompt's placeholder note, if any, ends the
+// user prompt.
+func (c *Config) BuildTask(responseType llm.ResponseType, prompt models.Prompt) (Task, error) {
+	task, err := c.buildTask(responseType, prompt)
+	if err != nil {
+		return Task{}, err
+	}
+	if note := strings.TrimSpace(prompt.PlaceholderNote); note != "" {
+		task.UserPrompt += "\n\n" + note
+	}
+	return task, nil
+}
+
+func (c *Config) buildTask(responseType llm.ResponseType, prompt models.Prompt) (Task, error) {
+	switch responseType {
+	case llm.ResponseTypeCoverLetter:
+		return Task{
+			TaskType:          models.TaskTypeCoverLetter,
+			SchemaName:        "cover_letter",
+			Schema:            c.CoverLetterSchema(),
+			SystemInstruction: c.SystemInstruction,
+			UserPrompt:        prompt.ToCoverLetterPrompt(c.DefaultWordRange),
+			Temperature:       prompt.GetOptimalTemperature(models.TaskTypeCoverLetter.String()),
+			Enhanced:          prompt.UseEnhancedTemplates,
+		}, nil
+	case llm.ResponseTypeMatchResult:
+		return Task{
+			TaskType:          models.TaskTypeJobAnalysis,
+			SchemaName:        "match_result",
+			Schema:            c.MatchAnalysisSchema(),
+			SystemInstruction: c.SystemInstruction,
+			UserPrompt:        prompt.ToMatchAnalysisPrompt(c.MinMatchScore, c.MaxMatchScore),
+			Temperature:       prompt.GetOptimalTemperature(models.TaskTypeJobAnalysis.String()),
+			Enhanced:          prompt.UseEnhancedTemplates,
+		}, nil
+	case llm.ResponseTypeCVParsing:
+		return Task{
+			TaskType:          models.TaskTypeCVParsing,
+			SchemaName:        "cv_parsing",
+			Schema:            c.CVParsingSchema(),
+			SystemInstruction: cvParsingSystemInstruction,
+			UserPrompt:        buildCVParsingPrompt(prompt.CVText, prompt.Language),
+			Temperature:       0.1, // low temperature for consistent parsing
+		}, nil
+	case llm.ResponseTypeJobExtraction:
+		return Task{
+			TaskType:          models.TaskTypeJobExtraction,
+			SchemaName:        "job_extraction",
+			Schema:            c.JobExtractionSchema(),
+			SystemInstruction: prompts.JobExtractionSystemInstruction,
+			UserPrompt:        prompt.ToJobExtractionPrompt(),
+			Temperature:       0.1, // low temperature for consistent extraction
+		}, nil
+	case llm.ResponseTypeCV:
+		return Task{
+			TaskType:          models.TaskTypeCVGeneration,
+			SchemaName:        "cv_generation",
+			Schema:            c.CVGenerationSchema(),
+			SystemInstruction: cvGenerationSystemInstruction,
+			UserPrompt:        prompt.ToCVGenerationPrompt(),
+			Temperature:       prompt.GetOptimalTemperature(models.TaskTypeCVGeneration.String()),
+			Enhanced:          prompt.UseEnhancedTemplates,
+		}, nil
+	case llm.ResponseTypeInterviewPrep:
+		return Task{
+			TaskType:          models.TaskTypeInterviewPrep,
+			SchemaName:        "interview_prep",
+			Schema:            c.InterviewPrepSchema(),
+			SystemInstruction: c.SystemInstruction,
+			UserPrompt:        prompt.ToInterviewPrepPrompt(),
+			Temperature:       prompt.GetOptimalTemperature(models.TaskTypeInterviewPrep.String()),
+			Enhanced:          prompt.UseEnhancedTemplates,
+		}, nil
+	case llm.ResponseTypeEmail:
+		return Task{
+			TaskType:          models.TaskTypeEmail,
+			SchemaName:        "email",
+			Schema:            c.EmailSchema(),
+			SystemInstruction: c.SystemInstruction,
+			UserPrompt:        prompt.ToEmailPrompt(),
+			Temperature:       prompt.GetOptimalTemperature(models.TaskTypeEmail.String()),
+			Enhanced:          prompt.UseEnhancedTemplates,
+		}, nil
+	case llm.ResponseTypeLearningPlan:
+		return Task{
+			TaskType:          models.TaskTypeLearningPlan,
+			SchemaName:        "learning_plan",
+			Schema:            c.LearningPlanSchema(),
+			SystemInstruction: c.SystemInstruction,
+			UserPrompt:        prompt.ToLearningPlanPrompt(),
+			Temperature:       prompt.GetOptimalTemperature(models.TaskTypeLearningPlan.String()),
+			Enhanced:          prompt.UseEnhancedTemplates,
+		}, nil
+	case llm.ResponseTypeSectionRewrite:
+		return Task{
+			TaskType:          models.TaskTypeSectionRewrite,
+			SchemaName:        "section_rewrite",
+			Schema:            c.SectionRewriteSchema(),
+			SystemInstruction: c.SystemInstruction,
+			UserPrompt:        prompt.ToSectionRewritePrompt(),
+			Temperature:       prompt.GetOptimalTemperature(models.TaskTypeSectionRewrite.String()),
+			Enhanced:          prompt.UseEnhancedTemplates,
+		}, nil
+	default:
+		return Task{}, WrapError(ErrUnsupportedResponseType, fmt.Errorf("response type: %s", responseType))
+	}
+}
+
+// Parse converts raw model output into the typed result expected for the response type.
+func (c *Config) Parse(responseType llm.ResponseType, raw string) (any, error) {
+	switch responseType {
+	case llm.ResponseTypeCoverLetter:
+		return c.ParseCoverLetter(raw)
+	case llm.ResponseTypeMatchResult:
+		return c.ParseMatchResult(raw)
+	case llm.ResponseTypeCVParsing:
+		return c.ParseCV(raw)
+	case llm.ResponseTypeJobExtraction:
+		return c.ParseJobExtraction(raw)
+	case llm.ResponseTypeCV:
+		return c.ParseGeneratedCV(raw)
+	case llm.ResponseTypeInterviewPrep:
+		return c.ParseInterviewPrep(raw)
+	case llm.ResponseTypeEmail:
+		return c.ParseEmail(raw)
+	case llm.ResponseTypeLearningPlan:
+		return c.ParseLearningPlan(raw)
+	case llm.ResponseTypeSectionRewrite:
+		return c.ParseSectionRewrite(raw)
+	default:
+		return nil, WrapError(ErrUnsupportedResponseType, fmt.Errorf("response type: %s", responseType))
+	}
+}
@@ -0,0 +1,181 @@
+package structured
+
+import (
+	"testing"
+
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_BuildTask(t *testing.T) {
+	cfg := NewConfig()
+	prompt := *models.NewPrompt("instructions", models.Request{
+		ApplicantName:    "Jane Doe",
+		ApplicantProfile: "Go developer",
+		JobDescription:   "Backend role",
+	}, false)
+
+	tests := []struct {
+		name         string
+		responseType llm.ResponseType
+		taskType     models.AITaskType
+		required     []string
+	}{
+		{"cover letter", llm.ResponseTypeCoverLetter, models.TaskTypeCoverLetter, []string{"content"}},
+		{"match result", llm.ResponseTypeMatchResult, models.TaskTypeJobAnalysis, []string{"matchScore", "strengths", "weaknesses", "highlights", "feedback"}},
+		{"cv parsing", llm.ResponseTypeCVParsing, models.TaskTypeCVParsing, []string{"isValid"}},
+		{"job extraction", llm.ResponseTypeJobExtraction, models.TaskTypeJobExtraction, []string{"isValid"}},
+		{"cv generation", llm.ResponseTypeCV, models.TaskTypeCVGeneration, []string{"isValid"}},
+		{"interview prep", llm.ResponseTypeInterviewPrep, models.TaskTypeInterviewPrep, []string{"questions", "questionsToAsk", "gapsToRehearse"}},
+		{"email", llm.ResponseTypeEmail, models.TaskTypeEmail, []string{"subject", "body"}},
+		{"learning plan", llm.ResponseTypeLearningPlan, models.TaskTypeLearningPlan, []string{"gaps"}},
+		{"section rewrite", llm.ResponseTypeSectionRewrite, models.TaskTypeSectionRewrite, []string{"content", "changes"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task, err := cfg.BuildTask(tt.responseType, prompt)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.taskType, task.TaskType)
+			taskType, ok := TaskTypeFor(tt.responseType)
+			assert.True(t, ok)
+			assert.Equal(t, tt.taskType, taskType)
+			assert.Equal(t, tt.required, task.Schema["required"])
+			assert.NotEmpty(t, task.SystemInstruction)
+			assert.NotEmpty(t, task.UserPrompt)
+			assert.Greater(t, task.Temperature, float32(0))
+		})
+	}
+
+	t.Run("unsupported response type", func(t *testing.T) {
+		_, err := cfg.BuildTask("unknown", prompt)
+		assert.ErrorIs(t, err, ErrUnsupportedResponseType)
+
+		_, ok := TaskTypeFor("unknown")
+		assert.False(t, ok)
+	})
+}
+
+func TestConfig_Parse(t *testing.T) {
+	cfg := NewConfig()
+
+	t.Run("match score out of range is clamped", func(t *testing.T) {
+		data, err := cfg.Parse(llm.ResponseTypeMatchResult, `{"matchScore": 150, "feedback": "ok"}`)
+		require.NoError(t, err)
+		result := data.(models.MatchResult)
+		assert.Equal(t, 0, result.MatchScore)
+		assert.Equal(t, []string{cfg.DefaultStrengthsMsg}, result.Strengths)
+	})
+
+	t.Run("empty cover letter is rejected", func(t *testing.T) {
+		_, err := cfg.Parse(llm.ResponseTypeCoverLetter, `{"content": ""}`)
+		assert.ErrorIs(t, err, ErrEmptyResponse)
+	})
+
+	t.Run("invalid CV is rejected with reason", func(t *testing.T) {
+		_, err := cfg.Parse(llm.ResponseTypeCVParsing, `{"isValid": false, "reason": "police report"}`)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "police report")
+	})
+
+	t.Run("interview prep without questions is rejected", func(t *testing.T) {
+		_, err := cfg.Parse(llm.ResponseTypeInterviewPrep, `{"questions": [], "questionsToAsk": ["Why now?"]}`)
+		assert.ErrorIs(t, err, ErrEmptyResponse)
+	})
+
+	t.Run("interview prep fills empty lists", func(t *testing.T) {
+		data, err := cfg.Parse(llm.ResponseTypeInterviewPrep, `{"questions": [{"question": "Why this role?", "answer": {"situation": "s", "task": "t", "action": "a", "result": "r"}}]}`)
+		require.NoError(t, err)
+		result := data.(models.InterviewPrep)
+		assert.Equal(t, "a", result.Questions[0].Answer.Action)
+		assert.Equal(t, []string{}, result.QuestionsToAsk)
+		assert.Equal(t, []string{}, result.GapsToRehearse)
+	})
+
+	t.Run("email without body is rejected", func(t *testing.T) {
+		_, err := cfg.Parse(llm.ResponseTypeEmail, `{"subject": "Checking in", "body": "  "}`)
+		assert.ErrorIs(t, err, ErrEmptyResponse)
+	})
+
+	t.Run("email subject prefix is removed", func(t *testing.T) {
+		data, err := cfg.Parse(llm.ResponseTypeEmail, `{"subject": "Subject: Checking in", "body": "Hi team"}`)
+		require.NoError(t, err)
+		assert.Equal(t, "Checking in", data.(models.Email).Subject)
+	})
+
+	t.Run("learning plan drops unnamed gaps", func(t *testing.T) {
+		data, err := cfg.Parse(llm.ResponseTypeLearningPlan, `{"gaps": [{"gap": " Kubernetes ", "steps": ["Run kind"]}, {"gap": ""}]}`)
+		require.NoError(t, err)
+		plan := data.(models.LearningPlan)
+		require.Len(t, plan.Gaps, 1)
+		assert.Equal(t, "Kubernetes", plan.Gaps[0].Gap)
+		assert.Equal(t, []string{}, plan.Gaps[0].Projects)
+	})
+
+	t.Run("learning plan without gaps is rejected", func(t *testing.T) {
+		_, err := cfg.Parse(llm.ResponseTypeLearningPlan, `{"gaps": []}`)
+		assert.ErrorIs(t, err, ErrEmptyResponse)
+	})
+
+	t.Run("job extraction is normalised", func(t *testing.T) {
+		data, err := cfg.Parse(llm.ResponseTypeJobExtraction, `{"isValid": true, "title": "Go Engineer", "company": "Acme", "jobType": "Contract", "confidence": {"title": 0.9}}`)
+		require.NoError(t, err)
+		result := data.(models.JobExtraction)
+		assert.Equal(t, "contract", result.JobType)
+		assert.Equal(t, 0.9, result.Confidence[models.JobFieldTitle])
+		assert.Equal(t, 0.0, result.Confidence[models.JobFieldCompany])
+		assert.Equal(t, []string{}, result.RequiredSkills)
+	})
+
+	t.Run("text that is not a job posting is rejected", func(t *testing.T) {
+		_, err := cfg.Parse(llm.ResponseTypeJobExtraction, `{"isValid": false, "reason": "news article"}`)
+		assert.ErrorIs(t, err, models.ErrNotJobPosting)
+		assert.Contains(t, err.Error(), "news article")
+	})
+
+	t.Run("section rewrite without content is rejected", func(t *testing.T) {
+		_, err := cfg.Parse(llm.ResponseTypeSectionRewrite, `{"content": " ", "changes": "Shortened"}`)
+		assert.ErrorIs(t, err, ErrEmptyResponse)
+	})
+
+	t.Run("malformed JSON", func(t *testing.T) {
+		_, err := cfg.Parse(llm.ResponseTypeCV, `not json`)
+		assert.ErrorIs(t, err, ErrResponseParseFailed)
+	})
+}
+
+func TestPartialStringField(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected string
+	}{
+		{"field not started", `{"cont`, ""},
+		{"value not started", `{"content": `, ""},
+		{"partial value", `{"content": "Dear Hiring`, "Dear Hiring"},
+		{"complete value", `{"content": "Dear\nTeam", "format": "plain"}`, "Dear\nTeam"},
+		{"escaped quote", `{"content": "say \"hi\" now`, `say "hi" now`},
+		{"cut escape", `{"content": "line\`, "line"},
+		{"cut unicode escape", `{"content": "caf\u00`, "caf"},
+		{"cut multibyte rune", "{\"content\": \"caf\xc3", "caf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, PartialStringField(tt.raw, "content"))
+		})
+	}
+}
+
+func TestBuildCVParsingPrompt(t *testing.T) {
+	cvText := "John Doe\nSoftware Engineer\n5 years experience"
+
+	result := buildCVParsingPrompt(cvText, "")
+
+	assert.Contains(t, result, "expert CV/Resume parser")
+	assert.Contains(t, result, cvText)
+	assert.Contains(t, result, "Document Text:")
+}
@@ -8,6 +8,9 @@ var (
 	// Validation errors
 	ErrValidationFailed = commonerrors.New("request validation failed")
 
+	// Extraction errors
+	ErrNotJobPosting = commonerrors.New("text is not a job posting")
+
 	// Setup errors
 	ErrProviderInitFailed  = commonerrors.New("failed to initialize AI provider")
 	ErrUnsupportedProvider = commonerrors.New("unsupported AI provider")
@@ -18,6 +18,11 @@ func TestErrorConstants(t *testing.T) {
 			err:      ErrValidationFailed,
 			expected: "request validation failed",
 		},
+		{
+			name:     "should_have_not_job_posting_error_message",
+			err:      ErrNotJobPosting,
+			expected: "text is not a job posting",
+		},
 		{
 			name:     "should_have_provider_init_failed_error_message",
 			err:      ErrProviderInitFailed,
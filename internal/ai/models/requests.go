@@ -2,6 +2,7 @@ package models
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/benidevo/vega/internal/ai/prompts"
 	"github.com/benidevo/vega/internal/ai/security"
@@ -12,11 +13,16 @@ import (
 type AITaskType string
 
 const (
-	TaskTypeCVParsing    AITaskType = "cv_parsing"
-	TaskTypeJobAnalysis  AITaskType = "job_analysis"
-	TaskTypeMatchResult  AITaskType = "match_result"
-	TaskTypeCoverLetter  AITaskType = "cover_letter"
-	TaskTypeCVGeneration AITaskType = "cv_generation"
+	TaskTypeCVParsing      AITaskType = "cv_parsing"
+	TaskTypeJobAnalysis    AITaskType = "job_analysis"
+	TaskTypeMatchResult    AITaskType = "match_result"
+	TaskTypeCoverLetter    AITaskType = "cover_letter"
+	TaskTypeCVGeneration   AITaskType = "cv_generation"
+	TaskTypeInterviewPrep  AITaskType = "interview_prep"
+	TaskTypeEmail          AITaskType = "email"
+	TaskTypeLearningPlan   AITaskType = "learning_plan"
+	TaskTypeJobExtraction  AITaskType = "job_extraction"
+	TaskTypeSectionRewrite AITaskType = "section_rewrite"
 )
 
 // String returns the string representation of the AITaskType
@@ -37,6 +43,162 @@ type Request struct {
 	Certifications  []settingsmodels.Certification  `json:"certifications,omitempty"`
 	Skills          []string                        `json:"skills,omitempty"`
 	YearsExperience int                             `json:"years_experience,omitempty"`
+
+	Email *EmailDetails `json:"email,omitempty"`
+
+	SkillGaps []SkillGapDetails `json:"skill_gaps,omitempty"`
+
+	Rewrite *RewriteDetails `json:"rewrite,omitempty"`
+
+	CoverLetter *CoverLetterOptions `json:"cover_letter,omitempty"`
+
+	// Language is the ISO 639-1 code of the language generated documents are
+	// written in. Empty means English.
+	Language string `json:"language,omitempty"`
+}
+
+// LetterTone is the tone a cover letter is written in.
+type LetterTone string
+
+const (
+	LetterToneFormal       LetterTone = prompts.LetterToneFormal
+	LetterToneWarm         LetterTone = prompts.LetterToneWarm
+	LetterToneConcise      LetterTone = prompts.LetterToneConcise
+	LetterToneEnthusiastic LetterTone = prompts.LetterToneEnthusiastic
+)
+
+// IsValid reports whether t is a known cover letter tone.
+func (t LetterTone) IsValid() bool {
+	for _, tone := range prompts.LetterTones() {
+		if string(t) == tone {
+			return true
+		}
+	}
+	return false
+}
+
+// LetterLength is the target length of a cover letter.
+type LetterLength string
+
+const (
+	LetterLengthShort    LetterLength = prompts.LetterLengthShort
+	LetterLengthStandard LetterLength = prompts.LetterLengthStandard
+	LetterLengthLong     LetterLength = prompts.LetterLengthLong
+)
+
+// IsValid reports whether l is a known cover letter length.
+func (l LetterLength) IsValid() bool {
+	for _, length := range prompts.LetterLengths() {
+		if string(l) == length {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultLanguage is the ISO 639-1 code of the language documents are written
+// in when none is chosen or detected.
+const DefaultLanguage = prompts.DefaultLanguage
+
+// IsValidLanguage reports whether documents can be written in the language
+// with the given ISO 639-1 code.
+func IsValidLanguage(code string) bool {
+	return prompts.LanguageName(code) != ""
+}
+
+// CoverLetterOptions controls how a cover letter is written. Empty fields use
+// the template's tone and the provider's word range.
+type CoverLetterOptions struct {
+	Tone   LetterTone   `json:"tone,omitempty"`
+	Length LetterLength `json:"length,omitempty"`
+}
+
+// Validate checks that every option that is set is known.
+func (o *CoverLetterOptions) Validate() error {
+	if o.Tone != "" && !o.Tone.IsValid() {
+		return fmt.Errorf("unknown cover letter tone %q", o.Tone)
+	}
+	if o.Length != "" && !o.Length.IsValid() {
+		return fmt.Errorf("unknown cover letter length %q", o.Length)
+	}
+	return nil
+}
+
+// SectionKind is a section of a generated document that can be rewritten on
+// its own.
+type SectionKind string
+
+const (
+	SectionSummary              SectionKind = prompts.SectionSummary
+	SectionExperience           SectionKind = prompts.SectionExperience
+	SectionSkills               SectionKind = prompts.SectionSkills
+	SectionCoverLetterParagraph SectionKind = prompts.SectionCoverLetterParagraph
+)
+
+// IsValid reports whether k is a section that can be rewritten.
+func (k SectionKind) IsValid() bool {
+	return prompts.SectionName(string(k)) != ""
+}
+
+// RewriteDetails describes the section to rewrite and how.
+type RewriteDetails struct {
+	Section     SectionKind `json:"section"`
+	Text        string      `json:"text"`
+	Instruction string      `json:"instruction"`
+}
+
+// SkillGapDetails describes a gap that keeps appearing in the applicant's job
+// analyses.
+type SkillGapDetails struct {
+	Name        string   `json:"name"`
+	Occurrences int      `json:"occurrences"`
+	JobTitles   []string `json:"job_titles,omitempty"`
+	Weaknesses  []string `json:"weaknesses,omitempty"`
+}
+
+// EmailKind is the kind of application email to write.
+type EmailKind string
+
+const (
+	EmailKindFollowUp    EmailKind = prompts.EmailFollowUp
+	EmailKindThankYou    EmailKind = prompts.EmailThankYou
+	EmailKindNegotiation EmailKind = prompts.EmailNegotiation
+)
+
+// IsValid reports whether k is a known email kind.
+func (k EmailKind) IsValid() bool {
+	return prompts.EmailTemplate(string(k)) != nil
+}
+
+// EmailTone is the tone an application email is written in.
+type EmailTone string
+
+const (
+	EmailToneProfessional EmailTone = prompts.EmailToneProfessional
+	EmailToneFriendly     EmailTone = prompts.EmailToneFriendly
+	EmailToneEnthusiastic EmailTone = prompts.EmailToneEnthusiastic
+	EmailToneFormal       EmailTone = prompts.EmailToneFormal
+)
+
+// IsValid reports whether t is a known email tone.
+func (t EmailTone) IsValid() bool {
+	for _, tone := range prompts.EmailTones() {
+		if string(t) == tone {
+			return true
+		}
+	}
+	return false
+}
+
+// EmailDetails describes the application email to write.
+type EmailDetails struct {
+	Kind        EmailKind `json:"kind"`
+	Tone        EmailTone `json:"tone"`
+	CompanyName string    `json:"company_name,omitempty"`
+	JobTitle    string    `json:"job_title,omitempty"`
+	JobStatus   string    `json:"job_status,omitempty"`
+	CoverLetter string    `json:"cover_letter,omitempty"`
+	Note        string    `json:"note,omitempty"`
 }
 
 // Prompt represents the structure for a prompt used in the application.
@@ -44,10 +206,14 @@ type Prompt struct {
 	Instructions string
 	Request
 	CVText               string
+	PostingText          string
 	UseEnhancedTemplates bool
 	Temperature          *float32
-	promptEnhancer       *prompts.PromptEnhancer
-	sanitizer            *security.PromptSanitizer
+	// PlaceholderNote tells the model how to treat placeholder tokens in a
+	// redacted prompt. Every task's prompt ends with it when set.
+	PlaceholderNote string
+	promptEnhancer  *prompts.PromptEnhancer
+	sanitizer       *security.PromptSanitizer
 }
 
 // NewPrompt creates a new prompt with optional enhanced features
@@ -79,6 +245,35 @@ func NewCVParsingPrompt(cvText string) *Prompt {
 	}
 }
 
+// NewJobExtractionPrompt creates a new prompt for extracting job details from
+// the text of a job posting
+func NewJobExtractionPrompt(postingText string) *Prompt {
+	return &Prompt{
+		Instructions:         "Extract structured job details from a job posting",
+		PostingText:          postingText,
+		UseEnhancedTemplates: false,
+		sanitizer:            security.NewPromptSanitizer(), // Always initialize sanitizer for security
+	}
+}
+
+// UsePromptVersion renders the prompt with the given template version instead
+// of the built-in one. It has no effect unless enhanced templates are used.
+func (p *Prompt) UsePromptVersion(version *prompts.Version) {
+	if version == nil || p.promptEnhancer == nil {
+		return
+	}
+	p.promptEnhancer.Use(version)
+}
+
+// PromptVersion returns the version of the named template this prompt renders
+// with, or an empty string when enhanced templates are not used.
+func (p Prompt) PromptVersion(name string) string {
+	if !p.UseEnhancedTemplates || p.promptEnhancer == nil {
+		return ""
+	}
+	return p.promptEnhancer.Version(name)
+}
+
 // SetTemperature sets a custom temperature for this prompt
 func (p *Prompt) SetTemperature(temp float32) {
 	p.Temperature = &temp
@@ -93,9 +288,25 @@ func (p *Prompt) GetOptimalTemperature(promptType string) float32 {
 	// Dynamic temperature based on task type
 	switch AITaskType(promptType) {
 	case TaskTypeCoverLetter:
+		if p.CoverLetter != nil {
+			switch p.CoverLetter.Tone {
+			case LetterToneFormal, LetterToneConcise:
+				return 0.5 // Tighter wording for restrained tones
+			case LetterToneEnthusiastic:
+				return 0.75 // More variety for an upbeat letter
+			}
+		}
 		return 0.65 // Higher creativity for writing
 	case TaskTypeCVGeneration:
 		return 0.55 // Higher creativity for CV content transformation
+	case TaskTypeInterviewPrep:
+		return 0.5 // Grounded answers with some variety in questions
+	case TaskTypeEmail:
+		return 0.6 // Natural phrasing for short personal emails
+	case TaskTypeLearningPlan:
+		return 0.5 // Practical plans with some variety in projects
+	case TaskTypeSectionRewrite:
+		return 0.45 // Close to the original wording with room to rephrase
 	case TaskTypeJobAnalysis:
 		return 0.2 // Lower for analytical consistency
 	default:
@@ -119,6 +330,13 @@ func (p Prompt) ToCoverLetterPrompt(defaultWordRange string) string {
 		sanitizedExtraContext = p.sanitizer.SanitizeExtraContext(p.ExtraContext)
 	}
 
+	wordRange := defaultWordRange
+	var tone string
+	if p.CoverLetter != nil {
+		wordRange = prompts.LetterWordRange(string(p.CoverLetter.Length), defaultWordRange)
+		tone = string(p.CoverLetter.Tone)
+	}
+
 	if p.UseEnhancedTemplates && p.promptEnhancer != nil {
 		return p.promptEnhancer.EnhanceCoverLetterPrompt(
 			sanitizedInstructions,
@@ -126,10 +344,21 @@ func (p Prompt) ToCoverLetterPrompt(defaultWordRange string) string {
 			sanitizedJobDescription,
 			sanitizedApplicantProfile,
 			sanitizedExtraContext,
-			defaultWordRange,
+			wordRange,
+			tone,
+			p.Language,
 		)
 	}
 
+	toneRequirement := "Write in a professional but personalized tone that reflects the candidate's personality"
+	if tone != "" {
+		toneRequirement = fmt.Sprintf("Write in a %s tone that still reflects the candidate's personality", tone)
+	}
+	languageRequirement := ""
+	if name := prompts.LanguageName(p.Language); name != "" && p.Language != prompts.DefaultLanguage {
+		languageRequirement = fmt.Sprintf("\n- Write the whole letter in %s", name)
+	}
+
 	return fmt.Sprintf(`%s
 
 Generate a professional cover letter with the following details:
@@ -140,7 +369,7 @@ Applicant Profile: %s
 %s
 
 Requirements:
-- Write in a professional but personalized tone that reflects the candidate's personality
+- %s%s
 - Highlight relevant skills and experiences from the applicant's profile that directly match the job requirements
 - Address specific requirements mentioned in the job description
 - Keep it concise (%s words)
@@ -165,7 +394,9 @@ Return a JSON object with ONLY this field:
 		sanitizedJobDescription,
 		sanitizedApplicantProfile,
 		sanitizedExtraContext,
-		defaultWordRange)
+		toneRequirement,
+		languageRequirement,
+		wordRange)
 }
 
 // ToCVGenerationPrompt builds a CV generation prompt with security sanitization.
@@ -189,9 +420,15 @@ func (p Prompt) ToCVGenerationPrompt() string {
 			sanitizedCVText,
 			sanitizedJobDescription,
 			sanitizedExtraContext,
+			p.Language,
 		)
 	}
 
+	languageInstruction := ""
+	if name := prompts.LanguageName(p.Language); name != "" && p.Language != prompts.DefaultLanguage {
+		languageInstruction = fmt.Sprintf("\n\nLANGUAGE: Write the title, summary, skills and descriptions in %s; keep names and dates exactly as provided and JSON field names in English", name)
+	}
+
 	return fmt.Sprintf(`%s
 
 Generate a tailored CV based on the user's profile and the job description.
@@ -213,7 +450,7 @@ INSTRUCTIONS:
 6. Use action verbs and quantify achievements where possible
 7. Keep descriptions concise and impactful
 8. CRITICAL: Use ONLY the information from the USER PROFILE above - do not make up names, companies, or experiences
-9. Format work experience descriptions as bullet points, each starting with "• " on a new line
+9. Format work experience descriptions as bullet points, each starting with "• " on a new line%s
 
 CRITICAL - ELIMINATE ALL AI LANGUAGE:
 10. BANNED WORDS/PHRASES: Never use "leverage", "utilize", "spearheaded", "orchestrated", "synergies", "cutting-edge", "innovative solutions", "dynamic", "passionate", "results-driven", "detail-oriented", "team player", "go-getter", "game-changer", "disruptive", "seamless", "robust", "scalable", "streamlined", "optimized", "enhanced", "facilitated", "collaborated with stakeholders", "deep dive", "circle back", "deliverables", "action items", "learnings", "best practices", "low-hanging fruit"
@@ -226,7 +463,8 @@ Generate a structured CV in JSON format following the exact schema requirements.
 		sanitizedInstructions,
 		sanitizedCVText,
 		sanitizedJobDescription,
-		sanitizedExtraContext)
+		sanitizedExtraContext,
+		languageInstruction)
 }
 
 // ToMatchAnalysisPrompt builds a job match analysis prompt from this Prompt
@@ -307,3 +545,240 @@ Return the analysis as a JSON object with EXACTLY this structure:
 		minMatchScore,
 		maxMatchScore)
 }
+
+// ToInterviewPrepPrompt builds an interview preparation prompt. Each entry in
+// WorkExperience is listed so suggested answers can be grounded in a real role.
+func (p Prompt) ToInterviewPrepPrompt() string {
+	sanitizedInstructions := p.Instructions
+	sanitizedApplicantName := p.ApplicantName
+	sanitizedJobDescription := p.JobDescription
+	sanitizedApplicantProfile := p.ApplicantProfile
+	sanitizedExtraContext := p.ExtraContext
+	workExperience := p.formatWorkExperience()
+
+	if p.sanitizer != nil {
+		sanitizedInstructions = p.sanitizer.SanitizeInstructions(p.Instructions)
+		sanitizedApplicantName = p.sanitizer.SanitizeText(p.ApplicantName)
+		sanitizedJobDescription = p.sanitizer.SanitizeJobDescription(p.JobDescription)
+		sanitizedApplicantProfile = p.sanitizer.SanitizeText(p.ApplicantProfile)
+		sanitizedExtraContext = p.sanitizer.SanitizeExtraContext(p.ExtraContext)
+	}
+
+	return prompts.EnhanceInterviewPrepPrompt(
+		sanitizedInstructions,
+		sanitizedApplicantName,
+		sanitizedJobDescription,
+		sanitizedApplicantProfile,
+		workExperience,
+		sanitizedExtraContext,
+	)
+}
+
+// formatWorkExperience lists the work experience entries as numbered roles
+// with their dates and descriptions. Each field is sanitized separately so the
+// list keeps its line breaks.
+func (p Prompt) formatWorkExperience() string {
+	sanitize := func(text string) string { return text }
+	if p.sanitizer != nil {
+		sanitize = p.sanitizer.SanitizeText
+	}
+
+	var b strings.Builder
+	for i, exp := range p.WorkExperience {
+		end := "Present"
+		if exp.EndDate != nil && !exp.Current {
+			end = exp.EndDate.Format("Jan 2006")
+		}
+
+		fmt.Fprintf(&b, "%d. %s at %s", i+1, sanitize(exp.Title), sanitize(exp.Company))
+		if exp.Location != "" {
+			fmt.Fprintf(&b, ", %s", sanitize(exp.Location))
+		}
+		fmt.Fprintf(&b, " (%s - %s)\n", exp.StartDate.Format("Jan 2006"), end)
+
+		if description := sanitize(exp.Description); description != "" {
+			fmt.Fprintf(&b, "   %s\n", strings.ReplaceAll(description, "\n", "\n   "))
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// ToEmailPrompt builds an application email prompt for the kind and tone in
+// Email. The company, status, note and saved cover letter are passed as
+// context for the email.
+func (p Prompt) ToEmailPrompt() string {
+	sanitizedInstructions := p.Instructions
+	sanitizedApplicantName := p.ApplicantName
+	sanitizedJobDescription := p.JobDescription
+	sanitizedApplicantProfile := p.ApplicantProfile
+	sanitizedExtraContext := p.ExtraContext
+
+	if p.sanitizer != nil {
+		sanitizedInstructions = p.sanitizer.SanitizeInstructions(p.Instructions)
+		sanitizedApplicantName = p.sanitizer.SanitizeText(p.ApplicantName)
+		sanitizedJobDescription = p.sanitizer.SanitizeJobDescription(p.JobDescription)
+		sanitizedApplicantProfile = p.sanitizer.SanitizeText(p.ApplicantProfile)
+		sanitizedExtraContext = p.sanitizer.SanitizeExtraContext(p.ExtraContext)
+	}
+
+	var email EmailDetails
+	if p.Email != nil {
+		email = *p.Email
+	}
+
+	return prompts.EnhanceEmailPrompt(
+		string(email.Kind),
+		string(email.Tone),
+		sanitizedInstructions,
+		sanitizedApplicantName,
+		sanitizedJobDescription,
+		sanitizedApplicantProfile,
+		p.formatEmailContext(email),
+		sanitizedExtraContext,
+	)
+}
+
+// formatEmailContext lists the details of the email being written. Each field
+// is sanitized separately so the list keeps its line breaks.
+func (p Prompt) formatEmailContext(email EmailDetails) string {
+	sanitize := func(text string) string { return text }
+	if p.sanitizer != nil {
+		sanitize = p.sanitizer.SanitizeText
+	}
+
+	var b strings.Builder
+	if email.CompanyName != "" {
+		fmt.Fprintf(&b, "Company: %s\n", sanitize(email.CompanyName))
+	}
+	if email.JobTitle != "" {
+		fmt.Fprintf(&b, "Role: %s\n", sanitize(email.JobTitle))
+	}
+	if email.JobStatus != "" {
+		fmt.Fprintf(&b, "Application status: %s\n", sanitize(email.JobStatus))
+	}
+	if note := sanitize(email.Note); note != "" {
+		fmt.Fprintf(&b, "Note from the applicant: %s\n", note)
+	}
+	if coverLetter := sanitize(email.CoverLetter); coverLetter != "" {
+		fmt.Fprintf(&b, "\nCover letter already sent:\n%s\n", coverLetter)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// ToLearningPlanPrompt builds a learning plan prompt for the gaps in
+// SkillGaps. JobDescription lists the roles the gaps were found in.
+func (p Prompt) ToLearningPlanPrompt() string {
+	sanitizedInstructions := p.Instructions
+	sanitizedApplicantName := p.ApplicantName
+	sanitizedJobDescription := p.JobDescription
+	sanitizedApplicantProfile := p.ApplicantProfile
+	sanitizedExtraContext := p.ExtraContext
+
+	if p.sanitizer != nil {
+		sanitizedInstructions = p.sanitizer.SanitizeInstructions(p.Instructions)
+		sanitizedApplicantName = p.sanitizer.SanitizeText(p.ApplicantName)
+		sanitizedJobDescription = p.sanitizer.SanitizeJobDescription(p.JobDescription)
+		sanitizedApplicantProfile = p.sanitizer.SanitizeText(p.ApplicantProfile)
+		sanitizedExtraContext = p.sanitizer.SanitizeExtraContext(p.ExtraContext)
+	}
+
+	return prompts.EnhanceLearningPlanPrompt(
+		sanitizedInstructions,
+		sanitizedApplicantName,
+		sanitizedJobDescription,
+		sanitizedApplicantProfile,
+		p.formatSkillGaps(),
+		sanitizedExtraContext,
+	)
+}
+
+// formatSkillGaps lists the skill gaps as numbered entries with how often
+// they appeared and the weaknesses that mention them. Each field is sanitized
+// separately so the list keeps its line breaks.
+func (p Prompt) formatSkillGaps() string {
+	sanitize := func(text string) string { return text }
+	if p.sanitizer != nil {
+		sanitize = p.sanitizer.SanitizeText
+	}
+
+	var b strings.Builder
+	for i, gap := range p.SkillGaps {
+		jobs := "job"
+		if gap.Occurrences != 1 {
+			jobs = "jobs"
+		}
+		fmt.Fprintf(&b, "%d. %s (seen in %d analysed %s)\n", i+1, sanitize(gap.Name), gap.Occurrences, jobs)
+
+		if len(gap.JobTitles) > 0 {
+			titles := make([]string, len(gap.JobTitles))
+			for j, title := range gap.JobTitles {
+				titles[j] = sanitize(title)
+			}
+			fmt.Fprintf(&b, "   Roles: %s\n", strings.Join(titles, "; "))
+		}
+		for _, weakness := range gap.Weaknesses {
+			if weakness = sanitize(weakness); weakness != "" {
+				fmt.Fprintf(&b, "   Feedback: %s\n", weakness)
+			}
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// ToJobExtractionPrompt builds a job extraction prompt for the posting text.
+func (p Prompt) ToJobExtractionPrompt() string {
+	sanitizedPostingText := p.PostingText
+	if p.sanitizer != nil {
+		sanitizedPostingText = p.sanitizer.SanitizeJobDescription(p.PostingText)
+	}
+
+	return prompts.JobExtractionPrompt(sanitizedPostingText)
+}
+
+// ToSectionRewritePrompt builds a prompt to rewrite the section in Rewrite
+// following its instruction.
+func (p Prompt) ToSectionRewritePrompt() string {
+	sanitizedInstructions := p.Instructions
+	sanitizedApplicantName := p.ApplicantName
+	sanitizedJobDescription := p.JobDescription
+	sanitizedApplicantProfile := p.ApplicantProfile
+	sanitizedExtraContext := p.ExtraContext
+
+	var rewrite RewriteDetails
+	if p.Rewrite != nil {
+		rewrite = *p.Rewrite
+	}
+	sanitizedRewriteInstruction := rewrite.Instruction
+	sanitizedSectionText := rewrite.Text
+
+	if p.sanitizer != nil {
+		sanitizedInstructions = p.sanitizer.SanitizeInstructions(p.Instructions)
+		sanitizedApplicantName = p.sanitizer.SanitizeText(p.ApplicantName)
+		sanitizedJobDescription = p.sanitizer.SanitizeJobDescription(p.JobDescription)
+		sanitizedApplicantProfile = p.sanitizer.SanitizeText(p.ApplicantProfile)
+		sanitizedExtraContext = p.sanitizer.SanitizeExtraContext(p.ExtraContext)
+		sanitizedRewriteInstruction = p.sanitizer.SanitizeInstructions(rewrite.Instruction)
+		sanitizedSectionText = p.sanitizeLines(rewrite.Text)
+	}
+
+	return prompts.EnhanceSectionRewritePrompt(
+		string(rewrite.Section),
+		sanitizedRewriteInstruction,
+		sanitizedInstructions,
+		sanitizedApplicantName,
+		sanitizedJobDescription,
+		sanitizedApplicantProfile,
+		sanitizedSectionText,
+		sanitizedExtraContext,
+	)
+}
+
+// sanitizeLines sanitizes text line by line so bullet points and paragraphs
+// keep their line breaks.
+func (p Prompt) sanitizeLines(text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	for i, line := range lines {
+		lines[i] = p.sanitizer.SanitizeText(line)
+	}
+	return strings.Join(lines, "\n")
+}
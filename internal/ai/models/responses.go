@@ -1,5 +1,11 @@
 package models
 
+import (
+	"strings"
+
+	"github.com/benidevo/vega/internal/ai/prompts"
+)
+
 // MatchResult represents the result of a matching process, including the match score,
 // identified strengths and weaknesses, key highlights, and overall feedback.
 type MatchResult struct {
@@ -8,6 +14,9 @@ type MatchResult struct {
 	Weaknesses []string `json:"weaknesses"`
 	Highlights []string `json:"highlights"`
 	Feedback   string   `json:"feedback"`
+
+	// PromptVersion is the version of the prompt template that produced the result
+	PromptVersion string `json:"promptVersion,omitempty"`
 }
 
 // CoverLetterFormat defines the format type for a cover letter, such as HTML, Markdown, or plain text.
@@ -22,8 +31,9 @@ const (
 
 // CoverLetter represents a cover letter with its format and content.
 type CoverLetter struct {
-	Format  CoverLetterFormat `json:"format"`
-	Content string            `json:"content"`
+	Format        CoverLetterFormat `json:"format"`
+	Content       string            `json:"content"`
+	PromptVersion string            `json:"promptVersion,omitempty"`
 }
 
 // CVParsingResult represents the structured data extracted from a CV/resume
@@ -35,6 +45,7 @@ type CVParsingResult struct {
 	Education      []Education      `json:"education,omitempty"`
 	Certifications []Certification  `json:"certifications,omitempty"`
 	Skills         []string         `json:"skills,omitempty"`
+	Language       string           `json:"language,omitempty"` // ISO 639-1 code of the CV's language, if known
 }
 
 // PersonalInfo contains basic personal information from a CV
@@ -81,7 +92,212 @@ type Certification struct {
 // GeneratedCV represents a CV generated for a specific job application
 type GeneratedCV struct {
 	CVParsingResult
-	GeneratedAt int64  `json:"generatedAt"` // Unix timestamp
-	JobID       int    `json:"jobId"`
-	JobTitle    string `json:"jobTitle"`
+	GeneratedAt   int64  `json:"generatedAt"` // Unix timestamp
+	JobID         int    `json:"jobId"`
+	JobTitle      string `json:"jobTitle"`
+	PromptVersion string `json:"promptVersion,omitempty"`
+}
+
+// InterviewPrep is a preparation pack for an upcoming interview.
+type InterviewPrep struct {
+	Questions      []InterviewQuestion `json:"questions"`
+	QuestionsToAsk []string            `json:"questionsToAsk"`
+	GapsToRehearse []string            `json:"gapsToRehearse"`
+	PromptVersion  string              `json:"promptVersion,omitempty"`
+}
+
+// InterviewQuestion is a question the applicant is likely to be asked, with a
+// suggested answer drawn from one of their roles.
+type InterviewQuestion struct {
+	Question   string     `json:"question"`
+	Reason     string     `json:"reason,omitempty"`     // Why the interviewer is likely to ask it
+	Experience string     `json:"experience,omitempty"` // Role the answer draws on, e.g. "Engineer at Acme"
+	Answer     STARAnswer `json:"answer"`
+}
+
+// STARAnswer is an answer structured as situation, task, action and result.
+type STARAnswer struct {
+	Situation string `json:"situation"`
+	Task      string `json:"task"`
+	Action    string `json:"action"`
+	Result    string `json:"result"`
+}
+
+// Email is a generated application email.
+type Email struct {
+	Subject       string `json:"subject"`
+	Body          string `json:"body"`
+	PromptVersion string `json:"promptVersion,omitempty"`
+}
+
+// LearningPlan is a plan for closing the applicant's most frequent skill gaps.
+type LearningPlan struct {
+	Gaps          []GapPlan `json:"gaps"`
+	PromptVersion string    `json:"promptVersion,omitempty"`
+}
+
+// GapPlan is the plan for a single skill gap.
+type GapPlan struct {
+	Gap      string   `json:"gap"`
+	Why      string   `json:"why,omitempty"`
+	Steps    []string `json:"steps"`
+	Projects []string `json:"projects"`
+	Phrasing []string `json:"phrasing"` // How to describe the new experience on a CV
+}
+
+// Confidence keys for the fields of a JobExtraction.
+const (
+	JobFieldTitle          = "title"
+	JobFieldCompany        = "company"
+	JobFieldLocation       = "location"
+	JobFieldJobType        = "jobType"
+	JobFieldRequiredSkills = "requiredSkills"
+	JobFieldSalary         = "salary"
+	JobFieldSeniority      = "seniority"
+	JobFieldApplicationURL = "applicationUrl"
+)
+
+// JobExtractionFields lists the fields a JobExtraction reports a confidence for.
+var JobExtractionFields = []string{
+	JobFieldTitle,
+	JobFieldCompany,
+	JobFieldLocation,
+	JobFieldJobType,
+	JobFieldRequiredSkills,
+	JobFieldSalary,
+	JobFieldSeniority,
+	JobFieldApplicationURL,
+}
+
+// JobExtraction is the structured data extracted from a pasted job posting.
+type JobExtraction struct {
+	IsValid        bool               `json:"isValid"`
+	Reason         string             `json:"reason,omitempty"`
+	Title          string             `json:"title"`
+	Company        string             `json:"company"`
+	Location       string             `json:"location"`
+	JobType        string             `json:"jobType"`   // One of prompts.ExtractionJobTypes
+	Seniority      string             `json:"seniority"` // One of prompts.ExtractionSeniorities, or empty
+	RequiredSkills []string           `json:"requiredSkills"`
+	SalaryMin      int                `json:"salaryMin"`
+	SalaryMax      int                `json:"salaryMax"`
+	SalaryCurrency string             `json:"salaryCurrency,omitempty"`
+	SalaryPeriod   string             `json:"salaryPeriod,omitempty"`
+	ApplicationURL string             `json:"applicationUrl,omitempty"`
+	Confidence     map[string]float64 `json:"confidence"` // Keyed by JobExtractionFields, from 0 to 1
+}
+
+// Normalize trims the extracted values, maps the job type and seniority onto
+// the allowed values and gives every field a confidence between 0 and 1.
+// Fields that were left empty always have a confidence of 0.
+func (e *JobExtraction) Normalize() {
+	e.Title = strings.TrimSpace(e.Title)
+	e.Company = strings.TrimSpace(e.Company)
+	e.Location = strings.TrimSpace(e.Location)
+	e.SalaryCurrency = strings.ToUpper(strings.TrimSpace(e.SalaryCurrency))
+	e.SalaryPeriod = strings.ToLower(strings.TrimSpace(e.SalaryPeriod))
+	e.ApplicationURL = strings.TrimSpace(e.ApplicationURL)
+	if !strings.HasPrefix(e.ApplicationURL, "http://") && !strings.HasPrefix(e.ApplicationURL, "https://") {
+		e.ApplicationURL = ""
+	}
+
+	e.JobType = normalizeChoice(e.JobType, prompts.ExtractionJobTypes())
+	if e.JobType == "" {
+		e.JobType = "other"
+	}
+	e.Seniority = normalizeChoice(e.Seniority, prompts.ExtractionSeniorities())
+
+	skills := make([]string, 0, len(e.RequiredSkills))
+	seen := make(map[string]bool, len(e.RequiredSkills))
+	for _, skill := range e.RequiredSkills {
+		skill = strings.TrimSpace(skill)
+		key := strings.ToLower(skill)
+		if skill == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		skills = append(skills, skill)
+	}
+	e.RequiredSkills = skills
+
+	if e.SalaryMin < 0 {
+		e.SalaryMin = 0
+	}
+	if e.SalaryMax < 0 {
+		e.SalaryMax = 0
+	}
+	if e.SalaryMax == 0 {
+		e.SalaryMax = e.SalaryMin
+	}
+	if e.SalaryMin == 0 || e.SalaryMin > e.SalaryMax {
+		e.SalaryMin = e.SalaryMax
+	}
+
+	empty := map[string]bool{
+		JobFieldTitle:          e.Title == "",
+		JobFieldCompany:        e.Company == "",
+		JobFieldLocation:       e.Location == "",
+		JobFieldRequiredSkills: len(e.RequiredSkills) == 0,
+		JobFieldSalary:         e.SalaryMax == 0,
+		JobFieldSeniority:      e.Seniority == "",
+		JobFieldApplicationURL: e.ApplicationURL == "",
+	}
+	confidence := make(map[string]float64, len(JobExtractionFields))
+	for _, field := range JobExtractionFields {
+		value := e.Confidence[field]
+		switch {
+		case empty[field] || value < 0:
+			value = 0
+		case value > 1:
+			value = 1
+		}
+		confidence[field] = value
+	}
+	e.Confidence = confidence
+}
+
+// normalizeChoice maps value onto one of choices, accepting differences in
+// case and separators such as "Full-time" for "full_time". It returns an empty
+// string when nothing matches.
+func normalizeChoice(value string, choices []string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	value = strings.NewReplacer("-", "_", " ", "_").Replace(value)
+	for _, choice := range choices {
+		if value == choice {
+			return choice
+		}
+	}
+	return ""
+}
+
+// SectionRewrite is a rewritten section of a CV or cover letter.
+type SectionRewrite struct {
+	Content string `json:"content"`
+	Changes string `json:"changes"`
+}
+
+// Normalize trims the rewrite and, for a skills list, puts the skills back on
+// a single comma separated line without duplicates.
+func (r *SectionRewrite) Normalize(section SectionKind) {
+	r.Content = strings.TrimSpace(r.Content)
+	r.Changes = strings.TrimSpace(r.Changes)
+	if section != SectionSkills {
+		return
+	}
+
+	fields := strings.FieldsFunc(r.Content, func(c rune) bool {
+		return c == ',' || c == '\n' || c == ';' || c == '•'
+	})
+	seen := make(map[string]bool, len(fields))
+	skills := make([]string, 0, len(fields))
+	for _, field := range fields {
+		skill := strings.TrimSuffix(strings.TrimSpace(field), ".")
+		key := strings.ToLower(skill)
+		if skill == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		skills = append(skills, skill)
+	}
+	r.Content = strings.Join(skills, ", ")
 }
@@ -423,3 +423,68 @@ func TestGeneratedCV(t *testing.T) {
 		assert.Equal(t, int64(1234567890), generatedCV.GeneratedAt)
 	})
 }
+
+func TestJobExtraction_Normalize(t *testing.T) {
+	t.Run("should_map_values_and_clamp_confidence", func(t *testing.T) {
+		extraction := JobExtraction{
+			IsValid:        true,
+			Title:          "  Senior Go Engineer ",
+			Company:        "Example Ltd",
+			JobType:        "Full-Time",
+			Seniority:      "Senior",
+			RequiredSkills: []string{"Go", " go", "", "Kubernetes"},
+			SalaryMin:      90000,
+			SalaryCurrency: "gbp",
+			ApplicationURL: "javascript:alert(1)",
+			Confidence:     map[string]float64{"title": 1.4, "company": 0.8, "location": 0.9, "seniority": -1},
+		}
+
+		extraction.Normalize()
+
+		assert.Equal(t, "Senior Go Engineer", extraction.Title)
+		assert.Equal(t, "full_time", extraction.JobType)
+		assert.Equal(t, "senior", extraction.Seniority)
+		assert.Equal(t, []string{"Go", "Kubernetes"}, extraction.RequiredSkills)
+		assert.Equal(t, 90000, extraction.SalaryMin)
+		assert.Equal(t, 90000, extraction.SalaryMax)
+		assert.Equal(t, "GBP", extraction.SalaryCurrency)
+		assert.Empty(t, extraction.ApplicationURL)
+
+		assert.Len(t, extraction.Confidence, len(JobExtractionFields))
+		assert.Equal(t, 1.0, extraction.Confidence[JobFieldTitle])
+		assert.Equal(t, 0.8, extraction.Confidence[JobFieldCompany])
+		assert.Equal(t, 0.0, extraction.Confidence[JobFieldLocation], "empty fields have no confidence")
+		assert.Equal(t, 0.0, extraction.Confidence[JobFieldSeniority])
+		assert.Equal(t, 0.0, extraction.Confidence[JobFieldApplicationURL])
+	})
+
+	t.Run("should_default_unknown_job_type_and_seniority", func(t *testing.T) {
+		extraction := JobExtraction{JobType: "permanent", Seniority: "guru", SalaryMin: 120000, SalaryMax: 100000}
+
+		extraction.Normalize()
+
+		assert.Equal(t, "other", extraction.JobType)
+		assert.Empty(t, extraction.Seniority)
+		assert.Equal(t, 100000, extraction.SalaryMin)
+		assert.NotNil(t, extraction.RequiredSkills)
+	})
+}
+
+func TestSectionRewrite_Normalize(t *testing.T) {
+	t.Run("should_rejoin_skills_without_duplicates", func(t *testing.T) {
+		rewrite := SectionRewrite{Content: "• Go\n• Kubernetes; go, Terraform.", Changes: " Grouped cloud tools. "}
+
+		rewrite.Normalize(SectionSkills)
+
+		assert.Equal(t, "Go, Kubernetes, Terraform", rewrite.Content)
+		assert.Equal(t, "Grouped cloud tools.", rewrite.Changes)
+	})
+
+	t.Run("should_keep_line_breaks_in_other_sections", func(t *testing.T) {
+		rewrite := SectionRewrite{Content: "\n• Led a team of 5\n• Cut costs by 20%\n"}
+
+		rewrite.Normalize(SectionExperience)
+
+		assert.Equal(t, "• Led a team of 5\n• Cut costs by 20%", rewrite.Content)
+	})
+}
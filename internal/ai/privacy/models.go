@@ -0,0 +1,40 @@
+package privacy
+
+import "github.com/benidevo/vega/internal/ai/llm"
+
+// Task is an AI task whose prompts can have personal details redacted.
+type Task struct {
+	Type        llm.ResponseType `json:"type"`
+	Label       string           `json:"label"`
+	Description string           `json:"description"`
+}
+
+// Tasks lists every AI task that can be redacted, in the order shown in
+// settings.
+var Tasks = []Task{
+	{Type: llm.ResponseTypeMatchResult, Label: "Job matching", Description: "Scoring how well your profile fits a job"},
+	{Type: llm.ResponseTypeCoverLetter, Label: "Cover letters", Description: "Writing cover letters"},
+	{Type: llm.ResponseTypeCV, Label: "CV generation", Description: "Tailoring your CV to a job"},
+	{Type: llm.ResponseTypeCVParsing, Label: "CV import", Description: "Reading an uploaded CV into your profile"},
+	{Type: llm.ResponseTypeInterviewPrep, Label: "Interview prep", Description: "Preparing interview questions and answers"},
+	{Type: llm.ResponseTypeEmail, Label: "Emails", Description: "Drafting follow-up and outreach emails"},
+	{Type: llm.ResponseTypeLearningPlan, Label: "Learning plans", Description: "Planning how to close skill gaps"},
+	{Type: llm.ResponseTypeJobExtraction, Label: "Job import", Description: "Reading a pasted job posting"},
+	{Type: llm.ResponseTypeSectionRewrite, Label: "Section rewrites", Description: "Rewriting one section of a document"},
+}
+
+// IsTask reports whether taskType is one of Tasks.
+func IsTask(taskType string) bool {
+	for _, task := range Tasks {
+		if string(task.Type) == taskType {
+			return true
+		}
+	}
+	return false
+}
+
+// Setting is whether redaction is enabled for one task.
+type Setting struct {
+	Task
+	Enabled bool `json:"enabled"`
+}
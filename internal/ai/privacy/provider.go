@@ -0,0 +1,142 @@
+package privacy
+
+import (
+	"context"
+	"strings"
+
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/benidevo/vega/internal/ai/security"
+	ctxutil "github.com/benidevo/vega/internal/common/context"
+	"github.com/benidevo/vega/internal/common/logger"
+)
+
+// tokenNote tells the model to carry placeholder tokens through unchanged so
+// they can be restored in its output.
+const tokenNote = "Personal details in this request are replaced by placeholders such as [NAME_1], [EMAIL_1], [PHONE_1] and [URL_1]. Copy any placeholder you need into your answer exactly as written, brackets included, and never guess the details behind it."
+
+// Policy decides whether a user's prompts for a task are redacted.
+type Policy interface {
+	RedactionEnabled(ctx context.Context, userID int, taskType string) bool
+}
+
+// RedactingProvider is an llm.Provider decorator that replaces personal
+// details in the prompt with placeholder tokens before it leaves the server,
+// and restores them in the response. Requests without a user in the context
+// are always redacted.
+type RedactingProvider struct {
+	next   llm.Provider
+	policy Policy
+	log    *logger.PrivacyLogger
+}
+
+// NewRedactingProvider wraps next so that prompts are redacted whenever policy
+// allows it. A nil policy redacts every request.
+func NewRedactingProvider(next llm.Provider, policy Policy) *RedactingProvider {
+	return &RedactingProvider{
+		next:   next,
+		policy: policy,
+		log:    logger.GetPrivacyLogger("ai_privacy"),
+	}
+}
+
+// Generate implements the Provider interface.
+func (p *RedactingProvider) Generate(ctx context.Context, request llm.GenerateRequest) (llm.GenerateResponse, error) {
+	redaction, request := p.redact(ctx, request)
+	resp, err := p.next.Generate(ctx, request)
+	if err == nil && redaction != nil {
+		resp.Data = redaction.RestoreValue(resp.Data)
+	}
+	return resp, err
+}
+
+// GenerateStream implements the llm.StreamingProvider interface. Streamed
+// chunks are restored too; a chunk ending in what may be the start of a token
+// is held back until the rest of it arrives.
+func (p *RedactingProvider) GenerateStream(ctx context.Context, request llm.GenerateRequest, onChunk llm.StreamHandler) (llm.GenerateResponse, error) {
+	redaction, request := p.redact(ctx, request)
+	if redaction == nil {
+		return llm.GenerateStream(ctx, p.next, request, onChunk)
+	}
+
+	stream := &restoringStream{redaction: redaction, onChunk: onChunk}
+	resp, err := llm.GenerateStream(ctx, p.next, request, stream.write)
+	if err != nil {
+		return resp, err
+	}
+	if err := stream.flush(); err != nil {
+		return resp, err
+	}
+
+	resp.Data = redaction.RestoreValue(resp.Data)
+	return resp, nil
+}
+
+// redact returns the redacted request and the redaction that restores its
+// response, or a nil redaction and the unchanged request when redaction is
+// turned off or there is nothing to redact.
+func (p *RedactingProvider) redact(ctx context.Context, request llm.GenerateRequest) (*security.Redaction, llm.GenerateRequest) {
+	taskType := string(request.ResponseType)
+	if userID, ok := ctxutil.GetUserID(ctx); ok && p.policy != nil && !p.policy.RedactionEnabled(ctx, userID, taskType) {
+		return nil, request
+	}
+
+	redaction := security.NewRedaction(request.Prompt.ApplicantName)
+	prompt := redaction.RedactValue(request.Prompt).(models.Prompt)
+	if redaction.Count() == 0 {
+		return nil, request
+	}
+
+	prompt.PlaceholderNote = tokenNote
+	request.Prompt = prompt
+
+	p.log.Debug().Str("task_type", taskType).Int("redacted", redaction.Count()).Msg("Redacted personal details from prompt")
+	return redaction, request
+}
+
+// ValidateModels delegates to the wrapped provider when it supports model
+// validation.
+func (p *RedactingProvider) ValidateModels(ctx context.Context) error {
+	if validator, ok := p.next.(interface {
+		ValidateModels(ctx context.Context) error
+	}); ok {
+		return validator.ValidateModels(ctx)
+	}
+	return nil
+}
+
+// restoringStream restores tokens in streamed output before passing it on.
+type restoringStream struct {
+	redaction *security.Redaction
+	onChunk   llm.StreamHandler
+	pending   string
+}
+
+func (s *restoringStream) write(chunk string) error {
+	s.pending += chunk
+
+	// Hold back a trailing "[" that may open a token split across chunks.
+	ready := len(s.pending)
+	if open := strings.LastIndex(s.pending, "["); open >= 0 &&
+		!strings.Contains(s.pending[open:], "]") &&
+		len(s.pending)-open < security.MaxTokenLength {
+		ready = open
+	}
+
+	out := s.pending[:ready]
+	s.pending = s.pending[ready:]
+	return s.emit(out)
+}
+
+func (s *restoringStream) flush() error {
+	out := s.pending
+	s.pending = ""
+	return s.emit(out)
+}
+
+func (s *restoringStream) emit(text string) error {
+	if text == "" || s.onChunk == nil {
+		return nil
+	}
+	return s.onChunk(s.redaction.RestoreJSON(text))
+}
@@ -0,0 +1,130 @@
+package privacy
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/llm/structured"
+	"github.com/benidevo/vega/internal/ai/models"
+	ctxutil "github.com/benidevo/vega/internal/common/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// echoProvider records the request and answers with a cover letter built
+// from the redacted applicant name, streamed in the given chunks.
+type echoProvider struct {
+	request llm.GenerateRequest
+	chunks  []string
+}
+
+func (p *echoProvider) Generate(ctx context.Context, request llm.GenerateRequest) (llm.GenerateResponse, error) {
+	p.request = request
+	return llm.GenerateResponse{Data: &models.CoverLetter{
+		Content: "Kind regards,\n" + request.Prompt.ApplicantName,
+	}}, nil
+}
+
+func (p *echoProvider) GenerateStream(ctx context.Context, request llm.GenerateRequest, onChunk llm.StreamHandler) (llm.GenerateResponse, error) {
+	for _, chunk := range p.chunks {
+		if err := onChunk(chunk); err != nil {
+			return llm.GenerateResponse{}, err
+		}
+	}
+	return p.Generate(ctx, request)
+}
+
+type staticPolicy bool
+
+func (p staticPolicy) RedactionEnabled(ctx context.Context, userID int, taskType string) bool {
+	return bool(p)
+}
+
+func coverLetterRequest() llm.GenerateRequest {
+	prompt := models.NewPrompt("Write a cover letter", models.Request{
+		ApplicantName:    "Jane Doe",
+		ApplicantProfile: "Name: Jane Doe\nEmail: jane@example.com\nLinkedIn: linkedin.com/in/janedoe",
+		JobDescription:   "Backend engineer at Acme",
+	}, false)
+	return llm.GenerateRequest{Prompt: *prompt, ResponseType: llm.ResponseTypeCoverLetter}
+}
+
+func TestRedactingProvider_Generate(t *testing.T) {
+	t.Run("should_redact_prompt_and_restore_response", func(t *testing.T) {
+		inner := &echoProvider{}
+		provider := NewRedactingProvider(inner, staticPolicy(true))
+		request := coverLetterRequest()
+
+		resp, err := provider.Generate(ctxutil.WithUserID(context.Background(), 3), request)
+
+		require.NoError(t, err)
+		sent := inner.request.Prompt
+		assert.Equal(t, "[NAME_1]", sent.ApplicantName)
+		assert.Equal(t, "Name: [NAME_1]\nEmail: [EMAIL_1]\nLinkedIn: [URL_1]", sent.ApplicantProfile)
+		assert.Equal(t, "Backend engineer at Acme", sent.JobDescription)
+		assert.Equal(t, tokenNote, sent.PlaceholderNote)
+		assert.Equal(t, "Write a cover letter", sent.Instructions)
+		assert.NotContains(t, sent.ToCoverLetterPrompt("250-350"), "jane@example.com")
+
+		assert.Equal(t, "Kind regards,\nJane Doe", resp.Data.(*models.CoverLetter).Content)
+		assert.Equal(t, "Jane Doe", request.Prompt.ApplicantName, "caller's request must not change")
+	})
+
+	t.Run("should_send_prompt_unchanged_when_user_turned_redaction_off", func(t *testing.T) {
+		inner := &echoProvider{}
+		provider := NewRedactingProvider(inner, staticPolicy(false))
+
+		_, err := provider.Generate(ctxutil.WithUserID(context.Background(), 3), coverLetterRequest())
+
+		require.NoError(t, err)
+		assert.Equal(t, "Jane Doe", inner.request.Prompt.ApplicantName)
+		assert.Equal(t, "Write a cover letter", inner.request.Prompt.Instructions)
+		assert.Empty(t, inner.request.Prompt.PlaceholderNote)
+	})
+
+	t.Run("should_redact_requests_without_user", func(t *testing.T) {
+		inner := &echoProvider{}
+		provider := NewRedactingProvider(inner, staticPolicy(false))
+
+		_, err := provider.Generate(context.Background(), coverLetterRequest())
+
+		require.NoError(t, err)
+		assert.Equal(t, "[NAME_1]", inner.request.Prompt.ApplicantName)
+	})
+}
+
+func TestRedactingProvider_CVParsingPromptCarriesPlaceholderNote(t *testing.T) {
+	inner := &echoProvider{}
+	provider := NewRedactingProvider(inner, nil)
+	request := llm.GenerateRequest{
+		Prompt:       *models.NewCVParsingPrompt("Jane Doe\nSoftware Engineer\njane@example.com\n+44 7700 900123"),
+		ResponseType: llm.ResponseTypeCVParsing,
+	}
+
+	_, err := provider.Generate(context.Background(), request)
+	require.NoError(t, err)
+
+	task, err := structured.NewConfig().BuildTask(llm.ResponseTypeCVParsing, inner.request.Prompt)
+	require.NoError(t, err)
+	assert.Contains(t, task.UserPrompt, "[EMAIL_1]")
+	assert.NotContains(t, task.UserPrompt, "jane@example.com")
+	assert.Contains(t, task.UserPrompt, tokenNote)
+}
+
+func TestRedactingProvider_GenerateStream(t *testing.T) {
+	inner := &echoProvider{chunks: []string{`{"content":"Regards, [NA`, `ME_1] <[EMAIL`, `_1]>`, `"} [`}}
+	provider := NewRedactingProvider(inner, nil)
+
+	var streamed []string
+	resp, err := provider.GenerateStream(context.Background(), coverLetterRequest(), func(chunk string) error {
+		streamed = append(streamed, chunk)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, `{"content":"Regards, Jane Doe <jane@example.com>"} [`, strings.Join(streamed, ""))
+	assert.Equal(t, `{"content":"Regards, `, streamed[0])
+	assert.Equal(t, "Kind regards,\nJane Doe", resp.Data.(*models.CoverLetter).Content)
+}
@@ -0,0 +1,76 @@
+package privacy
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Repository defines data access for users' redaction settings.
+type Repository interface {
+	GetSettings(ctx context.Context, userID int) (map[string]bool, error)
+	SaveSettings(ctx context.Context, userID int, settings map[string]bool) error
+}
+
+// repository implements the Repository interface
+type repository struct {
+	db *sql.DB
+}
+
+// NewRepository creates a new redaction settings repository
+func NewRepository(db *sql.DB) Repository {
+	return &repository{db: db}
+}
+
+// GetSettings returns whether redaction is enabled for each task the user has
+// changed, keyed by task type
+func (r *repository) GetSettings(ctx context.Context, userID int) (map[string]bool, error) {
+	query := `SELECT task_type, enabled FROM ai_redaction_settings WHERE user_id = ?`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get redaction settings: %w", err)
+	}
+	defer rows.Close()
+
+	settings := make(map[string]bool)
+	for rows.Next() {
+		var taskType string
+		var enabled bool
+		if err := rows.Scan(&taskType, &enabled); err != nil {
+			return nil, fmt.Errorf("failed to scan redaction setting: %w", err)
+		}
+		settings[taskType] = enabled
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read redaction settings: %w", err)
+	}
+	return settings, nil
+}
+
+// SaveSettings stores the given settings in one transaction, keyed by task type
+func (r *repository) SaveSettings(ctx context.Context, userID int, settings map[string]bool) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO ai_redaction_settings (user_id, task_type, enabled, updated_at)
+		VALUES (?, ?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(user_id, task_type) DO UPDATE SET
+			enabled = excluded.enabled,
+			updated_at = CURRENT_TIMESTAMP
+	`
+	for taskType, enabled := range settings {
+		if _, err := tx.ExecContext(ctx, query, userID, taskType, enabled); err != nil {
+			return fmt.Errorf("failed to save redaction setting: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit redaction settings: %w", err)
+	}
+	return nil
+}
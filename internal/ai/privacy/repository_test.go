@@ -0,0 +1,45 @@
+package privacy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_GetSettings(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT task_type, enabled FROM ai_redaction_settings").
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"task_type", "enabled"}).
+			AddRow("cover_letter", false).
+			AddRow("match_result", true))
+
+	settings, err := NewRepository(db).GetSettings(context.Background(), 4)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"cover_letter": false, "match_result": true}, settings)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_SaveSettings(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO ai_redaction_settings").
+		WithArgs(4, "email", false).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = NewRepository(db).SaveSettings(context.Background(), 4, map[string]bool{"email": false})
+
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,69 @@
+package privacy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/benidevo/vega/internal/common/logger"
+)
+
+// Service manages which AI tasks redact personal details for each user.
+// Redaction is enabled for every task until the user turns it off.
+type Service struct {
+	repo Repository
+	log  *logger.PrivacyLogger
+}
+
+// NewService creates a new redaction settings service
+func NewService(repo Repository) *Service {
+	return &Service{
+		repo: repo,
+		log:  logger.GetPrivacyLogger("ai_privacy"),
+	}
+}
+
+// GetSettings returns the user's redaction setting for every task, in the
+// order of Tasks.
+func (s *Service) GetSettings(ctx context.Context, userID int) ([]Setting, error) {
+	saved, err := s.repo.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := make([]Setting, 0, len(Tasks))
+	for _, task := range Tasks {
+		enabled, ok := saved[string(task.Type)]
+		settings = append(settings, Setting{Task: task, Enabled: !ok || enabled})
+	}
+	return settings, nil
+}
+
+// SaveSettings enables redaction for the given task types and disables it
+// for every other task.
+func (s *Service) SaveSettings(ctx context.Context, userID int, enabledTasks []string) error {
+	settings := make(map[string]bool, len(Tasks))
+	for _, task := range Tasks {
+		settings[string(task.Type)] = false
+	}
+	for _, taskType := range enabledTasks {
+		if !IsTask(taskType) {
+			return WrapError(ErrUnknownTask, fmt.Errorf("task '%s' cannot be redacted", taskType))
+		}
+		settings[taskType] = true
+	}
+	return s.repo.SaveSettings(ctx, userID, settings)
+}
+
+// RedactionEnabled reports whether the user's prompts for the task should be
+// redacted. Settings that cannot be loaded count as enabled, so a database
+// error never sends personal details the user asked to keep back.
+func (s *Service) RedactionEnabled(ctx context.Context, userID int, taskType string) bool {
+	saved, err := s.repo.GetSettings(ctx, userID)
+	if err != nil {
+		s.log.Warn().Err(err).Int("user_id", userID).Str("task_type", taskType).
+			Msg("Failed to load redaction settings, redacting")
+		return true
+	}
+	enabled, ok := saved[taskType]
+	return !ok || enabled
+}
@@ -0,0 +1,78 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryRepository struct {
+	settings map[string]bool
+	getErr   error
+}
+
+func (r *memoryRepository) GetSettings(ctx context.Context, userID int) (map[string]bool, error) {
+	return r.settings, r.getErr
+}
+
+func (r *memoryRepository) SaveSettings(ctx context.Context, userID int, settings map[string]bool) error {
+	r.settings = settings
+	return nil
+}
+
+func TestService_GetSettings(t *testing.T) {
+	repo := &memoryRepository{settings: map[string]bool{"cover_letter": false}}
+	service := NewService(repo)
+
+	settings, err := service.GetSettings(context.Background(), 1)
+
+	require.NoError(t, err)
+	require.Len(t, settings, len(Tasks))
+	for _, setting := range settings {
+		assert.Equal(t, setting.Type != "cover_letter", setting.Enabled, setting.Type)
+	}
+}
+
+func TestService_SaveSettings(t *testing.T) {
+	t.Run("should_disable_tasks_left_out", func(t *testing.T) {
+		repo := &memoryRepository{}
+		service := NewService(repo)
+
+		err := service.SaveSettings(context.Background(), 1, []string{"match_result"})
+
+		require.NoError(t, err)
+		assert.Len(t, repo.settings, len(Tasks))
+		assert.True(t, repo.settings["match_result"])
+		assert.False(t, repo.settings["cv_generation"])
+	})
+
+	t.Run("should_reject_unknown_task", func(t *testing.T) {
+		service := NewService(&memoryRepository{})
+
+		err := service.SaveSettings(context.Background(), 1, []string{"match_result", "poems"})
+
+		assert.ErrorIs(t, err, ErrUnknownTask)
+	})
+}
+
+func TestService_RedactionEnabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		repo     *memoryRepository
+		expected bool
+	}{
+		{name: "should_default_to_enabled", repo: &memoryRepository{}, expected: true},
+		{name: "should_follow_saved_setting", repo: &memoryRepository{settings: map[string]bool{"email": false}}, expected: false},
+		{name: "should_redact_when_settings_fail_to_load", repo: &memoryRepository{getErr: errors.New("database is locked")}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewService(tt.repo)
+			assert.Equal(t, tt.expected, service.RedactionEnabled(context.Background(), 1, "email"))
+		})
+	}
+}
@@ -9,17 +9,26 @@ import (
 // The templates are designed to be profession-agnostic, allowing the AI to understand context
 // from the specific job description and candidate profile provided.
 type PromptTemplate struct {
-	Role        string
-	Context     string
-	Examples    []Example
-	Task        string
-	Constraints []string
-	OutputSpec  string
+	Role        string    `json:"role"`
+	Context     string    `json:"context,omitempty"`
+	Examples    []Example `json:"examples,omitempty"`
+	Task        string    `json:"task"`
+	Constraints []string  `json:"constraints,omitempty"`
+	OutputSpec  string    `json:"output_spec,omitempty"`
 }
 
 type Example struct {
-	Input  string
-	Output string
+	Input  string `json:"input"`
+	Output string `json:"output"`
+}
+
+// clone returns a copy of the template that can be modified without
+// affecting the original.
+func (t *PromptTemplate) clone() *PromptTemplate {
+	c := *t
+	c.Examples = append([]Example(nil), t.Examples...)
+	c.Constraints = append([]string(nil), t.Constraints...)
+	return &c
 }
 
 // BuildPrompt constructs the final prompt from a template
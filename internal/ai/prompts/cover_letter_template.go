@@ -1,6 +1,57 @@
 package prompts
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
+
+// Tones a cover letter can be written in.
+const (
+	LetterToneFormal       = "formal"
+	LetterToneWarm         = "warm"
+	LetterToneConcise      = "concise"
+	LetterToneEnthusiastic = "enthusiastic"
+)
+
+var letterToneGuidance = map[string]string{
+	LetterToneFormal:       "polished and respectful, with no slang or jokes, suited to traditional industries and senior audiences",
+	LetterToneWarm:         "friendly and personal, like a note to someone you'd like to work with, while staying professional",
+	LetterToneConcise:      "direct and to the point: short sentences, one idea per paragraph and no scene-setting",
+	LetterToneEnthusiastic: "upbeat and clearly excited about the role and company, without gushing or exaggerating",
+}
+
+// Lengths a cover letter can be written at.
+const (
+	LetterLengthShort    = "short"
+	LetterLengthStandard = "standard"
+	LetterLengthLong     = "long"
+)
+
+// Word ranges for non-standard lengths. A standard letter uses the
+// provider's configured word range.
+var letterWordRanges = map[string]string{
+	LetterLengthShort: "100-150",
+	LetterLengthLong:  "300-400",
+}
+
+// LetterTones returns the tones a cover letter can be written in.
+func LetterTones() []string {
+	return []string{LetterToneFormal, LetterToneWarm, LetterToneConcise, LetterToneEnthusiastic}
+}
+
+// LetterLengths returns the lengths a cover letter can be written at.
+func LetterLengths() []string {
+	return []string{LetterLengthShort, LetterLengthStandard, LetterLengthLong}
+}
+
+// LetterWordRange returns the word range for a cover letter length. Standard
+// and unknown lengths use defaultRange.
+func LetterWordRange(length, defaultRange string) string {
+	if wordRange, ok := letterWordRanges[length]; ok {
+		return wordRange
+	}
+	return defaultRange
+}
 
 // CoverLetterTemplate returns a template for cover letter generation
 func CoverLetterTemplate() *PromptTemplate {
@@ -46,9 +97,21 @@ func CoverLetterTemplate() *PromptTemplate {
 	}
 }
 
-// EnhanceCoverLetterPrompt enhances a cover letter prompt
-func EnhanceCoverLetterPrompt(systemInstruction, applicantName, jobDescription, applicantProfile, extraContext, wordRange string) string {
-	template := CoverLetterTemplate()
+// EnhanceCoverLetterPrompt enhances a cover letter prompt. An empty or
+// unknown tone leaves the template's own tone, and an empty language writes
+// the letter in English.
+func EnhanceCoverLetterPrompt(systemInstruction, applicantName, jobDescription, applicantProfile, extraContext, wordRange, tone, language string) string {
+	return buildCoverLetterPrompt(CoverLetterTemplate(), systemInstruction, applicantName, jobDescription, applicantProfile, extraContext, wordRange, tone, language)
+}
+
+func buildCoverLetterPrompt(template *PromptTemplate, systemInstruction, applicantName, jobDescription, applicantProfile, extraContext, wordRange, tone, language string) string {
+	if guidance, ok := letterToneGuidance[tone]; ok {
+		template.Constraints = append(template.Constraints, fmt.Sprintf("TONE: Write in a %s tone: %s. This takes priority over the general tone guidance above", tone, guidance))
+	}
+	if constraint := languageConstraint(language); constraint != "" {
+		template.Constraints = append(template.Constraints, constraint+", and translate the 'Best regards,' sign-off into the same language")
+	}
+
 	params := map[string]any{
 		"wordRange":   wordRange,
 		"currentDate": time.Now().Format("January 2, 2006"),
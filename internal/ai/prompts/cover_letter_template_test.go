@@ -79,6 +79,8 @@ func TestEnhanceCoverLetterPrompt(t *testing.T) {
 				tt.applicantProfile,
 				tt.extraContext,
 				tt.wordRange,
+				"",
+				"",
 			)
 
 			for _, expected := range tt.expectedContains {
@@ -87,3 +89,27 @@ func TestEnhanceCoverLetterPrompt(t *testing.T) {
 		})
 	}
 }
+
+func TestEnhanceCoverLetterPrompt_ToneAndLanguage(t *testing.T) {
+	t.Run("should_add_tone_and_language", func(t *testing.T) {
+		result := EnhanceCoverLetterPrompt("", "Jane Doe", "Engineer", "Profile", "", "100-150", LetterToneConcise, "fr")
+
+		assert.Contains(t, result, "TONE: Write in a concise tone")
+		assert.Contains(t, result, "Write the entire output in French")
+		assert.Contains(t, result, "**Word Count:** 100-150 words")
+	})
+
+	t.Run("should_ignore_unknown_tone_and_english", func(t *testing.T) {
+		result := EnhanceCoverLetterPrompt("", "Jane Doe", "Engineer", "Profile", "", "150-250", "sarcastic", "en")
+
+		assert.NotContains(t, result, "- TONE:")
+		assert.NotContains(t, result, "- LANGUAGE:")
+	})
+}
+
+func TestLetterWordRange(t *testing.T) {
+	assert.Equal(t, "100-150", LetterWordRange(LetterLengthShort, "150-250"))
+	assert.Equal(t, "150-250", LetterWordRange(LetterLengthStandard, "150-250"))
+	assert.Equal(t, "300-400", LetterWordRange(LetterLengthLong, "150-250"))
+	assert.Equal(t, "150-250", LetterWordRange("", "150-250"))
+}
@@ -253,9 +253,13 @@ Research and incorporate company values and culture where relevant.
 
 Generate only valid JSON without any preamble or explanation.`
 
-// EnhanceCVGenerationPrompt enhances a CV generation prompt
-func EnhanceCVGenerationPrompt(systemInstruction, cvText, jobDescription, extraContext string) string {
-	template := CVGenerationEnhancedTemplate
+// EnhanceCVGenerationPrompt enhances a CV generation prompt. language is the
+// ISO 639-1 code of the language to write the CV in; empty means English.
+func EnhanceCVGenerationPrompt(systemInstruction, cvText, jobDescription, extraContext, language string) string {
+	return buildCVGenerationPrompt(CVGenerationEnhancedTemplate, systemInstruction, cvText, jobDescription, extraContext, language)
+}
+
+func buildCVGenerationPrompt(template, systemInstruction, cvText, jobDescription, extraContext, language string) string {
 	enhancedPrompt := systemInstruction + "\n\n" + template
 
 	// Inject CV constraints
@@ -282,5 +286,14 @@ func EnhanceCVGenerationPrompt(systemInstruction, cvText, jobDescription, extraC
 		}
 	}
 
+	if constraint := cvLanguageConstraint(language); constraint != "" {
+		section := "## Language\n" + constraint
+		if strings.Contains(enhancedPrompt, "## Output Format") {
+			enhancedPrompt = strings.Replace(enhancedPrompt, "## Output Format", section+"\n\n## Output Format", 1)
+		} else {
+			enhancedPrompt += "\n\n" + section
+		}
+	}
+
 	return enhancedPrompt
 }
@@ -1,6 +1,7 @@
 package prompts
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -58,11 +59,23 @@ func TestEnhanceCVGenerationPrompt(t *testing.T) {
 				tt.cvText,
 				tt.jobDescription,
 				tt.extraContext,
+				"",
 			)
 
 			for _, expected := range tt.expectedContains {
 				assert.Contains(t, result, expected)
 			}
+			assert.NotContains(t, result, "## Language")
 		})
 	}
+
+	t.Run("should_add_language_section_before_output_format", func(t *testing.T) {
+		result := EnhanceCVGenerationPrompt("", "CV content", "Job description", "", "fr")
+
+		section := strings.Index(result, "## Language")
+		assert.NotEqual(t, -1, section)
+		assert.Less(t, section, strings.Index(result, "## Output Format"))
+		assert.Contains(t, result, "work experience descriptions in French")
+		assert.Contains(t, result, "copy dates exactly as provided")
+	})
 }
@@ -0,0 +1,165 @@
+package prompts
+
+import (
+	"fmt"
+	"time"
+)
+
+// Kinds of application emails, each with its own template.
+const (
+	EmailFollowUp    = "follow_up"
+	EmailThankYou    = "thank_you"
+	EmailNegotiation = "negotiation"
+)
+
+// Tones an application email can be written in.
+const (
+	EmailToneProfessional = "professional"
+	EmailToneFriendly     = "friendly"
+	EmailToneEnthusiastic = "enthusiastic"
+	EmailToneFormal       = "formal"
+)
+
+var emailToneGuidance = map[string]string{
+	EmailToneProfessional: "polite and businesslike, warm without being casual",
+	EmailToneFriendly:     "relaxed and personable, like writing to someone you've already met",
+	EmailToneEnthusiastic: "upbeat and clearly excited about the role, without gushing",
+	EmailToneFormal:       "formal and reserved, suited to traditional industries and senior audiences",
+}
+
+// FollowUpEmailTemplate returns a template for following up on an application
+func FollowUpEmailTemplate() *PromptTemplate {
+	return &PromptTemplate{
+		Role:    "You are a career coach who helps candidates write short follow-up emails that get replies. You know hiring teams are busy, so a good follow-up is brief, specific and easy to answer.",
+		Context: "The candidate applied for the job below and hasn't heard back. They want to check on their application without sounding pushy, and remind the hiring team why they're a good fit.",
+		Examples: []Example{
+			{
+				Input: "Candidate: Backend developer, applied two weeks ago\nJob: Senior Go Engineer at Example Ltd\nTone: professional",
+				Output: `{
+  "subject": "Following up on my Senior Go Engineer application",
+  "body": "Hi Example team,\n\nI applied for the Senior Go Engineer role two weeks ago and wanted to check where things stand. I'm still very interested, especially in the work on your payments platform, which lines up with the billing services I've run in production for the last three years.\n\nIf it would help, I'm happy to share more about that work or answer any questions.\n\nBest regards,\nAlex Morgan"
+}`,
+			},
+		},
+		Task: "Write a follow-up email about the candidate's application. Mention the role by name, add one specific reason they fit that the hiring team may have missed, and end with a simple question or offer that is easy to reply to. Sign with the applicant's actual name from the 'Applicant Name' field.",
+		Constraints: emailConstraints(
+			"Keep the body under 120 words",
+			"Do not apologise for following up or guilt the reader about not replying",
+			"If a cover letter is provided, don't repeat it; pick one point it did not cover",
+		),
+		OutputSpec: emailOutputSpec,
+	}
+}
+
+// ThankYouEmailTemplate returns a template for thanking interviewers
+func ThankYouEmailTemplate() *PromptTemplate {
+	return &PromptTemplate{
+		Role:    "You are a career coach who helps candidates write thank-you notes after interviews. You know the best notes are sent quickly, feel personal and remind the interviewer of something concrete from the conversation.",
+		Context: "The candidate has just interviewed for the job below. They want to thank the interviewers, reinforce their interest and leave a good last impression.",
+		Examples: []Example{
+			{
+				Input: "Candidate: Product designer\nJob: Senior Product Designer at Example Ltd\nNote: talked about redesigning onboarding\nTone: friendly",
+				Output: `{
+  "subject": "Thanks for today",
+  "body": "Hi Sam,\n\nThanks for taking the time to talk today. I really enjoyed hearing about the plans for onboarding. The drop-off you mentioned in the second step is something I worked on at my last company, and I've been thinking about it since we spoke.\n\nI'm even more excited about the role now. Let me know if there's anything else I can send over.\n\nBest,\nJordan Lee"
+}`,
+			},
+		},
+		Task: "Write a thank-you email to send after an interview for this job. Thank the interviewers, refer to something specific from the job or the applicant's note, and restate interest in one sentence. Sign with the applicant's actual name from the 'Applicant Name' field.",
+		Constraints: emailConstraints(
+			"Keep the body under 130 words",
+			"Only mention interview topics that appear in the applicant's note; never invent details of the conversation",
+			"Do not re-pitch the whole profile; one short reminder of fit is enough",
+		),
+		OutputSpec: emailOutputSpec,
+	}
+}
+
+// NegotiationEmailTemplate returns a template for replying to a job offer
+func NegotiationEmailTemplate() *PromptTemplate {
+	return &PromptTemplate{
+		Role:    "You are a career coach who helps candidates negotiate job offers. You know that a good negotiation email is appreciative, specific about what is being asked for, and makes it easy for the employer to say yes.",
+		Context: "The candidate has received an offer for the job below and wants to reply. The applicant's note says what they want to ask for or clarify.",
+		Examples: []Example{
+			{
+				Input: "Candidate: Data analyst with 5 years experience\nJob: Senior Data Analyst at Example Ltd\nNote: offer is 70k, hoping for 78k, also want one remote day\nTone: professional",
+				Output: `{
+  "subject": "Senior Data Analyst offer",
+  "body": "Hi Priya,\n\nThank you for the offer. I'm excited about joining the analytics team and the work on the forecasting models.\n\nBefore I accept, I'd like to talk about the base salary. Given my five years building reporting for finance teams, and what similar roles are paying in the area, I was hoping for something closer to 78k. I'd also like to ask whether one remote day a week would be possible.\n\nI'm keen to make this work and happy to talk it through whenever suits you.\n\nBest regards,\nChris Walker"
+}`,
+			},
+		},
+		Task: "Write a reply to the job offer that thanks the employer and raises the points in the applicant's note. Back each request with a reason drawn from the applicant's profile, and end on a collaborative note. Sign with the applicant's actual name from the 'Applicant Name' field.",
+		Constraints: emailConstraints(
+			"Keep the body under 180 words",
+			"Only ask for what the applicant's note mentions; if the note is empty, thank them and ask for time to review the details",
+			"Never make up numbers, competing offers or benefits that the note does not mention",
+			"Stay positive; do not threaten to walk away",
+		),
+		OutputSpec: emailOutputSpec,
+	}
+}
+
+const emailOutputSpec = "Return ONLY a valid JSON object with 'subject' (a short subject line without a 'Subject:' prefix) and 'body' (the email text, properly formatted with \\n for line breaks)"
+
+// emailConstraints combines the shared email rules with kind-specific ones.
+func emailConstraints(specific ...string) []string {
+	constraints := EmailAntiAIConstraints()
+	constraints = append(constraints, specific...)
+	return append(constraints,
+		"Do not include placeholder text like [Hiring Manager] or [Your Name]; if the recipient's name is unknown, address the team",
+		"Do not use em dashes (—) in your writing, use commas or rewrite the sentence instead",
+	)
+}
+
+// EmailKinds returns the kinds of email that can be generated.
+func EmailKinds() []string {
+	return []string{EmailFollowUp, EmailThankYou, EmailNegotiation}
+}
+
+// EmailTones returns the tones an email can be written in.
+func EmailTones() []string {
+	return []string{EmailToneProfessional, EmailToneFriendly, EmailToneEnthusiastic, EmailToneFormal}
+}
+
+// EmailTemplate returns the template for the kind of email, or nil if the
+// kind is unknown.
+func EmailTemplate(kind string) *PromptTemplate {
+	switch kind {
+	case EmailFollowUp:
+		return FollowUpEmailTemplate()
+	case EmailThankYou:
+		return ThankYouEmailTemplate()
+	case EmailNegotiation:
+		return NegotiationEmailTemplate()
+	default:
+		return nil
+	}
+}
+
+// EnhanceEmailPrompt builds an application email prompt. emailContext
+// describes the company, status, note and cover letter and is placed before
+// any extra context. Unknown kinds fall back to a follow-up email and
+// unknown tones to a professional one.
+func EnhanceEmailPrompt(kind, tone, systemInstruction, applicantName, jobDescription, applicantProfile, emailContext, extraContext string) string {
+	template := EmailTemplate(kind)
+	if template == nil {
+		template = FollowUpEmailTemplate()
+	}
+
+	guidance, ok := emailToneGuidance[tone]
+	if !ok {
+		tone, guidance = EmailToneProfessional, emailToneGuidance[EmailToneProfessional]
+	}
+	template.Constraints = append(template.Constraints, fmt.Sprintf("TONE: Write in a %s tone: %s", tone, guidance))
+
+	context := emailContext
+	if extraContext != "" {
+		context = emailContext + "\n\n" + extraContext
+	}
+
+	params := map[string]any{
+		"currentDate": time.Now().Format("January 2, 2006"),
+	}
+	return template.BuildPrompt(systemInstruction, applicantName, jobDescription, applicantProfile, context, params)
+}
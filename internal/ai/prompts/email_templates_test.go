@@ -0,0 +1,51 @@
+package prompts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailTemplate(t *testing.T) {
+	for _, kind := range EmailKinds() {
+		t.Run(kind, func(t *testing.T) {
+			template := EmailTemplate(kind)
+
+			if assert.NotNil(t, template) {
+				assert.NotEmpty(t, template.Role)
+				assert.NotEmpty(t, template.Task)
+				assert.Contains(t, template.OutputSpec, "'subject'")
+				assert.Contains(t, template.OutputSpec, "'body'")
+			}
+		})
+	}
+
+	assert.Nil(t, EmailTemplate("cold_outreach"))
+}
+
+func TestEnhanceEmailPrompt(t *testing.T) {
+	t.Run("should_use_template_for_kind_and_tone", func(t *testing.T) {
+		prompt := EnhanceEmailPrompt(EmailThankYou, EmailToneFriendly, "System", "Jane Doe", "SRE at Example", "Support engineer", "Company: Example", "")
+
+		assert.Contains(t, prompt, "System")
+		assert.Contains(t, prompt, "thank-you email")
+		assert.Contains(t, prompt, "**Applicant Name:** Jane Doe")
+		assert.Contains(t, prompt, "**Additional Context:**\nCompany: Example")
+		assert.Contains(t, prompt, "TONE: Write in a friendly tone")
+	})
+
+	t.Run("should_fall_back_for_unknown_kind_and_tone", func(t *testing.T) {
+		prompt := EnhanceEmailPrompt("unknown", "sarcastic", "", "Jane Doe", "Job", "Profile", "Company: Example", "Extra")
+
+		assert.Contains(t, prompt, "follow-up email")
+		assert.Contains(t, prompt, "TONE: Write in a professional tone")
+		assert.Contains(t, prompt, "Company: Example\n\nExtra")
+	})
+
+	t.Run("should_not_change_shared_templates", func(t *testing.T) {
+		before := len(NegotiationEmailTemplate().Constraints)
+		EnhanceEmailPrompt(EmailNegotiation, EmailToneFormal, "", "Jane", "Job", "Profile", "", "")
+
+		assert.Len(t, NegotiationEmailTemplate().Constraints, before)
+	})
+}
@@ -0,0 +1,16 @@
+package prompts
+
+import (
+	commonerrors "github.com/benidevo/vega/internal/common/errors"
+)
+
+var (
+	ErrUnknownPrompt        = commonerrors.New("unknown prompt")
+	ErrInvalidPromptVersion = commonerrors.New("invalid prompt version")
+	ErrPromptLoadFailed     = commonerrors.New("failed to load prompt versions")
+)
+
+// WrapError wraps the given innerErr with the provided sentinelErr.
+func WrapError(sentinelErr, innerErr error) error {
+	return commonerrors.WrapError(sentinelErr, innerErr)
+}
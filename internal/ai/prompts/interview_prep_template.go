@@ -0,0 +1,63 @@
+package prompts
+
+import "time"
+
+// InterviewPrepTemplate returns a template for interview preparation packs
+func InterviewPrepTemplate() *PromptTemplate {
+	return &PromptTemplate{
+		Role:    "You are an interview coach who has sat on hiring panels across many industries. You know which questions interviewers ask for a given role and how strong candidates answer them with specific stories from their own work.",
+		Context: "The candidate has an interview coming up for the job below. You're preparing a pack they can rehearse from: the questions they're likely to face, answers built from their real work history, questions they can ask the panel, and the weak spots an interviewer is likely to probe.",
+		Examples: []Example{
+			{
+				Input: "Candidate: Support Engineer at Acme (2021-Present), handled escalations and wrote the on-call runbook\nJob: Site Reliability Engineer focused on incident response",
+				Output: `{
+  "questions": [
+    {
+      "question": "Tell me about an incident you helped resolve under pressure.",
+      "reason": "The role is built around incident response, so they'll want proof you stay calm and methodical.",
+      "experience": "Support Engineer at Acme",
+      "answer": {
+        "situation": "A payment outage hit during a Friday release and support tickets were piling up.",
+        "task": "I was on call and had to find the cause and keep customers informed.",
+        "action": "I rolled back the release, paired with the developer to find the bad migration, and posted updates every 15 minutes.",
+        "result": "Payments were back within 40 minutes, and the steps we took became the first page of the on-call runbook."
+      }
+    }
+  ],
+  "questionsToAsk": ["How does the team run post-incident reviews, and what changed after the last one?"],
+  "gapsToRehearse": ["No production infrastructure ownership yet: be ready to explain how runbook and escalation work carries over."]
+}`,
+			},
+		},
+		Task: "Prepare an interview pack for this job. List the questions the candidate is most likely to be asked, mixing role-specific, technical and behavioral questions. For each one, suggest an answer in the STAR format (situation, task, action, result) built from ONE of the roles listed under Work Experience, and name that role in the 'experience' field as 'Title at Company'. Then list questions the candidate could ask the interviewers and the gaps between their profile and the job that they should rehearse answers for.",
+		Constraints: func() []string {
+			constraints := InterviewPrepAntiAIConstraints()
+			additionalConstraints := []string{
+				"Include 6-8 likely questions, ordered from most to least likely",
+				"Ground every answer in the candidate's Work Experience. Never invent employers, titles, projects or numbers that the profile does not support",
+				"If no role fits a question, say so in the situation and suggest how to answer honestly from the closest experience",
+				"Write answers in the first person, the way the candidate would say them out loud",
+				"Keep each STAR part to one or two sentences",
+				"Include 3-5 questions to ask that show the candidate has read the job description, not generic questions about culture",
+				"Include 2-4 gaps to rehearse, each naming the gap and how to address it",
+				"Do not use em dashes (—) in your writing, use commas or rewrite the sentence instead",
+			}
+			return append(constraints, additionalConstraints...)
+		}(),
+		OutputSpec: "Return ONLY a valid JSON object with 'questions' (array of objects with 'question', 'reason', 'experience' and 'answer' containing 'situation', 'task', 'action' and 'result'), 'questionsToAsk' (array of strings) and 'gapsToRehearse' (array of strings)",
+	}
+}
+
+// EnhanceInterviewPrepPrompt builds an interview preparation prompt. The work
+// experience is appended to the applicant profile so answers can cite it.
+func EnhanceInterviewPrepPrompt(systemInstruction, applicantName, jobDescription, applicantProfile, workExperience, extraContext string) string {
+	profile := applicantProfile
+	if workExperience != "" {
+		profile += "\n\n**Work Experience:**\n" + workExperience
+	}
+
+	params := map[string]any{
+		"currentDate": time.Now().Format("January 2, 2006"),
+	}
+	return InterviewPrepTemplate().BuildPrompt(systemInstruction, applicantName, jobDescription, profile, extraContext, params)
+}
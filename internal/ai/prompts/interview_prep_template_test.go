@@ -0,0 +1,28 @@
+package prompts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnhanceInterviewPrepPrompt(t *testing.T) {
+	t.Run("should_include_work_experience_in_profile", func(t *testing.T) {
+		prompt := EnhanceInterviewPrepPrompt("System", "Jane Doe", "SRE at Example", "Support engineer", "1. Support Engineer at Acme (2021 - Present)", "")
+
+		assert.Contains(t, prompt, "System")
+		assert.Contains(t, prompt, "**Applicant Name:** Jane Doe")
+		assert.Contains(t, prompt, "SRE at Example")
+		assert.Contains(t, prompt, "Support engineer\n\n**Work Experience:**\n1. Support Engineer at Acme (2021 - Present)")
+		assert.Contains(t, prompt, "STAR format")
+		assert.Contains(t, prompt, "gapsToRehearse")
+		assert.NotContains(t, prompt, "**Additional Context:**")
+	})
+
+	t.Run("should_omit_empty_work_experience", func(t *testing.T) {
+		prompt := EnhanceInterviewPrepPrompt("", "Jane Doe", "Job", "Profile", "", "Context")
+
+		assert.NotContains(t, prompt, "**Work Experience:**")
+		assert.Contains(t, prompt, "**Additional Context:**\nContext")
+	})
+}
@@ -0,0 +1,59 @@
+package prompts
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Job types the extractor may return. They match the job tracker's job types.
+var extractionJobTypes = []string{"full_time", "part_time", "contract", "intern", "remote", "freelance", "other"}
+
+// Seniority levels the extractor may return.
+var extractionSeniorities = []string{"intern", "junior", "mid", "senior", "lead", "principal", "executive"}
+
+// ExtractionJobTypes returns the job types a job extraction may contain.
+func ExtractionJobTypes() []string {
+	return append([]string(nil), extractionJobTypes...)
+}
+
+// ExtractionSeniorities returns the seniority levels a job extraction may contain.
+func ExtractionSeniorities() []string {
+	return append([]string(nil), extractionSeniorities...)
+}
+
+// JobExtractionSystemInstruction is the system instruction for turning a
+// pasted job posting into structured fields.
+const JobExtractionSystemInstruction = `You are a precise job posting parser. First decide whether the text is a job posting at all, then extract structured fields from it. Always include an "isValid" field in your response. Only extract what the posting states or clearly implies; never invent a company, salary or URL. Give every field a confidence between 0 and 1 that reflects how directly the posting supports it.`
+
+// JobExtractionPrompt wraps the text of a job posting with extraction
+// instructions.
+func JobExtractionPrompt(postingText string) string {
+	return fmt.Sprintf(`Extract the details of the job posting below.
+
+VALIDATION RULES:
+- The text MUST be a job posting, job advert or role description
+- Reject anything else (articles, CVs, product pages, empty pages) with: {"isValid": false, "reason": "explanation"}
+
+EXTRACTION INSTRUCTIONS (only if the text is a job posting):
+- title: the job title without the company name or location
+- company: the hiring company, not a recruiting agency or job board, when both are named
+- location: city and country as written, or "Remote" if the role is fully remote
+- jobType: one of %s
+- requiredSkills: the skills and technologies the role asks for, most important first, each as a short name (e.g. "Go", "Kubernetes")
+- salaryMin and salaryMax: whole numbers with no currency symbols or separators; use the same value for both if a single figure is given and 0 if no salary is stated
+- salaryCurrency: ISO 4217 code such as "USD", "EUR" or "GBP"
+- salaryPeriod: "year", "month", "day" or "hour"
+- seniority: one of %s
+- applicationUrl: the link to apply, only if it appears in the text
+- confidence: a number between 0 and 1 for each of title, company, location, jobType, requiredSkills, salary, seniority and applicationUrl; use 0 for fields you left empty
+- Use empty strings rather than guessing when a field is not stated
+- Always include {"isValid": true} for valid postings
+
+Job Posting:
+%s
+
+Please return the information in the exact JSON schema format specified.`,
+		strings.Join(extractionJobTypes, ", "),
+		strings.Join(extractionSeniorities, ", "),
+		postingText)
+}
@@ -0,0 +1,26 @@
+package prompts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobExtractionPrompt(t *testing.T) {
+	t.Run("should_include_posting_and_allowed_values", func(t *testing.T) {
+		prompt := JobExtractionPrompt("Senior Go Engineer at Example Ltd")
+
+		assert.Contains(t, prompt, "Job Posting:\nSenior Go Engineer at Example Ltd")
+		assert.Contains(t, prompt, "full_time, part_time, contract, intern, remote, freelance, other")
+		assert.Contains(t, prompt, "intern, junior, mid, senior, lead, principal, executive")
+		assert.Contains(t, prompt, `"isValid": false`)
+	})
+
+	t.Run("should_return_copies_of_allowed_values", func(t *testing.T) {
+		types := ExtractionJobTypes()
+		types[0] = "changed"
+
+		assert.Equal(t, "full_time", ExtractionJobTypes()[0])
+		assert.Contains(t, ExtractionSeniorities(), "senior")
+	})
+}
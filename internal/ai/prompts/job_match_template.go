@@ -116,8 +116,12 @@ func JobMatchTemplate() *PromptTemplate {
 
 // EnhanceJobMatchPrompt enhances a job matching prompt
 func EnhanceJobMatchPrompt(systemInstruction, applicantName, jobDescription, applicantProfile, extraContext string, minScore, maxScore int) string {
-	template := JobMatchTemplate()
+	return buildJobMatchPrompt(JobMatchTemplate(), systemInstruction, applicantName, jobDescription, applicantProfile, extraContext, minScore, maxScore)
+}
 
+// buildJobMatchPrompt adds the anti-AI constraints and score range to
+// template, which must not be shared with other callers.
+func buildJobMatchPrompt(template *PromptTemplate, systemInstruction, applicantName, jobDescription, applicantProfile, extraContext string, minScore, maxScore int) string {
 	template.Constraints = append(template.Constraints, JobMatchAntiAIConstraints()...)
 
 	params := map[string]any{
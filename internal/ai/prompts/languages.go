@@ -0,0 +1,63 @@
+package prompts
+
+// Languages documents can be written in, keyed by ISO 639-1 code.
+var languageNames = map[string]string{
+	"en": "English",
+	"de": "German",
+	"es": "Spanish",
+	"fr": "French",
+	"it": "Italian",
+	"nl": "Dutch",
+	"pl": "Polish",
+	"pt": "Portuguese",
+}
+
+// DefaultLanguage is the language documents are written in when none is
+// chosen.
+const DefaultLanguage = "en"
+
+// Languages returns the codes of the languages documents can be written in,
+// with English first.
+func Languages() []string {
+	return []string{"en", "de", "es", "fr", "it", "nl", "pl", "pt"}
+}
+
+// LanguageName returns the English name of a language code, or an empty
+// string if the language is not supported.
+func LanguageName(code string) string {
+	return languageNames[code]
+}
+
+// languageConstraint tells the model which language to write in. English
+// needs no instruction.
+func languageConstraint(code string) string {
+	name := LanguageName(code)
+	if name == "" || code == DefaultLanguage {
+		return ""
+	}
+	return "LANGUAGE: Write the entire output in " + name + ", using the conventions a native " + name + " speaker would expect, even though the job description and profile may be in another language. Keep JSON field names in English"
+}
+
+// cvLanguageConstraint tells the model which language to write a CV in. Dates
+// stay as given because they are localised when the CV is displayed.
+func cvLanguageConstraint(code string) string {
+	name := LanguageName(code)
+	if name == "" || code == DefaultLanguage {
+		return ""
+	}
+	return "- LANGUAGE: Write the title, summary, skills and work experience descriptions in " + name + ", as a native " + name + " speaker would, even if the profile or job description is in another language\n" +
+		"- Keep names of people, companies, institutions and products as written, and copy dates exactly as provided\n" +
+		"- Keep JSON field names in English"
+}
+
+// CVParsingLanguageNote returns extra guidance for parsing a CV written in
+// the language with the given code, or an empty string for English and
+// unknown languages.
+func CVParsingLanguageNote(code string) string {
+	name := LanguageName(code)
+	if name == "" || code == DefaultLanguage {
+		return ""
+	}
+	return "LANGUAGE NOTE: This CV appears to be written in " + name + ". Keep names, job titles and descriptions in " + name + " exactly as written; do not translate them. " +
+		"Convert " + name + " month names and date formats (for example \"03/2021\" or \"März 2021\") to the required date formats, and treat words meaning \"present\" or \"today\" (such as \"heute\", \"aujourd'hui\", \"actualidad\") as \"Present\"."
+}
@@ -0,0 +1,29 @@
+package prompts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLanguages(t *testing.T) {
+	assert.Equal(t, DefaultLanguage, Languages()[0])
+	for _, code := range Languages() {
+		assert.NotEmpty(t, LanguageName(code), code)
+	}
+	assert.Empty(t, LanguageName("xx"))
+}
+
+func TestLanguageConstraint(t *testing.T) {
+	assert.Empty(t, languageConstraint(""))
+	assert.Empty(t, languageConstraint("en"))
+	assert.Empty(t, languageConstraint("xx"))
+	assert.Contains(t, languageConstraint("de"), "Write the entire output in German")
+}
+
+func TestCVParsingLanguageNote(t *testing.T) {
+	assert.Empty(t, CVParsingLanguageNote(""))
+	assert.Empty(t, CVParsingLanguageNote("en"))
+	assert.Contains(t, CVParsingLanguageNote("de"), "written in German")
+	assert.Contains(t, CVParsingLanguageNote("de"), "\"Present\"")
+}
@@ -0,0 +1,64 @@
+package prompts
+
+import "time"
+
+// LearningPlanTemplate returns a template for skill-gap learning plans
+func LearningPlanTemplate() *PromptTemplate {
+	return &PromptTemplate{
+		Role:    "You are a career coach and senior practitioner who helps people close the skill gaps that keep coming up in their job applications. You favour hands-on projects over courses, and you know how hiring managers read new experience on a CV.",
+		Context: "The candidate has had several jobs analysed against their profile. The same gaps keep appearing in those analyses. They want a concrete plan for each of their top gaps: what to learn, a project that proves it, and how to describe the new experience honestly once they have it.",
+		Examples: []Example{
+			{
+				Input: "Candidate: Backend developer, 4 years of Python and PostgreSQL\nGap: Kubernetes (missing in 5 of 8 jobs; 'No production container orchestration experience')",
+				Output: `{
+  "gaps": [
+    {
+      "gap": "Kubernetes",
+      "why": "Five of the roles you're targeting deploy on Kubernetes and expect you to own your service's manifests.",
+      "steps": [
+        "Run a local cluster with kind and deploy one of your existing Python services to it",
+        "Add readiness and liveness probes, resource limits and a rolling update strategy",
+        "Set up a Helm chart for the service and deploy it to a managed cluster on a free tier"
+      ],
+      "projects": [
+        "Move the side project API you already run on a VM onto a small managed cluster, with CI building and deploying the image"
+      ],
+      "phrasing": [
+        "Deployed and operated a Python API on Kubernetes (Helm, probes, rolling updates) with CI-driven releases"
+      ]
+    }
+  ]
+}`,
+			},
+		},
+		Task: "Write a learning plan for each gap listed under Skill Gaps, in the order given. For each gap, say in one sentence why it matters for the roles the candidate is targeting, give 3-5 concrete steps in order, suggest 1-2 small projects that would prove the skill to an interviewer, and give 1-2 lines the candidate could add to their CV or profile once the project is done.",
+		Constraints: func() []string {
+			constraints := AntiAILanguageConstraints()
+			additionalConstraints := []string{
+				"Write one plan per gap and use the gap name exactly as given in the 'gap' field",
+				"Build on what the candidate already knows; refer to their existing skills and roles where it helps",
+				"Prefer projects the candidate can finish in a few weekends over long courses or certifications",
+				"Phrasing must describe only what the suggested project would actually produce; never claim professional experience the candidate doesn't have",
+				"Keep each step to one sentence",
+				"Do not use em dashes (—) in your writing, use commas or rewrite the sentence instead",
+			}
+			return append(constraints, additionalConstraints...)
+		}(),
+		OutputSpec: "Return ONLY a valid JSON object with 'gaps' (array of objects with 'gap', 'why', 'steps' (array of strings), 'projects' (array of strings) and 'phrasing' (array of strings))",
+	}
+}
+
+// EnhanceLearningPlanPrompt builds a learning plan prompt. targetRoles lists
+// the analysed jobs the gaps came from and skillGaps lists the gaps to plan
+// for, most frequent first.
+func EnhanceLearningPlanPrompt(systemInstruction, applicantName, targetRoles, applicantProfile, skillGaps, extraContext string) string {
+	context := "**Skill Gaps:**\n" + skillGaps
+	if extraContext != "" {
+		context += "\n\n" + extraContext
+	}
+
+	params := map[string]any{
+		"currentDate": time.Now().Format("January 2, 2006"),
+	}
+	return LearningPlanTemplate().BuildPrompt(systemInstruction, applicantName, targetRoles, applicantProfile, context, params)
+}
@@ -0,0 +1,25 @@
+package prompts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnhanceLearningPlanPrompt(t *testing.T) {
+	t.Run("should_place_gaps_before_extra_context", func(t *testing.T) {
+		prompt := EnhanceLearningPlanPrompt("System", "Jane Doe", "SRE at Example", "Support engineer", "1. Kubernetes (in 3 of 5 jobs)", "Context")
+
+		assert.Contains(t, prompt, "System")
+		assert.Contains(t, prompt, "**Applicant Name:** Jane Doe")
+		assert.Contains(t, prompt, "SRE at Example")
+		assert.Contains(t, prompt, "**Additional Context:**\n**Skill Gaps:**\n1. Kubernetes (in 3 of 5 jobs)\n\nContext")
+		assert.Contains(t, prompt, "'phrasing'")
+	})
+
+	t.Run("should_omit_empty_extra_context", func(t *testing.T) {
+		prompt := EnhanceLearningPlanPrompt("", "Jane Doe", "Job", "Profile", "1. Go", "")
+
+		assert.Contains(t, prompt, "**Skill Gaps:**\n1. Go\n\n# Your Task")
+	})
+}
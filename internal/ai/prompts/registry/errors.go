@@ -0,0 +1,16 @@
+package registry
+
+import (
+	commonerrors "github.com/benidevo/vega/internal/common/errors"
+)
+
+var (
+	ErrVersionNotFound = commonerrors.New("prompt version not found")
+	ErrVersionExists   = commonerrors.New("prompt version already exists")
+	ErrInvalidRollout  = commonerrors.New("invalid prompt rollout")
+)
+
+// WrapError wraps the given innerErr with the provided sentinelErr.
+func WrapError(sentinelErr, innerErr error) error {
+	return commonerrors.WrapError(sentinelErr, innerErr)
+}
@@ -0,0 +1,75 @@
+package registry
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/benidevo/vega/internal/ai/prompts"
+)
+
+// Source identifies where a prompt version was loaded from.
+type Source string
+
+const (
+	SourceBuiltin   Source = "builtin"
+	SourceDirectory Source = "directory"
+	SourceDatabase  Source = "database"
+)
+
+// Entry is a prompt version known to the registry.
+type Entry struct {
+	*prompts.Version
+	Source    Source
+	CreatedAt time.Time
+}
+
+// Rollout selects the template version of a prompt as stored in the
+// prompt_rollouts table. CandidatePercent of users receive CandidateVersion;
+// everyone else receives DefaultVersion.
+type Rollout struct {
+	Name             string    `json:"name"`
+	DefaultVersion   string    `json:"default_version"`
+	CandidateVersion string    `json:"candidate_version"`
+	CandidatePercent int       `json:"candidate_percent"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// defaultRollout sends every request to the built-in template.
+func defaultRollout(name string) *Rollout {
+	return &Rollout{Name: name, DefaultVersion: prompts.BuiltinVersion}
+}
+
+// Validate checks the rollout's prompt name and percentage.
+func (r *Rollout) Validate() error {
+	if !prompts.IsPromptName(r.Name) {
+		return WrapError(ErrInvalidRollout, fmt.Errorf("prompt '%s' cannot be versioned", r.Name))
+	}
+	if r.DefaultVersion == "" {
+		return WrapError(ErrInvalidRollout, fmt.Errorf("default version is required"))
+	}
+	if r.CandidatePercent < 0 || r.CandidatePercent > 100 {
+		return WrapError(ErrInvalidRollout, fmt.Errorf("candidate percentage must be between 0 and 100"))
+	}
+	if r.CandidateVersion == "" && r.CandidatePercent > 0 {
+		return WrapError(ErrInvalidRollout, fmt.Errorf("candidate version is required when routing requests to it"))
+	}
+	return nil
+}
+
+// VersionFor returns the version the user is routed to. Users are assigned to
+// a stable bucket per prompt, so each user keeps seeing the same variant
+// while the percentage is unchanged.
+func (r *Rollout) VersionFor(userID int) string {
+	if r.CandidateVersion != "" && bucket(r.Name, userID) < r.CandidatePercent {
+		return r.CandidateVersion
+	}
+	return r.DefaultVersion
+}
+
+// bucket maps a user to a number between 0 and 99 for the named prompt.
+func bucket(name string, userID int) int {
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%s:%d", name, userID)
+	return int(h.Sum32() % 100)
+}
@@ -0,0 +1,185 @@
+package registry
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/benidevo/vega/internal/ai/prompts"
+	ctxutil "github.com/benidevo/vega/internal/common/context"
+	"github.com/benidevo/vega/internal/common/logger"
+	"github.com/benidevo/vega/internal/config"
+)
+
+// Registry resolves named, versioned prompt templates from the built-in
+// prompts, a directory and the database, and chooses the version each request
+// uses according to the prompt's rollout.
+type Registry struct {
+	repo  Repository
+	files []*Entry
+	log   *logger.PrivacyLogger
+}
+
+// New creates a registry backed by repo. files are versions loaded from a
+// directory; they cannot be changed at runtime.
+func New(repo Repository, files []*prompts.Version) *Registry {
+	entries := make([]*Entry, 0, len(files))
+	for _, v := range files {
+		entries = append(entries, &Entry{Version: v, Source: SourceDirectory})
+	}
+
+	return &Registry{
+		repo:  repo,
+		files: entries,
+		log:   logger.GetPrivacyLogger("prompt_registry"),
+	}
+}
+
+// Setup creates a registry backed by db and the versions in cfg.AIPromptsDir.
+// A directory that cannot be loaded is logged and ignored.
+func Setup(db *sql.DB, cfg *config.Settings) *Registry {
+	var files []*prompts.Version
+	if cfg.AIPromptsDir != "" {
+		loaded, err := prompts.LoadDir(cfg.AIPromptsDir)
+		if err != nil {
+			logger.GetPrivacyLogger("prompt_registry").Error().Err(err).
+				Str("dir", cfg.AIPromptsDir).
+				Msg("Ignoring prompt versions directory")
+		} else {
+			files = loaded
+		}
+	}
+	return New(NewRepository(db), files)
+}
+
+// Versions returns every known version of the named prompt, built-in first.
+func (r *Registry) Versions(ctx context.Context, name string) ([]*Entry, error) {
+	if !prompts.IsPromptName(name) {
+		return nil, WrapError(prompts.ErrUnknownPrompt, fmt.Errorf("prompt '%s' cannot be versioned", name))
+	}
+
+	entries := []*Entry{{Version: prompts.Builtin(name), Source: SourceBuiltin}}
+	for _, entry := range r.files {
+		if entry.Name == name {
+			entries = append(entries, entry)
+		}
+	}
+
+	stored, err := r.repo.ListVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range stored {
+		if entry.Name == name {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// Version returns the named prompt's version, or ErrVersionNotFound.
+func (r *Registry) Version(ctx context.Context, name, version string) (*Entry, error) {
+	if version == prompts.BuiltinVersion {
+		if builtin := prompts.Builtin(name); builtin != nil {
+			return &Entry{Version: builtin, Source: SourceBuiltin}, nil
+		}
+		return nil, ErrVersionNotFound
+	}
+
+	for _, entry := range r.files {
+		if entry.Name == name && entry.Version.Version == version {
+			return entry, nil
+		}
+	}
+	return r.repo.GetVersion(ctx, name, version)
+}
+
+// AddVersion stores a new prompt version in the database. Versions cannot be
+// replaced once added, so results keep pointing at the text that produced them.
+func (r *Registry) AddVersion(ctx context.Context, version *prompts.Version) error {
+	if version.Version == prompts.BuiltinVersion {
+		return WrapError(prompts.ErrInvalidPromptVersion, fmt.Errorf("version '%s' is reserved", prompts.BuiltinVersion))
+	}
+	if err := version.Validate(); err != nil {
+		return err
+	}
+
+	_, err := r.Version(ctx, version.Name, version.Version)
+	if err == nil {
+		return ErrVersionExists
+	}
+	if !errors.Is(err, ErrVersionNotFound) {
+		return err
+	}
+	return r.repo.CreateVersion(ctx, version)
+}
+
+// Rollouts returns the rollout of every versioned prompt. Prompts without a
+// stored rollout use the built-in template.
+func (r *Registry) Rollouts(ctx context.Context) ([]*Rollout, error) {
+	stored, err := r.repo.ListRollouts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*Rollout, len(stored))
+	for _, rollout := range stored {
+		byName[rollout.Name] = rollout
+	}
+
+	rollouts := make([]*Rollout, 0, len(prompts.PromptNames()))
+	for _, name := range prompts.PromptNames() {
+		if rollout, ok := byName[name]; ok {
+			rollouts = append(rollouts, rollout)
+		} else {
+			rollouts = append(rollouts, defaultRollout(name))
+		}
+	}
+	return rollouts, nil
+}
+
+// SetRollout validates and stores the rollout of a prompt.
+func (r *Registry) SetRollout(ctx context.Context, rollout *Rollout) error {
+	if err := rollout.Validate(); err != nil {
+		return err
+	}
+
+	for _, version := range []string{rollout.DefaultVersion, rollout.CandidateVersion} {
+		if version == "" {
+			continue
+		}
+		if _, err := r.Version(ctx, rollout.Name, version); err != nil {
+			if errors.Is(err, ErrVersionNotFound) {
+				return WrapError(ErrInvalidRollout, fmt.Errorf("%s has no version '%s'", rollout.Name, version))
+			}
+			return err
+		}
+	}
+	return r.repo.SaveRollout(ctx, rollout)
+}
+
+// Select implements services.PromptSelector. It returns the version of the
+// named prompt that the user in ctx is routed to, falling back to the
+// built-in template when the rollout cannot be read or points at a missing
+// version.
+func (r *Registry) Select(ctx context.Context, name string) *prompts.Version {
+	rollout, err := r.repo.GetRollout(ctx, name)
+	if err != nil {
+		r.log.Warn().Err(err).Str("prompt", name).Msg("Using built-in prompt; rollout could not be read")
+		return prompts.Builtin(name)
+	}
+	if rollout == nil {
+		return prompts.Builtin(name)
+	}
+
+	userID, _ := ctxutil.GetUserID(ctx)
+	version := rollout.VersionFor(userID)
+
+	entry, err := r.Version(ctx, name, version)
+	if err != nil {
+		r.log.Warn().Err(err).Str("prompt", name).Str("version", version).Msg("Using built-in prompt; selected version is unavailable")
+		return prompts.Builtin(name)
+	}
+	return entry.Version
+}
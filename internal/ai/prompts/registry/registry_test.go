@@ -0,0 +1,264 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/benidevo/vega/internal/ai/prompts"
+	ctxutil "github.com/benidevo/vega/internal/common/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryRepository struct {
+	versions   []*Entry
+	rollouts   map[string]*Rollout
+	rolloutErr error
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{rollouts: map[string]*Rollout{}}
+}
+
+func (m *memoryRepository) ListVersions(ctx context.Context) ([]*Entry, error) {
+	return m.versions, nil
+}
+
+func (m *memoryRepository) GetVersion(ctx context.Context, name, version string) (*Entry, error) {
+	for _, entry := range m.versions {
+		if entry.Name == name && entry.Version.Version == version {
+			return entry, nil
+		}
+	}
+	return nil, ErrVersionNotFound
+}
+
+func (m *memoryRepository) CreateVersion(ctx context.Context, version *prompts.Version) error {
+	m.versions = append(m.versions, &Entry{Version: version, Source: SourceDatabase})
+	return nil
+}
+
+func (m *memoryRepository) ListRollouts(ctx context.Context) ([]*Rollout, error) {
+	var rollouts []*Rollout
+	for _, rollout := range m.rollouts {
+		rollouts = append(rollouts, rollout)
+	}
+	return rollouts, nil
+}
+
+func (m *memoryRepository) GetRollout(ctx context.Context, name string) (*Rollout, error) {
+	if m.rolloutErr != nil {
+		return nil, m.rolloutErr
+	}
+	return m.rollouts[name], nil
+}
+
+func (m *memoryRepository) SaveRollout(ctx context.Context, rollout *Rollout) error {
+	m.rollouts[rollout.Name] = rollout
+	return nil
+}
+
+func coverLetterVersion(version string) *prompts.Version {
+	return &prompts.Version{
+		Name:     prompts.PromptCoverLetter,
+		Version:  version,
+		Template: &prompts.PromptTemplate{Role: "Writer", Task: "Write a cover letter"},
+	}
+}
+
+func TestRegistry_Versions(t *testing.T) {
+	repo := newMemoryRepository()
+	require.NoError(t, repo.CreateVersion(context.Background(), coverLetterVersion("v3")))
+	r := New(repo, []*prompts.Version{coverLetterVersion("v2")})
+
+	entries, err := r.Versions(context.Background(), prompts.PromptCoverLetter)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, SourceBuiltin, entries[0].Source)
+	assert.Equal(t, "v2", entries[1].Version.Version)
+	assert.Equal(t, SourceDirectory, entries[1].Source)
+	assert.Equal(t, "v3", entries[2].Version.Version)
+	assert.Equal(t, SourceDatabase, entries[2].Source)
+
+	_, err = r.Versions(context.Background(), "unknown")
+	assert.ErrorIs(t, err, prompts.ErrUnknownPrompt)
+}
+
+func TestRegistry_AddVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		version     *prompts.Version
+		expectedErr error
+	}{
+		{
+			name:    "should_store_new_version",
+			version: coverLetterVersion("v3"),
+		},
+		{
+			name:        "should_reject_builtin_version",
+			version:     coverLetterVersion(prompts.BuiltinVersion),
+			expectedErr: prompts.ErrInvalidPromptVersion,
+		},
+		{
+			name:        "should_reject_version_loaded_from_directory",
+			version:     coverLetterVersion("v2"),
+			expectedErr: ErrVersionExists,
+		},
+		{
+			name:        "should_reject_invalid_version",
+			version:     &prompts.Version{Name: prompts.PromptCoverLetter, Version: "v4"},
+			expectedErr: prompts.ErrInvalidPromptVersion,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMemoryRepository()
+			r := New(repo, []*prompts.Version{coverLetterVersion("v2")})
+
+			err := r.AddVersion(context.Background(), tt.version)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Empty(t, repo.versions)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, repo.versions, 1)
+			}
+		})
+	}
+}
+
+func TestRegistry_SetRollout(t *testing.T) {
+	tests := []struct {
+		name        string
+		rollout     *Rollout
+		expectedErr error
+	}{
+		{
+			name:    "should_save_rollout_with_known_versions",
+			rollout: &Rollout{Name: prompts.PromptCoverLetter, DefaultVersion: prompts.BuiltinVersion, CandidateVersion: "v2", CandidatePercent: 20},
+		},
+		{
+			name:        "should_reject_unknown_candidate",
+			rollout:     &Rollout{Name: prompts.PromptCoverLetter, DefaultVersion: prompts.BuiltinVersion, CandidateVersion: "v9", CandidatePercent: 20},
+			expectedErr: ErrInvalidRollout,
+		},
+		{
+			name:        "should_reject_percentage_out_of_range",
+			rollout:     &Rollout{Name: prompts.PromptCoverLetter, DefaultVersion: prompts.BuiltinVersion, CandidateVersion: "v2", CandidatePercent: 120},
+			expectedErr: ErrInvalidRollout,
+		},
+		{
+			name:        "should_reject_percentage_without_candidate",
+			rollout:     &Rollout{Name: prompts.PromptCoverLetter, DefaultVersion: prompts.BuiltinVersion, CandidatePercent: 10},
+			expectedErr: ErrInvalidRollout,
+		},
+		{
+			name:        "should_reject_unknown_prompt",
+			rollout:     &Rollout{Name: "cv_parsing", DefaultVersion: prompts.BuiltinVersion},
+			expectedErr: ErrInvalidRollout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMemoryRepository()
+			r := New(repo, []*prompts.Version{coverLetterVersion("v2")})
+
+			err := r.SetRollout(context.Background(), tt.rollout)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Empty(t, repo.rollouts)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.rollout, repo.rollouts[tt.rollout.Name])
+			}
+		})
+	}
+}
+
+func TestRegistry_Rollouts(t *testing.T) {
+	repo := newMemoryRepository()
+	repo.rollouts[prompts.PromptJobMatch] = &Rollout{Name: prompts.PromptJobMatch, DefaultVersion: "v2"}
+	r := New(repo, nil)
+
+	rollouts, err := r.Rollouts(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rollouts, len(prompts.PromptNames()))
+	for _, rollout := range rollouts {
+		if rollout.Name == prompts.PromptJobMatch {
+			assert.Equal(t, "v2", rollout.DefaultVersion)
+		} else {
+			assert.Equal(t, prompts.BuiltinVersion, rollout.DefaultVersion)
+		}
+	}
+}
+
+func TestRegistry_Select(t *testing.T) {
+	t.Run("should_use_builtin_without_rollout", func(t *testing.T) {
+		r := New(newMemoryRepository(), []*prompts.Version{coverLetterVersion("v2")})
+
+		version := r.Select(context.Background(), prompts.PromptCoverLetter)
+
+		assert.Equal(t, prompts.BuiltinVersion, version.Version)
+	})
+
+	t.Run("should_use_builtin_when_rollout_cannot_be_read", func(t *testing.T) {
+		repo := newMemoryRepository()
+		repo.rolloutErr = fmt.Errorf("database is locked")
+		r := New(repo, []*prompts.Version{coverLetterVersion("v2")})
+
+		version := r.Select(context.Background(), prompts.PromptCoverLetter)
+
+		assert.Equal(t, prompts.BuiltinVersion, version.Version)
+	})
+
+	t.Run("should_use_builtin_when_selected_version_is_missing", func(t *testing.T) {
+		repo := newMemoryRepository()
+		repo.rollouts[prompts.PromptCoverLetter] = &Rollout{Name: prompts.PromptCoverLetter, DefaultVersion: "v9"}
+		r := New(repo, nil)
+
+		version := r.Select(context.Background(), prompts.PromptCoverLetter)
+
+		assert.Equal(t, prompts.BuiltinVersion, version.Version)
+	})
+
+	t.Run("should_route_share_of_users_to_candidate", func(t *testing.T) {
+		repo := newMemoryRepository()
+		repo.rollouts[prompts.PromptCoverLetter] = &Rollout{
+			Name:             prompts.PromptCoverLetter,
+			DefaultVersion:   prompts.BuiltinVersion,
+			CandidateVersion: "v2",
+			CandidatePercent: 30,
+		}
+		r := New(repo, []*prompts.Version{coverLetterVersion("v2")})
+
+		candidates := 0
+		for userID := 1; userID <= 1000; userID++ {
+			ctx := ctxutil.WithUserID(context.Background(), userID)
+			version := r.Select(ctx, prompts.PromptCoverLetter)
+			if version.Version == "v2" {
+				candidates++
+			}
+			assert.Equal(t, version.Version, r.Select(ctx, prompts.PromptCoverLetter).Version, "user %d should keep the same version", userID)
+		}
+		assert.InDelta(t, 300, candidates, 60)
+	})
+}
+
+func TestRollout_VersionFor(t *testing.T) {
+	rollout := &Rollout{Name: prompts.PromptJobMatch, DefaultVersion: "v1", CandidateVersion: "v2"}
+
+	rollout.CandidatePercent = 0
+	for userID := 1; userID <= 100; userID++ {
+		assert.Equal(t, "v1", rollout.VersionFor(userID))
+	}
+
+	rollout.CandidatePercent = 100
+	for userID := 1; userID <= 100; userID++ {
+		assert.Equal(t, "v2", rollout.VersionFor(userID))
+	}
+}
@@ -0,0 +1,188 @@
+package registry
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/benidevo/vega/internal/ai/prompts"
+)
+
+// Repository defines data access for prompt versions and rollouts.
+type Repository interface {
+	ListVersions(ctx context.Context) ([]*Entry, error)
+	GetVersion(ctx context.Context, name, version string) (*Entry, error)
+	CreateVersion(ctx context.Context, version *prompts.Version) error
+	ListRollouts(ctx context.Context) ([]*Rollout, error)
+	GetRollout(ctx context.Context, name string) (*Rollout, error)
+	SaveRollout(ctx context.Context, rollout *Rollout) error
+}
+
+// repository implements the Repository interface
+type repository struct {
+	db *sql.DB
+}
+
+// NewRepository creates a new prompt registry repository
+func NewRepository(db *sql.DB) Repository {
+	return &repository{db: db}
+}
+
+// ListVersions returns all stored prompt versions, oldest first
+func (r *repository) ListVersions(ctx context.Context) ([]*Entry, error) {
+	query := `
+		SELECT definition, created_at
+		FROM prompt_versions
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prompt versions: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*Entry
+	for rows.Next() {
+		entry, err := scanEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list prompt versions: %w", err)
+	}
+	return entries, nil
+}
+
+// GetVersion returns a stored prompt version, or ErrVersionNotFound
+func (r *repository) GetVersion(ctx context.Context, name, version string) (*Entry, error) {
+	query := `
+		SELECT definition, created_at
+		FROM prompt_versions
+		WHERE name = ? AND version = ?
+	`
+
+	entry, err := scanEntry(r.db.QueryRowContext(ctx, query, name, version))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrVersionNotFound
+	}
+	return entry, err
+}
+
+// CreateVersion stores a new prompt version
+func (r *repository) CreateVersion(ctx context.Context, version *prompts.Version) error {
+	definition, err := json.Marshal(version)
+	if err != nil {
+		return fmt.Errorf("failed to encode prompt version: %w", err)
+	}
+
+	query := `
+		INSERT INTO prompt_versions (name, version, definition)
+		VALUES (?, ?, ?)
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, version.Name, version.Version, string(definition)); err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return ErrVersionExists
+		}
+		return fmt.Errorf("failed to save prompt version: %w", err)
+	}
+	return nil
+}
+
+// ListRollouts returns the stored rollouts of all prompts
+func (r *repository) ListRollouts(ctx context.Context) ([]*Rollout, error) {
+	query := `
+		SELECT name, default_version, candidate_version, candidate_percent, updated_at
+		FROM prompt_rollouts
+		ORDER BY name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prompt rollouts: %w", err)
+	}
+	defer rows.Close()
+
+	var rollouts []*Rollout
+	for rows.Next() {
+		var rollout Rollout
+		if err := rows.Scan(&rollout.Name, &rollout.DefaultVersion, &rollout.CandidateVersion, &rollout.CandidatePercent, &rollout.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan prompt rollout: %w", err)
+		}
+		rollouts = append(rollouts, &rollout)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list prompt rollouts: %w", err)
+	}
+	return rollouts, nil
+}
+
+// GetRollout returns the rollout of the named prompt, or nil when none is stored
+func (r *repository) GetRollout(ctx context.Context, name string) (*Rollout, error) {
+	query := `
+		SELECT name, default_version, candidate_version, candidate_percent, updated_at
+		FROM prompt_rollouts
+		WHERE name = ?
+	`
+
+	var rollout Rollout
+	err := r.db.QueryRowContext(ctx, query, name).Scan(
+		&rollout.Name, &rollout.DefaultVersion, &rollout.CandidateVersion, &rollout.CandidatePercent, &rollout.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prompt rollout: %w", err)
+	}
+	return &rollout, nil
+}
+
+// SaveRollout stores the rollout, replacing any existing one for the prompt
+func (r *repository) SaveRollout(ctx context.Context, rollout *Rollout) error {
+	query := `
+		INSERT INTO prompt_rollouts (name, default_version, candidate_version, candidate_percent)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(name) DO UPDATE SET
+			default_version = excluded.default_version,
+			candidate_version = excluded.candidate_version,
+			candidate_percent = excluded.candidate_percent,
+			updated_at = CURRENT_TIMESTAMP
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		rollout.Name, rollout.DefaultVersion, rollout.CandidateVersion, rollout.CandidatePercent,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save prompt rollout: %w", err)
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanEntry(row rowScanner) (*Entry, error) {
+	var definition string
+	entry := &Entry{Source: SourceDatabase}
+	if err := row.Scan(&definition, &entry.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan prompt version: %w", err)
+	}
+
+	version, err := prompts.ParseVersion([]byte(definition))
+	if err != nil {
+		return nil, fmt.Errorf("stored prompt version is invalid: %w", err)
+	}
+	entry.Version = version
+	return entry, nil
+}
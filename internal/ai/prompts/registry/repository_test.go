@@ -0,0 +1,148 @@
+package registry
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/benidevo/vega/internal/ai/prompts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_GetVersion(t *testing.T) {
+	t.Run("should_parse_stored_definition", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		now := time.Now()
+		definition := `{"name":"cover_letter","version":"v2","template":{"role":"Writer","task":"Write a cover letter"}}`
+		mock.ExpectQuery("SELECT definition, created_at FROM prompt_versions").
+			WithArgs("cover_letter", "v2").
+			WillReturnRows(sqlmock.NewRows([]string{"definition", "created_at"}).AddRow(definition, now))
+
+		entry, err := NewRepository(db).GetVersion(context.Background(), "cover_letter", "v2")
+
+		require.NoError(t, err)
+		assert.Equal(t, "v2", entry.Version.Version)
+		assert.Equal(t, "Writer", entry.Template.Role)
+		assert.Equal(t, SourceDatabase, entry.Source)
+		assert.Equal(t, now, entry.CreatedAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should_return_not_found_when_missing", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT definition, created_at FROM prompt_versions").
+			WithArgs("cover_letter", "v9").
+			WillReturnError(sql.ErrNoRows)
+
+		_, err = NewRepository(db).GetVersion(context.Background(), "cover_letter", "v9")
+
+		assert.ErrorIs(t, err, ErrVersionNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_CreateVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupErr    error
+		expectedErr error
+	}{
+		{
+			name: "should_insert_version",
+		},
+		{
+			name:        "should_return_exists_on_unique_violation",
+			setupErr:    errors.New("UNIQUE constraint failed: prompt_versions.name, prompt_versions.version"),
+			expectedErr: ErrVersionExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			version := &prompts.Version{Name: prompts.PromptCVGeneration, Version: "v2", Text: "Write a CV for {{CV_TEXT}}"}
+			exec := mock.ExpectExec("INSERT INTO prompt_versions").
+				WithArgs("cv_generation", "v2", sqlmock.AnyArg())
+			if tt.setupErr != nil {
+				exec.WillReturnError(tt.setupErr)
+			} else {
+				exec.WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+
+			err = NewRepository(db).CreateVersion(context.Background(), version)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRepository_GetRollout(t *testing.T) {
+	t.Run("should_return_stored_rollout", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT name, default_version, candidate_version, candidate_percent, updated_at FROM prompt_rollouts").
+			WithArgs("job_match").
+			WillReturnRows(sqlmock.NewRows([]string{"name", "default_version", "candidate_version", "candidate_percent", "updated_at"}).
+				AddRow("job_match", "builtin", "v2", 25, time.Now()))
+
+		rollout, err := NewRepository(db).GetRollout(context.Background(), "job_match")
+
+		require.NoError(t, err)
+		assert.Equal(t, "v2", rollout.CandidateVersion)
+		assert.Equal(t, 25, rollout.CandidatePercent)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should_return_nil_when_missing", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT name, default_version, candidate_version, candidate_percent, updated_at FROM prompt_rollouts").
+			WithArgs("job_match").
+			WillReturnError(sql.ErrNoRows)
+
+		rollout, err := NewRepository(db).GetRollout(context.Background(), "job_match")
+
+		assert.NoError(t, err)
+		assert.Nil(t, rollout)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_SaveRollout(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO prompt_rollouts").
+		WithArgs("job_match", "builtin", "v2", 25).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = NewRepository(db).SaveRollout(context.Background(), &Rollout{
+		Name: "job_match", DefaultVersion: "builtin", CandidateVersion: "v2", CandidatePercent: 25,
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
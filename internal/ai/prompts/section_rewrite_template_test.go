@@ -0,0 +1,32 @@
+package prompts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnhanceSectionRewritePrompt(t *testing.T) {
+	t.Run("should_include_section_instruction_and_format", func(t *testing.T) {
+		prompt := EnhanceSectionRewritePrompt(SectionSkills, "shorten", "System", "Jane Doe", "SRE at Example", "Support engineer", "Go, Kubernetes, Terraform", "")
+
+		assert.Contains(t, prompt, "System")
+		assert.Contains(t, prompt, "**Section:** CV skills list\n\n**Rewrite Instruction:** shorten\n\n**Original Text:**\nGo, Kubernetes, Terraform\n\n# Your Task")
+		assert.Contains(t, prompt, "FORMAT: Return the skills as a single comma-separated line")
+		assert.Contains(t, prompt, "'changes'")
+	})
+
+	t.Run("should_fall_back_for_unknown_section", func(t *testing.T) {
+		prompt := EnhanceSectionRewritePrompt("footer", "shorten", "", "Jane Doe", "Job", "Profile", "Some text", "Context")
+
+		assert.Contains(t, prompt, "**Section:** document section")
+		assert.Contains(t, prompt, "Some text\n\nContext")
+		assert.NotContains(t, prompt, "FORMAT:")
+	})
+
+	t.Run("should_name_every_section", func(t *testing.T) {
+		for _, section := range RewriteSections() {
+			assert.NotEmpty(t, SectionName(section), section)
+		}
+	})
+}
@@ -51,3 +51,23 @@ func JobMatchAntiAIConstraints() []string {
 	}
 	return append(constraints, matchSpecific...)
 }
+
+// InterviewPrepAntiAIConstraints returns interview preparation-specific anti-AI language rules
+func InterviewPrepAntiAIConstraints() []string {
+	constraints := AntiAILanguageConstraints()
+	prepSpecific := []string{
+		"SPOKEN ANSWERS: Write answers the way a person would actually say them in an interview",
+		"Avoid rehearsed-sounding openers like 'Great question' or 'In my previous role I was responsible for'",
+	}
+	return append(constraints, prepSpecific...)
+}
+
+// EmailAntiAIConstraints returns application email-specific anti-AI language rules
+func EmailAntiAIConstraints() []string {
+	constraints := AntiAILanguageConstraints()
+	emailSpecific := []string{
+		"Write like a person dashing off a considered email, not a formal letter",
+		"Skip stock openers like 'I hope this email finds you well' or 'I am writing to'",
+	}
+	return append(constraints, emailSpecific...)
+}
@@ -2,7 +2,9 @@ package prompts
 
 // PromptEnhancer provides methods to enhance existing prompts
 type PromptEnhancer struct {
-	templates map[string]*PromptTemplate
+	templates  map[string]*PromptTemplate
+	cvTemplate string
+	versions   map[string]string
 }
 
 // NewPromptEnhancer creates a new prompt enhancer
@@ -12,20 +14,50 @@ func NewPromptEnhancer() *PromptEnhancer {
 			"cover_letter": CoverLetterTemplate(),
 			"job_match":    JobMatchTemplate(),
 		},
+		cvTemplate: CVGenerationEnhancedTemplate,
+		versions: map[string]string{
+			PromptCoverLetter:  BuiltinVersion,
+			PromptJobMatch:     BuiltinVersion,
+			PromptCVGeneration: BuiltinVersion,
+		},
+	}
+}
+
+// Use replaces the template of the version's prompt with the version
+func (pe *PromptEnhancer) Use(version *Version) {
+	switch version.Name {
+	case PromptCoverLetter, PromptJobMatch:
+		if version.Template == nil {
+			return
+		}
+		pe.templates[version.Name] = version.Template
+	case PromptCVGeneration:
+		if version.Text == "" {
+			return
+		}
+		pe.cvTemplate = version.Text
+	default:
+		return
 	}
+	pe.versions[version.Name] = version.Version
+}
+
+// Version returns the version of the named prompt's template
+func (pe *PromptEnhancer) Version(name string) string {
+	return pe.versions[name]
 }
 
 // EnhanceCoverLetterPrompt enhances a cover letter prompt
-func (pe *PromptEnhancer) EnhanceCoverLetterPrompt(systemInstruction, applicantName, jobDescription, applicantProfile, extraContext, wordRange string) string {
-	return EnhanceCoverLetterPrompt(systemInstruction, applicantName, jobDescription, applicantProfile, extraContext, wordRange)
+func (pe *PromptEnhancer) EnhanceCoverLetterPrompt(systemInstruction, applicantName, jobDescription, applicantProfile, extraContext, wordRange, tone, language string) string {
+	return buildCoverLetterPrompt(pe.templates[PromptCoverLetter].clone(), systemInstruction, applicantName, jobDescription, applicantProfile, extraContext, wordRange, tone, language)
 }
 
 // EnhanceJobMatchPrompt enhances a job matching prompt
 func (pe *PromptEnhancer) EnhanceJobMatchPrompt(systemInstruction, applicantName, jobDescription, applicantProfile, extraContext string, minScore, maxScore int) string {
-	return EnhanceJobMatchPrompt(systemInstruction, applicantName, jobDescription, applicantProfile, extraContext, minScore, maxScore)
+	return buildJobMatchPrompt(pe.templates[PromptJobMatch].clone(), systemInstruction, applicantName, jobDescription, applicantProfile, extraContext, minScore, maxScore)
 }
 
 // EnhanceCVGenerationPrompt enhances a CV generation prompt
-func (pe *PromptEnhancer) EnhanceCVGenerationPrompt(systemInstruction, cvText, jobDescription, extraContext string) string {
-	return EnhanceCVGenerationPrompt(systemInstruction, cvText, jobDescription, extraContext)
+func (pe *PromptEnhancer) EnhanceCVGenerationPrompt(systemInstruction, cvText, jobDescription, extraContext, language string) string {
+	return buildCVGenerationPrompt(pe.cvTemplate, systemInstruction, cvText, jobDescription, extraContext, language)
 }
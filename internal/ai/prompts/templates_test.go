@@ -1,6 +1,7 @@
 package prompts
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -25,6 +26,8 @@ func TestPromptEnhancer_EnhanceCoverLetterPrompt(t *testing.T) {
 		"Experienced developer",
 		"Extra context",
 		"300-500",
+		"",
+		"",
 	)
 
 	assert.Contains(t, result, "John Doe")
@@ -60,9 +63,50 @@ func TestPromptEnhancer_EnhanceCVGenerationPrompt(t *testing.T) {
 		"Current CV content",
 		"Target job description",
 		"Extra requirements",
+		"",
 	)
 
 	assert.Contains(t, result, "Current CV content")
 	assert.Contains(t, result, "Target job description")
 	assert.Contains(t, result, "Extra requirements")
 }
+
+func TestPromptEnhancer_Use(t *testing.T) {
+	enhancer := NewPromptEnhancer()
+	assert.Equal(t, BuiltinVersion, enhancer.Version(PromptCoverLetter))
+
+	enhancer.Use(&Version{
+		Name:     PromptCoverLetter,
+		Version:  "concise",
+		Template: &PromptTemplate{Role: "Concise writer", Task: "Write a short letter"},
+	})
+	enhancer.Use(&Version{
+		Name:    PromptCVGeneration,
+		Version: "plain",
+		Text:    "CV: {{.CVText}}\nJob: {{.JobDescription}}",
+	})
+
+	assert.Equal(t, "concise", enhancer.Version(PromptCoverLetter))
+	assert.Equal(t, "plain", enhancer.Version(PromptCVGeneration))
+	assert.Equal(t, BuiltinVersion, enhancer.Version(PromptJobMatch))
+
+	letter := enhancer.EnhanceCoverLetterPrompt("", "John Doe", "Engineer", "Profile", "", "200-300", "", "")
+	assert.Contains(t, letter, "Concise writer")
+	assert.Contains(t, letter, "Write a short letter")
+
+	cv := enhancer.EnhanceCVGenerationPrompt("System", "My CV", "The job", "", "")
+	assert.Equal(t, "System\n\nCV: My CV\nJob: The job", cv)
+
+	localized := enhancer.EnhanceCVGenerationPrompt("System", "My CV", "The job", "", "de")
+	assert.True(t, strings.HasPrefix(localized, "System\n\nCV: My CV\nJob: The job\n\n## Language\n"))
+}
+
+func TestPromptEnhancer_EnhanceJobMatchPromptDoesNotModifyTemplate(t *testing.T) {
+	enhancer := NewPromptEnhancer()
+	constraints := len(enhancer.templates[PromptJobMatch].Constraints)
+
+	enhancer.EnhanceJobMatchPrompt("", "Jane", "Role", "Profile", "", 0, 100)
+	enhancer.EnhanceJobMatchPrompt("", "Jane", "Role", "Profile", "", 0, 100)
+
+	assert.Len(t, enhancer.templates[PromptJobMatch].Constraints, constraints)
+}
@@ -0,0 +1,132 @@
+package prompts
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Names of the prompts that can be versioned.
+const (
+	PromptCoverLetter  = "cover_letter"
+	PromptJobMatch     = "job_match"
+	PromptCVGeneration = "cv_generation"
+)
+
+// BuiltinVersion identifies the templates compiled into the application.
+const BuiltinVersion = "builtin"
+
+// versionPattern restricts version identifiers to short, URL-safe labels.
+var versionPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+// PromptNames returns the names of the prompts that can be versioned.
+func PromptNames() []string {
+	return []string{PromptCoverLetter, PromptJobMatch, PromptCVGeneration}
+}
+
+// IsPromptName reports whether name is a prompt that can be versioned.
+func IsPromptName(name string) bool {
+	for _, n := range PromptNames() {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Version is a named, versioned prompt template. Cover letter and job match
+// prompts are described by Template; the CV generation prompt is a text
+// template in Text using the placeholders of CVGenerationEnhancedTemplate.
+type Version struct {
+	Name     string          `json:"name"`
+	Version  string          `json:"version"`
+	Template *PromptTemplate `json:"template,omitempty"`
+	Text     string          `json:"text,omitempty"`
+}
+
+// Validate checks that the version is complete for the prompt it names.
+func (v *Version) Validate() error {
+	if !IsPromptName(v.Name) {
+		return WrapError(ErrUnknownPrompt, fmt.Errorf("prompt '%s' cannot be versioned", v.Name))
+	}
+	if !versionPattern.MatchString(v.Version) {
+		return WrapError(ErrInvalidPromptVersion, fmt.Errorf("version '%s' must be 1-64 letters, digits, dots, dashes or underscores", v.Version))
+	}
+
+	if v.Name == PromptCVGeneration {
+		for _, placeholder := range []string{"{{.CVText}}", "{{.JobDescription}}"} {
+			if !strings.Contains(v.Text, placeholder) {
+				return WrapError(ErrInvalidPromptVersion, fmt.Errorf("%s text must contain %s", v.Name, placeholder))
+			}
+		}
+		return nil
+	}
+
+	if v.Template == nil || strings.TrimSpace(v.Template.Task) == "" {
+		return WrapError(ErrInvalidPromptVersion, fmt.Errorf("%s template must define a task", v.Name))
+	}
+	return nil
+}
+
+// BuiltinVersions returns the built-in template of every versioned prompt.
+func BuiltinVersions() []*Version {
+	return []*Version{
+		{Name: PromptCoverLetter, Version: BuiltinVersion, Template: CoverLetterTemplate()},
+		{Name: PromptJobMatch, Version: BuiltinVersion, Template: JobMatchTemplate()},
+		{Name: PromptCVGeneration, Version: BuiltinVersion, Text: CVGenerationEnhancedTemplate},
+	}
+}
+
+// Builtin returns the built-in template of the named prompt, or nil when the
+// prompt cannot be versioned.
+func Builtin(name string) *Version {
+	for _, v := range BuiltinVersions() {
+		if v.Name == name {
+			return v
+		}
+	}
+	return nil
+}
+
+// ParseVersion decodes and validates a JSON encoded prompt version.
+func ParseVersion(data []byte) (*Version, error) {
+	var v Version
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, WrapError(ErrInvalidPromptVersion, err)
+	}
+	if v.Version == BuiltinVersion {
+		return nil, WrapError(ErrInvalidPromptVersion, fmt.Errorf("version '%s' is reserved", BuiltinVersion))
+	}
+	if err := v.Validate(); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// LoadDir reads every *.json prompt version in dir, in file name order.
+func LoadDir(dir string) ([]*Version, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, WrapError(ErrPromptLoadFailed, err)
+	}
+	sort.Strings(paths)
+
+	versions := make([]*Version, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, WrapError(ErrPromptLoadFailed, err)
+		}
+
+		v, err := ParseVersion(data)
+		if err != nil {
+			return nil, WrapError(ErrPromptLoadFailed, fmt.Errorf("%s: %w", filepath.Base(path), err))
+		}
+		versions = append(versions, v)
+	}
+	return versions, nil
+}
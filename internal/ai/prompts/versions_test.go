@@ -0,0 +1,94 @@
+package prompts
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersion_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		version Version
+		wantErr error
+	}{
+		{
+			name:    "should_accept_template_version",
+			version: Version{Name: PromptJobMatch, Version: "v2", Template: &PromptTemplate{Task: "Score the match"}},
+		},
+		{
+			name:    "should_accept_cv_text_version",
+			version: Version{Name: PromptCVGeneration, Version: "2025.10", Text: "{{.CVText}} {{.JobDescription}}"},
+		},
+		{
+			name:    "should_reject_unknown_prompt",
+			version: Version{Name: "cv_parsing", Version: "v2", Template: &PromptTemplate{Task: "Parse"}},
+			wantErr: ErrUnknownPrompt,
+		},
+		{
+			name:    "should_reject_invalid_version_label",
+			version: Version{Name: PromptJobMatch, Version: "v 2", Template: &PromptTemplate{Task: "Score"}},
+			wantErr: ErrInvalidPromptVersion,
+		},
+		{
+			name:    "should_reject_template_without_task",
+			version: Version{Name: PromptCoverLetter, Version: "v2", Template: &PromptTemplate{Role: "Writer"}},
+			wantErr: ErrInvalidPromptVersion,
+		},
+		{
+			name:    "should_reject_cv_text_without_placeholders",
+			version: Version{Name: PromptCVGeneration, Version: "v2", Text: "Write a CV"},
+			wantErr: ErrInvalidPromptVersion,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.version.Validate()
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, errors.Is(err, tt.wantErr), "got %v", err)
+		})
+	}
+}
+
+func TestBuiltinVersions(t *testing.T) {
+	for _, name := range PromptNames() {
+		v := Builtin(name)
+		require.NotNil(t, v, name)
+		assert.Equal(t, BuiltinVersion, v.Version)
+		assert.NoError(t, v.Validate())
+	}
+	assert.Nil(t, Builtin("cv_parsing"))
+}
+
+func TestParseVersion_RejectsBuiltinLabel(t *testing.T) {
+	_, err := ParseVersion([]byte(`{"name":"job_match","version":"builtin","template":{"task":"Score"}}`))
+	assert.True(t, errors.Is(err, ErrInvalidPromptVersion))
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.json"), []byte(`{"name":"job_match","version":"strict","template":{"role":"Recruiter","task":"Score strictly","constraints":["Be blunt"]}}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.json"), []byte(`{"name":"cv_generation","version":"short","text":"{{.CVText}} for {{.JobDescription}}"}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0o600))
+
+	versions, err := LoadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+
+	assert.Equal(t, "short", versions[0].Version)
+	assert.Equal(t, "strict", versions[1].Version)
+	assert.Equal(t, "Recruiter", versions[1].Template.Role)
+	assert.Equal(t, []string{"Be blunt"}, versions[1].Template.Constraints)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "c.json"), []byte(`{"name":"job_match"}`), 0o600))
+	_, err = LoadDir(dir)
+	assert.True(t, errors.Is(err, ErrPromptLoadFailed))
+}
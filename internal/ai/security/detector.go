@@ -0,0 +1,108 @@
+package security
+
+import (
+	"math"
+	"regexp"
+	"sort"
+)
+
+// DefaultInjectionThreshold is the risk score from which text is treated as
+// a likely prompt injection.
+const DefaultInjectionThreshold = 0.5
+
+// injectionRule is one pattern of a prompt injection attempt. Weight is the
+// probability, from 0 to 1, that text matching it is an injection.
+type injectionRule struct {
+	name    string
+	pattern *regexp.Regexp
+	weight  float64
+}
+
+// Span is a part of the text that matched an injection rule. Start and End
+// are byte offsets into the text.
+type Span struct {
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Rule  string `json:"rule"`
+	Text  string `json:"text"`
+}
+
+// Detection is the result of checking text for prompt injection. Score runs
+// from 0 (nothing suspicious) to 1 (almost certainly an injection).
+type Detection struct {
+	Score float64 `json:"score"`
+	Spans []Span  `json:"spans,omitempty"`
+}
+
+// Exceeds reports whether the detection scores at or above threshold.
+func (d Detection) Exceeds(threshold float64) bool {
+	return len(d.Spans) > 0 && d.Score >= threshold
+}
+
+// InjectionDetector scores text for prompt injection attempts without
+// changing it.
+type InjectionDetector struct {
+	rules []injectionRule
+}
+
+// NewInjectionDetector creates a detector with the built-in rules
+func NewInjectionDetector() *InjectionDetector {
+	return &InjectionDetector{
+		rules: []injectionRule{
+			// Role markers that try to open a new conversation turn
+			{"role_marker", regexp.MustCompile(`(?i)\b(system|assistant|human)\s*[:：]`), 0.3},
+
+			// Role manipulation attempts
+			{"role_change", regexp.MustCompile(`(?i)you\s+are\s+now\s+`), 0.5},
+			{"ignore_instructions", regexp.MustCompile(`(?i)(ignore|disregard)\s+(all\s+|any\s+|the\s+)?(previous|prior|above|earlier|all)\s+(instructions?|prompts?|rules)`), 0.8},
+			{"forget_instructions", regexp.MustCompile(`(?i)forget\s+(everything|all)\s+`), 0.5},
+
+			// Instruction override attempts
+			{"new_instructions", regexp.MustCompile(`(?i)(new|updated?)\s+instructions?\s*[:：]`), 0.6},
+			{"addressed_to_ai", regexp.MustCompile(`(?i)\bif\s+you\s+are\s+an?\s+(ai|llm|language\s+model|assistant|chatbot)\b`), 0.6},
+			{"score_override", regexp.MustCompile(`(?i)(rate|score|rank)\s+(this|the|every)\s+(candidate|applicant)\s+(as\s+)?(100|10/10|a\s+perfect|the\s+best)`), 0.7},
+			{"prompt_leak", regexp.MustCompile(`(?i)(reveal|print|repeat|show)\s+(your|the)\s+(system\s+)?(prompt|instructions)`), 0.6},
+
+			// JSON/Code injection attempts
+			{"json_injection", regexp.MustCompile(`[{}]\s*"[^"]*"\s*[:：]\s*[{}]`), 0.3},
+			{"output_override", regexp.MustCompile(`(?i)return\s+(json|code|script)`), 0.2},
+		},
+	}
+}
+
+// Detect returns the risk score of text and the spans that contributed to
+// it. Each rule counts once towards the score however often it matches, and
+// the rules combine as independent signals, so the score only reaches 1 when
+// a rule is certain.
+func (d *InjectionDetector) Detect(text string) Detection {
+	var detection Detection
+	if text == "" {
+		return detection
+	}
+
+	clean := 1.0
+	for _, rule := range d.rules {
+		matches := rule.pattern.FindAllStringIndex(text, -1)
+		if len(matches) == 0 {
+			continue
+		}
+		clean *= 1 - rule.weight
+		for _, m := range matches {
+			detection.Spans = append(detection.Spans, Span{
+				Start: m[0],
+				End:   m[1],
+				Rule:  rule.name,
+				Text:  text[m[0]:m[1]],
+			})
+		}
+	}
+
+	sort.Slice(detection.Spans, func(i, j int) bool {
+		if detection.Spans[i].Start != detection.Spans[j].Start {
+			return detection.Spans[i].Start < detection.Spans[j].Start
+		}
+		return detection.Spans[i].End > detection.Spans[j].End
+	})
+	detection.Score = math.Round((1-clean)*100) / 100
+	return detection
+}
@@ -0,0 +1,298 @@
+package security
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Kinds of personal details a Redaction replaces, used as token prefixes
+const (
+	PIIName  = "NAME"
+	PIIEmail = "EMAIL"
+	PIIPhone = "PHONE"
+	PIIURL   = "URL"
+)
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+	// Only links to personal profiles are redacted; company and job board
+	// links are needed to understand a posting.
+	profileURLPattern = regexp.MustCompile(`(?i)(?:https?://)?(?:[a-z]{2,3}\.)?(?:linkedin\.com/in|github\.com|gitlab\.com|twitter\.com|x\.com|facebook\.com|instagram\.com)/[^\s,;"'<>()\[\]]+`)
+
+	phonePattern = regexp.MustCompile(`\+?\(?\d[\d ().\-]{7,18}\d`)
+	yearPattern  = regexp.MustCompile(`^(19|20)\d{2}$`)
+
+	tokenPattern = regexp.MustCompile(`\[(NAME|EMAIL|PHONE|URL)_\d+\]`)
+)
+
+// Phone numbers have between this many digits
+const (
+	minPhoneDigits = 9
+	maxPhoneDigits = 15
+)
+
+// MaxTokenLength is the length of the longest placeholder token a Redaction
+// produces in practice, used to hold back partial tokens in streamed output.
+const MaxTokenLength = len("[EMAIL_999]")
+
+// Redaction swaps personal details in prompt text for placeholder tokens such
+// as [NAME_1] or [EMAIL_1] and puts them back in the model's output. A value
+// always gets the same token, so one Redaction should be used for a whole
+// request.
+type Redaction struct {
+	names     []string
+	tokens    map[string]string // original value to token
+	originals map[string]string // token to original value
+	counts    map[string]int
+}
+
+// NewRedaction creates a Redaction that also replaces the given names. Each
+// name is matched in full and by its parts, so "Jane Doe" also covers "Jane"
+// and "Doe" on their own. Names are matched case-sensitively on word
+// boundaries.
+func NewRedaction(names ...string) *Redaction {
+	seen := make(map[string]bool)
+	var candidates []string
+	for _, name := range names {
+		name = strings.Join(strings.Fields(name), " ")
+		if name == "" {
+			continue
+		}
+		for _, candidate := range append([]string{name}, strings.Fields(name)...) {
+			if utf8.RuneCountInString(candidate) >= 2 && !seen[candidate] {
+				seen[candidate] = true
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+	// Longest first, so a full name wins over its parts
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return len(candidates[i]) > len(candidates[j])
+	})
+
+	return &Redaction{
+		names:     candidates,
+		tokens:    make(map[string]string),
+		originals: make(map[string]string),
+		counts:    make(map[string]int),
+	}
+}
+
+// Redact replaces emails, phone numbers, profile links and the known names in
+// text with placeholder tokens.
+func (r *Redaction) Redact(text string) string {
+	if text == "" {
+		return text
+	}
+
+	text = r.replacePattern(text, emailPattern, PIIEmail, nil)
+	text = r.replacePattern(text, profileURLPattern, PIIURL, nil)
+	text = r.replacePattern(text, phonePattern, PIIPhone, isPhoneNumber)
+	for _, name := range r.names {
+		text = r.replaceName(text, name)
+	}
+	return text
+}
+
+// Restore puts the original values back in place of the tokens in text.
+// Tokens this Redaction did not produce are left alone.
+func (r *Redaction) Restore(text string) string {
+	if len(r.originals) == 0 {
+		return text
+	}
+	return tokenPattern.ReplaceAllStringFunc(text, func(token string) string {
+		if original, ok := r.originals[token]; ok {
+			return original
+		}
+		return token
+	})
+}
+
+// RestoreJSON is Restore for raw JSON output, such as streamed chunks, where
+// the original values must be escaped as JSON string content.
+func (r *Redaction) RestoreJSON(text string) string {
+	if len(r.originals) == 0 {
+		return text
+	}
+	return tokenPattern.ReplaceAllStringFunc(text, func(token string) string {
+		original, ok := r.originals[token]
+		if !ok {
+			return token
+		}
+		encoded, err := json.Marshal(original)
+		if err != nil {
+			return token
+		}
+		return string(encoded[1 : len(encoded)-1])
+	})
+}
+
+// Count returns the number of distinct values redacted so far.
+func (r *Redaction) Count() int {
+	return len(r.originals)
+}
+
+// RedactValue returns a copy of v with every exported string field, slice
+// element and map value redacted. v itself is not modified.
+func (r *Redaction) RedactValue(v any) any {
+	return mapStrings(v, r.Redact)
+}
+
+// RestoreValue returns a copy of v with the tokens in every exported string
+// field, slice element and map value restored. v itself is not modified.
+func (r *Redaction) RestoreValue(v any) any {
+	if len(r.originals) == 0 {
+		return v
+	}
+	return mapStrings(v, r.Restore)
+}
+
+func (r *Redaction) token(kind, value string) string {
+	if token, ok := r.tokens[value]; ok {
+		return token
+	}
+	r.counts[kind]++
+	token := fmt.Sprintf("[%s_%d]", kind, r.counts[kind])
+	r.tokens[value] = token
+	r.originals[token] = value
+	return token
+}
+
+func (r *Redaction) replacePattern(text string, pattern *regexp.Regexp, kind string, accept func(string) bool) string {
+	return pattern.ReplaceAllStringFunc(text, func(match string) string {
+		if accept != nil && !accept(match) {
+			return match
+		}
+		return r.token(kind, match)
+	})
+}
+
+// replaceName replaces whole-word occurrences of name. Word boundaries are
+// checked by hand because \b in Go regular expressions only knows ASCII.
+func (r *Redaction) replaceName(text, name string) string {
+	var b strings.Builder
+	rest := text
+	for {
+		i := strings.Index(rest, name)
+		if i < 0 {
+			b.WriteString(rest)
+			return b.String()
+		}
+		end := i + len(name)
+		before, _ := utf8.DecodeLastRuneInString(rest[:i])
+		after, _ := utf8.DecodeRuneInString(rest[end:])
+		b.WriteString(rest[:i])
+		if isWordRune(before) || isWordRune(after) {
+			b.WriteString(name)
+		} else {
+			b.WriteString(r.token(PIIName, name))
+		}
+		rest = rest[end:]
+	}
+}
+
+func isWordRune(r rune) bool {
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_')
+}
+
+// isPhoneNumber rules out number runs that only look like phone numbers,
+// such as year ranges and salary bands.
+func isPhoneNumber(match string) bool {
+	digits := 0
+	for _, r := range match {
+		if unicode.IsDigit(r) {
+			digits++
+		}
+	}
+	if digits < minPhoneDigits || digits > maxPhoneDigits {
+		return false
+	}
+	if strings.Contains(match, " - ") {
+		return false
+	}
+
+	groups := strings.FieldsFunc(match, func(r rune) bool { return !unicode.IsDigit(r) })
+	allYears := true
+	for _, group := range groups {
+		if !yearPattern.MatchString(group) {
+			allYears = false
+			break
+		}
+	}
+	return !allYears
+}
+
+// mapStrings returns a deep copy of v with fn applied to every string it can
+// set. Unexported fields are copied as they are.
+func mapStrings(v any, fn func(string) string) any {
+	if v == nil {
+		return nil
+	}
+	return mapValue(reflect.ValueOf(v), fn).Interface()
+}
+
+func mapValue(v reflect.Value, fn func(string) string) reflect.Value {
+	switch v.Kind() {
+	case reflect.String:
+		out := reflect.New(v.Type()).Elem()
+		out.SetString(fn(v.String()))
+		return out
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type().Elem())
+		out.Elem().Set(mapValue(v.Elem(), fn))
+		return out
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(mapValue(v.Elem(), fn))
+		return out
+	case reflect.Struct:
+		out := reflect.New(v.Type()).Elem()
+		out.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if field := out.Field(i); field.CanSet() {
+				field.Set(mapValue(v.Field(i), fn))
+			}
+		}
+		return out
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(mapValue(v.Index(i), fn))
+		}
+		return out
+	case reflect.Array:
+		out := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(mapValue(v.Index(i), fn))
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), mapValue(iter.Value(), fn))
+		}
+		return out
+	default:
+		return v
+	}
+}
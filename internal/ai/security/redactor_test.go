@@ -0,0 +1,148 @@
+package security
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRedaction_Redact(t *testing.T) {
+	tests := []struct {
+		name     string
+		names    []string
+		input    string
+		expected string
+	}{
+		{
+			name:     "email",
+			input:    "Contact: jane.doe@example.com",
+			expected: "Contact: [EMAIL_1]",
+		},
+		{
+			name:     "phone number",
+			input:    "Phone: +44 20 7946 0958",
+			expected: "Phone: [PHONE_1]",
+		},
+		{
+			name:     "profile links",
+			input:    "https://www.linkedin.com/in/janedoe and github.com/janedoe",
+			expected: "[URL_1] and [URL_2]",
+		},
+		{
+			name:     "company links are kept",
+			input:    "Apply at https://careers.acme.com/jobs/42",
+			expected: "Apply at https://careers.acme.com/jobs/42",
+		},
+		{
+			name:     "full name and parts",
+			names:    []string{"Jane Doe"},
+			input:    "Name: Jane Doe\nJane led the team. Ms Doe mentored.",
+			expected: "Name: [NAME_1]\n[NAME_2] led the team. Ms [NAME_3] mentored.",
+		},
+		{
+			name:     "names only on word boundaries",
+			names:    []string{"Ann Lee"},
+			input:    "Annual planning with Ann",
+			expected: "Annual planning with [NAME_1]",
+		},
+		{
+			name:     "non-ascii names",
+			names:    []string{"José Müller"},
+			input:    "José Müller, Müllerstraße",
+			expected: "[NAME_1], Müllerstraße",
+		},
+		{
+			name:     "year ranges and short numbers are kept",
+			input:    "2019 - 2021, 2018-2020 2021, 5 years, 120000 - 150000",
+			expected: "2019 - 2021, 2018-2020 2021, 5 years, 120000 - 150000",
+		},
+		{
+			name:     "repeated values share a token",
+			input:    "a@b.io then a@b.io and c@d.io",
+			expected: "[EMAIL_1] then [EMAIL_1] and [EMAIL_2]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redaction := NewRedaction(tt.names...)
+			result := redaction.Redact(tt.input)
+
+			if result != tt.expected {
+				t.Errorf("Redact(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+			if restored := redaction.Restore(result); restored != tt.input {
+				t.Errorf("Restore(%q) = %q, want %q", result, restored, tt.input)
+			}
+		})
+	}
+}
+
+func TestRedaction_Restore(t *testing.T) {
+	redaction := NewRedaction("Jane Doe")
+	redaction.Redact("Jane Doe, jane@example.com")
+
+	t.Run("unknown tokens are left alone", func(t *testing.T) {
+		result := redaction.Restore("[NAME_1] <[EMAIL_1]> [PHONE_7]")
+		if result != "Jane Doe <jane@example.com> [PHONE_7]" {
+			t.Errorf("unexpected restore result: %q", result)
+		}
+	})
+
+	t.Run("json output is escaped", func(t *testing.T) {
+		quoted := NewRedaction(`Jane "JD" Doe`)
+		quoted.Redact(`Jane "JD" Doe`)
+
+		result := quoted.RestoreJSON(`{"name":"[NAME_1]"}`)
+		if result != `{"name":"Jane \"JD\" Doe"}` {
+			t.Errorf("unexpected json restore result: %q", result)
+		}
+	})
+}
+
+func TestRedaction_Values(t *testing.T) {
+	type contact struct {
+		Email string
+		Links []string
+		Notes map[string]string
+		note  string
+	}
+	type profile struct {
+		Name    string
+		Contact *contact
+		Years   int
+	}
+
+	original := profile{
+		Name: "Jane Doe",
+		Contact: &contact{
+			Email: "jane@example.com",
+			Links: []string{"linkedin.com/in/janedoe"},
+			Notes: map[string]string{"phone": "+1 (555) 010-9999"},
+			note:  "jane@example.com",
+		},
+		Years: 7,
+	}
+
+	redaction := NewRedaction("Jane Doe")
+	redacted := redaction.RedactValue(original).(profile)
+
+	if redacted.Name != "[NAME_1]" || redacted.Contact.Email != "[EMAIL_1]" ||
+		redacted.Contact.Links[0] != "[URL_1]" || redacted.Contact.Notes["phone"] != "[PHONE_1]" {
+		t.Errorf("unexpected redacted value: %+v %+v", redacted, redacted.Contact)
+	}
+	if redacted.Contact.note != "jane@example.com" || redacted.Years != 7 {
+		t.Errorf("unexported and non-string fields should be copied as they are: %+v", redacted.Contact)
+	}
+	if original.Contact.Email != "jane@example.com" || original.Contact.Links[0] != "linkedin.com/in/janedoe" {
+		t.Errorf("original value was modified: %+v", original.Contact)
+	}
+
+	restored := redaction.RestoreValue(&redacted).(*profile)
+	if !reflect.DeepEqual(*restored, original) {
+		t.Errorf("RestoreValue() = %+v, want %+v", restored, original)
+	}
+	if !strings.HasPrefix(redacted.Name, "[NAME") {
+		t.Errorf("restoring should not modify the redacted value, got %q", redacted.Name)
+	}
+}
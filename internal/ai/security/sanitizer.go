@@ -1,63 +1,69 @@
 package security
 
 import (
-	"regexp"
 	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/benidevo/vega/internal/common/logger"
 )
 
-// PromptSanitizer provides methods to sanitize user input before using in LLM prompts
+// MaxPromptTextLength is the longest text, in bytes, a single field of a
+// prompt may hold. Longer text is cut and marked as truncated.
+const MaxPromptTextLength = 10000
+
+// truncationMarker is appended to text cut at MaxPromptTextLength
+const truncationMarker = "... [TRUNCATED]"
+
+// PromptSanitizer prepares user input for use in LLM prompts. It keeps the
+// text as written, apart from control characters and excess length, and logs
+// likely prompt injections so they can be reviewed rather than silently
+// rewritten.
 type PromptSanitizer struct {
-	// Patterns that could be used for prompt injection
-	injectionPatterns []*regexp.Regexp
+	detector *InjectionDetector
+	log      *logger.PrivacyLogger
 }
 
 // NewPromptSanitizer creates a new instance of PromptSanitizer
 func NewPromptSanitizer() *PromptSanitizer {
-	patterns := []*regexp.Regexp{
-		// System instruction attempts
-		regexp.MustCompile(`(?i)system\s*[:：]\s*`),
-		regexp.MustCompile(`(?i)assistant\s*[:：]\s*`),
-		regexp.MustCompile(`(?i)human\s*[:：]\s*`),
-
-		// Role manipulation attempts
-		regexp.MustCompile(`(?i)you\s+are\s+now\s+`),
-		regexp.MustCompile(`(?i)ignore\s+(previous|all)\s+(instructions?|prompts?)`),
-		regexp.MustCompile(`(?i)forget\s+(everything|all)\s+`),
-
-		// Instruction override attempts
-		regexp.MustCompile(`(?i)new\s+instructions?\s*[:：]`),
-		regexp.MustCompile(`(?i)updated?\s+instructions?\s*[:：]`),
-		regexp.MustCompile(`(?i)instead\s+of\s+`),
-
-		// JSON/Code injection attempts
-		regexp.MustCompile(`[{}]\s*"[^"]*"\s*[:：]\s*[{}]`),
-		regexp.MustCompile(`(?i)return\s+(json|code|script)`),
-	}
-
 	return &PromptSanitizer{
-		injectionPatterns: patterns,
+		detector: NewInjectionDetector(),
+		log:      logger.GetPrivacyLogger("prompt_sanitizer"),
 	}
 }
 
-// SanitizeText removes potential prompt injection patterns from user input
+// SanitizeText removes control characters from user input and truncates it
+// at MaxPromptTextLength. Line breaks, indentation and the wording are kept;
+// injection attempts are detected and logged but not changed.
 func (s *PromptSanitizer) SanitizeText(input string) string {
 	if input == "" {
 		return input
 	}
 
-	sanitized := input
+	sanitized := strings.ReplaceAll(input, "\r\n", "\n")
+	sanitized = strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' || !unicode.IsControl(r) {
+			return r
+		}
+		return -1
+	}, sanitized)
+	sanitized = strings.TrimSpace(sanitized)
 
-	for _, pattern := range s.injectionPatterns {
-		sanitized = pattern.ReplaceAllString(sanitized, "[FILTERED]")
+	if detection := s.detector.Detect(sanitized); detection.Exceeds(DefaultInjectionThreshold) {
+		s.log.Warn().
+			Float64("score", detection.Score).
+			Int("spans", len(detection.Spans)).
+			Msg("Prompt text looks like a prompt injection")
 	}
 
-	if len(sanitized) > 10000 {
-		sanitized = sanitized[:10000] + "... [TRUNCATED]"
+	if len(sanitized) > MaxPromptTextLength {
+		s.log.Warn().
+			Int("length", len(sanitized)).
+			Int("limit", MaxPromptTextLength).
+			Msg("Truncating prompt text")
+		sanitized = truncateUTF8(sanitized, MaxPromptTextLength) + truncationMarker
 	}
 
-	sanitized = regexp.MustCompile(`\s+`).ReplaceAllString(sanitized, " ")
-	sanitized = strings.TrimSpace(sanitized)
-
 	return sanitized
 }
 
@@ -80,3 +86,14 @@ func (s *PromptSanitizer) SanitizeInstructions(instructions string) string {
 func (s *PromptSanitizer) SanitizeExtraContext(context string) string {
 	return s.SanitizeText(context)
 }
+
+// truncateUTF8 cuts text to at most limit bytes without splitting a rune
+func truncateUTF8(text string, limit int) string {
+	if len(text) <= limit {
+		return text
+	}
+	for limit > 0 && !utf8.RuneStart(text[limit]) {
+		limit--
+	}
+	return text[:limit]
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/benidevo/vega/internal/ai/helpers"
 	"github.com/benidevo/vega/internal/ai/llm"
 	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/benidevo/vega/internal/ai/prompts"
 	"github.com/benidevo/vega/internal/ai/validation"
 	"github.com/benidevo/vega/internal/common/logger"
 )
@@ -19,6 +20,7 @@ type CVGeneratorService struct {
 	log       *logger.PrivacyLogger
 	validator *validation.AIRequestValidator
 	helper    *helpers.ServiceHelper
+	prompts   PromptSelector
 }
 
 // NewCVGeneratorService creates and returns a new instance of CVGeneratorService
@@ -33,8 +35,24 @@ func NewCVGeneratorService(model llm.Provider) *CVGeneratorService {
 	}
 }
 
+// SetPromptSelector chooses the prompt template version for each request.
+func (c *CVGeneratorService) SetPromptSelector(selector PromptSelector) {
+	c.prompts = selector
+}
+
 // GenerateCV generates a CV based on the provided request.
 func (c *CVGeneratorService) GenerateCV(ctx context.Context, req models.Request, jobID int, jobTitle string) (*models.GeneratedCV, error) {
+	return c.generate(ctx, req, jobID, jobTitle, nil)
+}
+
+// GenerateCVStream generates a CV like GenerateCV, passing the raw model output
+// to onDelta as it is produced. When the provider cannot stream, onDelta is not
+// called and only the final result is returned.
+func (c *CVGeneratorService) GenerateCVStream(ctx context.Context, req models.Request, jobID int, jobTitle string, onDelta func(text string) error) (*models.GeneratedCV, error) {
+	return c.generate(ctx, req, jobID, jobTitle, onDelta)
+}
+
+func (c *CVGeneratorService) generate(ctx context.Context, req models.Request, jobID int, jobTitle string, onChunk llm.StreamHandler) (*models.GeneratedCV, error) {
 	start := time.Now()
 
 	c.helper.LogOperationStart("cv_generation", req.ApplicantName)
@@ -49,14 +67,23 @@ func (c *CVGeneratorService) GenerateCV(ctx context.Context, req models.Request,
 		req,
 		true,
 	)
+	prompt.UsePromptVersion(selectPromptVersion(ctx, c.prompts, prompts.PromptCVGeneration))
 
 	optimalTemp := prompt.GetOptimalTemperature(string(models.TaskTypeCVGeneration))
 	prompt.SetTemperature(optimalTemp)
 
-	response, err := c.model.Generate(ctx, llm.GenerateRequest{
+	request := llm.GenerateRequest{
 		Prompt:       *prompt,
 		ResponseType: llm.ResponseTypeCV,
-	})
+	}
+
+	var response llm.GenerateResponse
+	var err error
+	if onChunk == nil {
+		response, err = c.model.Generate(ctx, request)
+	} else {
+		response, err = llm.GenerateStream(ctx, c.model, request, onChunk)
+	}
 	if err != nil {
 		return nil, c.helper.LogOperationError("cv_generation", req.ApplicantName, constants.ErrorTypeAIGenerationFailed, time.Since(start), err)
 	}
@@ -89,7 +116,9 @@ func (c *CVGeneratorService) GenerateCV(ctx context.Context, req models.Request,
 		GeneratedAt:     time.Now().Unix(),
 		JobID:           jobID,
 		JobTitle:        jobTitle,
+		PromptVersion:   prompt.PromptVersion(prompts.PromptCVGeneration),
 	}
+	generatedCV.Language = req.Language
 
 	metadata := c.helper.CreateOperationMetadata(optimalTemp, prompt.UseEnhancedTemplates, map[string]interface{}{
 		"job_id":                jobID,
@@ -97,6 +126,8 @@ func (c *CVGeneratorService) GenerateCV(ctx context.Context, req models.Request,
 		"work_experience_count": len(result.WorkExperience),
 		"education_count":       len(result.Education),
 		"skills_count":          len(result.Skills),
+		"prompt_version":        generatedCV.PromptVersion,
+		"language":              req.Language,
 	})
 
 	c.helper.LogOperationSuccess("cv_generation", req.ApplicantName, time.Since(start), prompt.UseEnhancedTemplates, metadata)
@@ -42,7 +42,9 @@ func (c *CVParserService) ParseCV(ctx context.Context, cvText string) (*models.C
 			models.WrapError(models.ErrValidationFailed, fmt.Errorf("CV text cannot be empty")))
 	}
 
+	language := helpers.DetectLanguage(cvText)
 	prompt := models.NewCVParsingPrompt(cvText)
+	prompt.Language = language
 
 	request := llm.GenerateRequest{
 		Prompt:       *prompt,
@@ -59,11 +61,13 @@ func (c *CVParserService) ParseCV(ctx context.Context, cvText string) (*models.C
 		parseErr := fmt.Errorf("unexpected response type: %T", response.Data)
 		return nil, c.helper.LogOperationError("cv_parsing", "anonymous", constants.ErrorTypeResponseParseFailed, time.Since(start), parseErr)
 	}
+	result.Language = language
 
 	metadata := c.helper.CreateOperationMetadata(0.1, false, map[string]interface{}{
 		"method":    "gemini_cv_parsing",
 		"model":     response.Metadata["model"],
 		"task_type": response.Metadata["task_type"],
+		"language":  language,
 	})
 
 	c.helper.LogOperationSuccess("cv_parsing", "anonymous", time.Since(start), false, metadata)
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/benidevo/vega/internal/ai/constants"
+	"github.com/benidevo/vega/internal/ai/helpers"
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/benidevo/vega/internal/ai/prompts"
+	"github.com/benidevo/vega/internal/ai/validation"
+	"github.com/benidevo/vega/internal/common/logger"
+)
+
+// EmailService generates application emails using a specified LLM provider.
+type EmailService struct {
+	model     llm.Provider
+	log       *logger.PrivacyLogger
+	validator *validation.AIRequestValidator
+	helper    *helpers.ServiceHelper
+}
+
+// NewEmailService creates and returns a new instance of EmailService
+// using the provided llm.Provider as the underlying model.
+func NewEmailService(model llm.Provider) *EmailService {
+	log := logger.GetPrivacyLogger("ai_email")
+	return &EmailService{
+		model:     model,
+		log:       log,
+		validator: validation.NewAIRequestValidator(),
+		helper:    helpers.NewServiceHelper(log),
+	}
+}
+
+// GenerateEmail writes the follow-up, thank-you or negotiation email described
+// by req.Email. A missing tone defaults to professional.
+func (s *EmailService) GenerateEmail(ctx context.Context, req models.Request) (*models.Email, error) {
+	start := time.Now()
+
+	s.helper.LogOperationStart(constants.OperationEmail, req.ApplicantName)
+
+	if err := s.validateEmailRequest(&req); err != nil {
+		return nil, s.helper.LogValidationError(constants.OperationEmail, req.ApplicantName, err)
+	}
+
+	prompt := models.NewPrompt(
+		"You are a professional career advisor who writes short, personal emails for job seekers.",
+		req,
+		true,
+	)
+
+	response, err := s.model.Generate(ctx, llm.GenerateRequest{
+		Prompt:       *prompt,
+		ResponseType: llm.ResponseTypeEmail,
+	})
+	if err != nil {
+		return nil, s.helper.LogOperationError(constants.OperationEmail, req.ApplicantName, constants.ErrorTypeAIGenerationFailed, time.Since(start), err)
+	}
+
+	result, ok := response.Data.(models.Email)
+	if !ok {
+		err := fmt.Errorf("unexpected response type: expected Email, got %T", response.Data)
+		return nil, s.helper.LogOperationError(constants.OperationEmail, req.ApplicantName, constants.ErrorTypeResponseParseFailed, time.Since(start), err)
+	}
+
+	// The email templates are not versioned yet
+	result.PromptVersion = prompts.BuiltinVersion
+
+	metadata := s.helper.CreateOperationMetadata(prompt.GetOptimalTemperature(models.TaskTypeEmail.String()), prompt.UseEnhancedTemplates, map[string]interface{}{
+		"email_kind":       string(req.Email.Kind),
+		"email_tone":       string(req.Email.Tone),
+		"has_cover_letter": req.Email.CoverLetter != "",
+		"body_length":      len(result.Body),
+	})
+
+	s.helper.LogOperationSuccess(constants.OperationEmail, req.ApplicantName, time.Since(start), prompt.UseEnhancedTemplates, metadata)
+
+	return &result, nil
+}
+
+// validateEmailRequest validates the request and its email details.
+func (s *EmailService) validateEmailRequest(req *models.Request) error {
+	if err := s.validator.ValidateRequest(*req); err != nil {
+		return err
+	}
+
+	if req.Email == nil {
+		return models.WrapError(models.ErrValidationFailed, fmt.Errorf("email details are required"))
+	}
+	if !req.Email.Kind.IsValid() {
+		return models.WrapError(models.ErrValidationFailed, fmt.Errorf("unknown email kind '%s'", req.Email.Kind))
+	}
+
+	email := *req.Email
+	if email.Tone == "" {
+		email.Tone = models.EmailToneProfessional
+	}
+	if !email.Tone.IsValid() {
+		return models.WrapError(models.ErrValidationFailed, fmt.Errorf("unknown email tone '%s'", email.Tone))
+	}
+	req.Email = &email
+
+	return nil
+}
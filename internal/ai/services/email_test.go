@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/benidevo/vega/internal/ai/prompts"
+	"github.com/benidevo/vega/internal/ai/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestEmailRequest(kind models.EmailKind, tone models.EmailTone) models.Request {
+	req := createTestRequest()
+	req.Email = &models.EmailDetails{
+		Kind:        kind,
+		Tone:        tone,
+		CompanyName: "Tech Corp",
+		JobTitle:    "Software Engineer",
+		JobStatus:   "Applied",
+	}
+	return req
+}
+
+func TestEmailService_GenerateEmail(t *testing.T) {
+	testData := testutil.NewTestData()
+
+	t.Run("should_generate_email_when_request_valid", func(t *testing.T) {
+		provider := &testutil.MockProvider{}
+		provider.SetupEmailMock(testData.ValidEmail(), nil)
+
+		result, err := NewEmailService(provider).GenerateEmail(context.Background(), createTestEmailRequest(models.EmailKindFollowUp, models.EmailToneFriendly))
+
+		require.NoError(t, err)
+		assert.Equal(t, testData.ValidEmail().Subject, result.Subject)
+		assert.Equal(t, prompts.BuiltinVersion, result.PromptVersion)
+		provider.AssertExpectations(t)
+	})
+
+	t.Run("should_default_to_professional_tone", func(t *testing.T) {
+		provider := &testutil.MockProvider{}
+		provider.On("Generate", mock.Anything, mock.MatchedBy(func(req llm.GenerateRequest) bool {
+			return req.Prompt.Email != nil && req.Prompt.Email.Tone == models.EmailToneProfessional
+		})).Return(llm.GenerateResponse{Data: testData.ValidEmail()}, nil)
+
+		_, err := NewEmailService(provider).GenerateEmail(context.Background(), createTestEmailRequest(models.EmailKindThankYou, ""))
+
+		require.NoError(t, err)
+		provider.AssertExpectations(t)
+	})
+
+	t.Run("should_return_error_when_kind_unknown", func(t *testing.T) {
+		provider := &testutil.MockProvider{}
+
+		_, err := NewEmailService(provider).GenerateEmail(context.Background(), createTestEmailRequest("cold_outreach", models.EmailToneFormal))
+
+		assert.ErrorIs(t, err, models.ErrValidationFailed)
+		provider.AssertNotCalled(t, "Generate", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should_return_error_when_tone_unknown", func(t *testing.T) {
+		provider := &testutil.MockProvider{}
+
+		_, err := NewEmailService(provider).GenerateEmail(context.Background(), createTestEmailRequest(models.EmailKindNegotiation, "sarcastic"))
+
+		assert.ErrorIs(t, err, models.ErrValidationFailed)
+		provider.AssertNotCalled(t, "Generate", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should_return_error_when_email_details_missing", func(t *testing.T) {
+		provider := &testutil.MockProvider{}
+
+		_, err := NewEmailService(provider).GenerateEmail(context.Background(), createTestRequest())
+
+		assert.ErrorIs(t, err, models.ErrValidationFailed)
+	})
+
+	t.Run("should_return_error_when_provider_fails", func(t *testing.T) {
+		provider := &testutil.MockProvider{}
+		provider.SetupEmailMock(models.Email{}, fmt.Errorf("provider down"))
+
+		_, err := NewEmailService(provider).GenerateEmail(context.Background(), createTestEmailRequest(models.EmailKindFollowUp, models.EmailToneProfessional))
+
+		require.Error(t, err)
+	})
+
+	t.Run("should_return_error_when_response_has_wrong_type", func(t *testing.T) {
+		provider := &testutil.MockProvider{}
+		provider.SetupGenericMock(llm.GenerateResponse{Data: models.CoverLetter{Content: "hi"}}, nil)
+
+		_, err := NewEmailService(provider).GenerateEmail(context.Background(), createTestEmailRequest(models.EmailKindFollowUp, models.EmailToneProfessional))
+
+		require.Error(t, err)
+	})
+}
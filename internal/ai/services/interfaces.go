@@ -5,8 +5,15 @@ import (
 
 	"github.com/benidevo/vega/internal/ai/llm"
 	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/benidevo/vega/internal/ai/prompts"
 )
 
+// PromptSelector chooses the template version of the named prompt for the
+// user in ctx. A nil version means the built-in template.
+type PromptSelector interface {
+	Select(ctx context.Context, name string) *prompts.Version
+}
+
 // CVGenerator defines the interface for CV generation
 type CVGenerator interface {
 	Generate(ctx context.Context, request llm.GenerateRequest) (llm.GenerateResponse, error)
@@ -30,6 +37,7 @@ type LetterGenerator interface {
 // CVGeneratorServiceInterface defines the public interface of CVGeneratorService
 type CVGeneratorServiceInterface interface {
 	GenerateCV(ctx context.Context, req models.Request, jobID int, jobTitle string) (*models.GeneratedCV, error)
+	GenerateCVStream(ctx context.Context, req models.Request, jobID int, jobTitle string, onDelta func(text string) error) (*models.GeneratedCV, error)
 }
 
 // CVParserServiceInterface defines the public interface of CVParserService
@@ -37,6 +45,11 @@ type CVParserServiceInterface interface {
 	ParseCV(ctx context.Context, cvContent string) (*models.CVParsingResult, error)
 }
 
+// JobExtractorServiceInterface defines the public interface of JobExtractorService
+type JobExtractorServiceInterface interface {
+	ExtractJob(ctx context.Context, posting string) (*models.JobExtraction, error)
+}
+
 // JobMatcherServiceInterface defines the public interface of JobMatcherService
 type JobMatcherServiceInterface interface {
 	AnalyzeMatch(ctx context.Context, req models.Request) (*models.MatchResult, error)
@@ -46,4 +59,34 @@ type JobMatcherServiceInterface interface {
 // LetterGeneratorServiceInterface defines the public interface of LetterGeneratorService
 type LetterGeneratorServiceInterface interface {
 	GenerateCoverLetter(ctx context.Context, req models.Request) (*models.CoverLetter, error)
+	GenerateCoverLetterStream(ctx context.Context, req models.Request, onDelta func(text string) error) (*models.CoverLetter, error)
+}
+
+// InterviewPrepServiceInterface defines the public interface of InterviewPrepService
+type InterviewPrepServiceInterface interface {
+	GenerateInterviewPrep(ctx context.Context, req models.Request) (*models.InterviewPrep, error)
+}
+
+// EmailServiceInterface defines the public interface of EmailService
+type EmailServiceInterface interface {
+	GenerateEmail(ctx context.Context, req models.Request) (*models.Email, error)
+}
+
+// LearningPlanServiceInterface defines the public interface of LearningPlanService
+type LearningPlanServiceInterface interface {
+	GenerateLearningPlan(ctx context.Context, req models.Request) (*models.LearningPlan, error)
+}
+
+// SectionRewriterServiceInterface defines the public interface of SectionRewriterService
+type SectionRewriterServiceInterface interface {
+	RewriteSection(ctx context.Context, req models.Request) (*models.SectionRewrite, error)
+}
+
+// selectPromptVersion returns the version of the named prompt chosen by
+// selector, or nil when no selector is configured.
+func selectPromptVersion(ctx context.Context, selector PromptSelector, name string) *prompts.Version {
+	if selector == nil {
+		return nil
+	}
+	return selector.Select(ctx, name)
 }
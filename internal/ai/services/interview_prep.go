@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/benidevo/vega/internal/ai/constants"
+	"github.com/benidevo/vega/internal/ai/helpers"
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/benidevo/vega/internal/ai/prompts"
+	"github.com/benidevo/vega/internal/ai/validation"
+	"github.com/benidevo/vega/internal/common/logger"
+)
+
+// InterviewPrepService generates interview preparation packs using a specified LLM provider.
+type InterviewPrepService struct {
+	model     llm.Provider
+	log       *logger.PrivacyLogger
+	validator *validation.AIRequestValidator
+	helper    *helpers.ServiceHelper
+}
+
+// NewInterviewPrepService creates and returns a new instance of InterviewPrepService
+// using the provided llm.Provider as the underlying model.
+func NewInterviewPrepService(model llm.Provider) *InterviewPrepService {
+	log := logger.GetPrivacyLogger("ai_interview_prep")
+	return &InterviewPrepService{
+		model:     model,
+		log:       log,
+		validator: validation.NewAIRequestValidator(),
+		helper:    helpers.NewServiceHelper(log),
+	}
+}
+
+// GenerateInterviewPrep generates likely interview questions with suggested
+// STAR answers grounded in the request's work experience, questions to ask
+// the interviewers and gaps to rehearse.
+func (s *InterviewPrepService) GenerateInterviewPrep(ctx context.Context, req models.Request) (*models.InterviewPrep, error) {
+	start := time.Now()
+
+	s.helper.LogOperationStart(constants.OperationInterviewPrep, req.ApplicantName)
+
+	if err := s.validator.ValidateRequest(req); err != nil {
+		return nil, s.helper.LogValidationError(constants.OperationInterviewPrep, req.ApplicantName, err)
+	}
+
+	prompt := models.NewPrompt(
+		"You are a professional career advisor and experienced interview coach.",
+		req,
+		true,
+	)
+
+	response, err := s.model.Generate(ctx, llm.GenerateRequest{
+		Prompt:       *prompt,
+		ResponseType: llm.ResponseTypeInterviewPrep,
+	})
+	if err != nil {
+		return nil, s.helper.LogOperationError(constants.OperationInterviewPrep, req.ApplicantName, constants.ErrorTypeAIGenerationFailed, time.Since(start), err)
+	}
+
+	result, ok := response.Data.(models.InterviewPrep)
+	if !ok {
+		err := fmt.Errorf("unexpected response type: expected InterviewPrep, got %T", response.Data)
+		return nil, s.helper.LogOperationError(constants.OperationInterviewPrep, req.ApplicantName, constants.ErrorTypeResponseParseFailed, time.Since(start), err)
+	}
+
+	if err := s.validateInterviewPrep(&result); err != nil {
+		return nil, s.helper.LogOperationError(constants.OperationInterviewPrep, req.ApplicantName, constants.ErrorTypeValidationFailed, time.Since(start), err)
+	}
+
+	// The interview prep template is not versioned yet
+	result.PromptVersion = prompts.BuiltinVersion
+
+	metadata := s.helper.CreateOperationMetadata(prompt.GetOptimalTemperature(models.TaskTypeInterviewPrep.String()), prompt.UseEnhancedTemplates, map[string]interface{}{
+		"question_count":        len(result.Questions),
+		"questions_to_ask":      len(result.QuestionsToAsk),
+		"gaps_to_rehearse":      len(result.GapsToRehearse),
+		"work_experience_count": len(req.WorkExperience),
+	})
+
+	s.helper.LogOperationSuccess(constants.OperationInterviewPrep, req.ApplicantName, time.Since(start), prompt.UseEnhancedTemplates, metadata)
+
+	return &result, nil
+}
+
+// validateInterviewPrep drops questions without a question or answer and
+// rejects packs with none left.
+func (s *InterviewPrepService) validateInterviewPrep(prep *models.InterviewPrep) error {
+	questions := prep.Questions[:0]
+	for _, q := range prep.Questions {
+		if q.Question == "" || (q.Answer == models.STARAnswer{}) {
+			continue
+		}
+		questions = append(questions, q)
+	}
+	prep.Questions = questions
+
+	if len(prep.Questions) == 0 {
+		return models.WrapError(models.ErrValidationFailed, fmt.Errorf("generated interview prep has no answered questions"))
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/benidevo/vega/internal/ai/prompts"
+	"github.com/benidevo/vega/internal/ai/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterviewPrepService_GenerateInterviewPrep(t *testing.T) {
+	testData := testutil.NewTestData()
+
+	t.Run("should_generate_interview_prep_when_request_valid", func(t *testing.T) {
+		provider := &testutil.MockProvider{}
+		provider.SetupInterviewPrepMock(testData.ValidInterviewPrep(), nil)
+
+		result, err := NewInterviewPrepService(provider).GenerateInterviewPrep(context.Background(), createTestRequest())
+
+		require.NoError(t, err)
+		require.Len(t, result.Questions, 1)
+		assert.Equal(t, "Senior Frontend Developer at WebTech Solutions", result.Questions[0].Experience)
+		assert.Equal(t, prompts.BuiltinVersion, result.PromptVersion)
+		provider.AssertExpectations(t)
+	})
+
+	t.Run("should_drop_questions_without_answers", func(t *testing.T) {
+		prep := testData.ValidInterviewPrep()
+		prep.Questions = append(prep.Questions, models.InterviewQuestion{Question: "Why us?"})
+
+		provider := &testutil.MockProvider{}
+		provider.SetupInterviewPrepMock(prep, nil)
+
+		result, err := NewInterviewPrepService(provider).GenerateInterviewPrep(context.Background(), createTestRequest())
+
+		require.NoError(t, err)
+		assert.Len(t, result.Questions, 1)
+	})
+
+	t.Run("should_return_error_when_no_question_is_answered", func(t *testing.T) {
+		provider := &testutil.MockProvider{}
+		provider.SetupInterviewPrepMock(models.InterviewPrep{
+			Questions: []models.InterviewQuestion{{Question: "Why us?"}},
+		}, nil)
+
+		_, err := NewInterviewPrepService(provider).GenerateInterviewPrep(context.Background(), createTestRequest())
+
+		assert.ErrorIs(t, err, models.ErrValidationFailed)
+	})
+
+	t.Run("should_return_error_when_request_invalid", func(t *testing.T) {
+		provider := &testutil.MockProvider{}
+
+		_, err := NewInterviewPrepService(provider).GenerateInterviewPrep(context.Background(), models.Request{ApplicantName: "John Doe"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "validation failed")
+		provider.AssertNotCalled(t, "Generate", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should_return_error_when_provider_fails", func(t *testing.T) {
+		provider := &testutil.MockProvider{}
+		provider.SetupInterviewPrepMock(models.InterviewPrep{}, fmt.Errorf("provider down"))
+
+		_, err := NewInterviewPrepService(provider).GenerateInterviewPrep(context.Background(), createTestRequest())
+
+		require.Error(t, err)
+	})
+
+	t.Run("should_return_error_when_response_has_wrong_type", func(t *testing.T) {
+		provider := &testutil.MockProvider{}
+		provider.SetupGenericMock(llm.GenerateResponse{Data: models.CoverLetter{Content: "hi"}}, nil)
+
+		_, err := NewInterviewPrepService(provider).GenerateInterviewPrep(context.Background(), createTestRequest())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unexpected response type")
+	})
+}
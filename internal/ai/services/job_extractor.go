@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/benidevo/vega/internal/ai/constants"
+	"github.com/benidevo/vega/internal/ai/helpers"
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/benidevo/vega/internal/common/logger"
+)
+
+// JobExtractorService extracts structured job details from pasted job
+// postings using a language model provider.
+type JobExtractorService struct {
+	model  llm.Provider
+	log    *logger.PrivacyLogger
+	helper *helpers.ServiceHelper
+}
+
+// NewJobExtractorService creates and returns a new instance of JobExtractorService
+// using the provided llm.Provider as the model.
+func NewJobExtractorService(model llm.Provider) *JobExtractorService {
+	log := logger.GetPrivacyLogger("ai_job_extractor")
+	return &JobExtractorService{
+		model:  model,
+		log:    log,
+		helper: helpers.NewServiceHelper(log),
+	}
+}
+
+// ExtractJob extracts a job draft from the text or HTML of a job posting.
+// Text that is not a job posting is rejected with models.ErrNotJobPosting.
+func (s *JobExtractorService) ExtractJob(ctx context.Context, posting string) (*models.JobExtraction, error) {
+	start := time.Now()
+
+	s.helper.LogOperationStart(constants.OperationJobExtraction, "anonymous")
+
+	postingText := helpers.PostingText(posting)
+	if postingText == "" {
+		return nil, s.helper.LogValidationError(constants.OperationJobExtraction, "anonymous",
+			models.WrapError(models.ErrValidationFailed, fmt.Errorf("job posting cannot be empty")))
+	}
+
+	request := llm.GenerateRequest{
+		Prompt:       *models.NewJobExtractionPrompt(postingText),
+		ResponseType: llm.ResponseTypeJobExtraction,
+	}
+
+	response, err := s.model.Generate(ctx, request)
+	if err != nil {
+		return nil, s.helper.LogOperationError(constants.OperationJobExtraction, "anonymous", constants.ErrorTypeAIAnalysisFailed, time.Since(start), err)
+	}
+
+	result, ok := response.Data.(models.JobExtraction)
+	if !ok {
+		parseErr := fmt.Errorf("unexpected response type: expected JobExtraction, got %T", response.Data)
+		return nil, s.helper.LogOperationError(constants.OperationJobExtraction, "anonymous", constants.ErrorTypeResponseParseFailed, time.Since(start), parseErr)
+	}
+
+	metadata := s.helper.CreateOperationMetadata(0.1, false, map[string]interface{}{
+		"model":     response.Metadata["model"],
+		"task_type": response.Metadata["task_type"],
+	})
+
+	s.helper.LogOperationSuccess(constants.OperationJobExtraction, "anonymous", time.Since(start), false, metadata)
+
+	return &result, nil
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/benidevo/vega/internal/ai/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobExtractorService_ExtractJob(t *testing.T) {
+	testData := testutil.NewTestData()
+
+	t.Run("should_extract_job_when_posting_valid", func(t *testing.T) {
+		provider := &testutil.MockProvider{}
+		provider.SetupJobExtractionMock(testData.ValidJobExtraction(), nil)
+
+		result, err := NewJobExtractorService(provider).ExtractJob(context.Background(), "Frontend Engineer at WebTech\nReact, TypeScript")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Frontend Engineer", result.Title)
+		assert.Equal(t, 0.9, result.Confidence[models.JobFieldSalary])
+		provider.AssertExpectations(t)
+	})
+
+	t.Run("should_send_page_text_when_posting_is_html", func(t *testing.T) {
+		provider := &testutil.MockProvider{}
+		provider.On("Generate", mock.Anything, mock.MatchedBy(func(req llm.GenerateRequest) bool {
+			return req.Prompt.PostingText == "Frontend Engineer\n\nWebTech"
+		})).Return(llm.GenerateResponse{Data: testData.ValidJobExtraction()}, nil)
+
+		_, err := NewJobExtractorService(provider).ExtractJob(context.Background(), "<html><body><h1>Frontend Engineer</h1><p>WebTech</p><script>x()</script></body></html>")
+
+		require.NoError(t, err)
+		provider.AssertExpectations(t)
+	})
+
+	t.Run("should_return_error_when_posting_empty", func(t *testing.T) {
+		provider := &testutil.MockProvider{}
+
+		_, err := NewJobExtractorService(provider).ExtractJob(context.Background(), "<div> </div>")
+
+		assert.ErrorIs(t, err, models.ErrValidationFailed)
+		provider.AssertNotCalled(t, "Generate", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should_return_error_when_not_a_job_posting", func(t *testing.T) {
+		provider := &testutil.MockProvider{}
+		provider.SetupJobExtractionMock(models.JobExtraction{}, models.WrapError(models.ErrNotJobPosting, fmt.Errorf("recipe")))
+
+		_, err := NewJobExtractorService(provider).ExtractJob(context.Background(), "Mix flour and water")
+
+		assert.ErrorIs(t, err, models.ErrNotJobPosting)
+	})
+
+	t.Run("should_return_error_when_response_has_wrong_type", func(t *testing.T) {
+		provider := &testutil.MockProvider{}
+		provider.On("Generate", mock.Anything, mock.Anything).Return(llm.GenerateResponse{Data: "not a job"}, nil)
+
+		_, err := NewJobExtractorService(provider).ExtractJob(context.Background(), "Frontend Engineer")
+
+		require.Error(t, err)
+	})
+}
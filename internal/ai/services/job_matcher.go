@@ -9,6 +9,7 @@ import (
 	"github.com/benidevo/vega/internal/ai/helpers"
 	"github.com/benidevo/vega/internal/ai/llm"
 	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/benidevo/vega/internal/ai/prompts"
 	"github.com/benidevo/vega/internal/ai/validation"
 	"github.com/benidevo/vega/internal/common/logger"
 )
@@ -20,6 +21,7 @@ type JobMatcherService struct {
 	log       *logger.PrivacyLogger
 	validator *validation.AIRequestValidator
 	helper    *helpers.ServiceHelper
+	prompts   PromptSelector
 }
 
 // NewJobMatcherService creates and returns a new instance of JobMatcherService
@@ -35,6 +37,11 @@ func NewJobMatcherService(model llm.Provider) *JobMatcherService {
 	}
 }
 
+// SetPromptSelector chooses the prompt template version for each request.
+func (j *JobMatcherService) SetPromptSelector(selector PromptSelector) {
+	j.prompts = selector
+}
+
 // AnalyzeMatch analyzes the match between a job applicant and a job.
 func (j *JobMatcherService) AnalyzeMatch(ctx context.Context, req models.Request) (*models.MatchResult, error) {
 	start := time.Now()
@@ -50,6 +57,7 @@ func (j *JobMatcherService) AnalyzeMatch(ctx context.Context, req models.Request
 		req,
 		true,
 	)
+	prompt.UsePromptVersion(selectPromptVersion(ctx, j.prompts, prompts.PromptJobMatch))
 
 	response, err := j.model.Generate(ctx, llm.GenerateRequest{
 		Prompt:       *prompt,
@@ -66,11 +74,13 @@ func (j *JobMatcherService) AnalyzeMatch(ctx context.Context, req models.Request
 	}
 
 	j.validateMatchResult(&result)
+	result.PromptVersion = prompt.PromptVersion(prompts.PromptJobMatch)
 
 	metadata := j.helper.CreateOperationMetadata(prompt.GetOptimalTemperature(models.TaskTypeJobAnalysis.String()), prompt.UseEnhancedTemplates, map[string]interface{}{
 		"match_score":      result.MatchScore,
 		"strengths_count":  len(result.Strengths),
 		"weaknesses_count": len(result.Weaknesses),
+		"prompt_version":   result.PromptVersion,
 	})
 
 	j.helper.LogOperationSuccess(constants.OperationMatchAnalysis, req.ApplicantName, time.Since(start), prompt.UseEnhancedTemplates, metadata)
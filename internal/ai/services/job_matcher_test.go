@@ -7,6 +7,7 @@ import (
 
 	"github.com/benidevo/vega/internal/ai/llm"
 	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/benidevo/vega/internal/ai/prompts"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -76,11 +77,12 @@ func TestJobMatcherService_AnalyzeMatch(t *testing.T) {
 				})).Return(response, nil)
 			},
 			expectedResult: &models.MatchResult{
-				MatchScore: 85,
-				Strengths:  []string{"Strong Go skills", "Relevant experience"},
-				Weaknesses: []string{"Limited Docker experience"},
-				Highlights: []string{"Perfect cultural fit"},
-				Feedback:   "Great candidate for this role",
+				MatchScore:    85,
+				Strengths:     []string{"Strong Go skills", "Relevant experience"},
+				Weaknesses:    []string{"Limited Docker experience"},
+				Highlights:    []string{"Perfect cultural fit"},
+				Feedback:      "Great candidate for this role",
+				PromptVersion: prompts.BuiltinVersion,
 			},
 		},
 		{
@@ -167,11 +169,12 @@ Skills: Python, ML, SQL`,
 				}, nil)
 			},
 			expectedResult: &models.MatchResult{
-				MatchScore: 80,
-				Strengths:  []string{"Strong ML background"},
-				Weaknesses: []string{"Limited production experience"},
-				Highlights: []string{"Excellent Python skills"},
-				Feedback:   "Good fit with room for growth",
+				MatchScore:    80,
+				Strengths:     []string{"Strong ML background"},
+				Weaknesses:    []string{"Limited production experience"},
+				Highlights:    []string{"Excellent Python skills"},
+				Feedback:      "Good fit with room for growth",
+				PromptVersion: prompts.BuiltinVersion,
 			},
 		},
 	}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/benidevo/vega/internal/ai/constants"
+	"github.com/benidevo/vega/internal/ai/helpers"
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/benidevo/vega/internal/ai/prompts"
+	"github.com/benidevo/vega/internal/ai/validation"
+	"github.com/benidevo/vega/internal/common/logger"
+)
+
+// LearningPlanService generates skill-gap learning plans using a specified LLM provider.
+type LearningPlanService struct {
+	model     llm.Provider
+	log       *logger.PrivacyLogger
+	validator *validation.AIRequestValidator
+	helper    *helpers.ServiceHelper
+}
+
+// NewLearningPlanService creates and returns a new instance of LearningPlanService
+// using the provided llm.Provider as the underlying model.
+func NewLearningPlanService(model llm.Provider) *LearningPlanService {
+	log := logger.GetPrivacyLogger("ai_learning_plan")
+	return &LearningPlanService{
+		model:     model,
+		log:       log,
+		validator: validation.NewAIRequestValidator(),
+		helper:    helpers.NewServiceHelper(log),
+	}
+}
+
+// GenerateLearningPlan writes a learning plan for each gap in req.SkillGaps.
+// JobDescription should list the roles the gaps were found in.
+func (s *LearningPlanService) GenerateLearningPlan(ctx context.Context, req models.Request) (*models.LearningPlan, error) {
+	start := time.Now()
+
+	s.helper.LogOperationStart(constants.OperationLearningPlan, req.ApplicantName)
+
+	if err := s.validator.ValidateRequest(req); err != nil {
+		return nil, s.helper.LogValidationError(constants.OperationLearningPlan, req.ApplicantName, err)
+	}
+	if len(req.SkillGaps) == 0 {
+		err := models.WrapError(models.ErrValidationFailed, fmt.Errorf("at least one skill gap is required"))
+		return nil, s.helper.LogValidationError(constants.OperationLearningPlan, req.ApplicantName, err)
+	}
+
+	prompt := models.NewPrompt(
+		"You are a practical career coach who helps job seekers close recurring skill gaps.",
+		req,
+		true,
+	)
+
+	response, err := s.model.Generate(ctx, llm.GenerateRequest{
+		Prompt:       *prompt,
+		ResponseType: llm.ResponseTypeLearningPlan,
+	})
+	if err != nil {
+		return nil, s.helper.LogOperationError(constants.OperationLearningPlan, req.ApplicantName, constants.ErrorTypeAIGenerationFailed, time.Since(start), err)
+	}
+
+	result, ok := response.Data.(models.LearningPlan)
+	if !ok {
+		err := fmt.Errorf("unexpected response type: expected LearningPlan, got %T", response.Data)
+		return nil, s.helper.LogOperationError(constants.OperationLearningPlan, req.ApplicantName, constants.ErrorTypeResponseParseFailed, time.Since(start), err)
+	}
+
+	// The learning plan template is not versioned yet
+	result.PromptVersion = prompts.BuiltinVersion
+
+	metadata := s.helper.CreateOperationMetadata(prompt.GetOptimalTemperature(models.TaskTypeLearningPlan.String()), prompt.UseEnhancedTemplates, map[string]interface{}{
+		"gap_count":  len(req.SkillGaps),
+		"plan_count": len(result.Gaps),
+	})
+
+	s.helper.LogOperationSuccess(constants.OperationLearningPlan, req.ApplicantName, time.Since(start), prompt.UseEnhancedTemplates, metadata)
+
+	return &result, nil
+}
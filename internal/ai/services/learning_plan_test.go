@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/benidevo/vega/internal/ai/prompts"
+	"github.com/benidevo/vega/internal/ai/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestLearningPlanRequest() models.Request {
+	req := createTestRequest()
+	req.SkillGaps = []models.SkillGapDetails{
+		{Name: "GraphQL", Occurrences: 3, JobTitles: []string{"Frontend Engineer at WebTech"}},
+	}
+	return req
+}
+
+func TestLearningPlanService_GenerateLearningPlan(t *testing.T) {
+	testData := testutil.NewTestData()
+
+	t.Run("should_generate_plan_when_request_valid", func(t *testing.T) {
+		provider := &testutil.MockProvider{}
+		provider.SetupLearningPlanMock(testData.ValidLearningPlan(), nil)
+
+		result, err := NewLearningPlanService(provider).GenerateLearningPlan(context.Background(), createTestLearningPlanRequest())
+
+		require.NoError(t, err)
+		require.Len(t, result.Gaps, 1)
+		assert.Equal(t, "GraphQL", result.Gaps[0].Gap)
+		assert.Equal(t, prompts.BuiltinVersion, result.PromptVersion)
+		provider.AssertExpectations(t)
+	})
+
+	t.Run("should_return_error_when_no_gaps", func(t *testing.T) {
+		provider := &testutil.MockProvider{}
+
+		_, err := NewLearningPlanService(provider).GenerateLearningPlan(context.Background(), createTestRequest())
+
+		assert.ErrorIs(t, err, models.ErrValidationFailed)
+		provider.AssertNotCalled(t, "Generate", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should_return_error_when_provider_fails", func(t *testing.T) {
+		provider := &testutil.MockProvider{}
+		provider.SetupLearningPlanMock(models.LearningPlan{}, fmt.Errorf("provider down"))
+
+		_, err := NewLearningPlanService(provider).GenerateLearningPlan(context.Background(), createTestLearningPlanRequest())
+
+		require.Error(t, err)
+	})
+
+	t.Run("should_return_error_when_response_has_wrong_type", func(t *testing.T) {
+		provider := &testutil.MockProvider{}
+		provider.On("Generate", mock.Anything, mock.Anything).Return(llm.GenerateResponse{Data: "not a plan"}, nil)
+
+		_, err := NewLearningPlanService(provider).GenerateLearningPlan(context.Background(), createTestLearningPlanRequest())
+
+		require.Error(t, err)
+	})
+}
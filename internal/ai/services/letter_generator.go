@@ -3,12 +3,15 @@ package services
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/benidevo/vega/internal/ai/constants"
 	"github.com/benidevo/vega/internal/ai/helpers"
 	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/llm/structured"
 	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/benidevo/vega/internal/ai/prompts"
 	"github.com/benidevo/vega/internal/ai/validation"
 	"github.com/benidevo/vega/internal/common/logger"
 )
@@ -19,6 +22,7 @@ type CoverLetterGeneratorService struct {
 	log       *logger.PrivacyLogger
 	validator *validation.AIRequestValidator
 	helper    *helpers.ServiceHelper
+	prompts   PromptSelector
 }
 
 // NewCoverLetterGeneratorService creates and returns a new instance of CoverLetterGeneratorService
@@ -33,8 +37,35 @@ func NewCoverLetterGeneratorService(model llm.Provider) *CoverLetterGeneratorSer
 	}
 }
 
+// SetPromptSelector chooses the prompt template version for each request.
+func (c *CoverLetterGeneratorService) SetPromptSelector(selector PromptSelector) {
+	c.prompts = selector
+}
+
 // GenerateCoverLetter generates a cover letter based on the provided request.
 func (c *CoverLetterGeneratorService) GenerateCoverLetter(ctx context.Context, req models.Request) (*models.CoverLetter, error) {
+	return c.generate(ctx, req, nil)
+}
+
+// GenerateCoverLetterStream generates a cover letter like GenerateCoverLetter,
+// passing the letter text to onDelta as it is produced. When the provider
+// cannot stream, onDelta is not called and only the final result is returned.
+func (c *CoverLetterGeneratorService) GenerateCoverLetterStream(ctx context.Context, req models.Request, onDelta func(text string) error) (*models.CoverLetter, error) {
+	var raw strings.Builder
+	sent := 0
+	return c.generate(ctx, req, func(chunk string) error {
+		raw.WriteString(chunk)
+		preview := structured.PartialStringField(raw.String(), "content")
+		if len(preview) <= sent {
+			return nil
+		}
+		delta := preview[sent:]
+		sent = len(preview)
+		return onDelta(delta)
+	})
+}
+
+func (c *CoverLetterGeneratorService) generate(ctx context.Context, req models.Request, onChunk llm.StreamHandler) (*models.CoverLetter, error) {
 	start := time.Now()
 
 	c.helper.LogOperationStart(constants.OperationCoverLetter, req.ApplicantName)
@@ -42,6 +73,11 @@ func (c *CoverLetterGeneratorService) GenerateCoverLetter(ctx context.Context, r
 	if err := c.validator.ValidateRequest(req); err != nil {
 		return nil, c.helper.LogValidationError(constants.OperationCoverLetter, req.ApplicantName, err)
 	}
+	if req.CoverLetter != nil {
+		if err := req.CoverLetter.Validate(); err != nil {
+			return nil, c.helper.LogValidationError(constants.OperationCoverLetter, req.ApplicantName, models.WrapError(models.ErrValidationFailed, err))
+		}
+	}
 
 	// Use enhanced prompting by default
 	prompt := models.NewPrompt(
@@ -49,11 +85,20 @@ func (c *CoverLetterGeneratorService) GenerateCoverLetter(ctx context.Context, r
 		req,
 		true,
 	)
+	prompt.UsePromptVersion(selectPromptVersion(ctx, c.prompts, prompts.PromptCoverLetter))
 
-	response, err := c.model.Generate(ctx, llm.GenerateRequest{
+	request := llm.GenerateRequest{
 		Prompt:       *prompt,
 		ResponseType: llm.ResponseTypeCoverLetter,
-	})
+	}
+
+	var response llm.GenerateResponse
+	var err error
+	if onChunk == nil {
+		response, err = c.model.Generate(ctx, request)
+	} else {
+		response, err = llm.GenerateStream(ctx, c.model, request, onChunk)
+	}
 	if err != nil {
 		return nil, c.helper.LogOperationError(constants.OperationCoverLetter, req.ApplicantName, constants.ErrorTypeAIGenerationFailed, time.Since(start), err)
 	}
@@ -68,10 +113,21 @@ func (c *CoverLetterGeneratorService) GenerateCoverLetter(ctx context.Context, r
 		return nil, c.helper.LogOperationError(constants.OperationCoverLetter, req.ApplicantName, constants.ErrorTypeValidationFailed, time.Since(start), err)
 	}
 
-	metadata := c.helper.CreateOperationMetadata(prompt.GetOptimalTemperature("cover_letter"), prompt.UseEnhancedTemplates, map[string]interface{}{
+	result.PromptVersion = prompt.PromptVersion(prompts.PromptCoverLetter)
+
+	details := map[string]interface{}{
 		"content_length": len(result.Content),
 		"format":         string(result.Format),
-	})
+		"prompt_version": result.PromptVersion,
+	}
+	if req.CoverLetter != nil {
+		details["tone"] = string(req.CoverLetter.Tone)
+		details["length"] = string(req.CoverLetter.Length)
+	}
+	if req.Language != "" {
+		details["language"] = req.Language
+	}
+	metadata := c.helper.CreateOperationMetadata(prompt.GetOptimalTemperature(models.TaskTypeCoverLetter.String()), prompt.UseEnhancedTemplates, details)
 
 	c.helper.LogOperationSuccess(constants.OperationCoverLetter, req.ApplicantName, time.Since(start), prompt.UseEnhancedTemplates, metadata)
 
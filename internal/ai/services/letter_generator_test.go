@@ -86,6 +86,34 @@ func TestCoverLetterGeneratorService_GenerateCoverLetter(t *testing.T) {
 			expectError:   true,
 			errorContains: "validation failed",
 		},
+		{
+			name: "should_return_error_when_options_unknown",
+			request: func() models.Request {
+				req := createTestRequest()
+				req.CoverLetter = &models.CoverLetterOptions{Tone: "sarcastic"}
+				return req
+			}(),
+			setupMock: func(m *MockLetterGenerator) {
+			},
+			expectError:   true,
+			errorContains: "validation failed",
+		},
+		{
+			name: "should_pass_options_to_prompt",
+			request: func() models.Request {
+				req := createTestRequest()
+				req.CoverLetter = &models.CoverLetterOptions{Tone: models.LetterToneConcise, Length: models.LetterLengthShort}
+				req.Language = "fr"
+				return req
+			}(),
+			setupMock: func(m *MockLetterGenerator) {
+				m.On("Generate", mock.Anything, mock.MatchedBy(func(req llm.GenerateRequest) bool {
+					return req.Prompt.CoverLetter != nil && req.Prompt.Language == "fr"
+				})).Return(llm.GenerateResponse{
+					Data: models.CoverLetter{Content: "Madame, Monsieur,", Format: models.CoverLetterTypePlainText},
+				}, nil)
+			},
+		},
 		{
 			name:    "should_return_error_when_provider_fails",
 			request: createTestRequest(),
@@ -159,3 +187,65 @@ func TestCoverLetterGeneratorService_GenerateCoverLetter(t *testing.T) {
 		})
 	}
 }
+
+type streamingLetterGenerator struct {
+	MockLetterGenerator
+	chunks []string
+}
+
+func (m *streamingLetterGenerator) GenerateStream(ctx context.Context, request llm.GenerateRequest, onChunk llm.StreamHandler) (llm.GenerateResponse, error) {
+	for _, chunk := range m.chunks {
+		if err := onChunk(chunk); err != nil {
+			return llm.GenerateResponse{}, err
+		}
+	}
+	return m.Generate(ctx, request)
+}
+
+func TestCoverLetterGeneratorService_GenerateCoverLetterStream(t *testing.T) {
+	coverLetter := models.CoverLetter{Content: "Dear Team,\nHello", Format: models.CoverLetterTypePlainText}
+
+	t.Run("should_stream_decoded_letter_text", func(t *testing.T) {
+		provider := &streamingLetterGenerator{chunks: []string{`{"cont`, `ent": "Dear `, `Team,\`, `nHello"`, `}`}}
+		provider.On("Generate", mock.Anything, mock.Anything).Return(llm.GenerateResponse{Data: coverLetter}, nil)
+
+		var deltas []string
+		service := NewCoverLetterGeneratorService(provider)
+		result, err := service.GenerateCoverLetterStream(context.Background(), createTestRequest(), func(text string) error {
+			deltas = append(deltas, text)
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, coverLetter.Content, result.Content)
+		assert.Equal(t, []string{"Dear ", "Team,", "\nHello"}, deltas)
+	})
+
+	t.Run("should_return_final_result_when_provider_cannot_stream", func(t *testing.T) {
+		provider := &MockLetterGenerator{}
+		provider.On("Generate", mock.Anything, mock.Anything).Return(llm.GenerateResponse{Data: coverLetter}, nil)
+
+		called := false
+		service := NewCoverLetterGeneratorService(provider)
+		result, err := service.GenerateCoverLetterStream(context.Background(), createTestRequest(), func(text string) error {
+			called = true
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, coverLetter.Content, result.Content)
+		assert.False(t, called)
+	})
+
+	t.Run("should_abort_when_handler_fails", func(t *testing.T) {
+		provider := &streamingLetterGenerator{chunks: []string{`{"content": "Dear`}}
+
+		service := NewCoverLetterGeneratorService(provider)
+		_, err := service.GenerateCoverLetterStream(context.Background(), createTestRequest(), func(text string) error {
+			return fmt.Errorf("client disconnected")
+		})
+
+		assert.ErrorContains(t, err, "client disconnected")
+		provider.AssertNotCalled(t, "Generate", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/benidevo/vega/internal/ai/constants"
+	"github.com/benidevo/vega/internal/ai/helpers"
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/benidevo/vega/internal/ai/validation"
+	"github.com/benidevo/vega/internal/common/logger"
+)
+
+// SectionRewriterService rewrites single sections of generated CVs and cover
+// letters using a specified LLM provider.
+type SectionRewriterService struct {
+	model     llm.Provider
+	log       *logger.PrivacyLogger
+	validator *validation.AIRequestValidator
+	helper    *helpers.ServiceHelper
+}
+
+// NewSectionRewriterService creates and returns a new instance of
+// SectionRewriterService using the provided llm.Provider as the underlying
+// model.
+func NewSectionRewriterService(model llm.Provider) *SectionRewriterService {
+	log := logger.GetPrivacyLogger("ai_section_rewriter")
+	return &SectionRewriterService{
+		model:     model,
+		log:       log,
+		validator: validation.NewAIRequestValidator(),
+		helper:    helpers.NewServiceHelper(log),
+	}
+}
+
+// RewriteSection rewrites the section in req.Rewrite following its
+// instruction and returns only the rewritten section.
+func (s *SectionRewriterService) RewriteSection(ctx context.Context, req models.Request) (*models.SectionRewrite, error) {
+	start := time.Now()
+
+	s.helper.LogOperationStart(constants.OperationSectionRewrite, req.ApplicantName)
+
+	if err := s.validateRewriteRequest(req); err != nil {
+		return nil, s.helper.LogValidationError(constants.OperationSectionRewrite, req.ApplicantName, err)
+	}
+
+	prompt := models.NewPrompt(
+		"You are a careful CV editor who rewrites one section at a time.",
+		req,
+		true,
+	)
+
+	response, err := s.model.Generate(ctx, llm.GenerateRequest{
+		Prompt:       *prompt,
+		ResponseType: llm.ResponseTypeSectionRewrite,
+	})
+	if err != nil {
+		return nil, s.helper.LogOperationError(constants.OperationSectionRewrite, req.ApplicantName, constants.ErrorTypeAIGenerationFailed, time.Since(start), err)
+	}
+
+	result, ok := response.Data.(models.SectionRewrite)
+	if !ok {
+		err := fmt.Errorf("unexpected response type: expected SectionRewrite, got %T", response.Data)
+		return nil, s.helper.LogOperationError(constants.OperationSectionRewrite, req.ApplicantName, constants.ErrorTypeResponseParseFailed, time.Since(start), err)
+	}
+
+	result.Normalize(req.Rewrite.Section)
+	if result.Content == "" {
+		err := fmt.Errorf("rewrite of %s section was empty", req.Rewrite.Section)
+		return nil, s.helper.LogOperationError(constants.OperationSectionRewrite, req.ApplicantName, constants.ErrorTypeResponseParseFailed, time.Since(start), err)
+	}
+
+	metadata := s.helper.CreateOperationMetadata(prompt.GetOptimalTemperature(models.TaskTypeSectionRewrite.String()), prompt.UseEnhancedTemplates, map[string]interface{}{
+		"section":         string(req.Rewrite.Section),
+		"original_length": len(req.Rewrite.Text),
+		"content_length":  len(result.Content),
+	})
+
+	s.helper.LogOperationSuccess(constants.OperationSectionRewrite, req.ApplicantName, time.Since(start), prompt.UseEnhancedTemplates, metadata)
+
+	return &result, nil
+}
+
+// validateRewriteRequest validates the request and its rewrite details.
+func (s *SectionRewriterService) validateRewriteRequest(req models.Request) error {
+	if err := s.validator.ValidateRequest(req); err != nil {
+		return err
+	}
+
+	if req.Rewrite == nil {
+		return models.WrapError(models.ErrValidationFailed, fmt.Errorf("rewrite details are required"))
+	}
+	if !req.Rewrite.Section.IsValid() {
+		return models.WrapError(models.ErrValidationFailed, fmt.Errorf("unknown section '%s'", req.Rewrite.Section))
+	}
+	if strings.TrimSpace(req.Rewrite.Text) == "" {
+		return models.WrapError(models.ErrValidationFailed, fmt.Errorf("section text is required"))
+	}
+	if strings.TrimSpace(req.Rewrite.Instruction) == "" {
+		return models.WrapError(models.ErrValidationFailed, fmt.Errorf("rewrite instruction is required"))
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/benidevo/vega/internal/ai/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestRewriteRequest(section models.SectionKind, text, instruction string) models.Request {
+	req := createTestRequest()
+	req.Rewrite = &models.RewriteDetails{
+		Section:     section,
+		Text:        text,
+		Instruction: instruction,
+	}
+	return req
+}
+
+func TestSectionRewriterService_RewriteSection(t *testing.T) {
+	testData := testutil.NewTestData()
+
+	t.Run("should_rewrite_section_when_request_valid", func(t *testing.T) {
+		provider := &testutil.MockProvider{}
+		provider.SetupSectionRewriteMock(testData.ValidSectionRewrite(), nil)
+
+		result, err := NewSectionRewriterService(provider).RewriteSection(context.Background(),
+			createTestRewriteRequest(models.SectionExperience, "• Built React dashboards", "quantify results"))
+
+		require.NoError(t, err)
+		assert.Equal(t, testData.ValidSectionRewrite().Content, result.Content)
+		provider.AssertExpectations(t)
+	})
+
+	t.Run("should_normalize_skills_list", func(t *testing.T) {
+		provider := &testutil.MockProvider{}
+		provider.SetupSectionRewriteMock(models.SectionRewrite{Content: "Go\nKubernetes, go", Changes: "Shortened"}, nil)
+
+		result, err := NewSectionRewriterService(provider).RewriteSection(context.Background(),
+			createTestRewriteRequest(models.SectionSkills, "Go, Kubernetes, Terraform, Docker", "shorten"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "Go, Kubernetes", result.Content)
+	})
+
+	t.Run("should_return_error_when_section_unknown", func(t *testing.T) {
+		provider := &testutil.MockProvider{}
+
+		_, err := NewSectionRewriterService(provider).RewriteSection(context.Background(),
+			createTestRewriteRequest("education", "BSc Computer Science", "shorten"))
+
+		assert.ErrorIs(t, err, models.ErrValidationFailed)
+		provider.AssertNotCalled(t, "Generate", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should_return_error_when_instruction_missing", func(t *testing.T) {
+		provider := &testutil.MockProvider{}
+
+		_, err := NewSectionRewriterService(provider).RewriteSection(context.Background(),
+			createTestRewriteRequest(models.SectionSummary, "Engineer with 5 years of experience", " "))
+
+		assert.ErrorIs(t, err, models.ErrValidationFailed)
+		provider.AssertNotCalled(t, "Generate", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should_return_error_when_rewrite_details_missing", func(t *testing.T) {
+		provider := &testutil.MockProvider{}
+
+		_, err := NewSectionRewriterService(provider).RewriteSection(context.Background(), createTestRequest())
+
+		assert.ErrorIs(t, err, models.ErrValidationFailed)
+	})
+
+	t.Run("should_return_error_when_provider_fails", func(t *testing.T) {
+		provider := &testutil.MockProvider{}
+		provider.SetupSectionRewriteMock(models.SectionRewrite{}, fmt.Errorf("provider down"))
+
+		_, err := NewSectionRewriterService(provider).RewriteSection(context.Background(),
+			createTestRewriteRequest(models.SectionSummary, "Engineer with 5 years of experience", "shorten"))
+
+		require.Error(t, err)
+	})
+
+	t.Run("should_return_error_when_response_has_wrong_type", func(t *testing.T) {
+		provider := &testutil.MockProvider{}
+		provider.SetupGenericMock(llm.GenerateResponse{Data: models.Email{Subject: "Hi", Body: "Hello"}}, nil)
+
+		_, err := NewSectionRewriterService(provider).RewriteSection(context.Background(),
+			createTestRewriteRequest(models.SectionSummary, "Engineer with 5 years of experience", "shorten"))
+
+		require.Error(t, err)
+	})
+}
@@ -3,16 +3,30 @@ package ai
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/llm/cached"
+	"github.com/benidevo/vega/internal/ai/llm/fallback"
 	"github.com/benidevo/vega/internal/ai/llm/gemini"
+	"github.com/benidevo/vega/internal/ai/llm/ollama"
+	"github.com/benidevo/vega/internal/ai/llm/openai"
+	"github.com/benidevo/vega/internal/ai/llm/replay"
+	"github.com/benidevo/vega/internal/ai/llm/structured"
 	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/benidevo/vega/internal/ai/privacy"
 	"github.com/benidevo/vega/internal/ai/services"
+	"github.com/benidevo/vega/internal/ai/usage"
+	"github.com/benidevo/vega/internal/cache"
+	"github.com/benidevo/vega/internal/common/logger"
 	"github.com/benidevo/vega/internal/config"
 )
 
 const (
 	ProviderGemini = "gemini"
+	ProviderOpenAI = "openai"
+	ProviderOllama = "ollama"
+	ProviderReplay = "replay"
 )
 
 type AIService struct {
@@ -20,21 +34,210 @@ type AIService struct {
 	CoverLetterGenerator *services.CoverLetterGeneratorService
 	CVParser             *services.CVParserService
 	CVGenerator          *services.CVGeneratorService
+	InterviewPrep        *services.InterviewPrepService
+	Email                *services.EmailService
+	LearningPlan         *services.LearningPlanService
+	JobExtractor         *services.JobExtractorService
+	SectionRewriter      *services.SectionRewriterService
+
+	provider llm.Provider
+}
+
+type setupOptions struct {
+	usageRepo      usage.Repository
+	responseCache  cache.Cache
+	promptSelector services.PromptSelector
+	redaction      privacy.Policy
+}
+
+// Option customizes how Setup builds the AI service.
+type Option func(*setupOptions)
+
+// WithUsageTracking records every LLM call in the given usage repository.
+func WithUsageTracking(repo usage.Repository) Option {
+	return func(o *setupOptions) {
+		o.usageRepo = repo
+	}
+}
+
+// WithResponseCache serves repeated identical requests from the given cache
+// when AI_CACHE_ENABLED is set.
+func WithResponseCache(store cache.Cache) Option {
+	return func(o *setupOptions) {
+		o.responseCache = store
+	}
+}
+
+// WithPromptSelector chooses the prompt template version of each cover letter,
+// job match and CV generation request.
+func WithPromptSelector(selector services.PromptSelector) Option {
+	return func(o *setupOptions) {
+		o.promptSelector = selector
+	}
+}
+
+// WithPIIRedaction replaces personal details in prompts with placeholder
+// tokens before they reach the provider, for the tasks the policy allows.
+func WithPIIRedaction(policy privacy.Policy) Option {
+	return func(o *setupOptions) {
+		o.redaction = policy
+	}
 }
 
 // Setup initializes the complete AI service with all dependencies.
 // It configures the LLM provider and creates all AI services.
-func Setup(cfg *config.Settings) (*AIService, error) {
+func Setup(cfg *config.Settings, opts ...Option) (*AIService, error) {
+	options := newSetupOptions(opts)
+
 	provider, err := createProvider(cfg)
 	if err != nil {
 		return nil, models.WrapError(models.ErrProviderInitFailed, err)
 	}
 
-	return NewAIService(provider), nil
+	return options.newService(cfg, provider, primaryProviderName(cfg), modelResolver(cfg, providerNames(cfg))), nil
+}
+
+func newSetupOptions(opts []Option) setupOptions {
+	var options setupOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// wrap adds usage tracking, response caching and PII redaction around
+// provider, as enabled by the options.
+func (o setupOptions) wrap(cfg *config.Settings, provider llm.Provider, name string, resolver cached.ModelResolver) llm.Provider {
+	if o.usageRepo != nil {
+		provider = usage.NewRecordingProvider(provider, o.usageRepo, name, usageModelResolver(cfg, name))
+	}
+
+	// The cache wraps usage tracking so that cache hits are not recorded as LLM calls.
+	if o.responseCache != nil && cfg.AICacheEnabled {
+		provider = cached.New(provider, o.responseCache, cached.NewConfig(cfg), resolver)
+	}
+
+	// Redaction wraps the cache so that cached responses hold tokens rather
+	// than personal details.
+	if o.redaction != nil {
+		provider = privacy.NewRedactingProvider(provider, o.redaction)
+	}
+	return provider
+}
+
+// newService builds an AI service around provider with the options applied.
+func (o setupOptions) newService(cfg *config.Settings, provider llm.Provider, name string, resolver cached.ModelResolver) *AIService {
+	service := NewAIService(o.wrap(cfg, provider, name, resolver))
+	if o.promptSelector != nil {
+		service.SetPromptSelector(o.promptSelector)
+	}
+	return service
+}
+
+// providerNames returns the configured provider chain.
+func providerNames(cfg *config.Settings) []string {
+	if len(cfg.AIProviders) > 0 {
+		return cfg.AIProviders
+	}
+	return []string{cfg.AIProvider}
+}
+
+// primaryProviderName returns the name of the first configured provider.
+func primaryProviderName(cfg *config.Settings) string {
+	return providerNames(cfg)[0]
+}
+
+// modelResolver returns the provider and model serving each task type across
+// the given provider chain, for use in response cache keys.
+func modelResolver(cfg *config.Settings, names []string) cached.ModelResolver {
+	return func(taskType string) string {
+		parts := make([]string, 0, len(names))
+		for _, name := range names {
+			parts = append(parts, name+"/"+providerModel(cfg, name, taskType))
+		}
+		return strings.Join(parts, ",")
+	}
+}
+
+// usageModelResolver returns the model the named provider is configured to
+// use for each response type, for usage records of calls that fail.
+func usageModelResolver(cfg *config.Settings, name string) usage.ModelResolver {
+	return func(responseType llm.ResponseType) string {
+		taskType, ok := structured.TaskTypeFor(responseType)
+		if !ok {
+			return ""
+		}
+		return providerModel(cfg, name, taskType.String())
+	}
+}
+
+// providerModel returns the model the named provider uses for a task type.
+func providerModel(cfg *config.Settings, name, taskType string) string {
+	switch name {
+	case ProviderGemini:
+		return gemini.NewConfig(cfg).GetModelForTask(taskType)
+	case ProviderOpenAI:
+		return openai.NewConfig(cfg).GetModelForTask(taskType)
+	case ProviderOllama:
+		return ollama.NewConfig(cfg).GetModelForTask(taskType)
+	case ProviderReplay:
+		return cfg.AIReplayMode
+	default:
+		return ""
+	}
 }
 
 func createProvider(cfg *config.Settings) (llm.Provider, error) {
-	switch cfg.AIProvider {
+	switch len(cfg.AIProviders) {
+	case 0:
+		return createNamedProvider(cfg, cfg.AIProvider)
+	case 1:
+		return createNamedProvider(cfg, cfg.AIProviders[0])
+	default:
+		return createFallbackProvider(cfg)
+	}
+}
+
+// createFallbackProvider builds a fallback chain from cfg.AIProviders. Providers
+// that cannot be initialized are left out of the chain with a warning.
+func createFallbackProvider(cfg *config.Settings) (llm.Provider, error) {
+	log := logger.GetPrivacyLogger("ai_setup")
+
+	var members []fallback.Member
+	var lastErr error
+	for _, name := range cfg.AIProviders {
+		provider, err := createNamedProvider(cfg, name)
+		if err != nil {
+			log.Warn().Err(err).Str("provider", name).Msg("Skipping AI provider in fallback chain")
+			lastErr = err
+			continue
+		}
+		members = append(members, fallback.Member{Name: name, Provider: provider})
+	}
+
+	if len(members) == 0 {
+		return nil, lastErr
+	}
+
+	return fallback.New(members, fallback.Options{
+		Breaker: fallback.BreakerConfig{
+			FailureThreshold: cfg.AIBreakerThreshold,
+			OpenTimeout:      cfg.AIBreakerOpenTimeout,
+		},
+		ShouldFailover: isRetryableProviderError,
+	})
+}
+
+// isRetryableProviderError reports whether a provider error indicates a
+// transient outage worth failing over on.
+func isRetryableProviderError(err error) bool {
+	return gemini.IsRetryableError(err) ||
+		openai.IsRetryableError(err) ||
+		ollama.IsRetryableError(err)
+}
+
+func createNamedProvider(cfg *config.Settings, name string) (llm.Provider, error) {
+	switch name {
 	case ProviderGemini:
 		if cfg.GeminiAPIKey == "" {
 			return nil, models.WrapError(models.ErrMissingAPIKey, fmt.Errorf("GEMINI_API_KEY is required for Gemini provider"))
@@ -46,11 +249,74 @@ func createProvider(cfg *config.Settings) (llm.Provider, error) {
 			return nil, models.WrapError(models.ErrProviderInitFailed, err)
 		}
 		return provider, nil
+	case ProviderOpenAI:
+		if cfg.OpenAIBaseURL == "" {
+			return nil, models.WrapError(models.ErrProviderInitFailed, fmt.Errorf("OPENAI_BASE_URL is required for OpenAI-compatible provider"))
+		}
+
+		provider, err := openai.New(openai.NewConfig(cfg))
+		if err != nil {
+			return nil, models.WrapError(models.ErrProviderInitFailed, err)
+		}
+		return provider, nil
+	case ProviderOllama:
+		if cfg.OllamaBaseURL == "" {
+			return nil, models.WrapError(models.ErrProviderInitFailed, fmt.Errorf("OLLAMA_BASE_URL is required for Ollama provider"))
+		}
+
+		provider, err := ollama.New(ollama.NewConfig(cfg))
+		if err != nil {
+			return nil, models.WrapError(models.ErrProviderInitFailed, err)
+		}
+		return provider, nil
+	case ProviderReplay:
+		return createReplayProvider(cfg)
 	default:
-		return nil, models.WrapError(models.ErrUnsupportedProvider, fmt.Errorf("provider '%s' is not supported", cfg.AIProvider))
+		return nil, models.WrapError(models.ErrUnsupportedProvider, fmt.Errorf("provider '%s' is not supported", name))
 	}
 }
 
+// createReplayProvider builds the record/replay provider. In record mode the
+// configured upstream provider is wrapped and its responses saved as fixtures.
+func createReplayProvider(cfg *config.Settings) (llm.Provider, error) {
+	replayCfg := replay.NewConfig(cfg)
+
+	var upstream llm.Provider
+	if replayCfg.Mode == replay.ModeRecord {
+		if replayCfg.Upstream == ProviderReplay {
+			return nil, models.WrapError(models.ErrProviderInitFailed, fmt.Errorf("AI_REPLAY_UPSTREAM cannot be '%s'", ProviderReplay))
+		}
+
+		var err error
+		upstream, err = createNamedProvider(cfg, replayCfg.Upstream)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	provider, err := replay.New(replayCfg, upstream)
+	if err != nil {
+		return nil, models.WrapError(models.ErrProviderInitFailed, err)
+	}
+	return provider, nil
+}
+
+// modelValidator is implemented by providers that can verify their configured
+// models are available before the first request.
+type modelValidator interface {
+	ValidateModels(ctx context.Context) error
+}
+
+// ValidateModels checks that the models configured for the service's
+// providers are available. Providers that cannot enumerate models are
+// assumed valid.
+func (s *AIService) ValidateModels(ctx context.Context) error {
+	if validator, ok := s.provider.(modelValidator); ok {
+		return validator.ValidateModels(ctx)
+	}
+	return nil
+}
+
 // NewAIService initializes the AI service with the provided LLM provider.
 func NewAIService(provider llm.Provider) *AIService {
 	return &AIService{
@@ -58,5 +324,19 @@ func NewAIService(provider llm.Provider) *AIService {
 		CoverLetterGenerator: services.NewCoverLetterGeneratorService(provider),
 		CVParser:             services.NewCVParserService(provider),
 		CVGenerator:          services.NewCVGeneratorService(provider),
+		InterviewPrep:        services.NewInterviewPrepService(provider),
+		Email:                services.NewEmailService(provider),
+		LearningPlan:         services.NewLearningPlanService(provider),
+		JobExtractor:         services.NewJobExtractorService(provider),
+		SectionRewriter:      services.NewSectionRewriterService(provider),
+		provider:             provider,
 	}
 }
+
+// SetPromptSelector chooses the prompt template version used by the services
+// that generate from versioned prompts.
+func (s *AIService) SetPromptSelector(selector services.PromptSelector) {
+	s.JobMatcher.SetPromptSelector(selector)
+	s.CoverLetterGenerator.SetPromptSelector(selector)
+	s.CVGenerator.SetPromptSelector(selector)
+}
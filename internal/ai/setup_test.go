@@ -2,6 +2,8 @@ package ai
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/benidevo/vega/internal/ai/llm"
@@ -37,10 +39,83 @@ func TestSetup(t *testing.T) {
 			expectError: true,
 			errorType:   models.ErrMissingAPIKey,
 		},
+		{
+			name: "successful OpenAI-compatible setup",
+			config: &config.Settings{
+				AIProvider:    ProviderOpenAI,
+				OpenAIBaseURL: "http://localhost:8000/v1",
+				OpenAIModel:   "llama-3.1-8b-instruct",
+			},
+			expectError: false,
+		},
+		{
+			name: "missing OpenAI base URL",
+			config: &config.Settings{
+				AIProvider: ProviderOpenAI,
+			},
+			expectError: true,
+			errorType:   models.ErrProviderInitFailed,
+		},
+		{
+			name: "successful Ollama setup",
+			config: &config.Settings{
+				AIProvider:    ProviderOllama,
+				OllamaBaseURL: "http://localhost:11434",
+				OllamaModel:   "llama3.1:8b",
+			},
+			expectError: false,
+		},
+		{
+			name: "missing Ollama base URL",
+			config: &config.Settings{
+				AIProvider: ProviderOllama,
+			},
+			expectError: true,
+			errorType:   models.ErrProviderInitFailed,
+		},
+		{
+			name: "successful synthetic replay setup",
+			config: &config.Settings{
+				AIProvider:   ProviderReplay,
+				AIReplayMode: "synthetic",
+			},
+			expectError: false,
+		},
+		{
+			name: "replay record mode without usable upstream",
+			config: &config.Settings{
+				AIProvider:         ProviderReplay,
+				AIReplayMode:       "record",
+				AIReplayFixtureDir: "fixtures",
+				AIReplayUpstream:   ProviderGemini,
+			},
+			expectError: true,
+			errorType:   models.ErrMissingAPIKey,
+		},
+		{
+			name: "replay record mode cannot wrap itself",
+			config: &config.Settings{
+				AIProvider:         ProviderReplay,
+				AIReplayMode:       "record",
+				AIReplayFixtureDir: "fixtures",
+				AIReplayUpstream:   ProviderReplay,
+			},
+			expectError: true,
+			errorType:   models.ErrProviderInitFailed,
+		},
+		{
+			name: "unsupported replay mode",
+			config: &config.Settings{
+				AIProvider:   ProviderReplay,
+				AIReplayMode: "rewind",
+			},
+			expectError: true,
+			errorType:   models.ErrProviderInitFailed,
+		},
 		{
 			name: "unsupported provider",
 			config: &config.Settings{
-				AIProvider: "openai",
+				AIProvider: "anthropic",
 			},
 			expectError: true,
 			errorType:   models.ErrUnsupportedProvider,
@@ -100,6 +175,32 @@ func TestCreateProvider(t *testing.T) {
 			expectError: true,
 			errorType:   models.ErrMissingAPIKey,
 		},
+		{
+			name: "fallback chain skips providers that fail to initialize",
+			config: &config.Settings{
+				AIProviders:   []string{ProviderGemini, ProviderOllama},
+				OllamaBaseURL: "http://localhost:11434",
+				OllamaModel:   "llama3.1:8b",
+			},
+			expectError: false,
+		},
+		{
+			name: "fallback chain with no usable providers",
+			config: &config.Settings{
+				AIProviders: []string{ProviderGemini, "claude"},
+			},
+			expectError: true,
+			errorType:   models.ErrUnsupportedProvider,
+		},
+		{
+			name: "single entry provider list",
+			config: &config.Settings{
+				AIProvider:    ProviderGemini,
+				AIProviders:   []string{ProviderOpenAI},
+				OpenAIBaseURL: "http://localhost:8000/v1",
+			},
+			expectError: false,
+		},
 		{
 			name: "unsupported provider type",
 			config: &config.Settings{
@@ -128,6 +229,35 @@ func TestCreateProvider(t *testing.T) {
 	}
 }
 
+func TestAIService_ValidateModels(t *testing.T) {
+	t.Run("providers without model listing are assumed valid", func(t *testing.T) {
+		service, err := Setup(&config.Settings{
+			AIProvider:   ProviderGemini,
+			GeminiAPIKey: "test-key",
+		})
+		require.NoError(t, err)
+		assert.NoError(t, service.ValidateModels(context.Background()))
+	})
+
+	t.Run("reports missing Ollama models", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"models": [{"name": "mistral:latest"}]}`))
+		}))
+		defer server.Close()
+
+		service, err := Setup(&config.Settings{
+			AIProvider:    ProviderOllama,
+			OllamaBaseURL: server.URL,
+			OllamaModel:   "llama3.1:8b",
+		})
+		require.NoError(t, err)
+
+		err = service.ValidateModels(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "llama3.1:8b")
+	})
+}
+
 func TestNewAIService(t *testing.T) {
 	t.Run("creates service with all components", func(t *testing.T) {
 		mockProvider := &MockProvider{}
@@ -137,6 +267,11 @@ func TestNewAIService(t *testing.T) {
 		require.NotNil(t, service)
 		assert.NotNil(t, service.JobMatcher)
 		assert.NotNil(t, service.CoverLetterGenerator)
+		assert.NotNil(t, service.InterviewPrep)
+		assert.NotNil(t, service.Email)
+		assert.NotNil(t, service.LearningPlan)
+		assert.NotNil(t, service.JobExtractor)
+		assert.NotNil(t, service.SectionRewriter)
 	})
 }
 
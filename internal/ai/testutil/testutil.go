@@ -100,6 +100,99 @@ func (m *MockProvider) SetupCVGenerationMock(result models.CVParsingResult, err
 	})).Return(response, err)
 }
 
+// SetupInterviewPrepMock configures the mock for interview preparation operations
+func (m *MockProvider) SetupInterviewPrepMock(result models.InterviewPrep, err error) {
+	response := llm.GenerateResponse{
+		Data:     result,
+		Duration: 900 * time.Millisecond,
+		Tokens:   0,
+		Metadata: map[string]interface{}{
+			"temperature": float32(0.5),
+			"enhanced":    true,
+			"model":       "gemini-2.5-flash",
+			"task_type":   "interview_prep",
+		},
+	}
+
+	m.On("Generate", mock.Anything, mock.MatchedBy(func(req llm.GenerateRequest) bool {
+		return req.ResponseType == llm.ResponseTypeInterviewPrep
+	})).Return(response, err)
+}
+
+// SetupEmailMock configures the mock for application email operations
+func (m *MockProvider) SetupEmailMock(result models.Email, err error) {
+	response := llm.GenerateResponse{
+		Data:     result,
+		Duration: 600 * time.Millisecond,
+		Tokens:   0,
+		Metadata: map[string]interface{}{
+			"temperature": float32(0.6),
+			"enhanced":    true,
+			"model":       "gemini-2.5-flash",
+			"task_type":   "email",
+		},
+	}
+
+	m.On("Generate", mock.Anything, mock.MatchedBy(func(req llm.GenerateRequest) bool {
+		return req.ResponseType == llm.ResponseTypeEmail
+	})).Return(response, err)
+}
+
+// SetupLearningPlanMock configures the mock for learning plan operations
+func (m *MockProvider) SetupLearningPlanMock(result models.LearningPlan, err error) {
+	response := llm.GenerateResponse{
+		Data:     result,
+		Duration: 900 * time.Millisecond,
+		Tokens:   0,
+		Metadata: map[string]interface{}{
+			"temperature": float32(0.5),
+			"enhanced":    true,
+			"model":       "gemini-2.5-flash",
+			"task_type":   "learning_plan",
+		},
+	}
+
+	m.On("Generate", mock.Anything, mock.MatchedBy(func(req llm.GenerateRequest) bool {
+		return req.ResponseType == llm.ResponseTypeLearningPlan
+	})).Return(response, err)
+}
+
+// SetupJobExtractionMock configures the mock for job extraction operations
+func (m *MockProvider) SetupJobExtractionMock(result models.JobExtraction, err error) {
+	response := llm.GenerateResponse{
+		Data:     result,
+		Duration: 700 * time.Millisecond,
+		Tokens:   0,
+		Metadata: map[string]interface{}{
+			"temperature": float32(0.1),
+			"model":       "gemini-2.5-flash",
+			"task_type":   "job_extraction",
+		},
+	}
+
+	m.On("Generate", mock.Anything, mock.MatchedBy(func(req llm.GenerateRequest) bool {
+		return req.ResponseType == llm.ResponseTypeJobExtraction
+	})).Return(response, err)
+}
+
+// SetupSectionRewriteMock configures the mock for section rewrite operations
+func (m *MockProvider) SetupSectionRewriteMock(result models.SectionRewrite, err error) {
+	response := llm.GenerateResponse{
+		Data:     result,
+		Duration: 600 * time.Millisecond,
+		Tokens:   0,
+		Metadata: map[string]interface{}{
+			"temperature": float32(0.45),
+			"model":       "gemini-2.5-flash",
+			"task_type":   "section_rewrite",
+		},
+	}
+
+	m.On("Generate", mock.Anything, mock.MatchedBy(func(req llm.GenerateRequest) bool {
+		return req.ResponseType == llm.ResponseTypeSectionRewrite
+	})).Return(response, err)
+}
+
 // SetupGenericMock configures the mock to return any response for any request
 func (m *MockProvider) SetupGenericMock(response llm.GenerateResponse, err error) {
 	m.On("Generate", mock.Anything, mock.AnythingOfType("llm.GenerateRequest")).
@@ -432,6 +525,86 @@ func (td *TestData) ValidGeneratedCV() models.CVParsingResult {
 	}
 }
 
+// ValidInterviewPrep returns a sample interview preparation pack
+func (td *TestData) ValidInterviewPrep() models.InterviewPrep {
+	return models.InterviewPrep{
+		Questions: []models.InterviewQuestion{
+			{
+				Question:   "Tell me about a time you made a page noticeably faster.",
+				Reason:     "The role lists performance as a priority.",
+				Experience: "Senior Frontend Developer at WebTech Solutions",
+				Answer: models.STARAnswer{
+					Situation: "Our product pages took over four seconds to load on mobile.",
+					Task:      "I was asked to bring that under two seconds before the holiday sale.",
+					Action:    "I split the bundle by route, lazy loaded images and removed two unused libraries.",
+					Result:    "Load times dropped by 30% and mobile conversions went up that quarter.",
+				},
+			},
+		},
+		QuestionsToAsk: []string{"How do you measure frontend performance today?"},
+		GapsToRehearse: []string{"No server-side rendering in production: explain the Next.js side projects."},
+	}
+}
+
+// ValidEmail returns a sample follow-up email
+func (td *TestData) ValidEmail() models.Email {
+	return models.Email{
+		Subject: "Checking in on my Frontend Engineer application",
+		Body:    "Hi WebTech team,\n\nI applied for the Frontend Engineer role two weeks ago and wanted to check in. I'm still very keen, especially on the design system work.\n\nBest regards,\nSarah Johnson",
+	}
+}
+
+// ValidLearningPlan returns a sample learning plan for one skill gap
+func (td *TestData) ValidLearningPlan() models.LearningPlan {
+	return models.LearningPlan{
+		Gaps: []models.GapPlan{
+			{
+				Gap:      "GraphQL",
+				Why:      "Three of the frontend roles you analysed use GraphQL APIs.",
+				Steps:    []string{"Build a small GraphQL server over an existing REST API", "Add Apollo Client to a React app that uses it"},
+				Projects: []string{"Rewrite the data layer of your portfolio site to use GraphQL"},
+				Phrasing: []string{"Built a GraphQL API and React client with Apollo for a personal project"},
+			},
+		},
+	}
+}
+
+// ValidJobExtraction returns a sample job extracted from a pasted posting
+func (td *TestData) ValidJobExtraction() models.JobExtraction {
+	return models.JobExtraction{
+		IsValid:        true,
+		Title:          "Frontend Engineer",
+		Company:        "WebTech",
+		Location:       "London, UK",
+		JobType:        "full_time",
+		RequiredSkills: []string{"React", "TypeScript", "GraphQL"},
+		SalaryMin:      60000,
+		SalaryMax:      75000,
+		SalaryCurrency: "GBP",
+		SalaryPeriod:   "year",
+		Seniority:      "mid",
+		ApplicationURL: "https://webtech.example/careers/frontend",
+		Confidence: map[string]float64{
+			models.JobFieldTitle:          0.95,
+			models.JobFieldCompany:        0.9,
+			models.JobFieldLocation:       0.8,
+			models.JobFieldJobType:        0.6,
+			models.JobFieldRequiredSkills: 0.85,
+			models.JobFieldSalary:         0.9,
+			models.JobFieldSeniority:      0.5,
+			models.JobFieldApplicationURL: 0.9,
+		},
+	}
+}
+
+// ValidSectionRewrite returns a sample rewritten work experience entry
+func (td *TestData) ValidSectionRewrite() models.SectionRewrite {
+	return models.SectionRewrite{
+		Content: "• Led a team of 4 engineers building React dashboards\n• Cut page load times by 40% with code splitting",
+		Changes: "Added the team size and load time figures from the profile.",
+	}
+}
+
 // NewTestData creates a new TestData instance
 func NewTestData() *TestData {
 	return &TestData{}
@@ -0,0 +1,64 @@
+package usage
+
+import "time"
+
+// Record is a single LLM call persisted in the llm_usage table.
+type Record struct {
+	ID               int       `json:"id"`
+	UserID           int       `json:"user_id"` // 0 when the call was not made on behalf of a user
+	TaskType         string    `json:"task_type"`
+	Provider         string    `json:"provider"`
+	Model            string    `json:"model"`
+	PromptTokens     int       `json:"prompt_tokens"`
+	CompletionTokens int       `json:"completion_tokens"`
+	TotalTokens      int       `json:"total_tokens"`
+	LatencyMs        int64     `json:"latency_ms"`
+	Success          bool      `json:"success"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// Aggregate holds summed usage over a group of LLM calls.
+type Aggregate struct {
+	Requests         int   `json:"requests"`
+	Failures         int   `json:"failures"`
+	PromptTokens     int   `json:"prompt_tokens"`
+	CompletionTokens int   `json:"completion_tokens"`
+	TotalTokens      int   `json:"total_tokens"`
+	AvgLatencyMs     int64 `json:"avg_latency_ms"`
+}
+
+// MonthlyUsage is the aggregate usage for one calendar month.
+type MonthlyUsage struct {
+	Month string `json:"month"` // Format: "2024-01"
+	Aggregate
+}
+
+// TaskUsage is the aggregate usage for one task type.
+type TaskUsage struct {
+	TaskType string `json:"task_type"`
+	Aggregate
+}
+
+// Summary is a user's LLM usage overview.
+type Summary struct {
+	CurrentMonth string         `json:"current_month"`
+	Total        Aggregate      `json:"total"`
+	Months       []MonthlyUsage `json:"months"`
+	ByTask       []TaskUsage    `json:"by_task"` // Current month only
+}
+
+// taskLabels maps recorded task types to display names
+var taskLabels = map[string]string{
+	"cover_letter":  "Cover Letter",
+	"match_result":  "Job Analysis",
+	"cv_parsing":    "CV Parsing",
+	"cv_generation": "CV Generation",
+}
+
+// Label returns a human-readable name for the task type.
+func (t TaskUsage) Label() string {
+	if label, ok := taskLabels[t.TaskType]; ok {
+		return label
+	}
+	return t.TaskType
+}
@@ -0,0 +1,94 @@
+package usage
+
+import (
+	"context"
+	"time"
+
+	"github.com/benidevo/vega/internal/ai/llm"
+	ctxutil "github.com/benidevo/vega/internal/common/context"
+	"github.com/benidevo/vega/internal/common/logger"
+)
+
+// RecordingProvider is an llm.Provider decorator that writes every call,
+// successful or not, to the usage ledger. The user is taken from the
+// request context.
+type RecordingProvider struct {
+	next     llm.Provider
+	repo     Repository
+	provider string
+	model    ModelResolver
+	log      *logger.PrivacyLogger
+}
+
+// ModelResolver returns the model a request of the given response type is
+// configured to use.
+type ModelResolver func(responseType llm.ResponseType) string
+
+// NewRecordingProvider wraps next so that its calls are recorded in repo.
+// providerName and model are used when the response does not name the
+// serving provider and model, as for failed calls. model may be nil.
+func NewRecordingProvider(next llm.Provider, repo Repository, providerName string, model ModelResolver) *RecordingProvider {
+	return &RecordingProvider{
+		next:     next,
+		repo:     repo,
+		provider: providerName,
+		model:    model,
+		log:      logger.GetPrivacyLogger("ai_usage"),
+	}
+}
+
+// Generate implements the Provider interface.
+func (p *RecordingProvider) Generate(ctx context.Context, request llm.GenerateRequest) (llm.GenerateResponse, error) {
+	start := time.Now()
+	resp, err := p.next.Generate(ctx, request)
+	p.record(ctx, request, resp, err, time.Since(start))
+	return resp, err
+}
+
+// GenerateStream implements the llm.StreamingProvider interface, streaming
+// through the wrapped provider when it supports it.
+func (p *RecordingProvider) GenerateStream(ctx context.Context, request llm.GenerateRequest, onChunk llm.StreamHandler) (llm.GenerateResponse, error) {
+	start := time.Now()
+	resp, err := llm.GenerateStream(ctx, p.next, request, onChunk)
+	p.record(ctx, request, resp, err, time.Since(start))
+	return resp, err
+}
+
+func (p *RecordingProvider) record(ctx context.Context, request llm.GenerateRequest, resp llm.GenerateResponse, err error, latency time.Duration) {
+	record := &Record{
+		TaskType:         string(request.ResponseType),
+		Provider:         p.provider,
+		PromptTokens:     resp.PromptTokens,
+		CompletionTokens: resp.CompletionTokens,
+		TotalTokens:      resp.Tokens,
+		LatencyMs:        latency.Milliseconds(),
+		Success:          err == nil,
+	}
+	if userID, ok := ctxutil.GetUserID(ctx); ok {
+		record.UserID = userID
+	}
+	if provider, ok := resp.Metadata["provider"].(string); ok && provider != "" {
+		record.Provider = provider
+	}
+	if model, ok := resp.Metadata["model"].(string); ok && model != "" {
+		record.Model = model
+	} else if p.model != nil {
+		record.Model = p.model(request.ResponseType)
+	}
+
+	// Recording must never fail the user's request
+	if recordErr := p.repo.Create(context.WithoutCancel(ctx), record); recordErr != nil {
+		p.log.Warn().Err(recordErr).Str("task_type", record.TaskType).Msg("Failed to record LLM usage")
+	}
+}
+
+// ValidateModels delegates to the wrapped provider when it supports model
+// validation.
+func (p *RecordingProvider) ValidateModels(ctx context.Context) error {
+	if validator, ok := p.next.(interface {
+		ValidateModels(ctx context.Context) error
+	}); ok {
+		return validator.ValidateModels(ctx)
+	}
+	return nil
+}
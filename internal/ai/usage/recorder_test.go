@@ -0,0 +1,100 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/benidevo/vega/internal/ai/llm"
+	ctxutil "github.com/benidevo/vega/internal/common/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubProvider struct {
+	resp llm.GenerateResponse
+	err  error
+}
+
+func (p *stubProvider) Generate(ctx context.Context, request llm.GenerateRequest) (llm.GenerateResponse, error) {
+	return p.resp, p.err
+}
+
+type memoryRepository struct {
+	records   []*Record
+	createErr error
+}
+
+func (r *memoryRepository) Create(ctx context.Context, record *Record) error {
+	if r.createErr != nil {
+		return r.createErr
+	}
+	r.records = append(r.records, record)
+	return nil
+}
+
+func (r *memoryRepository) GetMonthlyUsage(ctx context.Context, userID int, since string) ([]MonthlyUsage, error) {
+	return nil, nil
+}
+
+func (r *memoryRepository) GetTaskUsage(ctx context.Context, userID int, month string) ([]TaskUsage, error) {
+	return nil, nil
+}
+
+func TestRecordingProvider_Generate(t *testing.T) {
+	t.Run("should_record_successful_call", func(t *testing.T) {
+		repo := &memoryRepository{}
+		inner := &stubProvider{resp: llm.GenerateResponse{
+			Tokens:           150,
+			PromptTokens:     100,
+			CompletionTokens: 50,
+			Metadata:         map[string]any{"model": "gpt-4o-mini", "provider": "openai"},
+		}}
+		provider := NewRecordingProvider(inner, repo, "gemini", nil)
+
+		ctx := ctxutil.WithUserID(context.Background(), 9)
+		resp, err := provider.Generate(ctx, llm.GenerateRequest{ResponseType: llm.ResponseTypeCoverLetter})
+
+		require.NoError(t, err)
+		assert.Equal(t, 150, resp.Tokens)
+		require.Len(t, repo.records, 1)
+		record := repo.records[0]
+		assert.Equal(t, 9, record.UserID)
+		assert.Equal(t, "cover_letter", record.TaskType)
+		assert.Equal(t, "openai", record.Provider)
+		assert.Equal(t, "gpt-4o-mini", record.Model)
+		assert.Equal(t, 100, record.PromptTokens)
+		assert.Equal(t, 50, record.CompletionTokens)
+		assert.Equal(t, 150, record.TotalTokens)
+		assert.True(t, record.Success)
+	})
+
+	t.Run("should_record_failed_call", func(t *testing.T) {
+		repo := &memoryRepository{}
+		providerErr := errors.New("boom")
+		configured := func(responseType llm.ResponseType) string {
+			assert.Equal(t, llm.ResponseTypeMatchResult, responseType)
+			return "gemini-2.5-flash"
+		}
+		provider := NewRecordingProvider(&stubProvider{err: providerErr}, repo, "gemini", configured)
+
+		_, err := provider.Generate(context.Background(), llm.GenerateRequest{ResponseType: llm.ResponseTypeMatchResult})
+
+		assert.ErrorIs(t, err, providerErr)
+		require.Len(t, repo.records, 1)
+		assert.False(t, repo.records[0].Success)
+		assert.Equal(t, 0, repo.records[0].UserID)
+		assert.Equal(t, "gemini", repo.records[0].Provider)
+		assert.Equal(t, "gemini-2.5-flash", repo.records[0].Model)
+	})
+
+	t.Run("should_not_fail_when_recording_fails", func(t *testing.T) {
+		repo := &memoryRepository{createErr: errors.New("db down")}
+		provider := NewRecordingProvider(&stubProvider{resp: llm.GenerateResponse{Data: "ok"}}, repo, "gemini", nil)
+
+		resp, err := provider.Generate(context.Background(), llm.GenerateRequest{ResponseType: llm.ResponseTypeCV})
+
+		require.NoError(t, err)
+		assert.Equal(t, "ok", resp.Data)
+	})
+}
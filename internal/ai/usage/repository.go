@@ -0,0 +1,114 @@
+package usage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Repository defines data access for the LLM usage ledger.
+type Repository interface {
+	Create(ctx context.Context, record *Record) error
+	GetMonthlyUsage(ctx context.Context, userID int, since string) ([]MonthlyUsage, error)
+	GetTaskUsage(ctx context.Context, userID int, month string) ([]TaskUsage, error)
+}
+
+// repository implements the Repository interface
+type repository struct {
+	db *sql.DB
+}
+
+// NewRepository creates a new usage repository
+func NewRepository(db *sql.DB) Repository {
+	return &repository{db: db}
+}
+
+// aggregateColumns selects the Aggregate fields in scan order
+const aggregateColumns = `
+	COUNT(*),
+	COALESCE(SUM(CASE WHEN success THEN 0 ELSE 1 END), 0),
+	COALESCE(SUM(prompt_tokens), 0),
+	COALESCE(SUM(completion_tokens), 0),
+	COALESCE(SUM(total_tokens), 0),
+	CAST(COALESCE(AVG(latency_ms), 0) AS INTEGER)`
+
+// Create inserts a usage record
+func (r *repository) Create(ctx context.Context, record *Record) error {
+	var userID any
+	if record.UserID > 0 {
+		userID = record.UserID
+	}
+
+	query := `
+		INSERT INTO llm_usage (user_id, task_type, provider, model, prompt_tokens, completion_tokens, total_tokens, latency_ms, success)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		userID, record.TaskType, record.Provider, record.Model,
+		record.PromptTokens, record.CompletionTokens, record.TotalTokens,
+		record.LatencyMs, record.Success,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record LLM usage: %w", err)
+	}
+
+	if id, err := result.LastInsertId(); err == nil {
+		record.ID = int(id)
+	}
+	return nil
+}
+
+// GetMonthlyUsage returns per-month aggregates for a user from the given month onwards, newest first
+func (r *repository) GetMonthlyUsage(ctx context.Context, userID int, since string) ([]MonthlyUsage, error) {
+	query := `
+		SELECT strftime('%Y-%m', created_at) AS month,` + aggregateColumns + `
+		FROM llm_usage
+		WHERE user_id = ? AND strftime('%Y-%m', created_at) >= ?
+		GROUP BY month
+		ORDER BY month DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get monthly LLM usage: %w", err)
+	}
+	defer rows.Close()
+
+	var months []MonthlyUsage
+	for rows.Next() {
+		var m MonthlyUsage
+		if err := rows.Scan(&m.Month, &m.Requests, &m.Failures, &m.PromptTokens, &m.CompletionTokens, &m.TotalTokens, &m.AvgLatencyMs); err != nil {
+			return nil, fmt.Errorf("failed to scan monthly LLM usage: %w", err)
+		}
+		months = append(months, m)
+	}
+	return months, rows.Err()
+}
+
+// GetTaskUsage returns per-task aggregates for a user in the given month
+func (r *repository) GetTaskUsage(ctx context.Context, userID int, month string) ([]TaskUsage, error) {
+	query := `
+		SELECT task_type,` + aggregateColumns + `
+		FROM llm_usage
+		WHERE user_id = ? AND strftime('%Y-%m', created_at) = ?
+		GROUP BY task_type
+		ORDER BY task_type
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, month)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LLM usage by task: %w", err)
+	}
+	defer rows.Close()
+
+	var tasks []TaskUsage
+	for rows.Next() {
+		var t TaskUsage
+		if err := rows.Scan(&t.TaskType, &t.Requests, &t.Failures, &t.PromptTokens, &t.CompletionTokens, &t.TotalTokens, &t.AvgLatencyMs); err != nil {
+			return nil, fmt.Errorf("failed to scan LLM usage by task: %w", err)
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks, rows.Err()
+}
@@ -0,0 +1,122 @@
+package usage
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Create(t *testing.T) {
+	tests := []struct {
+		name           string
+		record         *Record
+		expectedUserID any
+		setupErr       error
+		expectError    bool
+	}{
+		{
+			name:           "should_insert_record_for_user",
+			record:         &Record{UserID: 7, TaskType: "cover_letter", Provider: "gemini", Model: "gemini-2.5-flash", PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15, LatencyMs: 120, Success: true},
+			expectedUserID: 7,
+		},
+		{
+			name:           "should_insert_null_user_when_missing",
+			record:         &Record{TaskType: "cv_parsing", Success: false},
+			expectedUserID: nil,
+		},
+		{
+			name:           "should_return_error_when_database_fails",
+			record:         &Record{UserID: 1, TaskType: "match_result"},
+			expectedUserID: 1,
+			setupErr:       sql.ErrConnDone,
+			expectError:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			r := tt.record
+			exec := mock.ExpectExec("INSERT INTO llm_usage").
+				WithArgs(tt.expectedUserID, r.TaskType, r.Provider, r.Model, r.PromptTokens, r.CompletionTokens, r.TotalTokens, r.LatencyMs, r.Success)
+			if tt.setupErr != nil {
+				exec.WillReturnError(tt.setupErr)
+			} else {
+				exec.WillReturnResult(sqlmock.NewResult(42, 1))
+			}
+
+			err = NewRepository(db).Create(context.Background(), r)
+
+			if tt.expectError {
+				assert.ErrorContains(t, err, "failed to record LLM usage")
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, 42, r.ID)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRepository_GetMonthlyUsage(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	columns := []string{"month", "requests", "failures", "prompt_tokens", "completion_tokens", "total_tokens", "avg_latency"}
+	mock.ExpectQuery("SELECT strftime\\('%Y-%m', created_at\\) AS month").
+		WithArgs(3, "2024-01").
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("2024-02", 4, 1, 400, 200, 600, 900).
+			AddRow("2024-01", 2, 0, 100, 50, 150, 700))
+
+	months, err := NewRepository(db).GetMonthlyUsage(context.Background(), 3, "2024-01")
+
+	require.NoError(t, err)
+	require.Len(t, months, 2)
+	assert.Equal(t, "2024-02", months[0].Month)
+	assert.Equal(t, 4, months[0].Requests)
+	assert.Equal(t, 1, months[0].Failures)
+	assert.Equal(t, 600, months[0].TotalTokens)
+	assert.Equal(t, int64(700), months[1].AvgLatencyMs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_GetTaskUsage(t *testing.T) {
+	t.Run("should_group_by_task", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		columns := []string{"task_type", "requests", "failures", "prompt_tokens", "completion_tokens", "total_tokens", "avg_latency"}
+		mock.ExpectQuery("SELECT task_type").
+			WithArgs(3, "2024-02").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("cover_letter", 3, 0, 300, 150, 450, 800))
+
+		tasks, err := NewRepository(db).GetTaskUsage(context.Background(), 3, "2024-02")
+
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		assert.Equal(t, "Cover Letter", tasks[0].Label())
+		assert.Equal(t, 450, tasks[0].TotalTokens)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should_return_error_when_database_fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT task_type").WillReturnError(sql.ErrConnDone)
+
+		_, err = NewRepository(db).GetTaskUsage(context.Background(), 3, "2024-02")
+		assert.ErrorContains(t, err, "failed to get LLM usage by task")
+	})
+}
@@ -0,0 +1,65 @@
+package usage
+
+import (
+	"context"
+	"time"
+)
+
+// summaryMonths is the number of months, including the current one, shown in a Summary
+const summaryMonths = 6
+
+// Service provides aggregated views over the LLM usage ledger.
+type Service struct {
+	repo Repository
+	now  func() time.Time
+}
+
+// NewService creates a new usage service
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo, now: time.Now}
+}
+
+// GetSummary returns the user's usage for recent months and a per-task
+// breakdown of the current month.
+func (s *Service) GetSummary(ctx context.Context, userID int) (*Summary, error) {
+	now := s.now().UTC()
+	currentMonth := now.Format("2006-01")
+	since := time.Date(now.Year(), now.Month()-(summaryMonths-1), 1, 0, 0, 0, 0, time.UTC).Format("2006-01")
+
+	months, err := s.repo.GetMonthlyUsage(ctx, userID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	byTask, err := s.repo.GetTaskUsage(ctx, userID, currentMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &Summary{
+		CurrentMonth: currentMonth,
+		Months:       months,
+		ByTask:       byTask,
+	}
+	if summary.Months == nil {
+		summary.Months = []MonthlyUsage{}
+	}
+	if summary.ByTask == nil {
+		summary.ByTask = []TaskUsage{}
+	}
+
+	var latencyTotal int64
+	for _, m := range months {
+		summary.Total.Requests += m.Requests
+		summary.Total.Failures += m.Failures
+		summary.Total.PromptTokens += m.PromptTokens
+		summary.Total.CompletionTokens += m.CompletionTokens
+		summary.Total.TotalTokens += m.TotalTokens
+		latencyTotal += m.AvgLatencyMs * int64(m.Requests)
+	}
+	if summary.Total.Requests > 0 {
+		summary.Total.AvgLatencyMs = latencyTotal / int64(summary.Total.Requests)
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,77 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type summaryRepository struct {
+	memoryRepository
+	months   []MonthlyUsage
+	tasks    []TaskUsage
+	since    string
+	month    string
+	queryErr error
+}
+
+func (r *summaryRepository) GetMonthlyUsage(ctx context.Context, userID int, since string) ([]MonthlyUsage, error) {
+	r.since = since
+	return r.months, r.queryErr
+}
+
+func (r *summaryRepository) GetTaskUsage(ctx context.Context, userID int, month string) ([]TaskUsage, error) {
+	r.month = month
+	return r.tasks, nil
+}
+
+func TestService_GetSummary(t *testing.T) {
+	fixedNow := func() time.Time { return time.Date(2024, time.March, 15, 10, 0, 0, 0, time.UTC) }
+
+	t.Run("should_aggregate_totals", func(t *testing.T) {
+		repo := &summaryRepository{
+			months: []MonthlyUsage{
+				{Month: "2024-03", Aggregate: Aggregate{Requests: 3, Failures: 1, PromptTokens: 300, CompletionTokens: 100, TotalTokens: 400, AvgLatencyMs: 1000}},
+				{Month: "2024-02", Aggregate: Aggregate{Requests: 1, PromptTokens: 50, CompletionTokens: 50, TotalTokens: 100, AvgLatencyMs: 600}},
+			},
+			tasks: []TaskUsage{{TaskType: "cover_letter", Aggregate: Aggregate{Requests: 3}}},
+		}
+		service := NewService(repo)
+		service.now = fixedNow
+
+		summary, err := service.GetSummary(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, "2023-10", repo.since)
+		assert.Equal(t, "2024-03", repo.month)
+		assert.Equal(t, "2024-03", summary.CurrentMonth)
+		assert.Equal(t, 4, summary.Total.Requests)
+		assert.Equal(t, 1, summary.Total.Failures)
+		assert.Equal(t, 500, summary.Total.TotalTokens)
+		assert.Equal(t, int64(900), summary.Total.AvgLatencyMs)
+		assert.Len(t, summary.ByTask, 1)
+	})
+
+	t.Run("should_return_empty_slices_without_usage", func(t *testing.T) {
+		service := NewService(&summaryRepository{})
+		service.now = fixedNow
+
+		summary, err := service.GetSummary(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.NotNil(t, summary.Months)
+		assert.NotNil(t, summary.ByTask)
+		assert.Equal(t, int64(0), summary.Total.AvgLatencyMs)
+	})
+
+	t.Run("should_return_repository_error", func(t *testing.T) {
+		service := NewService(&summaryRepository{queryErr: errors.New("db down")})
+
+		_, err := service.GetSummary(context.Background(), 1)
+		assert.Error(t, err)
+	})
+}
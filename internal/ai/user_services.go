@@ -0,0 +1,133 @@
+package ai
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/benidevo/vega/internal/ai/credentials"
+	"github.com/benidevo/vega/internal/ai/llm"
+	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/benidevo/vega/internal/common/logger"
+	"github.com/benidevo/vega/internal/config"
+)
+
+// keyVerifier is implemented by providers that can check their API key
+// without generating content.
+type keyVerifier interface {
+	VerifyAPIKey(ctx context.Context) error
+}
+
+// VerifyAPIKey checks that apiKey is accepted by the named provider.
+func VerifyAPIKey(ctx context.Context, cfg *config.Settings, provider, apiKey string) error {
+	p, err := createKeyedProvider(cfg, provider, apiKey)
+	if err != nil {
+		return err
+	}
+
+	if verifier, ok := p.(keyVerifier); ok {
+		return verifier.VerifyAPIKey(ctx)
+	}
+	return nil
+}
+
+// createKeyedProvider builds the named provider with apiKey in place of the
+// server's key.
+func createKeyedProvider(cfg *config.Settings, name, apiKey string) (llm.Provider, error) {
+	keyed := *cfg
+	switch name {
+	case ProviderGemini:
+		keyed.GeminiAPIKey = apiKey
+	case ProviderOpenAI:
+		keyed.OpenAIAPIKey = apiKey
+	default:
+		return nil, models.WrapError(models.ErrUnsupportedProvider, fmt.Errorf("provider '%s' does not accept user keys", name))
+	}
+	return createNamedProvider(&keyed, name)
+}
+
+// SetupCredentials creates the service that stores users' own provider keys,
+// encrypted with cfg.CredentialsEncryptionKey.
+func SetupCredentials(db *sql.DB, cfg *config.Settings) (*credentials.Service, error) {
+	cipher, err := credentials.NewCipher(cfg.CredentialsEncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	verify := func(ctx context.Context, provider, apiKey string) error {
+		return VerifyAPIKey(ctx, cfg, provider, apiKey)
+	}
+	return credentials.NewService(credentials.NewRepository(db), cipher, verify), nil
+}
+
+// UserServices builds AI services backed by users' own provider keys.
+// Services are reused until the user's key changes.
+type UserServices struct {
+	cfg         *config.Settings
+	credentials *credentials.Service
+	options     setupOptions
+	log         *logger.PrivacyLogger
+
+	mu       sync.Mutex
+	services map[int]*userService
+}
+
+type userService struct {
+	provider string
+	apiKey   string
+	service  *AIService
+}
+
+// NewUserServices creates a per-user AI service factory. opts apply to every
+// service it builds, as they do for Setup.
+func NewUserServices(cfg *config.Settings, creds *credentials.Service, opts ...Option) *UserServices {
+	return &UserServices{
+		cfg:         cfg,
+		credentials: creds,
+		options:     newSetupOptions(opts),
+		log:         logger.GetPrivacyLogger("ai_setup"),
+		services:    make(map[int]*userService),
+	}
+}
+
+// ForUser returns an AI service that uses the user's own key. The boolean is
+// false when the user has no usable key and the server's service applies.
+func (u *UserServices) ForUser(ctx context.Context, userID int) (*AIService, bool, error) {
+	provider, apiKey, err := u.credentials.APIKey(ctx, userID)
+	if errors.Is(err, credentials.ErrCredentialNotFound) {
+		u.forget(userID)
+		return nil, false, nil
+	}
+	if errors.Is(err, credentials.ErrDecryptionFailed) {
+		u.log.Warn().Err(err).Int("user_id", userID).Msg("Ignoring unreadable user AI provider key")
+		u.forget(userID)
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if cached, ok := u.services[userID]; ok && cached.provider == provider && cached.apiKey == apiKey {
+		return cached.service, true, nil
+	}
+
+	p, err := createKeyedProvider(u.cfg, provider, apiKey)
+	if err != nil {
+		return nil, false, models.WrapError(models.ErrProviderInitFailed, err)
+	}
+
+	service := u.options.newService(u.cfg, p, provider, modelResolver(u.cfg, []string{provider}))
+	u.services[userID] = &userService{provider: provider, apiKey: apiKey, service: service}
+	return service, true, nil
+}
+
+func (u *UserServices) forget(userID int) {
+	u.mu.Lock()
+	delete(u.services, userID)
+	u.mu.Unlock()
+}
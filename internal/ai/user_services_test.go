@@ -0,0 +1,93 @@
+package ai
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/benidevo/vega/internal/ai/credentials"
+	"github.com/benidevo/vega/internal/ai/models"
+	"github.com/benidevo/vega/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryCredentials struct {
+	credentials map[int]credentials.Credential
+}
+
+func (m *memoryCredentials) Get(ctx context.Context, userID int) (*credentials.Credential, error) {
+	c, ok := m.credentials[userID]
+	if !ok {
+		return nil, credentials.ErrCredentialNotFound
+	}
+	return &c, nil
+}
+
+func (m *memoryCredentials) Upsert(ctx context.Context, credential *credentials.Credential) error {
+	m.credentials[credential.UserID] = *credential
+	return nil
+}
+
+func (m *memoryCredentials) Delete(ctx context.Context, userID int) error {
+	delete(m.credentials, userID)
+	return nil
+}
+
+func TestVerifyAPIKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer good-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": []}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Settings{OpenAIBaseURL: server.URL}
+
+	assert.NoError(t, VerifyAPIKey(context.Background(), cfg, ProviderOpenAI, "good-key"))
+	assert.Error(t, VerifyAPIKey(context.Background(), cfg, ProviderOpenAI, "bad-key"))
+
+	err := VerifyAPIKey(context.Background(), cfg, ProviderOllama, "key")
+	assert.ErrorIs(t, err, models.ErrUnsupportedProvider)
+}
+
+func TestUserServices_ForUser(t *testing.T) {
+	cfg := &config.Settings{OpenAIBaseURL: "http://localhost:8000/v1", CredentialsEncryptionKey: "secret"}
+	cipher, err := credentials.NewCipher(cfg.CredentialsEncryptionKey)
+	require.NoError(t, err)
+	creds := credentials.NewService(&memoryCredentials{credentials: map[int]credentials.Credential{}}, cipher, nil)
+	services := NewUserServices(cfg, creds)
+	ctx := context.Background()
+
+	service, ok, err := services.ForUser(ctx, 7)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Nil(t, service)
+
+	_, err = creds.Save(ctx, 7, ProviderOpenAI, "sk-first")
+	require.NoError(t, err)
+
+	first, ok, err := services.ForUser(ctx, 7)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	require.NotNil(t, first)
+
+	again, _, err := services.ForUser(ctx, 7)
+	require.NoError(t, err)
+	assert.Same(t, first, again, "the service is reused while the key is unchanged")
+
+	_, err = creds.Save(ctx, 7, ProviderOpenAI, "sk-second")
+	require.NoError(t, err)
+
+	rotated, _, err := services.ForUser(ctx, 7)
+	require.NoError(t, err)
+	assert.NotSame(t, first, rotated)
+
+	require.NoError(t, creds.Remove(ctx, 7))
+	_, ok, err = services.ForUser(ctx, 7)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -49,6 +49,11 @@ func (v *AIRequestValidator) validateBasicFields(req models.Request) error {
 			fmt.Errorf("job description is required"))
 	}
 
+	if req.Language != "" && !models.IsValidLanguage(req.Language) {
+		return models.WrapError(models.ErrValidationFailed,
+			fmt.Errorf("unsupported language %q", req.Language))
+	}
+
 	return nil
 }
 
@@ -50,6 +50,26 @@ func TestAIRequestValidator_ValidateRequest(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "supported language",
+			request: models.Request{
+				ApplicantName:    "John Doe",
+				ApplicantProfile: "Software Engineer",
+				JobDescription:   "Backend Developer role",
+				Language:         "de",
+			},
+			expectError: false,
+		},
+		{
+			name: "unsupported language",
+			request: models.Request{
+				ApplicantName:    "John Doe",
+				ApplicantProfile: "Software Engineer",
+				JobDescription:   "Backend Developer role",
+				Language:         "xx",
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
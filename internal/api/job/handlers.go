@@ -1,7 +1,9 @@
 package job
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 
 	apimodels "github.com/benidevo/vega/internal/api/job/models"
 	ctxutil "github.com/benidevo/vega/internal/common/context"
@@ -45,6 +47,84 @@ func (h *JobAPIHandler) CreateJob(c *gin.Context) {
 		return
 	}
 
+	// Get context with role information
+	ctx := c.Request.Context()
+	if roleValue, exists := c.Get("role"); exists {
+		if role, ok := roleValue.(string); ok {
+			ctx = ctxutil.WithRole(ctx, role)
+		}
+	}
+
+	// Fill the fields the caller left out from the job posting, if one was
+	// sent. A posting that looks like it contains instructions for the AI is
+	// never extracted; only its text is kept, as the description to review.
+	var postingFlagged bool
+	if req.HasRawText() {
+		draft, flagged := h.jobService.ScreenPosting(req.RawText)
+		postingFlagged = flagged
+		var err error
+		if !flagged {
+			draft, err = h.jobService.ExtractJobDraft(ctx, userID, req.RawText)
+		}
+		if err != nil {
+			h.jobService.LogError(err)
+			switch {
+			case errors.Is(err, models.ErrNotJobPosting),
+				errors.Is(err, models.ErrPostingTextTooLong):
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": models.GetSentinelError(err).Error(),
+				})
+			case errors.Is(err, models.ErrAIServiceUnavailable):
+				c.JSON(http.StatusServiceUnavailable, gin.H{
+					"error": "Job extraction is not available; send the job fields instead of raw_text",
+				})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{
+					"error": "Failed to extract job details",
+				})
+			}
+			return
+		}
+		req.ApplyDraft(draft)
+	}
+
+	if err := req.Validate(); err != nil {
+		h.jobService.LogError(err)
+		if postingFlagged {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":       "The job posting needs review before it can be extracted; send the title and company to save it",
+				"needsReview": true,
+			})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	// Jobs are saved in the first stage of the pipeline unless the request
+	// names one
+	statusOption := models.WithStatus(models.INTERESTED)
+	if req.Stage != "" {
+		pipeline, err := h.jobService.GetPipeline(ctx, userID)
+		if err != nil {
+			h.jobService.LogError(err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to create job",
+			})
+			return
+		}
+		stage, err := pipeline.StageFromString(req.Stage)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		statusOption = models.WithStage(stage)
+	}
+
 	jobType := models.JobTypeFromString(req.JobType)
 
 	jobOptions := []models.JobOption{
@@ -53,15 +133,13 @@ func (h *JobAPIHandler) CreateJob(c *gin.Context) {
 		models.WithSourceURL(req.SourceURL),
 		models.WithApplicationURL(req.ApplicationURL),
 		models.WithNotes(req.Notes),
-		models.WithStatus(models.INTERESTED),
+		statusOption,
 	}
-
-	// Get context with role information
-	ctx := c.Request.Context()
-	if roleValue, exists := c.Get("role"); exists {
-		if role, ok := roleValue.(string); ok {
-			ctx = ctxutil.WithRole(ctx, role)
-		}
+	if len(req.Skills) > 0 {
+		jobOptions = append(jobOptions, models.WithRequiredSkills(req.Skills))
+	}
+	if len(req.Tags) > 0 {
+		jobOptions = append(jobOptions, models.WithTags(req.Tags))
 	}
 
 	createdJob, isNew, err := h.jobService.CreateJob(
@@ -77,7 +155,11 @@ func (h *JobAPIHandler) CreateJob(c *gin.Context) {
 		switch err {
 		case models.ErrJobTitleRequired,
 			models.ErrJobDescriptionRequired,
-			models.ErrCompanyRequired:
+			models.ErrCompanyRequired,
+			models.ErrTagTooLong,
+			models.ErrTooManyTags,
+			models.ErrStageNotFound,
+			models.ErrInvalidJobStatus:
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error": err.Error(),
 			})
@@ -117,12 +199,131 @@ func (h *JobAPIHandler) CreateJob(c *gin.Context) {
 		}
 	}
 
+	// Captured descriptions come from arbitrary web pages, so they are
+	// screened for instructions aimed at the AI before any AI task runs
+	var needsReview bool
+	if isNew {
+		review, err := h.jobService.ScreenJobDescription(ctx, userID, createdJob)
+		if err != nil {
+			// Log the error but don't fail the job creation
+			h.jobService.LogError(err)
+		}
+		needsReview = review.BlocksAI()
+	}
+
 	c.JSON(http.StatusOK, apimodels.CreateJobResponse{
-		Message: "Job created successfully",
-		JobID:   createdJob.ID,
+		Message:     "Job created successfully",
+		JobID:       createdJob.ID,
+		NeedsReview: needsReview,
 	})
 }
 
+// ListJobs returns a page of the user's jobs. The search parameter runs a
+// full-text search over the jobs, with the best matches first unless another
+// sort order is requested. The status parameter takes a pipeline stage and the
+// category parameter a status, covering all its stages. The tags parameter, a
+// comma-separated list, limits the jobs to those with any of the tags, or all
+// of them with match=all.
+func (h *JobAPIHandler) ListJobs(c *gin.Context) {
+	userIDValue, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Unauthorized",
+		})
+		return
+	}
+	userID := userIDValue.(int)
+
+	page := 1
+	if p, err := models.ParsePositiveInt(c.DefaultQuery("page", "1")); err == nil && p > 0 {
+		page = p
+	}
+	limit := 20
+	if l, err := models.ParsePositiveInt(c.DefaultQuery("limit", "20")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+
+	filter := models.JobFilter{
+		Query:     strings.TrimSpace(c.Query("search")),
+		Limit:     limit,
+		Offset:    (page - 1) * limit,
+		SortBy:    c.DefaultQuery("sort", "updated_at"),
+		SortOrder: c.DefaultQuery("order", "desc"),
+	}
+	if filter.Query != "" && c.Query("sort") == "" {
+		filter.SortBy = "relevance"
+	}
+
+	// The status parameter is a stage of the user's pipeline, or a status for
+	// all the stages of that category, as is the category parameter
+	if status := c.Query("status"); status != "" {
+		pipeline, err := h.jobService.GetPipeline(c.Request.Context(), userID)
+		if err != nil {
+			h.jobService.LogError(err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to get jobs",
+			})
+			return
+		}
+		if stage := pipeline.FindStage(status); stage != nil && stage.ID > 0 {
+			filter.StageID = &stage.ID
+		} else if stage != nil {
+			filter.Status = &stage.Category
+		} else {
+			jobStatus, err := models.JobStatusFromString(status)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": err.Error(),
+				})
+				return
+			}
+			filter.Status = &jobStatus
+		}
+	}
+	if category := c.Query("category"); category != "" {
+		jobStatus, err := models.JobStatusFromString(category)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		filter.Status = &jobStatus
+	}
+
+	tags, err := models.NormalizeTags(models.ParseTags(c.Query("tags")))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if len(tags) > 0 {
+		filter.Tags = tags
+		filter.TagMatch = models.TagMatchFromString(c.Query("match"))
+	}
+
+	result, err := h.jobService.GetJobsWithPagination(c.Request.Context(), userID, filter)
+	if err != nil {
+		h.jobService.LogError(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get jobs",
+		})
+		return
+	}
+
+	response := apimodels.ListJobsResponse{
+		Jobs:       make([]apimodels.JobSummary, len(result.Jobs)),
+		Page:       result.Pagination.CurrentPage,
+		TotalPages: result.Pagination.TotalPages,
+		Total:      result.Pagination.TotalItems,
+	}
+	for i, job := range result.Jobs {
+		response.Jobs[i] = apimodels.NewJobSummary(job)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
 // GetQuotaStatus returns the current quota status for the authenticated user
 func (h *JobAPIHandler) GetQuotaStatus(c *gin.Context) {
 	userIDValue, exists := c.Get("userID")
@@ -11,9 +11,14 @@ import (
 type jobService interface {
 	CreateJob(ctx context.Context, userID int, title, description, companyName string, options ...models.JobOption) (*models.Job, bool, error)
 	GetJob(ctx context.Context, userID int, jobID int) (*models.Job, error)
+	GetJobsWithPagination(ctx context.Context, userID int, filter models.JobFilter) (*models.JobsWithPagination, error)
 	UpdateJob(ctx context.Context, userID int, job *models.Job) error
 	DeleteJob(ctx context.Context, userID int, jobID int) error
 	GetQuotaStatus(ctx context.Context, userID int) (*quota.QuotaStatus, error)
+	GetPipeline(ctx context.Context, userID int) (models.Pipeline, error)
+	ScreenPosting(rawText string) (*models.JobDraft, bool)
+	ExtractJobDraft(ctx context.Context, userID int, rawText string) (*models.JobDraft, error)
+	ScreenJobDescription(ctx context.Context, userID int, job *models.Job) (*models.InjectionReview, error)
 	LogError(err error)
 }
 
@@ -1,19 +1,138 @@
 package models
 
-// CreateJobRequest represents the request payload for creating a job
+import (
+	"errors"
+	"strings"
+	"time"
+
+	jobmodels "github.com/benidevo/vega/internal/job/models"
+)
+
+// CreateJobRequest represents the request payload for creating a job.
+// Title, company, location and description may be left out when RawText
+// holds the job posting; they are then extracted from it.
 type CreateJobRequest struct {
-	Title          string `json:"title" binding:"required"`
-	Company        string `json:"company" binding:"required"`
-	Location       string `json:"location" binding:"required"`
-	Description    string `json:"description" binding:"required"`
-	JobType        string `json:"jobType,omitempty"`
-	ApplicationURL string `json:"applicationUrl,omitempty"`
-	SourceURL      string `json:"sourceUrl" binding:"required"`
-	Notes          string `json:"notes,omitempty"`
+	Title          string   `json:"title"`
+	Company        string   `json:"company"`
+	Location       string   `json:"location"`
+	Description    string   `json:"description"`
+	JobType        string   `json:"jobType,omitempty"`
+	Skills         []string `json:"skills,omitempty"`
+	ApplicationURL string   `json:"applicationUrl,omitempty"`
+	SourceURL      string   `json:"sourceUrl" binding:"required"`
+	Notes          string   `json:"notes,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+	Stage          string   `json:"stage,omitempty"`    // Pipeline stage, or a status for its first stage
+	RawText        string   `json:"raw_text,omitempty"` // Posting text or page HTML
+}
+
+// HasRawText reports whether the request carries a job posting to extract
+// the job from.
+func (r *CreateJobRequest) HasRawText() bool {
+	return strings.TrimSpace(r.RawText) != ""
+}
+
+// Validate checks that the fields needed to create a job are present. Without
+// raw text the location is required as well; a posting may not state one.
+func (r *CreateJobRequest) Validate() error {
+	var missing []string
+	if strings.TrimSpace(r.Title) == "" {
+		missing = append(missing, "title")
+	}
+	if strings.TrimSpace(r.Company) == "" {
+		missing = append(missing, "company")
+	}
+	if strings.TrimSpace(r.Location) == "" && !r.HasRawText() {
+		missing = append(missing, "location")
+	}
+	if strings.TrimSpace(r.Description) == "" {
+		missing = append(missing, "description")
+	}
+	if len(missing) > 0 {
+		return errors.New("missing " + strings.Join(missing, ", ") + "; provide them or raw_text")
+	}
+	if _, err := jobmodels.NormalizeTags(r.Tags); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ApplyDraft fills the fields left empty in the request from a job extracted
+// from its raw text. Values sent by the caller are kept.
+func (r *CreateJobRequest) ApplyDraft(draft *jobmodels.JobDraft) {
+	if strings.TrimSpace(r.Title) == "" {
+		r.Title = draft.Title
+	}
+	if strings.TrimSpace(r.Company) == "" {
+		r.Company = draft.Company
+	}
+	if strings.TrimSpace(r.Location) == "" {
+		r.Location = draft.Location
+	}
+	if strings.TrimSpace(r.Description) == "" {
+		r.Description = draft.Description
+	}
+	if r.JobType == "" {
+		r.JobType = draft.JobType.FormValue()
+	}
+	if len(r.Skills) == 0 {
+		r.Skills = draft.RequiredSkills
+	}
+	if r.ApplicationURL == "" {
+		r.ApplicationURL = draft.ApplicationURL
+	}
+	if r.Notes == "" {
+		r.Notes = draft.Notes()
+	}
 }
 
 // CreateJobResponse represents the response after creating a job
 type CreateJobResponse struct {
 	Message string `json:"message"`
 	JobID   int    `json:"jobId,omitempty"`
+	// NeedsReview is set when the description looks like it contains
+	// instructions for the AI and has to be reviewed before AI features run.
+	NeedsReview bool `json:"needsReview,omitempty"`
+}
+
+// JobSummary is a job in a list of jobs
+type JobSummary struct {
+	ID         int       `json:"id"`
+	Title      string    `json:"title"`
+	Company    string    `json:"company"`
+	Location   string    `json:"location,omitempty"`
+	Status     string    `json:"status"`
+	Category   string    `json:"category"`
+	MatchScore *int      `json:"matchScore,omitempty"`
+	SourceURL  string    `json:"sourceUrl,omitempty"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+	Tags       []string  `json:"tags,omitempty"`
+	// Snippet is the part of the job that matched the search, with the
+	// matched terms marked. It is only set when searching.
+	Snippet []jobmodels.SnippetSegment `json:"snippet,omitempty"`
+}
+
+// NewJobSummary creates the list entry of a job
+func NewJobSummary(job *jobmodels.Job) JobSummary {
+	return JobSummary{
+		ID:         job.ID,
+		Title:      job.Title,
+		Company:    job.Company.Name,
+		Location:   job.Location,
+		Status:     job.StageName(),
+		Category:   job.Status.FormValue(),
+		MatchScore: job.MatchScore,
+		SourceURL:  job.SourceURL,
+		UpdatedAt:  job.UpdatedAt,
+		Tags:       job.Tags,
+		Snippet:    job.SearchSnippet,
+	}
+}
+
+// ListJobsResponse represents a page of the user's jobs
+type ListJobsResponse struct {
+	Jobs       []JobSummary `json:"jobs"`
+	Page       int          `json:"page"`
+	TotalPages int          `json:"totalPages"`
+	Total      int          `json:"total"`
 }
@@ -8,6 +8,7 @@ import (
 func RegisterRoutes(router *gin.RouterGroup, handler *JobAPIHandler) {
 	jobRoutes := router.Group("")
 	{
+		jobRoutes.GET("", handler.ListJobs)
 		jobRoutes.POST("", handler.CreateJob)
 		jobRoutes.GET("/quota", handler.GetQuotaStatus)
 	}
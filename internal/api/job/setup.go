@@ -1,17 +1,12 @@
 package job
 
 import (
-	"database/sql"
-
-	"github.com/benidevo/vega/internal/cache"
-	"github.com/benidevo/vega/internal/config"
 	"github.com/benidevo/vega/internal/job"
 	"github.com/benidevo/vega/internal/quota"
 )
 
-// Setup initializes the job API module with its dependencies
-func Setup(db *sql.DB, cfg *config.Settings, cache cache.Cache, quotaService *quota.UnifiedService) *JobAPIHandler {
-	jobService := job.SetupService(db, cfg, cache)
-
+// Setup initializes the job API module around the job service shared with
+// the web handlers
+func Setup(jobService *job.JobService, quotaService *quota.UnifiedService) *JobAPIHandler {
 	return NewJobAPIHandler(jobService, quotaService)
 }
@@ -10,6 +10,8 @@ type contextKey string
 const (
 	// UserRoleKey is the context key for user role
 	UserRoleKey contextKey = "userRole"
+	// UserIDKey is the context key for the authenticated user's ID
+	UserIDKey contextKey = "userID"
 )
 
 // WithRole adds the user role to the context
@@ -22,3 +24,14 @@ func GetRole(ctx context.Context) (string, bool) {
 	role, ok := ctx.Value(UserRoleKey).(string)
 	return role, ok
 }
+
+// WithUserID adds the authenticated user's ID to the context
+func WithUserID(ctx context.Context, userID int) context.Context {
+	return context.WithValue(ctx, UserIDKey, userID)
+}
+
+// GetUserID retrieves the authenticated user's ID from the context
+func GetUserID(ctx context.Context) (int, bool) {
+	userID, ok := ctx.Value(UserIDKey).(int)
+	return userID, ok
+}
@@ -150,6 +150,31 @@ func TestConcurrentAccess(t *testing.T) {
 	})
 }
 
+func TestWithUserID(t *testing.T) {
+	t.Run("should_round_trip_user_id", func(t *testing.T) {
+		ctx := WithUserID(context.Background(), 42)
+
+		userID, ok := GetUserID(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, 42, userID)
+	})
+
+	t.Run("should_return_false_when_user_id_missing", func(t *testing.T) {
+		userID, ok := GetUserID(context.Background())
+		assert.False(t, ok)
+		assert.Equal(t, 0, userID)
+	})
+
+	t.Run("should_not_collide_with_role", func(t *testing.T) {
+		ctx := WithRole(WithUserID(context.Background(), 7), "admin")
+
+		userID, _ := GetUserID(ctx)
+		role, _ := GetRole(ctx)
+		assert.Equal(t, 7, userID)
+		assert.Equal(t, "admin", role)
+	})
+}
+
 func BenchmarkWithRole(b *testing.B) {
 	ctx := context.Background()
 	b.ResetTimer()
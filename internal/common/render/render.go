@@ -21,12 +21,14 @@ func NewHTMLRenderer(cfg *config.Settings) *HTMLRenderer {
 func (r *HTMLRenderer) BaseTemplateData(c *gin.Context) gin.H {
 	username, _ := c.Get("username")
 	csrfToken, _ := c.Get("csrfToken")
+	role, _ := c.Get("role")
 	return gin.H{
 		"currentYear":         time.Now().Year(),
 		"securityPageEnabled": true, // Always enabled
 		"username":            username,
 		"isCloudMode":         r.cfg.IsCloudMode,
 		"csrfToken":           csrfToken,
+		"isAdmin":             role == "Admin",
 	}
 }
 
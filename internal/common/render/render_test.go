@@ -49,6 +49,7 @@ func TestBaseTemplateData(t *testing.T) {
 			assert.Nil(t, data["username"])
 			assert.Equal(t, false, data["isCloudMode"])
 			assert.Nil(t, data["csrfToken"])
+			assert.Equal(t, false, data["isAdmin"])
 		})
 
 		router.ServeHTTP(w, c.Request)
@@ -64,12 +65,14 @@ func TestBaseTemplateData(t *testing.T) {
 		router.GET("/", func(ctx *gin.Context) {
 			ctx.Set("username", "testuser")
 			ctx.Set("csrfToken", "test-csrf-token")
+			ctx.Set("role", "Admin")
 
 			data := renderer.BaseTemplateData(ctx)
 
 			assert.Equal(t, "testuser", data["username"])
 			assert.Equal(t, "test-csrf-token", data["csrfToken"])
 			assert.Equal(t, true, data["isCloudMode"])
+			assert.Equal(t, true, data["isAdmin"])
 		})
 
 		router.ServeHTTP(w, c.Request)
@@ -0,0 +1,104 @@
+package textdiff
+
+import "regexp"
+
+// Op is the kind of change a diff segment represents.
+type Op string
+
+const (
+	Equal  Op = "equal"
+	Insert Op = "insert"
+	Delete Op = "delete"
+)
+
+// Segment is a run of text that was kept, inserted or deleted.
+type Segment struct {
+	Op   Op
+	Text string
+}
+
+// IsInsert reports whether the segment was added.
+func (s Segment) IsInsert() bool { return s.Op == Insert }
+
+// IsDelete reports whether the segment was removed.
+func (s Segment) IsDelete() bool { return s.Op == Delete }
+
+// maxCells limits the size of the comparison table. Texts that would need a
+// bigger table are shown as one deletion followed by one insertion.
+const maxCells = 4_000_000
+
+var tokenPattern = regexp.MustCompile(`\s+|[^\s]+`)
+
+// Words returns a word-level diff that turns before into after. Whitespace is
+// compared like words so line breaks show up as changes, and adjacent
+// segments with the same Op are merged.
+func Words(before, after string) []Segment {
+	a := tokenPattern.FindAllString(before, -1)
+	b := tokenPattern.FindAllString(after, -1)
+
+	if len(a)*len(b) > maxCells {
+		var segments []Segment
+		segments = appendSegment(segments, Delete, before)
+		return appendSegment(segments, Insert, after)
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var segments []Segment
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			segments = appendSegment(segments, Equal, a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			segments = appendSegment(segments, Delete, a[i])
+			i++
+		default:
+			segments = appendSegment(segments, Insert, b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		segments = appendSegment(segments, Delete, a[i])
+	}
+	for ; j < len(b); j++ {
+		segments = appendSegment(segments, Insert, b[j])
+	}
+	return segments
+}
+
+// Changed reports whether the diff contains any insertions or deletions.
+func Changed(segments []Segment) bool {
+	for _, segment := range segments {
+		if segment.Op != Equal {
+			return true
+		}
+	}
+	return false
+}
+
+func appendSegment(segments []Segment, op Op, text string) []Segment {
+	if text == "" {
+		return segments
+	}
+	if n := len(segments); n > 0 && segments[n-1].Op == op {
+		segments[n-1].Text += text
+		return segments
+	}
+	return append(segments, Segment{Op: op, Text: text})
+}
@@ -0,0 +1,43 @@
+package textdiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWords(t *testing.T) {
+	t.Run("should_mark_replaced_words", func(t *testing.T) {
+		segments := Words("Improved checkout performance", "Cut checkout latency by 60%")
+
+		assert.Equal(t, []Segment{
+			{Op: Delete, Text: "Improved"},
+			{Op: Insert, Text: "Cut"},
+			{Op: Equal, Text: " checkout "},
+			{Op: Delete, Text: "performance"},
+			{Op: Insert, Text: "latency by 60%"},
+		}, segments)
+		assert.True(t, Changed(segments))
+	})
+
+	t.Run("should_keep_line_breaks", func(t *testing.T) {
+		segments := Words("• Led a team\n• Shipped v2", "• Led a team")
+
+		assert.Equal(t, []Segment{
+			{Op: Equal, Text: "• Led a team"},
+			{Op: Delete, Text: "\n• Shipped v2"},
+		}, segments)
+	})
+
+	t.Run("should_report_unchanged_text", func(t *testing.T) {
+		segments := Words("Go, SQL", "Go, SQL")
+
+		assert.Equal(t, []Segment{{Op: Equal, Text: "Go, SQL"}}, segments)
+		assert.False(t, Changed(segments))
+	})
+
+	t.Run("should_handle_empty_text", func(t *testing.T) {
+		assert.Equal(t, []Segment{{Op: Insert, Text: "New text"}}, Words("", "New text"))
+		assert.Empty(t, Words("", ""))
+	})
+}
@@ -16,6 +16,7 @@ func TestNewSettings(t *testing.T) {
 		"DB_CONNECTION_STRING", "LOG_LEVEL", "ACCESS_TOKEN_EXPIRY", "REFRESH_TOKEN_EXPIRY",
 		"GOOGLE_OAUTH_ENABLED", "CORS_ALLOWED_ORIGINS", "ENABLE_SECURITY_HEADERS",
 		"AI_PROVIDER", "GEMINI_API_KEY", "CACHE_MAX_MEMORY_MB", "CACHE_DEFAULT_TTL",
+		"TOKEN_SECRET", "CREDENTIALS_ENCRYPTION_KEY",
 	}
 	for _, env := range envVars {
 		os.Unsetenv(env)
@@ -132,6 +133,25 @@ func TestNewSettings(t *testing.T) {
 				assert.Equal(t, "test-secret", s.GoogleClientSecret)
 			},
 		},
+		{
+			name: "should_not_reuse_token_secret_for_credentials_encryption",
+			setup: func() {
+				os.Setenv("TOKEN_SECRET", "token-secret")
+			},
+			validate: func(t *testing.T, s Settings) {
+				assert.Equal(t, "token-secret", s.TokenSecret)
+				assert.Empty(t, s.CredentialsEncryptionKey)
+			},
+		},
+		{
+			name: "should_read_credentials_encryption_key_from_env",
+			setup: func() {
+				os.Setenv("CREDENTIALS_ENCRYPTION_KEY", "credentials-secret")
+			},
+			validate: func(t *testing.T, s Settings) {
+				assert.Equal(t, "credentials-secret", s.CredentialsEncryptionKey)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -394,3 +414,78 @@ func TestSettingsWithFileEnvVars(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAIProviders(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		expected []string
+	}{
+		{
+			name:     "should_return_nil_when_no_env",
+			envValue: "",
+			expected: nil,
+		},
+		{
+			name:     "should_parse_ordered_list",
+			envValue: "gemini, ollama ,openai",
+			expected: []string{"gemini", "ollama", "openai"},
+		},
+		{
+			name:     "should_skip_empty_entries",
+			envValue: "gemini,,",
+			expected: []string{"gemini"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.envValue != "" {
+				os.Setenv("AI_PROVIDERS", tt.envValue)
+				defer os.Unsetenv("AI_PROVIDERS")
+			}
+
+			assert.Equal(t, tt.expected, getAIProviders())
+		})
+	}
+}
+
+func TestGetAICacheTTL(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		expected time.Duration
+	}{
+		{
+			name:     "should_return_default_when_no_env",
+			envValue: "",
+			expected: time.Hour,
+		},
+		{
+			name:     "should_parse_duration",
+			envValue: "15m",
+			expected: 15 * time.Minute,
+		},
+		{
+			name:     "should_allow_zero_to_disable_caching",
+			envValue: "0s",
+			expected: 0,
+		},
+		{
+			name:     "should_return_default_when_invalid",
+			envValue: "-5m",
+			expected: time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.envValue != "" {
+				os.Setenv("AI_CACHE_TTL_JOB_ANALYSIS", tt.envValue)
+				defer os.Unsetenv("AI_CACHE_TTL_JOB_ANALYSIS")
+			}
+
+			assert.Equal(t, tt.expected, getAICacheTTL("AI_CACHE_TTL_JOB_ANALYSIS", time.Hour))
+		})
+	}
+}
@@ -32,6 +32,11 @@ type Settings struct {
 	CookieSecure       bool
 	CookieSameSite     string
 
+	// CredentialsEncryptionKey encrypts users' own AI provider keys at rest.
+	// Users cannot save their own keys unless it is set. Changing it makes
+	// previously stored keys unreadable.
+	CredentialsEncryptionKey string
+
 	GoogleOAuthEnabled      bool
 	GoogleClientID          string
 	GoogleClientSecret      string
@@ -48,12 +53,56 @@ type Settings struct {
 	ResetAdminPassword bool
 
 	AIProvider             string
+	AIProviders            []string      // Ordered fallback chain; overrides AIProvider when set
+	AIBreakerThreshold     int           // Consecutive failures before a provider's circuit opens
+	AIBreakerOpenTimeout   time.Duration // How long an open circuit waits before a trial request
 	GeminiAPIKey           string
 	GeminiModel            string
 	GeminiModelCVParsing   string // Fast model for CV parsing
 	GeminiModelJobAnalysis string // Advanced model for job analysis
 	GeminiModelCoverLetter string // Advanced model for cover letter generation
 
+	// OpenAI-compatible provider (OpenAI, vLLM, llama.cpp, ...)
+	OpenAIBaseURL          string
+	OpenAIAPIKey           string
+	OpenAIModel            string
+	OpenAIModelCVParsing   string
+	OpenAIModelJobAnalysis string
+	OpenAIModelCoverLetter string
+
+	// Ollama provider for fully local inference
+	OllamaBaseURL          string
+	OllamaModel            string
+	OllamaModelCVParsing   string
+	OllamaModelJobAnalysis string
+	OllamaModelCoverLetter string
+
+	// Replay provider for offline demos and tests
+	AIReplayMode       string // record, replay or synthetic
+	AIReplayFixtureDir string
+	AIReplayUpstream   string // Provider wrapped in record mode
+
+	// AI response cache, keyed by prompt, model, temperature and response type
+	AICacheEnabled           bool
+	AICacheTTLJobAnalysis    time.Duration
+	AICacheTTLCoverLetter    time.Duration
+	AICacheTTLCVParsing      time.Duration
+	AICacheTTLCVGeneration   time.Duration
+	AICacheTTLInterviewPrep  time.Duration
+	AICacheTTLEmail          time.Duration
+	AICacheTTLLearningPlan   time.Duration
+	AICacheTTLJobExtraction  time.Duration
+	AICacheTTLSectionRewrite time.Duration
+
+	// Directory of versioned prompt templates loaded alongside the built-in ones
+	AIPromptsDir string
+
+	// Embeddings used for instant job similarity scores. "local" embeds on the
+	// server without any provider; gemini, openai and ollama use the
+	// provider's embedding model and fall back to local embeddings on errors.
+	AIEmbeddingProvider string
+	AIEmbeddingModel    string // Provider embedding model; empty uses the provider's default
+
 	// Cloud mode - enables multi-tenant deployment with OAuth-only authentication
 	IsCloudMode bool
 
@@ -74,6 +123,7 @@ func NewSettings() Settings {
 	isDevelopment := getEnv("IS_DEVELOPMENT", "false") == "true"
 	isTest := getEnv("GO_ENV", "") == "test"
 	isCloudMode := getEnv("CLOUD_MODE", "false") == "true"
+	tokenSecret := getEnv("TOKEN_SECRET", "default-secret-key")
 
 	// Production-optimized defaults
 	accessTokenExpiry := 60 * time.Minute
@@ -124,7 +174,7 @@ func NewSettings() Settings {
 		DBDriver:           "sqlite",
 		LogLevel:           getEnv("LOG_LEVEL", getDefaultLogLevel(isDevelopment)),
 		IsDevelopment:      isDevelopment,
-		TokenSecret:        getEnv("TOKEN_SECRET", "default-secret-key"),
+		TokenSecret:        tokenSecret,
 		IsTest:             isTest,
 		MigrationsDir:      "migrations/sqlite",
 
@@ -138,6 +188,8 @@ func NewSettings() Settings {
 		CookieSecure:       cookieSecure,
 		CookieSameSite:     "lax",
 
+		CredentialsEncryptionKey: getEnv("CREDENTIALS_ENCRYPTION_KEY", ""),
+
 		GoogleOAuthEnabled:      isCloudMode, // Google OAuth is required in cloud mode
 		GoogleClientID:          getEnv("GOOGLE_CLIENT_ID", ""),
 		GoogleClientSecret:      getEnv("GOOGLE_CLIENT_SECRET", ""),
@@ -155,13 +207,49 @@ func NewSettings() Settings {
 		AdminPassword:      getEnv("ADMIN_PASSWORD", "VegaAdmin"),
 		ResetAdminPassword: getEnv("RESET_ADMIN_PASSWORD", "false") == "true",
 
-		AIProvider:             "gemini",
+		AIProvider:             getEnv("AI_PROVIDER", "gemini"),
+		AIProviders:            getAIProviders(),
+		AIBreakerThreshold:     getAIBreakerThreshold(),
+		AIBreakerOpenTimeout:   getAIBreakerOpenTimeout(),
 		GeminiAPIKey:           getEnv("GEMINI_API_KEY", ""),
 		GeminiModel:            getEnv("GEMINI_MODEL", "gemini-2.5-flash"), // Default/fallback model
 		GeminiModelCVParsing:   getEnv("GEMINI_MODEL_CV_PARSING", "gemini-1.5-flash"),
 		GeminiModelJobAnalysis: getEnv("GEMINI_MODEL_JOB_ANALYSIS", "gemini-2.5-flash"),
 		GeminiModelCoverLetter: getEnv("GEMINI_MODEL_COVER_LETTER", "gemini-2.5-flash"),
 
+		OpenAIBaseURL:          getEnv("OPENAI_BASE_URL", "https://api.openai.com/v1"),
+		OpenAIAPIKey:           getEnv("OPENAI_API_KEY", ""),
+		OpenAIModel:            getEnv("OPENAI_MODEL", "gpt-4o-mini"),
+		OpenAIModelCVParsing:   getEnv("OPENAI_MODEL_CV_PARSING", ""),
+		OpenAIModelJobAnalysis: getEnv("OPENAI_MODEL_JOB_ANALYSIS", ""),
+		OpenAIModelCoverLetter: getEnv("OPENAI_MODEL_COVER_LETTER", ""),
+
+		OllamaBaseURL:          getEnv("OLLAMA_BASE_URL", "http://localhost:11434"),
+		OllamaModel:            getEnv("OLLAMA_MODEL", "llama3.1:8b"),
+		OllamaModelCVParsing:   getEnv("OLLAMA_MODEL_CV_PARSING", ""),
+		OllamaModelJobAnalysis: getEnv("OLLAMA_MODEL_JOB_ANALYSIS", ""),
+		OllamaModelCoverLetter: getEnv("OLLAMA_MODEL_COVER_LETTER", ""),
+
+		AIReplayMode:       getEnv("AI_REPLAY_MODE", "replay"),
+		AIReplayFixtureDir: getEnv("AI_REPLAY_FIXTURE_DIR", "./data/fixtures/llm"),
+		AIReplayUpstream:   getEnv("AI_REPLAY_UPSTREAM", "gemini"),
+
+		AICacheEnabled:           getEnv("AI_CACHE_ENABLED", "true") == "true",
+		AICacheTTLJobAnalysis:    getAICacheTTL("AI_CACHE_TTL_JOB_ANALYSIS", time.Hour),
+		AICacheTTLCoverLetter:    getAICacheTTL("AI_CACHE_TTL_COVER_LETTER", 30*time.Minute),
+		AICacheTTLCVParsing:      getAICacheTTL("AI_CACHE_TTL_CV_PARSING", time.Hour),
+		AICacheTTLCVGeneration:   getAICacheTTL("AI_CACHE_TTL_CV_GENERATION", 30*time.Minute),
+		AICacheTTLInterviewPrep:  getAICacheTTL("AI_CACHE_TTL_INTERVIEW_PREP", 30*time.Minute),
+		AICacheTTLEmail:          getAICacheTTL("AI_CACHE_TTL_EMAIL", 30*time.Minute),
+		AICacheTTLLearningPlan:   getAICacheTTL("AI_CACHE_TTL_LEARNING_PLAN", time.Hour),
+		AICacheTTLJobExtraction:  getAICacheTTL("AI_CACHE_TTL_JOB_EXTRACTION", time.Hour),
+		AICacheTTLSectionRewrite: getAICacheTTL("AI_CACHE_TTL_SECTION_REWRITE", 30*time.Minute),
+
+		AIPromptsDir: getEnv("AI_PROMPTS_DIR", ""),
+
+		AIEmbeddingProvider: getEnv("AI_EMBEDDING_PROVIDER", "local"),
+		AIEmbeddingModel:    getEnv("AI_EMBEDDING_MODEL", ""),
+
 		IsCloudMode: isCloudMode,
 
 		CachePath:        getEnv("CACHE_PATH", "./data/cache"),
@@ -277,3 +365,50 @@ func getCacheDefaultTTL() time.Duration {
 	}
 	return time.Hour // Default 1 hour
 }
+
+// getAICacheTTL returns the AI response cache TTL for a task type. A value
+// of 0 disables caching for that task.
+func getAICacheTTL(key string, defaultTTL time.Duration) time.Duration {
+	if envVal := getEnv(key, ""); envVal != "" {
+		if duration, err := time.ParseDuration(envVal); err == nil && duration >= 0 {
+			return duration
+		}
+	}
+	return defaultTTL
+}
+
+// getAIProviders returns the ordered AI provider fallback chain from AI_PROVIDERS
+func getAIProviders() []string {
+	envVal := getEnv("AI_PROVIDERS", "")
+	if envVal == "" {
+		return nil
+	}
+
+	var providers []string
+	for _, name := range strings.Split(envVal, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			providers = append(providers, name)
+		}
+	}
+	return providers
+}
+
+// getAIBreakerThreshold returns the consecutive failure count that opens a provider circuit
+func getAIBreakerThreshold() int {
+	if envVal := getEnv("AI_BREAKER_THRESHOLD", ""); envVal != "" {
+		if threshold, err := strconv.Atoi(envVal); err == nil && threshold > 0 {
+			return threshold
+		}
+	}
+	return 3
+}
+
+// getAIBreakerOpenTimeout returns how long an open provider circuit stays open
+func getAIBreakerOpenTimeout() time.Duration {
+	if envVal := getEnv("AI_BREAKER_OPEN_TIMEOUT", ""); envVal != "" {
+		if duration, err := time.ParseDuration(envVal); err == nil {
+			return duration
+		}
+	}
+	return 30 * time.Second
+}
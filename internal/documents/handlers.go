@@ -187,6 +187,11 @@ func (h *DocumentHandler) ExportDocument(c *gin.Context) {
 		"companyName": companyName,
 	}
 
+	if doc.DocumentType == models.DocumentTypeResume {
+		responseData["language"] = jobmodels.CVLanguageOrDefault(doc.Settings.Language)
+		responseData["headings"] = jobmodels.CVHeadingsFor(doc.Settings.Language)
+	}
+
 	if doc.DocumentType == models.DocumentTypeCoverLetter {
 		var coverLetterData struct {
 			Content      string                  `json:"content"`
@@ -292,7 +297,7 @@ func (h *DocumentHandler) GetDocumentPartial(c *gin.Context) {
 
 func (h *DocumentHandler) formatCVAsHTML(cv *jobmodels.GeneratedCV) string {
 	const resumeTemplate = `<!DOCTYPE html>
-<html lang="en">
+<html lang="{{.Language}}">
 <head>
     <meta charset="UTF-8">
     <meta name="viewport" content="width=device-width, initial-scale=1.0">
@@ -348,24 +353,24 @@ func (h *DocumentHandler) formatCVAsHTML(cv *jobmodels.GeneratedCV) string {
 
     {{if .PersonalInfo.Summary}}
     <div class="section">
-        <h2>Professional Summary</h2>
+        <h2>{{.Headings.Summary}}</h2>
         <p class="description">{{.PersonalInfo.Summary}}</p>
     </div>
     {{end}}
 
     {{if .Skills}}
     <div class="section">
-        <h2>Skills</h2>
+        <h2>{{.Headings.Skills}}</h2>
         <div class="skills">{{.SkillsString}}</div>
     </div>
     {{end}}
 
     {{if .WorkExperience}}
     <div class="section">
-        <h2>Work Experience</h2>
+        <h2>{{.Headings.Experience}}</h2>
         {{range .WorkExperience}}
         <div class="experience-item">
-            <h3>{{.Title}} at {{.Company}}</h3>
+            <h3>{{.Role}}</h3>
             <div class="date">{{.StartDate}} - {{.EndDate}}{{if .Location}} | {{.Location}}{{end}}</div>
             <div class="description">{{.FormattedDescription}}</div>
         </div>
@@ -375,10 +380,10 @@ func (h *DocumentHandler) formatCVAsHTML(cv *jobmodels.GeneratedCV) string {
 
     {{if .Education}}
     <div class="section">
-        <h2>Education</h2>
+        <h2>{{.Headings.Education}}</h2>
         {{range .Education}}
         <div class="education-item">
-            <h3>{{.Degree}}{{if .FieldOfStudy}} in {{.FieldOfStudy}}{{end}}</h3>
+            <h3>{{.Degree}}</h3>
             <div>{{.Institution}}</div>
             <div class="date">{{.StartDate}} - {{.EndDate}}</div>
         </div>
@@ -388,7 +393,7 @@ func (h *DocumentHandler) formatCVAsHTML(cv *jobmodels.GeneratedCV) string {
 
     {{if .Certifications}}
     <div class="section">
-        <h2>Certifications</h2>
+        <h2>{{.Headings.Certifications}}</h2>
         {{range .Certifications}}
         <div style="margin-bottom: 8px;">
             <strong>{{.Name}}</strong>{{if .IssuingOrg}} - {{.IssuingOrg}}{{end}}{{if .IssueDate}} ({{.IssueDate}}){{end}}
@@ -400,22 +405,30 @@ func (h *DocumentHandler) formatCVAsHTML(cv *jobmodels.GeneratedCV) string {
 </html>`
 
 	type workExp struct {
-		Title                string
-		Company              string
+		Role                 string
 		StartDate            string
 		EndDate              string
 		Location             string
 		FormattedDescription template.HTML
 	}
 
+	type education struct {
+		Degree      string
+		Institution string
+		StartDate   string
+		EndDate     string
+	}
+
 	type templateData struct {
+		Language       string
+		Headings       jobmodels.CVHeadings
 		FullName       string
 		PersonalInfo   *jobmodels.PersonalInfo
 		ContactInfo    string
 		Skills         []string
 		SkillsString   string
 		WorkExperience []workExp
-		Education      []jobmodels.Education
+		Education      []education
 		Certifications []jobmodels.Certification
 	}
 
@@ -436,8 +449,7 @@ func (h *DocumentHandler) formatCVAsHTML(cv *jobmodels.GeneratedCV) string {
 	var workExperience []workExp
 	for _, exp := range cv.WorkExperience {
 		workExperience = append(workExperience, workExp{
-			Title:                exp.Title,
-			Company:              exp.Company,
+			Role:                 jobmodels.RoleAt(exp.Title, exp.Company, cv.Language),
 			StartDate:            exp.StartDate,
 			EndDate:              exp.EndDate,
 			Location:             exp.Location,
@@ -445,14 +457,26 @@ func (h *DocumentHandler) formatCVAsHTML(cv *jobmodels.GeneratedCV) string {
 		})
 	}
 
+	var educationEntries []education
+	for _, edu := range cv.Education {
+		educationEntries = append(educationEntries, education{
+			Degree:      jobmodels.DegreeIn(edu.Degree, edu.FieldOfStudy, cv.Language),
+			Institution: edu.Institution,
+			StartDate:   edu.StartDate,
+			EndDate:     edu.EndDate,
+		})
+	}
+
 	data := templateData{
+		Language:       jobmodels.CVLanguageOrDefault(cv.Language),
+		Headings:       jobmodels.CVHeadingsFor(cv.Language),
 		FullName:       fmt.Sprintf("%s %s", cv.PersonalInfo.FirstName, cv.PersonalInfo.LastName),
 		PersonalInfo:   &cv.PersonalInfo,
 		ContactInfo:    strings.Join(contactParts, " | "),
 		Skills:         cv.Skills,
 		SkillsString:   strings.Join(cv.Skills, " • "),
 		WorkExperience: workExperience,
-		Education:      cv.Education,
+		Education:      educationEntries,
 		Certifications: cv.Certifications,
 	}
 
@@ -526,6 +550,13 @@ type SaveDocumentRequest struct {
 	JobID        int    `json:"jobId" binding:"required"`
 	DocumentType string `json:"documentType" binding:"required,oneof=resume cover_letter"`
 	Content      string `json:"content" binding:"required"`
+	// PromptVersion identifies the prompt template that generated the content
+	PromptVersion string `json:"promptVersion" binding:"omitempty,max=64"`
+	// Tone, Length and Language are the options a cover letter was generated
+	// with, so regenerating it can reuse them
+	Tone     string `json:"tone" binding:"omitempty,max=32"`
+	Length   string `json:"length" binding:"omitempty,max=32"`
+	Language string `json:"language" binding:"omitempty,max=8"`
 }
 
 func (h *DocumentHandler) SaveDocument(c *gin.Context) {
@@ -623,6 +654,12 @@ func (h *DocumentHandler) SaveDocument(c *gin.Context) {
 		req.JobID,
 		docType,
 		content,
+		req.PromptVersion,
+		models.GenerationSettings{
+			Tone:     req.Tone,
+			Length:   req.Length,
+			Language: req.Language,
+		},
 	)
 
 	if err != nil {
@@ -10,6 +10,14 @@ type DocumentType string
 const (
 	DocumentTypeCoverLetter DocumentType = "cover_letter"
 	DocumentTypeResume      DocumentType = "resume"
+
+	// DocumentTypeInterviewPrep is an interview preparation pack, stored as
+	// JSON and shown on the job details page
+	DocumentTypeInterviewPrep DocumentType = "interview_prep"
+
+	// DocumentTypeEmail is a generated application email, stored as JSON.
+	// A job keeps every email generated for it
+	DocumentTypeEmail DocumentType = "email"
 )
 
 const MaxDocumentSize = 2 * 1024 * 1024
@@ -24,6 +32,21 @@ type Document struct {
 	SizeBytes    int          `json:"size_bytes"`
 	CreatedAt    time.Time    `json:"created_at"`
 	UpdatedAt    time.Time    `json:"updated_at"`
+
+	// PromptVersion is the version of the prompt template that generated the
+	// document, empty for documents created before prompts were versioned
+	PromptVersion string `json:"prompt_version,omitempty"`
+
+	// Settings are the generation options the document was created with
+	Settings GenerationSettings `json:"settings"`
+}
+
+// GenerationSettings are the options a document was generated with. Empty
+// values mean the built-in defaults were used.
+type GenerationSettings struct {
+	Tone     string `json:"tone,omitempty"`
+	Length   string `json:"length,omitempty"`
+	Language string `json:"language,omitempty"`
 }
 
 type DocumentSummary struct {
@@ -57,13 +80,19 @@ var (
 
 func ValidateDocumentType(docType DocumentType) error {
 	switch docType {
-	case DocumentTypeCoverLetter, DocumentTypeResume:
+	case DocumentTypeCoverLetter, DocumentTypeResume, DocumentTypeInterviewPrep, DocumentTypeEmail:
 		return nil
 	default:
 		return ErrInvalidDocumentType
 	}
 }
 
+// KeepsHistory reports whether saving a document of this type adds a new
+// document instead of replacing the job's existing one.
+func (t DocumentType) KeepsHistory() bool {
+	return t == DocumentTypeEmail
+}
+
 func (d *Document) Validate() error {
 	if d.UserID <= 0 {
 		return errors.New("invalid user ID")
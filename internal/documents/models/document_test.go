@@ -125,6 +125,16 @@ func TestValidateDocumentType(t *testing.T) {
 			docType: DocumentTypeResume,
 			wantErr: false,
 		},
+		{
+			name:    "valid_interview_prep",
+			docType: DocumentTypeInterviewPrep,
+			wantErr: false,
+		},
+		{
+			name:    "valid_email",
+			docType: DocumentTypeEmail,
+			wantErr: false,
+		},
 		{
 			name:    "invalid_type",
 			docType: "invalid",
@@ -150,6 +160,13 @@ func TestValidateDocumentType(t *testing.T) {
 	}
 }
 
+func TestDocumentType_KeepsHistory(t *testing.T) {
+	assert.True(t, DocumentTypeEmail.KeepsHistory())
+	assert.False(t, DocumentTypeCoverLetter.KeepsHistory())
+	assert.False(t, DocumentTypeResume.KeepsHistory())
+	assert.False(t, DocumentTypeInterviewPrep.KeepsHistory())
+}
+
 func TestDocumentSummary(t *testing.T) {
 	now := time.Now()
 	summary := DocumentSummary{
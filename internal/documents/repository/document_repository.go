@@ -25,6 +25,8 @@ func NewSQLiteDocumentRepository(db *sql.DB, cache cache.Cache) *SQLiteDocumentR
 	}
 }
 
+// UpsertDocument saves the document, replacing the job's existing document of
+// the same type. Types that keep history are always added as new documents.
 func (r *SQLiteDocumentRepository) UpsertDocument(ctx context.Context, doc *models.Document) error {
 	if doc == nil {
 		return fmt.Errorf("document cannot be nil")
@@ -40,13 +42,17 @@ func (r *SQLiteDocumentRepository) UpsertDocument(ctx context.Context, doc *mode
 	defer cancel()
 
 	query := `
-		INSERT INTO documents (user_id, job_id, document_type, content, format, size_bytes, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
-		ON CONFLICT(user_id, job_id, document_type) 
+		INSERT INTO documents (user_id, job_id, document_type, content, format, size_bytes, prompt_version, tone, length, language, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		ON CONFLICT(user_id, job_id, document_type) WHERE document_type != 'email'
 		DO UPDATE SET 
 			content = excluded.content,
 			format = excluded.format,
 			size_bytes = excluded.size_bytes,
+			prompt_version = excluded.prompt_version,
+			tone = excluded.tone,
+			length = excluded.length,
+			language = excluded.language,
 			updated_at = CURRENT_TIMESTAMP
 		RETURNING id, created_at, updated_at`
 
@@ -57,6 +63,10 @@ func (r *SQLiteDocumentRepository) UpsertDocument(ctx context.Context, doc *mode
 		doc.Content,
 		doc.Format,
 		doc.SizeBytes,
+		doc.PromptVersion,
+		doc.Settings.Tone,
+		doc.Settings.Length,
+		doc.Settings.Language,
 	).Scan(&doc.ID, &doc.CreatedAt, &doc.UpdatedAt)
 
 	if err != nil {
@@ -79,7 +89,7 @@ func (r *SQLiteDocumentRepository) GetDocument(ctx context.Context, docID, userI
 	}
 
 	query := `
-		SELECT id, user_id, job_id, document_type, content, format, size_bytes, created_at, updated_at
+		SELECT id, user_id, job_id, document_type, content, format, size_bytes, prompt_version, tone, length, language, created_at, updated_at
 		FROM documents
 		WHERE id = ? AND user_id = ?`
 
@@ -91,6 +101,10 @@ func (r *SQLiteDocumentRepository) GetDocument(ctx context.Context, docID, userI
 		&doc.Content,
 		&doc.Format,
 		&doc.SizeBytes,
+		&doc.PromptVersion,
+		&doc.Settings.Tone,
+		&doc.Settings.Length,
+		&doc.Settings.Language,
 		&doc.CreatedAt,
 		&doc.UpdatedAt,
 	)
@@ -113,9 +127,11 @@ func (r *SQLiteDocumentRepository) GetDocumentByJobAndType(ctx context.Context,
 	var doc models.Document
 
 	query := `
-		SELECT id, user_id, job_id, document_type, content, format, size_bytes, created_at, updated_at
+		SELECT id, user_id, job_id, document_type, content, format, size_bytes, prompt_version, tone, length, language, created_at, updated_at
 		FROM documents
-		WHERE user_id = ? AND job_id = ? AND document_type = ?`
+		WHERE user_id = ? AND job_id = ? AND document_type = ?
+		ORDER BY updated_at DESC, id DESC
+		LIMIT 1`
 
 	err := r.db.QueryRowContext(ctx, query, userID, jobID, docType).Scan(
 		&doc.ID,
@@ -125,6 +141,10 @@ func (r *SQLiteDocumentRepository) GetDocumentByJobAndType(ctx context.Context,
 		&doc.Content,
 		&doc.Format,
 		&doc.SizeBytes,
+		&doc.PromptVersion,
+		&doc.Settings.Tone,
+		&doc.Settings.Length,
+		&doc.Settings.Language,
 		&doc.CreatedAt,
 		&doc.UpdatedAt,
 	)
@@ -325,7 +345,7 @@ func (r *SQLiteDocumentRepository) GetDocumentMetrics(ctx context.Context, userI
 
 func (r *SQLiteDocumentRepository) GetDocumentsByJob(ctx context.Context, userID, jobID int) ([]*models.Document, error) {
 	query := `
-		SELECT id, user_id, job_id, document_type, content, format, size_bytes, created_at, updated_at
+		SELECT id, user_id, job_id, document_type, content, format, size_bytes, prompt_version, tone, length, language, created_at, updated_at
 		FROM documents
 		WHERE user_id = ? AND job_id = ?
 		ORDER BY document_type`
@@ -347,6 +367,10 @@ func (r *SQLiteDocumentRepository) GetDocumentsByJob(ctx context.Context, userID
 			&doc.Content,
 			&doc.Format,
 			&doc.SizeBytes,
+			&doc.PromptVersion,
+			&doc.Settings.Tone,
+			&doc.Settings.Length,
+			&doc.Settings.Language,
 			&doc.CreatedAt,
 			&doc.UpdatedAt,
 		)
@@ -359,6 +383,51 @@ func (r *SQLiteDocumentRepository) GetDocumentsByJob(ctx context.Context, userID
 	return documents, nil
 }
 
+// GetDocumentHistory returns every document of the type saved for the job,
+// newest first.
+func (r *SQLiteDocumentRepository) GetDocumentHistory(ctx context.Context, userID, jobID int, docType models.DocumentType) ([]*models.Document, error) {
+	query := `
+		SELECT id, user_id, job_id, document_type, content, format, size_bytes, prompt_version, tone, length, language, created_at, updated_at
+		FROM documents
+		WHERE user_id = ? AND job_id = ? AND document_type = ?
+		ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, jobID, docType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query document history: %w", err)
+	}
+	defer rows.Close()
+
+	var documents []*models.Document
+	for rows.Next() {
+		var doc models.Document
+		err := rows.Scan(
+			&doc.ID,
+			&doc.UserID,
+			&doc.JobID,
+			&doc.DocumentType,
+			&doc.Content,
+			&doc.Format,
+			&doc.SizeBytes,
+			&doc.PromptVersion,
+			&doc.Settings.Tone,
+			&doc.Settings.Length,
+			&doc.Settings.Language,
+			&doc.CreatedAt,
+			&doc.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan document: %w", err)
+		}
+		documents = append(documents, &doc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query document history: %w", err)
+	}
+
+	return documents, nil
+}
+
 func (r *SQLiteDocumentRepository) invalidateDocumentCache(userID int, jobID int, docType models.DocumentType) {
 	if r.cache == nil {
 		return
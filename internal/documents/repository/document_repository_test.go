@@ -26,11 +26,13 @@ func TestUpsertDocument(t *testing.T) {
 	repo := NewSQLiteDocumentRepository(db, nil)
 
 	doc := &models.Document{
-		UserID:       1,
-		JobID:        1,
-		DocumentType: models.DocumentTypeCoverLetter,
-		Content:      "<html>Test cover letter</html>",
-		Format:       "html",
+		UserID:        1,
+		JobID:         1,
+		DocumentType:  models.DocumentTypeCoverLetter,
+		Content:       "<html>Test cover letter</html>",
+		Format:        "html",
+		PromptVersion: "builtin",
+		Settings:      models.GenerationSettings{Tone: "warm", Length: "short", Language: "de"},
 	}
 
 	t.Run("insert new document", func(t *testing.T) {
@@ -39,7 +41,7 @@ func TestUpsertDocument(t *testing.T) {
 			AddRow(1, now, now)
 
 		mock.ExpectQuery(`INSERT INTO documents`).
-			WithArgs(doc.UserID, doc.JobID, doc.DocumentType, doc.Content, doc.Format, len(doc.Content)).
+			WithArgs(doc.UserID, doc.JobID, doc.DocumentType, doc.Content, doc.Format, len(doc.Content), doc.PromptVersion, "warm", "short", "de").
 			WillReturnRows(rows)
 
 		err := repo.UpsertDocument(ctx, doc)
@@ -59,7 +61,7 @@ func TestUpsertDocument(t *testing.T) {
 			AddRow(1, now.Add(-time.Hour), now)
 
 		mock.ExpectQuery(`INSERT INTO documents`).
-			WithArgs(doc.UserID, doc.JobID, doc.DocumentType, doc.Content, doc.Format, len(doc.Content)).
+			WithArgs(doc.UserID, doc.JobID, doc.DocumentType, doc.Content, doc.Format, len(doc.Content), doc.PromptVersion, "warm", "short", "de").
 			WillReturnRows(rows)
 
 		err := repo.UpsertDocument(ctx, doc)
@@ -78,8 +80,8 @@ func TestGetDocument(t *testing.T) {
 		now := time.Now()
 		rows := sqlmock.NewRows([]string{
 			"id", "user_id", "job_id", "document_type", "content",
-			"format", "size_bytes", "created_at", "updated_at",
-		}).AddRow(1, 1, 1, "cover_letter", "<html>Test</html>", "html", 17, now, now)
+			"format", "size_bytes", "prompt_version", "tone", "length", "language", "created_at", "updated_at",
+		}).AddRow(1, 1, 1, "cover_letter", "<html>Test</html>", "html", 17, "builtin", "formal", "long", "fr", now, now)
 
 		mock.ExpectQuery(`SELECT (.+) FROM documents WHERE id = \? AND user_id = \?`).
 			WithArgs(1, 1).
@@ -90,6 +92,8 @@ func TestGetDocument(t *testing.T) {
 		assert.NotNil(t, doc)
 		assert.Equal(t, 1, doc.ID)
 		assert.Equal(t, "<html>Test</html>", doc.Content)
+		assert.Equal(t, "builtin", doc.PromptVersion)
+		assert.Equal(t, models.GenerationSettings{Tone: "formal", Length: "long", Language: "fr"}, doc.Settings)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
@@ -140,6 +144,48 @@ func TestGetDocumentsByType(t *testing.T) {
 	})
 }
 
+func TestGetDocumentHistory(t *testing.T) {
+	ctx := context.Background()
+	db, mock := setupMockDB(t)
+	repo := NewSQLiteDocumentRepository(db, nil)
+
+	t.Run("returns documents newest first", func(t *testing.T) {
+		now := time.Now()
+		rows := sqlmock.NewRows([]string{
+			"id", "user_id", "job_id", "document_type", "content",
+			"format", "size_bytes", "prompt_version", "tone", "length", "language", "created_at", "updated_at",
+		}).AddRow(2, 1, 1, "email", `{"subject":"Second"}`, "json", 20, "builtin", "", "", "", now, now).
+			AddRow(1, 1, 1, "email", `{"subject":"First"}`, "json", 19, "builtin", "", "", "", now.Add(-time.Hour), now.Add(-time.Hour))
+
+		mock.ExpectQuery(`SELECT (.+) FROM documents WHERE user_id = \? AND job_id = \? AND document_type = \? ORDER BY created_at DESC, id DESC`).
+			WithArgs(1, 1, models.DocumentTypeEmail).
+			WillReturnRows(rows)
+
+		docs, err := repo.GetDocumentHistory(ctx, 1, 1, models.DocumentTypeEmail)
+		assert.NoError(t, err)
+		require.Len(t, docs, 2)
+		assert.Equal(t, 2, docs[0].ID)
+		assert.Equal(t, 1, docs[1].ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("returns empty history", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{
+			"id", "user_id", "job_id", "document_type", "content",
+			"format", "size_bytes", "prompt_version", "tone", "length", "language", "created_at", "updated_at",
+		})
+
+		mock.ExpectQuery(`SELECT (.+) FROM documents`).
+			WithArgs(1, 2, models.DocumentTypeEmail).
+			WillReturnRows(rows)
+
+		docs, err := repo.GetDocumentHistory(ctx, 1, 2, models.DocumentTypeEmail)
+		assert.NoError(t, err)
+		assert.Empty(t, docs)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestDeleteDocument(t *testing.T) {
 	ctx := context.Background()
 	db, mock := setupMockDB(t)
@@ -15,4 +15,5 @@ type DocumentRepository interface {
 	DeleteDocument(ctx context.Context, docID, userID int) error
 	GetDocumentMetrics(ctx context.Context, userID int) (*models.DocumentMetrics, error)
 	GetDocumentsByJob(ctx context.Context, userID, jobID int) ([]*models.Document, error)
+	GetDocumentHistory(ctx context.Context, userID, jobID int, docType models.DocumentType) ([]*models.Document, error)
 }
@@ -27,7 +27,9 @@ func NewDocumentService(repo repository.DocumentRepository, cache cache.Cache) *
 	}
 }
 
-func (s *DocumentService) SaveGeneratedDocument(ctx context.Context, userID, jobID int, docType models.DocumentType, content string) (*models.Document, error) {
+// SaveGeneratedDocument saves generated content for a job, recording the
+// prompt version and generation settings it was created with.
+func (s *DocumentService) SaveGeneratedDocument(ctx context.Context, userID, jobID int, docType models.DocumentType, content, promptVersion string, settings models.GenerationSettings) (*models.Document, error) {
 	userRef := fmt.Sprintf("user_%d", userID)
 
 	s.log.Debug().
@@ -55,12 +57,14 @@ func (s *DocumentService) SaveGeneratedDocument(ctx context.Context, userID, job
 	}
 
 	doc := &models.Document{
-		UserID:       userID,
-		JobID:        jobID,
-		DocumentType: docType,
-		Content:      content,
-		Format:       "html",
-		SizeBytes:    len(content),
+		UserID:        userID,
+		JobID:         jobID,
+		DocumentType:  docType,
+		Content:       content,
+		Format:        "html",
+		SizeBytes:     len(content),
+		PromptVersion: promptVersion,
+		Settings:      settings,
 	}
 
 	if err := doc.Validate(); err != nil {
@@ -354,6 +358,31 @@ func (s *DocumentService) GetDocumentsByJob(ctx context.Context, userID, jobID i
 	return docs, nil
 }
 
+// GetDocumentHistory returns every document of the type saved for the job,
+// newest first.
+func (s *DocumentService) GetDocumentHistory(ctx context.Context, userID, jobID int, docType models.DocumentType) ([]*models.Document, error) {
+	userRef := fmt.Sprintf("user_%d", userID)
+
+	s.log.Debug().
+		Str("user_ref", userRef).
+		Int("job_id", jobID).
+		Str("document_type", string(docType)).
+		Msg("Getting document history")
+
+	docs, err := s.repo.GetDocumentHistory(ctx, userID, jobID, docType)
+	if err != nil {
+		s.log.Error().
+			Str("user_ref", userRef).
+			Int("job_id", jobID).
+			Str("document_type", string(docType)).
+			Err(err).
+			Msg("Failed to get document history")
+		return nil, err
+	}
+
+	return docs, nil
+}
+
 func (s *DocumentService) CheckDocumentExists(ctx context.Context, userID, jobID int, docType models.DocumentType) (bool, error) {
 	doc, err := s.repo.GetDocumentByJobAndType(ctx, userID, jobID, docType)
 	if err == models.ErrDocumentNotFound {
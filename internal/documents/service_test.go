@@ -71,6 +71,14 @@ func (m *mockDocumentRepository) GetDocumentsByJob(ctx context.Context, userID,
 	return args.Get(0).([]*models.Document), args.Error(1)
 }
 
+func (m *mockDocumentRepository) GetDocumentHistory(ctx context.Context, userID, jobID int, docType models.DocumentType) ([]*models.Document, error) {
+	args := m.Called(ctx, userID, jobID, docType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Document), args.Error(1)
+}
+
 func TestSaveGeneratedDocument(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -137,6 +145,8 @@ func TestSaveGeneratedDocument(t *testing.T) {
 				tt.jobID,
 				tt.docType,
 				tt.content,
+				"builtin",
+				models.GenerationSettings{Tone: "warm", Language: "de"},
 			)
 
 			if tt.wantError {
@@ -153,6 +163,8 @@ func TestSaveGeneratedDocument(t *testing.T) {
 				assert.Equal(t, tt.docType, doc.DocumentType)
 				assert.Equal(t, tt.content, doc.Content)
 				assert.Equal(t, len(tt.content), doc.SizeBytes)
+				assert.Equal(t, "builtin", doc.PromptVersion)
+				assert.Equal(t, models.GenerationSettings{Tone: "warm", Language: "de"}, doc.Settings)
 				mockRepo.AssertExpectations(t)
 			}
 		})
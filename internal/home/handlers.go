@@ -81,14 +81,15 @@ func (h *Handler) GetHomePage(c *gin.Context) {
 	}
 
 	h.renderer.HTML(c, http.StatusOK, "layouts/base.html", gin.H{
-		"title":          homeData.Title,
-		"page":           homeData.Page,
-		"activeNav":      "dashboard",
-		"pageTitle":      "Dashboard",
-		"stats":          homeData.Stats,
-		"recentJobs":     homeData.RecentJobs,
-		"hasJobs":        homeData.HasJobs,
-		"showOnboarding": homeData.ShowOnboarding,
-		"quotaStatus":    homeData.QuotaStatus,
+		"title":             homeData.Title,
+		"page":              homeData.Page,
+		"activeNav":         "dashboard",
+		"pageTitle":         "Dashboard",
+		"stats":             homeData.Stats,
+		"recentJobs":        homeData.RecentJobs,
+		"hasJobs":           homeData.HasJobs,
+		"showOnboarding":    homeData.ShowOnboarding,
+		"quotaStatus":       homeData.QuotaStatus,
+		"upcomingReminders": homeData.UpcomingReminders,
 	})
 }
@@ -17,6 +17,9 @@ type HomePageData struct {
 	Title          string          `json:"title"`
 	Page           string          `json:"page"`
 	QuotaStatus    *QuotaStatus    `json:"quota_status"`
+
+	// Open reminders due within the next week, overdue ones included
+	UpcomingReminders []*models.Reminder `json:"upcoming_reminders"`
 }
 
 // QuotaStatus represents the current quota status for a user
@@ -36,6 +39,17 @@ type JobStatsSummary struct {
 	ActiveJobs    int `json:"active_jobs"`
 	OfferReceived int `json:"offer_received"`
 	Interested    int `json:"interested"`
+
+	// Activity over the last ActivityDays days, from the dates jobs actually
+	// changed status, and the average days from applying to the first
+	// interview over the TimedInterviews jobs that got one. HasActivity is
+	// false when the status history is not available.
+	HasActivity        bool `json:"has_activity"`
+	ActivityDays       int  `json:"activity_days"`
+	AppliedRecently    int  `json:"applied_recently"`
+	InterviewsRecently int  `json:"interviews_recently"`
+	AvgDaysToInterview int  `json:"avg_days_to_interview"`
+	TimedInterviews    int  `json:"timed_interviews"`
 }
 
 // JobSummary provides essential job info for homepage listings
@@ -56,7 +70,7 @@ func ToJobSummary(job *models.Job) JobSummary {
 		Company:    getCompanyName(job),
 		Location:   job.Location,
 		Status:     int(job.Status),
-		StatusText: job.Status.String(),
+		StatusText: job.StageName(),
 	}
 }
 
@@ -3,12 +3,16 @@ package home
 import (
 	"context"
 	"fmt"
+	"math"
 
 	"github.com/benidevo/vega/internal/job"
 	"github.com/benidevo/vega/internal/job/models"
 	"github.com/benidevo/vega/internal/job/repository"
 )
 
+// activityDays is the period the homepage summarizes status changes over
+const activityDays = 30
+
 // Service handles business logic for homepage data aggregation
 type Service struct {
 	jobRepository *repository.SQLiteJobRepository
@@ -43,6 +47,10 @@ func (s *Service) GetHomePageData(ctx context.Context, userID int, username stri
 		return nil, fmt.Errorf("failed to get recent jobs: %w", err)
 	}
 
+	if s.jobService != nil {
+		s.jobService.LoadJobStages(ctx, userID, recentJobs...)
+	}
+
 	homeData.Stats = JobStatsSummary{
 		TotalJobs:     jobStats.TotalJobs,
 		Applied:       statusCounts[models.APPLIED],
@@ -60,6 +68,27 @@ func (s *Service) GetHomePageData(ctx context.Context, userID int, username stri
 	homeData.HasJobs = jobStats.TotalJobs > 0
 	homeData.ShowOnboarding = jobStats.TotalJobs == 0
 
+	// Get status activity if job service is available
+	if s.jobService != nil {
+		activity, err := s.jobService.GetStatusActivity(ctx, userID, activityDays)
+		if err == nil && activity != nil {
+			homeData.Stats.HasActivity = true
+			homeData.Stats.ActivityDays = activityDays
+			homeData.Stats.AppliedRecently = activity.Applied
+			homeData.Stats.InterviewsRecently = activity.Interviews
+			homeData.Stats.AvgDaysToInterview = int(math.Round(activity.AvgDaysToInterview))
+			homeData.Stats.TimedInterviews = activity.TimedInterviews
+		}
+	}
+
+	// Get upcoming reminders if job service is available
+	if s.jobService != nil {
+		reminders, err := s.jobService.GetUpcomingReminders(ctx, userID)
+		if err == nil {
+			homeData.UpcomingReminders = reminders
+		}
+	}
+
 	// Get quota status if job service is available
 	if s.jobService != nil {
 		quotaStatus, err := s.jobService.GetQuotaStatus(ctx, userID)
@@ -15,20 +15,33 @@ type FieldCommand interface {
 // statusCommand handles status field updates
 type statusCommand struct{}
 
-// Execute updates the status of the given job based on the "status" form value from the request context.
+// Execute moves the given job to the pipeline stage named by the "status" form value from the request
+// context; a status such as "applied" means the first stage of that category. The optional
+// "status_note" and "status_date" values are recorded with the change, the latter for changes that
+// happened before they were tracked here.
 func (cmd *statusCommand) Execute(c *gin.Context, job *models.Job, service *JobService) (string, error) {
 	statusStr := c.PostForm("status")
 	if statusStr == "" {
 		return "", models.ErrStatusRequired
 	}
 
-	status, err := models.JobStatusFromString(statusStr)
+	ctx := c.Request.Context()
+	userID := c.GetInt("userID")
+	pipeline, err := service.GetPipeline(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	stage, err := pipeline.StageFromString(statusStr)
 	if err != nil {
 		return "", models.ErrInvalidJobStatus
 	}
 
-	job.Status = status
-	return "Job status updated to " + status.String(), nil
+	note := c.PostForm("status_note")
+	date := c.PostForm("status_date")
+	if err := service.ChangeJobStage(ctx, userID, job, stage, note, date); err != nil {
+		return "", err
+	}
+	return "Job status updated to " + stage.Name, nil
 }
 
 // notesCommand handles notes field updates
@@ -53,6 +66,34 @@ func (cmd *skillsCommand) Execute(c *gin.Context, job *models.Job, service *JobS
 	return "Skills updated successfully", nil
 }
 
+// tagsCommand handles tag updates
+type tagsCommand struct{}
+
+// Execute replaces the job's tags with the comma-separated "tags" form value.
+// Tags live in their own table, so they are saved here rather than with the
+// rest of the job.
+func (cmd *tagsCommand) Execute(c *gin.Context, job *models.Job, service *JobService) (string, error) {
+	tags := models.ParseTags(c.PostForm("tags"))
+	if err := service.SetJobTags(c.Request.Context(), c.GetInt("userID"), job, tags); err != nil {
+		return "", err
+	}
+	return "Tags updated successfully", nil
+}
+
+// descriptionCommand handles job description updates
+type descriptionCommand struct{}
+
+// Execute replaces the job description with the "description" form value.
+// Line breaks are kept so the posting's formatting survives the edit.
+func (cmd *descriptionCommand) Execute(c *gin.Context, job *models.Job, service *JobService) (string, error) {
+	description := strings.TrimSpace(c.PostForm("description"))
+	if description == "" {
+		return "", models.ErrJobDescriptionRequired
+	}
+	job.Description = description
+	return "Job description updated successfully", nil
+}
+
 // basicCommand handles basic job information updates
 type basicCommand struct{}
 
@@ -85,10 +126,12 @@ type CommandFactory struct {
 func NewCommandFactory() *CommandFactory {
 	return &CommandFactory{
 		commands: map[string]FieldCommand{
-			"status": &statusCommand{},
-			"notes":  &notesCommand{},
-			"skills": &skillsCommand{},
-			"basic":  &basicCommand{},
+			"status":      &statusCommand{},
+			"notes":       &notesCommand{},
+			"skills":      &skillsCommand{},
+			"basic":       &basicCommand{},
+			"description": &descriptionCommand{},
+			"tags":        &tagsCommand{},
 		},
 	}
 }
@@ -7,9 +7,12 @@ import (
 	"fmt"
 	"html/template"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/benidevo/vega/internal/ai/llm"
 	"github.com/benidevo/vega/internal/common/alerts"
 	ctxutil "github.com/benidevo/vega/internal/common/context"
 	"github.com/benidevo/vega/internal/common/render"
@@ -39,12 +42,53 @@ type jobService interface {
 
 	// AI operations
 	AnalyzeJobMatch(ctx context.Context, userID int, jobID int) (*models.JobMatchAnalysis, error)
-	GenerateCoverLetter(ctx context.Context, userID int, jobID int) (*models.CoverLetterWithProfile, error)
-	GenerateCV(ctx context.Context, userID int, jobID int) (*models.GeneratedCV, error)
+	GenerateCoverLetter(ctx context.Context, userID int, jobID int, options models.CoverLetterOptions) (*models.CoverLetterWithProfile, error)
+	GetCoverLetterOptions(ctx context.Context, userID, jobID int) (models.CoverLetterOptions, error)
+	GenerateCV(ctx context.Context, userID int, jobID int, language string) (*models.GeneratedCV, error)
+	GenerateInterviewPrep(ctx context.Context, userID int, jobID int) (*models.InterviewPrep, error)
+	GetInterviewPrep(ctx context.Context, userID int, jobID int) (*models.InterviewPrep, error)
+	GenerateEmail(ctx context.Context, userID, jobID int, kind, tone, note string) (*models.JobEmail, error)
+	GetEmailHistory(ctx context.Context, userID, jobID int) ([]*models.JobEmail, error)
+	GetSkillGaps(ctx context.Context, userID int) (*models.SkillGapReport, error)
+	GenerateLearningPlan(ctx context.Context, userID int) (*models.SkillGapReport, error)
+	MarkSkillGapAddressed(ctx context.Context, userID int, key, note string) (*models.SkillGapReport, error)
+	ExtractJobDraft(ctx context.Context, userID int, rawText string) (*models.JobDraft, error)
+	RewriteSection(ctx context.Context, userID, jobID int, section, text, instruction string) (*models.SectionRewrite, error)
+	StreamCoverLetter(ctx context.Context, userID int, jobID int, options models.CoverLetterOptions, onDelta func(text string) error) (*models.CoverLetterWithProfile, error)
+	StreamCV(ctx context.Context, userID int, jobID int, language string, onDelta func(text string) error) (*models.GeneratedCV, error)
 	CheckJobQuota(ctx context.Context, userID int, jobID int) (*quota.QuotaCheckResult, error)
 	GetJobMatchHistory(ctx context.Context, userID int, jobID int) ([]*models.MatchResult, error)
 	DeleteMatchResult(ctx context.Context, userID int, jobID int, matchID int) error
 
+	// Embedding similarity
+	GetJobFitScores(ctx context.Context, userID int, jobs []*models.Job) (map[int]int, error)
+	GetSimilarJobs(ctx context.Context, userID, jobID int) ([]*models.SimilarJob, error)
+
+	// Tags
+	GetTags(ctx context.Context, userID int) ([]*models.Tag, error)
+
+	// Pipeline stages
+	GetPipeline(ctx context.Context, userID int) (models.Pipeline, error)
+	CreateStage(ctx context.Context, userID int, name string, category models.JobStatus) (*models.PipelineStage, error)
+	UpdateStage(ctx context.Context, userID, stageID int, name string, category models.JobStatus) error
+	MoveStage(ctx context.Context, userID, stageID, offset int) error
+	DeleteStage(ctx context.Context, userID, stageID, replacementID int) error
+
+	// Status history
+	GetStatusTimeline(ctx context.Context, userID, jobID int) ([]models.StatusTimelineEntry, error)
+	UpdateStatusEvent(ctx context.Context, userID, jobID, eventID int, note, date string) error
+
+	// Reminders
+	GetJobReminders(ctx context.Context, userID, jobID int) ([]*models.Reminder, error)
+	GetOverdueReminderCounts(ctx context.Context, userID int, jobs []*models.Job) (map[int]int, error)
+	CreateReminder(ctx context.Context, userID, jobID int, message, due, offset, recurrence string) (*models.Reminder, error)
+	CompleteReminder(ctx context.Context, userID, jobID, reminderID int) error
+	DeleteReminder(ctx context.Context, userID, jobID, reminderID int) error
+
+	// Prompt injection review
+	GetInjectionReview(ctx context.Context, userID, jobID int) (*models.InjectionReview, error)
+	ApproveInjectionReview(ctx context.Context, userID, jobID int) error
+
 	// Logging
 	LogError(err error)
 }
@@ -143,11 +187,6 @@ func (h *JobHandler) formatValidationError(err error) string {
 				case "omitempty": // Skip this tag
 					continue
 				}
-			case "Status":
-				switch tag {
-				case "min", "max":
-					return models.ErrInvalidJobStatus.Error()
-				}
 			case "JobType":
 				switch tag {
 				case "min", "max":
@@ -202,11 +241,26 @@ func (h *JobHandler) renderError(c *gin.Context, err error) {
 		errors.Is(err, models.ErrJobDescriptionRequired) ||
 		errors.Is(err, models.ErrCompanyRequired) ||
 		errors.Is(err, models.ErrInvalidURLFormat) ||
+		errors.Is(err, models.ErrStatusNoteTooLong) ||
+		errors.Is(err, models.ErrInvalidStatusDate) ||
+		errors.Is(err, models.ErrStatusDateInFuture) ||
+		errors.Is(err, models.ErrReminderMessageRequired) ||
+		errors.Is(err, models.ErrReminderMessageTooLong) ||
+		errors.Is(err, models.ErrInvalidReminderDue) ||
+		errors.Is(err, models.ErrInvalidRecurrence) ||
+		errors.Is(err, models.ErrPostingTextRequired) ||
+		errors.Is(err, models.ErrPostingTextTooLong) ||
+		errors.Is(err, models.ErrNotJobPosting) ||
+		errors.Is(err, models.ErrJobNeedsReview) ||
+		errors.Is(err, models.ErrNoReviewPending) ||
 		errors.Is(err, models.ErrProfileIncomplete) ||
 		errors.Is(err, models.ErrProfileSummaryRequired) ||
 		errors.Is(err, models.ErrAIServiceUnavailable) {
 		statusCode = http.StatusBadRequest
-	} else if errors.Is(err, models.ErrJobNotFound) {
+	} else if errors.Is(err, models.ErrJobNotFound) ||
+		errors.Is(err, models.ErrStatusEventNotFound) ||
+		errors.Is(err, models.ErrReminderNotFound) ||
+		errors.Is(err, models.ErrStageNotFound) {
 		statusCode = http.StatusNotFound
 	}
 
@@ -231,8 +285,13 @@ func (h *JobHandler) renderDashboardError(c *gin.Context, err error) {
 
 	// Determine appropriate status code based on error type
 	if errors.Is(err, models.ErrInvalidJobStatus) ||
-		errors.Is(err, models.ErrStatusRequired) {
+		errors.Is(err, models.ErrStatusRequired) ||
+		errors.Is(err, models.ErrStatusNoteTooLong) ||
+		errors.Is(err, models.ErrInvalidStatusDate) ||
+		errors.Is(err, models.ErrStatusDateInFuture) {
 		statusCode = http.StatusBadRequest
+	} else if errors.Is(err, models.ErrStageNotFound) {
+		statusCode = http.StatusNotFound
 	}
 
 	alerts.RenderError(c, statusCode, sentinelErr.Error(), alerts.ContextDashboard)
@@ -283,11 +342,17 @@ func (h *JobHandler) ListJobsPage(c *gin.Context) {
 	userID := userIDValue.(int)
 
 	statusParam := c.Query("status")
+	searchQuery := strings.TrimSpace(c.Query("q"))
 	pageParam := c.DefaultQuery("page", "1")
 	limitParam := c.DefaultQuery("limit", "12")
 	sortByParam := c.DefaultQuery("sort", "match_score")
 	sortOrderParam := c.DefaultQuery("order", "desc")
 
+	// Search results are ranked by relevance unless another order is chosen
+	if searchQuery != "" && c.Query("sort") == "" {
+		sortByParam = "relevance"
+	}
+
 	// Parse pagination parameters
 	page := 1
 	if p, err := models.ParsePositiveInt(pageParam); err == nil && p > 0 {
@@ -306,6 +371,7 @@ func (h *JobHandler) ListJobsPage(c *gin.Context) {
 		"match_score": true,
 		"updated_at":  true,
 		"created_at":  true,
+		"relevance":   searchQuery != "",
 	}
 	if !validSortFields[sortByParam] {
 		sortByParam = "match_score" // Default to match_score
@@ -316,28 +382,59 @@ func (h *JobHandler) ListJobsPage(c *gin.Context) {
 	}
 
 	filter := models.JobFilter{
+		Query:     searchQuery,
 		Limit:     limit,
 		Offset:    offset,
 		SortBy:    sortByParam,
 		SortOrder: sortOrderParam,
 	}
 
+	// The status filter is a stage of the user's pipeline and the category
+	// filter takes every stage of a status. A status in the status filter,
+	// from links older than pipelines, is read as a category.
+	pipeline := h.pipelineFor(c, userID)
+	categoryQuery := c.Query("category")
 	if statusParam != "" && statusParam != "all" {
-		jobStatus, err := models.JobStatusFromString(statusParam)
-		if err == nil {
-			filter.Status = &jobStatus
+		if stage := pipeline.FindStage(statusParam); stage != nil {
+			statusParam = stage.Name
+			if stage.ID > 0 {
+				filter.StageID = &stage.ID
+			} else {
+				filter.Status = &stage.Category
+			}
+		} else {
+			categoryQuery = statusParam
+			statusParam = ""
 		}
 	}
+	categoryParam, categoryLabel := "", ""
+	if category, err := models.JobStatusFromString(categoryQuery); err == nil && filter.StageID == nil && filter.Status == nil {
+		filter.Status = &category
+		categoryParam = category.FormValue()
+		categoryLabel = category.String()
+	}
+
+	// A tag filter that can't be valid is ignored, like an unknown status
+	if tagFilter, err := models.NormalizeTags(models.ParseTags(c.Query("tags"))); err == nil && len(tagFilter) > 0 {
+		filter.Tags = tagFilter
+		filter.TagMatch = models.TagMatchFromString(c.Query("match"))
+	}
+	tagFilterParam := strings.Join(filter.Tags, ",")
 
 	jobsWithPagination, err := h.service.GetJobsWithPagination(c.Request.Context(), userID, filter)
 	if err != nil {
 		h.renderer.HTML(c, http.StatusInternalServerError, "layouts/base.html", gin.H{
-			"title":        "Dashboard",
-			"page":         "dashboard",
-			"activeNav":    "jobs",
-			"pageTitle":    "Jobs",
-			"jobs":         []*models.Job{},
-			"statusFilter": statusParam,
+			"title":          "Dashboard",
+			"page":           "dashboard",
+			"activeNav":      "jobs",
+			"pageTitle":      "Jobs",
+			"jobs":           []*models.Job{},
+			"statusFilter":   statusParam,
+			"categoryFilter": categoryParam,
+			"stages":         pipeline,
+			"searchQuery":    searchQuery,
+			"tagFilter":      filter.Tags,
+			"tagFilterParam": tagFilterParam,
 		})
 		return
 	}
@@ -346,7 +443,16 @@ func (h *JobHandler) ListJobsPage(c *gin.Context) {
 	if page > jobsWithPagination.Pagination.TotalPages && jobsWithPagination.Pagination.TotalPages > 0 {
 		redirectURL := "?page=" + strconv.Itoa(jobsWithPagination.Pagination.TotalPages)
 		if statusParam != "" && statusParam != "all" {
-			redirectURL += "&status=" + statusParam
+			redirectURL += "&status=" + url.QueryEscape(statusParam)
+		}
+		if categoryParam != "" {
+			redirectURL += "&category=" + categoryParam
+		}
+		if searchQuery != "" {
+			redirectURL += "&q=" + url.QueryEscape(searchQuery)
+		}
+		if tagFilterParam != "" {
+			redirectURL += "&tags=" + url.QueryEscape(tagFilterParam) + "&match=" + string(filter.TagMatch)
 		}
 
 		if c.GetHeader("HX-Request") == "true" {
@@ -358,16 +464,44 @@ func (h *JobHandler) ListJobsPage(c *gin.Context) {
 		return
 	}
 
+	fitScores, err := h.service.GetJobFitScores(c.Request.Context(), userID, jobsWithPagination.Jobs)
+	if err != nil {
+		// Log error but don't fail the page load
+		h.service.LogError(err)
+	}
+
+	overdueReminders, err := h.service.GetOverdueReminderCounts(c.Request.Context(), userID, jobsWithPagination.Jobs)
+	if err != nil {
+		// Log error but don't fail the page load
+		h.service.LogError(err)
+	}
+
+	tags, err := h.service.GetTags(c.Request.Context(), userID)
+	if err != nil {
+		// Log error but don't fail the page load
+		h.service.LogError(err)
+	}
+
 	templateData := gin.H{
-		"title":        "Dashboard",
-		"page":         "dashboard",
-		"activeNav":    "jobs",
-		"pageTitle":    "Jobs",
-		"jobs":         jobsWithPagination.Jobs,
-		"pagination":   jobsWithPagination.Pagination,
-		"statusFilter": statusParam,
-		"sortBy":       sortByParam,
-		"sortOrder":    sortOrderParam,
+		"title":            "Dashboard",
+		"page":             "dashboard",
+		"activeNav":        "jobs",
+		"pageTitle":        "Jobs",
+		"jobs":             jobsWithPagination.Jobs,
+		"pagination":       jobsWithPagination.Pagination,
+		"statusFilter":     statusParam,
+		"categoryFilter":   categoryParam,
+		"categoryLabel":    categoryLabel,
+		"stages":           pipeline,
+		"sortBy":           sortByParam,
+		"sortOrder":        sortOrderParam,
+		"searchQuery":      searchQuery,
+		"fitScores":        fitScores,
+		"overdueReminders": overdueReminders,
+		"tagFilter":        filter.Tags,
+		"tagFilterParam":   tagFilterParam,
+		"tagMatch":         string(filter.TagMatch),
+		"tagOptions":       newTagFilterOptions(tags, filter.Tags),
 	}
 
 	// Check if this is an HTMX request
@@ -381,6 +515,55 @@ func (h *JobHandler) ListJobsPage(c *gin.Context) {
 	h.renderer.HTML(c, http.StatusOK, "layouts/base.html", templateData)
 }
 
+// pipelineFor returns the user's pipeline stages for a page listing them. A
+// failure falls back to the default pipeline, whose stage names still work
+// as statuses, rather than failing the page.
+func (h *JobHandler) pipelineFor(c *gin.Context, userID int) models.Pipeline {
+	pipeline, err := h.service.GetPipeline(c.Request.Context(), userID)
+	if err != nil || len(pipeline) == 0 {
+		if err != nil {
+			h.service.LogError(err)
+		}
+		return models.DefaultPipeline()
+	}
+	return pipeline
+}
+
+// tagFilterOption is one of the user's tags in the jobs list filter
+type tagFilterOption struct {
+	Name     string
+	JobCount int
+	Active   bool
+	// Toggle is the tags parameter with this tag added to or removed from
+	// the current filter
+	Toggle string
+}
+
+// newTagFilterOptions lists the user's tags for the jobs list filter, marking
+// the ones in the current filter
+func newTagFilterOptions(tags []*models.Tag, active []string) []tagFilterOption {
+	options := make([]tagFilterOption, 0, len(tags))
+	for _, tag := range tags {
+		option := tagFilterOption{Name: tag.Name, JobCount: tag.JobCount}
+
+		toggled := make([]string, 0, len(active)+1)
+		for _, name := range active {
+			if strings.EqualFold(name, tag.Name) {
+				option.Active = true
+				continue
+			}
+			toggled = append(toggled, name)
+		}
+		if !option.Active {
+			toggled = append(toggled, tag.Name)
+		}
+
+		option.Toggle = strings.Join(toggled, ",")
+		options = append(options, option)
+	}
+	return options
+}
+
 // GetNewJobForm renders the form for adding a new job.
 // It populates the template with user and page information.
 func (h *JobHandler) GetNewJobForm(c *gin.Context) {
@@ -389,6 +572,7 @@ func (h *JobHandler) GetNewJobForm(c *gin.Context) {
 		"page":      "job-new",
 		"activeNav": "newjob",
 		"pageTitle": "New Job",
+		"stages":    h.pipelineFor(c, c.GetInt("userID")),
 	})
 }
 
@@ -425,8 +609,12 @@ func (h *JobHandler) CreateJob(c *gin.Context) {
 	jobTypeStr := c.PostForm("job_type")
 	jobType := models.JobTypeFromString(jobTypeStr)
 
-	statusStr := c.PostForm("status")
-	status, err := models.JobStatusFromString(statusStr)
+	pipeline, err := h.service.GetPipeline(c.Request.Context(), userID)
+	if err != nil {
+		h.renderError(c, err)
+		return
+	}
+	stage, err := pipeline.StageFromString(c.PostForm("status"))
 	if err != nil {
 		h.renderError(c, err)
 		return
@@ -434,7 +622,7 @@ func (h *JobHandler) CreateJob(c *gin.Context) {
 
 	options := []models.JobOption{
 		models.WithJobType(jobType),
-		models.WithStatus(status),
+		models.WithStage(stage),
 		models.WithRequiredSkills(skills),
 	}
 
@@ -471,6 +659,29 @@ func (h *JobHandler) CreateJob(c *gin.Context) {
 	c.Header("HX-Redirect", fmt.Sprintf("/jobs/%d/details", job.ID))
 }
 
+// ExtractJobDraft handles the HTTP request to extract job details from a
+// pasted job posting. It responds with the new job form prefilled from the
+// extracted details for the user to review and save.
+func (h *JobHandler) ExtractJobDraft(c *gin.Context) {
+	userIDValue, exists := c.Get("userID")
+	if !exists {
+		h.renderError(c, models.ErrUnauthorized)
+		return
+	}
+	userID := userIDValue.(int)
+
+	draft, err := h.service.ExtractJobDraft(c.Request.Context(), userID, c.PostForm("raw_text"))
+	if err != nil {
+		h.renderError(c, err)
+		return
+	}
+
+	h.renderer.HTML(c, http.StatusOK, "partials/job-form", gin.H{
+		"draft":  draft,
+		"stages": h.pipelineFor(c, userID),
+	})
+}
+
 // GetJobDetails handles the HTTP request to retrieve and display details for a specific job.
 func (h *JobHandler) GetJobDetails(c *gin.Context) {
 	if h.cfg != nil && h.cfg.IsTest {
@@ -538,6 +749,36 @@ func (h *JobHandler) GetJobDetails(c *gin.Context) {
 		}
 	}
 
+	interviewPrep, err := h.service.GetInterviewPrep(ctx, userID, jobID)
+	if err != nil {
+		// Log error but don't fail the page load
+		h.service.LogError(err)
+	}
+
+	emails, err := h.service.GetEmailHistory(ctx, userID, jobID)
+	if err != nil {
+		// Log error but don't fail the page load
+		h.service.LogError(err)
+	}
+
+	coverLetterOptions, err := h.service.GetCoverLetterOptions(ctx, userID, jobID)
+	if err != nil {
+		// Log error but don't fail the page load
+		h.service.LogError(err)
+	}
+
+	injectionReview, err := h.service.GetInjectionReview(ctx, userID, jobID)
+	if err != nil {
+		// Log error but don't fail the page load
+		h.service.LogError(err)
+	}
+
+	similarJobs, err := h.service.GetSimilarJobs(ctx, userID, jobID)
+	if err != nil {
+		// Log error but don't fail the page load
+		h.service.LogError(err)
+	}
+
 	h.renderer.HTML(c, http.StatusOK, "layouts/base.html", gin.H{
 		"title":                  "Job Details",
 		"page":                   "job-details",
@@ -552,8 +793,21 @@ func (h *JobHandler) GetJobDetails(c *gin.Context) {
 			}
 			return models.GetSentinelError(profileValidationError).Error()
 		}(),
-		"quotaCheck":   quotaCheckResult,
-		"isReanalysis": job.FirstAnalyzedAt != nil,
+		"quotaCheck":       quotaCheckResult,
+		"isReanalysis":     job.FirstAnalyzedAt != nil,
+		"hasInterviewPrep": interviewPrep != nil,
+		"hasEmails":        len(emails) > 0,
+		"letterOptions":    coverLetterOptions,
+		"injectionReview":  injectionReview,
+		"similarJobs":      similarJobs,
+		"today":            time.Now().UTC().Format(models.StatusDateLayout),
+		"stages":           h.pipelineFor(c, userID),
+		"descriptionSegments": func() []models.TextSegment {
+			if !injectionReview.BlocksAI() {
+				return nil
+			}
+			return injectionReview.Highlight(job.Description)
+		}(),
 		"quotaRemaining": func() int {
 			if quotaCheckResult.Status.Limit < 0 {
 				return -1 // Unlimited
@@ -643,8 +897,10 @@ func (h *JobHandler) UpdateJobField(c *gin.Context) {
 		return
 	}
 
-	// Use dashboard-specific alert for all status updates
+	// Use dashboard-specific alert for all status updates, and let the
+	// status history on the details page refresh itself
 	if field == "status" {
+		c.Header("HX-Trigger-After-Settle", "statusChanged")
 		alerts.RenderSuccess(c, successMessage, alerts.ContextDashboard)
 		return
 	}
@@ -699,7 +955,7 @@ func (h *JobHandler) AnalyzeJobMatch(c *gin.Context) {
 	}
 	userID := userIDValue.(int)
 
-	ctx := c.Request.Context()
+	ctx := aiRequestContext(c)
 	if roleValue, exists := c.Get("role"); exists {
 		if role, ok := roleValue.(string); ok {
 			ctx = ctxutil.WithRole(ctx, role)
@@ -742,21 +998,72 @@ func (h *JobHandler) GenerateCoverLetter(c *gin.Context) {
 	}
 	userID := userIDValue.(int)
 
-	result, err := h.service.GenerateCoverLetter(c.Request.Context(), userID, jobID)
+	result, err := h.service.GenerateCoverLetter(aiRequestContext(c), userID, jobID, coverLetterOptions(c))
 	if err != nil {
 		alerts.RenderError(c, http.StatusBadRequest, models.GetSentinelError(err).Error(), alerts.ContextGeneral)
 		return
 	}
 
+	html, err := h.renderCoverLetter(c.Request.Context(), userID, jobID, result)
+	if err != nil {
+		alerts.RenderError(c, http.StatusInternalServerError, "Error rendering cover letter", alerts.ContextGeneral)
+		return
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(html))
+}
+
+// StreamCoverLetter generates a cover letter and streams it as Server-Sent
+// Events: "delta" events carry letter text as it is written, followed by a
+// single "done" event with the rendered editor or an "error" event. The
+// result is saved to the user's documents.
+func (h *JobHandler) StreamCoverLetter(c *gin.Context) {
+	jobID, userID, ok := h.streamIDs(c)
+	if !ok {
+		return
+	}
+
+	startEventStream(c)
+	ctx := aiRequestContext(c)
+
+	result, err := h.service.StreamCoverLetter(ctx, userID, jobID, coverLetterOptions(c), func(text string) error {
+		return sendEvent(c, "delta", gin.H{"text": text})
+	})
+	if err != nil {
+		_ = sendEvent(c, "error", gin.H{"message": models.GetSentinelError(err).Error()})
+		return
+	}
+
+	html, err := h.renderCoverLetter(ctx, userID, jobID, result)
+	if err != nil {
+		h.service.LogError(fmt.Errorf("error rendering cover letter template: %w", err))
+		_ = sendEvent(c, "error", gin.H{"message": "Error rendering cover letter"})
+		return
+	}
+	_ = sendEvent(c, "done", gin.H{"html": html, "saved": true})
+}
+
+// coverLetterOptions reads the tone, length and language chosen for a cover
+// letter from the request form. Options left empty are resolved by the
+// service.
+func coverLetterOptions(c *gin.Context) models.CoverLetterOptions {
+	return models.CoverLetterOptions{
+		Tone:     strings.TrimSpace(c.PostForm("tone")),
+		Length:   strings.TrimSpace(c.PostForm("length")),
+		Language: strings.TrimSpace(c.PostForm("language")),
+	}
+}
+
+// renderCoverLetter renders the cover letter editor partial.
+func (h *JobHandler) renderCoverLetter(ctx context.Context, userID, jobID int, result *models.CoverLetterWithProfile) (string, error) {
 	// Fetch job details for PDF naming
-	job, err := h.service.GetJob(c.Request.Context(), userID, jobID)
+	job, err := h.service.GetJob(ctx, userID, jobID)
 	if err != nil {
 		h.service.LogError(fmt.Errorf("error fetching job for cover letter generation: %w", err))
 		// Continue without job details. This is not critical for generation
 		job = &models.Job{}
 	}
 
-	html, err := h.renderTemplate("partials/cover_letter_generator.html", gin.H{
+	return h.renderTemplate("partials/cover_letter_generator.html", gin.H{
 		"CoverLetter": result.CoverLetter,
 		"GeneratedCV": gin.H{
 			"PersonalInfo": result.PersonalInfo,
@@ -765,11 +1072,6 @@ func (h *JobHandler) GenerateCoverLetter(c *gin.Context) {
 		"JobTitle":    job.Title,
 		"CompanyName": job.Company.Name,
 	})
-	if err != nil {
-		alerts.RenderError(c, http.StatusInternalServerError, "Error rendering cover letter", alerts.ContextGeneral)
-		return
-	}
-	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(html))
 }
 
 // GenerateCV handles the HTMX request to generate AI CV
@@ -788,28 +1090,14 @@ func (h *JobHandler) GenerateCV(c *gin.Context) {
 	}
 	userID := userIDValue.(int)
 
-	generatedCV, err := h.service.GenerateCV(c.Request.Context(), userID, jobID)
+	generatedCV, err := h.service.GenerateCV(aiRequestContext(c), userID, jobID, strings.TrimSpace(c.PostForm("language")))
 	if err != nil {
 		alerts.RenderError(c, http.StatusBadRequest, models.GetSentinelError(err).Error(), alerts.ContextGeneral)
 		return
 	}
 
-	// Fetch job details for PDF naming
-	job, err := h.service.GetJob(c.Request.Context(), userID, jobID)
-	if err != nil {
-		h.service.LogError(fmt.Errorf("error fetching job for CV generation: %w", err))
-		// Continue without job details. This is not critical for generation
-		job = &models.Job{}
-	}
-
-	html, err := h.renderTemplate("partials/cv_generator.html", gin.H{
-		"GeneratedCV": generatedCV,
-		"JobID":       jobID,
-		"JobTitle":    job.Title,
-		"CompanyName": job.Company.Name,
-	})
+	html, err := h.renderCV(c.Request.Context(), userID, jobID, generatedCV)
 	if err != nil {
-
 		h.service.LogError(fmt.Errorf("error rendering CV template: %w", err))
 		alerts.RenderError(c, http.StatusInternalServerError, "Error rendering CV", alerts.ContextGeneral)
 		return
@@ -817,131 +1105,894 @@ func (h *JobHandler) GenerateCV(c *gin.Context) {
 	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(html))
 }
 
-// buildMatchAnalysisData creates template data for match analysis
-func (h *JobHandler) buildMatchAnalysisData(analysis *models.JobMatchAnalysis) gin.H {
-	var matchCategory, matchColor string
-	if analysis.MatchScore >= 80 {
-		matchCategory = "Excellent Match"
-		matchColor = "#10b981" // green
-	} else if analysis.MatchScore >= 70 {
-		matchCategory = "Strong Match"
-		matchColor = "#10b981" // green
-	} else if analysis.MatchScore >= 60 {
-		matchCategory = "Good Match"
-		matchColor = "#f59e0b" // yellow
-	} else if analysis.MatchScore >= 40 {
-		matchCategory = "Fair Match"
-		matchColor = "#f59e0b" // yellow
-	} else {
-		matchCategory = "Weak Match"
-		matchColor = "#ef4444" // red
+// StreamCV generates a CV and streams it as Server-Sent Events: "delta"
+// events carry raw model output as it arrives, followed by a single "done"
+// event with the rendered editor or an "error" event. The result is saved to
+// the user's documents.
+func (h *JobHandler) StreamCV(c *gin.Context) {
+	jobID, userID, ok := h.streamIDs(c)
+	if !ok {
+		return
 	}
 
-	// Calculate stroke offset for the circle progress
-	// 339.292 is the circumference of the circle with radius 54
-	strokeOffset := 339.292 - (339.292 * float64(analysis.MatchScore) / 100)
+	startEventStream(c)
+	ctx := aiRequestContext(c)
 
-	return gin.H{
-		"Analysis":      analysis,
-		"MatchCategory": matchCategory,
-		"MatchColor":    matchColor,
-		"StrokeOffset":  strokeOffset,
+	generatedCV, err := h.service.StreamCV(ctx, userID, jobID, strings.TrimSpace(c.PostForm("language")), func(text string) error {
+		return sendEvent(c, "delta", gin.H{"text": text})
+	})
+	if err != nil {
+		_ = sendEvent(c, "error", gin.H{"message": models.GetSentinelError(err).Error()})
+		return
 	}
-}
 
-// renderTemplate renders a template to string with given data
-func (h *JobHandler) renderTemplate(templateName string, data interface{}) (string, error) {
-	tmpl, err := template.ParseFiles("templates/" + templateName)
+	html, err := h.renderCV(ctx, userID, jobID, generatedCV)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse template %s: %w", templateName, err)
+		h.service.LogError(fmt.Errorf("error rendering CV template: %w", err))
+		_ = sendEvent(c, "error", gin.H{"message": "Error rendering CV"})
+		return
 	}
+	_ = sendEvent(c, "done", gin.H{"html": html, "saved": true})
+}
 
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
-		return "", fmt.Errorf("failed to execute template %s: %w", templateName, err)
+// renderCV renders the CV editor partial.
+func (h *JobHandler) renderCV(ctx context.Context, userID, jobID int, generatedCV *models.GeneratedCV) (string, error) {
+	// Fetch job details for PDF naming
+	job, err := h.service.GetJob(ctx, userID, jobID)
+	if err != nil {
+		h.service.LogError(fmt.Errorf("error fetching job for CV generation: %w", err))
+		// Continue without job details. This is not critical for generation
+		job = &models.Job{}
 	}
 
-	return buf.String(), nil
+	return h.renderTemplate("partials/cv_generator.html", gin.H{
+		"GeneratedCV": generatedCV,
+		"Headings":    models.CVHeadingsFor(generatedCV.Language),
+		"Language":    models.CVLanguageOrDefault(generatedCV.Language),
+		"JobID":       jobID,
+		"JobTitle":    job.Title,
+		"CompanyName": job.Company.Name,
+	})
 }
 
-// GetMatchHistory handles the request to display match analysis history page for a specific job
-func (h *JobHandler) GetMatchHistory(c *gin.Context) {
+// GenerateInterviewPrep handles the HTMX request to generate an interview
+// preparation pack
+func (h *JobHandler) GenerateInterviewPrep(c *gin.Context) {
 	jobIDValue, exists := c.Get("jobID")
 	if !exists {
-		h.renderError(c, models.ErrInvalidJobIDFormat)
+		alerts.RenderError(c, http.StatusBadRequest, "Invalid job ID format", alerts.ContextGeneral)
 		return
 	}
 	jobID := jobIDValue.(int)
-	jobIDStr := c.Param("id")
 
 	userIDValue, exists := c.Get("userID")
 	if !exists {
-		h.renderError(c, models.ErrUnauthorized)
+		alerts.RenderError(c, http.StatusUnauthorized, "Authentication required", alerts.ContextGeneral)
 		return
 	}
 	userID := userIDValue.(int)
 
-	job, err := h.service.GetJob(c.Request.Context(), userID, jobID)
-	if err != nil {
-		if errors.Is(err, models.ErrJobNotFound) {
-			h.renderer.Error(c, http.StatusNotFound, "Page Not Found")
-			return
-		}
-		h.renderError(c, err)
-		return
-	}
-
-	matchHistory, err := h.service.GetJobMatchHistory(c.Request.Context(), userID, jobID)
+	prep, err := h.service.GenerateInterviewPrep(aiRequestContext(c), userID, jobID)
 	if err != nil {
-		h.renderError(c, err)
+		alerts.RenderError(c, http.StatusBadRequest, models.GetSentinelError(err).Error(), alerts.ContextGeneral)
 		return
 	}
 
-	h.renderer.HTML(c, http.StatusOK, "layouts/base.html", gin.H{
-		"title":        "Match History",
-		"page":         "match-history",
-		"activeNav":    "jobs",
-		"pageTitle":    "Match History",
-		"job":          job,
-		"jobID":        jobIDStr,
-		"matchHistory": matchHistory,
-	})
+	h.renderInterviewPrep(c, jobID, prep)
 }
 
-// DeleteMatchResult handles the request to delete a specific match result
-func (h *JobHandler) DeleteMatchResult(c *gin.Context) {
+// GetInterviewPrep renders the saved interview preparation pack for a job.
+// It responds with 204 No Content when no pack has been generated.
+func (h *JobHandler) GetInterviewPrep(c *gin.Context) {
 	jobIDValue, exists := c.Get("jobID")
 	if !exists {
-		h.renderError(c, models.ErrInvalidJobIDFormat)
+		alerts.RenderError(c, http.StatusBadRequest, "Invalid job ID format", alerts.ContextGeneral)
 		return
 	}
 	jobID := jobIDValue.(int)
 
 	userIDValue, exists := c.Get("userID")
 	if !exists {
-		h.renderError(c, models.ErrUnauthorized)
+		alerts.RenderError(c, http.StatusUnauthorized, "Authentication required", alerts.ContextGeneral)
 		return
 	}
 	userID := userIDValue.(int)
 
-	matchIDStr := c.Param("matchId")
-	matchID, err := strconv.Atoi(matchIDStr)
+	prep, err := h.service.GetInterviewPrep(c.Request.Context(), userID, jobID)
 	if err != nil {
-		h.renderError(c, models.ErrInvalidJobIDFormat)
+		h.service.LogError(fmt.Errorf("error loading interview prep: %w", err))
+		alerts.RenderError(c, http.StatusInternalServerError, "Error loading interview prep", alerts.ContextGeneral)
+		return
+	}
+	if prep == nil {
+		c.Status(http.StatusNoContent)
 		return
 	}
 
-	err = h.service.DeleteMatchResult(c.Request.Context(), userID, jobID, matchID)
+	h.renderInterviewPrep(c, jobID, prep)
+}
+
+// renderInterviewPrep writes the interview prep partial to the response.
+func (h *JobHandler) renderInterviewPrep(c *gin.Context, jobID int, prep *models.InterviewPrep) {
+	html, err := h.renderTemplate("partials/interview_prep.html", gin.H{
+		"InterviewPrep": prep,
+		"JobID":         jobID,
+	})
 	if err != nil {
-		h.renderError(c, err)
+		h.service.LogError(fmt.Errorf("error rendering interview prep template: %w", err))
+		alerts.RenderError(c, http.StatusInternalServerError, "Error rendering interview prep", alerts.ContextGeneral)
 		return
 	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(html))
+}
 
-	if c.GetHeader("HX-Request") == "true" {
-		c.Header("HX-Redirect", fmt.Sprintf("/jobs/%d/match-history", jobID))
-		c.String(http.StatusOK, "")
+// GenerateEmail handles the HTMX request to generate an application email
+// for a job. It renders the job's email history with the new email first.
+func (h *JobHandler) GenerateEmail(c *gin.Context) {
+	jobIDValue, exists := c.Get("jobID")
+	if !exists {
+		alerts.RenderError(c, http.StatusBadRequest, "Invalid job ID format", alerts.ContextGeneral)
 		return
 	}
+	jobID := jobIDValue.(int)
 
-	c.Redirect(http.StatusFound, fmt.Sprintf("/jobs/%d/match-history", jobID))
+	userIDValue, exists := c.Get("userID")
+	if !exists {
+		alerts.RenderError(c, http.StatusUnauthorized, "Authentication required", alerts.ContextGeneral)
+		return
+	}
+	userID := userIDValue.(int)
+
+	email, err := h.service.GenerateEmail(aiRequestContext(c), userID, jobID,
+		c.PostForm("kind"), c.PostForm("tone"), c.PostForm("note"))
+	if err != nil {
+		alerts.RenderError(c, http.StatusBadRequest, models.GetSentinelError(err).Error(), alerts.ContextGeneral)
+		return
+	}
+
+	emails, err := h.service.GetEmailHistory(c.Request.Context(), userID, jobID)
+	if err != nil || len(emails) == 0 {
+		if err != nil {
+			h.service.LogError(fmt.Errorf("error loading email history: %w", err))
+		}
+		emails = []*models.JobEmail{email}
+	}
+
+	h.renderEmails(c, jobID, emails)
+}
+
+// GetEmails renders the emails generated for a job. It responds with 204 No
+// Content when none have been generated.
+func (h *JobHandler) GetEmails(c *gin.Context) {
+	jobIDValue, exists := c.Get("jobID")
+	if !exists {
+		alerts.RenderError(c, http.StatusBadRequest, "Invalid job ID format", alerts.ContextGeneral)
+		return
+	}
+	jobID := jobIDValue.(int)
+
+	userIDValue, exists := c.Get("userID")
+	if !exists {
+		alerts.RenderError(c, http.StatusUnauthorized, "Authentication required", alerts.ContextGeneral)
+		return
+	}
+	userID := userIDValue.(int)
+
+	emails, err := h.service.GetEmailHistory(c.Request.Context(), userID, jobID)
+	if err != nil {
+		h.service.LogError(fmt.Errorf("error loading email history: %w", err))
+		alerts.RenderError(c, http.StatusInternalServerError, "Error loading emails", alerts.ContextGeneral)
+		return
+	}
+	if len(emails) == 0 {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	h.renderEmails(c, jobID, emails)
+}
+
+// renderEmails writes the email history partial to the response.
+func (h *JobHandler) renderEmails(c *gin.Context, jobID int, emails []*models.JobEmail) {
+	html, err := h.renderTemplate("partials/job_emails.html", gin.H{
+		"Latest":  emails[0],
+		"History": emails[1:],
+		"JobID":   jobID,
+	})
+	if err != nil {
+		h.service.LogError(fmt.Errorf("error rendering emails template: %w", err))
+		alerts.RenderError(c, http.StatusInternalServerError, "Error rendering emails", alerts.ContextGeneral)
+		return
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(html))
+}
+
+// RewriteSection handles the HTMX request to rewrite one section of a
+// generated CV or cover letter. It renders the rewrite as a before and after
+// diff for the user to accept or reject; nothing is saved.
+func (h *JobHandler) RewriteSection(c *gin.Context) {
+	jobIDValue, exists := c.Get("jobID")
+	if !exists {
+		alerts.RenderError(c, http.StatusBadRequest, "Invalid job ID format", alerts.ContextGeneral)
+		return
+	}
+	jobID := jobIDValue.(int)
+
+	userIDValue, exists := c.Get("userID")
+	if !exists {
+		alerts.RenderError(c, http.StatusUnauthorized, "Authentication required", alerts.ContextGeneral)
+		return
+	}
+	userID := userIDValue.(int)
+
+	rewrite, err := h.service.RewriteSection(aiRequestContext(c), userID, jobID,
+		c.PostForm("section"), c.PostForm("text"), c.PostForm("instruction"))
+	if err != nil {
+		alerts.RenderError(c, http.StatusBadRequest, models.GetSentinelError(err).Error(), alerts.ContextGeneral)
+		return
+	}
+
+	html, err := h.renderTemplate("partials/section_rewrite.html", gin.H{
+		"Rewrite": rewrite,
+	})
+	if err != nil {
+		h.service.LogError(fmt.Errorf("error rendering section rewrite template: %w", err))
+		alerts.RenderError(c, http.StatusInternalServerError, "Error rendering rewrite", alerts.ContextGeneral)
+		return
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(html))
+}
+
+// GetLearningPlanPage displays the user's recurring skill gaps and their
+// learning plans under settings.
+func (h *JobHandler) GetLearningPlanPage(c *gin.Context) {
+	userID := c.GetInt("userID")
+
+	report, err := h.service.GetSkillGaps(c.Request.Context(), userID)
+	if err != nil {
+		h.service.LogError(fmt.Errorf("error loading skill gaps: %w", err))
+		h.renderer.Error(c, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	h.renderer.HTML(c, http.StatusOK, "layouts/base.html", gin.H{
+		"title":          "Learning Plan",
+		"page":           "settings-learning",
+		"activeNav":      "learning",
+		"activeSettings": "learning",
+		"pageTitle":      "Learning Plan",
+		"report":         report,
+	})
+}
+
+// GenerateLearningPlan handles the HTMX request to plan the most frequent
+// open skill gaps. It renders the updated list of gaps.
+func (h *JobHandler) GenerateLearningPlan(c *gin.Context) {
+	userID := c.GetInt("userID")
+
+	report, err := h.service.GenerateLearningPlan(aiRequestContext(c), userID)
+	if err != nil {
+		h.handleSkillGapError(c, err)
+		return
+	}
+
+	alerts.TriggerToast(c, "Learning plan generated", alerts.TypeSuccess)
+	h.renderer.HTML(c, http.StatusOK, "partials/skill-gaps", gin.H{"report": report})
+}
+
+// MarkSkillGapAddressed handles the HTMX request to mark a skill gap as
+// addressed. It renders the updated list of gaps.
+func (h *JobHandler) MarkSkillGapAddressed(c *gin.Context) {
+	userID := c.GetInt("userID")
+
+	report, err := h.service.MarkSkillGapAddressed(c.Request.Context(), userID, c.PostForm("key"), c.PostForm("note"))
+	if err != nil {
+		h.handleSkillGapError(c, err)
+		return
+	}
+
+	alerts.TriggerToast(c, "Skill gap marked as addressed and added to your profile context", alerts.TypeSuccess)
+	h.renderer.HTML(c, http.StatusOK, "partials/skill-gaps", gin.H{"report": report})
+}
+
+// handleSkillGapError responds to a failed skill gap request with an error
+// toast.
+func (h *JobHandler) handleSkillGapError(c *gin.Context, err error) {
+	switch sentinel := models.GetSentinelError(err); {
+	case errors.Is(err, models.ErrSkillGapNotFound):
+		alerts.TriggerToast(c, sentinel.Error(), alerts.TypeError)
+		c.Status(http.StatusNotFound)
+	case errors.Is(err, models.ErrFailedToGetJob),
+		errors.Is(err, models.ErrFailedToSaveSkillGap),
+		errors.Is(err, models.ErrSkillGapStoreRequired),
+		errors.Is(err, models.ErrProfileServiceRequired):
+		h.service.LogError(fmt.Errorf("skill gap request failed: %w", err))
+		alerts.TriggerToast(c, "Something went wrong. Please try again", alerts.TypeError)
+		c.Status(http.StatusInternalServerError)
+	default:
+		alerts.TriggerToast(c, sentinel.Error(), alerts.TypeError)
+		c.Status(http.StatusBadRequest)
+	}
+}
+
+// GetPipelinePage displays the user's pipeline stages under settings.
+func (h *JobHandler) GetPipelinePage(c *gin.Context) {
+	userID := c.GetInt("userID")
+
+	pipeline, err := h.service.GetPipeline(c.Request.Context(), userID)
+	if err != nil {
+		h.service.LogError(fmt.Errorf("error loading pipeline: %w", err))
+		h.renderer.Error(c, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	h.renderer.HTML(c, http.StatusOK, "layouts/base.html", gin.H{
+		"title":          "Pipeline",
+		"page":           "settings-pipeline",
+		"activeNav":      "pipeline",
+		"activeSettings": "pipeline",
+		"pageTitle":      "Pipeline",
+		"stages":         pipeline,
+		"categories":     models.JobStatuses(),
+	})
+}
+
+// CreatePipelineStage handles the HTMX request to add a stage to the end of
+// the user's pipeline. It renders the updated list of stages.
+func (h *JobHandler) CreatePipelineStage(c *gin.Context) {
+	userID := c.GetInt("userID")
+
+	category, err := models.JobStatusFromString(c.PostForm("category"))
+	if err != nil {
+		h.handlePipelineError(c, err)
+		return
+	}
+
+	stage, err := h.service.CreateStage(c.Request.Context(), userID, c.PostForm("name"), category)
+	if err != nil {
+		h.handlePipelineError(c, err)
+		return
+	}
+
+	alerts.TriggerToast(c, fmt.Sprintf("Stage %q added", stage.Name), alerts.TypeSuccess)
+	h.renderPipelineStages(c, userID)
+}
+
+// UpdatePipelineStage handles the HTMX request to rename a stage and set its
+// category. It renders the updated list of stages.
+func (h *JobHandler) UpdatePipelineStage(c *gin.Context) {
+	userID := c.GetInt("userID")
+
+	stageID, err := strconv.Atoi(c.Param("stageId"))
+	if err != nil {
+		h.handlePipelineError(c, models.ErrStageNotFound)
+		return
+	}
+	category, err := models.JobStatusFromString(c.PostForm("category"))
+	if err != nil {
+		h.handlePipelineError(c, err)
+		return
+	}
+
+	if err := h.service.UpdateStage(c.Request.Context(), userID, stageID, c.PostForm("name"), category); err != nil {
+		h.handlePipelineError(c, err)
+		return
+	}
+
+	alerts.TriggerToast(c, "Stage updated", alerts.TypeSuccess)
+	h.renderPipelineStages(c, userID)
+}
+
+// MovePipelineStage handles the HTMX request to move a stage one place up or
+// down the pipeline. It renders the updated list of stages.
+func (h *JobHandler) MovePipelineStage(c *gin.Context) {
+	userID := c.GetInt("userID")
+
+	stageID, err := strconv.Atoi(c.Param("stageId"))
+	if err != nil {
+		h.handlePipelineError(c, models.ErrStageNotFound)
+		return
+	}
+	offset := -1
+	if c.PostForm("direction") == "down" {
+		offset = 1
+	}
+
+	if err := h.service.MoveStage(c.Request.Context(), userID, stageID, offset); err != nil {
+		h.handlePipelineError(c, err)
+		return
+	}
+	h.renderPipelineStages(c, userID)
+}
+
+// DeletePipelineStage handles the HTMX request to remove a stage, moving its
+// jobs to the stage given by the move_to parameter. It renders the updated
+// list of stages.
+func (h *JobHandler) DeletePipelineStage(c *gin.Context) {
+	userID := c.GetInt("userID")
+
+	stageID, err := strconv.Atoi(c.Param("stageId"))
+	if err != nil {
+		h.handlePipelineError(c, models.ErrStageNotFound)
+		return
+	}
+	replacementID, err := strconv.Atoi(c.Query("move_to"))
+	if err != nil {
+		h.handlePipelineError(c, models.ErrStageNotFound)
+		return
+	}
+
+	if err := h.service.DeleteStage(c.Request.Context(), userID, stageID, replacementID); err != nil {
+		h.handlePipelineError(c, err)
+		return
+	}
+
+	alerts.TriggerToast(c, "Stage removed", alerts.TypeSuccess)
+	h.renderPipelineStages(c, userID)
+}
+
+// renderPipelineStages renders the user's pipeline stages for the settings
+// page.
+func (h *JobHandler) renderPipelineStages(c *gin.Context, userID int) {
+	pipeline, err := h.service.GetPipeline(c.Request.Context(), userID)
+	if err != nil {
+		h.handlePipelineError(c, err)
+		return
+	}
+
+	h.renderer.HTML(c, http.StatusOK, "partials/pipeline-stages", gin.H{
+		"stages":     pipeline,
+		"categories": models.JobStatuses(),
+	})
+}
+
+// handlePipelineError responds to a failed pipeline request with an error
+// toast.
+func (h *JobHandler) handlePipelineError(c *gin.Context, err error) {
+	switch sentinel := models.GetSentinelError(err); {
+	case errors.Is(err, models.ErrStageNotFound):
+		alerts.TriggerToast(c, sentinel.Error(), alerts.TypeError)
+		c.Status(http.StatusNotFound)
+	case errors.Is(err, models.ErrFailedToGetPipeline),
+		errors.Is(err, models.ErrFailedToSavePipeline),
+		errors.Is(err, models.ErrPipelineStoreRequired):
+		h.service.LogError(fmt.Errorf("pipeline request failed: %w", err))
+		alerts.TriggerToast(c, "Something went wrong. Please try again", alerts.TypeError)
+		c.Status(http.StatusInternalServerError)
+	default:
+		alerts.TriggerToast(c, sentinel.Error(), alerts.TypeError)
+		c.Status(http.StatusBadRequest)
+	}
+}
+
+// streamIDs reads the job and user IDs for a streaming request, responding
+// with a plain error status when either is missing.
+func (h *JobHandler) streamIDs(c *gin.Context) (jobID, userID int, ok bool) {
+	jobIDValue, exists := c.Get("jobID")
+	if !exists {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID format"})
+		return 0, 0, false
+	}
+
+	userIDValue, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return 0, 0, false
+	}
+
+	return jobIDValue.(int), userIDValue.(int), true
+}
+
+// aiRequestContext returns the request context, marked to bypass cached AI
+// responses when the client sent force_refresh=true.
+func aiRequestContext(c *gin.Context) context.Context {
+	ctx := c.Request.Context()
+
+	value := c.Query("force_refresh")
+	if value == "" {
+		value = c.PostForm("force_refresh")
+	}
+	if force, _ := strconv.ParseBool(value); force {
+		ctx = llm.WithForceRefresh(ctx)
+	}
+	return ctx
+}
+
+// startEventStream writes the response headers for a Server-Sent Events stream.
+func startEventStream(c *gin.Context) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// Disable proxy buffering so events reach the browser immediately
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+}
+
+// sendEvent writes a single Server-Sent Event and flushes it to the client.
+// It returns an error once the client has gone away.
+func sendEvent(c *gin.Context, event string, data any) error {
+	if err := c.Request.Context().Err(); err != nil {
+		return err
+	}
+	c.SSEvent(event, data)
+	c.Writer.Flush()
+	return nil
+}
+
+// buildMatchAnalysisData creates template data for match analysis
+func (h *JobHandler) buildMatchAnalysisData(analysis *models.JobMatchAnalysis) gin.H {
+	var matchCategory, matchColor string
+	if analysis.MatchScore >= 80 {
+		matchCategory = "Excellent Match"
+		matchColor = "#10b981" // green
+	} else if analysis.MatchScore >= 70 {
+		matchCategory = "Strong Match"
+		matchColor = "#10b981" // green
+	} else if analysis.MatchScore >= 60 {
+		matchCategory = "Good Match"
+		matchColor = "#f59e0b" // yellow
+	} else if analysis.MatchScore >= 40 {
+		matchCategory = "Fair Match"
+		matchColor = "#f59e0b" // yellow
+	} else {
+		matchCategory = "Weak Match"
+		matchColor = "#ef4444" // red
+	}
+
+	// Calculate stroke offset for the circle progress
+	// 339.292 is the circumference of the circle with radius 54
+	strokeOffset := 339.292 - (339.292 * float64(analysis.MatchScore) / 100)
+
+	return gin.H{
+		"Analysis":      analysis,
+		"MatchCategory": matchCategory,
+		"MatchColor":    matchColor,
+		"StrokeOffset":  strokeOffset,
+	}
+}
+
+// renderTemplate renders a template to string with given data
+func (h *JobHandler) renderTemplate(templateName string, data interface{}) (string, error) {
+	tmpl, err := template.ParseFiles("templates/" + templateName)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template %s: %w", templateName, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute template %s: %w", templateName, err)
+	}
+
+	return buf.String(), nil
+}
+
+// GetMatchHistory handles the request to display match analysis history page for a specific job
+func (h *JobHandler) GetMatchHistory(c *gin.Context) {
+	jobIDValue, exists := c.Get("jobID")
+	if !exists {
+		h.renderError(c, models.ErrInvalidJobIDFormat)
+		return
+	}
+	jobID := jobIDValue.(int)
+	jobIDStr := c.Param("id")
+
+	userIDValue, exists := c.Get("userID")
+	if !exists {
+		h.renderError(c, models.ErrUnauthorized)
+		return
+	}
+	userID := userIDValue.(int)
+
+	job, err := h.service.GetJob(c.Request.Context(), userID, jobID)
+	if err != nil {
+		if errors.Is(err, models.ErrJobNotFound) {
+			h.renderer.Error(c, http.StatusNotFound, "Page Not Found")
+			return
+		}
+		h.renderError(c, err)
+		return
+	}
+
+	matchHistory, err := h.service.GetJobMatchHistory(c.Request.Context(), userID, jobID)
+	if err != nil {
+		h.renderError(c, err)
+		return
+	}
+
+	h.renderer.HTML(c, http.StatusOK, "layouts/base.html", gin.H{
+		"title":        "Match History",
+		"page":         "match-history",
+		"activeNav":    "jobs",
+		"pageTitle":    "Match History",
+		"job":          job,
+		"jobID":        jobIDStr,
+		"matchHistory": matchHistory,
+	})
+}
+
+// DeleteMatchResult handles the request to delete a specific match result
+func (h *JobHandler) DeleteMatchResult(c *gin.Context) {
+	jobIDValue, exists := c.Get("jobID")
+	if !exists {
+		h.renderError(c, models.ErrInvalidJobIDFormat)
+		return
+	}
+	jobID := jobIDValue.(int)
+
+	userIDValue, exists := c.Get("userID")
+	if !exists {
+		h.renderError(c, models.ErrUnauthorized)
+		return
+	}
+	userID := userIDValue.(int)
+
+	matchIDStr := c.Param("matchId")
+	matchID, err := strconv.Atoi(matchIDStr)
+	if err != nil {
+		h.renderError(c, models.ErrInvalidJobIDFormat)
+		return
+	}
+
+	err = h.service.DeleteMatchResult(c.Request.Context(), userID, jobID, matchID)
+	if err != nil {
+		h.renderError(c, err)
+		return
+	}
+
+	if c.GetHeader("HX-Request") == "true" {
+		c.Header("HX-Redirect", fmt.Sprintf("/jobs/%d/match-history", jobID))
+		c.String(http.StatusOK, "")
+		return
+	}
+
+	c.Redirect(http.StatusFound, fmt.Sprintf("/jobs/%d/match-history", jobID))
+}
+
+// ApproveInjectionReview lets AI features run on a job whose description was
+// flagged as a likely prompt injection, after the user has reviewed it.
+func (h *JobHandler) ApproveInjectionReview(c *gin.Context) {
+	jobIDValue, exists := c.Get("jobID")
+	if !exists {
+		h.renderError(c, models.ErrInvalidJobIDFormat)
+		return
+	}
+	jobID := jobIDValue.(int)
+
+	userIDValue, exists := c.Get("userID")
+	if !exists {
+		h.renderError(c, models.ErrUnauthorized)
+		return
+	}
+	userID := userIDValue.(int)
+
+	if err := h.service.ApproveInjectionReview(c.Request.Context(), userID, jobID); err != nil {
+		h.renderError(c, err)
+		return
+	}
+
+	if c.GetHeader("HX-Request") == "true" {
+		alerts.TriggerToast(c, "Job description approved for AI features", alerts.TypeSuccess)
+		c.Header("HX-Redirect", fmt.Sprintf("/jobs/%d/details", jobID))
+		c.String(http.StatusOK, "")
+		return
+	}
+
+	c.Redirect(http.StatusFound, fmt.Sprintf("/jobs/%d/details", jobID))
+}
+
+// GetStatusTimeline renders the history of a job's status changes
+func (h *JobHandler) GetStatusTimeline(c *gin.Context) {
+	jobIDValue, exists := c.Get("jobID")
+	if !exists {
+		alerts.RenderError(c, http.StatusBadRequest, "Invalid job ID format", alerts.ContextGeneral)
+		return
+	}
+	jobID := jobIDValue.(int)
+
+	userIDValue, exists := c.Get("userID")
+	if !exists {
+		alerts.RenderError(c, http.StatusUnauthorized, "Authentication required", alerts.ContextGeneral)
+		return
+	}
+	userID := userIDValue.(int)
+
+	timeline, err := h.service.GetStatusTimeline(c.Request.Context(), userID, jobID)
+	if err != nil {
+		h.service.LogError(fmt.Errorf("error loading status history: %w", err))
+		alerts.RenderError(c, http.StatusInternalServerError, "Error loading status history", alerts.ContextGeneral)
+		return
+	}
+
+	h.renderStatusTimeline(c, jobID, timeline)
+}
+
+// UpdateStatusEvent sets the date and note of a recorded status change, then
+// renders the updated history
+func (h *JobHandler) UpdateStatusEvent(c *gin.Context) {
+	jobIDValue, exists := c.Get("jobID")
+	if !exists {
+		h.renderError(c, models.ErrInvalidJobIDFormat)
+		return
+	}
+	jobID := jobIDValue.(int)
+
+	userIDValue, exists := c.Get("userID")
+	if !exists {
+		h.renderError(c, models.ErrUnauthorized)
+		return
+	}
+	userID := userIDValue.(int)
+
+	eventID, err := strconv.Atoi(c.Param("eventId"))
+	if err != nil || eventID <= 0 {
+		h.renderError(c, models.ErrStatusEventNotFound)
+		return
+	}
+
+	ctx := c.Request.Context()
+	err = h.service.UpdateStatusEvent(ctx, userID, jobID, eventID, c.PostForm("note"), c.PostForm("date"))
+	if err != nil {
+		h.renderError(c, err)
+		return
+	}
+
+	timeline, err := h.service.GetStatusTimeline(ctx, userID, jobID)
+	if err != nil {
+		h.service.LogError(fmt.Errorf("error loading status history: %w", err))
+		alerts.RenderError(c, http.StatusInternalServerError, "Error loading status history", alerts.ContextGeneral)
+		return
+	}
+
+	alerts.TriggerToast(c, "Status history updated", alerts.TypeSuccess)
+	h.renderStatusTimeline(c, jobID, timeline)
+}
+
+// renderStatusTimeline writes the status history partial to the response.
+func (h *JobHandler) renderStatusTimeline(c *gin.Context, jobID int, timeline []models.StatusTimelineEntry) {
+	csrfToken, _ := c.Get("csrfToken")
+	html, err := h.renderTemplate("partials/status_timeline.html", gin.H{
+		"Timeline":  timeline,
+		"JobID":     jobID,
+		"Today":     time.Now().UTC().Format(models.StatusDateLayout),
+		"csrfToken": csrfToken,
+	})
+	if err != nil {
+		h.service.LogError(fmt.Errorf("error rendering status history template: %w", err))
+		alerts.RenderError(c, http.StatusInternalServerError, "Error rendering status history", alerts.ContextGeneral)
+		return
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(html))
+}
+
+// GetJobReminders renders a job's reminders
+func (h *JobHandler) GetJobReminders(c *gin.Context) {
+	jobIDValue, exists := c.Get("jobID")
+	if !exists {
+		alerts.RenderError(c, http.StatusBadRequest, "Invalid job ID format", alerts.ContextGeneral)
+		return
+	}
+	jobID := jobIDValue.(int)
+
+	userIDValue, exists := c.Get("userID")
+	if !exists {
+		alerts.RenderError(c, http.StatusUnauthorized, "Authentication required", alerts.ContextGeneral)
+		return
+	}
+	userID := userIDValue.(int)
+
+	h.renderReminders(c, userID, jobID)
+}
+
+// CreateReminder adds a reminder to a job, then renders the job's reminders
+func (h *JobHandler) CreateReminder(c *gin.Context) {
+	jobIDValue, exists := c.Get("jobID")
+	if !exists {
+		h.renderError(c, models.ErrInvalidJobIDFormat)
+		return
+	}
+	jobID := jobIDValue.(int)
+
+	userIDValue, exists := c.Get("userID")
+	if !exists {
+		h.renderError(c, models.ErrUnauthorized)
+		return
+	}
+	userID := userIDValue.(int)
+
+	_, err := h.service.CreateReminder(c.Request.Context(), userID, jobID,
+		c.PostForm("message"),
+		c.PostForm("due"),
+		c.PostForm("tz_offset"),
+		c.PostForm("recurrence"),
+	)
+	if err != nil {
+		h.renderError(c, err)
+		return
+	}
+
+	alerts.TriggerToast(c, "Reminder added", alerts.TypeSuccess)
+	h.renderReminders(c, userID, jobID)
+}
+
+// CompleteReminder marks a reminder done, or moves a recurring one to its
+// next due time, then renders the job's reminders
+func (h *JobHandler) CompleteReminder(c *gin.Context) {
+	jobID, userID, reminderID, ok := h.reminderIDs(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.CompleteReminder(c.Request.Context(), userID, jobID, reminderID); err != nil {
+		h.renderError(c, err)
+		return
+	}
+
+	alerts.TriggerToast(c, "Reminder done", alerts.TypeSuccess)
+	h.renderReminders(c, userID, jobID)
+}
+
+// DeleteReminder removes a reminder, then renders the job's reminders
+func (h *JobHandler) DeleteReminder(c *gin.Context) {
+	jobID, userID, reminderID, ok := h.reminderIDs(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteReminder(c.Request.Context(), userID, jobID, reminderID); err != nil {
+		h.renderError(c, err)
+		return
+	}
+
+	alerts.TriggerToast(c, "Reminder deleted", alerts.TypeSuccess)
+	h.renderReminders(c, userID, jobID)
+}
+
+// reminderIDs reads the job, user and reminder IDs of a request on one of a
+// job's reminders, rendering an error if any is missing
+func (h *JobHandler) reminderIDs(c *gin.Context) (jobID, userID, reminderID int, ok bool) {
+	jobIDValue, exists := c.Get("jobID")
+	if !exists {
+		h.renderError(c, models.ErrInvalidJobIDFormat)
+		return 0, 0, 0, false
+	}
+
+	userIDValue, exists := c.Get("userID")
+	if !exists {
+		h.renderError(c, models.ErrUnauthorized)
+		return 0, 0, 0, false
+	}
+
+	reminderID, err := strconv.Atoi(c.Param("reminderId"))
+	if err != nil || reminderID <= 0 {
+		h.renderError(c, models.ErrReminderNotFound)
+		return 0, 0, 0, false
+	}
+
+	return jobIDValue.(int), userIDValue.(int), reminderID, true
+}
+
+// renderReminders writes the reminders partial of a job to the response.
+func (h *JobHandler) renderReminders(c *gin.Context, userID, jobID int) {
+	reminders, err := h.service.GetJobReminders(c.Request.Context(), userID, jobID)
+	if err != nil {
+		h.service.LogError(fmt.Errorf("error loading reminders: %w", err))
+		alerts.RenderError(c, http.StatusInternalServerError, "Error loading reminders", alerts.ContextGeneral)
+		return
+	}
+
+	csrfToken, _ := c.Get("csrfToken")
+	html, err := h.renderTemplate("partials/job_reminders.html", gin.H{
+		"Reminders":   reminders,
+		"JobID":       jobID,
+		"Recurrences": models.ReminderRecurrences(),
+		"MaxLength":   models.MaxReminderMessageLength,
+		"csrfToken":   csrfToken,
+	})
+	if err != nil {
+		h.service.LogError(fmt.Errorf("error rendering reminders template: %w", err))
+		alerts.RenderError(c, http.StatusInternalServerError, "Error rendering reminders", alerts.ContextGeneral)
+		return
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(html))
 }
@@ -2,10 +2,14 @@ package job
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/benidevo/vega/internal/ai/llm"
 	"github.com/benidevo/vega/internal/common/alerts"
 	"github.com/benidevo/vega/internal/common/testutil"
 	"github.com/benidevo/vega/internal/config"
@@ -13,6 +17,7 @@ import (
 	"github.com/benidevo/vega/internal/quota"
 	settingsmodels "github.com/benidevo/vega/internal/settings/models"
 	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -93,19 +98,112 @@ func (m *mockJobService) AnalyzeJobMatch(ctx context.Context, userID int, jobID
 	return args.Get(0).(*models.JobMatchAnalysis), args.Error(1)
 }
 
-func (m *mockJobService) GenerateCoverLetter(ctx context.Context, userID int, jobID int) (*models.CoverLetterWithProfile, error) {
-	args := m.Called(ctx, userID, jobID)
+func (m *mockJobService) GenerateCoverLetter(ctx context.Context, userID int, jobID int, options models.CoverLetterOptions) (*models.CoverLetterWithProfile, error) {
+	args := m.Called(ctx, userID, jobID, options)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.CoverLetterWithProfile), args.Error(1)
 }
 
-func (m *mockJobService) GenerateCV(ctx context.Context, userID int, jobID int) (*models.GeneratedCV, error) {
+func (m *mockJobService) GetCoverLetterOptions(ctx context.Context, userID, jobID int) (models.CoverLetterOptions, error) {
+	args := m.Called(ctx, userID, jobID)
+	return args.Get(0).(models.CoverLetterOptions), args.Error(1)
+}
+
+func (m *mockJobService) GenerateCV(ctx context.Context, userID int, jobID int, language string) (*models.GeneratedCV, error) {
+	args := m.Called(ctx, userID, jobID, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GeneratedCV), args.Error(1)
+}
+
+func (m *mockJobService) GenerateInterviewPrep(ctx context.Context, userID int, jobID int) (*models.InterviewPrep, error) {
+	args := m.Called(ctx, userID, jobID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.InterviewPrep), args.Error(1)
+}
+
+func (m *mockJobService) GenerateEmail(ctx context.Context, userID, jobID int, kind, tone, note string) (*models.JobEmail, error) {
+	args := m.Called(ctx, userID, jobID, kind, tone, note)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.JobEmail), args.Error(1)
+}
+
+func (m *mockJobService) GetEmailHistory(ctx context.Context, userID, jobID int) ([]*models.JobEmail, error) {
+	args := m.Called(ctx, userID, jobID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.JobEmail), args.Error(1)
+}
+
+func (m *mockJobService) GetSkillGaps(ctx context.Context, userID int) (*models.SkillGapReport, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SkillGapReport), args.Error(1)
+}
+
+func (m *mockJobService) GenerateLearningPlan(ctx context.Context, userID int) (*models.SkillGapReport, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SkillGapReport), args.Error(1)
+}
+
+func (m *mockJobService) MarkSkillGapAddressed(ctx context.Context, userID int, key, note string) (*models.SkillGapReport, error) {
+	args := m.Called(ctx, userID, key, note)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SkillGapReport), args.Error(1)
+}
+
+func (m *mockJobService) ExtractJobDraft(ctx context.Context, userID int, rawText string) (*models.JobDraft, error) {
+	args := m.Called(ctx, userID, rawText)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.JobDraft), args.Error(1)
+}
+
+func (m *mockJobService) RewriteSection(ctx context.Context, userID, jobID int, section, text, instruction string) (*models.SectionRewrite, error) {
+	args := m.Called(ctx, userID, jobID, section, text, instruction)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SectionRewrite), args.Error(1)
+}
+
+func (m *mockJobService) GetInterviewPrep(ctx context.Context, userID int, jobID int) (*models.InterviewPrep, error) {
 	args := m.Called(ctx, userID, jobID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
+	return args.Get(0).(*models.InterviewPrep), args.Error(1)
+}
+
+func (m *mockJobService) StreamCoverLetter(ctx context.Context, userID int, jobID int, options models.CoverLetterOptions, onDelta func(text string) error) (*models.CoverLetterWithProfile, error) {
+	args := m.Called(ctx, userID, jobID, options, onDelta)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CoverLetterWithProfile), args.Error(1)
+}
+
+func (m *mockJobService) StreamCV(ctx context.Context, userID int, jobID int, language string, onDelta func(text string) error) (*models.GeneratedCV, error) {
+	args := m.Called(ctx, userID, jobID, language, onDelta)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
 	return args.Get(0).(*models.GeneratedCV), args.Error(1)
 }
 
@@ -130,6 +228,121 @@ func (m *mockJobService) DeleteMatchResult(ctx context.Context, userID int, jobI
 	return args.Error(0)
 }
 
+func (m *mockJobService) GetJobFitScores(ctx context.Context, userID int, jobs []*models.Job) (map[int]int, error) {
+	args := m.Called(ctx, userID, jobs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int]int), args.Error(1)
+}
+
+func (m *mockJobService) GetSimilarJobs(ctx context.Context, userID, jobID int) ([]*models.SimilarJob, error) {
+	args := m.Called(ctx, userID, jobID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.SimilarJob), args.Error(1)
+}
+
+func (m *mockJobService) GetTags(ctx context.Context, userID int) ([]*models.Tag, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Tag), args.Error(1)
+}
+
+func (m *mockJobService) GetPipeline(ctx context.Context, userID int) (models.Pipeline, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(models.Pipeline), args.Error(1)
+}
+
+func (m *mockJobService) CreateStage(ctx context.Context, userID int, name string, category models.JobStatus) (*models.PipelineStage, error) {
+	args := m.Called(ctx, userID, name, category)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PipelineStage), args.Error(1)
+}
+
+func (m *mockJobService) UpdateStage(ctx context.Context, userID, stageID int, name string, category models.JobStatus) error {
+	args := m.Called(ctx, userID, stageID, name, category)
+	return args.Error(0)
+}
+
+func (m *mockJobService) MoveStage(ctx context.Context, userID, stageID, offset int) error {
+	args := m.Called(ctx, userID, stageID, offset)
+	return args.Error(0)
+}
+
+func (m *mockJobService) DeleteStage(ctx context.Context, userID, stageID, replacementID int) error {
+	args := m.Called(ctx, userID, stageID, replacementID)
+	return args.Error(0)
+}
+
+func (m *mockJobService) GetStatusTimeline(ctx context.Context, userID, jobID int) ([]models.StatusTimelineEntry, error) {
+	args := m.Called(ctx, userID, jobID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.StatusTimelineEntry), args.Error(1)
+}
+
+func (m *mockJobService) UpdateStatusEvent(ctx context.Context, userID, jobID, eventID int, note, date string) error {
+	args := m.Called(ctx, userID, jobID, eventID, note, date)
+	return args.Error(0)
+}
+
+func (m *mockJobService) GetJobReminders(ctx context.Context, userID, jobID int) ([]*models.Reminder, error) {
+	args := m.Called(ctx, userID, jobID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Reminder), args.Error(1)
+}
+
+func (m *mockJobService) GetOverdueReminderCounts(ctx context.Context, userID int, jobs []*models.Job) (map[int]int, error) {
+	args := m.Called(ctx, userID, jobs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int]int), args.Error(1)
+}
+
+func (m *mockJobService) CreateReminder(ctx context.Context, userID, jobID int, message, due, offset, recurrence string) (*models.Reminder, error) {
+	args := m.Called(ctx, userID, jobID, message, due, offset, recurrence)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Reminder), args.Error(1)
+}
+
+func (m *mockJobService) CompleteReminder(ctx context.Context, userID, jobID, reminderID int) error {
+	args := m.Called(ctx, userID, jobID, reminderID)
+	return args.Error(0)
+}
+
+func (m *mockJobService) DeleteReminder(ctx context.Context, userID, jobID, reminderID int) error {
+	args := m.Called(ctx, userID, jobID, reminderID)
+	return args.Error(0)
+}
+
+func (m *mockJobService) GetInjectionReview(ctx context.Context, userID, jobID int) (*models.InjectionReview, error) {
+	args := m.Called(ctx, userID, jobID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.InjectionReview), args.Error(1)
+}
+
+func (m *mockJobService) ApproveInjectionReview(ctx context.Context, userID, jobID int) error {
+	args := m.Called(ctx, userID, jobID)
+	return args.Error(0)
+}
+
 func (m *mockJobService) LogError(err error) {
 	m.Called(err)
 }
@@ -231,6 +444,7 @@ func TestJobHandler_CreateJob(t *testing.T) {
 				mockService.On("ValidateURL", "https://example.com/job").Return(nil)
 				mockService.On("ValidateURL", "").Return(nil)
 				mockService.On("ValidateAndFilterSkills", "").Return([]string{})
+				mockService.On("GetPipeline", mock.Anything, 1).Return(models.DefaultPipeline(), nil)
 				job := &models.Job{
 					ID:          1,
 					Title:       "Software Engineer",
@@ -267,6 +481,7 @@ func TestJobHandler_CreateJob(t *testing.T) {
 			MockSetup: func() {
 				mockService.On("ValidateURL", "").Return(nil).Times(2)
 				mockService.On("ValidateAndFilterSkills", "").Return([]string{})
+				mockService.On("GetPipeline", mock.Anything, 1).Return(models.DefaultPipeline(), nil)
 				mockService.On("CreateJob", mock.Anything, 1, "", "Build awesome software", "Acme Corp", mock.Anything, mock.Anything, mock.Anything).
 					Return(nil, false, models.ErrJobTitleRequired)
 			},
@@ -289,6 +504,7 @@ func TestJobHandler_CreateJob(t *testing.T) {
 			MockSetup: func() {
 				mockService.On("ValidateURL", "").Return(nil).Times(2)
 				mockService.On("ValidateAndFilterSkills", "").Return([]string{})
+				mockService.On("GetPipeline", mock.Anything, 1).Return(models.DefaultPipeline(), nil)
 				mockService.On("CreateJob", mock.Anything, 1, "Software Engineer", "", "Acme Corp", mock.Anything, mock.Anything, mock.Anything).
 					Return(nil, false, models.ErrJobDescriptionRequired)
 			},
@@ -311,6 +527,7 @@ func TestJobHandler_CreateJob(t *testing.T) {
 			MockSetup: func() {
 				mockService.On("ValidateURL", "").Return(nil).Times(2)
 				mockService.On("ValidateAndFilterSkills", "").Return([]string{})
+				mockService.On("GetPipeline", mock.Anything, 1).Return(models.DefaultPipeline(), nil)
 				mockService.On("CreateJob", mock.Anything, 1, "Software Engineer", "Build awesome software", "", mock.Anything, mock.Anything, mock.Anything).
 					Return(nil, false, models.ErrCompanyNameRequired)
 			},
@@ -354,6 +571,7 @@ func TestJobHandler_CreateJob(t *testing.T) {
 			MockSetup: func() {
 				mockService.On("ValidateURL", "").Return(nil).Times(2)
 				mockService.On("ValidateAndFilterSkills", "").Return([]string{})
+				mockService.On("GetPipeline", mock.Anything, 1).Return(models.DefaultPipeline(), nil)
 				job := &models.Job{
 					ID:    1,
 					Title: "Software Engineer",
@@ -444,6 +662,59 @@ func TestJobHandler_DeleteJob(t *testing.T) {
 	}
 }
 
+func TestJobHandler_ApproveInjectionReview(t *testing.T) {
+	handler, mockService, _, router := setupTestJobHandler()
+
+	router.POST("/jobs/:id/description-review/approve", func(c *gin.Context) {
+		jobID, _ := strconv.Atoi(c.Param("id"))
+		setJobContext(c, 1, jobID)
+		handler.ApproveInjectionReview(c)
+	})
+
+	tests := []testutil.HandlerTestCase{
+		{
+			Name:   "should_approve_flagged_description",
+			Method: "POST",
+			Path:   "/jobs/5/description-review/approve",
+			Headers: map[string]string{
+				"HX-Request": "true",
+			},
+			MockSetup: func() {
+				mockService.On("ApproveInjectionReview", mock.Anything, 1, 5).Return(nil)
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedHeader: map[string]string{
+				"HX-Redirect": "/jobs/5/details",
+			},
+		},
+		{
+			Name:   "should_return_400_when_description_not_flagged",
+			Method: "POST",
+			Path:   "/jobs/6/description-review/approve",
+			Headers: map[string]string{
+				"HX-Request": "true",
+			},
+			MockSetup: func() {
+				mockService.On("ApproveInjectionReview", mock.Anything, 1, 6).Return(models.ErrNoReviewPending)
+			},
+			ExpectedStatus: http.StatusBadRequest,
+			ExpectedToast: &testutil.ToastAssertion{
+				Message: models.ErrNoReviewPending.Error(),
+				Type:    string(alerts.TypeError),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			mockService.ExpectedCalls = nil
+			mockService.Calls = nil
+			testutil.RunHandlerTest(t, router, tc)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
 // GetJobs method is not implemented in JobHandler
 // The handler uses ListJobsPage for displaying jobs
 func TestJobHandler_GetJobs(t *testing.T) {
@@ -453,3 +724,557 @@ func TestJobHandler_GetJobs(t *testing.T) {
 func intPtr(i int) *int {
 	return &i
 }
+
+func TestJobHandler_StreamCoverLetter(t *testing.T) {
+	handler, mockService, _, router := setupTestJobHandler()
+
+	router.POST("/jobs/:id/cover-letter/stream", func(c *gin.Context) {
+		jobID, _ := strconv.Atoi(c.Param("id"))
+		setJobContext(c, 1, jobID)
+		handler.StreamCoverLetter(c)
+	})
+
+	t.Run("should_stream_deltas_then_error_event", func(t *testing.T) {
+		mockService.ExpectedCalls = nil
+		options := models.CoverLetterOptions{Tone: "warm", Length: "short", Language: "de"}
+		mockService.On("StreamCoverLetter", mock.Anything, 1, 5, options, mock.Anything).
+			Run(func(args mock.Arguments) {
+				onDelta := args.Get(4).(func(text string) error)
+				_ = onDelta("Dear ")
+				_ = onDelta("Team")
+			}).
+			Return(nil, models.WrapError(models.ErrAIServiceUnavailable, errors.New("provider down")))
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/jobs/5/cover-letter/stream", strings.NewReader("tone=warm&length=short&language=de"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "text/event-stream")
+		body := w.Body.String()
+		assert.Contains(t, body, "event:delta\ndata:{\"text\":\"Dear \"}")
+		assert.Contains(t, body, "event:delta\ndata:{\"text\":\"Team\"}")
+		assert.Contains(t, body, "event:error\ndata:{\"message\":\""+models.ErrAIServiceUnavailable.Error()+"\"}")
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestJobHandler_GenerateCoverLetter(t *testing.T) {
+	handler, mockService, _, router := setupTestJobHandler()
+
+	router.POST("/jobs/:id/cover-letter", func(c *gin.Context) {
+		jobID, _ := strconv.Atoi(c.Param("id"))
+		setJobContext(c, 1, jobID)
+		handler.GenerateCoverLetter(c)
+	})
+
+	tc := testutil.HandlerTestCase{
+		Name:   "should_pass_letter_options_and_show_error",
+		Method: "POST",
+		Path:   "/jobs/6/cover-letter",
+		FormData: map[string]string{
+			"tone":     "sarcastic",
+			"length":   "long",
+			"language": "fr",
+		},
+		Headers: map[string]string{
+			"HX-Request": "true",
+		},
+		MockSetup: func() {
+			options := models.CoverLetterOptions{Tone: "sarcastic", Length: "long", Language: "fr"}
+			mockService.On("GenerateCoverLetter", mock.Anything, 1, 6, options).
+				Return(nil, models.ErrInvalidLetterTone)
+		},
+		ExpectedStatus: http.StatusBadRequest,
+		ValidateBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+			assert.Contains(t, w.Header().Get("HX-Trigger"), models.ErrInvalidLetterTone.Error())
+		},
+	}
+
+	testutil.RunHandlerTest(t, router, tc)
+	mockService.AssertExpectations(t)
+}
+
+func TestJobHandler_StreamCV(t *testing.T) {
+	handler, mockService, _, router := setupTestJobHandler()
+
+	router.POST("/jobs/:id/cv/stream", func(c *gin.Context) {
+		jobID, _ := strconv.Atoi(c.Param("id"))
+		setJobContext(c, 1, jobID)
+		handler.StreamCV(c)
+	})
+
+	mockService.On("StreamCV", mock.Anything, 1, 7, "de", mock.Anything).
+		Return(nil, models.ErrProfileIncomplete)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/jobs/7/cv/stream", strings.NewReader("language=de"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "event:error\ndata:{\"message\":\""+models.ErrProfileIncomplete.Error()+"\"}")
+	assert.NotContains(t, w.Body.String(), "event:delta")
+	mockService.AssertExpectations(t)
+}
+
+func TestJobHandler_GetInterviewPrep(t *testing.T) {
+	handler, mockService, _, router := setupTestJobHandler()
+
+	router.GET("/jobs/:id/interview-prep", func(c *gin.Context) {
+		jobID, _ := strconv.Atoi(c.Param("id"))
+		setJobContext(c, 1, jobID)
+		handler.GetInterviewPrep(c)
+	})
+
+	mockService.On("GetInterviewPrep", mock.Anything, 1, 3).Return(nil, nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/jobs/3/interview-prep", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestJobHandler_GenerateInterviewPrep(t *testing.T) {
+	handler, mockService, _, router := setupTestJobHandler()
+
+	router.POST("/jobs/:id/interview-prep", func(c *gin.Context) {
+		jobID, _ := strconv.Atoi(c.Param("id"))
+		setJobContext(c, 1, jobID)
+		handler.GenerateInterviewPrep(c)
+	})
+
+	tc := testutil.HandlerTestCase{
+		Name:   "should_show_error_when_work_experience_missing",
+		Method: "POST",
+		Path:   "/jobs/4/interview-prep",
+		Headers: map[string]string{
+			"HX-Request": "true",
+		},
+		MockSetup: func() {
+			mockService.On("GenerateInterviewPrep", mock.Anything, 1, 4).
+				Return(nil, models.ErrWorkExperienceRequired)
+		},
+		ExpectedStatus: http.StatusBadRequest,
+		ValidateBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+			assert.Contains(t, w.Header().Get("HX-Trigger"), models.ErrWorkExperienceRequired.Error())
+		},
+	}
+
+	testutil.RunHandlerTest(t, router, tc)
+	mockService.AssertExpectations(t)
+}
+
+func TestJobHandler_GetEmails(t *testing.T) {
+	handler, mockService, _, router := setupTestJobHandler()
+
+	router.GET("/jobs/:id/emails", func(c *gin.Context) {
+		jobID, _ := strconv.Atoi(c.Param("id"))
+		setJobContext(c, 1, jobID)
+		handler.GetEmails(c)
+	})
+
+	mockService.On("GetEmailHistory", mock.Anything, 1, 3).Return([]*models.JobEmail{}, nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/jobs/3/emails", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestJobHandler_GenerateEmail(t *testing.T) {
+	handler, mockService, _, router := setupTestJobHandler()
+
+	router.POST("/jobs/:id/emails", func(c *gin.Context) {
+		jobID, _ := strconv.Atoi(c.Param("id"))
+		setJobContext(c, 1, jobID)
+		handler.GenerateEmail(c)
+	})
+
+	tc := testutil.HandlerTestCase{
+		Name:   "should_pass_form_values_and_show_error",
+		Method: "POST",
+		Path:   "/jobs/4/emails",
+		FormData: map[string]string{
+			"kind": "thank_you",
+			"tone": "sarcastic",
+			"note": "Met Sam",
+		},
+		Headers: map[string]string{
+			"HX-Request": "true",
+		},
+		MockSetup: func() {
+			mockService.On("GenerateEmail", mock.Anything, 1, 4, "thank_you", "sarcastic", "Met Sam").
+				Return(nil, models.ErrInvalidEmailTone)
+		},
+		ExpectedStatus: http.StatusBadRequest,
+		ValidateBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+			assert.Contains(t, w.Header().Get("HX-Trigger"), models.ErrInvalidEmailTone.Error())
+		},
+	}
+
+	testutil.RunHandlerTest(t, router, tc)
+	mockService.AssertExpectations(t)
+}
+
+func TestJobHandler_RewriteSection(t *testing.T) {
+	handler, mockService, _, router := setupTestJobHandler()
+
+	router.POST("/jobs/:id/rewrite", func(c *gin.Context) {
+		jobID, _ := strconv.Atoi(c.Param("id"))
+		setJobContext(c, 1, jobID)
+		handler.RewriteSection(c)
+	})
+
+	tc := testutil.HandlerTestCase{
+		Name:   "should_hide_provider_errors",
+		Method: "POST",
+		Path:   "/jobs/4/rewrite",
+		FormData: map[string]string{
+			"section":     "summary",
+			"text":        "Backend engineer with five years of Go.",
+			"instruction": "shorten",
+		},
+		Headers: map[string]string{
+			"HX-Request": "true",
+		},
+		MockSetup: func() {
+			mockService.On("RewriteSection", mock.Anything, 1, 4, "summary", "Backend engineer with five years of Go.", "shorten").
+				Return(nil, models.WrapError(models.ErrSectionRewriteFailed, errors.New("quota exhausted for key abc")))
+		},
+		ExpectedStatus: http.StatusBadRequest,
+		ValidateBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+			assert.Contains(t, w.Header().Get("HX-Trigger"), "couldn't rewrite the section")
+			assert.NotContains(t, w.Header().Get("HX-Trigger"), "abc")
+		},
+	}
+
+	testutil.RunHandlerTest(t, router, tc)
+	mockService.AssertExpectations(t)
+}
+
+func TestJobHandler_GenerateLearningPlan(t *testing.T) {
+	handler, mockService, _, router := setupTestJobHandler()
+
+	router.POST("/settings/learning-plan/generate", func(c *gin.Context) {
+		setUserContext(c, 1)
+		handler.GenerateLearningPlan(c)
+	})
+
+	tc := testutil.HandlerTestCase{
+		Name:   "should_show_error_when_there_are_no_gaps",
+		Method: "POST",
+		Path:   "/settings/learning-plan/generate",
+		Headers: map[string]string{
+			"HX-Request": "true",
+		},
+		MockSetup: func() {
+			mockService.On("GenerateLearningPlan", mock.Anything, 1).Return(nil, models.ErrNoSkillGaps)
+		},
+		ExpectedStatus: http.StatusBadRequest,
+		ValidateBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+			assert.Contains(t, w.Header().Get("HX-Trigger"), models.ErrNoSkillGaps.Error())
+		},
+	}
+
+	testutil.RunHandlerTest(t, router, tc)
+	mockService.AssertExpectations(t)
+}
+
+func TestJobHandler_MarkSkillGapAddressed(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedToast  string
+	}{
+		{
+			name:           "should_return_not_found_for_unknown_gap",
+			err:            models.ErrSkillGapNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedToast:  models.ErrSkillGapNotFound.Error(),
+		},
+		{
+			name:           "should_hide_storage_errors",
+			err:            models.WrapError(models.ErrFailedToSaveSkillGap, errors.New("disk full")),
+			expectedStatus: http.StatusInternalServerError,
+			expectedToast:  "Something went wrong",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService, _, router := setupTestJobHandler()
+
+			router.POST("/settings/learning-plan/addressed", func(c *gin.Context) {
+				setUserContext(c, 1)
+				handler.MarkSkillGapAddressed(c)
+			})
+
+			mockService.On("MarkSkillGapAddressed", mock.Anything, 1, "graphql", "Built an API").Return(nil, tt.err)
+			mockService.On("LogError", mock.Anything).Maybe()
+
+			testutil.RunHandlerTest(t, router, testutil.HandlerTestCase{
+				Name:   tt.name,
+				Method: "POST",
+				Path:   "/settings/learning-plan/addressed",
+				FormData: map[string]string{
+					"key":  "graphql",
+					"note": "Built an API",
+				},
+				Headers: map[string]string{
+					"HX-Request": "true",
+				},
+				ExpectedStatus: tt.expectedStatus,
+				ValidateBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+					assert.Contains(t, w.Header().Get("HX-Trigger"), tt.expectedToast)
+					assert.NotContains(t, w.Header().Get("HX-Trigger"), "disk full")
+				},
+			})
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestJobHandler_ExtractJobDraft(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedToast  string
+	}{
+		{
+			name:           "should_reject_text_that_is_not_a_posting",
+			err:            models.WrapError(models.ErrNotJobPosting, errors.New("recipe")),
+			expectedStatus: http.StatusBadRequest,
+			expectedToast:  "doesn't look like a job posting",
+		},
+		{
+			name:           "should_ask_for_posting_text",
+			err:            models.ErrPostingTextRequired,
+			expectedStatus: http.StatusBadRequest,
+			expectedToast:  models.ErrPostingTextRequired.Error(),
+		},
+		{
+			name:           "should_hide_provider_errors",
+			err:            models.WrapError(models.ErrJobExtractionFailed, errors.New("quota exhausted for key abc")),
+			expectedStatus: http.StatusInternalServerError,
+			expectedToast:  "couldn't extract the job details",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService, _, router := setupTestJobHandler()
+
+			router.POST("/jobs/new/extract", func(c *gin.Context) {
+				setUserContext(c, 1)
+				handler.ExtractJobDraft(c)
+			})
+
+			mockService.On("ExtractJobDraft", mock.Anything, 1, "Senior Go Engineer at Acme").Return(nil, tt.err)
+
+			testutil.RunHandlerTest(t, router, testutil.HandlerTestCase{
+				Name:   tt.name,
+				Method: "POST",
+				Path:   "/jobs/new/extract",
+				FormData: map[string]string{
+					"raw_text": "Senior Go Engineer at Acme",
+				},
+				Headers: map[string]string{
+					"HX-Request": "true",
+				},
+				ExpectedStatus: tt.expectedStatus,
+				ValidateBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+					assert.Contains(t, w.Header().Get("HX-Trigger"), tt.expectedToast)
+					assert.NotContains(t, w.Header().Get("HX-Trigger"), "abc")
+				},
+			})
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestJobHandler_UpdateStatusEvent(t *testing.T) {
+	handler, mockService, _, router := setupTestJobHandler()
+
+	router.PUT("/jobs/:id/status-events/:eventId", func(c *gin.Context) {
+		jobID, _ := strconv.Atoi(c.Param("id"))
+		setJobContext(c, 1, jobID)
+		handler.UpdateStatusEvent(c)
+	})
+
+	tests := []testutil.HandlerTestCase{
+		{
+			Name:   "should_return_404_for_invalid_event_id",
+			Method: "PUT",
+			Path:   "/jobs/5/status-events/abc",
+			Headers: map[string]string{
+				"HX-Request": "true",
+			},
+			MockSetup:      func() {},
+			ExpectedStatus: http.StatusNotFound,
+			ExpectedToast: &testutil.ToastAssertion{
+				Message: models.ErrStatusEventNotFound.Error(),
+				Type:    string(alerts.TypeError),
+			},
+		},
+		{
+			Name:   "should_return_400_for_future_date",
+			Method: "PUT",
+			Path:   "/jobs/5/status-events/2",
+			FormData: map[string]string{
+				"date": "2999-01-01",
+				"note": "booked",
+			},
+			Headers: map[string]string{
+				"HX-Request": "true",
+			},
+			MockSetup: func() {
+				mockService.On("UpdateStatusEvent", mock.Anything, 1, 5, 2, "booked", "2999-01-01").
+					Return(models.ErrStatusDateInFuture)
+			},
+			ExpectedStatus: http.StatusBadRequest,
+			ExpectedToast: &testutil.ToastAssertion{
+				Message: models.ErrStatusDateInFuture.Error(),
+				Type:    string(alerts.TypeError),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			mockService.ExpectedCalls = nil
+			mockService.Calls = nil
+			testutil.RunHandlerTest(t, router, tc)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestJobHandler_Reminders(t *testing.T) {
+	handler, mockService, _, router := setupTestJobHandler()
+
+	router.POST("/jobs/:id/reminders", func(c *gin.Context) {
+		jobID, _ := strconv.Atoi(c.Param("id"))
+		setJobContext(c, 1, jobID)
+		handler.CreateReminder(c)
+	})
+	router.POST("/jobs/:id/reminders/:reminderId/done", func(c *gin.Context) {
+		jobID, _ := strconv.Atoi(c.Param("id"))
+		setJobContext(c, 1, jobID)
+		handler.CompleteReminder(c)
+	})
+
+	tests := []testutil.HandlerTestCase{
+		{
+			Name:   "should_return_400_when_message_is_missing",
+			Method: "POST",
+			Path:   "/jobs/5/reminders",
+			FormData: map[string]string{
+				"message":   " ",
+				"due":       "2030-01-02T09:00",
+				"tz_offset": "-60",
+			},
+			Headers: map[string]string{
+				"HX-Request": "true",
+			},
+			MockSetup: func() {
+				mockService.On("CreateReminder", mock.Anything, 1, 5, " ", "2030-01-02T09:00", "-60", "").
+					Return(nil, models.ErrReminderMessageRequired)
+			},
+			ExpectedStatus: http.StatusBadRequest,
+			ExpectedToast: &testutil.ToastAssertion{
+				Message: models.ErrReminderMessageRequired.Error(),
+				Type:    string(alerts.TypeError),
+			},
+		},
+		{
+			Name:   "should_return_404_for_invalid_reminder_id",
+			Method: "POST",
+			Path:   "/jobs/5/reminders/abc/done",
+			Headers: map[string]string{
+				"HX-Request": "true",
+			},
+			MockSetup:      func() {},
+			ExpectedStatus: http.StatusNotFound,
+			ExpectedToast: &testutil.ToastAssertion{
+				Message: models.ErrReminderNotFound.Error(),
+				Type:    string(alerts.TypeError),
+			},
+		},
+		{
+			Name:   "should_return_404_for_unknown_reminder",
+			Method: "POST",
+			Path:   "/jobs/5/reminders/9/done",
+			Headers: map[string]string{
+				"HX-Request": "true",
+			},
+			MockSetup: func() {
+				mockService.On("CompleteReminder", mock.Anything, 1, 5, 9).
+					Return(models.ErrReminderNotFound)
+			},
+			ExpectedStatus: http.StatusNotFound,
+			ExpectedToast: &testutil.ToastAssertion{
+				Message: models.ErrReminderNotFound.Error(),
+				Type:    string(alerts.TypeError),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			mockService.ExpectedCalls = nil
+			mockService.Calls = nil
+			testutil.RunHandlerTest(t, router, tc)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAIRequestContext(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		body     string
+		expected bool
+	}{
+		{name: "should_not_force_refresh_by_default", target: "/jobs/1/analyze", expected: false},
+		{name: "should_force_refresh_from_query", target: "/jobs/1/cv/stream?force_refresh=true", expected: true},
+		{name: "should_force_refresh_from_form", target: "/jobs/1/analyze", body: "force_refresh=true", expected: true},
+		{name: "should_ignore_invalid_value", target: "/jobs/1/analyze?force_refresh=yes-please", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			assert.Equal(t, tt.expected, llm.IsForceRefresh(aiRequestContext(c)))
+		})
+	}
+}
+
+func TestNewTagFilterOptions(t *testing.T) {
+	tags := []*models.Tag{
+		{Name: "Referral", JobCount: 3},
+		{Name: "remote", JobCount: 1},
+		{Name: "visa sponsorship", JobCount: 2},
+	}
+
+	options := newTagFilterOptions(tags, []string{"referral", "visa sponsorship"})
+
+	assert.Equal(t, []tagFilterOption{
+		{Name: "Referral", JobCount: 3, Active: true, Toggle: "visa sponsorship"},
+		{Name: "remote", JobCount: 1, Toggle: "referral,visa sponsorship,remote"},
+		{Name: "visa sponsorship", JobCount: 2, Active: true, Toggle: "referral"},
+	}, options)
+}
@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/benidevo/vega/internal/job/models"
 )
@@ -46,3 +47,64 @@ type JobRepository interface {
 	GetMonthlyAnalysisCount(ctx context.Context, userID int) (int, error)
 	SetFirstAnalyzedAt(ctx context.Context, jobID int) error
 }
+
+// SkillGapRepository defines methods for reading analysed jobs and storing
+// what the user has done about their skill gaps
+type SkillGapRepository interface {
+	GetAnalysedJobs(ctx context.Context, userID int) ([]*models.AnalysedJob, error)
+	GetSkillGapStates(ctx context.Context, userID int) ([]*models.SkillGapState, error)
+	SaveSkillGapPlan(ctx context.Context, userID int, key, name string, plan *models.GapPlan) error
+	MarkSkillGapAddressed(ctx context.Context, userID int, key, name, note string) error
+}
+
+// InjectionReviewRepository defines methods for storing job descriptions
+// flagged as likely prompt injections and the user's review of them
+type InjectionReviewRepository interface {
+	GetInjectionReview(ctx context.Context, userID, jobID int) (*models.InjectionReview, error)
+	SaveInjectionReview(ctx context.Context, userID int, review *models.InjectionReview) error
+	ApproveInjectionReview(ctx context.Context, userID, jobID int) error
+	DeleteInjectionReview(ctx context.Context, userID, jobID int) error
+}
+
+// TagRepository defines methods for the user's tags and the jobs they are on
+type TagRepository interface {
+	GetTags(ctx context.Context, userID int) ([]*models.Tag, error)
+	GetJobTags(ctx context.Context, userID int, jobIDs []int) (map[int][]string, error)
+	SetJobTags(ctx context.Context, userID, jobID int, tags []string) error
+}
+
+// StatusEventRepository defines methods for the history of job status changes.
+// The changes themselves are recorded by the database when a job's status is
+// updated.
+type StatusEventRepository interface {
+	GetStatusEvents(ctx context.Context, userID, jobID int) ([]*models.StatusEvent, error)
+	UpdateStatusEvent(ctx context.Context, userID, jobID, eventID int, note string, occurredAt time.Time) error
+	GetStatusActivity(ctx context.Context, userID int, since time.Time) (*models.StatusActivity, error)
+}
+
+// PipelineRepository defines methods for the user's pipeline stages and the
+// stage each job is at. Moving a job to a stage also sets its status to the
+// stage's category.
+type PipelineRepository interface {
+	GetStages(ctx context.Context, userID int) ([]*models.PipelineStage, error)
+	GetJobStages(ctx context.Context, userID int, jobIDs []int) (map[int]int, error)
+	SetJobStage(ctx context.Context, userID, jobID, stageID int) error
+	CreateStage(ctx context.Context, userID int, stage *models.PipelineStage) error
+	UpdateStage(ctx context.Context, userID int, stage *models.PipelineStage) error
+	SetStageOrder(ctx context.Context, userID int, stageIDs []int) error
+	DeleteStage(ctx context.Context, userID, stageID, replacementID int) error
+}
+
+// ReminderRepository defines methods for reminders attached to jobs. Times are
+// in UTC. MarkDueReminders works across users, for the scheduler.
+type ReminderRepository interface {
+	GetJobReminders(ctx context.Context, userID, jobID int) ([]*models.Reminder, error)
+	GetUpcomingReminders(ctx context.Context, userID int, until time.Time, limit int) ([]*models.Reminder, error)
+	GetOverdueCounts(ctx context.Context, userID int, jobIDs []int) (map[int]int, error)
+	GetReminder(ctx context.Context, userID, jobID, reminderID int) (*models.Reminder, error)
+	CreateReminder(ctx context.Context, userID int, reminder *models.Reminder) error
+	CompleteReminder(ctx context.Context, userID, jobID, reminderID int, completedAt time.Time) error
+	RescheduleReminder(ctx context.Context, userID, jobID, reminderID int, dueAt time.Time) error
+	DeleteReminder(ctx context.Context, userID, jobID, reminderID int) error
+	MarkDueReminders(ctx context.Context, now time.Time) (int, error)
+}
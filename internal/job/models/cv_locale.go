@@ -0,0 +1,157 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CVHeadings are the section headings of a CV in one language.
+type CVHeadings struct {
+	Summary        string `json:"summary"`
+	Skills         string `json:"skills"`
+	Experience     string `json:"experience"`
+	Education      string `json:"education"`
+	Certifications string `json:"certifications"`
+}
+
+// cvLocale holds the conventions for writing a CV in one language.
+type cvLocale struct {
+	headings  CVHeadings
+	months    [12]string
+	monthYear string // layout for a month and year, e.g. "%s %d"
+	present   string // end date of a current role
+	roleAt    string // layout joining a job title and company
+	degreeIn  string // layout joining a degree and field of study
+}
+
+// cvLocales are keyed by ISO 639-1 code and cover every language documents
+// can be written in.
+var cvLocales = map[string]cvLocale{
+	"en": {
+		headings:  CVHeadings{"Professional Summary", "Skills", "Work Experience", "Education", "Certifications"},
+		months:    [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		monthYear: "%s %d", present: "Present", roleAt: "%s at %s", degreeIn: "%s in %s",
+	},
+	"de": {
+		headings:  CVHeadings{"Profil", "Kenntnisse", "Berufserfahrung", "Ausbildung", "Zertifikate"},
+		months:    [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		monthYear: "%s %d", present: "heute", roleAt: "%s bei %s", degreeIn: "%s in %s",
+	},
+	"es": {
+		headings:  CVHeadings{"Perfil profesional", "Habilidades", "Experiencia laboral", "Formación", "Certificaciones"},
+		months:    [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		monthYear: "%s de %d", present: "actualidad", roleAt: "%s en %s", degreeIn: "%s en %s",
+	},
+	"fr": {
+		headings:  CVHeadings{"Profil professionnel", "Compétences", "Expérience professionnelle", "Formation", "Certifications"},
+		months:    [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		monthYear: "%s %d", present: "aujourd'hui", roleAt: "%s chez %s", degreeIn: "%s en %s",
+	},
+	"it": {
+		headings:  CVHeadings{"Profilo professionale", "Competenze", "Esperienza lavorativa", "Istruzione", "Certificazioni"},
+		months:    [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		monthYear: "%s %d", present: "oggi", roleAt: "%s presso %s", degreeIn: "%s in %s",
+	},
+	"nl": {
+		headings:  CVHeadings{"Profiel", "Vaardigheden", "Werkervaring", "Opleiding", "Certificeringen"},
+		months:    [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		monthYear: "%s %d", present: "heden", roleAt: "%s bij %s", degreeIn: "%s in %s",
+	},
+	"pl": {
+		headings:  CVHeadings{"Podsumowanie zawodowe", "Umiejętności", "Doświadczenie zawodowe", "Wykształcenie", "Certyfikaty"},
+		months:    [12]string{"styczeń", "luty", "marzec", "kwiecień", "maj", "czerwiec", "lipiec", "sierpień", "wrzesień", "październik", "listopad", "grudzień"},
+		monthYear: "%s %d", present: "obecnie", roleAt: "%s, %s", degreeIn: "%s, %s",
+	},
+	"pt": {
+		headings:  CVHeadings{"Resumo profissional", "Competências", "Experiência profissional", "Formação", "Certificações"},
+		months:    [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		monthYear: "%s de %d", present: "atual", roleAt: "%s, %s", degreeIn: "%s em %s",
+	},
+}
+
+// Date layouts generated and parsed CVs use, most specific first.
+var cvDateLayouts = []string{"January 2006", "Jan 2006", "2006-01", "01/2006", "2006"}
+
+// Words for the end date of a current role, in any supported language.
+var presentWords = map[string]bool{
+	"present": true, "current": true, "now": true, "heute": true, "actualidad": true,
+	"aujourd'hui": true, "presente": true, "oggi": true, "heden": true, "obecnie": true, "atual": true,
+}
+
+func localeFor(language string) cvLocale {
+	return cvLocales[CVLanguageOrDefault(language)]
+}
+
+// CVLanguageOrDefault returns the language if CVs can be written in it, or
+// English otherwise.
+func CVLanguageOrDefault(language string) string {
+	if _, ok := cvLocales[language]; ok {
+		return language
+	}
+	return "en"
+}
+
+// CVHeadingsFor returns the CV section headings for a language, falling back
+// to English for unknown languages.
+func CVHeadingsFor(language string) CVHeadings {
+	return localeFor(language).headings
+}
+
+// RoleAt joins a job title and company the way the language does, for
+// example "Engineer at Acme" or "Ingenieur bei Acme".
+func RoleAt(title, company, language string) string {
+	return fmt.Sprintf(localeFor(language).roleAt, title, company)
+}
+
+// DegreeIn joins a degree and its field of study, or returns the degree alone
+// if there is no field.
+func DegreeIn(degree, field, language string) string {
+	if field == "" {
+		return degree
+	}
+	return fmt.Sprintf(localeFor(language).degreeIn, degree, field)
+}
+
+// LocalizeCVDate rewrites a CV date such as "2021-03", "March 2021" or
+// "Present" in the conventions of the language. English dates and dates that
+// can't be parsed are returned unchanged.
+func LocalizeCVDate(date, language string) string {
+	locale, ok := cvLocales[language]
+	trimmed := strings.TrimSpace(date)
+	if !ok || language == "en" || trimmed == "" {
+		return date
+	}
+
+	if presentWords[strings.ToLower(trimmed)] {
+		return locale.present
+	}
+
+	for _, layout := range cvDateLayouts {
+		t, err := time.Parse(layout, trimmed)
+		if err != nil {
+			continue
+		}
+		if layout == "2006" {
+			return trimmed
+		}
+		return fmt.Sprintf(locale.monthYear, locale.months[t.Month()-1], t.Year())
+	}
+	return date
+}
+
+// Localize rewrites the CV's dates in the conventions of its language.
+func (cv *GeneratedCV) Localize() {
+	for i := range cv.WorkExperience {
+		cv.WorkExperience[i].StartDate = LocalizeCVDate(cv.WorkExperience[i].StartDate, cv.Language)
+		cv.WorkExperience[i].EndDate = LocalizeCVDate(cv.WorkExperience[i].EndDate, cv.Language)
+	}
+	for i := range cv.Education {
+		cv.Education[i].StartDate = LocalizeCVDate(cv.Education[i].StartDate, cv.Language)
+		cv.Education[i].EndDate = LocalizeCVDate(cv.Education[i].EndDate, cv.Language)
+	}
+	for i := range cv.Certifications {
+		cv.Certifications[i].IssueDate = LocalizeCVDate(cv.Certifications[i].IssueDate, cv.Language)
+		cv.Certifications[i].ExpiryDate = LocalizeCVDate(cv.Certifications[i].ExpiryDate, cv.Language)
+	}
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalizeCVDate(t *testing.T) {
+	tests := []struct {
+		name     string
+		date     string
+		language string
+		want     string
+	}{
+		{"english_unchanged", "2021-03", "en", "2021-03"},
+		{"unknown_language_unchanged", "March 2021", "xx", "March 2021"},
+		{"german_month_year", "March 2021", "de", "März 2021"},
+		{"german_short_month", "Mar 2021", "de", "März 2021"},
+		{"spanish_iso_month", "2021-03", "es", "marzo de 2021"},
+		{"french_slash_month", "03/2021", "fr", "mars 2021"},
+		{"year_only", "2019", "nl", "2019"},
+		{"present", "Present", "de", "heute"},
+		{"localised_present", "aujourd'hui", "pt", "atual"},
+		{"unparseable", "Spring 2020", "it", "Spring 2020"},
+		{"empty", "", "de", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, LocalizeCVDate(tt.date, tt.language))
+		})
+	}
+}
+
+func TestGeneratedCV_Localize(t *testing.T) {
+	cv := &GeneratedCV{
+		Language:       "de",
+		WorkExperience: []WorkExperience{{StartDate: "January 2020", EndDate: "Present"}},
+		Education:      []Education{{StartDate: "2014", EndDate: "2017-06"}},
+		Certifications: []Certification{{IssueDate: "Jan 2022"}},
+	}
+
+	cv.Localize()
+
+	assert.Equal(t, "Januar 2020", cv.WorkExperience[0].StartDate)
+	assert.Equal(t, "heute", cv.WorkExperience[0].EndDate)
+	assert.Equal(t, "2014", cv.Education[0].StartDate)
+	assert.Equal(t, "Juni 2017", cv.Education[0].EndDate)
+	assert.Equal(t, "Januar 2022", cv.Certifications[0].IssueDate)
+	assert.Empty(t, cv.Certifications[0].ExpiryDate)
+}
+
+func TestCVHeadingsFor(t *testing.T) {
+	assert.Equal(t, "Work Experience", CVHeadingsFor("en").Experience)
+	assert.Equal(t, "Berufserfahrung", CVHeadingsFor("de").Experience)
+	assert.Equal(t, CVHeadingsFor("en"), CVHeadingsFor("xx"))
+
+	for code := range cvLocales {
+		headings := CVHeadingsFor(code)
+		assert.NotEmpty(t, headings.Summary, code)
+		assert.NotEmpty(t, headings.Certifications, code)
+	}
+
+	assert.Equal(t, "Engineer at Acme", RoleAt("Engineer", "Acme", ""))
+	assert.Equal(t, "Ingenieur bei Acme", RoleAt("Ingenieur", "Acme", "de"))
+	assert.Equal(t, "MSc", DegreeIn("MSc", "", "fr"))
+	assert.Equal(t, "Licence en Informatique", DegreeIn("Licence", "Informatique", "fr"))
+}
@@ -15,6 +15,8 @@ type GeneratedCV struct {
 	Skills         []string         `json:"skills,omitempty"`
 	GeneratedAt    time.Time        `json:"generatedAt"`
 	JobTitle       string           `json:"jobTitle"`
+	PromptVersion  string           `json:"promptVersion,omitempty"`
+	Language       string           `json:"language,omitempty"` // ISO 639-1 code the CV is written in
 	CreatedAt      time.Time        `json:"createdAt"`
 	UpdatedAt      time.Time        `json:"updatedAt"`
 }
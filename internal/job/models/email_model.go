@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// JobEmail is an application email generated for a job, such as a follow-up,
+// a thank-you note or an offer negotiation reply.
+type JobEmail struct {
+	ID            int       `json:"id,omitempty"`
+	JobID         int       `json:"jobId"`
+	UserID        int       `json:"userId"`
+	Kind          string    `json:"kind"`
+	Tone          string    `json:"tone"`
+	JobStatus     string    `json:"jobStatus"`
+	Note          string    `json:"note,omitempty"`
+	Subject       string    `json:"subject"`
+	Body          string    `json:"body"`
+	PromptVersion string    `json:"promptVersion,omitempty"`
+	GeneratedAt   time.Time `json:"generatedAt"`
+}
+
+// KindLabel returns a readable name for the kind of email.
+func (e *JobEmail) KindLabel() string {
+	switch e.Kind {
+	case "follow_up":
+		return "Follow-up"
+	case "thank_you":
+		return "Thank you"
+	case "negotiation":
+		return "Offer negotiation"
+	default:
+		return e.Kind
+	}
+}
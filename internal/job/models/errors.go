@@ -6,18 +6,45 @@ import (
 
 var (
 	// Model validation errors
-	ErrUnauthorized            = commonerrors.New("unauthorized")
-	ErrInvalidJobStatus        = commonerrors.New("invalid job status")
-	ErrInvalidStatusTransition = commonerrors.New("job status can only move forward")
-	ErrJobTitleRequired        = commonerrors.New("job title is required")
-	ErrJobDescriptionRequired  = commonerrors.New("job description is required")
-	ErrCompanyRequired         = commonerrors.New("job company is required")
-	ErrInvalidFieldParam       = commonerrors.New("invalid field parameter")
-	ErrFieldRequired           = commonerrors.New("field parameter is required")
-	ErrInvalidURLFormat        = commonerrors.New("invalid URL format")
-	ErrSkillsRequired          = commonerrors.New("at least one valid skill is required")
-	ErrStatusRequired          = commonerrors.New("status is required")
-	ErrInvalidJobIDFormat      = commonerrors.New("invalid job ID format")
+	ErrUnauthorized               = commonerrors.New("unauthorized")
+	ErrInvalidJobStatus           = commonerrors.New("invalid job status")
+	ErrInvalidStatusTransition    = commonerrors.New("job status can only move forward")
+	ErrJobTitleRequired           = commonerrors.New("job title is required")
+	ErrJobDescriptionRequired     = commonerrors.New("job description is required")
+	ErrCompanyRequired            = commonerrors.New("job company is required")
+	ErrInvalidFieldParam          = commonerrors.New("invalid field parameter")
+	ErrFieldRequired              = commonerrors.New("field parameter is required")
+	ErrInvalidURLFormat           = commonerrors.New("invalid URL format")
+	ErrSkillsRequired             = commonerrors.New("at least one valid skill is required")
+	ErrStatusRequired             = commonerrors.New("status is required")
+	ErrInvalidJobIDFormat         = commonerrors.New("invalid job ID format")
+	ErrInvalidEmailKind           = commonerrors.New("unknown email type")
+	ErrInvalidEmailTone           = commonerrors.New("unknown email tone")
+	ErrEmailNoteTooLong           = commonerrors.New("email note is too long")
+	ErrSkillGapNoteTooLong        = commonerrors.New("skill gap note is too long")
+	ErrPostingTextRequired        = commonerrors.New("paste a job posting to extract its details")
+	ErrPostingTextTooLong         = commonerrors.New("job posting is too long")
+	ErrInvalidRewriteSection      = commonerrors.New("this section can't be rewritten")
+	ErrRewriteTextRequired        = commonerrors.New("the section to rewrite is empty")
+	ErrRewriteTextTooLong         = commonerrors.New("the section is too long to rewrite; select a shorter part")
+	ErrRewriteInstructionRequired = commonerrors.New("say how the section should be rewritten")
+	ErrRewriteInstructionTooLong  = commonerrors.New("rewrite instruction is too long")
+	ErrInvalidLetterTone          = commonerrors.New("unknown cover letter tone")
+	ErrInvalidLetterLength        = commonerrors.New("unknown cover letter length")
+	ErrInvalidDocumentLanguage    = commonerrors.New("documents can't be written in this language yet")
+	ErrTagTooLong                 = commonerrors.New("tags can be at most 50 characters long")
+	ErrTooManyTags                = commonerrors.New("a job can have at most 20 tags")
+	ErrStatusNoteTooLong          = commonerrors.New("status note is too long")
+	ErrInvalidStatusDate          = commonerrors.New("invalid status date")
+	ErrStatusDateInFuture         = commonerrors.New("a status change can't be dated in the future")
+	ErrStageNameRequired          = commonerrors.New("stage name is required")
+	ErrStageNameTooLong           = commonerrors.New("stage names can be at most 40 characters long")
+	ErrTooManyStages              = commonerrors.New("a pipeline can have at most 20 stages")
+	ErrInvalidStageOrder          = commonerrors.New("the new order must list every stage once")
+	ErrReminderMessageRequired    = commonerrors.New("say what the reminder is for")
+	ErrReminderMessageTooLong     = commonerrors.New("reminders can be at most 200 characters long")
+	ErrInvalidReminderDue         = commonerrors.New("invalid reminder due time")
+	ErrInvalidRecurrence          = commonerrors.New("unknown reminder recurrence")
 
 	// Repository errors
 	ErrJobNotFound           = commonerrors.New("job not found")
@@ -35,14 +62,47 @@ var (
 	ErrFailedToDeleteCompany = commonerrors.New("failed to delete company")
 	ErrFailedToGetJobStats   = commonerrors.New("failed to get job stats")
 	ErrFailedToGetJob        = commonerrors.New("failed to get job")
+	ErrSkillGapNotFound      = commonerrors.New("skill gap not found")
+	ErrFailedToSaveSkillGap  = commonerrors.New("failed to save skill gap")
+	ErrFailedToSaveReview    = commonerrors.New("failed to save job description review")
+	ErrFailedToSaveTags      = commonerrors.New("failed to save tags")
+	ErrFailedToGetTags       = commonerrors.New("failed to get tags")
+	ErrStatusEventNotFound   = commonerrors.New("status change not found")
+	ErrFailedToGetHistory    = commonerrors.New("failed to get status history")
+	ErrFailedToSaveHistory   = commonerrors.New("failed to save status history")
+	ErrStageNotFound         = commonerrors.New("pipeline stage not found")
+	ErrDuplicateStage        = commonerrors.New("you already have a stage with this name")
+	ErrLastStage             = commonerrors.New("a pipeline needs at least one stage")
+	ErrStageHasJobs          = commonerrors.New("move the jobs in this stage to another stage before changing its category")
+	ErrFailedToGetPipeline   = commonerrors.New("failed to get pipeline")
+	ErrFailedToSavePipeline  = commonerrors.New("failed to save pipeline")
+	ErrReminderNotFound      = commonerrors.New("reminder not found")
+	ErrFailedToGetReminders  = commonerrors.New("failed to get reminders")
+	ErrFailedToSaveReminder  = commonerrors.New("failed to save reminder")
 
 	// AI service errors
 	ErrAIServiceUnavailable   = commonerrors.New("AI service is not available")
 	ErrProfileServiceRequired = commonerrors.New("profile service dependency is required")
+	ErrDocumentSaveFailed     = commonerrors.New("generated document could not be saved")
+	ErrSkillGapStoreRequired  = commonerrors.New("skill gap repository dependency is required")
+	ErrNotJobPosting          = commonerrors.New("the pasted text doesn't look like a job posting")
+	ErrJobExtractionFailed    = commonerrors.New("couldn't extract the job details; please fill in the form yourself")
+	ErrSectionRewriteFailed   = commonerrors.New("couldn't rewrite the section; please try again")
+	ErrJobNeedsReview         = commonerrors.New("this job description looks like it contains instructions for the AI; review it on the job page before using AI features")
+	ErrReviewStoreRequired    = commonerrors.New("job description review repository dependency is required")
+	ErrTagStoreRequired       = commonerrors.New("tag repository dependency is required")
+	ErrHistoryStoreRequired   = commonerrors.New("status history repository dependency is required")
+	ErrPipelineStoreRequired  = commonerrors.New("pipeline repository dependency is required")
+	ErrReminderStoreRequired  = commonerrors.New("reminder repository dependency is required")
+	ErrNoReviewPending        = commonerrors.New("this job description has not been flagged for review")
 
 	// Profile validation errors for AI operations
 	ErrProfileIncomplete      = commonerrors.New("please complete your profile to use AI features")
 	ErrProfileSummaryRequired = commonerrors.New("please add a career summary to your profile to use AI features")
+	ErrWorkExperienceRequired = commonerrors.New("please add your work experience to your profile to prepare for interviews")
+
+	// Skill gap errors
+	ErrNoSkillGaps = commonerrors.New("no open skill gaps to plan for; analyse a few jobs first")
 
 	// Quota errors
 	ErrQuotaExceeded = commonerrors.New("monthly quota exceeded")
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// InjectionReview records that a job description looks like it contains
+// instructions aimed at the AI. AI features are blocked for the job until
+// the user approves the description.
+type InjectionReview struct {
+	JobID      int             `json:"jobId"`
+	Score      float64         `json:"score"`
+	Spans      []InjectionSpan `json:"spans"`
+	Approved   bool            `json:"approved"`
+	FlaggedAt  time.Time       `json:"flaggedAt"`
+	ReviewedAt *time.Time      `json:"reviewedAt,omitempty"`
+}
+
+// InjectionSpan is a part of the job description that matched an injection
+// rule. Start and End are byte offsets into the description.
+type InjectionSpan struct {
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Rule  string `json:"rule"`
+	Text  string `json:"text"`
+}
+
+// TextSegment is a run of text that is either flagged or plain, used to
+// highlight flagged spans in a job description.
+type TextSegment struct {
+	Text    string
+	Flagged bool
+	Rule    string
+}
+
+// BlocksAI reports whether the review stops AI features from running on the
+// job.
+func (r *InjectionReview) BlocksAI() bool {
+	return r != nil && !r.Approved
+}
+
+// ScorePercent returns the risk score as a whole percentage.
+func (r *InjectionReview) ScorePercent() int {
+	if r == nil {
+		return 0
+	}
+	return int(math.Round(r.Score * 100))
+}
+
+// Highlight splits description into plain and flagged segments. Overlapping
+// spans are merged, and spans that do not fit the description are skipped.
+func (r *InjectionReview) Highlight(description string) []TextSegment {
+	if r == nil || len(r.Spans) == 0 {
+		return []TextSegment{{Text: description}}
+	}
+
+	spans := make([]InjectionSpan, 0, len(r.Spans))
+	for _, span := range r.Spans {
+		if span.Start < 0 || span.End > len(description) || span.Start >= span.End {
+			continue
+		}
+		spans = append(spans, span)
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].Start < spans[j].Start })
+
+	var segments []TextSegment
+	pos := 0
+	for i := 0; i < len(spans); i++ {
+		start, end, rule := spans[i].Start, spans[i].End, spans[i].Rule
+		if start < pos {
+			start = pos
+		}
+		for i+1 < len(spans) && spans[i+1].Start < end {
+			i++
+			if spans[i].End > end {
+				end = spans[i].End
+			}
+		}
+		if start >= end {
+			continue
+		}
+
+		if start > pos {
+			segments = append(segments, TextSegment{Text: description[pos:start]})
+		}
+		segments = append(segments, TextSegment{Text: description[start:end], Flagged: true, Rule: rule})
+		pos = end
+	}
+	if pos < len(description) {
+		segments = append(segments, TextSegment{Text: description[pos:]})
+	}
+
+	return segments
+}
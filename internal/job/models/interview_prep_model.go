@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// InterviewPrep is an interview preparation pack generated for a job.
+type InterviewPrep struct {
+	JobID          int                 `json:"jobId"`
+	UserID         int                 `json:"userId"`
+	Questions      []InterviewQuestion `json:"questions"`
+	QuestionsToAsk []string            `json:"questionsToAsk"`
+	GapsToRehearse []string            `json:"gapsToRehearse"`
+	PromptVersion  string              `json:"promptVersion,omitempty"`
+	GeneratedAt    time.Time           `json:"generatedAt"`
+}
+
+// InterviewQuestion is a likely interview question with a suggested answer
+// drawn from one of the user's roles.
+type InterviewQuestion struct {
+	Question   string     `json:"question"`
+	Reason     string     `json:"reason,omitempty"`
+	Experience string     `json:"experience,omitempty"`
+	Answer     STARAnswer `json:"answer"`
+}
+
+// STARAnswer is an answer structured as situation, task, action and result.
+type STARAnswer struct {
+	Situation string `json:"situation"`
+	Task      string `json:"task"`
+	Action    string `json:"action"`
+	Result    string `json:"result"`
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+)
+
+// HighConfidence is the confidence from which an extracted field is trusted
+// without review.
+const HighConfidence = 0.7
+
+// JobDraft is a job extracted from a pasted job posting, used to prefill the
+// new job form before the user saves it.
+type JobDraft struct {
+	Title          string
+	Company        string
+	Location       string
+	Description    string
+	JobType        JobType
+	RequiredSkills []string
+	SalaryMin      int
+	SalaryMax      int
+	SalaryCurrency string
+	SalaryPeriod   string
+	Seniority      string
+	ApplicationURL string
+
+	// Confidence holds the extraction confidence, from 0 to 1, keyed by field:
+	// title, company, location, jobType, requiredSkills, salary, seniority
+	// and applicationUrl.
+	Confidence map[string]float64
+}
+
+// ConfidenceLevel returns "high" or "low" for an extracted field, or an empty
+// string when the field was not found in the posting.
+func (d *JobDraft) ConfidenceLevel(field string) string {
+	confidence, ok := d.Confidence[field]
+	switch {
+	case !ok || confidence <= 0:
+		return ""
+	case confidence >= HighConfidence:
+		return "high"
+	default:
+		return "low"
+	}
+}
+
+// Skills returns the required skills as a comma separated list.
+func (d *JobDraft) Skills() string {
+	return strings.Join(d.RequiredSkills, ", ")
+}
+
+// SalaryRange returns the salary range in a readable form, e.g.
+// "60,000–75,000 GBP per year", or an empty string when no salary was found.
+func (d *JobDraft) SalaryRange() string {
+	if d.SalaryMax <= 0 {
+		return ""
+	}
+
+	salary := formatThousands(d.SalaryMax)
+	if d.SalaryMin > 0 && d.SalaryMin < d.SalaryMax {
+		salary = formatThousands(d.SalaryMin) + "–" + salary
+	}
+	if d.SalaryCurrency != "" {
+		salary += " " + d.SalaryCurrency
+	}
+	if d.SalaryPeriod != "" {
+		salary += " per " + d.SalaryPeriod
+	}
+	return salary
+}
+
+// Notes returns the extracted details that have no field of their own on a
+// job, one per line.
+func (d *JobDraft) Notes() string {
+	var lines []string
+	if salary := d.SalaryRange(); salary != "" {
+		lines = append(lines, "Salary: "+salary)
+	}
+	if d.Seniority != "" {
+		lines = append(lines, "Seniority: "+strings.ToUpper(d.Seniority[:1])+d.Seniority[1:])
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatThousands formats n with comma thousands separators.
+func formatThousands(n int) string {
+	digits := strconv.Itoa(n)
+	var b strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(digit)
+	}
+	return b.String()
+}
@@ -7,7 +7,9 @@ import (
 	"time"
 )
 
-// JobStatus represents the status of a job application.
+// JobStatus represents the status of a job application. Users track jobs
+// through their own pipeline stages; the status is the category of the
+// job's stage, which stats and filters across pipelines use.
 type JobStatus int
 
 const (
@@ -19,7 +21,10 @@ const (
 	NOT_INTERESTED
 )
 
-// FromString converts a string representation of a job status to its corresponding JobStatus value.
+// JobStatusFromString converts a string representation of a job status to
+// its corresponding JobStatus value. Only the six categories are known here;
+// a user's own stage names, such as "Phone Screen", are resolved against
+// their pipeline with Pipeline.StageFromString.
 func JobStatusFromString(status string) (JobStatus, error) {
 	switch strings.ToLower(status) {
 	case "interested":
@@ -39,6 +44,38 @@ func JobStatusFromString(status string) (JobStatus, error) {
 	}
 }
 
+// JobStatuses returns every status, in pipeline order.
+func JobStatuses() []JobStatus {
+	return []JobStatus{INTERESTED, APPLIED, INTERVIEWING, OFFER_RECEIVED, REJECTED, NOT_INTERESTED}
+}
+
+// IsTerminal reports whether jobs with the status are no longer being
+// pursued.
+func (j JobStatus) IsTerminal() bool {
+	return j == REJECTED || j == NOT_INTERESTED
+}
+
+// FormValue returns the value used for the status in forms, query strings
+// and API requests, as accepted by JobStatusFromString.
+func (j JobStatus) FormValue() string {
+	switch j {
+	case INTERESTED:
+		return "interested"
+	case APPLIED:
+		return "applied"
+	case INTERVIEWING:
+		return "interviewing"
+	case OFFER_RECEIVED:
+		return "offer_received"
+	case REJECTED:
+		return "rejected"
+	case NOT_INTERESTED:
+		return "not_interested"
+	default:
+		return ""
+	}
+}
+
 // String returns the string representation of the JobStatus value.
 func (j JobStatus) String() string {
 	switch j {
@@ -112,6 +149,27 @@ func (j JobType) String() string {
 	}
 }
 
+// FormValue returns the value used for the job type in forms and API
+// requests, as accepted by JobTypeFromString.
+func (j JobType) FormValue() string {
+	switch j {
+	case FULL_TIME:
+		return "full_time"
+	case PART_TIME:
+		return "part_time"
+	case CONTRACT:
+		return "contract"
+	case INTERN:
+		return "intern"
+	case REMOTE:
+		return "remote"
+	case FREELANCE:
+		return "freelance"
+	default:
+		return "other"
+	}
+}
+
 // Job represents a job posting.
 // It includes metadata for database mapping and JSON serialization.
 type Job struct {
@@ -125,13 +183,25 @@ type Job struct {
 	RequiredSkills  []string   `json:"required_skills" db:"required_skills" sql:"type:text" validate:"max=50,dive,max=100"` // Stored as JSON
 	ApplicationURL  string     `json:"application_url" db:"application_url" sql:"type:text" validate:"omitempty,url"`
 	Company         Company    `json:"company" sql:"-" validate:"required"` // Not stored directly, company_id is used instead
-	Status          JobStatus  `json:"status" db:"status" sql:"type:integer;not null;default:0;index" validate:"min=0,max=5"`
+	Status          JobStatus  `json:"status" db:"status" sql:"type:integer;not null;default:0;index"`
 	MatchScore      *int       `json:"match_score,omitempty" db:"match_score" sql:"type:integer;index" validate:"omitempty,min=0,max=100"`
 	Notes           string     `json:"notes,omitempty" db:"notes" sql:"type:text" validate:"max=5000"`
 	CreatedAt       time.Time  `json:"created_at" db:"created_at" sql:"type:timestamp;not null;default:current_timestamp"`
 	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at" sql:"type:timestamp;not null;default:current_timestamp"`
 	FirstAnalyzedAt *time.Time `json:"first_analyzed_at,omitempty" db:"first_analyzed_at" sql:"type:timestamp"`
 
+	// Tags are the user's labels on the job. They are stored in their own
+	// table and loaded by the job service.
+	Tags []string `json:"tags,omitempty" sql:"-" validate:"max=20,dive,max=50"`
+
+	// Stage is the step of the user's pipeline the job is at; Status is its
+	// category. It is stored as stage_id and loaded by the job service.
+	Stage *PipelineStage `json:"stage,omitempty" sql:"-"`
+
+	// SearchSnippet is the part of the job that matched a search query. It is
+	// only set on search results.
+	SearchSnippet []SnippetSegment `json:"search_snippet,omitempty" sql:"-"`
+
 	// SQL-only fields
 	CompanyID int `json:"-" db:"company_id" sql:"type:integer;not null;index;references:companies(id)"`
 }
@@ -167,6 +237,13 @@ func WithRequiredSkills(skills []string) JobOption {
 	}
 }
 
+// WithTags sets the tags of the job
+func WithTags(tags []string) JobOption {
+	return func(j *Job) {
+		j.Tags = tags
+	}
+}
+
 // WithApplicationURL sets the application URL
 func WithApplicationURL(url string) JobOption {
 	return func(j *Job) {
@@ -181,6 +258,15 @@ func WithStatus(status JobStatus) JobOption {
 	}
 }
 
+// WithStage puts the job in a stage of the user's pipeline, setting its
+// status to the stage's category
+func WithStage(stage *PipelineStage) JobOption {
+	return func(j *Job) {
+		j.Stage = stage
+		j.Status = stage.Category
+	}
+}
+
 // WithNotes sets the notes for the job
 func WithNotes(notes string) JobOption {
 	return func(j *Job) {
@@ -226,6 +312,15 @@ func (j *Job) Validate() error {
 	return nil
 }
 
+// StageName returns the name of the job's stage, or of its status when the
+// stage isn't known.
+func (j *Job) StageName() string {
+	if j.Stage != nil {
+		return j.Stage.Name
+	}
+	return j.Status.String()
+}
+
 // IsMatched returns true if the job has a match score >= 70
 func (j *Job) IsMatched() bool {
 	return j.MatchScore != nil && *j.MatchScore >= 70
@@ -251,11 +346,15 @@ func (j *Job) GetMatchStatus() string {
 type JobFilter struct {
 	CompanyID *int
 	Status    *JobStatus
+	StageID   *int
 	JobType   *JobType
 	Matched   *bool
+	Query     string // full-text search query, see SearchExpression
+	Tags      []string
+	TagMatch  TagMatch // whether jobs need all or any of the tags
 	Limit     int
 	Offset    int
-	SortBy    string // "match_score", "updated_at", etc.
+	SortBy    string // "match_score", "updated_at", "relevance", etc.
 	SortOrder string // "asc" or "desc"
 }
 
@@ -279,16 +378,37 @@ type JobsWithPagination struct {
 	Pagination *PaginationInfo `json:"pagination"`
 }
 
+// SimilarJob is a saved job with how similar its description is to another
+// job, as a percentage
+type SimilarJob struct {
+	Job        *Job `json:"job"`
+	Similarity int  `json:"similarity"`
+}
+
 // CoverLetter represents a generated cover letter in the job domain.
 type CoverLetter struct {
-	ID          int       `json:"id"`
-	JobID       int       `json:"jobId"`
-	UserID      int       `json:"userId"`
-	Content     string    `json:"content"`
-	Format      string    `json:"format"`
-	GeneratedAt time.Time `json:"generatedAt"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	ID            int       `json:"id"`
+	JobID         int       `json:"jobId"`
+	UserID        int       `json:"userId"`
+	Content       string    `json:"content"`
+	Format        string    `json:"format"`
+	PromptVersion string    `json:"promptVersion,omitempty"`
+	GeneratedAt   time.Time `json:"generatedAt"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+
+	// Options are the tone, length and language the letter was written with
+	Options CoverLetterOptions `json:"options"`
+}
+
+// CoverLetterOptions controls the tone, length and language of a generated
+// cover letter. Empty fields fall back to the job's last cover letter, then
+// to the user's profile defaults; a language left empty after that follows
+// the job posting.
+type CoverLetterOptions struct {
+	Tone     string `json:"tone,omitempty"`
+	Length   string `json:"length,omitempty"`
+	Language string `json:"language,omitempty"`
 }
 
 // CoverLetterWithProfile holds a cover letter along with user profile information
@@ -299,17 +419,18 @@ type CoverLetterWithProfile struct {
 
 // JobMatchAnalysis represents a job match analysis result in the job domain.
 type JobMatchAnalysis struct {
-	ID         int       `json:"id"`
-	JobID      int       `json:"jobId"`
-	UserID     int       `json:"userId"`
-	MatchScore int       `json:"matchScore"`
-	Strengths  []string  `json:"strengths"`
-	Weaknesses []string  `json:"weaknesses"`
-	Highlights []string  `json:"highlights"`
-	Feedback   string    `json:"feedback"`
-	AnalyzedAt time.Time `json:"analyzedAt"`
-	CreatedAt  time.Time `json:"createdAt"`
-	UpdatedAt  time.Time `json:"updatedAt"`
+	ID            int       `json:"id"`
+	JobID         int       `json:"jobId"`
+	UserID        int       `json:"userId"`
+	MatchScore    int       `json:"matchScore"`
+	Strengths     []string  `json:"strengths"`
+	Weaknesses    []string  `json:"weaknesses"`
+	Highlights    []string  `json:"highlights"`
+	Feedback      string    `json:"feedback"`
+	PromptVersion string    `json:"promptVersion,omitempty"`
+	AnalyzedAt    time.Time `json:"analyzedAt"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
 }
 
 // ParsePositiveInt parses a string to a positive integer, returns error if invalid or negative
@@ -212,3 +212,27 @@ func TestJob_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestJobType_FormValue(t *testing.T) {
+	for _, jobType := range []JobType{FULL_TIME, PART_TIME, CONTRACT, INTERN, REMOTE, FREELANCE, OTHER} {
+		assert.Equal(t, jobType, JobTypeFromString(jobType.FormValue()))
+	}
+}
+
+func TestJobDraft_SalaryRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		draft    JobDraft
+		expected string
+	}{
+		{"no salary", JobDraft{}, ""},
+		{"single figure", JobDraft{SalaryMin: 950, SalaryMax: 950, SalaryPeriod: "day"}, "950 per day"},
+		{"range", JobDraft{SalaryMin: 1200000, SalaryMax: 1500000, SalaryCurrency: "JPY"}, "1,200,000–1,500,000 JPY"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.draft.SalaryRange())
+		})
+	}
+}
@@ -17,6 +17,9 @@ type MatchResult struct {
 	Highlights []string  `json:"highlights" db:"highlights" sql:"type:text"` // Stored as JSON
 	Feedback   string    `json:"feedback" db:"feedback" sql:"type:text"`
 	CreatedAt  time.Time `json:"created_at" db:"created_at" sql:"type:timestamp;not null;default:current_timestamp"`
+
+	// PromptVersion is the version of the job match prompt that produced the result
+	PromptVersion string `json:"prompt_version,omitempty" db:"prompt_version" sql:"type:text;not null;default:''"`
 }
 
 // MatchSummary represents a condensed version of a match result
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MaxPipelineStages bounds the number of stages in a user's pipeline
+	MaxPipelineStages = 20
+
+	// MaxStageNameLength bounds the length of a stage name, in characters
+	MaxStageNameLength = 40
+)
+
+// PipelineStage is a step of the user's application pipeline, such as
+// "Phone Screen" or "Take-home". Its category is the job status that jobs in
+// the stage count as.
+type PipelineStage struct {
+	ID       int       `json:"id"`
+	Name     string    `json:"name"`
+	Position int       `json:"position"`
+	Category JobStatus `json:"category"`
+	JobCount int       `json:"job_count"`
+}
+
+// IsTerminal reports whether jobs in the stage are no longer being pursued
+func (s *PipelineStage) IsTerminal() bool {
+	return s.Category.IsTerminal()
+}
+
+// Pipeline is the user's stages, in order
+type Pipeline []*PipelineStage
+
+// DefaultPipeline returns the pipeline every user starts with, one stage per
+// status. Its stages have no IDs.
+func DefaultPipeline() Pipeline {
+	statuses := JobStatuses()
+	pipeline := make(Pipeline, len(statuses))
+	for i, status := range statuses {
+		pipeline[i] = &PipelineStage{
+			Name:     status.String(),
+			Position: i + 1,
+			Category: status,
+		}
+	}
+	return pipeline
+}
+
+// Stage returns the stage with the given ID, or nil
+func (p Pipeline) Stage(id int) *PipelineStage {
+	for _, stage := range p {
+		if stage.ID == id {
+			return stage
+		}
+	}
+	return nil
+}
+
+// FirstInCategory returns the first stage of the given category, or nil if
+// the pipeline has none
+func (p Pipeline) FirstInCategory(category JobStatus) *PipelineStage {
+	for _, stage := range p {
+		if stage.Category == category {
+			return stage
+		}
+	}
+	return nil
+}
+
+// FindStage returns the stage with the given name, ignoring case and reading
+// underscores as spaces so that "phone_screen" finds "Phone Screen", or nil.
+func (p Pipeline) FindStage(name string) *PipelineStage {
+	name = strings.Join(strings.Fields(strings.ReplaceAll(name, "_", " ")), " ")
+	for _, stage := range p {
+		if strings.EqualFold(stage.Name, name) {
+			return stage
+		}
+	}
+	return nil
+}
+
+// StageFromString returns the stage a status value from a form or request
+// refers to: a stage name, or a status as accepted by JobStatusFromString,
+// which means the first stage of that category.
+func (p Pipeline) StageFromString(value string) (*PipelineStage, error) {
+	if stage := p.FindStage(value); stage != nil {
+		return stage, nil
+	}
+
+	status, err := JobStatusFromString(strings.TrimSpace(value))
+	if err != nil {
+		return nil, err
+	}
+	if stage := p.FirstInCategory(status); stage != nil {
+		return stage, nil
+	}
+	return nil, ErrInvalidJobStatus
+}
+
+// NormalizeStageName trims a stage name, collapses runs of whitespace within
+// it and checks its length
+func NormalizeStageName(name string) (string, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" {
+		return "", ErrStageNameRequired
+	}
+	if utf8.RuneCountInString(name) > MaxStageNameLength {
+		return "", ErrStageNameTooLong
+	}
+	return name, nil
+}
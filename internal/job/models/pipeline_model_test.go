@@ -0,0 +1,74 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultPipeline(t *testing.T) {
+	pipeline := DefaultPipeline()
+
+	require.Len(t, pipeline, len(JobStatuses()))
+	for i, status := range JobStatuses() {
+		assert.Equal(t, status.String(), pipeline[i].Name)
+		assert.Equal(t, status, pipeline[i].Category)
+		assert.Equal(t, i+1, pipeline[i].Position)
+	}
+}
+
+func TestPipeline_StageFromString(t *testing.T) {
+	pipeline := Pipeline{
+		{ID: 1, Name: "Interested", Category: INTERESTED},
+		{ID: 2, Name: "Applied", Category: APPLIED},
+		{ID: 3, Name: "Phone Screen", Category: INTERVIEWING},
+		{ID: 4, Name: "Onsite", Category: INTERVIEWING},
+		{ID: 5, Name: "Rejected", Category: REJECTED},
+	}
+
+	tests := []struct {
+		name    string
+		value   string
+		stageID int
+	}{
+		{"stage name", "Onsite", 4},
+		{"stage name ignoring case", "phone screen", 3},
+		{"stage name with underscores", "phone_screen", 3},
+		{"status means the first stage of the category", "interviewing", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stage, err := pipeline.StageFromString(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.stageID, stage.ID)
+		})
+	}
+
+	t.Run("unknown value", func(t *testing.T) {
+		_, err := pipeline.StageFromString("hired")
+		assert.ErrorIs(t, err, ErrInvalidJobStatus)
+	})
+
+	t.Run("status without a stage", func(t *testing.T) {
+		_, err := pipeline.StageFromString("offer_received")
+		assert.ErrorIs(t, err, ErrInvalidJobStatus)
+	})
+}
+
+func TestNormalizeStageName(t *testing.T) {
+	name, err := NormalizeStageName("  Take-home \t task ")
+	require.NoError(t, err)
+	assert.Equal(t, "Take-home task", name)
+
+	_, err = NormalizeStageName(" ")
+	assert.ErrorIs(t, err, ErrStageNameRequired)
+
+	_, err = NormalizeStageName(strings.Repeat("é", MaxStageNameLength))
+	assert.NoError(t, err)
+
+	_, err = NormalizeStageName(strings.Repeat("é", MaxStageNameLength+1))
+	assert.ErrorIs(t, err, ErrStageNameTooLong)
+}
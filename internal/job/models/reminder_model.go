@@ -0,0 +1,215 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxReminderMessageLength bounds the message of a reminder, in characters
+	MaxReminderMessageLength = 200
+
+	// ReminderDueLayout is the layout of due times entered by the user, that
+	// of a datetime-local input
+	ReminderDueLayout = "2006-01-02T15:04"
+
+	// FollowUpAfter is how long after applying the suggested follow-up is due
+	FollowUpAfter = 7 * 24 * time.Hour
+
+	// InterviewPrepAfter is how long after a job reaches the interviewing
+	// status the suggested interview preparation is due
+	InterviewPrepAfter = 24 * time.Hour
+)
+
+// ReminderStatus is where a reminder is in its life: waiting for its due
+// time, due and not yet acted on, or done
+type ReminderStatus int
+
+const (
+	ReminderPending ReminderStatus = iota
+	ReminderDue
+	ReminderDone
+)
+
+// ReminderRecurrence says how often a reminder repeats. Completing a
+// recurring reminder moves it to its next due time.
+type ReminderRecurrence string
+
+const (
+	RecurrenceNone        ReminderRecurrence = ""
+	RecurrenceDaily       ReminderRecurrence = "daily"
+	RecurrenceWeekly      ReminderRecurrence = "weekly"
+	RecurrenceFortnightly ReminderRecurrence = "fortnightly"
+	RecurrenceMonthly     ReminderRecurrence = "monthly"
+)
+
+// ReminderRecurrences returns every recurrence, starting with none
+func ReminderRecurrences() []ReminderRecurrence {
+	return []ReminderRecurrence{RecurrenceNone, RecurrenceDaily, RecurrenceWeekly, RecurrenceFortnightly, RecurrenceMonthly}
+}
+
+// ReminderRecurrenceFromString parses a recurrence from a form. Empty and
+// "none" mean the reminder doesn't repeat.
+func ReminderRecurrenceFromString(value string) (ReminderRecurrence, error) {
+	switch value = strings.ToLower(strings.TrimSpace(value)); value {
+	case "", "none":
+		return RecurrenceNone, nil
+	default:
+		for _, recurrence := range ReminderRecurrences() {
+			if string(recurrence) == value {
+				return recurrence, nil
+			}
+		}
+		return RecurrenceNone, ErrInvalidRecurrence
+	}
+}
+
+// String returns how the recurrence is shown to the user
+func (r ReminderRecurrence) String() string {
+	switch r {
+	case RecurrenceDaily:
+		return "Every day"
+	case RecurrenceWeekly:
+		return "Every week"
+	case RecurrenceFortnightly:
+		return "Every two weeks"
+	case RecurrenceMonthly:
+		return "Every month"
+	default:
+		return "Does not repeat"
+	}
+}
+
+// NextAfter returns the first due time after now of a reminder repeating
+// from the given due time, or the zero time if it doesn't repeat. Monthly
+// reminders keep the day of the month they started on, falling on the last
+// day of shorter months.
+func (r ReminderRecurrence) NextAfter(due, now time.Time) time.Time {
+	var days int
+	switch r {
+	case RecurrenceDaily:
+		days = 1
+	case RecurrenceWeekly:
+		days = 7
+	case RecurrenceFortnightly:
+		days = 14
+	case RecurrenceMonthly:
+		next := addMonths(due, 1)
+		for n := 2; !next.After(now); n++ {
+			next = addMonths(due, n)
+		}
+		return next
+	default:
+		return time.Time{}
+	}
+
+	next := due.AddDate(0, 0, days)
+	for !next.After(now) {
+		next = next.AddDate(0, 0, days)
+	}
+	return next
+}
+
+// addMonths adds n months to t, moving the day back to the last day of the
+// month it lands in when that month is shorter
+func addMonths(t time.Time, n int) time.Time {
+	year, month, day := t.Date()
+	first := time.Date(year, month+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// ReminderKind names the suggestion a reminder was created from. Reminders
+// the user adds have no kind.
+type ReminderKind string
+
+const (
+	ReminderKindFollowUp      ReminderKind = "follow_up"
+	ReminderKindInterviewPrep ReminderKind = "interview_prep"
+)
+
+// Reminder is a nudge to do something about a job at a given time. The job
+// title and company name are only set on reminders listed across jobs.
+type Reminder struct {
+	ID          int                `json:"id"`
+	JobID       int                `json:"job_id"`
+	Message     string             `json:"message"`
+	DueAt       time.Time          `json:"due_at"`
+	Recurrence  ReminderRecurrence `json:"recurrence,omitempty"`
+	Status      ReminderStatus     `json:"status"`
+	Kind        ReminderKind       `json:"kind,omitempty"`
+	CompletedAt *time.Time         `json:"completed_at,omitempty"`
+	CreatedAt   time.Time          `json:"created_at"`
+	JobTitle    string             `json:"job_title,omitempty"`
+	CompanyName string             `json:"company_name,omitempty"`
+}
+
+// IsOverdue reports whether the reminder has come due and not been done
+func (r *Reminder) IsOverdue() bool {
+	return r.Status == ReminderDue
+}
+
+// IsDone reports whether the reminder has been completed
+func (r *Reminder) IsDone() bool {
+	return r.Status == ReminderDone
+}
+
+// IsSuggested reports whether the reminder was created from a suggestion
+// rather than by the user
+func (r *Reminder) IsSuggested() bool {
+	return r.Kind != ""
+}
+
+// SuggestedReminder returns the reminder suggested when a job reaches a
+// status at the given time: following up a week after applying, and
+// preparing once an interview is on the way. Other statuses have none.
+func SuggestedReminder(jobID int, status JobStatus, at time.Time) *Reminder {
+	reminder := &Reminder{JobID: jobID, Status: ReminderPending}
+	switch status {
+	case APPLIED:
+		reminder.Kind = ReminderKindFollowUp
+		reminder.Message = "Follow up on your application"
+		reminder.DueAt = at.Add(FollowUpAfter)
+	case INTERVIEWING:
+		reminder.Kind = ReminderKindInterviewPrep
+		reminder.Message = "Prepare for your interview"
+		reminder.DueAt = at.Add(InterviewPrepAfter)
+	default:
+		return nil
+	}
+	reminder.DueAt = reminder.DueAt.UTC().Truncate(time.Minute)
+	return reminder
+}
+
+// ParseReminderDue parses a due time entered by the user in their own time
+// zone. The offset is the one the browser reports, the minutes to add to the
+// local time to get UTC; a missing or invalid offset is taken as UTC.
+func ParseReminderDue(value, offset string) (time.Time, error) {
+	due, err := time.Parse(ReminderDueLayout, strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}, ErrInvalidReminderDue
+	}
+
+	minutes, err := strconv.Atoi(strings.TrimSpace(offset))
+	if err != nil || minutes < -14*60 || minutes > 14*60 {
+		minutes = 0
+	}
+	return due.Add(time.Duration(minutes) * time.Minute), nil
+}
+
+// NormalizeReminderMessage trims the message of a reminder and checks that it
+// is given and not too long
+func NormalizeReminderMessage(message string) (string, error) {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return "", ErrReminderMessageRequired
+	}
+	if utf8.RuneCountInString(message) > MaxReminderMessageLength {
+		return "", ErrReminderMessageTooLong
+	}
+	return message, nil
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReminderRecurrence_NextAfter(t *testing.T) {
+	due := time.Date(2025, 1, 31, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		recurrence ReminderRecurrence
+		now        time.Time
+		want       time.Time
+	}{
+		{"none", RecurrenceNone, due, time.Time{}},
+		{"daily", RecurrenceDaily, due, due.AddDate(0, 0, 1)},
+		{"weekly", RecurrenceWeekly, due, due.AddDate(0, 0, 7)},
+		{"fortnightly", RecurrenceFortnightly, due, due.AddDate(0, 0, 14)},
+		{"monthly falls on the last day of a shorter month", RecurrenceMonthly, due, time.Date(2025, 2, 28, 9, 0, 0, 0, time.UTC)},
+		{"monthly keeps the day it started on", RecurrenceMonthly, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)},
+		{"monthly in a leap year", RecurrenceMonthly, time.Date(2028, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2028, 2, 29, 9, 0, 0, 0, time.UTC)},
+		{"monthly across the year end", RecurrenceMonthly, time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 31, 9, 0, 0, 0, time.UTC)},
+		{"skips due times already passed", RecurrenceWeekly, due.AddDate(0, 0, 20), due.AddDate(0, 0, 21)},
+		{"unknown recurrence", ReminderRecurrence("yearly"), due, time.Time{}},
+		{"unknown recurrence with a past due time", ReminderRecurrence("hourly"), due.AddDate(1, 0, 0), time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.recurrence.NextAfter(due, tt.now))
+		})
+	}
+}
+
+func TestReminderRecurrenceFromString(t *testing.T) {
+	for _, value := range []string{"", "none", " None "} {
+		recurrence, err := ReminderRecurrenceFromString(value)
+		require.NoError(t, err)
+		assert.Equal(t, RecurrenceNone, recurrence)
+	}
+
+	recurrence, err := ReminderRecurrenceFromString("Weekly")
+	require.NoError(t, err)
+	assert.Equal(t, RecurrenceWeekly, recurrence)
+
+	_, err = ReminderRecurrenceFromString("yearly")
+	assert.ErrorIs(t, err, ErrInvalidRecurrence)
+}
+
+func TestSuggestedReminder(t *testing.T) {
+	at := time.Date(2025, 6, 2, 14, 30, 45, 0, time.FixedZone("CEST", 2*60*60))
+
+	followUp := SuggestedReminder(3, APPLIED, at)
+	require.NotNil(t, followUp)
+	assert.Equal(t, ReminderKindFollowUp, followUp.Kind)
+	assert.Equal(t, time.Date(2025, 6, 9, 12, 30, 0, 0, time.UTC), followUp.DueAt)
+	assert.True(t, followUp.IsSuggested())
+
+	prep := SuggestedReminder(3, INTERVIEWING, at)
+	require.NotNil(t, prep)
+	assert.Equal(t, ReminderKindInterviewPrep, prep.Kind)
+	assert.Equal(t, time.Date(2025, 6, 3, 12, 30, 0, 0, time.UTC), prep.DueAt)
+
+	assert.Nil(t, SuggestedReminder(3, INTERESTED, at))
+	assert.Nil(t, SuggestedReminder(3, REJECTED, at))
+}
+
+func TestParseReminderDue(t *testing.T) {
+	t.Run("converts the user's local time to UTC", func(t *testing.T) {
+		due, err := ParseReminderDue("2025-06-02T09:00", "-120")
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2025, 6, 2, 7, 0, 0, 0, time.UTC), due)
+	})
+
+	t.Run("takes a missing or invalid offset as UTC", func(t *testing.T) {
+		for _, offset := range []string{"", "abc", "100000"} {
+			due, err := ParseReminderDue("2025-06-02T09:00", offset)
+			require.NoError(t, err)
+			assert.Equal(t, time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC), due)
+		}
+	})
+
+	t.Run("rejects an invalid time", func(t *testing.T) {
+		_, err := ParseReminderDue("tomorrow", "0")
+		assert.ErrorIs(t, err, ErrInvalidReminderDue)
+	})
+}
+
+func TestNormalizeReminderMessage(t *testing.T) {
+	message, err := NormalizeReminderMessage("  Chase the recruiter ")
+	require.NoError(t, err)
+	assert.Equal(t, "Chase the recruiter", message)
+
+	_, err = NormalizeReminderMessage("   ")
+	assert.ErrorIs(t, err, ErrReminderMessageRequired)
+
+	_, err = NormalizeReminderMessage(strings.Repeat("é", MaxReminderMessageLength+1))
+	assert.ErrorIs(t, err, ErrReminderMessageTooLong)
+}
@@ -0,0 +1,95 @@
+package models
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Markers placed around matched terms in search snippets. Control characters
+// are used so that they can't clash with text in the job itself.
+const (
+	SnippetMatchStart = "\x02"
+	SnippetMatchEnd   = "\x03"
+)
+
+// maxSearchTerms bounds the number of words in a search query
+const maxSearchTerms = 10
+
+// searchFillerWords are dropped from search queries that have other words, so
+// that "that Rust job in Berlin" is not ranked by how often a job says "in".
+// Only function words are listed; words such as "job" or "role" can be what
+// the user is looking for, as in "Role Playing Games".
+var searchFillerWords = map[string]bool{
+	"a": true, "an": true, "and": true, "at": true, "for": true, "in": true,
+	"of": true, "on": true, "that": true, "the": true, "to": true, "with": true,
+}
+
+// SnippetSegment is a piece of a search snippet; Match marks the terms that
+// matched the query.
+type SnippetSegment struct {
+	Text  string `json:"text"`
+	Match bool   `json:"match,omitempty"`
+}
+
+// SearchExpression turns a search query typed by a user into an FTS5 match
+// expression. A job matches when it contains any of the words, and jobs are
+// ranked by how well they match, so "that Rust job in Berlin" still finds a
+// Rust role in Berlin that never says "job". Words match as prefixes so that
+// "berl" finds Berlin, and are quoted, so characters with a meaning in FTS5
+// syntax are searched for literally. It returns an empty string when the
+// query has no words.
+func SearchExpression(query string) string {
+	words := strings.FieldsFunc(query, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '"'
+	})
+
+	var terms, fillers []string
+	for _, word := range words {
+		if strings.IndexFunc(word, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) < 0 {
+			continue
+		}
+
+		term := `"` + word + `"*`
+		if searchFillerWords[strings.ToLower(word)] {
+			fillers = append(fillers, term)
+			continue
+		}
+		terms = append(terms, term)
+	}
+
+	// A query made only of filler words is searched for as typed
+	if len(terms) == 0 {
+		terms = fillers
+	}
+	if len(terms) > maxSearchTerms {
+		terms = terms[:maxSearchTerms]
+	}
+	return strings.Join(terms, " OR ")
+}
+
+// ParseSnippet splits a snippet returned by the search index into segments,
+// using the markers around matched terms.
+func ParseSnippet(snippet string) []SnippetSegment {
+	var segments []SnippetSegment
+	for snippet != "" {
+		start := strings.Index(snippet, SnippetMatchStart)
+		if start < 0 {
+			segments = append(segments, SnippetSegment{Text: snippet})
+			break
+		}
+		if start > 0 {
+			segments = append(segments, SnippetSegment{Text: snippet[:start]})
+		}
+
+		snippet = snippet[start+len(SnippetMatchStart):]
+		end := strings.Index(snippet, SnippetMatchEnd)
+		if end < 0 {
+			end = len(snippet)
+		}
+		if end > 0 {
+			segments = append(segments, SnippetSegment{Text: snippet[:end], Match: true})
+		}
+		snippet = strings.TrimPrefix(snippet[end:], SnippetMatchEnd)
+	}
+	return segments
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchExpression(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{"empty", "   ", ""},
+		{"filler words are dropped", "that Rust job in Berlin", `"Rust"* OR "job"* OR "Berlin"*`},
+		{"only filler words", "in the", `"in"* OR "the"*`},
+		{"job and role are searched", "Role Playing Games", `"Role"* OR "Playing"* OR "Games"*`},
+		{"FTS syntax is searched literally", `go OR NOT "rust" title:senior -remote`, `"go"* OR "OR"* OR "NOT"* OR "rust"* OR "title:senior"* OR "-remote"*`},
+		{"punctuation only words are skipped", "golang - ** ()", `"golang"*`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SearchExpression(tt.query))
+		})
+	}
+}
+
+func TestParseSnippet(t *testing.T) {
+	snippet := "…senior " + SnippetMatchStart + "Rust" + SnippetMatchEnd + " role in " + SnippetMatchStart + "Berlin" + SnippetMatchEnd
+
+	assert.Equal(t, []SnippetSegment{
+		{Text: "…senior "},
+		{Text: "Rust", Match: true},
+		{Text: " role in "},
+		{Text: "Berlin", Match: true},
+	}, ParseSnippet(snippet))
+	assert.Nil(t, ParseSnippet(""))
+	assert.Equal(t, []SnippetSegment{{Text: "unclosed", Match: true}}, ParseSnippet(SnippetMatchStart+"unclosed"))
+}
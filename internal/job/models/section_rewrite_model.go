@@ -0,0 +1,37 @@
+package models
+
+import "github.com/benidevo/vega/internal/common/textdiff"
+
+// SectionRewrite is an AI rewrite of one section of a generated CV or cover
+// letter. It is shown next to the original so the user can accept or reject
+// it; nothing is saved until they do.
+type SectionRewrite struct {
+	JobID       int
+	Section     string
+	Instruction string
+	Original    string
+	Rewritten   string
+	Changes     string
+	Diff        []textdiff.Segment
+}
+
+// Changed reports whether the rewrite differs from the original.
+func (r *SectionRewrite) Changed() bool {
+	return textdiff.Changed(r.Diff)
+}
+
+// SectionLabel returns a readable name for the rewritten section.
+func (r *SectionRewrite) SectionLabel() string {
+	switch r.Section {
+	case "summary":
+		return "Professional summary"
+	case "experience":
+		return "Work experience"
+	case "skills":
+		return "Skills"
+	case "cover_letter_paragraph":
+		return "Cover letter paragraph"
+	default:
+		return r.Section
+	}
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// SkillGap is a missing skill or weakness that keeps coming up in the user's
+// job match analyses.
+type SkillGap struct {
+	Key           string        `json:"key"`
+	Name          string        `json:"name"`
+	Occurrences   int           `json:"occurrences"` // Number of analysed jobs the gap appears in
+	MissingSkill  bool          `json:"missingSkill"`
+	Jobs          []SkillGapJob `json:"jobs"`
+	Weaknesses    []string      `json:"weaknesses"` // Match feedback that mentions the gap
+	Plan          *GapPlan      `json:"plan,omitempty"`
+	AddressedAt   *time.Time    `json:"addressedAt,omitempty"`
+	AddressedNote string        `json:"addressedNote,omitempty"`
+}
+
+// IsAddressed reports whether the user has marked the gap as addressed.
+func (g *SkillGap) IsAddressed() bool {
+	return g.AddressedAt != nil
+}
+
+// SkillGapJob is an analysed job a skill gap was found in.
+type SkillGapJob struct {
+	JobID   int    `json:"jobId"`
+	Title   string `json:"title"`
+	Company string `json:"company"`
+}
+
+// GapPlan is a learning plan for closing a single skill gap.
+type GapPlan struct {
+	Why           string    `json:"why,omitempty"`
+	Steps         []string  `json:"steps"`
+	Projects      []string  `json:"projects"`
+	Phrasing      []string  `json:"phrasing"`
+	PromptVersion string    `json:"promptVersion,omitempty"`
+	GeneratedAt   time.Time `json:"generatedAt"`
+}
+
+// SkillGapReport groups the user's skill gaps into open and addressed gaps,
+// most frequent first.
+type SkillGapReport struct {
+	AnalysedJobs int         `json:"analysedJobs"`
+	Open         []*SkillGap `json:"open"`
+	Addressed    []*SkillGap `json:"addressed"`
+}
+
+// HasPlans reports whether any open gap has a learning plan.
+func (r *SkillGapReport) HasPlans() bool {
+	for _, gap := range r.Open {
+		if gap.Plan != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// SkillGapState is what has been stored for a skill gap: its learning plan
+// and whether the user has addressed it.
+type SkillGapState struct {
+	Key           string
+	Name          string
+	Plan          *GapPlan
+	AddressedAt   *time.Time
+	AddressedNote string
+}
+
+// AnalysedJob is a job with the weaknesses from its latest match analysis.
+type AnalysedJob struct {
+	JobID          int
+	Title          string
+	Company        string
+	RequiredSkills []string
+	Weaknesses     []string
+}
+
+// SkillGapKey normalizes a gap name so the same gap written with different
+// case or spacing is counted once.
+func SkillGapKey(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
@@ -0,0 +1,138 @@
+package models
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxStatusNoteLength bounds the note on a status change, in characters
+	MaxStatusNoteLength = 500
+
+	// StatusDateLayout is the layout of status dates entered by the user
+	StatusDateLayout = "2006-01-02"
+)
+
+// StatusEvent is a change of a job's pipeline stage. FromStatus is nil for
+// the stage the job was created with. The statuses are the categories of the
+// stages; the stage names are empty when the stage has since been deleted.
+type StatusEvent struct {
+	ID         int        `json:"id"`
+	JobID      int        `json:"job_id"`
+	FromStatus *JobStatus `json:"from_status,omitempty"`
+	ToStatus   JobStatus  `json:"to_status"`
+	FromStage  string     `json:"from_stage,omitempty"`
+	ToStage    string     `json:"to_stage,omitempty"`
+	ToStageID  int        `json:"to_stage_id,omitempty"`
+	Note       string     `json:"note,omitempty"`
+	OccurredAt time.Time  `json:"occurred_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// FromName returns the name of the stage the job moved from, or of its
+// status when the stage is gone. It is empty for the first stage.
+func (e *StatusEvent) FromName() string {
+	switch {
+	case e.FromStatus == nil:
+		return ""
+	case e.FromStage != "":
+		return e.FromStage
+	default:
+		return e.FromStatus.String()
+	}
+}
+
+// ToName returns the name of the stage the job moved to, or of its status
+// when the stage is gone
+func (e *StatusEvent) ToName() string {
+	if e.ToStage != "" {
+		return e.ToStage
+	}
+	return e.ToStatus.String()
+}
+
+// StatusTimelineEntry is a status change with how long the job stayed in the
+// status it moved to
+type StatusTimelineEntry struct {
+	Event   *StatusEvent
+	Days    int
+	Current bool
+}
+
+// StatusActivity summarizes the user's status changes over a period, using
+// the dates the changes happened rather than when jobs were last updated
+type StatusActivity struct {
+	Applied    int `json:"applied"`
+	Interviews int `json:"interviews"`
+
+	// AvgDaysToInterview is the average number of days from applying to a job
+	// to the first interview for it, over the TimedInterviews jobs that have
+	// both
+	AvgDaysToInterview float64 `json:"avg_days_to_interview"`
+	TimedInterviews    int     `json:"timed_interviews"`
+}
+
+// NewStatusTimeline returns the timeline of the given status changes, which
+// must be ordered by when they happened. The last status is the current one
+// and lasts until now.
+func NewStatusTimeline(events []*StatusEvent, now time.Time) []StatusTimelineEntry {
+	timeline := make([]StatusTimelineEntry, len(events))
+	for i, event := range events {
+		end := now
+		if i+1 < len(events) {
+			end = events[i+1].OccurredAt
+		}
+		timeline[i] = StatusTimelineEntry{
+			Event:   event,
+			Days:    daysBetween(event.OccurredAt, end),
+			Current: i == len(events)-1,
+		}
+	}
+	return timeline
+}
+
+// ParseStatusDate parses the date a status change happened, as entered by the
+// user. An empty date means now, as does today's date so that changes made
+// today keep their order. Earlier dates are taken as midday UTC, which falls
+// on the same day in most time zones.
+func ParseStatusDate(value string, now time.Time) (time.Time, error) {
+	now = now.UTC()
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return now, nil
+	}
+
+	date, err := time.Parse(StatusDateLayout, value)
+	if err != nil {
+		return time.Time{}, ErrInvalidStatusDate
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	switch {
+	case date.After(today):
+		return time.Time{}, ErrStatusDateInFuture
+	case date.Equal(today):
+		return now, nil
+	default:
+		return date.Add(12 * time.Hour), nil
+	}
+}
+
+// NormalizeStatusNote trims the note on a status change and checks its length
+func NormalizeStatusNote(note string) (string, error) {
+	note = strings.TrimSpace(note)
+	if utf8.RuneCountInString(note) > MaxStatusNoteLength {
+		return "", ErrStatusNoteTooLong
+	}
+	return note, nil
+}
+
+// daysBetween returns the number of whole days from start to end, or zero if
+// end is before start
+func daysBetween(start, end time.Time) int {
+	if end.Before(start) {
+		return 0
+	}
+	return int(end.Sub(start).Hours() / 24)
+}
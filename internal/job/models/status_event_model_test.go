@@ -0,0 +1,67 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStatusDate(t *testing.T) {
+	now := time.Date(2025, 3, 14, 16, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Time
+	}{
+		{"empty means now", "", now},
+		{"today means now", "2025-03-14", now},
+		{"earlier dates are at midday", " 2025-03-11 ", time.Date(2025, 3, 11, 12, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date, err := ParseStatusDate(tt.value, now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, date)
+		})
+	}
+
+	t.Run("future dates are rejected", func(t *testing.T) {
+		_, err := ParseStatusDate("2025-03-15", now)
+		assert.ErrorIs(t, err, ErrStatusDateInFuture)
+	})
+
+	t.Run("other formats are rejected", func(t *testing.T) {
+		_, err := ParseStatusDate("last Tuesday", now)
+		assert.ErrorIs(t, err, ErrInvalidStatusDate)
+	})
+}
+
+func TestNormalizeStatusNote(t *testing.T) {
+	note, err := NormalizeStatusNote("  through a referral \n")
+	require.NoError(t, err)
+	assert.Equal(t, "through a referral", note)
+
+	_, err = NormalizeStatusNote(strings.Repeat("é", MaxStatusNoteLength+1))
+	assert.ErrorIs(t, err, ErrStatusNoteTooLong)
+}
+
+func TestNewStatusTimeline(t *testing.T) {
+	start := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	interested := INTERESTED
+	events := []*StatusEvent{
+		{ID: 1, ToStatus: INTERESTED, OccurredAt: start},
+		{ID: 2, FromStatus: &interested, ToStatus: APPLIED, OccurredAt: start.AddDate(0, 0, 3)},
+	}
+
+	timeline := NewStatusTimeline(events, start.AddDate(0, 0, 10).Add(time.Hour))
+
+	require.Len(t, timeline, 2)
+	assert.Equal(t, StatusTimelineEntry{Event: events[0], Days: 3}, timeline[0])
+	assert.Equal(t, StatusTimelineEntry{Event: events[1], Days: 7, Current: true}, timeline[1])
+	assert.Empty(t, NewStatusTimeline(nil, start))
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MaxTagsPerJob bounds the number of tags on one job
+	MaxTagsPerJob = 20
+
+	// MaxTagLength bounds the length of a tag name, in characters
+	MaxTagLength = 50
+)
+
+// Tag is a label the user puts on jobs to group them across statuses
+type Tag struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	JobCount int    `json:"job_count"`
+}
+
+// TagMatch says whether a job must have all or any of the tags in a filter
+type TagMatch string
+
+const (
+	TagMatchAny TagMatch = "any"
+	TagMatchAll TagMatch = "all"
+)
+
+// TagMatchFromString returns the tag match for the given value, matching any
+// tag unless "all" is given
+func TagMatchFromString(value string) TagMatch {
+	if strings.EqualFold(strings.TrimSpace(value), string(TagMatchAll)) {
+		return TagMatchAll
+	}
+	return TagMatchAny
+}
+
+// ParseTags splits a comma-separated list of tags, as typed in the tag
+// editor or passed in a query string.
+func ParseTags(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// NormalizeTags trims the tags and collapses runs of whitespace within them.
+// Empty tags are dropped, as are tags that differ from an earlier one only in
+// case, since tag names are unique per user ignoring case.
+func NormalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.Join(strings.Fields(tag), " ")
+		if tag == "" {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > MaxTagLength {
+			return nil, ErrTagTooLong
+		}
+
+		key := strings.ToLower(tag)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, tag)
+	}
+
+	if len(normalized) > MaxTagsPerJob {
+		return nil, ErrTooManyTags
+	}
+	return normalized, nil
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		tags     []string
+		expected []string
+	}{
+		{"nothing", nil, []string{}},
+		{"whitespace is collapsed", []string{"  visa \t sponsorship "}, []string{"visa sponsorship"}},
+		{"empty tags are dropped", []string{"", "  ", "referral"}, []string{"referral"}},
+		{"first spelling of a duplicate is kept", []string{"Dream Company", "dream  company", "remote"}, []string{"Dream Company", "remote"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tags, err := NormalizeTags(tt.tags)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, tags)
+		})
+	}
+
+	t.Run("tags are limited in length", func(t *testing.T) {
+		_, err := NormalizeTags([]string{strings.Repeat("é", MaxTagLength)})
+		assert.NoError(t, err)
+
+		_, err = NormalizeTags([]string{strings.Repeat("é", MaxTagLength+1)})
+		assert.ErrorIs(t, err, ErrTagTooLong)
+	})
+
+	t.Run("jobs have a limited number of tags", func(t *testing.T) {
+		tags := make([]string, MaxTagsPerJob+1)
+		for i := range tags {
+			tags[i] = strings.Repeat("t", i+1)
+		}
+		_, err := NormalizeTags(tags)
+		assert.ErrorIs(t, err, ErrTooManyTags)
+	})
+}
+
+func TestParseTags(t *testing.T) {
+	assert.Nil(t, ParseTags("  "))
+	assert.Equal(t, []string{"referral", " remote"}, ParseTags("referral, remote"))
+}
+
+func TestTagMatchFromString(t *testing.T) {
+	assert.Equal(t, TagMatchAll, TagMatchFromString("all"))
+	assert.Equal(t, TagMatchAll, TagMatchFromString(" ALL "))
+	assert.Equal(t, TagMatchAny, TagMatchFromString("any"))
+	assert.Equal(t, TagMatchAny, TagMatchFromString(""))
+	assert.Equal(t, TagMatchAny, TagMatchFromString("both"))
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/benidevo/vega/internal/job/models"
+)
+
+// SQLiteInjectionReviewRepository is a SQLite implementation of
+// InjectionReviewRepository
+type SQLiteInjectionReviewRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteInjectionReviewRepository creates a new injection review repository
+func NewSQLiteInjectionReviewRepository(db *sql.DB) *SQLiteInjectionReviewRepository {
+	return &SQLiteInjectionReviewRepository{db: db}
+}
+
+// GetInjectionReview returns the review of a job's description, or nil when
+// the description has not been flagged
+func (r *SQLiteInjectionReviewRepository) GetInjectionReview(ctx context.Context, userID, jobID int) (*models.InjectionReview, error) {
+	query := `
+		SELECT job_id, score, spans, approved, flagged_at, reviewed_at
+		FROM job_injection_reviews
+		WHERE job_id = ? AND user_id = ?
+	`
+
+	var review models.InjectionReview
+	var spansJSON string
+	var flaggedAt, reviewedAt sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, jobID, userID).Scan(
+		&review.JobID,
+		&review.Score,
+		&spansJSON,
+		&review.Approved,
+		&flaggedAt,
+		&reviewedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, models.WrapError(models.ErrFailedToGetJob, err)
+	}
+
+	if err := json.Unmarshal([]byte(spansJSON), &review.Spans); err != nil {
+		review.Spans = []models.InjectionSpan{}
+	}
+	if flaggedAt.Valid {
+		review.FlaggedAt = flaggedAt.Time
+	}
+	if reviewedAt.Valid {
+		reviewed := reviewedAt.Time
+		review.ReviewedAt = &reviewed
+	}
+
+	return &review, nil
+}
+
+// SaveInjectionReview stores the review of a job's description, replacing
+// any earlier one
+func (r *SQLiteInjectionReviewRepository) SaveInjectionReview(ctx context.Context, userID int, review *models.InjectionReview) error {
+	spansJSON, err := json.Marshal(review.Spans)
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSaveReview, err)
+	}
+
+	query := `
+		INSERT INTO job_injection_reviews (job_id, user_id, score, spans, approved, flagged_at, reviewed_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(job_id) DO UPDATE SET
+			score = excluded.score,
+			spans = excluded.spans,
+			approved = excluded.approved,
+			flagged_at = excluded.flagged_at,
+			reviewed_at = excluded.reviewed_at
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		review.JobID,
+		userID,
+		review.Score,
+		string(spansJSON),
+		review.Approved,
+		review.FlaggedAt,
+		review.ReviewedAt,
+	)
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSaveReview, err)
+	}
+	return nil
+}
+
+// ApproveInjectionReview records that the user has reviewed a flagged job
+// description and allows AI features to run on it
+func (r *SQLiteInjectionReviewRepository) ApproveInjectionReview(ctx context.Context, userID, jobID int) error {
+	query := `
+		UPDATE job_injection_reviews
+		SET approved = 1, reviewed_at = ?
+		WHERE job_id = ? AND user_id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now().UTC(), jobID, userID)
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSaveReview, err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSaveReview, err)
+	}
+	if rows == 0 {
+		return models.ErrNoReviewPending
+	}
+	return nil
+}
+
+// DeleteInjectionReview removes the review of a job's description
+func (r *SQLiteInjectionReviewRepository) DeleteInjectionReview(ctx context.Context, userID, jobID int) error {
+	query := `DELETE FROM job_injection_reviews WHERE job_id = ? AND user_id = ?`
+
+	if _, err := r.db.ExecContext(ctx, query, jobID, userID); err != nil {
+		return models.WrapError(models.ErrFailedToSaveReview, err)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/benidevo/vega/internal/job/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteInjectionReviewRepository_GetInjectionReview(t *testing.T) {
+	t.Run("returns the stored review", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+		repo := NewSQLiteInjectionReviewRepository(db)
+
+		flaggedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+		rows := sqlmock.NewRows([]string{"job_id", "score", "spans", "approved", "flagged_at", "reviewed_at"}).
+			AddRow(7, 0.8, `[{"start":0,"end":6,"rule":"ignore_instructions","text":"Ignore"}]`, false, flaggedAt, nil)
+		mock.ExpectQuery("SELECT job_id, score, spans, approved").
+			WithArgs(7, testUserID).
+			WillReturnRows(rows)
+
+		review, err := repo.GetInjectionReview(context.Background(), testUserID, 7)
+
+		require.NoError(t, err)
+		require.NotNil(t, review)
+		assert.Equal(t, 7, review.JobID)
+		assert.Equal(t, 0.8, review.Score)
+		require.Len(t, review.Spans, 1)
+		assert.Equal(t, "ignore_instructions", review.Spans[0].Rule)
+		assert.Equal(t, flaggedAt, review.FlaggedAt)
+		assert.Nil(t, review.ReviewedAt)
+		assert.True(t, review.BlocksAI())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("returns nil when the job is not flagged", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+		repo := NewSQLiteInjectionReviewRepository(db)
+
+		mock.ExpectQuery("SELECT job_id").WithArgs(7, testUserID).WillReturnError(sql.ErrNoRows)
+
+		review, err := repo.GetInjectionReview(context.Background(), testUserID, 7)
+
+		require.NoError(t, err)
+		assert.Nil(t, review)
+	})
+
+	t.Run("wraps query errors", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+		repo := NewSQLiteInjectionReviewRepository(db)
+
+		mock.ExpectQuery("SELECT job_id").WithArgs(7, testUserID).WillReturnError(errors.New("db down"))
+
+		_, err := repo.GetInjectionReview(context.Background(), testUserID, 7)
+
+		assert.ErrorIs(t, err, models.ErrFailedToGetJob)
+	})
+}
+
+func TestSQLiteInjectionReviewRepository_SaveInjectionReview(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+	repo := NewSQLiteInjectionReviewRepository(db)
+
+	review := &models.InjectionReview{
+		JobID:     7,
+		Score:     0.6,
+		Spans:     []models.InjectionSpan{{Start: 0, End: 7, Rule: "role_marker", Text: "System:"}},
+		FlaggedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	mock.ExpectExec("INSERT INTO job_injection_reviews").
+		WithArgs(7, testUserID, 0.6, `[{"start":0,"end":7,"rule":"role_marker","text":"System:"}]`, false, review.FlaggedAt, review.ReviewedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := repo.SaveInjectionReview(context.Background(), testUserID, review)
+
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSQLiteInjectionReviewRepository_ApproveInjectionReview(t *testing.T) {
+	t.Run("approves a flagged job", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+		repo := NewSQLiteInjectionReviewRepository(db)
+
+		mock.ExpectExec("UPDATE job_injection_reviews").
+			WithArgs(sqlmock.AnyArg(), 7, testUserID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.ApproveInjectionReview(context.Background(), testUserID, 7)
+
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fails when the job is not flagged", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+		repo := NewSQLiteInjectionReviewRepository(db)
+
+		mock.ExpectExec("UPDATE job_injection_reviews").
+			WithArgs(sqlmock.AnyArg(), 7, testUserID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.ApproveInjectionReview(context.Background(), testUserID, 7)
+
+		assert.ErrorIs(t, err, models.ErrNoReviewPending)
+	})
+}
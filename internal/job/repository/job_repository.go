@@ -20,6 +20,21 @@ type scanner interface {
 	Scan(dest ...any) error
 }
 
+// snippetScanner scans the search snippet selected after the job columns
+type snippetScanner struct {
+	scanner
+	snippet *string
+}
+
+func (s snippetScanner) Scan(dest ...any) error {
+	return s.scanner.Scan(append(dest, s.snippet)...)
+}
+
+// searchRank orders search results by relevance. Matches in the title count
+// the most, then the company, skills and location, then notes and the
+// description.
+const searchRank = "bm25(jobs_fts, 10.0, 1.0, 2.0, 4.0, 6.0, 5.0)"
+
 // SQLiteJobRepository is a SQLite implementation of JobRepository
 type SQLiteJobRepository struct {
 	db                *sql.DB
@@ -180,11 +195,18 @@ func (r *SQLiteJobRepository) Create(ctx context.Context, userID int, jobModel *
 		INSERT INTO jobs (
 			title, description, location, job_type, source_url,
 			required_skills, application_url,
-			company_id, status, notes,
+			company_id, status, stage_id, notes,
 			created_at, updated_at, user_id
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
+	// Without a stage the database puts the job in the first stage of its
+	// status
+	var stageID any
+	if jobModel.Stage != nil && jobModel.Stage.ID > 0 {
+		stageID = jobModel.Stage.ID
+	}
+
 	result, err := r.db.ExecContext(
 		ctx,
 		query,
@@ -197,6 +219,7 @@ func (r *SQLiteJobRepository) Create(ctx context.Context, userID int, jobModel *
 		jobModel.ApplicationURL,
 		company.ID,
 		int(jobModel.Status),
+		stageID,
 		jobModel.Notes,
 		jobModel.CreatedAt,
 		jobModel.UpdatedAt,
@@ -271,13 +294,29 @@ func (r *SQLiteJobRepository) GetAll(ctx context.Context, userID int, filter mod
 			j.application_url, j.company_id, j.status, j.match_score,
 			j.notes, j.created_at, j.updated_at, j.user_id, j.first_analyzed_at,
 			c.name, c.created_at, c.updated_at
-		FROM jobs j
-		JOIN companies c ON j.company_id = c.id
 	`
 
 	var conditions []string
 	var args []any
 
+	search := models.SearchExpression(filter.Query)
+	if search != "" {
+		query += `,
+			snippet(jobs_fts, -1, ?, ?, '…', 16)
+		FROM jobs j
+		JOIN companies c ON j.company_id = c.id
+		JOIN jobs_fts ON jobs_fts.rowid = j.id
+		`
+		args = append(args, models.SnippetMatchStart, models.SnippetMatchEnd)
+		conditions = append(conditions, "jobs_fts MATCH ?")
+		args = append(args, search)
+	} else {
+		query += `
+		FROM jobs j
+		JOIN companies c ON j.company_id = c.id
+		`
+	}
+
 	conditions = append(conditions, "j.user_id = ?")
 	args = append(args, userID)
 
@@ -291,11 +330,21 @@ func (r *SQLiteJobRepository) GetAll(ctx context.Context, userID int, filter mod
 		args = append(args, int(*filter.Status))
 	}
 
+	if filter.StageID != nil {
+		conditions = append(conditions, "j.stage_id = ?")
+		args = append(args, *filter.StageID)
+	}
+
 	if filter.JobType != nil {
 		conditions = append(conditions, "j.job_type = ?")
 		args = append(args, int(*filter.JobType))
 	}
 
+	if condition, tagArgs := tagCondition(userID, filter); condition != "" {
+		conditions = append(conditions, condition)
+		args = append(args, tagArgs...)
+	}
+
 	if filter.Matched != nil {
 		if *filter.Matched {
 			conditions = append(conditions, "j.match_score >= 70")
@@ -313,12 +362,16 @@ func (r *SQLiteJobRepository) GetAll(ctx context.Context, userID int, filter mod
 		"match_score": true,
 		"created_at":  true,
 		"updated_at":  true,
+		"relevance":   true,
 		"":            true, // Allow empty for default
 	}
 
 	if !validSortFields[filter.SortBy] {
 		filter.SortBy = "" // Default to updated_at
 	}
+	if filter.SortBy == "relevance" && search == "" {
+		filter.SortBy = ""
+	}
 
 	if filter.SortOrder != "asc" && filter.SortOrder != "desc" && filter.SortOrder != "" {
 		filter.SortOrder = "desc"
@@ -345,6 +398,9 @@ func (r *SQLiteJobRepository) GetAll(ctx context.Context, userID int, filter mod
 		} else {
 			orderBy += "j.updated_at DESC"
 		}
+	case "relevance":
+		// Lower bm25 scores are better matches
+		orderBy += searchRank + ", j.updated_at DESC"
 	default:
 		orderBy += "j.updated_at DESC"
 	}
@@ -369,7 +425,16 @@ func (r *SQLiteJobRepository) GetAll(ctx context.Context, userID int, filter mod
 	var jobs []*models.Job
 
 	for rows.Next() {
-		job, err := r.scanJob(rows)
+		var job *models.Job
+		if search != "" {
+			var snippet string
+			job, err = r.scanJob(snippetScanner{scanner: rows, snippet: &snippet})
+			if err == nil {
+				job.SearchSnippet = models.ParseSnippet(snippet)
+			}
+		} else {
+			job, err = r.scanJob(rows)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -553,6 +618,35 @@ func (r *SQLiteJobRepository) UpdateStatus(ctx context.Context, userID int, id i
 	return nil
 }
 
+// tagCondition returns the condition restricting jobs to the tags in the
+// filter: jobs with any of the tags, or with all of them for TagMatchAll.
+// Tag names are compared ignoring case.
+func tagCondition(userID int, filter models.JobFilter) (string, []any) {
+	if len(filter.Tags) == 0 {
+		return "", nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(filter.Tags)), ",")
+	condition := fmt.Sprintf(`j.id IN (
+		SELECT jt.job_id
+		FROM job_tags jt
+		JOIN tags t ON t.id = jt.tag_id
+		WHERE t.user_id = ? AND t.name IN (%s)`, placeholders)
+
+	args := []any{userID}
+	for _, tag := range filter.Tags {
+		args = append(args, tag)
+	}
+
+	if filter.TagMatch == models.TagMatchAll {
+		condition += `
+		GROUP BY jt.job_id
+		HAVING COUNT(*) = ?`
+		args = append(args, len(filter.Tags))
+	}
+	return condition + ")", args
+}
+
 func (r *SQLiteJobRepository) GetCount(ctx context.Context, userID int, filter models.JobFilter) (int, error) {
 	query := `
 		SELECT COUNT(*)
@@ -563,6 +657,12 @@ func (r *SQLiteJobRepository) GetCount(ctx context.Context, userID int, filter m
 	var conditions []string
 	var args []any
 
+	if search := models.SearchExpression(filter.Query); search != "" {
+		query += " JOIN jobs_fts ON jobs_fts.rowid = j.id"
+		conditions = append(conditions, "jobs_fts MATCH ?")
+		args = append(args, search)
+	}
+
 	conditions = append(conditions, "j.user_id = ?")
 	args = append(args, userID)
 
@@ -576,11 +676,21 @@ func (r *SQLiteJobRepository) GetCount(ctx context.Context, userID int, filter m
 		args = append(args, int(*filter.Status))
 	}
 
+	if filter.StageID != nil {
+		conditions = append(conditions, "j.stage_id = ?")
+		args = append(args, *filter.StageID)
+	}
+
 	if filter.JobType != nil {
 		conditions = append(conditions, "j.job_type = ?")
 		args = append(args, int(*filter.JobType))
 	}
 
+	if condition, tagArgs := tagCondition(userID, filter); condition != "" {
+		conditions = append(conditions, condition)
+		args = append(args, tagArgs...)
+	}
+
 	if len(conditions) > 0 {
 		query += " WHERE " + strings.Join(conditions, " AND ")
 	}
@@ -755,8 +865,8 @@ func (r *SQLiteJobRepository) CreateMatchResult(ctx context.Context, userID int,
 	}
 
 	query := `
-		INSERT INTO match_results (job_id, match_score, strengths, weaknesses, highlights, feedback, user_id)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO match_results (job_id, match_score, strengths, weaknesses, highlights, feedback, prompt_version, user_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	result, err := r.db.ExecContext(
@@ -768,6 +878,7 @@ func (r *SQLiteJobRepository) CreateMatchResult(ctx context.Context, userID int,
 		string(weaknessesJSON),
 		string(highlightsJSON),
 		matchResult.Feedback,
+		matchResult.PromptVersion,
 		userID,
 	)
 	if err != nil {
@@ -797,7 +908,7 @@ func (r *SQLiteJobRepository) GetJobMatchHistory(ctx context.Context, userID int
 	}
 
 	query := `
-		SELECT id, job_id, match_score, strengths, weaknesses, highlights, feedback, created_at
+		SELECT id, job_id, match_score, strengths, weaknesses, highlights, feedback, prompt_version, created_at
 		FROM match_results
 		WHERE job_id = ? AND user_id = ?
 		ORDER BY created_at DESC
@@ -822,6 +933,7 @@ func (r *SQLiteJobRepository) GetJobMatchHistory(ctx context.Context, userID int
 			&weaknessesJSON,
 			&highlightsJSON,
 			&mr.Feedback,
+			&mr.PromptVersion,
 			&mr.CreatedAt,
 		)
 		if err != nil {
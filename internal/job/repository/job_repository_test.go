@@ -134,7 +134,7 @@ func TestSQLiteJobRepository_Create(t *testing.T) {
 					WithArgs(
 						j.Title, j.Description, j.Location, int(j.JobType),
 						j.SourceURL, skillsJSON, j.ApplicationURL, 1,
-						int(j.Status), j.Notes,
+						int(j.Status), nil, j.Notes,
 						sqlmock.AnyArg(), sqlmock.AnyArg(), testUserID,
 					).
 					WillReturnResult(sqlmock.NewResult(1, 1))
@@ -509,6 +509,128 @@ func TestSQLiteJobRepository_GetAll(t *testing.T) {
 
 		require.NoError(t, err)
 	})
+
+	t.Run("search ranks by relevance with snippets", func(t *testing.T) {
+		now := time.Now()
+
+		rows := sqlmock.NewRows([]string{
+			"j.id", "j.title", "j.description", "j.location", "j.job_type",
+			"j.source_url", "j.required_skills",
+			"j.application_url", "j.company_id", "j.status", "j.match_score",
+			"j.notes", "j.created_at", "j.updated_at", "j.user_id", "j.first_analyzed_at",
+			"c.name", "c.created_at", "c.updated_at", "snippet",
+		}).AddRow(
+			1, "Rust Engineer", "Systems work in Berlin", "Berlin", int(models.FULL_TIME),
+			"https://example.com", `["Rust"]`,
+			"", 1, int(models.INTERESTED), nil,
+			"", now, now, testUserID, nil,
+			"Ferris GmbH", now, now, "Systems work in "+models.SnippetMatchStart+"Berlin"+models.SnippetMatchEnd,
+		)
+
+		mock.ExpectQuery(`SELECT.*snippet\(jobs_fts.*JOIN jobs_fts ON jobs_fts.rowid = j.id.*WHERE jobs_fts MATCH \? AND j.user_id = \?.*ORDER BY bm25\(jobs_fts`).
+			WithArgs(models.SnippetMatchStart, models.SnippetMatchEnd, `"Rust"* OR "job"* OR "Berlin"*`, testUserID).
+			WillReturnRows(rows)
+
+		filter := models.JobFilter{
+			Query:  "that Rust job in Berlin",
+			SortBy: "relevance",
+		}
+		jobs, err := repo.GetAll(context.Background(), testUserID, filter)
+
+		require.NoError(t, err)
+		require.Len(t, jobs, 1)
+		assert.Equal(t, []models.SnippetSegment{
+			{Text: "Systems work in "},
+			{Text: "Berlin", Match: true},
+		}, jobs[0].SearchSnippet)
+	})
+
+	t.Run("relevance without a search uses default", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{
+			"j.id", "j.title", "j.description", "j.location", "j.job_type",
+			"j.source_url", "j.required_skills",
+			"j.application_url", "j.company_id", "j.status", "j.match_score",
+			"j.notes", "j.created_at", "j.updated_at", "j.user_id", "j.first_analyzed_at",
+			"c.name", "c.created_at", "c.updated_at",
+		})
+
+		mock.ExpectQuery("SELECT.*FROM jobs.*ORDER BY j.updated_at DESC").
+			WithArgs(testUserID).
+			WillReturnRows(rows)
+
+		_, err := repo.GetAll(context.Background(), testUserID, models.JobFilter{SortBy: "relevance"})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("filter by any of the tags", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{
+			"j.id", "j.title", "j.description", "j.location", "j.job_type",
+			"j.source_url", "j.required_skills",
+			"j.application_url", "j.company_id", "j.status", "j.match_score",
+			"j.notes", "j.created_at", "j.updated_at", "j.user_id", "j.first_analyzed_at",
+			"c.name", "c.created_at", "c.updated_at",
+		})
+
+		mock.ExpectQuery(`WHERE j.user_id = \? AND j.id IN \(\s*SELECT jt.job_id\s*FROM job_tags jt\s*JOIN tags t ON t.id = jt.tag_id\s*WHERE t.user_id = \? AND t.name IN \(\?,\?\)\) ORDER BY`).
+			WithArgs(testUserID, testUserID, "referral", "remote").
+			WillReturnRows(rows)
+
+		filter := models.JobFilter{Tags: []string{"referral", "remote"}, TagMatch: models.TagMatchAny}
+		_, err := repo.GetAll(context.Background(), testUserID, filter)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("filter by all of the tags", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{
+			"j.id", "j.title", "j.description", "j.location", "j.job_type",
+			"j.source_url", "j.required_skills",
+			"j.application_url", "j.company_id", "j.status", "j.match_score",
+			"j.notes", "j.created_at", "j.updated_at", "j.user_id", "j.first_analyzed_at",
+			"c.name", "c.created_at", "c.updated_at",
+		})
+
+		mock.ExpectQuery(`t.name IN \(\?,\?\)\s*GROUP BY jt.job_id\s*HAVING COUNT\(\*\) = \?\) ORDER BY`).
+			WithArgs(testUserID, testUserID, "referral", "remote", 2).
+			WillReturnRows(rows)
+
+		filter := models.JobFilter{Tags: []string{"referral", "remote"}, TagMatch: models.TagMatchAll}
+		_, err := repo.GetAll(context.Background(), testUserID, filter)
+
+		require.NoError(t, err)
+	})
+}
+
+func TestSQLiteJobRepository_GetCountWithSearch(t *testing.T) {
+	repo, mock, _ := setupJobRepositoryTest(t)
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\).*JOIN jobs_fts ON jobs_fts.rowid = j.id WHERE jobs_fts MATCH \? AND j.user_id = \?`).
+		WithArgs(`"tokio"*`, testUserID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	count, err := repo.GetCount(context.Background(), testUserID, models.JobFilter{Query: "tokio"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSQLiteJobRepository_GetCountWithTags(t *testing.T) {
+	repo, mock, _ := setupJobRepositoryTest(t)
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\).*WHERE j.user_id = \? AND j.id IN \(.*HAVING COUNT\(\*\) = \?\)`).
+		WithArgs(testUserID, testUserID, "referral", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	count, err := repo.GetCount(context.Background(), testUserID, models.JobFilter{
+		Tags:     []string{"referral"},
+		TagMatch: models.TagMatchAll,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestGetStatsByUserID(t *testing.T) {
@@ -810,12 +932,13 @@ func TestSQLiteJobRepository_CreateMatchResult(t *testing.T) {
 		{
 			name: "successful creation",
 			matchResult: &models.MatchResult{
-				JobID:      1,
-				MatchScore: 85,
-				Strengths:  []string{"Strong technical skills", "Relevant experience"},
-				Weaknesses: []string{"Limited industry experience"},
-				Highlights: []string{"Led similar project"},
-				Feedback:   "Great match overall",
+				JobID:         1,
+				MatchScore:    85,
+				Strengths:     []string{"Strong technical skills", "Relevant experience"},
+				Weaknesses:    []string{"Limited industry experience"},
+				Highlights:    []string{"Led similar project"},
+				Feedback:      "Great match overall",
+				PromptVersion: "builtin",
 			},
 			setupMock: func(mock sqlmock.Sqlmock) {
 				strengthsJSON, _ := json.Marshal([]string{"Strong technical skills", "Relevant experience"})
@@ -823,7 +946,7 @@ func TestSQLiteJobRepository_CreateMatchResult(t *testing.T) {
 				highlightsJSON, _ := json.Marshal([]string{"Led similar project"})
 
 				mock.ExpectExec("INSERT INTO match_results").
-					WithArgs(1, 85, string(strengthsJSON), string(weaknessesJSON), string(highlightsJSON), "Great match overall", testUserID).
+					WithArgs(1, 85, string(strengthsJSON), string(weaknessesJSON), string(highlightsJSON), "Great match overall", "builtin", testUserID).
 					WillReturnResult(sqlmock.NewResult(123, 1))
 			},
 			wantErr: false,
@@ -897,9 +1020,9 @@ func TestSQLiteJobRepository_GetJobMatchHistory(t *testing.T) {
 				weaknesses2, _ := json.Marshal([]string{})
 				highlights2, _ := json.Marshal([]string{"Perfect fit"})
 
-				rows := sqlmock.NewRows([]string{"id", "job_id", "match_score", "strengths", "weaknesses", "highlights", "feedback", "created_at"}).
-					AddRow(2, 1, 90, string(strengths2), string(weaknesses2), string(highlights2), "Latest analysis", time.Now()).
-					AddRow(1, 1, 75, string(strengths1), string(weaknesses1), string(highlights1), "First analysis", time.Now().Add(-24*time.Hour))
+				rows := sqlmock.NewRows([]string{"id", "job_id", "match_score", "strengths", "weaknesses", "highlights", "feedback", "prompt_version", "created_at"}).
+					AddRow(2, 1, 90, string(strengths2), string(weaknesses2), string(highlights2), "Latest analysis", "strict", time.Now()).
+					AddRow(1, 1, 75, string(strengths1), string(weaknesses1), string(highlights1), "First analysis", "", time.Now().Add(-24*time.Hour))
 
 				mock.ExpectQuery("SELECT .* FROM match_results WHERE job_id = \\? AND user_id = \\? ORDER BY created_at DESC").
 					WithArgs(1, testUserID).
@@ -907,13 +1030,14 @@ func TestSQLiteJobRepository_GetJobMatchHistory(t *testing.T) {
 			},
 			want: []*models.MatchResult{
 				{
-					ID:         2,
-					JobID:      1,
-					MatchScore: 90,
-					Strengths:  []string{"Excellent match"},
-					Weaknesses: []string{},
-					Highlights: []string{"Perfect fit"},
-					Feedback:   "Latest analysis",
+					ID:            2,
+					JobID:         1,
+					MatchScore:    90,
+					Strengths:     []string{"Excellent match"},
+					Weaknesses:    []string{},
+					Highlights:    []string{"Perfect fit"},
+					Feedback:      "Latest analysis",
+					PromptVersion: "strict",
 				},
 				{
 					ID:         1,
@@ -931,7 +1055,7 @@ func TestSQLiteJobRepository_GetJobMatchHistory(t *testing.T) {
 			name:  "no results found",
 			jobID: 999,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "job_id", "match_score", "strengths", "weaknesses", "highlights", "feedback", "created_at"})
+				rows := sqlmock.NewRows([]string{"id", "job_id", "match_score", "strengths", "weaknesses", "highlights", "feedback", "prompt_version", "created_at"})
 				mock.ExpectQuery("SELECT .* FROM match_results WHERE job_id = \\? AND user_id = \\? ORDER BY created_at DESC").
 					WithArgs(999, testUserID).
 					WillReturnRows(rows)
@@ -974,6 +1098,7 @@ func TestSQLiteJobRepository_GetJobMatchHistory(t *testing.T) {
 					assert.Equal(t, tt.want[i].Weaknesses, result.Weaknesses)
 					assert.Equal(t, tt.want[i].Highlights, result.Highlights)
 					assert.Equal(t, tt.want[i].Feedback, result.Feedback)
+					assert.Equal(t, tt.want[i].PromptVersion, result.PromptVersion)
 				}
 			}
 
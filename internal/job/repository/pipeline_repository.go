@@ -0,0 +1,360 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/benidevo/vega/internal/cache"
+	"github.com/benidevo/vega/internal/job/models"
+)
+
+// SQLitePipelineRepository is a SQLite implementation of PipelineRepository
+type SQLitePipelineRepository struct {
+	db    *sql.DB
+	cache cache.Cache
+}
+
+// NewSQLitePipelineRepository creates a new pipeline repository
+func NewSQLitePipelineRepository(db *sql.DB, cache cache.Cache) *SQLitePipelineRepository {
+	return &SQLitePipelineRepository{db: db, cache: cache}
+}
+
+// GetStages returns the user's pipeline stages in order, with the number of
+// jobs in each
+func (r *SQLitePipelineRepository) GetStages(ctx context.Context, userID int) ([]*models.PipelineStage, error) {
+	query := `
+		SELECT s.id, s.name, s.position, s.category, COUNT(j.id)
+		FROM pipeline_stages s
+		LEFT JOIN jobs j ON j.stage_id = s.id AND j.user_id = s.user_id
+		WHERE s.user_id = ?
+		GROUP BY s.id, s.name, s.position, s.category
+		ORDER BY s.position, s.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, models.WrapError(models.ErrFailedToGetPipeline, err)
+	}
+	defer rows.Close()
+
+	var stages []*models.PipelineStage
+	for rows.Next() {
+		var stage models.PipelineStage
+		if err := rows.Scan(&stage.ID, &stage.Name, &stage.Position, &stage.Category, &stage.JobCount); err != nil {
+			return nil, models.WrapError(models.ErrFailedToGetPipeline, err)
+		}
+		stages = append(stages, &stage)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, models.WrapError(models.ErrFailedToGetPipeline, err)
+	}
+	return stages, nil
+}
+
+// GetJobStages returns the stage IDs of the given jobs, keyed by job ID. Jobs
+// without a stage are left out.
+func (r *SQLitePipelineRepository) GetJobStages(ctx context.Context, userID int, jobIDs []int) (map[int]int, error) {
+	stages := make(map[int]int)
+	if len(jobIDs) == 0 {
+		return stages, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(jobIDs)), ",")
+	query := fmt.Sprintf(`
+		SELECT id, stage_id
+		FROM jobs
+		WHERE user_id = ? AND stage_id IS NOT NULL AND id IN (%s)
+	`, placeholders)
+
+	args := []any{userID}
+	for _, id := range jobIDs {
+		args = append(args, id)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, models.WrapError(models.ErrFailedToGetPipeline, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var jobID, stageID int
+		if err := rows.Scan(&jobID, &stageID); err != nil {
+			return nil, models.WrapError(models.ErrFailedToGetPipeline, err)
+		}
+		stages[jobID] = stageID
+	}
+	if err := rows.Err(); err != nil {
+		return nil, models.WrapError(models.ErrFailedToGetPipeline, err)
+	}
+	return stages, nil
+}
+
+// SetJobStage moves a job to one of the user's stages and sets its status to
+// the stage's category
+func (r *SQLitePipelineRepository) SetJobStage(ctx context.Context, userID, jobID, stageID int) error {
+	if jobID <= 0 {
+		return models.ErrInvalidJobID
+	}
+
+	var category int
+	err := r.db.QueryRowContext(ctx,
+		"SELECT category FROM pipeline_stages WHERE id = ? AND user_id = ?",
+		stageID, userID,
+	).Scan(&category)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.ErrStageNotFound
+	}
+	if err != nil {
+		return models.WrapError(models.ErrFailedToUpdateJob, err)
+	}
+
+	query := "UPDATE jobs SET stage_id = ?, status = ?, updated_at = ? WHERE id = ? AND user_id = ?"
+	result, err := r.db.ExecContext(ctx, query, stageID, category, time.Now().UTC(), jobID, userID)
+	if err != nil {
+		return models.WrapError(models.ErrFailedToUpdateJob, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return models.WrapError(models.ErrFailedToUpdateJob, err)
+	}
+	if rowsAffected == 0 {
+		return models.ErrJobNotFound
+	}
+
+	_ = r.cache.Delete(ctx,
+		fmt.Sprintf("stats:u%d:summary", userID),
+		fmt.Sprintf("stats:u%d:by-status", userID),
+		fmt.Sprintf("job:u%d:id%d", userID, jobID),
+	)
+	return nil
+}
+
+// CreateStage adds a stage to the end of the user's pipeline and sets its ID
+// and position
+func (r *SQLitePipelineRepository) CreateStage(ctx context.Context, userID int, stage *models.PipelineStage) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSavePipeline, err)
+	}
+	defer tx.Rollback()
+
+	var count, position int
+	err = tx.QueryRowContext(ctx,
+		"SELECT COUNT(*), COALESCE(MAX(position), 0) FROM pipeline_stages WHERE user_id = ?",
+		userID,
+	).Scan(&count, &position)
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSavePipeline, err)
+	}
+	if count >= models.MaxPipelineStages {
+		return models.ErrTooManyStages
+	}
+
+	result, err := tx.ExecContext(ctx,
+		"INSERT INTO pipeline_stages (user_id, name, position, category) VALUES (?, ?, ?, ?)",
+		userID, stage.Name, position+1, int(stage.Category),
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return models.ErrDuplicateStage
+		}
+		return models.WrapError(models.ErrFailedToSavePipeline, err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSavePipeline, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.WrapError(models.ErrFailedToSavePipeline, err)
+	}
+
+	stage.ID = int(id)
+	stage.Position = position + 1
+	return nil
+}
+
+// UpdateStage renames a stage and sets its category. The category of a stage
+// that holds jobs can't be changed, as the jobs' status history records
+// changes of stage and would miss the change of status.
+func (r *SQLitePipelineRepository) UpdateStage(ctx context.Context, userID int, stage *models.PipelineStage) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSavePipeline, err)
+	}
+	defer tx.Rollback()
+
+	var category, jobCount int
+	err = tx.QueryRowContext(ctx, `
+		SELECT s.category, (SELECT COUNT(*) FROM jobs j WHERE j.stage_id = s.id AND j.user_id = s.user_id)
+		FROM pipeline_stages s
+		WHERE s.id = ? AND s.user_id = ?
+	`, stage.ID, userID).Scan(&category, &jobCount)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.ErrStageNotFound
+	}
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSavePipeline, err)
+	}
+	if category != int(stage.Category) && jobCount > 0 {
+		return models.ErrStageHasJobs
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE pipeline_stages SET name = ?, category = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, stage.Name, int(stage.Category), stage.ID, userID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return models.ErrDuplicateStage
+		}
+		return models.WrapError(models.ErrFailedToSavePipeline, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSavePipeline, err)
+	}
+	if rowsAffected == 0 {
+		return models.ErrStageNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.WrapError(models.ErrFailedToSavePipeline, err)
+	}
+
+	r.invalidateJobs(ctx, userID)
+	return nil
+}
+
+// SetStageOrder orders the user's pipeline as given. The IDs must be those of
+// all the user's stages.
+func (r *SQLitePipelineRepository) SetStageOrder(ctx context.Context, userID int, stageIDs []int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSavePipeline, err)
+	}
+	defer tx.Rollback()
+
+	var count int
+	err = tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM pipeline_stages WHERE user_id = ?", userID).Scan(&count)
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSavePipeline, err)
+	}
+	if count != len(stageIDs) {
+		return models.ErrInvalidStageOrder
+	}
+
+	seen := make(map[int]bool, len(stageIDs))
+	for i, id := range stageIDs {
+		if seen[id] {
+			return models.ErrInvalidStageOrder
+		}
+		seen[id] = true
+
+		result, err := tx.ExecContext(ctx,
+			"UPDATE pipeline_stages SET position = ? WHERE id = ? AND user_id = ?",
+			i+1, id, userID,
+		)
+		if err != nil {
+			return models.WrapError(models.ErrFailedToSavePipeline, err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return models.WrapError(models.ErrFailedToSavePipeline, err)
+		}
+		if rowsAffected == 0 {
+			return models.ErrInvalidStageOrder
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.WrapError(models.ErrFailedToSavePipeline, err)
+	}
+	return nil
+}
+
+// DeleteStage removes a stage from the user's pipeline, moving its jobs to the
+// replacement stage first. The last stage can't be removed.
+func (r *SQLitePipelineRepository) DeleteStage(ctx context.Context, userID, stageID, replacementID int) error {
+	if stageID == replacementID {
+		return models.ErrStageNotFound
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSavePipeline, err)
+	}
+	defer tx.Rollback()
+
+	var count int
+	err = tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM pipeline_stages WHERE user_id = ?", userID).Scan(&count)
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSavePipeline, err)
+	}
+	if count <= 1 {
+		return models.ErrLastStage
+	}
+
+	var category int
+	err = tx.QueryRowContext(ctx,
+		"SELECT category FROM pipeline_stages WHERE id = ? AND user_id = ?",
+		replacementID, userID,
+	).Scan(&category)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.ErrStageNotFound
+	}
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSavePipeline, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE jobs SET stage_id = ?, status = ?, updated_at = ?
+		WHERE stage_id = ? AND user_id = ?
+	`, replacementID, category, time.Now().UTC(), stageID, userID)
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSavePipeline, err)
+	}
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM pipeline_stages WHERE id = ? AND user_id = ?", stageID, userID)
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSavePipeline, err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSavePipeline, err)
+	}
+	if rowsAffected == 0 {
+		return models.ErrStageNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.WrapError(models.ErrFailedToSavePipeline, err)
+	}
+
+	r.invalidateJobs(ctx, userID)
+	return nil
+}
+
+// invalidateJobs drops the user's cached jobs and stats after a change that
+// may have moved many jobs
+func (r *SQLitePipelineRepository) invalidateJobs(ctx context.Context, userID int) {
+	_ = r.cache.DeletePattern(ctx, fmt.Sprintf("job:u%d:*", userID))
+	_ = r.cache.Delete(ctx,
+		fmt.Sprintf("stats:u%d:summary", userID),
+		fmt.Sprintf("stats:u%d:by-status", userID),
+	)
+}
+
+// isUniqueViolation reports whether an error is SQLite refusing a duplicate
+// value for a unique column
+func isUniqueViolation(err error) bool {
+	return strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
@@ -0,0 +1,249 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/benidevo/vega/internal/cache"
+	"github.com/benidevo/vega/internal/job/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLitePipelineRepository_GetStages(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+	repo := NewSQLitePipelineRepository(db, cache.NewNoOpCache())
+
+	mock.ExpectQuery(`SELECT s.id, s.name, s.position, s.category, COUNT\(j.id\)\s*FROM pipeline_stages s`).
+		WithArgs(testUserID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "position", "category", "count"}).
+			AddRow(1, "Interested", 1, 0, 4).
+			AddRow(7, "Phone Screen", 2, 2, 1))
+
+	stages, err := repo.GetStages(context.Background(), testUserID)
+
+	require.NoError(t, err)
+	assert.Equal(t, []*models.PipelineStage{
+		{ID: 1, Name: "Interested", Position: 1, Category: models.INTERESTED, JobCount: 4},
+		{ID: 7, Name: "Phone Screen", Position: 2, Category: models.INTERVIEWING, JobCount: 1},
+	}, stages)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSQLitePipelineRepository_SetJobStage(t *testing.T) {
+	t.Run("moves the job and sets its status to the stage's category", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+		repo := NewSQLitePipelineRepository(db, cache.NewNoOpCache())
+
+		mock.ExpectQuery("SELECT category FROM pipeline_stages WHERE id = \\? AND user_id = \\?").
+			WithArgs(7, testUserID).
+			WillReturnRows(sqlmock.NewRows([]string{"category"}).AddRow(int(models.INTERVIEWING)))
+		mock.ExpectExec("UPDATE jobs SET stage_id = \\?, status = \\?, updated_at = \\? WHERE id = \\? AND user_id = \\?").
+			WithArgs(7, int(models.INTERVIEWING), sqlmock.AnyArg(), 3, testUserID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.SetJobStage(context.Background(), testUserID, 3, 7)
+
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rejects another user's stage", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+		repo := NewSQLitePipelineRepository(db, cache.NewNoOpCache())
+
+		mock.ExpectQuery("SELECT category FROM pipeline_stages").
+			WithArgs(7, testUserID).
+			WillReturnRows(sqlmock.NewRows([]string{"category"}))
+
+		err := repo.SetJobStage(context.Background(), testUserID, 3, 7)
+
+		assert.ErrorIs(t, err, models.ErrStageNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSQLitePipelineRepository_CreateStage(t *testing.T) {
+	t.Run("appends the stage", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+		repo := NewSQLitePipelineRepository(db, cache.NewNoOpCache())
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\), COALESCE\\(MAX\\(position\\), 0\\) FROM pipeline_stages").
+			WithArgs(testUserID).
+			WillReturnRows(sqlmock.NewRows([]string{"count", "max"}).AddRow(6, 6))
+		mock.ExpectExec("INSERT INTO pipeline_stages").
+			WithArgs(testUserID, "Take-home", 7, int(models.INTERVIEWING)).
+			WillReturnResult(sqlmock.NewResult(12, 1))
+		mock.ExpectCommit()
+
+		stage := &models.PipelineStage{Name: "Take-home", Category: models.INTERVIEWING}
+		err := repo.CreateStage(context.Background(), testUserID, stage)
+
+		require.NoError(t, err)
+		assert.Equal(t, 12, stage.ID)
+		assert.Equal(t, 7, stage.Position)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rejects a duplicate name", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+		repo := NewSQLitePipelineRepository(db, cache.NewNoOpCache())
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\)").
+			WithArgs(testUserID).
+			WillReturnRows(sqlmock.NewRows([]string{"count", "max"}).AddRow(6, 6))
+		mock.ExpectExec("INSERT INTO pipeline_stages").
+			WillReturnError(errors.New("UNIQUE constraint failed: pipeline_stages.user_id, pipeline_stages.name"))
+		mock.ExpectRollback()
+
+		err := repo.CreateStage(context.Background(), testUserID, &models.PipelineStage{Name: "applied"})
+
+		assert.ErrorIs(t, err, models.ErrDuplicateStage)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("limits the number of stages", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+		repo := NewSQLitePipelineRepository(db, cache.NewNoOpCache())
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\)").
+			WithArgs(testUserID).
+			WillReturnRows(sqlmock.NewRows([]string{"count", "max"}).AddRow(models.MaxPipelineStages, models.MaxPipelineStages))
+		mock.ExpectRollback()
+
+		err := repo.CreateStage(context.Background(), testUserID, &models.PipelineStage{Name: "Onsite"})
+
+		assert.ErrorIs(t, err, models.ErrTooManyStages)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSQLitePipelineRepository_UpdateStage(t *testing.T) {
+	expectStage := func(mock sqlmock.Sqlmock, category models.JobStatus, jobCount int) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT s.category, \\(SELECT COUNT\\(\\*\\) FROM jobs j WHERE j.stage_id = s.id").
+			WithArgs(7, testUserID).
+			WillReturnRows(sqlmock.NewRows([]string{"category", "count"}).AddRow(int(category), jobCount))
+	}
+
+	t.Run("changes the category of an empty stage", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+		repo := NewSQLitePipelineRepository(db, cache.NewNoOpCache())
+
+		expectStage(mock, models.INTERVIEWING, 0)
+		mock.ExpectExec("UPDATE pipeline_stages SET name = \\?, category = \\?").
+			WithArgs("Onsite", int(models.OFFER_RECEIVED), 7, testUserID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.UpdateStage(context.Background(), testUserID, &models.PipelineStage{ID: 7, Name: "Onsite", Category: models.OFFER_RECEIVED})
+
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("renames a stage that holds jobs", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+		repo := NewSQLitePipelineRepository(db, cache.NewNoOpCache())
+
+		expectStage(mock, models.INTERVIEWING, 2)
+		mock.ExpectExec("UPDATE pipeline_stages SET name = \\?, category = \\?").
+			WithArgs("Onsite", int(models.INTERVIEWING), 7, testUserID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.UpdateStage(context.Background(), testUserID, &models.PipelineStage{ID: 7, Name: "Onsite", Category: models.INTERVIEWING})
+
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("keeps the category of a stage that holds jobs", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+		repo := NewSQLitePipelineRepository(db, cache.NewNoOpCache())
+
+		expectStage(mock, models.INTERVIEWING, 2)
+		mock.ExpectRollback()
+
+		err := repo.UpdateStage(context.Background(), testUserID, &models.PipelineStage{ID: 7, Name: "Onsite", Category: models.REJECTED})
+
+		assert.ErrorIs(t, err, models.ErrStageHasJobs)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rejects another user's stage", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+		repo := NewSQLitePipelineRepository(db, cache.NewNoOpCache())
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT s.category").
+			WithArgs(7, testUserID).
+			WillReturnRows(sqlmock.NewRows([]string{"category", "count"}))
+		mock.ExpectRollback()
+
+		err := repo.UpdateStage(context.Background(), testUserID, &models.PipelineStage{ID: 7, Name: "Onsite", Category: models.REJECTED})
+
+		assert.ErrorIs(t, err, models.ErrStageNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSQLitePipelineRepository_DeleteStage(t *testing.T) {
+	t.Run("moves the stage's jobs to the replacement", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+		repo := NewSQLitePipelineRepository(db, cache.NewNoOpCache())
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM pipeline_stages").
+			WithArgs(testUserID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+		mock.ExpectQuery("SELECT category FROM pipeline_stages").
+			WithArgs(3, testUserID).
+			WillReturnRows(sqlmock.NewRows([]string{"category"}).AddRow(int(models.INTERVIEWING)))
+		mock.ExpectExec("UPDATE jobs SET stage_id = \\?, status = \\?, updated_at = \\?\\s*WHERE stage_id = \\? AND user_id = \\?").
+			WithArgs(3, int(models.INTERVIEWING), sqlmock.AnyArg(), 7, testUserID).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec("DELETE FROM pipeline_stages WHERE id = \\? AND user_id = \\?").
+			WithArgs(7, testUserID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.DeleteStage(context.Background(), testUserID, 7, 3)
+
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("keeps the last stage", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+		repo := NewSQLitePipelineRepository(db, cache.NewNoOpCache())
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM pipeline_stages").
+			WithArgs(testUserID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectRollback()
+
+		err := repo.DeleteStage(context.Background(), testUserID, 7, 3)
+
+		assert.ErrorIs(t, err, models.ErrLastStage)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,305 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/benidevo/vega/internal/job/models"
+)
+
+// reminderTimeLayout is the layout of due_at and completed_at, that of
+// CURRENT_TIMESTAMP, so that times compare as text
+const reminderTimeLayout = "2006-01-02 15:04:05"
+
+// reminderColumns are the columns scanned by scanReminder
+const reminderColumns = `r.id, r.job_id, r.message, r.due_at, r.recurrence, r.status, r.kind, r.completed_at, r.created_at`
+
+// SQLiteReminderRepository is a SQLite implementation of ReminderRepository
+type SQLiteReminderRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteReminderRepository creates a new reminder repository
+func NewSQLiteReminderRepository(db *sql.DB) *SQLiteReminderRepository {
+	return &SQLiteReminderRepository{db: db}
+}
+
+// GetJobReminders returns the reminders of a job, open ones first, each group
+// by due time
+func (r *SQLiteReminderRepository) GetJobReminders(ctx context.Context, userID, jobID int) ([]*models.Reminder, error) {
+	query := `
+		SELECT ` + reminderColumns + `
+		FROM reminders r
+		WHERE r.job_id = ? AND r.user_id = ?
+		ORDER BY r.status = ?, r.due_at, r.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, jobID, userID, int(models.ReminderDone))
+	if err != nil {
+		return nil, models.WrapError(models.ErrFailedToGetReminders, err)
+	}
+	defer rows.Close()
+
+	var reminders []*models.Reminder
+	for rows.Next() {
+		reminder, err := scanReminder(rows, false)
+		if err != nil {
+			return nil, models.WrapError(models.ErrFailedToGetReminders, err)
+		}
+		reminders = append(reminders, reminder)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, models.WrapError(models.ErrFailedToGetReminders, err)
+	}
+	return reminders, nil
+}
+
+// GetUpcomingReminders returns the user's open reminders due by the given
+// time, overdue ones included, soonest first, with their job's title and
+// company
+func (r *SQLiteReminderRepository) GetUpcomingReminders(ctx context.Context, userID int, until time.Time, limit int) ([]*models.Reminder, error) {
+	query := `
+		SELECT ` + reminderColumns + `, j.title, COALESCE(c.name, '')
+		FROM reminders r
+		JOIN jobs j ON j.id = r.job_id AND j.user_id = r.user_id
+		LEFT JOIN companies c ON c.id = j.company_id
+		WHERE r.user_id = ? AND r.status != ? AND r.due_at <= ?
+		ORDER BY r.due_at, r.id
+		LIMIT ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query,
+		userID,
+		int(models.ReminderDone),
+		until.UTC().Format(reminderTimeLayout),
+		limit,
+	)
+	if err != nil {
+		return nil, models.WrapError(models.ErrFailedToGetReminders, err)
+	}
+	defer rows.Close()
+
+	var reminders []*models.Reminder
+	for rows.Next() {
+		reminder, err := scanReminder(rows, true)
+		if err != nil {
+			return nil, models.WrapError(models.ErrFailedToGetReminders, err)
+		}
+		reminders = append(reminders, reminder)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, models.WrapError(models.ErrFailedToGetReminders, err)
+	}
+	return reminders, nil
+}
+
+// GetOverdueCounts returns the number of overdue reminders of each of the
+// given jobs, keyed by job ID. Jobs without any are left out.
+func (r *SQLiteReminderRepository) GetOverdueCounts(ctx context.Context, userID int, jobIDs []int) (map[int]int, error) {
+	counts := make(map[int]int)
+	if len(jobIDs) == 0 {
+		return counts, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(jobIDs)), ",")
+	query := fmt.Sprintf(`
+		SELECT job_id, COUNT(*)
+		FROM reminders
+		WHERE user_id = ? AND status = ? AND job_id IN (%s)
+		GROUP BY job_id
+	`, placeholders)
+
+	args := []any{userID, int(models.ReminderDue)}
+	for _, id := range jobIDs {
+		args = append(args, id)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, models.WrapError(models.ErrFailedToGetReminders, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var jobID, count int
+		if err := rows.Scan(&jobID, &count); err != nil {
+			return nil, models.WrapError(models.ErrFailedToGetReminders, err)
+		}
+		counts[jobID] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, models.WrapError(models.ErrFailedToGetReminders, err)
+	}
+	return counts, nil
+}
+
+// GetReminder returns one of a job's reminders
+func (r *SQLiteReminderRepository) GetReminder(ctx context.Context, userID, jobID, reminderID int) (*models.Reminder, error) {
+	query := `
+		SELECT ` + reminderColumns + `
+		FROM reminders r
+		WHERE r.id = ? AND r.job_id = ? AND r.user_id = ?
+	`
+
+	reminder, err := scanReminder(r.db.QueryRowContext(ctx, query, reminderID, jobID, userID), false)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrReminderNotFound
+	}
+	if err != nil {
+		return nil, models.WrapError(models.ErrFailedToGetReminders, err)
+	}
+	return reminder, nil
+}
+
+// CreateReminder adds a reminder to one of the user's jobs and sets its ID
+func (r *SQLiteReminderRepository) CreateReminder(ctx context.Context, userID int, reminder *models.Reminder) error {
+	query := `
+		INSERT INTO reminders (job_id, user_id, message, due_at, recurrence, status, kind)
+		SELECT id, user_id, ?, ?, ?, ?, ?
+		FROM jobs
+		WHERE id = ? AND user_id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		reminder.Message,
+		reminder.DueAt.UTC().Format(reminderTimeLayout),
+		string(reminder.Recurrence),
+		int(reminder.Status),
+		string(reminder.Kind),
+		reminder.JobID,
+		userID,
+	)
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSaveReminder, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSaveReminder, err)
+	}
+	if rowsAffected == 0 {
+		return models.ErrJobNotFound
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSaveReminder, err)
+	}
+	reminder.ID = int(id)
+	return nil
+}
+
+// CompleteReminder marks a reminder done
+func (r *SQLiteReminderRepository) CompleteReminder(ctx context.Context, userID, jobID, reminderID int, completedAt time.Time) error {
+	query := `
+		UPDATE reminders SET status = ?, completed_at = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND job_id = ? AND user_id = ?
+	`
+
+	return r.update(ctx, query,
+		int(models.ReminderDone),
+		completedAt.UTC().Format(reminderTimeLayout),
+		reminderID,
+		jobID,
+		userID,
+	)
+}
+
+// RescheduleReminder moves a reminder to a new due time and makes it pending
+// again
+func (r *SQLiteReminderRepository) RescheduleReminder(ctx context.Context, userID, jobID, reminderID int, dueAt time.Time) error {
+	query := `
+		UPDATE reminders SET due_at = ?, status = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND job_id = ? AND user_id = ?
+	`
+
+	return r.update(ctx, query,
+		dueAt.UTC().Format(reminderTimeLayout),
+		int(models.ReminderPending),
+		reminderID,
+		jobID,
+		userID,
+	)
+}
+
+// DeleteReminder removes a reminder
+func (r *SQLiteReminderRepository) DeleteReminder(ctx context.Context, userID, jobID, reminderID int) error {
+	return r.update(ctx,
+		"DELETE FROM reminders WHERE id = ? AND job_id = ? AND user_id = ?",
+		reminderID, jobID, userID,
+	)
+}
+
+// MarkDueReminders marks every pending reminder whose due time has passed as
+// due, whoever it belongs to, and returns how many there were
+func (r *SQLiteReminderRepository) MarkDueReminders(ctx context.Context, now time.Time) (int, error) {
+	query := `
+		UPDATE reminders SET status = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE status = ? AND due_at <= ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		int(models.ReminderDue),
+		int(models.ReminderPending),
+		now.UTC().Format(reminderTimeLayout),
+	)
+	if err != nil {
+		return 0, models.WrapError(models.ErrFailedToSaveReminder, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, models.WrapError(models.ErrFailedToSaveReminder, err)
+	}
+	return int(rowsAffected), nil
+}
+
+// update runs a statement changing one reminder
+func (r *SQLiteReminderRepository) update(ctx context.Context, query string, args ...any) error {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSaveReminder, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSaveReminder, err)
+	}
+	if rowsAffected == 0 {
+		return models.ErrReminderNotFound
+	}
+	return nil
+}
+
+// scanReminder scans the reminderColumns of a row, followed by the job title
+// and company name when withJob is set
+func scanReminder(row interface{ Scan(...any) error }, withJob bool) (*models.Reminder, error) {
+	var reminder models.Reminder
+	var completedAt sql.NullTime
+	dest := []any{
+		&reminder.ID,
+		&reminder.JobID,
+		&reminder.Message,
+		&reminder.DueAt,
+		&reminder.Recurrence,
+		&reminder.Status,
+		&reminder.Kind,
+		&completedAt,
+		&reminder.CreatedAt,
+	}
+	if withJob {
+		dest = append(dest, &reminder.JobTitle, &reminder.CompanyName)
+	}
+
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	if completedAt.Valid {
+		reminder.CompletedAt = &completedAt.Time
+	}
+	return &reminder, nil
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/benidevo/vega/internal/job/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteReminderRepository_CreateReminder(t *testing.T) {
+	due := time.Date(2025, 6, 9, 12, 30, 0, 0, time.UTC)
+
+	t.Run("adds the reminder to the user's job", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+		repo := NewSQLiteReminderRepository(db)
+
+		mock.ExpectExec(`INSERT INTO reminders \(job_id, user_id, message, due_at, recurrence, status, kind\)\s*SELECT id, user_id`).
+			WithArgs("Follow up", "2025-06-09 12:30:00", "", int(models.ReminderPending), "follow_up", 3, testUserID).
+			WillReturnResult(sqlmock.NewResult(11, 1))
+
+		reminder := &models.Reminder{JobID: 3, Message: "Follow up", DueAt: due, Kind: models.ReminderKindFollowUp}
+		err := repo.CreateReminder(context.Background(), testUserID, reminder)
+
+		require.NoError(t, err)
+		assert.Equal(t, 11, reminder.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rejects another user's job", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+		repo := NewSQLiteReminderRepository(db)
+
+		mock.ExpectExec("INSERT INTO reminders").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.CreateReminder(context.Background(), testUserID, &models.Reminder{JobID: 3, Message: "Follow up", DueAt: due})
+
+		assert.ErrorIs(t, err, models.ErrJobNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSQLiteReminderRepository_CompleteReminder(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+	repo := NewSQLiteReminderRepository(db)
+
+	mock.ExpectExec(`UPDATE reminders SET status = \?, completed_at = \?`).
+		WithArgs(int(models.ReminderDone), sqlmock.AnyArg(), 5, 3, testUserID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.CompleteReminder(context.Background(), testUserID, 3, 5, time.Now())
+
+	assert.ErrorIs(t, err, models.ErrReminderNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSQLiteReminderRepository_GetOverdueCounts(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+	repo := NewSQLiteReminderRepository(db)
+
+	mock.ExpectQuery(`SELECT job_id, COUNT\(\*\)\s*FROM reminders\s*WHERE user_id = \? AND status = \? AND job_id IN \(\?,\?\)`).
+		WithArgs(testUserID, int(models.ReminderDue), 3, 4).
+		WillReturnRows(sqlmock.NewRows([]string{"job_id", "count"}).AddRow(4, 2))
+
+	counts, err := repo.GetOverdueCounts(context.Background(), testUserID, []int{3, 4})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[int]int{4: 2}, counts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSQLiteReminderRepository_MarkDueReminders(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+	repo := NewSQLiteReminderRepository(db)
+
+	now := time.Date(2025, 6, 9, 12, 30, 15, 0, time.UTC)
+	mock.ExpectExec(`UPDATE reminders SET status = \?, updated_at = CURRENT_TIMESTAMP\s*WHERE status = \? AND due_at <= \?`).
+		WithArgs(int(models.ReminderDue), int(models.ReminderPending), "2025-06-09 12:30:15").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	count, err := repo.MarkDueReminders(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
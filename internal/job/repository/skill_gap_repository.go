@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/benidevo/vega/internal/job/models"
+)
+
+// SQLiteSkillGapRepository is a SQLite implementation of SkillGapRepository
+type SQLiteSkillGapRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteSkillGapRepository creates a new skill gap repository
+func NewSQLiteSkillGapRepository(db *sql.DB) *SQLiteSkillGapRepository {
+	return &SQLiteSkillGapRepository{db: db}
+}
+
+// GetAnalysedJobs returns the user's jobs that have been analysed, each with
+// the weaknesses from its latest match result
+func (r *SQLiteSkillGapRepository) GetAnalysedJobs(ctx context.Context, userID int) ([]*models.AnalysedJob, error) {
+	query := `
+		SELECT j.id, j.title, c.name, j.required_skills, mr.weaknesses
+		FROM jobs j
+		JOIN companies c ON c.id = j.company_id
+		JOIN match_results mr ON mr.id = (
+			SELECT id FROM match_results
+			WHERE job_id = j.id AND user_id = j.user_id
+			ORDER BY created_at DESC, id DESC
+			LIMIT 1
+		)
+		WHERE j.user_id = ?
+		ORDER BY j.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, models.WrapError(models.ErrFailedToGetJob, err)
+	}
+	defer rows.Close()
+
+	jobs := []*models.AnalysedJob{}
+	for rows.Next() {
+		var job models.AnalysedJob
+		var skillsJSON, weaknessesJSON sql.NullString
+
+		if err := rows.Scan(&job.JobID, &job.Title, &job.Company, &skillsJSON, &weaknessesJSON); err != nil {
+			return nil, models.WrapError(models.ErrFailedToGetJob, err)
+		}
+
+		if err := json.Unmarshal([]byte(skillsJSON.String), &job.RequiredSkills); err != nil {
+			job.RequiredSkills = []string{}
+		}
+		if err := json.Unmarshal([]byte(weaknessesJSON.String), &job.Weaknesses); err != nil {
+			job.Weaknesses = []string{}
+		}
+
+		jobs = append(jobs, &job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, models.WrapError(models.ErrFailedToGetJob, err)
+	}
+
+	return jobs, nil
+}
+
+// GetSkillGapStates returns the stored plans and addressed flags of the
+// user's skill gaps
+func (r *SQLiteSkillGapRepository) GetSkillGapStates(ctx context.Context, userID int) ([]*models.SkillGapState, error) {
+	query := `
+		SELECT gap_key, name, plan, plan_prompt_version, plan_generated_at, addressed_at, addressed_note
+		FROM skill_gaps
+		WHERE user_id = ?
+		ORDER BY id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, models.WrapError(models.ErrFailedToGetJob, err)
+	}
+	defer rows.Close()
+
+	states := []*models.SkillGapState{}
+	for rows.Next() {
+		var state models.SkillGapState
+		var planJSON sql.NullString
+		var promptVersion string
+		var generatedAt, addressedAt sql.NullTime
+
+		err := rows.Scan(
+			&state.Key,
+			&state.Name,
+			&planJSON,
+			&promptVersion,
+			&generatedAt,
+			&addressedAt,
+			&state.AddressedNote,
+		)
+		if err != nil {
+			return nil, models.WrapError(models.ErrFailedToGetJob, err)
+		}
+
+		if planJSON.Valid && planJSON.String != "" {
+			var plan models.GapPlan
+			if err := json.Unmarshal([]byte(planJSON.String), &plan); err == nil {
+				plan.PromptVersion = promptVersion
+				if generatedAt.Valid {
+					plan.GeneratedAt = generatedAt.Time
+				}
+				state.Plan = &plan
+			}
+		}
+		if addressedAt.Valid {
+			addressed := addressedAt.Time
+			state.AddressedAt = &addressed
+		}
+
+		states = append(states, &state)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, models.WrapError(models.ErrFailedToGetJob, err)
+	}
+
+	return states, nil
+}
+
+// SaveSkillGapPlan stores the learning plan of a skill gap, replacing any
+// earlier plan
+func (r *SQLiteSkillGapRepository) SaveSkillGapPlan(ctx context.Context, userID int, key, name string, plan *models.GapPlan) error {
+	planJSON, err := json.Marshal(plan)
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSaveSkillGap, err)
+	}
+
+	query := `
+		INSERT INTO skill_gaps (user_id, gap_key, name, plan, plan_prompt_version, plan_generated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(user_id, gap_key) DO UPDATE SET
+			name = excluded.name,
+			plan = excluded.plan,
+			plan_prompt_version = excluded.plan_prompt_version,
+			plan_generated_at = excluded.plan_generated_at,
+			updated_at = CURRENT_TIMESTAMP
+	`
+
+	_, err = r.db.ExecContext(ctx, query, userID, key, name, string(planJSON), plan.PromptVersion, plan.GeneratedAt)
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSaveSkillGap, err)
+	}
+	return nil
+}
+
+// MarkSkillGapAddressed records that the user has addressed a skill gap
+func (r *SQLiteSkillGapRepository) MarkSkillGapAddressed(ctx context.Context, userID int, key, name, note string) error {
+	query := `
+		INSERT INTO skill_gaps (user_id, gap_key, name, addressed_at, addressed_note)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(user_id, gap_key) DO UPDATE SET
+			addressed_at = excluded.addressed_at,
+			addressed_note = excluded.addressed_note,
+			updated_at = CURRENT_TIMESTAMP
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID, key, name, time.Now().UTC(), note)
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSaveSkillGap, err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/benidevo/vega/internal/job/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteSkillGapRepository_GetAnalysedJobs(t *testing.T) {
+	t.Run("returns jobs with their latest weaknesses", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+		repo := NewSQLiteSkillGapRepository(db)
+
+		rows := sqlmock.NewRows([]string{"id", "title", "name", "required_skills", "weaknesses"}).
+			AddRow(1, "Platform Engineer", "Acme", `["Go","Kubernetes"]`, `["No Kubernetes experience"]`).
+			AddRow(2, "Backend Engineer", "Globex", `not json`, nil)
+		mock.ExpectQuery("SELECT j.id, j.title, c.name, j.required_skills, mr.weaknesses").
+			WithArgs(testUserID).
+			WillReturnRows(rows)
+
+		jobs, err := repo.GetAnalysedJobs(context.Background(), testUserID)
+
+		require.NoError(t, err)
+		require.Len(t, jobs, 2)
+		assert.Equal(t, "Acme", jobs[0].Company)
+		assert.Equal(t, []string{"Go", "Kubernetes"}, jobs[0].RequiredSkills)
+		assert.Equal(t, []string{"No Kubernetes experience"}, jobs[0].Weaknesses)
+		assert.Empty(t, jobs[1].RequiredSkills)
+		assert.Empty(t, jobs[1].Weaknesses)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("wraps query errors", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+		repo := NewSQLiteSkillGapRepository(db)
+
+		mock.ExpectQuery("SELECT j.id").WithArgs(testUserID).WillReturnError(errors.New("db down"))
+
+		_, err := repo.GetAnalysedJobs(context.Background(), testUserID)
+
+		assert.ErrorIs(t, err, models.ErrFailedToGetJob)
+	})
+}
+
+func TestSQLiteSkillGapRepository_GetSkillGapStates(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+	repo := NewSQLiteSkillGapRepository(db)
+
+	generatedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	addressedAt := generatedAt.Add(time.Hour)
+	rows := sqlmock.NewRows([]string{"gap_key", "name", "plan", "plan_prompt_version", "plan_generated_at", "addressed_at", "addressed_note"}).
+		AddRow("kubernetes", "Kubernetes", `{"why":"Common","steps":["Learn pods"],"projects":[],"phrasing":[]}`, "builtin", generatedAt, nil, "").
+		AddRow("graphql", "GraphQL", nil, "", nil, addressedAt, "Built an API")
+	mock.ExpectQuery("SELECT gap_key, name, plan").WithArgs(testUserID).WillReturnRows(rows)
+
+	states, err := repo.GetSkillGapStates(context.Background(), testUserID)
+
+	require.NoError(t, err)
+	require.Len(t, states, 2)
+	require.NotNil(t, states[0].Plan)
+	assert.Equal(t, []string{"Learn pods"}, states[0].Plan.Steps)
+	assert.Equal(t, "builtin", states[0].Plan.PromptVersion)
+	assert.Equal(t, generatedAt, states[0].Plan.GeneratedAt)
+	assert.Nil(t, states[0].AddressedAt)
+	assert.Nil(t, states[1].Plan)
+	require.NotNil(t, states[1].AddressedAt)
+	assert.Equal(t, "Built an API", states[1].AddressedNote)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSQLiteSkillGapRepository_SaveSkillGapPlan(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+	repo := NewSQLiteSkillGapRepository(db)
+
+	plan := &models.GapPlan{Steps: []string{"Learn pods"}, PromptVersion: "builtin", GeneratedAt: time.Now().UTC()}
+	mock.ExpectExec("INSERT INTO skill_gaps").
+		WithArgs(testUserID, "kubernetes", "Kubernetes", sqlmock.AnyArg(), "builtin", plan.GeneratedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := repo.SaveSkillGapPlan(context.Background(), testUserID, "kubernetes", "Kubernetes", plan)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSQLiteSkillGapRepository_MarkSkillGapAddressed(t *testing.T) {
+	t.Run("stores the addressed gap", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+		repo := NewSQLiteSkillGapRepository(db)
+
+		mock.ExpectExec("INSERT INTO skill_gaps").
+			WithArgs(testUserID, "graphql", "GraphQL", sqlmock.AnyArg(), "Built an API").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := repo.MarkSkillGapAddressed(context.Background(), testUserID, "graphql", "GraphQL", "Built an API")
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("wraps exec errors", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+		repo := NewSQLiteSkillGapRepository(db)
+
+		mock.ExpectExec("INSERT INTO skill_gaps").WillReturnError(errors.New("db down"))
+
+		err := repo.MarkSkillGapAddressed(context.Background(), testUserID, "graphql", "GraphQL", "")
+
+		assert.ErrorIs(t, err, models.ErrFailedToSaveSkillGap)
+	})
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/benidevo/vega/internal/job/models"
+)
+
+// statusEventTimeLayout is the layout of occurred_at, the one CURRENT_TIMESTAMP
+// uses in the triggers recording status changes, so that times compare as text
+const statusEventTimeLayout = "2006-01-02 15:04:05"
+
+// SQLiteStatusEventRepository is a SQLite implementation of
+// StatusEventRepository
+type SQLiteStatusEventRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteStatusEventRepository creates a new status event repository
+func NewSQLiteStatusEventRepository(db *sql.DB) *SQLiteStatusEventRepository {
+	return &SQLiteStatusEventRepository{db: db}
+}
+
+// GetStatusEvents returns the status changes of a job in the order they
+// happened, with the names of the stages involved
+func (r *SQLiteStatusEventRepository) GetStatusEvents(ctx context.Context, userID, jobID int) ([]*models.StatusEvent, error) {
+	query := `
+		SELECT
+			e.id, e.job_id, e.from_status, e.to_status,
+			COALESCE(e.to_stage_id, 0), COALESCE(fs.name, ''), COALESCE(ts.name, ''),
+			e.note, e.occurred_at, e.created_at
+		FROM job_status_events e
+		LEFT JOIN pipeline_stages fs ON fs.id = e.from_stage_id
+		LEFT JOIN pipeline_stages ts ON ts.id = e.to_stage_id
+		WHERE e.job_id = ? AND e.user_id = ?
+		ORDER BY e.occurred_at, e.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, jobID, userID)
+	if err != nil {
+		return nil, models.WrapError(models.ErrFailedToGetHistory, err)
+	}
+	defer rows.Close()
+
+	var events []*models.StatusEvent
+	for rows.Next() {
+		var event models.StatusEvent
+		var fromStatus sql.NullInt64
+		if err := rows.Scan(
+			&event.ID,
+			&event.JobID,
+			&fromStatus,
+			&event.ToStatus,
+			&event.ToStageID,
+			&event.FromStage,
+			&event.ToStage,
+			&event.Note,
+			&event.OccurredAt,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, models.WrapError(models.ErrFailedToGetHistory, err)
+		}
+		if fromStatus.Valid {
+			status := models.JobStatus(fromStatus.Int64)
+			event.FromStatus = &status
+		}
+		events = append(events, &event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, models.WrapError(models.ErrFailedToGetHistory, err)
+	}
+	return events, nil
+}
+
+// UpdateStatusEvent sets the note of a status change and when it happened
+func (r *SQLiteStatusEventRepository) UpdateStatusEvent(ctx context.Context, userID, jobID, eventID int, note string, occurredAt time.Time) error {
+	query := `
+		UPDATE job_status_events SET note = ?, occurred_at = ?
+		WHERE id = ? AND job_id = ? AND user_id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		note,
+		occurredAt.UTC().Format(statusEventTimeLayout),
+		eventID,
+		jobID,
+		userID,
+	)
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSaveHistory, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSaveHistory, err)
+	}
+	if rowsAffected == 0 {
+		return models.ErrStatusEventNotFound
+	}
+	return nil
+}
+
+// GetStatusActivity counts the jobs the user applied to and got an interview
+// for since the given time, and averages the days from applying to the first
+// interview over all jobs that have both
+func (r *SQLiteStatusEventRepository) GetStatusActivity(ctx context.Context, userID int, since time.Time) (*models.StatusActivity, error) {
+	countQuery := `
+		SELECT
+			COUNT(DISTINCT CASE WHEN to_status = ? THEN job_id END),
+			COUNT(DISTINCT CASE WHEN to_status = ? THEN job_id END)
+		FROM job_status_events
+		WHERE user_id = ? AND occurred_at >= ?
+	`
+
+	var activity models.StatusActivity
+	err := r.db.QueryRowContext(ctx, countQuery,
+		int(models.APPLIED),
+		int(models.INTERVIEWING),
+		userID,
+		since.UTC().Format(statusEventTimeLayout),
+	).Scan(&activity.Applied, &activity.Interviews)
+	if err != nil {
+		return nil, models.WrapError(models.ErrFailedToGetHistory, err)
+	}
+
+	durationQuery := `
+		SELECT COALESCE(AVG(julianday(i.first_at) - julianday(a.first_at)), 0), COUNT(*)
+		FROM (
+			SELECT job_id, MIN(occurred_at) AS first_at
+			FROM job_status_events
+			WHERE user_id = ? AND to_status = ?
+			GROUP BY job_id
+		) a
+		JOIN (
+			SELECT job_id, MIN(occurred_at) AS first_at
+			FROM job_status_events
+			WHERE user_id = ? AND to_status = ?
+			GROUP BY job_id
+		) i ON i.job_id = a.job_id AND i.first_at >= a.first_at
+	`
+
+	err = r.db.QueryRowContext(ctx, durationQuery,
+		userID, int(models.APPLIED),
+		userID, int(models.INTERVIEWING),
+	).Scan(&activity.AvgDaysToInterview, &activity.TimedInterviews)
+	if err != nil {
+		return nil, models.WrapError(models.ErrFailedToGetHistory, err)
+	}
+	return &activity, nil
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/benidevo/vega/internal/job/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteStatusEventRepository_GetStatusEvents(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+	repo := NewSQLiteStatusEventRepository(db)
+
+	added := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	applied := time.Date(2025, 3, 4, 12, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT\s*e.id, e.job_id, e.from_status, e.to_status,.*FROM job_status_events e\s*LEFT JOIN pipeline_stages fs ON fs.id = e.from_stage_id\s*LEFT JOIN pipeline_stages ts ON ts.id = e.to_stage_id\s*WHERE e.job_id = \? AND e.user_id = \?\s*ORDER BY e.occurred_at, e.id`).
+		WithArgs(7, testUserID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "job_id", "from_status", "to_status", "to_stage_id", "from_stage", "to_stage", "note", "occurred_at", "created_at"}).
+			AddRow(1, 7, nil, int(models.INTERESTED), 11, "", "Interested", "", added, added).
+			AddRow(2, 7, int(models.INTERESTED), int(models.APPLIED), 12, "Interested", "Phone Screen", "through a referral", applied, added))
+
+	events, err := repo.GetStatusEvents(context.Background(), testUserID, 7)
+
+	require.NoError(t, err)
+	interested := models.INTERESTED
+	assert.Equal(t, []*models.StatusEvent{
+		{ID: 1, JobID: 7, ToStatus: models.INTERESTED, ToStageID: 11, ToStage: "Interested", OccurredAt: added, CreatedAt: added},
+		{ID: 2, JobID: 7, FromStatus: &interested, ToStatus: models.APPLIED, ToStageID: 12, FromStage: "Interested", ToStage: "Phone Screen", Note: "through a referral", OccurredAt: applied, CreatedAt: added},
+	}, events)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSQLiteStatusEventRepository_UpdateStatusEvent(t *testing.T) {
+	occurredAt := time.Date(2025, 3, 4, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+
+	t.Run("stores the time in UTC", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+		repo := NewSQLiteStatusEventRepository(db)
+
+		mock.ExpectExec(`UPDATE job_status_events SET note = \?, occurred_at = \?\s*WHERE id = \? AND job_id = \? AND user_id = \?`).
+			WithArgs("through a referral", "2025-03-04 11:00:00", 2, 7, testUserID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.UpdateStatusEvent(context.Background(), testUserID, 7, 2, "through a referral", occurredAt)
+
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("reports changes of other jobs as not found", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+		repo := NewSQLiteStatusEventRepository(db)
+
+		mock.ExpectExec(`UPDATE job_status_events`).
+			WithArgs("", "2025-03-04 11:00:00", 2, 8, testUserID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.UpdateStatusEvent(context.Background(), testUserID, 8, 2, "", occurredAt)
+
+		assert.ErrorIs(t, err, models.ErrStatusEventNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSQLiteStatusEventRepository_GetStatusActivity(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+	repo := NewSQLiteStatusEventRepository(db)
+
+	since := time.Date(2025, 2, 12, 8, 30, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT\s*COUNT\(DISTINCT CASE WHEN to_status = \? THEN job_id END\)`).
+		WithArgs(int(models.APPLIED), int(models.INTERVIEWING), testUserID, "2025-02-12 08:30:00").
+		WillReturnRows(sqlmock.NewRows([]string{"applied", "interviews"}).AddRow(5, 2))
+	mock.ExpectQuery(`SELECT COALESCE\(AVG\(julianday\(i.first_at\) - julianday\(a.first_at\)\), 0\), COUNT\(\*\)`).
+		WithArgs(testUserID, int(models.APPLIED), testUserID, int(models.INTERVIEWING)).
+		WillReturnRows(sqlmock.NewRows([]string{"avg", "count"}).AddRow(8.5, 4))
+
+	activity, err := repo.GetStatusActivity(context.Background(), testUserID, since)
+
+	require.NoError(t, err)
+	assert.Equal(t, &models.StatusActivity{
+		Applied:            5,
+		Interviews:         2,
+		AvgDaysToInterview: 8.5,
+		TimedInterviews:    4,
+	}, activity)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/benidevo/vega/internal/job/models"
+)
+
+// SQLiteTagRepository is a SQLite implementation of TagRepository
+type SQLiteTagRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteTagRepository creates a new tag repository
+func NewSQLiteTagRepository(db *sql.DB) *SQLiteTagRepository {
+	return &SQLiteTagRepository{db: db}
+}
+
+// GetTags returns the user's tags that are on at least one job, with the
+// number of jobs they are on, ordered by name
+func (r *SQLiteTagRepository) GetTags(ctx context.Context, userID int) ([]*models.Tag, error) {
+	query := `
+		SELECT t.id, t.name, COUNT(jt.job_id)
+		FROM tags t
+		JOIN job_tags jt ON jt.tag_id = t.id
+		WHERE t.user_id = ?
+		GROUP BY t.id, t.name
+		ORDER BY t.name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, models.WrapError(models.ErrFailedToGetTags, err)
+	}
+	defer rows.Close()
+
+	var tags []*models.Tag
+	for rows.Next() {
+		var tag models.Tag
+		if err := rows.Scan(&tag.ID, &tag.Name, &tag.JobCount); err != nil {
+			return nil, models.WrapError(models.ErrFailedToGetTags, err)
+		}
+		tags = append(tags, &tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, models.WrapError(models.ErrFailedToGetTags, err)
+	}
+	return tags, nil
+}
+
+// GetJobTags returns the tag names of the given jobs, ordered by name and
+// keyed by job ID. Jobs without tags are left out.
+func (r *SQLiteTagRepository) GetJobTags(ctx context.Context, userID int, jobIDs []int) (map[int][]string, error) {
+	tags := make(map[int][]string)
+	if len(jobIDs) == 0 {
+		return tags, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(jobIDs)), ",")
+	query := fmt.Sprintf(`
+		SELECT jt.job_id, t.name
+		FROM job_tags jt
+		JOIN tags t ON t.id = jt.tag_id
+		WHERE t.user_id = ? AND jt.job_id IN (%s)
+		ORDER BY t.name
+	`, placeholders)
+
+	args := []any{userID}
+	for _, id := range jobIDs {
+		args = append(args, id)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, models.WrapError(models.ErrFailedToGetTags, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var jobID int
+		var name string
+		if err := rows.Scan(&jobID, &name); err != nil {
+			return nil, models.WrapError(models.ErrFailedToGetTags, err)
+		}
+		tags[jobID] = append(tags[jobID], name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, models.WrapError(models.ErrFailedToGetTags, err)
+	}
+	return tags, nil
+}
+
+// SetJobTags replaces the tags of a job in one transaction. Tags the user
+// doesn't have yet are created, keeping the spelling of existing ones, and
+// tags no longer on any job are removed.
+func (r *SQLiteTagRepository) SetJobTags(ctx context.Context, userID, jobID int, tags []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSaveTags, err)
+	}
+	defer tx.Rollback()
+
+	var exists int
+	err = tx.QueryRowContext(ctx, "SELECT 1 FROM jobs WHERE id = ? AND user_id = ?", jobID, userID).Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.ErrJobNotFound
+	}
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSaveTags, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM job_tags WHERE job_id = ?", jobID); err != nil {
+		return models.WrapError(models.ErrFailedToSaveTags, err)
+	}
+
+	for _, tag := range tags {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO tags (user_id, name) VALUES (?, ?)
+			ON CONFLICT(user_id, name) DO NOTHING
+		`, userID, tag)
+		if err != nil {
+			return models.WrapError(models.ErrFailedToSaveTags, err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT OR IGNORE INTO job_tags (job_id, tag_id)
+			SELECT ?, id FROM tags WHERE user_id = ? AND name = ?
+		`, jobID, userID, tag)
+		if err != nil {
+			return models.WrapError(models.ErrFailedToSaveTags, err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM tags
+		WHERE user_id = ? AND id NOT IN (SELECT tag_id FROM job_tags)
+	`, userID)
+	if err != nil {
+		return models.WrapError(models.ErrFailedToSaveTags, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.WrapError(models.ErrFailedToSaveTags, err)
+	}
+	return nil
+}
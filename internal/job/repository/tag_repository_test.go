@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/benidevo/vega/internal/job/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteTagRepository_GetTags(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+	repo := NewSQLiteTagRepository(db)
+
+	mock.ExpectQuery(`SELECT t.id, t.name, COUNT\(jt.job_id\)\s*FROM tags t\s*JOIN job_tags jt`).
+		WithArgs(testUserID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "count"}).
+			AddRow(2, "referral", 3).
+			AddRow(1, "visa sponsorship", 1))
+
+	tags, err := repo.GetTags(context.Background(), testUserID)
+
+	require.NoError(t, err)
+	assert.Equal(t, []*models.Tag{
+		{ID: 2, Name: "referral", JobCount: 3},
+		{ID: 1, Name: "visa sponsorship", JobCount: 1},
+	}, tags)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSQLiteTagRepository_GetJobTags(t *testing.T) {
+	t.Run("groups tags by job", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+		repo := NewSQLiteTagRepository(db)
+
+		mock.ExpectQuery(`SELECT jt.job_id, t.name.*WHERE t.user_id = \? AND jt.job_id IN \(\?,\?,\?\)`).
+			WithArgs(testUserID, 1, 2, 3).
+			WillReturnRows(sqlmock.NewRows([]string{"job_id", "name"}).
+				AddRow(1, "referral").
+				AddRow(3, "referral").
+				AddRow(1, "remote"))
+
+		tags, err := repo.GetJobTags(context.Background(), testUserID, []int{1, 2, 3})
+
+		require.NoError(t, err)
+		assert.Equal(t, map[int][]string{
+			1: {"referral", "remote"},
+			3: {"referral"},
+		}, tags)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("skips the query without jobs", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+		repo := NewSQLiteTagRepository(db)
+
+		tags, err := repo.GetJobTags(context.Background(), testUserID, nil)
+
+		require.NoError(t, err)
+		assert.Empty(t, tags)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSQLiteTagRepository_SetJobTags(t *testing.T) {
+	t.Run("replaces the job's tags", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+		repo := NewSQLiteTagRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT 1 FROM jobs WHERE id = \\? AND user_id = \\?").
+			WithArgs(7, testUserID).
+			WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+		mock.ExpectExec("DELETE FROM job_tags WHERE job_id = \\?").
+			WithArgs(7).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		for _, tag := range []string{"referral", "remote"} {
+			mock.ExpectExec("INSERT INTO tags").
+				WithArgs(testUserID, tag).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectExec("INSERT OR IGNORE INTO job_tags").
+				WithArgs(7, testUserID, tag).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+		}
+		mock.ExpectExec("DELETE FROM tags").
+			WithArgs(testUserID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.SetJobTags(context.Background(), testUserID, 7, []string{"referral", "remote"})
+
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("returns not found for another user's job", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+		repo := NewSQLiteTagRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT 1 FROM jobs").
+			WithArgs(7, testUserID).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		err := repo.SetJobTags(context.Background(), testUserID, 7, []string{"referral"})
+
+		assert.ErrorIs(t, err, models.ErrJobNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rolls back on failure", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+		repo := NewSQLiteTagRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT 1 FROM jobs").
+			WithArgs(7, testUserID).
+			WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+		mock.ExpectExec("DELETE FROM job_tags").
+			WithArgs(7).
+			WillReturnError(errors.New("db down"))
+		mock.ExpectRollback()
+
+		err := repo.SetJobTags(context.Background(), testUserID, 7, []string{"referral"})
+
+		assert.ErrorIs(t, err, models.ErrFailedToSaveTags)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
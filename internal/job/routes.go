@@ -7,6 +7,7 @@ func RegisterRoutes(router *gin.RouterGroup, handler *JobHandler) {
 	router.GET("", handler.ListJobsPage)
 	router.GET("/new", handler.GetNewJobForm)
 	router.POST("/new", handler.CreateJob)
+	router.POST("/new/extract", handler.ExtractJobDraft)
 
 	jobRoutes := router.Group("")
 	jobRoutes.Use(handler.ValidateJobID())
@@ -14,10 +15,37 @@ func RegisterRoutes(router *gin.RouterGroup, handler *JobHandler) {
 		jobRoutes.GET("/:id/details", handler.GetJobDetails)
 		jobRoutes.GET("/:id/match-history", handler.GetMatchHistory)
 		jobRoutes.DELETE("/:id/match-history/:matchId", handler.DeleteMatchResult)
+		jobRoutes.GET("/:id/status-events", handler.GetStatusTimeline)
+		jobRoutes.PUT("/:id/status-events/:eventId", handler.UpdateStatusEvent)
+		jobRoutes.GET("/:id/reminders", handler.GetJobReminders)
+		jobRoutes.POST("/:id/reminders", handler.CreateReminder)
+		jobRoutes.POST("/:id/reminders/:reminderId/done", handler.CompleteReminder)
+		jobRoutes.DELETE("/:id/reminders/:reminderId", handler.DeleteReminder)
 		jobRoutes.PUT("/:id/:field", handler.UpdateJobField)
 		jobRoutes.DELETE("/:id", handler.DeleteJob)
+		jobRoutes.POST("/:id/description-review/approve", handler.ApproveInjectionReview)
 		jobRoutes.POST("/:id/analyze", handler.AnalyzeJobMatch)
 		jobRoutes.POST("/:id/cover-letter", handler.GenerateCoverLetter)
 		jobRoutes.POST("/:id/cv", handler.GenerateCV)
+		jobRoutes.GET("/:id/interview-prep", handler.GetInterviewPrep)
+		jobRoutes.POST("/:id/interview-prep", handler.GenerateInterviewPrep)
+		jobRoutes.GET("/:id/emails", handler.GetEmails)
+		jobRoutes.POST("/:id/emails", handler.GenerateEmail)
+		jobRoutes.POST("/:id/rewrite", handler.RewriteSection)
+		jobRoutes.POST("/:id/cover-letter/stream", handler.StreamCoverLetter)
+		jobRoutes.POST("/:id/cv/stream", handler.StreamCV)
 	}
 }
+
+// RegisterSettingsRoutes registers the job-derived settings pages with the
+// settings router group.
+func RegisterSettingsRoutes(router *gin.RouterGroup, handler *JobHandler) {
+	router.GET("/learning-plan", handler.GetLearningPlanPage)
+	router.POST("/learning-plan/generate", handler.GenerateLearningPlan)
+	router.POST("/learning-plan/addressed", handler.MarkSkillGapAddressed)
+	router.GET("/pipeline", handler.GetPipelinePage)
+	router.POST("/pipeline", handler.CreatePipelineStage)
+	router.PUT("/pipeline/:stageId", handler.UpdatePipelineStage)
+	router.POST("/pipeline/:stageId/move", handler.MovePipelineStage)
+	router.DELETE("/pipeline/:stageId", handler.DeletePipelineStage)
+}
@@ -5,6 +5,8 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/benidevo/vega/internal/config"
+
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 )
@@ -161,3 +163,19 @@ func TestJobRoutesStructure(t *testing.T) {
 		})
 	})
 }
+
+func TestRegisterSettingsRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	RegisterSettingsRoutes(router.Group("/settings"), NewJobHandler(NewJobService(nil, nil, nil, nil, &config.Settings{}), &config.Settings{}))
+
+	registered := make(map[string]bool)
+	for _, route := range router.Routes() {
+		registered[route.Method+" "+route.Path] = true
+	}
+
+	assert.True(t, registered["GET /settings/learning-plan"])
+	assert.True(t, registered["POST /settings/learning-plan/generate"])
+	assert.True(t, registered["POST /settings/learning-plan/addressed"])
+}
@@ -21,14 +21,22 @@ import (
 
 // JobService provides business logic for job management.
 type JobService struct {
-	jobRepo         interfaces.JobRepository
-	aiService       *ai.AIService
-	settingsService *settings.SettingsService
-	quotaService    *quota.Service
-	documentService *documents.DocumentService
-	cfg             *config.Settings
-	log             *logger.PrivacyLogger
-	validator       *validator.Validate
+	jobRepo             interfaces.JobRepository
+	aiService           *ai.AIService
+	userAIServices      UserAIServices
+	settingsService     *settings.SettingsService
+	quotaService        *quota.Service
+	documentService     *documents.DocumentService
+	skillGapRepo        interfaces.SkillGapRepository
+	injectionReviewRepo interfaces.InjectionReviewRepository
+	tagRepo             interfaces.TagRepository
+	statusEventRepo     interfaces.StatusEventRepository
+	pipelineRepo        interfaces.PipelineRepository
+	reminderRepo        interfaces.ReminderRepository
+	embeddings          Embeddings
+	cfg                 *config.Settings
+	log                 *logger.PrivacyLogger
+	validator           *validator.Validate
 }
 
 // NewJobService creates a new JobService instance.
@@ -50,6 +58,36 @@ func (s *JobService) SetDocumentService(documentService *documents.DocumentServi
 	s.documentService = documentService
 }
 
+// UserAIServices builds AI services backed by a user's own provider key.
+type UserAIServices interface {
+	ForUser(ctx context.Context, userID int) (*ai.AIService, bool, error)
+}
+
+// SetUserAIServices lets users with their own provider key generate with it
+// instead of the server's AI service
+func (s *JobService) SetUserAIServices(userAIServices UserAIServices) {
+	s.userAIServices = userAIServices
+}
+
+// aiServiceFor returns the AI service to use for the user, preferring one
+// backed by the user's own provider key
+func (s *JobService) aiServiceFor(ctx context.Context, userID int) (*ai.AIService, error) {
+	if s.userAIServices != nil {
+		service, ok, err := s.userAIServices.ForUser(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return service, nil
+		}
+	}
+
+	if s.aiService == nil {
+		return nil, models.ErrAIServiceUnavailable
+	}
+	return s.aiService, nil
+}
+
 // CheckCoverLetterExists checks if a cover letter exists for a job
 func (s *JobService) CheckCoverLetterExists(ctx context.Context, userID int, jobID int) (bool, error) {
 	if s.documentService == nil {
@@ -114,10 +152,12 @@ func (s *JobService) ValidateFieldName(field string) error {
 	}
 
 	validFields := map[string]bool{
-		"status": true,
-		"notes":  true,
-		"skills": true,
-		"basic":  true,
+		"status":      true,
+		"notes":       true,
+		"skills":      true,
+		"basic":       true,
+		"description": true,
+		"tags":        true,
 	}
 
 	if !validFields[field] {
@@ -201,6 +241,12 @@ func (s *JobService) CreateJob(ctx context.Context, userID int, title, descripti
 	job := models.NewJob(title, description, company, options...)
 	job.UserID = userID
 
+	tags, err := models.NormalizeTags(job.Tags)
+	if err != nil {
+		return nil, false, err
+	}
+	job.Tags = tags
+
 	if err := s.validator.Struct(job); err != nil {
 		s.log.Error().
 			Str("title", title).
@@ -219,6 +265,15 @@ func (s *JobService) CreateJob(ctx context.Context, userID int, title, descripti
 		return nil, false, err
 	}
 
+	if err := s.setJobStage(ctx, userID, job); err != nil {
+		s.log.Error().
+			Str("title", title).
+			Str("company", companyName).
+			Err(err).
+			Msg("Job validation failed")
+		return nil, false, err
+	}
+
 	createdJob, isNew, err := s.jobRepo.GetOrCreate(ctx, userID, job)
 	if err != nil {
 		s.log.Error().
@@ -235,6 +290,14 @@ func (s *JobService) CreateJob(ctx context.Context, userID int, title, descripti
 			Str("title", createdJob.Title).
 			Str("company", createdJob.Company.Name).
 			Msg("Job created successfully")
+
+		// The job is saved either way; its tags can be added again later
+		if len(tags) > 0 && s.tagRepo != nil {
+			if err := s.SetJobTags(ctx, userID, createdJob, tags); err != nil {
+				s.log.Warn().Int("job_id", createdJob.ID).Err(err).Msg("Failed to tag new job")
+			}
+		}
+		s.suggestReminder(ctx, userID, createdJob, time.Now().UTC())
 	} else {
 		s.log.Info().
 			Int("job_id", createdJob.ID).
@@ -264,6 +327,9 @@ func (s *JobService) GetJob(ctx context.Context, userID int, id int) (*models.Jo
 		return nil, err
 	}
 
+	s.loadTags(ctx, userID, job)
+	s.LoadJobStages(ctx, userID, job)
+
 	s.log.Debug().
 		Int("job_id", job.ID).
 		Str("title", job.Title).
@@ -297,6 +363,9 @@ func (s *JobService) GetJobsWithPagination(ctx context.Context, userID int, filt
 		return nil, err
 	}
 
+	s.loadTags(ctx, userID, jobs...)
+	s.LoadJobStages(ctx, userID, jobs...)
+
 	currentPage := (filter.Offset / filter.Limit) + 1
 	totalPages := (totalCount + filter.Limit - 1) / filter.Limit // Ceiling division
 
@@ -357,6 +426,14 @@ func (s *JobService) UpdateJob(ctx context.Context, userID int, job *models.Job)
 		return err
 	}
 
+	if err := s.setJobStage(ctx, userID, job); err != nil {
+		s.log.Error().
+			Int("job_id", job.ID).
+			Err(err).
+			Msg("Job validation failed")
+		return err
+	}
+
 	job.UpdatedAt = time.Now().UTC()
 
 	err := s.jobRepo.Update(ctx, userID, job)
@@ -373,6 +450,8 @@ func (s *JobService) UpdateJob(ctx context.Context, userID int, job *models.Job)
 		Str("title", job.Title).
 		Msg("Job updated successfully")
 
+	s.rescreenJobDescription(ctx, userID, job)
+
 	return nil
 }
 
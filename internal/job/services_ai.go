@@ -2,11 +2,17 @@ package job
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	aihelpers "github.com/benidevo/vega/internal/ai/helpers"
+	"github.com/benidevo/vega/internal/ai/llm"
 	aimodels "github.com/benidevo/vega/internal/ai/models"
+	ctxutil "github.com/benidevo/vega/internal/common/context"
+	documentsmodels "github.com/benidevo/vega/internal/documents/models"
 	"github.com/benidevo/vega/internal/job/models"
 	settingsmodels "github.com/benidevo/vega/internal/settings/models"
 )
@@ -21,13 +27,18 @@ func (s *JobService) AnalyzeJobMatch(ctx context.Context, userID, jobID int) (*m
 		Str("operation", "job_match_analysis").
 		Msg("Starting job match analysis")
 
-	if s.aiService == nil {
-		s.log.Error().
+	aiService, err := s.aiServiceFor(ctx, userID)
+	if err != nil {
+		s.log.Error().Err(err).
 			Str("user_ref", userRef).
 			Int("job_id", jobID).
 			Str("error_type", "ai_service_unavailable").
 			Msg("AI service not available")
-		return nil, models.ErrAIServiceUnavailable
+		return nil, err
+	}
+
+	if err := s.checkInjectionReview(ctx, userID, jobID); err != nil {
+		return nil, err
 	}
 
 	if s.settingsService == nil {
@@ -103,7 +114,8 @@ func (s *JobService) AnalyzeJobMatch(ctx context.Context, userID, jobID int) (*m
 
 	aiRequest := s.buildAIRequest(job, profile)
 
-	aiResult, err := s.aiService.JobMatcher.AnalyzeMatch(ctx, aiRequest)
+	aiCtx, cacheStatus := llm.WithCacheStatus(ctxutil.WithUserID(ctx, userID))
+	aiResult, err := aiService.JobMatcher.AnalyzeMatch(aiCtx, aiRequest)
 	if err != nil {
 		s.log.Error().Err(err).
 			Str("user_ref", userRef).
@@ -116,12 +128,13 @@ func (s *JobService) AnalyzeJobMatch(ctx context.Context, userID, jobID int) (*m
 	result := s.convertToJobMatchAnalysis(aiResult, userID, jobID)
 
 	matchResult := &models.MatchResult{
-		JobID:      jobID,
-		MatchScore: aiResult.MatchScore,
-		Strengths:  aiResult.Strengths,
-		Weaknesses: aiResult.Weaknesses,
-		Highlights: aiResult.Highlights,
-		Feedback:   aiResult.Feedback,
+		JobID:         jobID,
+		MatchScore:    aiResult.MatchScore,
+		Strengths:     aiResult.Strengths,
+		Weaknesses:    aiResult.Weaknesses,
+		Highlights:    aiResult.Highlights,
+		Feedback:      aiResult.Feedback,
+		PromptVersion: aiResult.PromptVersion,
 	}
 
 	if err := s.jobRepo.CreateMatchResult(ctx, userID, matchResult); err != nil {
@@ -149,8 +162,9 @@ func (s *JobService) AnalyzeJobMatch(ctx context.Context, userID, jobID int) (*m
 			Msg("Job match score updated successfully")
 	}
 
-	// Record the analysis in quota system if available
-	if s.quotaService != nil && job.FirstAnalyzedAt == nil {
+	// Record the analysis in quota system if available. Cached results
+	// did not call the AI provider and are free.
+	if s.quotaService != nil && job.FirstAnalyzedAt == nil && !cacheStatus.Hit() {
 		if err := s.quotaService.RecordAnalysis(ctx, userID, jobID); err != nil {
 			s.log.Warn().Err(err).
 				Str("user_ref", userRef).
@@ -172,7 +186,59 @@ func (s *JobService) AnalyzeJobMatch(ctx context.Context, userID, jobID int) (*m
 }
 
 // GenerateCoverLetter generates a cover letter for a specific job application.
-func (s *JobService) GenerateCoverLetter(ctx context.Context, userID, jobID int) (*models.CoverLetterWithProfile, error) {
+// Options left empty are taken from the job's last cover letter, then from
+// the user's profile defaults.
+func (s *JobService) GenerateCoverLetter(ctx context.Context, userID, jobID int, options models.CoverLetterOptions) (*models.CoverLetterWithProfile, error) {
+	return s.generateCoverLetter(ctx, userID, jobID, options, nil)
+}
+
+// StreamCoverLetter generates a cover letter like GenerateCoverLetter, passing
+// the letter text to onDelta as it is produced, and saves the validated result
+// as the job's cover letter document along with the options it was written
+// with.
+func (s *JobService) StreamCoverLetter(ctx context.Context, userID, jobID int, options models.CoverLetterOptions, onDelta func(text string) error) (*models.CoverLetterWithProfile, error) {
+	result, err := s.generateCoverLetter(ctx, userID, jobID, options, onDelta)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := json.Marshal(map[string]any{
+		"content":      result.CoverLetter.Content,
+		"personalInfo": result.PersonalInfo,
+	})
+	if err != nil {
+		return nil, models.WrapError(models.ErrDocumentSaveFailed, err)
+	}
+
+	settings := documentsmodels.GenerationSettings{
+		Tone:     result.CoverLetter.Options.Tone,
+		Length:   result.CoverLetter.Options.Length,
+		Language: result.CoverLetter.Options.Language,
+	}
+	if err := s.saveGeneratedDocument(ctx, userID, jobID, documentsmodels.DocumentTypeCoverLetter, string(content), result.CoverLetter.PromptVersion, settings); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetCoverLetterOptions returns the options the next cover letter for a job
+// will be written with if the user doesn't choose any: those of the job's
+// last cover letter, falling back to the user's profile defaults.
+func (s *JobService) GetCoverLetterOptions(ctx context.Context, userID, jobID int) (models.CoverLetterOptions, error) {
+	if s.settingsService == nil {
+		return models.CoverLetterOptions{}, models.ErrProfileServiceRequired
+	}
+
+	profile, err := s.settingsService.GetProfileSettings(ctx, userID)
+	if err != nil {
+		return models.CoverLetterOptions{}, err
+	}
+
+	last := s.lastDocumentSettings(ctx, userID, jobID, documentsmodels.DocumentTypeCoverLetter)
+	return resolveCoverLetterOptions(models.CoverLetterOptions{}, last, profile), nil
+}
+
+func (s *JobService) generateCoverLetter(ctx context.Context, userID, jobID int, options models.CoverLetterOptions, onDelta func(text string) error) (*models.CoverLetterWithProfile, error) {
 	userRef := fmt.Sprintf("user_%d", userID)
 
 	s.log.Debug().
@@ -181,13 +247,22 @@ func (s *JobService) GenerateCoverLetter(ctx context.Context, userID, jobID int)
 		Str("operation", "cover_letter_generation").
 		Msg("Starting cover letter generation")
 
-	if s.aiService == nil {
-		s.log.Error().
+	if err := validateCoverLetterOptions(options); err != nil {
+		return nil, err
+	}
+
+	aiService, err := s.aiServiceFor(ctx, userID)
+	if err != nil {
+		s.log.Error().Err(err).
 			Str("user_ref", userRef).
 			Int("job_id", jobID).
 			Str("error_type", "ai_service_unavailable").
 			Msg("AI service not available")
-		return nil, models.ErrAIServiceUnavailable
+		return nil, err
+	}
+
+	if err := s.checkInjectionReview(ctx, userID, jobID); err != nil {
+		return nil, err
 	}
 
 	if s.settingsService == nil {
@@ -228,8 +303,23 @@ func (s *JobService) GenerateCoverLetter(ctx context.Context, userID, jobID int)
 		return nil, err
 	}
 
+	options = resolveCoverLetterOptions(options, s.lastDocumentSettings(ctx, userID, jobID, documentsmodels.DocumentTypeCoverLetter), profile)
+	options.Language = documentLanguage(options.Language, job.Description)
+
 	aiRequest := s.buildAIRequest(job, profile)
-	aiResult, err := s.aiService.CoverLetterGenerator.GenerateCoverLetter(ctx, aiRequest)
+	aiRequest.CoverLetter = &aimodels.CoverLetterOptions{
+		Tone:   aimodels.LetterTone(options.Tone),
+		Length: aimodels.LetterLength(options.Length),
+	}
+	aiRequest.Language = options.Language
+	aiCtx := ctxutil.WithUserID(ctx, userID)
+
+	var aiResult *aimodels.CoverLetter
+	if onDelta == nil {
+		aiResult, err = aiService.CoverLetterGenerator.GenerateCoverLetter(aiCtx, aiRequest)
+	} else {
+		aiResult, err = aiService.CoverLetterGenerator.GenerateCoverLetterStream(aiCtx, aiRequest, onDelta)
+	}
 	if err != nil {
 		s.log.Error().Err(err).
 			Str("user_ref", userRef).
@@ -240,6 +330,7 @@ func (s *JobService) GenerateCoverLetter(ctx context.Context, userID, jobID int)
 	}
 
 	coverLetter := s.convertToCoverLetter(aiResult, userID, jobID)
+	coverLetter.Options = options
 
 	personalInfo := &models.PersonalInfo{
 		FirstName: profile.FirstName,
@@ -266,6 +357,78 @@ func (s *JobService) GenerateCoverLetter(ctx context.Context, userID, jobID int)
 	return result, nil
 }
 
+// validateCoverLetterOptions checks the options a user chose for a cover
+// letter. Empty options are allowed.
+func validateCoverLetterOptions(options models.CoverLetterOptions) error {
+	if options.Tone != "" && !aimodels.LetterTone(options.Tone).IsValid() {
+		return models.ErrInvalidLetterTone
+	}
+	if options.Length != "" && !aimodels.LetterLength(options.Length).IsValid() {
+		return models.ErrInvalidLetterLength
+	}
+	if options.Language != "" && !aimodels.IsValidLanguage(options.Language) {
+		return models.ErrInvalidDocumentLanguage
+	}
+	return nil
+}
+
+// lastDocumentSettings returns the settings the job's saved document of the
+// given type was generated with, or empty settings if there isn't one.
+func (s *JobService) lastDocumentSettings(ctx context.Context, userID, jobID int, docType documentsmodels.DocumentType) documentsmodels.GenerationSettings {
+	if s.documentService == nil {
+		return documentsmodels.GenerationSettings{}
+	}
+
+	doc, err := s.documentService.GetDocumentByJobAndType(ctx, userID, jobID, docType)
+	if err != nil {
+		return documentsmodels.GenerationSettings{}
+	}
+	return doc.Settings
+}
+
+// resolveCoverLetterOptions fills each option the user left empty from the
+// last cover letter's settings, then from the profile defaults. Stored values
+// that are no longer supported are skipped.
+func resolveCoverLetterOptions(options models.CoverLetterOptions, last documentsmodels.GenerationSettings, profile *settingsmodels.Profile) models.CoverLetterOptions {
+	isTone := func(v string) bool { return aimodels.LetterTone(v).IsValid() }
+	isLength := func(v string) bool { return aimodels.LetterLength(v).IsValid() }
+
+	var defaults settingsmodels.Profile
+	if profile != nil {
+		defaults = *profile
+	}
+
+	return models.CoverLetterOptions{
+		Tone:     firstValidOption(isTone, options.Tone, last.Tone, defaults.LetterTone),
+		Length:   firstValidOption(isLength, options.Length, last.Length, defaults.LetterLength),
+		Language: firstValidOption(aimodels.IsValidLanguage, options.Language, last.Language, defaults.LetterLanguage),
+	}
+}
+
+// documentLanguage returns the language to write a document in: the chosen
+// language if there is one, otherwise the language the job posting is written
+// in, otherwise English.
+func documentLanguage(chosen, jobDescription string) string {
+	if chosen != "" && aimodels.IsValidLanguage(chosen) {
+		return chosen
+	}
+	if detected := aihelpers.DetectLanguage(jobDescription); aimodels.IsValidLanguage(detected) {
+		return detected
+	}
+	return aimodels.DefaultLanguage
+}
+
+// firstValidOption returns the first value accepted by valid, or an empty
+// string if there is none.
+func firstValidOption(valid func(string) bool, values ...string) string {
+	for _, value := range values {
+		if value != "" && valid(value) {
+			return value
+		}
+	}
+	return ""
+}
+
 // buildAIRequest creates an AI request from job and profile data.
 func (s *JobService) buildAIRequest(job *models.Job, profile *settingsmodels.Profile) aimodels.Request {
 	applicantName := "Applicant"
@@ -479,16 +642,17 @@ func (s *JobService) convertToJobMatchAnalysis(aiResult *aimodels.MatchResult, u
 	now := time.Now().UTC()
 
 	return &models.JobMatchAnalysis{
-		JobID:      jobID,
-		UserID:     userID,
-		MatchScore: aiResult.MatchScore,
-		Strengths:  aiResult.Strengths,
-		Weaknesses: aiResult.Weaknesses,
-		Highlights: aiResult.Highlights,
-		Feedback:   aiResult.Feedback,
-		AnalyzedAt: now,
-		CreatedAt:  now,
-		UpdatedAt:  now,
+		JobID:         jobID,
+		UserID:        userID,
+		MatchScore:    aiResult.MatchScore,
+		Strengths:     aiResult.Strengths,
+		Weaknesses:    aiResult.Weaknesses,
+		Highlights:    aiResult.Highlights,
+		Feedback:      aiResult.Feedback,
+		PromptVersion: aiResult.PromptVersion,
+		AnalyzedAt:    now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
 	}
 }
 
@@ -497,13 +661,14 @@ func (s *JobService) convertToCoverLetter(aiResult *aimodels.CoverLetter, userID
 	now := time.Now().UTC()
 
 	return &models.CoverLetter{
-		JobID:       jobID,
-		UserID:      userID,
-		Content:     aiResult.Content,
-		Format:      string(aiResult.Format),
-		GeneratedAt: now,
-		CreatedAt:   now,
-		UpdatedAt:   now,
+		JobID:         jobID,
+		UserID:        userID,
+		Content:       aiResult.Content,
+		Format:        string(aiResult.Format),
+		PromptVersion: aiResult.PromptVersion,
+		GeneratedAt:   now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
 	}
 }
 
@@ -537,8 +702,34 @@ func (s *JobService) ValidateProfileForAI(profile *settingsmodels.Profile) error
 	return nil
 }
 
-// GenerateCV generates a CV for a specific job application.
-func (s *JobService) GenerateCV(ctx context.Context, userID, jobID int) (*models.GeneratedCV, error) {
+// GenerateCV generates a CV for a specific job application. language is the
+// ISO 639-1 code to write the CV in; when empty, the CV is written in the
+// language of the job posting.
+func (s *JobService) GenerateCV(ctx context.Context, userID, jobID int, language string) (*models.GeneratedCV, error) {
+	return s.generateCV(ctx, userID, jobID, language, nil)
+}
+
+// StreamCV generates a CV like GenerateCV, passing the raw model output to
+// onDelta as it is produced, and saves the validated result as the job's
+// resume document.
+func (s *JobService) StreamCV(ctx context.Context, userID, jobID int, language string, onDelta func(text string) error) (*models.GeneratedCV, error) {
+	result, err := s.generateCV(ctx, userID, jobID, language, onDelta)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := json.Marshal(result)
+	if err != nil {
+		return nil, models.WrapError(models.ErrDocumentSaveFailed, err)
+	}
+
+	if err := s.saveGeneratedDocument(ctx, userID, jobID, documentsmodels.DocumentTypeResume, string(content), result.PromptVersion, documentsmodels.GenerationSettings{Language: result.Language}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *JobService) generateCV(ctx context.Context, userID, jobID int, language string, onDelta func(text string) error) (*models.GeneratedCV, error) {
 	userRef := fmt.Sprintf("user_%d", userID)
 
 	s.log.Debug().
@@ -547,13 +738,22 @@ func (s *JobService) GenerateCV(ctx context.Context, userID, jobID int) (*models
 		Str("operation", "cv_generation").
 		Msg("Starting CV generation")
 
-	if s.aiService == nil {
-		s.log.Error().
+	if language != "" && !aimodels.IsValidLanguage(language) {
+		return nil, models.ErrInvalidDocumentLanguage
+	}
+
+	aiService, err := s.aiServiceFor(ctx, userID)
+	if err != nil {
+		s.log.Error().Err(err).
 			Str("user_ref", userRef).
 			Int("job_id", jobID).
 			Str("error_type", "ai_service_unavailable").
 			Msg("AI service not available")
-		return nil, models.ErrAIServiceUnavailable
+		return nil, err
+	}
+
+	if err := s.checkInjectionReview(ctx, userID, jobID); err != nil {
+		return nil, err
 	}
 
 	if s.settingsService == nil {
@@ -596,8 +796,16 @@ func (s *JobService) GenerateCV(ctx context.Context, userID, jobID int) (*models
 
 	aiRequest := s.buildAIRequest(job, profile)
 	aiRequest.CVText = s.buildProfileSummary(profile)
+	aiRequest.Language = documentLanguage(language, job.Description)
 
-	aiResult, err := s.aiService.CVGenerator.GenerateCV(ctx, aiRequest, jobID, job.Title)
+	aiCtx := ctxutil.WithUserID(ctx, userID)
+
+	var aiResult *aimodels.GeneratedCV
+	if onDelta == nil {
+		aiResult, err = aiService.CVGenerator.GenerateCV(aiCtx, aiRequest, jobID, job.Title)
+	} else {
+		aiResult, err = aiService.CVGenerator.GenerateCVStream(aiCtx, aiRequest, jobID, job.Title, onDelta)
+	}
 	if err != nil {
 		s.log.Error().Err(err).
 			Str("user_ref", userRef).
@@ -619,6 +827,25 @@ func (s *JobService) GenerateCV(ctx context.Context, userID, jobID int) (*models
 	return result, nil
 }
 
+// saveGeneratedDocument stores generated content, and the settings it was
+// generated with, as the job's document of the given type, replacing any
+// previous version.
+func (s *JobService) saveGeneratedDocument(ctx context.Context, userID, jobID int, docType documentsmodels.DocumentType, content, promptVersion string, settings documentsmodels.GenerationSettings) error {
+	if s.documentService == nil {
+		return models.WrapError(models.ErrDocumentSaveFailed, fmt.Errorf("document service not configured"))
+	}
+
+	if _, err := s.documentService.SaveGeneratedDocument(ctx, userID, jobID, docType, content, promptVersion, settings); err != nil {
+		s.log.Error().Err(err).
+			Str("user_ref", fmt.Sprintf("user_%d", userID)).
+			Int("job_id", jobID).
+			Str("document_type", string(docType)).
+			Msg("Failed to save generated document")
+		return models.WrapError(models.ErrDocumentSaveFailed, err)
+	}
+	return nil
+}
+
 // convertToGeneratedCV converts AI CV result to job domain model.
 func (s *JobService) convertToGeneratedCV(aiResult *aimodels.GeneratedCV, userID, jobID int, profile *settingsmodels.Profile) *models.GeneratedCV {
 	now := time.Now().UTC()
@@ -638,7 +865,7 @@ func (s *JobService) convertToGeneratedCV(aiResult *aimodels.GeneratedCV, userID
 		personalInfo.Location = profile.Location
 	}
 
-	return &models.GeneratedCV{
+	cv := &models.GeneratedCV{
 		JobID:          jobID,
 		UserID:         userID,
 		IsValid:        aiResult.IsValid,
@@ -650,9 +877,13 @@ func (s *JobService) convertToGeneratedCV(aiResult *aimodels.GeneratedCV, userID
 		Skills:         aiResult.Skills,
 		GeneratedAt:    time.Unix(aiResult.GeneratedAt, 0),
 		JobTitle:       aiResult.JobTitle,
+		PromptVersion:  aiResult.PromptVersion,
+		Language:       aiResult.Language,
 		CreatedAt:      now,
 		UpdatedAt:      now,
 	}
+	cv.Localize()
+	return cv
 }
 
 func convertPersonalInfo(ai aimodels.PersonalInfo) models.PersonalInfo {
@@ -719,8 +950,10 @@ func convertCertifications(profileCerts []settingsmodels.Certification) []models
 }
 
 func (s *JobService) formatCVAsHTML(cv *models.GeneratedCV) string {
+	headings := models.CVHeadingsFor(cv.Language)
+
 	var html strings.Builder
-	html.WriteString("<div class='cv-content'>")
+	html.WriteString(fmt.Sprintf("<div class='cv-content' lang='%s'>", models.CVLanguageOrDefault(cv.Language)))
 
 	// Personal Info
 	html.WriteString("<section class='personal-info'>")
@@ -732,16 +965,16 @@ func (s *JobService) formatCVAsHTML(cv *models.GeneratedCV) string {
 	// Skills
 	if len(cv.Skills) > 0 {
 		html.WriteString("<section class='skills'>")
-		html.WriteString("<h2>Skills</h2><p>")
+		html.WriteString(fmt.Sprintf("<h2>%s</h2><p>", headings.Skills))
 		html.WriteString(strings.Join(cv.Skills, ", "))
 		html.WriteString("</p></section>")
 	}
 
 	// Work Experience
 	if len(cv.WorkExperience) > 0 {
-		html.WriteString("<section class='experience'><h2>Work Experience</h2>")
+		html.WriteString(fmt.Sprintf("<section class='experience'><h2>%s</h2>", headings.Experience))
 		for _, exp := range cv.WorkExperience {
-			html.WriteString(fmt.Sprintf("<div><h3>%s at %s</h3>", exp.Title, exp.Company))
+			html.WriteString(fmt.Sprintf("<div><h3>%s</h3>", models.RoleAt(exp.Title, exp.Company, cv.Language)))
 			html.WriteString(fmt.Sprintf("<p>%s - %s</p>", exp.StartDate, exp.EndDate))
 			html.WriteString(fmt.Sprintf("<p>%s</p></div>", exp.Description))
 		}
@@ -750,7 +983,7 @@ func (s *JobService) formatCVAsHTML(cv *models.GeneratedCV) string {
 
 	// Education
 	if len(cv.Education) > 0 {
-		html.WriteString("<section class='education'><h2>Education</h2>")
+		html.WriteString(fmt.Sprintf("<section class='education'><h2>%s</h2>", headings.Education))
 		for _, edu := range cv.Education {
 			html.WriteString(fmt.Sprintf("<div><h3>%s</h3>", edu.Degree))
 			html.WriteString(fmt.Sprintf("<p>%s | %s - %s</p></div>", edu.Institution, edu.StartDate, edu.EndDate))
@@ -760,7 +993,7 @@ func (s *JobService) formatCVAsHTML(cv *models.GeneratedCV) string {
 
 	// Certifications
 	if len(cv.Certifications) > 0 {
-		html.WriteString("<section class='certifications'><h2>Certifications</h2>")
+		html.WriteString(fmt.Sprintf("<section class='certifications'><h2>%s</h2>", headings.Certifications))
 		for _, cert := range cv.Certifications {
 			html.WriteString(fmt.Sprintf("<div><h3>%s</h3>", cert.Name))
 			if cert.IssuingOrg != "" {
@@ -775,3 +1008,358 @@ func (s *JobService) formatCVAsHTML(cv *models.GeneratedCV) string {
 	html.WriteString("</div>")
 	return html.String()
 }
+
+// GenerateInterviewPrep generates an interview preparation pack for a job
+// and saves it as the job's interview prep document. Suggested answers are
+// grounded in the user's work experience, so at least one entry is required.
+func (s *JobService) GenerateInterviewPrep(ctx context.Context, userID, jobID int) (*models.InterviewPrep, error) {
+	userRef := fmt.Sprintf("user_%d", userID)
+
+	s.log.Debug().
+		Str("user_ref", userRef).
+		Int("job_id", jobID).
+		Str("operation", "interview_prep_generation").
+		Msg("Starting interview prep generation")
+
+	aiService, err := s.aiServiceFor(ctx, userID)
+	if err != nil {
+		s.log.Error().Err(err).
+			Str("user_ref", userRef).
+			Int("job_id", jobID).
+			Str("error_type", "ai_service_unavailable").
+			Msg("AI service not available")
+		return nil, err
+	}
+
+	if err := s.checkInjectionReview(ctx, userID, jobID); err != nil {
+		return nil, err
+	}
+
+	if s.settingsService == nil {
+		s.log.Error().
+			Str("user_ref", userRef).
+			Int("job_id", jobID).
+			Str("error_type", "settings_service_unavailable").
+			Msg("Settings service not available")
+		return nil, models.ErrProfileServiceRequired
+	}
+
+	job, err := s.GetJob(ctx, userID, jobID)
+	if err != nil {
+		s.log.Error().Err(err).
+			Str("user_ref", userRef).
+			Int("job_id", jobID).
+			Str("error_type", "job_not_found").
+			Msg("Job not found for interview prep generation")
+		return nil, err
+	}
+
+	profile, err := s.settingsService.GetProfileWithRelated(ctx, userID)
+	if err != nil {
+		s.log.Error().Err(err).
+			Str("user_ref", userRef).
+			Int("job_id", jobID).
+			Str("error_type", "profile_fetch_failed").
+			Msg("Failed to get user profile for interview prep generation")
+		return nil, err
+	}
+
+	if err := s.ValidateProfileForAI(profile); err != nil {
+		s.log.Warn().
+			Str("user_ref", userRef).
+			Int("job_id", jobID).
+			Str("error_type", "profile_incomplete").
+			Msg("Profile incomplete for AI interview prep generation")
+		return nil, err
+	}
+
+	if len(profile.WorkExperience) == 0 {
+		return nil, models.ErrWorkExperienceRequired
+	}
+
+	aiRequest := s.buildAIRequest(job, profile)
+	aiResult, err := aiService.InterviewPrep.GenerateInterviewPrep(ctxutil.WithUserID(ctx, userID), aiRequest)
+	if err != nil {
+		s.log.Error().Err(err).
+			Str("user_ref", userRef).
+			Int("job_id", jobID).
+			Str("error_type", "ai_generation_failed").
+			Msg("Interview prep generation failed")
+		return nil, err
+	}
+
+	result := s.convertToInterviewPrep(aiResult, userID, jobID)
+
+	content, err := json.Marshal(result)
+	if err != nil {
+		return nil, models.WrapError(models.ErrDocumentSaveFailed, err)
+	}
+	if err := s.saveGeneratedDocument(ctx, userID, jobID, documentsmodels.DocumentTypeInterviewPrep, string(content), result.PromptVersion, documentsmodels.GenerationSettings{}); err != nil {
+		return nil, err
+	}
+
+	s.log.Info().
+		Str("user_ref", userRef).
+		Int("job_id", jobID).
+		Str("operation", "interview_prep_generation").
+		Int("question_count", len(result.Questions)).
+		Bool("success", true).
+		Msg("Interview prep generation completed")
+
+	return result, nil
+}
+
+// GetInterviewPrep returns the saved interview preparation pack for a job, or
+// nil when none has been generated.
+func (s *JobService) GetInterviewPrep(ctx context.Context, userID, jobID int) (*models.InterviewPrep, error) {
+	if s.documentService == nil {
+		return nil, nil
+	}
+
+	doc, err := s.documentService.GetDocumentByJobAndType(ctx, userID, jobID, documentsmodels.DocumentTypeInterviewPrep)
+	if err != nil {
+		if errors.Is(err, documentsmodels.ErrDocumentNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var prep models.InterviewPrep
+	if err := json.Unmarshal([]byte(doc.Content), &prep); err != nil {
+		return nil, fmt.Errorf("failed to decode interview prep document: %w", err)
+	}
+	return &prep, nil
+}
+
+// convertToInterviewPrep converts AI interview prep result to job domain model.
+func (s *JobService) convertToInterviewPrep(aiResult *aimodels.InterviewPrep, userID, jobID int) *models.InterviewPrep {
+	questions := make([]models.InterviewQuestion, len(aiResult.Questions))
+	for i, q := range aiResult.Questions {
+		questions[i] = models.InterviewQuestion{
+			Question:   q.Question,
+			Reason:     q.Reason,
+			Experience: q.Experience,
+			Answer: models.STARAnswer{
+				Situation: q.Answer.Situation,
+				Task:      q.Answer.Task,
+				Action:    q.Answer.Action,
+				Result:    q.Answer.Result,
+			},
+		}
+	}
+
+	return &models.InterviewPrep{
+		JobID:          jobID,
+		UserID:         userID,
+		Questions:      questions,
+		QuestionsToAsk: aiResult.QuestionsToAsk,
+		GapsToRehearse: aiResult.GapsToRehearse,
+		PromptVersion:  aiResult.PromptVersion,
+		GeneratedAt:    time.Now().UTC(),
+	}
+}
+
+// maxEmailNoteLength limits the note a user can add to an email request.
+const maxEmailNoteLength = 1000
+
+// GenerateEmail writes an application email of the given kind and tone for a
+// job and saves it to the job's email history. An empty kind is chosen from
+// the job's status and an empty tone defaults to professional. The saved
+// cover letter, if any, is passed along so the email doesn't repeat it.
+func (s *JobService) GenerateEmail(ctx context.Context, userID, jobID int, kind, tone, note string) (*models.JobEmail, error) {
+	userRef := fmt.Sprintf("user_%d", userID)
+
+	s.log.Debug().
+		Str("user_ref", userRef).
+		Int("job_id", jobID).
+		Str("operation", "email_generation").
+		Msg("Starting email generation")
+
+	note = strings.TrimSpace(note)
+	if len(note) > maxEmailNoteLength {
+		return nil, models.ErrEmailNoteTooLong
+	}
+
+	emailTone := aimodels.EmailTone(tone)
+	if tone == "" {
+		emailTone = aimodels.EmailToneProfessional
+	}
+	if !emailTone.IsValid() {
+		return nil, models.ErrInvalidEmailTone
+	}
+
+	if kind != "" && !aimodels.EmailKind(kind).IsValid() {
+		return nil, models.ErrInvalidEmailKind
+	}
+
+	aiService, err := s.aiServiceFor(ctx, userID)
+	if err != nil {
+		s.log.Error().Err(err).
+			Str("user_ref", userRef).
+			Int("job_id", jobID).
+			Str("error_type", "ai_service_unavailable").
+			Msg("AI service not available")
+		return nil, err
+	}
+
+	if err := s.checkInjectionReview(ctx, userID, jobID); err != nil {
+		return nil, err
+	}
+
+	if s.settingsService == nil {
+		s.log.Error().
+			Str("user_ref", userRef).
+			Int("job_id", jobID).
+			Str("error_type", "settings_service_unavailable").
+			Msg("Settings service not available")
+		return nil, models.ErrProfileServiceRequired
+	}
+
+	job, err := s.GetJob(ctx, userID, jobID)
+	if err != nil {
+		s.log.Error().Err(err).
+			Str("user_ref", userRef).
+			Int("job_id", jobID).
+			Str("error_type", "job_not_found").
+			Msg("Job not found for email generation")
+		return nil, err
+	}
+
+	emailKind := aimodels.EmailKind(kind)
+	if kind == "" {
+		emailKind = defaultEmailKind(job.Status)
+	}
+
+	profile, err := s.settingsService.GetProfileWithRelated(ctx, userID)
+	if err != nil {
+		s.log.Error().Err(err).
+			Str("user_ref", userRef).
+			Int("job_id", jobID).
+			Str("error_type", "profile_fetch_failed").
+			Msg("Failed to get user profile for email generation")
+		return nil, err
+	}
+
+	if err := s.ValidateProfileForAI(profile); err != nil {
+		s.log.Warn().
+			Str("user_ref", userRef).
+			Int("job_id", jobID).
+			Str("error_type", "profile_incomplete").
+			Msg("Profile incomplete for AI email generation")
+		return nil, err
+	}
+
+	aiRequest := s.buildAIRequest(job, profile)
+	aiRequest.Email = &aimodels.EmailDetails{
+		Kind:        emailKind,
+		Tone:        emailTone,
+		CompanyName: job.Company.Name,
+		JobTitle:    job.Title,
+		JobStatus:   job.StageName(),
+		CoverLetter: s.savedCoverLetter(ctx, userID, jobID),
+		Note:        note,
+	}
+
+	aiResult, err := aiService.Email.GenerateEmail(ctxutil.WithUserID(ctx, userID), aiRequest)
+	if err != nil {
+		s.log.Error().Err(err).
+			Str("user_ref", userRef).
+			Int("job_id", jobID).
+			Str("error_type", "ai_generation_failed").
+			Msg("Email generation failed")
+		return nil, err
+	}
+
+	result := &models.JobEmail{
+		JobID:         jobID,
+		UserID:        userID,
+		Kind:          string(emailKind),
+		Tone:          string(emailTone),
+		JobStatus:     job.StageName(),
+		Note:          note,
+		Subject:       aiResult.Subject,
+		Body:          aiResult.Body,
+		PromptVersion: aiResult.PromptVersion,
+		GeneratedAt:   time.Now().UTC(),
+	}
+
+	content, err := json.Marshal(result)
+	if err != nil {
+		return nil, models.WrapError(models.ErrDocumentSaveFailed, err)
+	}
+	if err := s.saveGeneratedDocument(ctx, userID, jobID, documentsmodels.DocumentTypeEmail, string(content), result.PromptVersion, documentsmodels.GenerationSettings{}); err != nil {
+		return nil, err
+	}
+
+	s.log.Info().
+		Str("user_ref", userRef).
+		Int("job_id", jobID).
+		Str("operation", "email_generation").
+		Str("email_kind", result.Kind).
+		Bool("success", true).
+		Msg("Email generation completed")
+
+	return result, nil
+}
+
+// GetEmailHistory returns the emails generated for a job, newest first.
+func (s *JobService) GetEmailHistory(ctx context.Context, userID, jobID int) ([]*models.JobEmail, error) {
+	if s.documentService == nil {
+		return nil, nil
+	}
+
+	docs, err := s.documentService.GetDocumentHistory(ctx, userID, jobID, documentsmodels.DocumentTypeEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	emails := make([]*models.JobEmail, 0, len(docs))
+	for _, doc := range docs {
+		var email models.JobEmail
+		if err := json.Unmarshal([]byte(doc.Content), &email); err != nil {
+			return nil, fmt.Errorf("failed to decode email document: %w", err)
+		}
+		email.ID = doc.ID
+		emails = append(emails, &email)
+	}
+	return emails, nil
+}
+
+// savedCoverLetter returns the text of the job's saved cover letter, or an
+// empty string when there is none or it cannot be read.
+func (s *JobService) savedCoverLetter(ctx context.Context, userID, jobID int) string {
+	if s.documentService == nil {
+		return ""
+	}
+
+	doc, err := s.documentService.GetDocumentByJobAndType(ctx, userID, jobID, documentsmodels.DocumentTypeCoverLetter)
+	if err != nil {
+		if !errors.Is(err, documentsmodels.ErrDocumentNotFound) {
+			s.log.Warn().Err(err).
+				Str("user_ref", fmt.Sprintf("user_%d", userID)).
+				Int("job_id", jobID).
+				Msg("Could not read saved cover letter for email generation")
+		}
+		return ""
+	}
+
+	var coverLetter struct {
+		Content string `json:"content"`
+	}
+	if err := json.Unmarshal([]byte(doc.Content), &coverLetter); err != nil {
+		return doc.Content
+	}
+	return coverLetter.Content
+}
+
+// defaultEmailKind picks the email that usually fits the job's status.
+func defaultEmailKind(status models.JobStatus) aimodels.EmailKind {
+	switch status {
+	case models.INTERVIEWING:
+		return aimodels.EmailKindThankYou
+	case models.OFFER_RECEIVED:
+		return aimodels.EmailKindNegotiation
+	default:
+		return aimodels.EmailKindFollowUp
+	}
+}
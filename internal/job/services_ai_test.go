@@ -9,6 +9,7 @@ import (
 	"github.com/benidevo/vega/internal/ai"
 	aimodels "github.com/benidevo/vega/internal/ai/models"
 	"github.com/benidevo/vega/internal/config"
+	documentsmodels "github.com/benidevo/vega/internal/documents/models"
 	"github.com/benidevo/vega/internal/job/models"
 	settingsmodels "github.com/benidevo/vega/internal/settings/models"
 	"github.com/rs/zerolog"
@@ -140,19 +141,167 @@ func TestJobService_AnalyzeJobMatch_NoSettingsService(t *testing.T) {
 	assert.Nil(t, result)
 }
 
+type stubUserAIServices struct {
+	service *ai.AIService
+	err     error
+}
+
+func (s *stubUserAIServices) ForUser(ctx context.Context, userID int) (*ai.AIService, bool, error) {
+	return s.service, s.service != nil, s.err
+}
+
+func TestJobService_AIServiceFor(t *testing.T) {
+	serverService := &ai.AIService{}
+	userService := &ai.AIService{}
+
+	tests := []struct {
+		name          string
+		serverService *ai.AIService
+		userServices  UserAIServices
+		expected      *ai.AIService
+		expectedError error
+	}{
+		{
+			name:          "should_use_server_service_without_user_services",
+			serverService: serverService,
+			expected:      serverService,
+		},
+		{
+			name:          "should_prefer_user_key",
+			serverService: serverService,
+			userServices:  &stubUserAIServices{service: userService},
+			expected:      userService,
+		},
+		{
+			name:          "should_fall_back_when_user_has_no_key",
+			serverService: serverService,
+			userServices:  &stubUserAIServices{},
+			expected:      serverService,
+		},
+		{
+			name:         "should_use_user_key_when_server_ai_is_disabled",
+			userServices: &stubUserAIServices{service: userService},
+			expected:     userService,
+		},
+		{
+			name:          "should_fail_without_any_service",
+			userServices:  &stubUserAIServices{},
+			expectedError: models.ErrAIServiceUnavailable,
+		},
+		{
+			name:          "should_not_fall_back_when_user_key_fails",
+			serverService: serverService,
+			userServices:  &stubUserAIServices{err: aimodels.ErrProviderInitFailed},
+			expectedError: aimodels.ErrProviderInitFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewJobService(&MockJobRepository{}, tt.serverService, nil, nil, &config.Settings{})
+			if tt.userServices != nil {
+				service.SetUserAIServices(tt.userServices)
+			}
+
+			actual, err := service.aiServiceFor(context.Background(), 1)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, actual)
+			} else {
+				assert.NoError(t, err)
+				assert.Same(t, tt.expected, actual)
+			}
+		})
+	}
+}
+
 func TestJobService_GenerateCoverLetter_NoAIService(t *testing.T) {
 	mockJobRepo := &MockJobRepository{}
 	cfg := &config.Settings{}
 
 	service := NewJobService(mockJobRepo, nil, nil, nil, cfg)
 
-	result, err := service.GenerateCoverLetter(context.Background(), 1, 1)
+	result, err := service.GenerateCoverLetter(context.Background(), 1, 1, models.CoverLetterOptions{})
 
 	assert.Error(t, err)
 	assert.Equal(t, models.ErrAIServiceUnavailable, err)
 	assert.Nil(t, result)
 }
 
+func TestJobService_GenerateCoverLetter_InvalidOptions(t *testing.T) {
+	service := NewJobService(&MockJobRepository{}, nil, nil, nil, &config.Settings{})
+
+	tests := []struct {
+		name    string
+		options models.CoverLetterOptions
+		want    error
+	}{
+		{"unknown tone", models.CoverLetterOptions{Tone: "sarcastic"}, models.ErrInvalidLetterTone},
+		{"unknown length", models.CoverLetterOptions{Length: "epic"}, models.ErrInvalidLetterLength},
+		{"unsupported language", models.CoverLetterOptions{Language: "xx"}, models.ErrInvalidDocumentLanguage},
+	}
+
+	for _, tt := range tests {
+		_, err := service.GenerateCoverLetter(context.Background(), 1, 1, tt.options)
+		assert.ErrorIs(t, err, tt.want, tt.name)
+	}
+}
+
+func TestResolveCoverLetterOptions(t *testing.T) {
+	profile := &settingsmodels.Profile{LetterTone: "formal", LetterLength: "long", LetterLanguage: "fr"}
+
+	t.Run("should_prefer_chosen_options", func(t *testing.T) {
+		chosen := models.CoverLetterOptions{Tone: "warm", Length: "short", Language: "de"}
+		last := documentsmodels.GenerationSettings{Tone: "concise", Length: "standard", Language: "es"}
+
+		assert.Equal(t, chosen, resolveCoverLetterOptions(chosen, last, profile))
+	})
+
+	t.Run("should_reuse_last_letter_settings_before_profile_defaults", func(t *testing.T) {
+		last := documentsmodels.GenerationSettings{Tone: "concise", Language: "es"}
+
+		got := resolveCoverLetterOptions(models.CoverLetterOptions{}, last, profile)
+
+		assert.Equal(t, models.CoverLetterOptions{Tone: "concise", Length: "long", Language: "es"}, got)
+	})
+
+	t.Run("should_skip_unsupported_stored_values", func(t *testing.T) {
+		last := documentsmodels.GenerationSettings{Tone: "sarcastic", Language: "xx"}
+
+		got := resolveCoverLetterOptions(models.CoverLetterOptions{}, last, nil)
+
+		assert.Equal(t, models.CoverLetterOptions{}, got)
+	})
+}
+
+func TestDocumentLanguage(t *testing.T) {
+	german := "Wir suchen eine Entwicklerin mit Erfahrung in Go. Sie arbeiten mit unserem Team an der Plattform."
+
+	assert.Equal(t, "fr", documentLanguage("fr", german))
+	assert.Equal(t, "de", documentLanguage("", german))
+	assert.Equal(t, "de", documentLanguage("xx", german))
+	assert.Equal(t, "en", documentLanguage("", "Go developer"))
+}
+
+func TestJobService_ConvertToGeneratedCV_LocalizesDates(t *testing.T) {
+	service := NewJobService(&MockJobRepository{}, nil, nil, nil, &config.Settings{})
+	aiResult := &aimodels.GeneratedCV{
+		CVParsingResult: aimodels.CVParsingResult{
+			IsValid:        true,
+			WorkExperience: []aimodels.WorkExperience{{Company: "Acme", Title: "Entwicklerin", StartDate: "March 2021", EndDate: "Present"}},
+			Language:       "de",
+		},
+	}
+
+	cv := service.convertToGeneratedCV(aiResult, 1, 2, &settingsmodels.Profile{})
+
+	assert.Equal(t, "de", cv.Language)
+	assert.Equal(t, "März 2021", cv.WorkExperience[0].StartDate)
+	assert.Equal(t, "heute", cv.WorkExperience[0].EndDate)
+	assert.Contains(t, service.formatCVAsHTML(cv), "<h2>Berufserfahrung</h2>")
+}
+
 func TestJobService_ValidateProfileForAI(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -387,26 +536,168 @@ func TestJobService_GenerateCV_NoAIService(t *testing.T) {
 
 	service := NewJobService(mockJobRepo, nil, nil, nil, cfg)
 
-	result, err := service.GenerateCV(context.Background(), 1, 1)
+	result, err := service.GenerateCV(context.Background(), 1, 1, "")
 
 	assert.Error(t, err)
 	assert.Equal(t, models.ErrAIServiceUnavailable, err)
 	assert.Nil(t, result)
 }
 
+func TestJobService_GenerateCV_InvalidLanguage(t *testing.T) {
+	service := NewJobService(&MockJobRepository{}, nil, nil, nil, &config.Settings{})
+
+	result, err := service.GenerateCV(context.Background(), 1, 1, "xx")
+
+	assert.ErrorIs(t, err, models.ErrInvalidDocumentLanguage)
+	assert.Nil(t, result)
+}
+
 func TestJobService_GenerateCV_NoSettingsService(t *testing.T) {
 	mockJobRepo := &MockJobRepository{}
 	cfg := &config.Settings{}
 
 	service := NewJobService(mockJobRepo, &ai.AIService{}, nil, nil, cfg)
 
-	result, err := service.GenerateCV(context.Background(), 1, 1)
+	result, err := service.GenerateCV(context.Background(), 1, 1, "")
+
+	assert.Error(t, err)
+	assert.Equal(t, models.ErrProfileServiceRequired, err)
+	assert.Nil(t, result)
+}
+
+func TestJobService_GenerateInterviewPrep_NoAIService(t *testing.T) {
+	mockJobRepo := &MockJobRepository{}
+	cfg := &config.Settings{}
+
+	service := NewJobService(mockJobRepo, nil, nil, nil, cfg)
+
+	result, err := service.GenerateInterviewPrep(context.Background(), 1, 1)
+
+	assert.Error(t, err)
+	assert.Equal(t, models.ErrAIServiceUnavailable, err)
+	assert.Nil(t, result)
+}
+
+func TestJobService_GenerateInterviewPrep_NoSettingsService(t *testing.T) {
+	mockJobRepo := &MockJobRepository{}
+	cfg := &config.Settings{}
+
+	service := NewJobService(mockJobRepo, &ai.AIService{}, nil, nil, cfg)
+
+	result, err := service.GenerateInterviewPrep(context.Background(), 1, 1)
 
 	assert.Error(t, err)
 	assert.Equal(t, models.ErrProfileServiceRequired, err)
 	assert.Nil(t, result)
 }
 
+func TestJobService_GetInterviewPrep_NoDocumentService(t *testing.T) {
+	mockJobRepo := &MockJobRepository{}
+	cfg := &config.Settings{}
+	service := NewJobService(mockJobRepo, nil, nil, nil, cfg)
+
+	result, err := service.GetInterviewPrep(context.Background(), 1, 1)
+
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func TestJobService_convertToInterviewPrep(t *testing.T) {
+	mockJobRepo := &MockJobRepository{}
+	cfg := &config.Settings{}
+	service := NewJobService(mockJobRepo, nil, nil, nil, cfg)
+
+	aiResult := &aimodels.InterviewPrep{
+		Questions: []aimodels.InterviewQuestion{
+			{
+				Question:   "Tell us about a migration you led.",
+				Reason:     "The role owns a platform migration.",
+				Experience: "Senior Engineer at Acme",
+				Answer: aimodels.STARAnswer{
+					Situation: "Legacy monolith",
+					Task:      "Split billing out",
+					Action:    "Introduced a strangler service",
+					Result:    "Zero-downtime cutover",
+				},
+			},
+		},
+		QuestionsToAsk: []string{"How is on-call shared?"},
+		GapsToRehearse: []string{"Kubernetes"},
+		PromptVersion:  "builtin",
+	}
+
+	result := service.convertToInterviewPrep(aiResult, 1, 2)
+
+	assert.Equal(t, 2, result.JobID)
+	assert.Equal(t, 1, result.UserID)
+	assert.Len(t, result.Questions, 1)
+	assert.Equal(t, "Tell us about a migration you led.", result.Questions[0].Question)
+	assert.Equal(t, "Senior Engineer at Acme", result.Questions[0].Experience)
+	assert.Equal(t, "Zero-downtime cutover", result.Questions[0].Answer.Result)
+	assert.Equal(t, []string{"How is on-call shared?"}, result.QuestionsToAsk)
+	assert.Equal(t, []string{"Kubernetes"}, result.GapsToRehearse)
+	assert.Equal(t, "builtin", result.PromptVersion)
+	assert.NotZero(t, result.GeneratedAt)
+}
+
+func TestJobService_GenerateEmail_NoAIService(t *testing.T) {
+	mockJobRepo := &MockJobRepository{}
+	cfg := &config.Settings{}
+
+	service := NewJobService(mockJobRepo, nil, nil, nil, cfg)
+
+	result, err := service.GenerateEmail(context.Background(), 1, 1, "", "", "")
+
+	assert.Error(t, err)
+	assert.Equal(t, models.ErrAIServiceUnavailable, err)
+	assert.Nil(t, result)
+}
+
+func TestJobService_GenerateEmail_InvalidRequest(t *testing.T) {
+	mockJobRepo := &MockJobRepository{}
+	cfg := &config.Settings{}
+	service := NewJobService(mockJobRepo, &ai.AIService{}, nil, nil, cfg)
+
+	tests := []struct {
+		name        string
+		kind        string
+		tone        string
+		note        string
+		expectedErr error
+	}{
+		{name: "unknown_kind", kind: "cold_outreach", expectedErr: models.ErrInvalidEmailKind},
+		{name: "unknown_tone", kind: "follow_up", tone: "sarcastic", expectedErr: models.ErrInvalidEmailTone},
+		{name: "note_too_long", note: strings.Repeat("a", maxEmailNoteLength+1), expectedErr: models.ErrEmailNoteTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.GenerateEmail(context.Background(), 1, 1, tt.kind, tt.tone, tt.note)
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Nil(t, result)
+		})
+	}
+}
+
+func TestJobService_GetEmailHistory_NoDocumentService(t *testing.T) {
+	mockJobRepo := &MockJobRepository{}
+	cfg := &config.Settings{}
+	service := NewJobService(mockJobRepo, nil, nil, nil, cfg)
+
+	result, err := service.GetEmailHistory(context.Background(), 1, 1)
+
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func TestDefaultEmailKind(t *testing.T) {
+	assert.Equal(t, aimodels.EmailKindFollowUp, defaultEmailKind(models.APPLIED))
+	assert.Equal(t, aimodels.EmailKindThankYou, defaultEmailKind(models.INTERVIEWING))
+	assert.Equal(t, aimodels.EmailKindNegotiation, defaultEmailKind(models.OFFER_RECEIVED))
+	assert.Equal(t, aimodels.EmailKindFollowUp, defaultEmailKind(models.INTERESTED))
+}
+
 func TestJobService_calculateTotalExperience(t *testing.T) {
 	mockJobRepo := &MockJobRepository{}
 	cfg := &config.Settings{}
@@ -0,0 +1,123 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	aihelpers "github.com/benidevo/vega/internal/ai/helpers"
+	aimodels "github.com/benidevo/vega/internal/ai/models"
+	ctxutil "github.com/benidevo/vega/internal/common/context"
+	"github.com/benidevo/vega/internal/job/models"
+)
+
+// maxPostingTextLength limits the pasted posting or page HTML accepted for
+// extraction. Saved job pages are mostly markup, so this is far more than the
+// text the model sees.
+const maxPostingTextLength = 500_000
+
+// Limits on the skills kept from an extraction, matching job validation.
+const (
+	maxDraftSkills      = 50
+	maxDraftSkillLength = 100
+)
+
+// ExtractJobDraft asks the AI to extract the details of a job from the text
+// or HTML of a job posting. The draft is not saved; it is used to prefill a
+// new job, with the cleaned posting text as its description.
+func (s *JobService) ExtractJobDraft(ctx context.Context, userID int, rawText string) (*models.JobDraft, error) {
+	userRef := fmt.Sprintf("user_%d", userID)
+
+	if strings.TrimSpace(rawText) == "" {
+		return nil, models.ErrPostingTextRequired
+	}
+	if len(rawText) > maxPostingTextLength {
+		return nil, models.ErrPostingTextTooLong
+	}
+
+	s.log.Debug().
+		Str("user_ref", userRef).
+		Str("operation", "job_extraction").
+		Msg("Starting job extraction")
+
+	aiService, err := s.aiServiceFor(ctx, userID)
+	if err != nil {
+		s.log.Error().Err(err).
+			Str("user_ref", userRef).
+			Str("error_type", "ai_service_unavailable").
+			Msg("AI service not available")
+		return nil, err
+	}
+	if aiService.JobExtractor == nil {
+		return nil, models.ErrAIServiceUnavailable
+	}
+
+	result, err := aiService.JobExtractor.ExtractJob(ctxutil.WithUserID(ctx, userID), rawText)
+	if err != nil {
+		s.log.Error().Err(err).
+			Str("user_ref", userRef).
+			Str("error_type", "ai_extraction_failed").
+			Msg("Job extraction failed")
+		if errors.Is(err, aimodels.ErrNotJobPosting) {
+			return nil, models.WrapError(models.ErrNotJobPosting, err)
+		}
+		return nil, models.WrapError(models.ErrJobExtractionFailed, err)
+	}
+
+	draft := s.convertToJobDraft(result, aihelpers.PostingText(rawText))
+
+	s.log.Info().
+		Str("user_ref", userRef).
+		Str("operation", "job_extraction").
+		Int("skill_count", len(draft.RequiredSkills)).
+		Bool("success", true).
+		Msg("Job extraction completed")
+
+	return draft, nil
+}
+
+// convertToJobDraft converts an AI extraction into a job draft, dropping
+// values the job form would reject.
+func (s *JobService) convertToJobDraft(result *aimodels.JobExtraction, description string) *models.JobDraft {
+	confidence := make(map[string]float64, len(result.Confidence))
+	for field, value := range result.Confidence {
+		confidence[field] = value
+	}
+
+	applicationURL := result.ApplicationURL
+	if s.ValidateURL(applicationURL) != nil {
+		applicationURL = ""
+		delete(confidence, aimodels.JobFieldApplicationURL)
+	}
+
+	return &models.JobDraft{
+		Title:          result.Title,
+		Company:        result.Company,
+		Location:       result.Location,
+		Description:    description,
+		JobType:        models.JobTypeFromString(result.JobType),
+		RequiredSkills: draftSkills(result.RequiredSkills),
+		SalaryMin:      result.SalaryMin,
+		SalaryMax:      result.SalaryMax,
+		SalaryCurrency: result.SalaryCurrency,
+		SalaryPeriod:   result.SalaryPeriod,
+		Seniority:      result.Seniority,
+		ApplicationURL: applicationURL,
+		Confidence:     confidence,
+	}
+}
+
+// draftSkills returns the extracted skills that a job can hold.
+func draftSkills(extracted []string) []string {
+	skills := make([]string, 0, len(extracted))
+	for _, skill := range extracted {
+		if len(skills) == maxDraftSkills {
+			break
+		}
+		if skill != "" && len(skill) <= maxDraftSkillLength {
+			skills = append(skills, skill)
+		}
+	}
+	return skills
+}
@@ -0,0 +1,89 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/benidevo/vega/internal/ai"
+	aimodels "github.com/benidevo/vega/internal/ai/models"
+	aitestutil "github.com/benidevo/vega/internal/ai/testutil"
+	"github.com/benidevo/vega/internal/config"
+	"github.com/benidevo/vega/internal/job/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobService_ExtractJobDraft(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should_build_draft_from_extraction", func(t *testing.T) {
+		extraction := aitestutil.NewTestData().ValidJobExtraction()
+		extraction.JobType = "contract"
+		extraction.ApplicationURL = "ftp://webtech.example/apply"
+		provider := &aitestutil.MockProvider{}
+		provider.SetupJobExtractionMock(extraction, nil)
+		service := NewJobService(&MockJobRepository{}, ai.NewAIService(provider), nil, nil, &config.Settings{})
+
+		draft, err := service.ExtractJobDraft(ctx, testUserID, "<h1>Frontend Engineer</h1><p>WebTech, London</p>")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Frontend Engineer", draft.Title)
+		assert.Equal(t, models.CONTRACT, draft.JobType)
+		assert.Equal(t, "Frontend Engineer\n\nWebTech, London", draft.Description)
+		assert.Equal(t, "React, TypeScript, GraphQL", draft.Skills())
+		assert.Empty(t, draft.ApplicationURL, "non-http URLs are dropped")
+		assert.Empty(t, draft.ConfidenceLevel(aimodels.JobFieldApplicationURL))
+		assert.Equal(t, "high", draft.ConfidenceLevel(aimodels.JobFieldTitle))
+		assert.Equal(t, "low", draft.ConfidenceLevel(aimodels.JobFieldSeniority))
+		assert.Equal(t, "Salary: 60,000–75,000 GBP per year\nSeniority: Mid", draft.Notes())
+		provider.AssertExpectations(t)
+	})
+
+	t.Run("should_report_text_that_is_not_a_posting", func(t *testing.T) {
+		provider := &aitestutil.MockProvider{}
+		provider.SetupJobExtractionMock(aimodels.JobExtraction{}, aimodels.WrapError(aimodels.ErrNotJobPosting, errors.New("recipe")))
+		service := NewJobService(&MockJobRepository{}, ai.NewAIService(provider), nil, nil, &config.Settings{})
+
+		_, err := service.ExtractJobDraft(ctx, testUserID, "Mix flour and water")
+
+		assert.ErrorIs(t, err, models.ErrNotJobPosting)
+	})
+
+	t.Run("should_wrap_provider_errors", func(t *testing.T) {
+		provider := &aitestutil.MockProvider{}
+		provider.SetupJobExtractionMock(aimodels.JobExtraction{}, errors.New("timeout"))
+		service := NewJobService(&MockJobRepository{}, ai.NewAIService(provider), nil, nil, &config.Settings{})
+
+		_, err := service.ExtractJobDraft(ctx, testUserID, "Frontend Engineer")
+
+		assert.ErrorIs(t, err, models.ErrJobExtractionFailed)
+	})
+
+	t.Run("should_validate_posting_text", func(t *testing.T) {
+		service := NewJobService(&MockJobRepository{}, nil, nil, nil, &config.Settings{})
+
+		_, err := service.ExtractJobDraft(ctx, testUserID, "  ")
+		assert.ErrorIs(t, err, models.ErrPostingTextRequired)
+
+		_, err = service.ExtractJobDraft(ctx, testUserID, strings.Repeat("a", maxPostingTextLength+1))
+		assert.ErrorIs(t, err, models.ErrPostingTextTooLong)
+
+		_, err = service.ExtractJobDraft(ctx, testUserID, "Frontend Engineer")
+		assert.ErrorIs(t, err, models.ErrAIServiceUnavailable)
+	})
+}
+
+func TestDraftSkills(t *testing.T) {
+	extracted := []string{"Go", strings.Repeat("x", maxDraftSkillLength+1)}
+	for i := 0; i < maxDraftSkills+5; i++ {
+		extracted = append(extracted, "Skill")
+	}
+
+	skills := draftSkills(extracted)
+
+	assert.Len(t, skills, maxDraftSkills)
+	assert.Equal(t, "Go", skills[0])
+	assert.Equal(t, "Skill", skills[1])
+}
@@ -0,0 +1,166 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	aihelpers "github.com/benidevo/vega/internal/ai/helpers"
+	"github.com/benidevo/vega/internal/ai/security"
+	"github.com/benidevo/vega/internal/job/interfaces"
+	"github.com/benidevo/vega/internal/job/models"
+)
+
+// SetInjectionReviewRepository sets the repository used for job descriptions
+// flagged as likely prompt injections.
+func (s *JobService) SetInjectionReviewRepository(repo interfaces.InjectionReviewRepository) {
+	s.injectionReviewRepo = repo
+}
+
+// ScreenJobDescription checks a job description for instructions aimed at the
+// AI. A description scoring at or above the threshold is flagged for review,
+// which blocks AI features on the job until the user approves it; a
+// description that no longer scores that high clears any earlier flag.
+func (s *JobService) ScreenJobDescription(ctx context.Context, userID int, job *models.Job) (*models.InjectionReview, error) {
+	if s.injectionReviewRepo == nil || job == nil {
+		return nil, nil
+	}
+	return s.screenJobDescription(ctx, userID, job, nil)
+}
+
+// ScreenPosting checks a raw job posting for instructions aimed at the AI
+// before it is extracted. A posting scoring at or above the threshold must not
+// reach the AI, so it comes back as a draft holding only its text as the
+// description, to be saved and flagged for review like any other job.
+func (s *JobService) ScreenPosting(rawText string) (*models.JobDraft, bool) {
+	text := aihelpers.PostingText(rawText)
+	detection := security.NewInjectionDetector().Detect(text)
+	if !detection.Exceeds(security.DefaultInjectionThreshold) {
+		return nil, false
+	}
+
+	s.log.Warn().
+		Float64("score", detection.Score).
+		Int("spans", len(detection.Spans)).
+		Msg("Job posting flagged as a likely prompt injection; skipping extraction")
+
+	return &models.JobDraft{Description: text}, true
+}
+
+// screenJobDescription flags the job description when it scores at or above
+// the threshold. An approval of the previous review is kept as long as the
+// same text is flagged.
+func (s *JobService) screenJobDescription(ctx context.Context, userID int, job *models.Job, previous *models.InjectionReview) (*models.InjectionReview, error) {
+	detection := security.NewInjectionDetector().Detect(job.Description)
+	if !detection.Exceeds(security.DefaultInjectionThreshold) {
+		if err := s.injectionReviewRepo.DeleteInjectionReview(ctx, userID, job.ID); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	review := &models.InjectionReview{
+		JobID:     job.ID,
+		Score:     detection.Score,
+		Spans:     make([]models.InjectionSpan, len(detection.Spans)),
+		FlaggedAt: time.Now().UTC(),
+	}
+	for i, span := range detection.Spans {
+		review.Spans[i] = models.InjectionSpan{
+			Start: span.Start,
+			End:   span.End,
+			Rule:  span.Rule,
+			Text:  span.Text,
+		}
+	}
+	if previous != nil && previous.Approved && sameFlaggedText(previous.Spans, review.Spans) {
+		review.Approved = true
+		review.FlaggedAt = previous.FlaggedAt
+		review.ReviewedAt = previous.ReviewedAt
+	}
+
+	if err := s.injectionReviewRepo.SaveInjectionReview(ctx, userID, review); err != nil {
+		s.log.Error().
+			Int("job_id", job.ID).
+			Err(err).
+			Msg("Failed to flag job description")
+		return nil, err
+	}
+
+	s.log.Warn().
+		Int("job_id", job.ID).
+		Float64("score", review.Score).
+		Int("spans", len(review.Spans)).
+		Msg("Job description flagged as a likely prompt injection")
+
+	return review, nil
+}
+
+// GetInjectionReview returns the review of a job's description, or nil when
+// it has not been flagged.
+func (s *JobService) GetInjectionReview(ctx context.Context, userID, jobID int) (*models.InjectionReview, error) {
+	if s.injectionReviewRepo == nil {
+		return nil, nil
+	}
+	return s.injectionReviewRepo.GetInjectionReview(ctx, userID, jobID)
+}
+
+// ApproveInjectionReview records that the user has reviewed a flagged job
+// description and lets AI features run on it.
+func (s *JobService) ApproveInjectionReview(ctx context.Context, userID, jobID int) error {
+	if s.injectionReviewRepo == nil {
+		return models.ErrReviewStoreRequired
+	}
+
+	if err := s.injectionReviewRepo.ApproveInjectionReview(ctx, userID, jobID); err != nil {
+		return err
+	}
+
+	s.log.Info().Int("job_id", jobID).Msg("Flagged job description approved for AI")
+	return nil
+}
+
+// checkInjectionReview returns ErrJobNeedsReview when the job's description
+// is flagged and has not been approved.
+func (s *JobService) checkInjectionReview(ctx context.Context, userID, jobID int) error {
+	review, err := s.GetInjectionReview(ctx, userID, jobID)
+	if err != nil {
+		s.log.Error().
+			Int("job_id", jobID).
+			Err(err).
+			Msg("Failed to get job description review")
+		return err
+	}
+	if review.BlocksAI() {
+		return models.ErrJobNeedsReview
+	}
+	return nil
+}
+
+// rescreenJobDescription screens an updated job description again when it
+// was flagged before, so that editing out the flagged text clears the flag.
+func (s *JobService) rescreenJobDescription(ctx context.Context, userID int, job *models.Job) {
+	review, err := s.GetInjectionReview(ctx, userID, job.ID)
+	if err != nil || review == nil {
+		return
+	}
+
+	if _, err := s.screenJobDescription(ctx, userID, job, review); err != nil {
+		s.log.Warn().
+			Int("job_id", job.ID).
+			Err(err).
+			Msg("Failed to screen updated job description")
+	}
+}
+
+// sameFlaggedText reports whether two reviews flag the same text
+func sameFlaggedText(a, b []models.InjectionSpan) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Rule != b[i].Rule || a[i].Text != b[i].Text {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,198 @@
+package job
+
+import (
+	"context"
+
+	"github.com/benidevo/vega/internal/job/interfaces"
+	"github.com/benidevo/vega/internal/job/models"
+)
+
+// SetPipelineRepository sets the repository used for the user's pipeline
+// stages.
+func (s *JobService) SetPipelineRepository(repo interfaces.PipelineRepository) {
+	s.pipelineRepo = repo
+}
+
+// GetPipeline returns the user's pipeline stages in order, with the number of
+// jobs in each. Without a pipeline repository every user has the default
+// pipeline.
+func (s *JobService) GetPipeline(ctx context.Context, userID int) (models.Pipeline, error) {
+	if s.pipelineRepo == nil {
+		return models.DefaultPipeline(), nil
+	}
+
+	stages, err := s.pipelineRepo.GetStages(ctx, userID)
+	if err != nil {
+		s.log.Error().Err(err).Msg("Failed to get pipeline")
+		return nil, err
+	}
+	return models.Pipeline(stages), nil
+}
+
+// CreateStage adds a stage to the end of the user's pipeline.
+func (s *JobService) CreateStage(ctx context.Context, userID int, name string, category models.JobStatus) (*models.PipelineStage, error) {
+	if s.pipelineRepo == nil {
+		return nil, models.ErrPipelineStoreRequired
+	}
+
+	stage, err := newStage(name, category)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.pipelineRepo.CreateStage(ctx, userID, stage); err != nil {
+		s.log.Error().Err(err).Msg("Failed to create pipeline stage")
+		return nil, err
+	}
+	return stage, nil
+}
+
+// UpdateStage renames a stage and sets its category. Jobs in the stage count
+// as the new category from then on.
+func (s *JobService) UpdateStage(ctx context.Context, userID, stageID int, name string, category models.JobStatus) error {
+	if s.pipelineRepo == nil {
+		return models.ErrPipelineStoreRequired
+	}
+
+	stage, err := newStage(name, category)
+	if err != nil {
+		return err
+	}
+	stage.ID = stageID
+
+	if err := s.pipelineRepo.UpdateStage(ctx, userID, stage); err != nil {
+		s.log.Error().
+			Int("stage_id", stageID).
+			Err(err).
+			Msg("Failed to update pipeline stage")
+		return err
+	}
+	return nil
+}
+
+// MoveStage moves a stage one place up the pipeline, or down for a positive
+// offset. Moving the first stage up or the last one down changes nothing.
+func (s *JobService) MoveStage(ctx context.Context, userID, stageID, offset int) error {
+	if s.pipelineRepo == nil {
+		return models.ErrPipelineStoreRequired
+	}
+
+	pipeline, err := s.GetPipeline(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	index := -1
+	ids := make([]int, len(pipeline))
+	for i, stage := range pipeline {
+		ids[i] = stage.ID
+		if stage.ID == stageID {
+			index = i
+		}
+	}
+	if index < 0 {
+		return models.ErrStageNotFound
+	}
+
+	target := index - 1
+	if offset > 0 {
+		target = index + 1
+	}
+	if target < 0 || target >= len(ids) {
+		return nil
+	}
+	ids[index], ids[target] = ids[target], ids[index]
+
+	if err := s.pipelineRepo.SetStageOrder(ctx, userID, ids); err != nil {
+		s.log.Error().
+			Int("stage_id", stageID).
+			Err(err).
+			Msg("Failed to reorder pipeline")
+		return err
+	}
+	return nil
+}
+
+// DeleteStage removes a stage from the user's pipeline, moving its jobs to
+// the replacement stage.
+func (s *JobService) DeleteStage(ctx context.Context, userID, stageID, replacementID int) error {
+	if s.pipelineRepo == nil {
+		return models.ErrPipelineStoreRequired
+	}
+
+	if err := s.pipelineRepo.DeleteStage(ctx, userID, stageID, replacementID); err != nil {
+		s.log.Error().
+			Int("stage_id", stageID).
+			Err(err).
+			Msg("Failed to delete pipeline stage")
+		return err
+	}
+	return nil
+}
+
+// LoadJobStages sets the pipeline stage of the given jobs. A failure leaves
+// the jobs without stages, shown by their status, rather than failing the
+// page showing them.
+func (s *JobService) LoadJobStages(ctx context.Context, userID int, jobs ...*models.Job) {
+	if s.pipelineRepo == nil || len(jobs) == 0 {
+		return
+	}
+
+	ids := make([]int, len(jobs))
+	for i, job := range jobs {
+		ids[i] = job.ID
+	}
+
+	stageIDs, err := s.pipelineRepo.GetJobStages(ctx, userID, ids)
+	if err != nil {
+		s.log.Warn().Err(err).Msg("Failed to load job stages")
+		return
+	}
+	pipeline, err := s.pipelineRepo.GetStages(ctx, userID)
+	if err != nil {
+		s.log.Warn().Err(err).Msg("Failed to load job stages")
+		return
+	}
+
+	for _, job := range jobs {
+		job.Stage = models.Pipeline(pipeline).Stage(stageIDs[job.ID])
+	}
+}
+
+// newStage validates the name and category of a stage
+func newStage(name string, category models.JobStatus) (*models.PipelineStage, error) {
+	name, err := models.NormalizeStageName(name)
+	if err != nil {
+		return nil, err
+	}
+	if category < models.INTERESTED || category > models.NOT_INTERESTED {
+		return nil, models.ErrInvalidJobStatus
+	}
+	return &models.PipelineStage{Name: name, Category: category}, nil
+}
+
+// setJobStage checks the stage of a job being saved against the user's
+// pipeline and sets the job's status to the stage's category. A job given a
+// stage must be in one of the user's own stages; a job given only a status
+// goes in the first stage of that category.
+func (s *JobService) setJobStage(ctx context.Context, userID int, job *models.Job) error {
+	pipeline, err := s.GetPipeline(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	var stage *models.PipelineStage
+	if job.Stage != nil && job.Stage.ID > 0 {
+		if stage = pipeline.Stage(job.Stage.ID); stage == nil {
+			return models.ErrStageNotFound
+		}
+	} else if stage = pipeline.FirstInCategory(job.Status); stage == nil {
+		return models.ErrInvalidJobStatus
+	}
+
+	job.Status = stage.Category
+	if stage.ID > 0 {
+		job.Stage = stage
+	}
+	return nil
+}
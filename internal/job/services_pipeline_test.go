@@ -0,0 +1,212 @@
+package job
+
+import (
+	"context"
+	"testing"
+
+	"github.com/benidevo/vega/internal/ai"
+	"github.com/benidevo/vega/internal/config"
+	"github.com/benidevo/vega/internal/job/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryPipelineRepository keeps a pipeline and the stages of jobs in memory
+type memoryPipelineRepository struct {
+	stages    []*models.PipelineStage
+	jobStages map[int]int
+}
+
+func newMemoryPipelineRepository() *memoryPipelineRepository {
+	stages := models.DefaultPipeline()
+	for i, stage := range stages {
+		stage.ID = i + 1
+	}
+	stages = append(stages, &models.PipelineStage{ID: 7, Name: "Phone Screen", Position: 7, Category: models.INTERVIEWING})
+	return &memoryPipelineRepository{stages: stages, jobStages: map[int]int{}}
+}
+
+func (r *memoryPipelineRepository) GetStages(ctx context.Context, userID int) ([]*models.PipelineStage, error) {
+	return r.stages, nil
+}
+
+func (r *memoryPipelineRepository) GetJobStages(ctx context.Context, userID int, jobIDs []int) (map[int]int, error) {
+	stages := map[int]int{}
+	for _, id := range jobIDs {
+		if stageID, ok := r.jobStages[id]; ok {
+			stages[id] = stageID
+		}
+	}
+	return stages, nil
+}
+
+func (r *memoryPipelineRepository) SetJobStage(ctx context.Context, userID, jobID, stageID int) error {
+	if models.Pipeline(r.stages).Stage(stageID) == nil {
+		return models.ErrStageNotFound
+	}
+	r.jobStages[jobID] = stageID
+	return nil
+}
+
+func (r *memoryPipelineRepository) CreateStage(ctx context.Context, userID int, stage *models.PipelineStage) error {
+	stage.ID = len(r.stages) + 1
+	r.stages = append(r.stages, stage)
+	return nil
+}
+
+func (r *memoryPipelineRepository) UpdateStage(ctx context.Context, userID int, stage *models.PipelineStage) error {
+	return nil
+}
+
+func (r *memoryPipelineRepository) SetStageOrder(ctx context.Context, userID int, stageIDs []int) error {
+	ordered := make([]*models.PipelineStage, len(stageIDs))
+	for i, id := range stageIDs {
+		ordered[i] = models.Pipeline(r.stages).Stage(id)
+	}
+	r.stages = ordered
+	return nil
+}
+
+func (r *memoryPipelineRepository) DeleteStage(ctx context.Context, userID, stageID, replacementID int) error {
+	return nil
+}
+
+func stageNames(pipeline models.Pipeline) []string {
+	names := make([]string, len(pipeline))
+	for i, stage := range pipeline {
+		names[i] = stage.Name
+	}
+	return names
+}
+
+func TestJobService_MoveStage(t *testing.T) {
+	ctx := context.Background()
+	setup := func() (*JobService, *memoryPipelineRepository) {
+		repo := newMemoryPipelineRepository()
+		service := NewJobService(&MockJobRepository{}, &ai.AIService{}, nil, nil, &config.Settings{})
+		service.SetPipelineRepository(repo)
+		return service, repo
+	}
+
+	t.Run("swaps the stage with the one above", func(t *testing.T) {
+		service, repo := setup()
+
+		require.NoError(t, service.MoveStage(ctx, 1, 7, -1))
+
+		assert.Equal(t, []string{
+			"Interested", "Applied", "Interviewing", "Offer Received", "Rejected", "Phone Screen", "Not Interested",
+		}, stageNames(repo.stages))
+	})
+
+	t.Run("leaves the last stage in place when moved down", func(t *testing.T) {
+		service, repo := setup()
+
+		require.NoError(t, service.MoveStage(ctx, 1, 7, 1))
+
+		assert.Equal(t, "Phone Screen", repo.stages[6].Name)
+	})
+
+	t.Run("rejects an unknown stage", func(t *testing.T) {
+		service, _ := setup()
+
+		assert.ErrorIs(t, service.MoveStage(ctx, 1, 42, 1), models.ErrStageNotFound)
+	})
+}
+
+func TestJobService_CreateStage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("needs a pipeline store", func(t *testing.T) {
+		service := NewJobService(&MockJobRepository{}, &ai.AIService{}, nil, nil, &config.Settings{})
+
+		_, err := service.CreateStage(ctx, 1, "Onsite", models.INTERVIEWING)
+		assert.ErrorIs(t, err, models.ErrPipelineStoreRequired)
+	})
+
+	t.Run("validates the stage", func(t *testing.T) {
+		service := NewJobService(&MockJobRepository{}, &ai.AIService{}, nil, nil, &config.Settings{})
+		service.SetPipelineRepository(newMemoryPipelineRepository())
+
+		_, err := service.CreateStage(ctx, 1, "  ", models.INTERVIEWING)
+		assert.ErrorIs(t, err, models.ErrStageNameRequired)
+
+		_, err = service.CreateStage(ctx, 1, "Onsite", models.JobStatus(9))
+		assert.ErrorIs(t, err, models.ErrInvalidJobStatus)
+	})
+
+	t.Run("saves the normalized name", func(t *testing.T) {
+		service := NewJobService(&MockJobRepository{}, &ai.AIService{}, nil, nil, &config.Settings{})
+		service.SetPipelineRepository(newMemoryPipelineRepository())
+
+		stage, err := service.CreateStage(ctx, 1, " Reference   check ", models.OFFER_RECEIVED)
+		require.NoError(t, err)
+		assert.Equal(t, "Reference check", stage.Name)
+		assert.Equal(t, models.OFFER_RECEIVED, stage.Category)
+	})
+}
+
+func TestJobService_ChangeJobStage_WithPipeline(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryPipelineRepository()
+	jobRepo := new(MockJobRepository)
+	service := NewJobService(jobRepo, &ai.AIService{}, nil, nil, &config.Settings{})
+	service.SetPipelineRepository(repo)
+
+	job := &models.Job{ID: 3, Status: models.APPLIED}
+	service.LoadJobStages(ctx, 1, job)
+	assert.Nil(t, job.Stage)
+
+	screen := models.Pipeline(repo.stages).Stage(7)
+	require.NoError(t, service.ChangeJobStage(ctx, 1, job, screen, "", ""))
+
+	assert.Equal(t, 7, repo.jobStages[3])
+	assert.Equal(t, models.INTERVIEWING, job.Status)
+	assert.Equal(t, "Phone Screen", job.StageName())
+	jobRepo.AssertNotCalled(t, "UpdateStatus")
+
+	reloaded := &models.Job{ID: 3, Status: models.INTERVIEWING}
+	service.LoadJobStages(ctx, 1, reloaded)
+	assert.Equal(t, "Phone Screen", reloaded.StageName())
+}
+
+func TestJobService_CreateJob_WithPipeline(t *testing.T) {
+	ctx := context.Background()
+	setup := func() (*JobService, *MockJobRepository) {
+		jobRepo := new(MockJobRepository)
+		service := NewJobService(jobRepo, &ai.AIService{}, nil, nil, &config.Settings{})
+		service.SetPipelineRepository(newMemoryPipelineRepository())
+		return service, jobRepo
+	}
+
+	t.Run("takes the status from the user's stage", func(t *testing.T) {
+		service, jobRepo := setup()
+		jobRepo.On("GetOrCreate", ctx, 1, mock.AnythingOfType("*models.Job")).
+			Return(&models.Job{ID: 3}, false, nil)
+
+		stage := &models.PipelineStage{ID: 7, Category: models.REJECTED}
+		_, _, err := service.CreateJob(ctx, 1, "Engineer", "Build things", "Acme", models.WithStage(stage))
+		require.NoError(t, err)
+
+		saved := jobRepo.Calls[0].Arguments.Get(2).(*models.Job)
+		assert.Equal(t, "Phone Screen", saved.StageName())
+		assert.Equal(t, models.INTERVIEWING, saved.Status)
+	})
+
+	t.Run("rejects a stage that isn't the user's", func(t *testing.T) {
+		service, jobRepo := setup()
+
+		stage := &models.PipelineStage{ID: 42, Name: "Onsite", Category: models.INTERVIEWING}
+		_, _, err := service.CreateJob(ctx, 1, "Engineer", "Build things", "Acme", models.WithStage(stage))
+		assert.ErrorIs(t, err, models.ErrStageNotFound)
+		jobRepo.AssertNotCalled(t, "GetOrCreate")
+	})
+
+	t.Run("rejects a status outside the categories", func(t *testing.T) {
+		service, jobRepo := setup()
+
+		_, _, err := service.CreateJob(ctx, 1, "Engineer", "Build things", "Acme", models.WithStatus(models.JobStatus(9)))
+		assert.ErrorIs(t, err, models.ErrInvalidJobStatus)
+		jobRepo.AssertNotCalled(t, "GetOrCreate")
+	})
+}
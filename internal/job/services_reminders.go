@@ -0,0 +1,214 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/benidevo/vega/internal/job/interfaces"
+	"github.com/benidevo/vega/internal/job/models"
+)
+
+const (
+	// upcomingReminderWindow is how far ahead upcoming reminders are listed
+	upcomingReminderWindow = 7 * 24 * time.Hour
+
+	// upcomingReminderLimit bounds the number of upcoming reminders listed
+	upcomingReminderLimit = 5
+)
+
+// SetReminderRepository sets the repository used for reminders on jobs.
+func (s *JobService) SetReminderRepository(repo interfaces.ReminderRepository) {
+	s.reminderRepo = repo
+}
+
+// GetJobReminders returns the reminders of a job, open ones first. Without a
+// reminder repository there are none.
+func (s *JobService) GetJobReminders(ctx context.Context, userID, jobID int) ([]*models.Reminder, error) {
+	if s.reminderRepo == nil {
+		return nil, nil
+	}
+
+	reminders, err := s.reminderRepo.GetJobReminders(ctx, userID, jobID)
+	if err != nil {
+		s.log.Error().
+			Int("job_id", jobID).
+			Err(err).
+			Msg("Failed to get reminders")
+		return nil, err
+	}
+	return reminders, nil
+}
+
+// GetUpcomingReminders returns the user's open reminders due within the next
+// week, overdue ones first.
+func (s *JobService) GetUpcomingReminders(ctx context.Context, userID int) ([]*models.Reminder, error) {
+	if s.reminderRepo == nil {
+		return nil, nil
+	}
+
+	until := time.Now().UTC().Add(upcomingReminderWindow)
+	reminders, err := s.reminderRepo.GetUpcomingReminders(ctx, userID, until, upcomingReminderLimit)
+	if err != nil {
+		s.log.Error().Err(err).Msg("Failed to get upcoming reminders")
+		return nil, err
+	}
+	return reminders, nil
+}
+
+// GetOverdueReminderCounts returns the number of overdue reminders of each of
+// the given jobs, keyed by job ID.
+func (s *JobService) GetOverdueReminderCounts(ctx context.Context, userID int, jobs []*models.Job) (map[int]int, error) {
+	if s.reminderRepo == nil || len(jobs) == 0 {
+		return map[int]int{}, nil
+	}
+
+	ids := make([]int, len(jobs))
+	for i, job := range jobs {
+		ids[i] = job.ID
+	}
+
+	counts, err := s.reminderRepo.GetOverdueCounts(ctx, userID, ids)
+	if err != nil {
+		s.log.Error().Err(err).Msg("Failed to get overdue reminders")
+		return nil, err
+	}
+	return counts, nil
+}
+
+// CreateReminder adds a reminder to a job. The due time is as entered by the
+// user, in the time zone given by offset; a due time that has already passed
+// makes the reminder due straight away.
+func (s *JobService) CreateReminder(ctx context.Context, userID, jobID int, message, due, offset, recurrence string) (*models.Reminder, error) {
+	if s.reminderRepo == nil {
+		return nil, models.ErrReminderStoreRequired
+	}
+
+	message, err := models.NormalizeReminderMessage(message)
+	if err != nil {
+		return nil, err
+	}
+	dueAt, err := models.ParseReminderDue(due, offset)
+	if err != nil {
+		return nil, err
+	}
+	repeat, err := models.ReminderRecurrenceFromString(recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	reminder := &models.Reminder{
+		JobID:      jobID,
+		Message:    message,
+		DueAt:      dueAt,
+		Recurrence: repeat,
+	}
+	if err := s.saveReminder(ctx, userID, reminder, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	return reminder, nil
+}
+
+// CompleteReminder marks a reminder done. A recurring reminder moves to its
+// next due time instead.
+func (s *JobService) CompleteReminder(ctx context.Context, userID, jobID, reminderID int) error {
+	if s.reminderRepo == nil {
+		return models.ErrReminderStoreRequired
+	}
+
+	reminder, err := s.reminderRepo.GetReminder(ctx, userID, jobID, reminderID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	if next := reminder.Recurrence.NextAfter(reminder.DueAt, now); !next.IsZero() {
+		err = s.reminderRepo.RescheduleReminder(ctx, userID, jobID, reminderID, next)
+	} else {
+		err = s.reminderRepo.CompleteReminder(ctx, userID, jobID, reminderID, now)
+	}
+	if err != nil {
+		s.log.Error().
+			Int("reminder_id", reminderID).
+			Err(err).
+			Msg("Failed to complete reminder")
+		return err
+	}
+	return nil
+}
+
+// DeleteReminder removes a reminder from a job.
+func (s *JobService) DeleteReminder(ctx context.Context, userID, jobID, reminderID int) error {
+	if s.reminderRepo == nil {
+		return models.ErrReminderStoreRequired
+	}
+
+	if err := s.reminderRepo.DeleteReminder(ctx, userID, jobID, reminderID); err != nil {
+		s.log.Error().
+			Int("reminder_id", reminderID).
+			Err(err).
+			Msg("Failed to delete reminder")
+		return err
+	}
+	return nil
+}
+
+// MarkDueReminders marks every user's reminders whose due time has passed as
+// due, returning how many there were. The scheduler calls it periodically.
+func (s *JobService) MarkDueReminders(ctx context.Context, now time.Time) (int, error) {
+	if s.reminderRepo == nil {
+		return 0, nil
+	}
+
+	count, err := s.reminderRepo.MarkDueReminders(ctx, now)
+	if err != nil {
+		s.log.Error().Err(err).Msg("Failed to mark reminders due")
+		return 0, err
+	}
+	return count, nil
+}
+
+// suggestReminder adds the reminder suggested for a job reaching a status at
+// the given time, unless the job already has an open one of that kind. A
+// failure is only logged, as the suggestion is not what the user asked for.
+func (s *JobService) suggestReminder(ctx context.Context, userID int, job *models.Job, at time.Time) {
+	if s.reminderRepo == nil {
+		return
+	}
+	suggestion := models.SuggestedReminder(job.ID, job.Status, at)
+	if suggestion == nil {
+		return
+	}
+
+	reminders, err := s.reminderRepo.GetJobReminders(ctx, userID, job.ID)
+	if err != nil {
+		s.log.Warn().Int("job_id", job.ID).Err(err).Msg("Failed to suggest reminder")
+		return
+	}
+	for _, reminder := range reminders {
+		if reminder.Kind == suggestion.Kind && !reminder.IsDone() {
+			return
+		}
+	}
+
+	if err := s.saveReminder(ctx, userID, suggestion, time.Now().UTC()); err != nil {
+		s.log.Warn().Int("job_id", job.ID).Err(err).Msg("Failed to suggest reminder")
+	}
+}
+
+// saveReminder creates a reminder, due already if its due time is not after
+// now
+func (s *JobService) saveReminder(ctx context.Context, userID int, reminder *models.Reminder, now time.Time) error {
+	reminder.Status = models.ReminderPending
+	if !reminder.DueAt.After(now) {
+		reminder.Status = models.ReminderDue
+	}
+
+	if err := s.reminderRepo.CreateReminder(ctx, userID, reminder); err != nil {
+		s.log.Error().
+			Int("job_id", reminder.JobID).
+			Err(err).
+			Msg("Failed to create reminder")
+		return err
+	}
+	return nil
+}
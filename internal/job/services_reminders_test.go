@@ -0,0 +1,203 @@
+package job
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/benidevo/vega/internal/ai"
+	"github.com/benidevo/vega/internal/config"
+	"github.com/benidevo/vega/internal/job/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryReminderRepository keeps reminders in memory
+type memoryReminderRepository struct {
+	reminders []*models.Reminder
+}
+
+func (r *memoryReminderRepository) GetJobReminders(ctx context.Context, userID, jobID int) ([]*models.Reminder, error) {
+	var reminders []*models.Reminder
+	for _, reminder := range r.reminders {
+		if reminder.JobID == jobID {
+			reminders = append(reminders, reminder)
+		}
+	}
+	return reminders, nil
+}
+
+func (r *memoryReminderRepository) GetUpcomingReminders(ctx context.Context, userID int, until time.Time, limit int) ([]*models.Reminder, error) {
+	return r.reminders, nil
+}
+
+func (r *memoryReminderRepository) GetOverdueCounts(ctx context.Context, userID int, jobIDs []int) (map[int]int, error) {
+	return map[int]int{}, nil
+}
+
+func (r *memoryReminderRepository) GetReminder(ctx context.Context, userID, jobID, reminderID int) (*models.Reminder, error) {
+	for _, reminder := range r.reminders {
+		if reminder.ID == reminderID && reminder.JobID == jobID {
+			return reminder, nil
+		}
+	}
+	return nil, models.ErrReminderNotFound
+}
+
+func (r *memoryReminderRepository) CreateReminder(ctx context.Context, userID int, reminder *models.Reminder) error {
+	reminder.ID = len(r.reminders) + 1
+	r.reminders = append(r.reminders, reminder)
+	return nil
+}
+
+func (r *memoryReminderRepository) CompleteReminder(ctx context.Context, userID, jobID, reminderID int, completedAt time.Time) error {
+	reminder, err := r.GetReminder(ctx, userID, jobID, reminderID)
+	if err != nil {
+		return err
+	}
+	reminder.Status = models.ReminderDone
+	reminder.CompletedAt = &completedAt
+	return nil
+}
+
+func (r *memoryReminderRepository) RescheduleReminder(ctx context.Context, userID, jobID, reminderID int, dueAt time.Time) error {
+	reminder, err := r.GetReminder(ctx, userID, jobID, reminderID)
+	if err != nil {
+		return err
+	}
+	reminder.DueAt = dueAt
+	reminder.Status = models.ReminderPending
+	return nil
+}
+
+func (r *memoryReminderRepository) DeleteReminder(ctx context.Context, userID, jobID, reminderID int) error {
+	return nil
+}
+
+func (r *memoryReminderRepository) MarkDueReminders(ctx context.Context, now time.Time) (int, error) {
+	return 0, nil
+}
+
+func TestJobService_ChangeJobStage_SuggestsReminders(t *testing.T) {
+	ctx := context.Background()
+	pipeline := newMemoryPipelineRepository()
+
+	setup := func() (*JobService, *memoryReminderRepository) {
+		// The change to applied as the database would record it, for the
+		// backdated changes to set their date on
+		events := &memoryStatusEventRepository{}
+		events.record(3, nil, models.APPLIED, time.Now().UTC())
+
+		reminders := &memoryReminderRepository{}
+		service := NewJobService(new(MockJobRepository), &ai.AIService{}, nil, nil, &config.Settings{})
+		service.SetPipelineRepository(pipeline)
+		service.SetStatusEventRepository(events)
+		service.SetReminderRepository(reminders)
+		return service, reminders
+	}
+	stage := func(category models.JobStatus) *models.PipelineStage {
+		return models.Pipeline(pipeline.stages).FirstInCategory(category)
+	}
+
+	t.Run("suggests a follow-up a week after applying", func(t *testing.T) {
+		service, reminders := setup()
+		date := time.Now().UTC().AddDate(0, 0, -2).Format(models.StatusDateLayout)
+
+		require.NoError(t, service.ChangeJobStage(ctx, 1, &models.Job{ID: 3}, stage(models.APPLIED), "", date))
+
+		require.Len(t, reminders.reminders, 1)
+		suggestion := reminders.reminders[0]
+		assert.Equal(t, models.ReminderKindFollowUp, suggestion.Kind)
+		assert.Equal(t, date, suggestion.DueAt.Add(-models.FollowUpAfter).Format(models.StatusDateLayout))
+		assert.Equal(t, models.ReminderPending, suggestion.Status)
+	})
+
+	t.Run("makes a suggestion already past due", func(t *testing.T) {
+		service, reminders := setup()
+		date := time.Now().UTC().AddDate(0, 0, -10).Format(models.StatusDateLayout)
+
+		require.NoError(t, service.ChangeJobStage(ctx, 1, &models.Job{ID: 3}, stage(models.APPLIED), "", date))
+
+		require.Len(t, reminders.reminders, 1)
+		assert.True(t, reminders.reminders[0].IsOverdue())
+	})
+
+	t.Run("does not repeat an open suggestion", func(t *testing.T) {
+		service, reminders := setup()
+		job := &models.Job{ID: 3}
+
+		require.NoError(t, service.ChangeJobStage(ctx, 1, job, stage(models.INTERVIEWING), "", ""))
+		require.NoError(t, service.ChangeJobStage(ctx, 1, job, stage(models.APPLIED), "", ""))
+		require.NoError(t, service.ChangeJobStage(ctx, 1, job, stage(models.INTERVIEWING), "", ""))
+
+		require.Len(t, reminders.reminders, 2)
+		assert.Equal(t, models.ReminderKindInterviewPrep, reminders.reminders[0].Kind)
+		assert.Equal(t, models.ReminderKindFollowUp, reminders.reminders[1].Kind)
+	})
+
+	t.Run("suggests nothing within the same status", func(t *testing.T) {
+		service, reminders := setup()
+		job := &models.Job{ID: 3, Status: models.INTERVIEWING}
+
+		require.NoError(t, service.ChangeJobStage(ctx, 1, job, models.Pipeline(pipeline.stages).Stage(7), "", ""))
+
+		assert.Empty(t, reminders.reminders)
+	})
+}
+
+func TestJobService_CompleteReminder(t *testing.T) {
+	ctx := context.Background()
+	due := time.Now().UTC().Add(-time.Hour).Truncate(time.Minute)
+
+	setup := func(recurrence models.ReminderRecurrence) (*JobService, *models.Reminder) {
+		reminder := &models.Reminder{ID: 1, JobID: 3, Message: "Check the portal", DueAt: due, Recurrence: recurrence, Status: models.ReminderDue}
+		service := NewJobService(new(MockJobRepository), &ai.AIService{}, nil, nil, &config.Settings{})
+		service.SetReminderRepository(&memoryReminderRepository{reminders: []*models.Reminder{reminder}})
+		return service, reminder
+	}
+
+	t.Run("marks a one-off reminder done", func(t *testing.T) {
+		service, reminder := setup(models.RecurrenceNone)
+
+		require.NoError(t, service.CompleteReminder(ctx, 1, 3, 1))
+
+		assert.True(t, reminder.IsDone())
+		assert.NotNil(t, reminder.CompletedAt)
+	})
+
+	t.Run("moves a recurring reminder to its next due time", func(t *testing.T) {
+		service, reminder := setup(models.RecurrenceWeekly)
+
+		require.NoError(t, service.CompleteReminder(ctx, 1, 3, 1))
+
+		assert.Equal(t, models.ReminderPending, reminder.Status)
+		assert.Equal(t, due.AddDate(0, 0, 7), reminder.DueAt)
+		assert.Nil(t, reminder.CompletedAt)
+	})
+
+	t.Run("returns not found for another job's reminder", func(t *testing.T) {
+		service, _ := setup(models.RecurrenceNone)
+
+		assert.ErrorIs(t, service.CompleteReminder(ctx, 1, 4, 1), models.ErrReminderNotFound)
+	})
+}
+
+func TestJobService_CreateReminder(t *testing.T) {
+	ctx := context.Background()
+	reminders := &memoryReminderRepository{}
+	service := NewJobService(new(MockJobRepository), &ai.AIService{}, nil, nil, &config.Settings{})
+	service.SetReminderRepository(reminders)
+
+	reminder, err := service.CreateReminder(ctx, 1, 3, " Chase the recruiter ", "2099-03-04T09:30", "-60", "monthly")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Chase the recruiter", reminder.Message)
+	assert.Equal(t, time.Date(2099, 3, 4, 8, 30, 0, 0, time.UTC), reminder.DueAt)
+	assert.Equal(t, models.RecurrenceMonthly, reminder.Recurrence)
+	assert.Equal(t, models.ReminderPending, reminder.Status)
+	assert.False(t, reminder.IsSuggested())
+
+	_, err = service.CreateReminder(ctx, 1, 3, "Chase", "2099-03-04T09:30", "", "yearly")
+	assert.ErrorIs(t, err, models.ErrInvalidRecurrence)
+	assert.Len(t, reminders.reminders, 1)
+}
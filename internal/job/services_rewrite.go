@@ -0,0 +1,149 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	aimodels "github.com/benidevo/vega/internal/ai/models"
+	ctxutil "github.com/benidevo/vega/internal/common/context"
+	"github.com/benidevo/vega/internal/common/textdiff"
+	"github.com/benidevo/vega/internal/job/models"
+)
+
+// Limits on a section rewrite request. A single section is far shorter than
+// a whole document; longer text means the editor sent more than one section.
+const (
+	maxRewriteTextLength        = 5000
+	maxRewriteInstructionLength = 300
+)
+
+// RewriteSection asks the AI to rewrite one section of a generated CV or
+// cover letter following the user's instruction. text is the section as it
+// currently appears in the editor, so manual edits elsewhere in the document
+// are untouched. The rewrite is returned with a diff against the original and
+// is not saved.
+func (s *JobService) RewriteSection(ctx context.Context, userID, jobID int, section, text, instruction string) (*models.SectionRewrite, error) {
+	userRef := fmt.Sprintf("user_%d", userID)
+
+	s.log.Debug().
+		Str("user_ref", userRef).
+		Int("job_id", jobID).
+		Str("operation", "section_rewrite").
+		Str("section", section).
+		Msg("Starting section rewrite")
+
+	sectionKind := aimodels.SectionKind(section)
+	if !sectionKind.IsValid() {
+		return nil, models.ErrInvalidRewriteSection
+	}
+
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, models.ErrRewriteTextRequired
+	}
+	if len(text) > maxRewriteTextLength {
+		return nil, models.ErrRewriteTextTooLong
+	}
+
+	instruction = strings.TrimSpace(instruction)
+	if instruction == "" {
+		return nil, models.ErrRewriteInstructionRequired
+	}
+	if len(instruction) > maxRewriteInstructionLength {
+		return nil, models.ErrRewriteInstructionTooLong
+	}
+
+	aiService, err := s.aiServiceFor(ctx, userID)
+	if err != nil {
+		s.log.Error().Err(err).
+			Str("user_ref", userRef).
+			Int("job_id", jobID).
+			Str("error_type", "ai_service_unavailable").
+			Msg("AI service not available")
+		return nil, err
+	}
+	if aiService.SectionRewriter == nil {
+		return nil, models.ErrAIServiceUnavailable
+	}
+
+	if err := s.checkInjectionReview(ctx, userID, jobID); err != nil {
+		return nil, err
+	}
+
+	if s.settingsService == nil {
+		s.log.Error().
+			Str("user_ref", userRef).
+			Int("job_id", jobID).
+			Str("error_type", "settings_service_unavailable").
+			Msg("Settings service not available")
+		return nil, models.ErrProfileServiceRequired
+	}
+
+	job, err := s.GetJob(ctx, userID, jobID)
+	if err != nil {
+		s.log.Error().Err(err).
+			Str("user_ref", userRef).
+			Int("job_id", jobID).
+			Str("error_type", "job_not_found").
+			Msg("Job not found for section rewrite")
+		return nil, err
+	}
+
+	profile, err := s.settingsService.GetProfileWithRelated(ctx, userID)
+	if err != nil {
+		s.log.Error().Err(err).
+			Str("user_ref", userRef).
+			Int("job_id", jobID).
+			Str("error_type", "profile_fetch_failed").
+			Msg("Failed to get user profile for section rewrite")
+		return nil, err
+	}
+
+	if err := s.ValidateProfileForAI(profile); err != nil {
+		s.log.Warn().
+			Str("user_ref", userRef).
+			Int("job_id", jobID).
+			Str("error_type", "profile_incomplete").
+			Msg("Profile incomplete for AI section rewrite")
+		return nil, err
+	}
+
+	aiRequest := s.buildAIRequest(job, profile)
+	aiRequest.Rewrite = &aimodels.RewriteDetails{
+		Section:     sectionKind,
+		Text:        text,
+		Instruction: instruction,
+	}
+
+	aiResult, err := aiService.SectionRewriter.RewriteSection(ctxutil.WithUserID(ctx, userID), aiRequest)
+	if err != nil {
+		s.log.Error().Err(err).
+			Str("user_ref", userRef).
+			Int("job_id", jobID).
+			Str("error_type", "ai_generation_failed").
+			Msg("Section rewrite failed")
+		return nil, models.WrapError(models.ErrSectionRewriteFailed, err)
+	}
+
+	result := &models.SectionRewrite{
+		JobID:       jobID,
+		Section:     section,
+		Instruction: instruction,
+		Original:    text,
+		Rewritten:   aiResult.Content,
+		Changes:     aiResult.Changes,
+		Diff:        textdiff.Words(text, aiResult.Content),
+	}
+
+	s.log.Info().
+		Str("user_ref", userRef).
+		Int("job_id", jobID).
+		Str("operation", "section_rewrite").
+		Str("section", section).
+		Bool("changed", result.Changed()).
+		Bool("success", true).
+		Msg("Section rewrite completed")
+
+	return result, nil
+}
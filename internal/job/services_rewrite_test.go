@@ -0,0 +1,49 @@
+package job
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/benidevo/vega/internal/ai"
+	aitestutil "github.com/benidevo/vega/internal/ai/testutil"
+	"github.com/benidevo/vega/internal/config"
+	"github.com/benidevo/vega/internal/job/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobService_RewriteSection(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should_validate_request", func(t *testing.T) {
+		service := NewJobService(&MockJobRepository{}, nil, nil, nil, &config.Settings{})
+
+		tests := []struct {
+			name        string
+			section     string
+			text        string
+			instruction string
+			want        error
+		}{
+			{"unknown section", "footer", "Some text", "shorten", models.ErrInvalidRewriteSection},
+			{"empty text", "summary", "  ", "shorten", models.ErrRewriteTextRequired},
+			{"text too long", "summary", strings.Repeat("a", maxRewriteTextLength+1), "shorten", models.ErrRewriteTextTooLong},
+			{"empty instruction", "skills", "Go, SQL", " ", models.ErrRewriteInstructionRequired},
+			{"instruction too long", "skills", "Go, SQL", strings.Repeat("a", maxRewriteInstructionLength+1), models.ErrRewriteInstructionTooLong},
+			{"no AI service", "experience", "• Built APIs", "quantify results", models.ErrAIServiceUnavailable},
+		}
+
+		for _, tt := range tests {
+			_, err := service.RewriteSection(ctx, testUserID, 1, tt.section, tt.text, tt.instruction)
+			assert.ErrorIs(t, err, tt.want, tt.name)
+		}
+	})
+
+	t.Run("should_require_settings_service", func(t *testing.T) {
+		service := NewJobService(&MockJobRepository{}, ai.NewAIService(&aitestutil.MockProvider{}), nil, nil, &config.Settings{})
+
+		_, err := service.RewriteSection(ctx, testUserID, 1, "summary", "Backend engineer.", "shorten")
+
+		assert.ErrorIs(t, err, models.ErrProfileServiceRequired)
+	})
+}
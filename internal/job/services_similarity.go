@@ -0,0 +1,204 @@
+package job
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/benidevo/vega/internal/ai/embeddings"
+	"github.com/benidevo/vega/internal/job/models"
+	settingsmodels "github.com/benidevo/vega/internal/settings/models"
+)
+
+const (
+	// similarJobsLimit is the number of similar jobs shown for a job.
+	similarJobsLimit = 5
+
+	// minJobSimilarity leaves out jobs that only share common words.
+	minJobSimilarity = 0.25
+
+	// similarJobCandidates bounds how many of the user's most recently
+	// updated jobs are compared when looking for similar jobs.
+	similarJobCandidates = 200
+)
+
+// Embeddings computes similarity scores from stored embedding vectors.
+type Embeddings interface {
+	Similarities(ctx context.Context, userID int, target embeddings.Subject, candidates []embeddings.Subject) (map[int]float64, error)
+	Refresh(ctx context.Context, userID int, subjects ...embeddings.Subject) error
+}
+
+// SetEmbeddings enables instant similarity scores, which compare embedding
+// vectors instead of running a full AI analysis.
+func (s *JobService) SetEmbeddings(service Embeddings) {
+	s.embeddings = service
+}
+
+// GetJobFitScores returns how similar each job is to the user's profile, as a
+// percentage keyed by job ID. Nothing is returned when similarity scores are
+// disabled or the profile has nothing to compare yet.
+func (s *JobService) GetJobFitScores(ctx context.Context, userID int, jobs []*models.Job) (map[int]int, error) {
+	if s.embeddings == nil || s.settingsService == nil || len(jobs) == 0 {
+		return nil, nil
+	}
+
+	profile, err := s.settingsService.GetProfileSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	text := profileEmbeddingText(profile)
+	if text == "" {
+		return nil, nil
+	}
+
+	target := embeddings.Subject{Kind: embeddings.KindProfile, ID: embeddings.ProfileID, Text: text}
+	similarities, err := s.embeddings.Similarities(ctx, userID, target, jobSubjects(jobs))
+	if err != nil {
+		s.log.Warn().Err(err).Msg("Failed to compute job fit scores")
+		return nil, err
+	}
+
+	scores := make(map[int]int, len(similarities))
+	for jobID, similarity := range similarities {
+		scores[jobID] = similarityPercent(similarity)
+	}
+	return scores, nil
+}
+
+// GetSimilarJobs returns the user's saved jobs most similar to the given job,
+// most similar first.
+func (s *JobService) GetSimilarJobs(ctx context.Context, userID, jobID int) ([]*models.SimilarJob, error) {
+	if s.embeddings == nil {
+		return nil, nil
+	}
+
+	job, err := s.GetJob(ctx, userID, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := s.jobRepo.GetAll(ctx, userID, models.JobFilter{
+		Limit:     similarJobCandidates,
+		SortBy:    "updated_at",
+		SortOrder: "desc",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]*models.Job, 0, len(jobs))
+	for _, candidate := range jobs {
+		if candidate.ID != job.ID {
+			candidates = append(candidates, candidate)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	target := embeddings.Subject{Kind: embeddings.KindJob, ID: job.ID, Text: jobEmbeddingText(job)}
+	similarities, err := s.embeddings.Similarities(ctx, userID, target, jobSubjects(candidates))
+	if err != nil {
+		s.log.Warn().Int("job_id", jobID).Err(err).Msg("Failed to find similar jobs")
+		return nil, err
+	}
+
+	var similar []*models.SimilarJob
+	for _, candidate := range candidates {
+		if similarity := similarities[candidate.ID]; similarity >= minJobSimilarity {
+			similar = append(similar, &models.SimilarJob{
+				Job:        candidate,
+				Similarity: similarityPercent(similarity),
+			})
+		}
+	}
+	sort.SliceStable(similar, func(i, j int) bool {
+		return similar[i].Similarity > similar[j].Similarity
+	})
+	if len(similar) > similarJobsLimit {
+		similar = similar[:similarJobsLimit]
+	}
+	return similar, nil
+}
+
+// ProfileChanged recomputes the user's profile vector in the background, so
+// that fit scores on the jobs list reflect the change without waiting for
+// the embedding provider.
+func (s *JobService) ProfileChanged(ctx context.Context, userID int) {
+	if s.embeddings == nil || s.settingsService == nil {
+		return
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		profile, err := s.settingsService.GetProfileSettings(ctx, userID)
+		if err != nil {
+			return
+		}
+		text := profileEmbeddingText(profile)
+		if text == "" {
+			return
+		}
+
+		subject := embeddings.Subject{Kind: embeddings.KindProfile, ID: embeddings.ProfileID, Text: text}
+		if err := s.embeddings.Refresh(ctx, userID, subject); err != nil {
+			s.log.Warn().Err(err).Msg("Failed to refresh profile embedding")
+		}
+	}()
+}
+
+// jobSubjects returns the text to embed for each job
+func jobSubjects(jobs []*models.Job) []embeddings.Subject {
+	subjects := make([]embeddings.Subject, len(jobs))
+	for i, job := range jobs {
+		subjects[i] = embeddings.Subject{Kind: embeddings.KindJob, ID: job.ID, Text: jobEmbeddingText(job)}
+	}
+	return subjects
+}
+
+// jobEmbeddingText describes a job by its title, skills and description
+func jobEmbeddingText(job *models.Job) string {
+	var parts []string
+	parts = appendNonEmpty(parts, job.Title)
+	parts = appendNonEmpty(parts, strings.Join(job.RequiredSkills, ", "))
+	parts = appendNonEmpty(parts, job.Description)
+	return strings.Join(parts, "\n")
+}
+
+// profileEmbeddingText describes a profile by what a job description would
+// ask for. Contact details are left out.
+func profileEmbeddingText(profile *settingsmodels.Profile) string {
+	if profile == nil {
+		return ""
+	}
+
+	var parts []string
+	parts = appendNonEmpty(parts, profile.Title)
+	parts = appendNonEmpty(parts, strings.Join(profile.Skills, ", "))
+	parts = appendNonEmpty(parts, profile.CareerSummary)
+	for _, experience := range profile.WorkExperience {
+		parts = appendNonEmpty(parts, experience.Title)
+		parts = appendNonEmpty(parts, experience.Description)
+	}
+	for _, education := range profile.Education {
+		parts = appendNonEmpty(parts, strings.TrimSpace(education.Degree+" "+education.FieldOfStudy))
+	}
+	for _, certification := range profile.Certifications {
+		parts = appendNonEmpty(parts, certification.Name)
+	}
+	return strings.Join(parts, "\n")
+}
+
+func appendNonEmpty(parts []string, text string) []string {
+	if text = strings.TrimSpace(text); text != "" {
+		parts = append(parts, text)
+	}
+	return parts
+}
+
+// similarityPercent converts a cosine similarity to a percentage, treating
+// dissimilar texts as 0%
+func similarityPercent(similarity float64) int {
+	return int(math.Round(math.Max(0, math.Min(1, similarity)) * 100))
+}
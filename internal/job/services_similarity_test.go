@@ -0,0 +1,96 @@
+package job
+
+import (
+	"context"
+	"testing"
+
+	"github.com/benidevo/vega/internal/ai/embeddings"
+	"github.com/benidevo/vega/internal/config"
+	"github.com/benidevo/vega/internal/job/models"
+	settingsmodels "github.com/benidevo/vega/internal/settings/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedEmbeddings returns preset similarities by candidate ID
+type fixedEmbeddings struct {
+	similarities map[int]float64
+	target       embeddings.Subject
+	candidates   []embeddings.Subject
+}
+
+func (e *fixedEmbeddings) Similarities(ctx context.Context, userID int, target embeddings.Subject, candidates []embeddings.Subject) (map[int]float64, error) {
+	e.target = target
+	e.candidates = candidates
+	scores := make(map[int]float64, len(candidates))
+	for _, candidate := range candidates {
+		scores[candidate.ID] = e.similarities[candidate.ID]
+	}
+	return scores, nil
+}
+
+func (e *fixedEmbeddings) Refresh(ctx context.Context, userID int, subjects ...embeddings.Subject) error {
+	return nil
+}
+
+func TestJobService_GetSimilarJobs(t *testing.T) {
+	ctx := context.Background()
+	job := &models.Job{ID: 1, Title: "Go Engineer", Description: "Build Go services", RequiredSkills: []string{"Go", "Kubernetes"}}
+	jobs := []*models.Job{
+		job,
+		{ID: 2, Title: "Backend Engineer"},
+		{ID: 3, Title: "Platform Engineer"},
+		{ID: 4, Title: "Pastry Chef"},
+	}
+
+	repo := &MockJobRepository{}
+	repo.On("GetByID", ctx, 1, 1).Return(job, nil)
+	repo.On("GetAll", ctx, 1, mock.MatchedBy(func(filter models.JobFilter) bool {
+		return filter.Limit == similarJobCandidates && filter.SortBy == "updated_at"
+	})).Return(jobs, nil)
+
+	fake := &fixedEmbeddings{similarities: map[int]float64{2: 0.41, 3: 0.72, 4: 0.05}}
+	service := NewJobService(repo, nil, nil, nil, &config.Settings{})
+	service.SetEmbeddings(fake)
+
+	similar, err := service.GetSimilarJobs(ctx, 1, 1)
+
+	require.NoError(t, err)
+	require.Len(t, similar, 2)
+	assert.Equal(t, 3, similar[0].Job.ID)
+	assert.Equal(t, 72, similar[0].Similarity)
+	assert.Equal(t, 2, similar[1].Job.ID)
+	assert.Len(t, fake.candidates, 3, "the job itself should not be a candidate")
+	assert.Equal(t, "Go Engineer\nGo, Kubernetes\nBuild Go services", fake.target.Text)
+	repo.AssertExpectations(t)
+
+	t.Run("should return nothing when embeddings are disabled", func(t *testing.T) {
+		similar, err := NewJobService(repo, nil, nil, nil, &config.Settings{}).GetSimilarJobs(ctx, 1, 1)
+		require.NoError(t, err)
+		assert.Nil(t, similar)
+	})
+}
+
+func TestProfileEmbeddingText(t *testing.T) {
+	profile := &settingsmodels.Profile{
+		FirstName:     "Ada",
+		Email:         "ada@example.com",
+		Title:         "Backend Engineer",
+		Skills:        []string{"Go", "SQL"},
+		CareerSummary: "  Builds reliable APIs.  ",
+		WorkExperience: []settingsmodels.WorkExperience{
+			{Company: "Acme", Title: "Software Engineer", Description: "Payments platform"},
+		},
+		Education: []settingsmodels.Education{
+			{Institution: "MIT", Degree: "BSc", FieldOfStudy: "Computer Science"},
+		},
+	}
+
+	assert.Equal(t,
+		"Backend Engineer\nGo, SQL\nBuilds reliable APIs.\nSoftware Engineer\nPayments platform\nBSc Computer Science",
+		profileEmbeddingText(profile),
+	)
+	assert.Empty(t, profileEmbeddingText(&settingsmodels.Profile{FirstName: "Ada"}))
+	assert.Empty(t, profileEmbeddingText(nil))
+}
@@ -0,0 +1,523 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	aimodels "github.com/benidevo/vega/internal/ai/models"
+	ctxutil "github.com/benidevo/vega/internal/common/context"
+	"github.com/benidevo/vega/internal/job/interfaces"
+	"github.com/benidevo/vega/internal/job/models"
+	settingsmodels "github.com/benidevo/vega/internal/settings/models"
+)
+
+const (
+	// maxLearningPlanGaps is how many of the most frequent open gaps get a
+	// learning plan at a time.
+	maxLearningPlanGaps = 5
+	// maxGapWeaknesses limits the match feedback kept as examples per gap.
+	maxGapWeaknesses = 3
+	// maxGapJobTitles limits the roles listed per gap in the prompt.
+	maxGapJobTitles = 5
+	// maxSkillGapNoteLength limits the note a user can add when addressing a gap.
+	maxSkillGapNoteLength = 500
+)
+
+// SetSkillGapRepository sets the repository used for skill gaps and their
+// learning plans.
+func (s *JobService) SetSkillGapRepository(repo interfaces.SkillGapRepository) {
+	s.skillGapRepo = repo
+}
+
+// GetSkillGaps works out the user's skill gaps from the latest match analysis
+// of each of their jobs. Required skills missing from the profile and
+// weaknesses are ranked by how many analysed jobs they appear in.
+func (s *JobService) GetSkillGaps(ctx context.Context, userID int) (*models.SkillGapReport, error) {
+	if s.skillGapRepo == nil {
+		return nil, models.ErrSkillGapStoreRequired
+	}
+	if s.settingsService == nil {
+		return nil, models.ErrProfileServiceRequired
+	}
+
+	profile, err := s.settingsService.GetProfileSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.skillGapReport(ctx, userID, profile.Skills)
+}
+
+// GenerateLearningPlan asks the AI for a learning plan for each of the user's
+// most frequent open skill gaps and saves the plans.
+func (s *JobService) GenerateLearningPlan(ctx context.Context, userID int) (*models.SkillGapReport, error) {
+	userRef := fmt.Sprintf("user_%d", userID)
+
+	s.log.Debug().
+		Str("user_ref", userRef).
+		Str("operation", "learning_plan_generation").
+		Msg("Starting learning plan generation")
+
+	aiService, err := s.aiServiceFor(ctx, userID)
+	if err != nil {
+		s.log.Error().Err(err).
+			Str("user_ref", userRef).
+			Str("error_type", "ai_service_unavailable").
+			Msg("AI service not available")
+		return nil, err
+	}
+
+	if s.settingsService == nil {
+		s.log.Error().
+			Str("user_ref", userRef).
+			Str("error_type", "settings_service_unavailable").
+			Msg("Settings service not available")
+		return nil, models.ErrProfileServiceRequired
+	}
+
+	if s.skillGapRepo == nil {
+		return nil, models.ErrSkillGapStoreRequired
+	}
+
+	profile, err := s.settingsService.GetProfileWithRelated(ctx, userID)
+	if err != nil {
+		s.log.Error().Err(err).
+			Str("user_ref", userRef).
+			Str("error_type", "profile_fetch_failed").
+			Msg("Failed to get user profile for learning plan generation")
+		return nil, err
+	}
+
+	if err := s.ValidateProfileForAI(profile); err != nil {
+		s.log.Warn().
+			Str("user_ref", userRef).
+			Str("error_type", "profile_incomplete").
+			Msg("Profile incomplete for AI learning plan generation")
+		return nil, err
+	}
+
+	report, err := s.skillGapReport(ctx, userID, profile.Skills)
+	if err != nil {
+		return nil, err
+	}
+
+	top := report.Open
+	if len(top) > maxLearningPlanGaps {
+		top = top[:maxLearningPlanGaps]
+	}
+	if len(top) == 0 {
+		return nil, models.ErrNoSkillGaps
+	}
+
+	aiRequest := s.buildLearningPlanRequest(profile, top)
+	aiResult, err := aiService.LearningPlan.GenerateLearningPlan(ctxutil.WithUserID(ctx, userID), aiRequest)
+	if err != nil {
+		s.log.Error().Err(err).
+			Str("user_ref", userRef).
+			Str("error_type", "ai_generation_failed").
+			Msg("Learning plan generation failed")
+		return nil, err
+	}
+
+	plans := matchGapPlans(top, aiResult)
+	for _, gap := range top {
+		plan, ok := plans[gap.Key]
+		if !ok {
+			continue
+		}
+		if err := s.skillGapRepo.SaveSkillGapPlan(ctx, userID, gap.Key, gap.Name, plan); err != nil {
+			return nil, err
+		}
+		gap.Plan = plan
+	}
+
+	s.log.Info().
+		Str("user_ref", userRef).
+		Str("operation", "learning_plan_generation").
+		Int("gap_count", len(top)).
+		Int("plan_count", len(plans)).
+		Bool("success", true).
+		Msg("Learning plan generation completed")
+
+	return report, nil
+}
+
+// MarkSkillGapAddressed marks an open skill gap as addressed and adds a line
+// about it to the profile's personal context, so later analyses and documents
+// take the new experience into account.
+func (s *JobService) MarkSkillGapAddressed(ctx context.Context, userID int, key, note string) (*models.SkillGapReport, error) {
+	note = strings.TrimSpace(note)
+	if utf8.RuneCountInString(note) > maxSkillGapNoteLength {
+		return nil, models.ErrSkillGapNoteTooLong
+	}
+
+	if s.skillGapRepo == nil {
+		return nil, models.ErrSkillGapStoreRequired
+	}
+	if s.settingsService == nil {
+		return nil, models.ErrProfileServiceRequired
+	}
+
+	profile, err := s.settingsService.GetProfileSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := s.skillGapReport(ctx, userID, profile.Skills)
+	if err != nil {
+		return nil, err
+	}
+
+	index := -1
+	for i, gap := range report.Open {
+		if gap.Key == key {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, models.ErrSkillGapNotFound
+	}
+	gap := report.Open[index]
+
+	profile.Context = appendAddressedGap(profile.Context, gap.Name, note)
+	if err := s.settingsService.ValidateContext(profile.Context); err != nil {
+		return nil, err
+	}
+	if err := s.settingsService.UpdateProfile(ctx, profile); err != nil {
+		return nil, err
+	}
+
+	if err := s.skillGapRepo.MarkSkillGapAddressed(ctx, userID, gap.Key, gap.Name, note); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	gap.AddressedAt = &now
+	gap.AddressedNote = note
+	report.Open = append(report.Open[:index], report.Open[index+1:]...)
+	report.Addressed = append([]*models.SkillGap{gap}, report.Addressed...)
+
+	s.log.Info().
+		Str("user_ref", fmt.Sprintf("user_%d", userID)).
+		Str("operation", "skill_gap_addressed").
+		Bool("success", true).
+		Msg("Skill gap marked as addressed")
+
+	return report, nil
+}
+
+// skillGapReport aggregates the user's skill gaps and merges in what has been
+// stored about them.
+func (s *JobService) skillGapReport(ctx context.Context, userID int, profileSkills []string) (*models.SkillGapReport, error) {
+	jobs, err := s.skillGapRepo.GetAnalysedJobs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	states, err := s.skillGapRepo.GetSkillGapStates(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildSkillGapReport(aggregateSkillGaps(jobs, profileSkills), states, len(jobs)), nil
+}
+
+// buildLearningPlanRequest creates an AI request for planning the given gaps.
+// The roles the gaps were found in stand in for the job description.
+func (s *JobService) buildLearningPlanRequest(profile *settingsmodels.Profile, gaps []*models.SkillGap) aimodels.Request {
+	applicantName := "Applicant"
+	if profile.FirstName != "" || profile.LastName != "" {
+		applicantName = fmt.Sprintf("%s %s", profile.FirstName, profile.LastName)
+	}
+
+	var roles strings.Builder
+	roles.WriteString("Roles the applicant has been analysed against:\n")
+	seen := make(map[int]bool)
+
+	details := make([]aimodels.SkillGapDetails, 0, len(gaps))
+	for _, gap := range gaps {
+		titles := make([]string, 0, maxGapJobTitles)
+		for _, job := range gap.Jobs {
+			title := fmt.Sprintf("%s at %s", job.Title, job.Company)
+			if len(titles) < maxGapJobTitles {
+				titles = append(titles, title)
+			}
+			if !seen[job.JobID] {
+				seen[job.JobID] = true
+				roles.WriteString(fmt.Sprintf("- %s\n", title))
+			}
+		}
+
+		details = append(details, aimodels.SkillGapDetails{
+			Name:        gap.Name,
+			Occurrences: gap.Occurrences,
+			JobTitles:   titles,
+			Weaknesses:  gap.Weaknesses,
+		})
+	}
+
+	return aimodels.Request{
+		ApplicantName:    applicantName,
+		ApplicantProfile: s.buildProfileSummary(profile),
+		JobDescription:   roles.String(),
+		ExtraContext:     profile.Context,
+		SkillGaps:        details,
+
+		WorkExperience:  profile.WorkExperience,
+		Education:       profile.Education,
+		Certifications:  profile.Certifications,
+		Skills:          profile.Skills,
+		YearsExperience: int(s.calculateTotalExperience(profile.WorkExperience)),
+	}
+}
+
+// matchGapPlans pairs the plans in the AI result with the gaps they were
+// written for. Plans are matched by gap name, falling back to their position
+// when the model renamed a gap.
+func matchGapPlans(gaps []*models.SkillGap, aiResult *aimodels.LearningPlan) map[string]*models.GapPlan {
+	now := time.Now().UTC()
+	convert := func(p aimodels.GapPlan) *models.GapPlan {
+		return &models.GapPlan{
+			Why:           p.Why,
+			Steps:         p.Steps,
+			Projects:      p.Projects,
+			Phrasing:      p.Phrasing,
+			PromptVersion: aiResult.PromptVersion,
+			GeneratedAt:   now,
+		}
+	}
+
+	used := make([]bool, len(aiResult.Gaps))
+	byKey := make(map[string]int, len(aiResult.Gaps))
+	for i, p := range aiResult.Gaps {
+		if _, ok := byKey[models.SkillGapKey(p.Gap)]; !ok {
+			byKey[models.SkillGapKey(p.Gap)] = i
+		}
+	}
+
+	plans := make(map[string]*models.GapPlan, len(gaps))
+	var unmatched []int
+	for i, gap := range gaps {
+		if j, ok := byKey[gap.Key]; ok && !used[j] {
+			used[j] = true
+			plans[gap.Key] = convert(aiResult.Gaps[j])
+		} else {
+			unmatched = append(unmatched, i)
+		}
+	}
+	for _, i := range unmatched {
+		if i < len(aiResult.Gaps) && !used[i] {
+			used[i] = true
+			plans[gaps[i].Key] = convert(aiResult.Gaps[i])
+		}
+	}
+	return plans
+}
+
+// aggregateSkillGaps finds the gaps across the analysed jobs. Each required
+// skill missing from the profile is a gap; a weakness that mentions one counts
+// towards that skill and is kept as an example. Other weaknesses are grouped
+// by their text. Gaps are counted once per job and sorted most frequent first.
+func aggregateSkillGaps(jobs []*models.AnalysedJob, profileSkills []string) []*models.SkillGap {
+	have := make(map[string]bool, len(profileSkills))
+	for _, skill := range profileSkills {
+		if key := models.SkillGapKey(skill); key != "" {
+			have[key] = true
+		}
+	}
+
+	gaps := make(map[string]*models.SkillGap)
+	var skillKeys []string
+	for _, job := range jobs {
+		for _, skill := range job.RequiredSkills {
+			key := models.SkillGapKey(skill)
+			if key == "" || have[key] {
+				continue
+			}
+			if _, ok := gaps[key]; !ok {
+				gaps[key] = &models.SkillGap{Key: key, Name: strings.TrimSpace(skill), MissingSkill: true}
+				skillKeys = append(skillKeys, key)
+			}
+		}
+	}
+
+	for _, job := range jobs {
+		ref := models.SkillGapJob{JobID: job.JobID, Title: job.Title, Company: job.Company}
+		counted := make(map[string]bool)
+		count := func(gap *models.SkillGap) {
+			if !counted[gap.Key] {
+				counted[gap.Key] = true
+				gap.Occurrences++
+				gap.Jobs = append(gap.Jobs, ref)
+			}
+		}
+
+		for _, skill := range job.RequiredSkills {
+			if gap, ok := gaps[models.SkillGapKey(skill)]; ok {
+				count(gap)
+			}
+		}
+
+		for _, weakness := range job.Weaknesses {
+			weakness = strings.TrimSpace(weakness)
+			if weakness == "" {
+				continue
+			}
+
+			matched := false
+			for _, key := range skillKeys {
+				if mentionsSkill(weakness, key) {
+					matched = true
+					count(gaps[key])
+					addGapWeakness(gaps[key], weakness)
+				}
+			}
+			if matched {
+				continue
+			}
+
+			key := models.SkillGapKey(weakness)
+			gap, ok := gaps[key]
+			if !ok {
+				gap = &models.SkillGap{Key: key, Name: weakness}
+				gaps[key] = gap
+			}
+			count(gap)
+		}
+	}
+
+	result := make([]*models.SkillGap, 0, len(gaps))
+	for _, gap := range gaps {
+		if gap.Occurrences > 0 {
+			result = append(result, gap)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Occurrences != b.Occurrences {
+			return a.Occurrences > b.Occurrences
+		}
+		if a.MissingSkill != b.MissingSkill {
+			return a.MissingSkill
+		}
+		return a.Key < b.Key
+	})
+
+	return result
+}
+
+// buildSkillGapReport merges stored plans and addressed flags into the
+// aggregated gaps. Addressed gaps that no longer appear in any analysis are
+// still listed so the user can see what they have worked on.
+func buildSkillGapReport(gaps []*models.SkillGap, states []*models.SkillGapState, analysedJobs int) *models.SkillGapReport {
+	byKey := make(map[string]*models.SkillGapState, len(states))
+	for _, state := range states {
+		byKey[state.Key] = state
+	}
+
+	report := &models.SkillGapReport{
+		AnalysedJobs: analysedJobs,
+		Open:         []*models.SkillGap{},
+		Addressed:    []*models.SkillGap{},
+	}
+
+	for _, gap := range gaps {
+		if state, ok := byKey[gap.Key]; ok {
+			gap.Plan = state.Plan
+			gap.AddressedAt = state.AddressedAt
+			gap.AddressedNote = state.AddressedNote
+			delete(byKey, gap.Key)
+		}
+
+		if gap.IsAddressed() {
+			report.Addressed = append(report.Addressed, gap)
+		} else {
+			report.Open = append(report.Open, gap)
+		}
+	}
+
+	for _, state := range states {
+		if _, ok := byKey[state.Key]; !ok || state.AddressedAt == nil {
+			continue
+		}
+		report.Addressed = append(report.Addressed, &models.SkillGap{
+			Key:           state.Key,
+			Name:          state.Name,
+			Plan:          state.Plan,
+			AddressedAt:   state.AddressedAt,
+			AddressedNote: state.AddressedNote,
+		})
+	}
+
+	sort.SliceStable(report.Addressed, func(i, j int) bool {
+		return report.Addressed[i].AddressedAt.After(*report.Addressed[j].AddressedAt)
+	})
+
+	return report
+}
+
+// appendAddressedGap adds a line about an addressed gap to the personal
+// context. The line is only added once, so retrying after a failure doesn't
+// repeat it.
+func appendAddressedGap(personalContext, name, note string) string {
+	line := fmt.Sprintf("Addressed skill gap: %s", name)
+	if note != "" {
+		line = fmt.Sprintf("%s (%s)", line, note)
+	}
+
+	personalContext = strings.TrimSpace(personalContext)
+	if strings.Contains(personalContext, line) {
+		return personalContext
+	}
+	if personalContext == "" {
+		return line
+	}
+	return personalContext + "\n" + line
+}
+
+// addGapWeakness keeps a weakness as an example for the gap, skipping
+// duplicates and stopping at maxGapWeaknesses.
+func addGapWeakness(gap *models.SkillGap, weakness string) {
+	if len(gap.Weaknesses) >= maxGapWeaknesses {
+		return
+	}
+	for _, existing := range gap.Weaknesses {
+		if strings.EqualFold(existing, weakness) {
+			return
+		}
+	}
+	gap.Weaknesses = append(gap.Weaknesses, weakness)
+}
+
+// mentionsSkill reports whether text mentions the skill as a whole word, so
+// "Go" matches "No Go experience" but not "good".
+func mentionsSkill(text, skillKey string) bool {
+	text = models.SkillGapKey(text)
+	for offset := 0; offset < len(text); {
+		i := strings.Index(text[offset:], skillKey)
+		if i < 0 {
+			return false
+		}
+		start := offset + i
+		end := start + len(skillKey)
+
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if (start == 0 || !isWordRune(before)) && (end == len(text) || !isWordRune(after)) {
+			return true
+		}
+		offset = start + 1
+	}
+	return false
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,135 @@
+package job
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	aimodels "github.com/benidevo/vega/internal/ai/models"
+	"github.com/benidevo/vega/internal/config"
+	"github.com/benidevo/vega/internal/job/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregateSkillGaps(t *testing.T) {
+	jobs := []*models.AnalysedJob{
+		{
+			JobID: 1, Title: "Platform Engineer", Company: "Acme",
+			RequiredSkills: []string{"Go", "Kubernetes", "Terraform"},
+			Weaknesses:     []string{"No production Kubernetes experience", "Limited public speaking"},
+		},
+		{
+			JobID: 2, Title: "SRE", Company: "Globex",
+			RequiredSkills: []string{"kubernetes ", "Python"},
+			Weaknesses:     []string{"limited  public speaking"},
+		},
+		{
+			JobID: 3, Title: "Backend Engineer", Company: "Initech",
+			RequiredSkills: []string{"Go"},
+			Weaknesses:     []string{"Little exposure to Terraform modules", "Kubernetes knowledge is shallow"},
+		},
+	}
+
+	gaps := aggregateSkillGaps(jobs, []string{"go", "Python"})
+
+	require.Len(t, gaps, 3)
+
+	assert.Equal(t, "kubernetes", gaps[0].Key)
+	assert.Equal(t, "Kubernetes", gaps[0].Name)
+	assert.Equal(t, 3, gaps[0].Occurrences)
+	assert.True(t, gaps[0].MissingSkill)
+	assert.Equal(t, []string{"No production Kubernetes experience", "Kubernetes knowledge is shallow"}, gaps[0].Weaknesses)
+	assert.Equal(t, "Globex", gaps[0].Jobs[1].Company)
+
+	assert.Equal(t, "terraform", gaps[1].Key)
+	assert.Equal(t, 2, gaps[1].Occurrences)
+
+	assert.Equal(t, "limited public speaking", gaps[2].Key)
+	assert.Equal(t, 2, gaps[2].Occurrences)
+	assert.False(t, gaps[2].MissingSkill)
+}
+
+func TestMentionsSkill(t *testing.T) {
+	assert.True(t, mentionsSkill("No Go experience", "go"))
+	assert.True(t, mentionsSkill("Go", "go"))
+	assert.True(t, mentionsSkill("Needs C++ for the engine work", "c++"))
+	assert.False(t, mentionsSkill("Good communication", "go"))
+	assert.False(t, mentionsSkill("Uses Django", "go"))
+	assert.True(t, mentionsSkill("Cargo and Go tooling", "go"))
+}
+
+func TestBuildSkillGapReport(t *testing.T) {
+	older := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(24 * time.Hour)
+	gaps := []*models.SkillGap{
+		{Key: "kubernetes", Name: "Kubernetes", Occurrences: 3},
+		{Key: "graphql", Name: "GraphQL", Occurrences: 2},
+	}
+	states := []*models.SkillGapState{
+		{Key: "kubernetes", Name: "Kubernetes", Plan: &models.GapPlan{Steps: []string{"Learn pods"}}},
+		{Key: "graphql", Name: "GraphQL", AddressedAt: &older, AddressedNote: "Built an API"},
+		{Key: "rust", Name: "Rust", AddressedAt: &newer},
+		{Key: "elixir", Name: "Elixir"},
+	}
+
+	report := buildSkillGapReport(gaps, states, 4)
+
+	assert.Equal(t, 4, report.AnalysedJobs)
+	require.Len(t, report.Open, 1)
+	assert.Equal(t, "kubernetes", report.Open[0].Key)
+	assert.NotNil(t, report.Open[0].Plan)
+	assert.True(t, report.HasPlans())
+
+	require.Len(t, report.Addressed, 2)
+	assert.Equal(t, "rust", report.Addressed[0].Key)
+	assert.Equal(t, "graphql", report.Addressed[1].Key)
+	assert.Equal(t, "Built an API", report.Addressed[1].AddressedNote)
+}
+
+func TestMatchGapPlans(t *testing.T) {
+	gaps := []*models.SkillGap{
+		{Key: "kubernetes", Name: "Kubernetes"},
+		{Key: "limited public speaking", Name: "Limited public speaking"},
+	}
+	aiResult := &aimodels.LearningPlan{
+		Gaps: []aimodels.GapPlan{
+			{Gap: "kubernetes", Steps: []string{"Learn pods"}},
+			{Gap: "Public speaking", Steps: []string{"Give a meetup talk"}},
+		},
+		PromptVersion: "builtin",
+	}
+
+	plans := matchGapPlans(gaps, aiResult)
+
+	require.Len(t, plans, 2)
+	assert.Equal(t, []string{"Learn pods"}, plans["kubernetes"].Steps)
+	assert.Equal(t, []string{"Give a meetup talk"}, plans["limited public speaking"].Steps)
+	assert.Equal(t, "builtin", plans["kubernetes"].PromptVersion)
+}
+
+func TestAppendAddressedGap(t *testing.T) {
+	assert.Equal(t, "Addressed skill gap: GraphQL", appendAddressedGap("", "GraphQL", ""))
+
+	context := appendAddressedGap("Open to relocation", "GraphQL", "Built an API")
+	assert.Equal(t, "Open to relocation\nAddressed skill gap: GraphQL (Built an API)", context)
+	assert.Equal(t, context, appendAddressedGap(context, "GraphQL", "Built an API"))
+}
+
+func TestJobService_SkillGaps_MissingDependencies(t *testing.T) {
+	service := NewJobService(&MockJobRepository{}, nil, nil, nil, &config.Settings{})
+	ctx := context.Background()
+
+	_, err := service.GetSkillGaps(ctx, testUserID)
+	assert.ErrorIs(t, err, models.ErrSkillGapStoreRequired)
+
+	_, err = service.GenerateLearningPlan(ctx, testUserID)
+	assert.ErrorIs(t, err, models.ErrAIServiceUnavailable)
+
+	_, err = service.MarkSkillGapAddressed(ctx, testUserID, "graphql", "")
+	assert.ErrorIs(t, err, models.ErrSkillGapStoreRequired)
+
+	_, err = service.MarkSkillGapAddressed(ctx, testUserID, "graphql", strings.Repeat("a", maxSkillGapNoteLength+1))
+	assert.ErrorIs(t, err, models.ErrSkillGapNoteTooLong)
+}
@@ -0,0 +1,210 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/benidevo/vega/internal/job/interfaces"
+	"github.com/benidevo/vega/internal/job/models"
+)
+
+// SetStatusEventRepository sets the repository used for the history of job
+// status changes.
+func (s *JobService) SetStatusEventRepository(repo interfaces.StatusEventRepository) {
+	s.statusEventRepo = repo
+}
+
+// ChangeJobStage moves a job to a stage of the user's pipeline, setting its
+// status to the stage's category. The database records the change as it
+// happens; a note or an earlier date given by the user is then set on the
+// recorded change. Moving a job to the stage it is at changes nothing.
+func (s *JobService) ChangeJobStage(ctx context.Context, userID int, job *models.Job, stage *models.PipelineStage, note, date string) error {
+	note, err := models.NormalizeStatusNote(note)
+	if err != nil {
+		return err
+	}
+	occurredAt, err := models.ParseStatusDate(date, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if isJobStage(job, stage) {
+		return nil
+	}
+	previous := job.Status
+
+	// Without stages a job is moved by its status alone
+	if s.pipelineRepo != nil {
+		err = s.pipelineRepo.SetJobStage(ctx, userID, job.ID, stage.ID)
+	} else {
+		err = s.jobRepo.UpdateStatus(ctx, userID, job.ID, stage.Category)
+	}
+	if err != nil {
+		s.log.Error().
+			Int("job_id", job.ID).
+			Err(err).
+			Msg("Failed to update job stage")
+		return err
+	}
+	job.Stage = stage
+	job.Status = stage.Category
+
+	// Reaching a status from another one may come with a suggested reminder,
+	// timed from when the change happened
+	if previous != job.Status {
+		s.suggestReminder(ctx, userID, job, occurredAt)
+	}
+
+	if note == "" && date == "" {
+		return nil
+	}
+	if s.statusEventRepo == nil {
+		return models.ErrHistoryStoreRequired
+	}
+
+	events, err := s.statusEventRepo.GetStatusEvents(ctx, userID, job.ID)
+	if err != nil {
+		s.log.Error().
+			Int("job_id", job.ID).
+			Err(err).
+			Msg("Failed to get status history")
+		return err
+	}
+
+	var latest *models.StatusEvent
+	for _, event := range events {
+		if isEventStage(event, stage) && (latest == nil || event.ID > latest.ID) {
+			latest = event
+		}
+	}
+	if latest == nil {
+		return models.ErrStatusEventNotFound
+	}
+	if date == "" {
+		occurredAt = latest.OccurredAt
+	}
+
+	return s.updateStatusEvent(ctx, userID, job.ID, latest.ID, note, occurredAt, events)
+}
+
+// GetStatusTimeline returns the status changes of a job in the order they
+// happened, with how long the job stayed in each status.
+func (s *JobService) GetStatusTimeline(ctx context.Context, userID, jobID int) ([]models.StatusTimelineEntry, error) {
+	if s.statusEventRepo == nil {
+		return nil, nil
+	}
+
+	events, err := s.statusEventRepo.GetStatusEvents(ctx, userID, jobID)
+	if err != nil {
+		s.log.Error().
+			Int("job_id", jobID).
+			Err(err).
+			Msg("Failed to get status history")
+		return nil, err
+	}
+	return models.NewStatusTimeline(events, time.Now().UTC()), nil
+}
+
+// UpdateStatusEvent sets the note of a recorded status change and the date it
+// happened, for changes made in the app after they happened.
+func (s *JobService) UpdateStatusEvent(ctx context.Context, userID, jobID, eventID int, note, date string) error {
+	if s.statusEventRepo == nil {
+		return models.ErrHistoryStoreRequired
+	}
+
+	note, err := models.NormalizeStatusNote(note)
+	if err != nil {
+		return err
+	}
+
+	events, err := s.statusEventRepo.GetStatusEvents(ctx, userID, jobID)
+	if err != nil {
+		s.log.Error().
+			Int("job_id", jobID).
+			Err(err).
+			Msg("Failed to get status history")
+		return err
+	}
+
+	var event *models.StatusEvent
+	for _, e := range events {
+		if e.ID == eventID {
+			event = e
+			break
+		}
+	}
+	if event == nil {
+		return models.ErrStatusEventNotFound
+	}
+
+	occurredAt := event.OccurredAt
+	if date != "" && date != event.OccurredAt.Format(models.StatusDateLayout) {
+		if occurredAt, err = models.ParseStatusDate(date, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	return s.updateStatusEvent(ctx, userID, jobID, eventID, note, occurredAt, events)
+}
+
+// GetStatusActivity summarizes the user's status changes over the last given
+// number of days.
+func (s *JobService) GetStatusActivity(ctx context.Context, userID, days int) (*models.StatusActivity, error) {
+	if s.statusEventRepo == nil {
+		return nil, models.ErrHistoryStoreRequired
+	}
+
+	since := time.Now().UTC().AddDate(0, 0, -days)
+	activity, err := s.statusEventRepo.GetStatusActivity(ctx, userID, since)
+	if err != nil {
+		s.log.Error().Err(err).Msg("Failed to get status activity")
+		return nil, err
+	}
+	return activity, nil
+}
+
+// updateStatusEvent saves a status change. A change dated before the job's
+// first status moves that status back with it, since the job must have been
+// known by then.
+func (s *JobService) updateStatusEvent(ctx context.Context, userID, jobID, eventID int, note string, occurredAt time.Time, events []*models.StatusEvent) error {
+	if err := s.statusEventRepo.UpdateStatusEvent(ctx, userID, jobID, eventID, note, occurredAt); err != nil {
+		s.log.Error().
+			Int("job_id", jobID).
+			Int("event_id", eventID).
+			Err(err).
+			Msg("Failed to update status change")
+		return err
+	}
+
+	for _, event := range events {
+		if event.FromStatus != nil || event.ID == eventID || !occurredAt.Before(event.OccurredAt) {
+			continue
+		}
+		if err := s.statusEventRepo.UpdateStatusEvent(ctx, userID, jobID, event.ID, event.Note, occurredAt); err != nil {
+			s.log.Error().
+				Int("job_id", jobID).
+				Int("event_id", event.ID).
+				Err(err).
+				Msg("Failed to move first status")
+			return err
+		}
+	}
+	return nil
+}
+
+// isJobStage reports whether the job is at the stage, going by its status
+// when stages aren't stored
+func isJobStage(job *models.Job, stage *models.PipelineStage) bool {
+	if job.Stage != nil && stage.ID > 0 {
+		return job.Stage.ID == stage.ID
+	}
+	return job.Status == stage.Category
+}
+
+// isEventStage reports whether a status change moved the job to the stage
+func isEventStage(event *models.StatusEvent, stage *models.PipelineStage) bool {
+	if event.ToStageID > 0 && stage.ID > 0 {
+		return event.ToStageID == stage.ID
+	}
+	return event.ToStatus == stage.Category
+}
@@ -0,0 +1,228 @@
+package job
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/benidevo/vega/internal/ai"
+	"github.com/benidevo/vega/internal/config"
+	"github.com/benidevo/vega/internal/job/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStatusEventRepository keeps status changes in memory. Like the
+// database triggers, it records a change whenever the job repository's
+// UpdateStatus is called through record.
+type memoryStatusEventRepository struct {
+	events []*models.StatusEvent
+}
+
+func (r *memoryStatusEventRepository) record(jobID int, from *models.JobStatus, to models.JobStatus, at time.Time) {
+	r.events = append(r.events, &models.StatusEvent{
+		ID:         len(r.events) + 1,
+		JobID:      jobID,
+		FromStatus: from,
+		ToStatus:   to,
+		OccurredAt: at,
+	})
+}
+
+func (r *memoryStatusEventRepository) GetStatusEvents(ctx context.Context, userID, jobID int) ([]*models.StatusEvent, error) {
+	var events []*models.StatusEvent
+	for _, event := range r.events {
+		if event.JobID == jobID {
+			copied := *event
+			events = append(events, &copied)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].OccurredAt.Before(events[j].OccurredAt)
+	})
+	return events, nil
+}
+
+func (r *memoryStatusEventRepository) UpdateStatusEvent(ctx context.Context, userID, jobID, eventID int, note string, occurredAt time.Time) error {
+	for _, event := range r.events {
+		if event.ID == eventID && event.JobID == jobID {
+			event.Note = note
+			event.OccurredAt = occurredAt
+			return nil
+		}
+	}
+	return models.ErrStatusEventNotFound
+}
+
+func (r *memoryStatusEventRepository) GetStatusActivity(ctx context.Context, userID int, since time.Time) (*models.StatusActivity, error) {
+	return &models.StatusActivity{}, nil
+}
+
+func TestJobService_ChangeJobStage(t *testing.T) {
+	ctx := context.Background()
+	added := time.Now().UTC().AddDate(0, 0, -1)
+	pipeline := models.DefaultPipeline()
+	applied := pipeline.FirstInCategory(models.APPLIED)
+
+	setup := func() (*JobService, *MockJobRepository, *memoryStatusEventRepository) {
+		jobRepo := new(MockJobRepository)
+		events := &memoryStatusEventRepository{}
+		events.record(7, nil, models.INTERESTED, added)
+
+		service := NewJobService(jobRepo, &ai.AIService{}, nil, nil, &config.Settings{})
+		service.SetStatusEventRepository(events)
+		return service, jobRepo, events
+	}
+	recordChange := func(events *memoryStatusEventRepository, from, to models.JobStatus) func(mock.Arguments) {
+		return func(mock.Arguments) {
+			events.record(7, &from, to, time.Now().UTC())
+		}
+	}
+
+	t.Run("updates the status", func(t *testing.T) {
+		service, jobRepo, events := setup()
+		jobRepo.On("UpdateStatus", ctx, 1, 7, models.APPLIED).
+			Run(recordChange(events, models.INTERESTED, models.APPLIED)).Return(nil)
+
+		job := &models.Job{ID: 7, Status: models.INTERESTED}
+		err := service.ChangeJobStage(ctx, 1, job, applied, "", "")
+
+		require.NoError(t, err)
+		assert.Equal(t, models.APPLIED, job.Status)
+		assert.Len(t, events.events, 2)
+		jobRepo.AssertExpectations(t)
+	})
+
+	t.Run("records the note and date", func(t *testing.T) {
+		service, jobRepo, events := setup()
+		jobRepo.On("UpdateStatus", ctx, 1, 7, models.APPLIED).
+			Run(recordChange(events, models.INTERESTED, models.APPLIED)).Return(nil)
+
+		date := added.AddDate(0, 0, -6).Format(models.StatusDateLayout)
+		err := service.ChangeJobStage(ctx, 1, &models.Job{ID: 7}, applied, " through a referral ", date)
+
+		require.NoError(t, err)
+		change := events.events[1]
+		assert.Equal(t, "through a referral", change.Note)
+		assert.Equal(t, date, change.OccurredAt.Format(models.StatusDateLayout))
+
+		// The job was added to the app after it was applied to, so its first
+		// status moves back to keep the history in order
+		assert.Equal(t, change.OccurredAt, events.events[0].OccurredAt)
+	})
+
+	t.Run("does nothing when the status is unchanged", func(t *testing.T) {
+		service, jobRepo, events := setup()
+
+		err := service.ChangeJobStage(ctx, 1, &models.Job{ID: 7, Status: models.INTERESTED}, pipeline.FirstInCategory(models.INTERESTED), "a note", "")
+
+		require.NoError(t, err)
+		assert.Len(t, events.events, 1)
+		jobRepo.AssertNotCalled(t, "UpdateStatus")
+	})
+
+	t.Run("rejects invalid input before changing the status", func(t *testing.T) {
+		service, jobRepo, _ := setup()
+		job := &models.Job{ID: 7}
+
+		err := service.ChangeJobStage(ctx, 1, job, applied, "", time.Now().AddDate(0, 0, 2).Format(models.StatusDateLayout))
+		assert.ErrorIs(t, err, models.ErrStatusDateInFuture)
+
+		err = service.ChangeJobStage(ctx, 1, job, applied, strings.Repeat("x", models.MaxStatusNoteLength+1), "")
+		assert.ErrorIs(t, err, models.ErrStatusNoteTooLong)
+
+		assert.Equal(t, models.INTERESTED, job.Status)
+		jobRepo.AssertNotCalled(t, "UpdateStatus")
+	})
+}
+
+func TestJobService_UpdateStatusEvent(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	interested := models.INTERESTED
+
+	setup := func() (*JobService, *memoryStatusEventRepository) {
+		events := &memoryStatusEventRepository{}
+		events.record(7, nil, models.INTERESTED, now.AddDate(0, 0, -10))
+		events.record(7, &interested, models.APPLIED, now.AddDate(0, 0, -3))
+
+		service := NewJobService(new(MockJobRepository), &ai.AIService{}, nil, nil, &config.Settings{})
+		service.SetStatusEventRepository(events)
+		return service, events
+	}
+
+	t.Run("keeps the time when the date is unchanged", func(t *testing.T) {
+		service, events := setup()
+		occurredAt := events.events[1].OccurredAt
+
+		err := service.UpdateStatusEvent(ctx, 1, 7, 2, "phone screen booked", occurredAt.Format(models.StatusDateLayout))
+
+		require.NoError(t, err)
+		assert.Equal(t, "phone screen booked", events.events[1].Note)
+		assert.Equal(t, occurredAt, events.events[1].OccurredAt)
+	})
+
+	t.Run("backdates the change", func(t *testing.T) {
+		service, events := setup()
+		date := now.AddDate(0, 0, -5).Format(models.StatusDateLayout)
+
+		err := service.UpdateStatusEvent(ctx, 1, 7, 2, "", date)
+
+		require.NoError(t, err)
+		assert.Equal(t, date, events.events[1].OccurredAt.Format(models.StatusDateLayout))
+		assert.Equal(t, now.AddDate(0, 0, -10), events.events[0].OccurredAt)
+	})
+
+	t.Run("rejects changes of other jobs", func(t *testing.T) {
+		service, _ := setup()
+
+		err := service.UpdateStatusEvent(ctx, 1, 8, 2, "", "")
+
+		assert.ErrorIs(t, err, models.ErrStatusEventNotFound)
+	})
+
+	t.Run("requires the status event repository", func(t *testing.T) {
+		service := NewJobService(new(MockJobRepository), &ai.AIService{}, nil, nil, &config.Settings{})
+
+		err := service.UpdateStatusEvent(ctx, 1, 7, 2, "", "")
+
+		assert.ErrorIs(t, err, models.ErrHistoryStoreRequired)
+	})
+}
+
+func TestStatusCommand_Execute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	jobRepo := new(MockJobRepository)
+	events := &memoryStatusEventRepository{}
+	service := NewJobService(jobRepo, &ai.AIService{}, nil, nil, &config.Settings{})
+	service.SetStatusEventRepository(events)
+
+	jobRepo.On("UpdateStatus", mock.Anything, 1, 7, models.INTERVIEWING).
+		Run(func(mock.Arguments) {
+			applied := models.APPLIED
+			events.record(7, &applied, models.INTERVIEWING, time.Now().UTC())
+		}).Return(nil)
+
+	form := url.Values{"status": {"interviewing"}, "status_note": {"with the hiring manager"}}
+	req := httptest.NewRequest(http.MethodPut, "/jobs/7/status", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = req
+	c.Set("userID", 1)
+
+	job := &models.Job{ID: 7, Status: models.APPLIED}
+	message, err := (&statusCommand{}).Execute(c, job, service)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Job status updated to Interviewing", message)
+	assert.Equal(t, models.INTERVIEWING, job.Status)
+	require.Len(t, events.events, 1)
+	assert.Equal(t, "with the hiring manager", events.events[0].Note)
+}
@@ -0,0 +1,74 @@
+package job
+
+import (
+	"context"
+
+	"github.com/benidevo/vega/internal/job/interfaces"
+	"github.com/benidevo/vega/internal/job/models"
+)
+
+// SetTagRepository sets the repository used for the user's job tags.
+func (s *JobService) SetTagRepository(repo interfaces.TagRepository) {
+	s.tagRepo = repo
+}
+
+// GetTags returns the user's tags that are on at least one job, ordered by
+// name.
+func (s *JobService) GetTags(ctx context.Context, userID int) ([]*models.Tag, error) {
+	if s.tagRepo == nil {
+		return nil, nil
+	}
+
+	tags, err := s.tagRepo.GetTags(ctx, userID)
+	if err != nil {
+		s.log.Error().Err(err).Msg("Failed to get tags")
+		return nil, err
+	}
+	return tags, nil
+}
+
+// SetJobTags replaces the tags of a job. The tags are normalized first, so
+// that tags differing only in case or spacing are stored once.
+func (s *JobService) SetJobTags(ctx context.Context, userID int, job *models.Job, tags []string) error {
+	if s.tagRepo == nil {
+		return models.ErrTagStoreRequired
+	}
+
+	tags, err := models.NormalizeTags(tags)
+	if err != nil {
+		return err
+	}
+
+	if err := s.tagRepo.SetJobTags(ctx, userID, job.ID, tags); err != nil {
+		s.log.Error().
+			Int("job_id", job.ID).
+			Err(err).
+			Msg("Failed to save job tags")
+		return err
+	}
+
+	job.Tags = tags
+	return nil
+}
+
+// loadTags sets the tags of the given jobs. A failure leaves the jobs
+// without tags rather than failing the page showing them.
+func (s *JobService) loadTags(ctx context.Context, userID int, jobs ...*models.Job) {
+	if s.tagRepo == nil || len(jobs) == 0 {
+		return
+	}
+
+	ids := make([]int, len(jobs))
+	for i, job := range jobs {
+		ids[i] = job.ID
+	}
+
+	tags, err := s.tagRepo.GetJobTags(ctx, userID, ids)
+	if err != nil {
+		s.log.Warn().Err(err).Msg("Failed to load job tags")
+		return
+	}
+	for _, job := range jobs {
+		job.Tags = tags[job.ID]
+	}
+}
@@ -0,0 +1,152 @@
+package job
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/benidevo/vega/internal/ai"
+	"github.com/benidevo/vega/internal/config"
+	"github.com/benidevo/vega/internal/job/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryTagRepository keeps job tags in memory
+type memoryTagRepository struct {
+	jobTags map[int][]string
+}
+
+func (r *memoryTagRepository) GetTags(ctx context.Context, userID int) ([]*models.Tag, error) {
+	counts := map[string]int{}
+	for _, tags := range r.jobTags {
+		for _, tag := range tags {
+			counts[tag]++
+		}
+	}
+	var tags []*models.Tag
+	for name, count := range counts {
+		tags = append(tags, &models.Tag{Name: name, JobCount: count})
+	}
+	return tags, nil
+}
+
+func (r *memoryTagRepository) GetJobTags(ctx context.Context, userID int, jobIDs []int) (map[int][]string, error) {
+	tags := map[int][]string{}
+	for _, id := range jobIDs {
+		if jobTags, ok := r.jobTags[id]; ok {
+			tags[id] = jobTags
+		}
+	}
+	return tags, nil
+}
+
+func (r *memoryTagRepository) SetJobTags(ctx context.Context, userID, jobID int, tags []string) error {
+	r.jobTags[jobID] = tags
+	return nil
+}
+
+func TestTagsCommand_Execute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &memoryTagRepository{jobTags: map[int][]string{}}
+	service := NewJobService(&MockJobRepository{}, &ai.AIService{}, nil, nil, &config.Settings{})
+	service.SetTagRepository(repo)
+
+	execute := func(tags string) (*models.Job, string, error) {
+		form := url.Values{"tags": {tags}}
+		req := httptest.NewRequest(http.MethodPut, "/jobs/7/tags", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = req
+		c.Set("userID", 1)
+
+		job := &models.Job{ID: 7}
+		message, err := (&tagsCommand{}).Execute(c, job, service)
+		return job, message, err
+	}
+
+	t.Run("saves normalized tags", func(t *testing.T) {
+		job, message, err := execute(" Referral ,visa   sponsorship, referral,, ")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Tags updated successfully", message)
+		assert.Equal(t, []string{"Referral", "visa sponsorship"}, job.Tags)
+		assert.Equal(t, []string{"Referral", "visa sponsorship"}, repo.jobTags[7])
+	})
+
+	t.Run("clears tags", func(t *testing.T) {
+		job, _, err := execute("")
+
+		require.NoError(t, err)
+		assert.Empty(t, job.Tags)
+		assert.Empty(t, repo.jobTags[7])
+	})
+
+	t.Run("rejects tags that are too long", func(t *testing.T) {
+		_, _, err := execute(strings.Repeat("x", models.MaxTagLength+1))
+
+		assert.ErrorIs(t, err, models.ErrTagTooLong)
+	})
+
+	t.Run("requires the tag repository", func(t *testing.T) {
+		service := NewJobService(&MockJobRepository{}, &ai.AIService{}, nil, nil, &config.Settings{})
+		err := service.SetJobTags(context.Background(), 1, &models.Job{ID: 7}, []string{"referral"})
+
+		assert.ErrorIs(t, err, models.ErrTagStoreRequired)
+	})
+}
+
+func TestJobService_Tags(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("loads the tags of listed jobs", func(t *testing.T) {
+		jobRepo := new(MockJobRepository)
+		service := NewJobService(jobRepo, &ai.AIService{}, nil, nil, &config.Settings{})
+		service.SetTagRepository(&memoryTagRepository{jobTags: map[int][]string{1: {"referral"}}})
+
+		filter := models.JobFilter{Tags: []string{"referral"}, TagMatch: models.TagMatchAll, Limit: 12}
+		jobRepo.On("GetAll", ctx, 1, filter).Return([]*models.Job{{ID: 1}, {ID: 2}}, nil)
+		jobRepo.On("GetCount", ctx, 1, filter).Return(2, nil)
+
+		result, err := service.GetJobsWithPagination(ctx, 1, filter)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"referral"}, result.Jobs[0].Tags)
+		assert.Empty(t, result.Jobs[1].Tags)
+	})
+
+	t.Run("tags new jobs", func(t *testing.T) {
+		jobRepo := new(MockJobRepository)
+		repo := &memoryTagRepository{jobTags: map[int][]string{}}
+		service := NewJobService(jobRepo, &ai.AIService{}, nil, nil, &config.Settings{})
+		service.SetTagRepository(repo)
+
+		jobRepo.On("GetOrCreate", ctx, 1, mock.AnythingOfType("*models.Job")).
+			Return(&models.Job{ID: 5, Title: "Engineer"}, true, nil)
+
+		job, isNew, err := service.CreateJob(ctx, 1, "Engineer", "Build things", "Acme",
+			models.WithTags([]string{"Dream company", "dream company", "remote"}))
+
+		require.NoError(t, err)
+		assert.True(t, isNew)
+		assert.Equal(t, []string{"Dream company", "remote"}, job.Tags)
+		assert.Equal(t, []string{"Dream company", "remote"}, repo.jobTags[5])
+	})
+
+	t.Run("rejects too many tags on new jobs", func(t *testing.T) {
+		service := NewJobService(new(MockJobRepository), &ai.AIService{}, nil, nil, &config.Settings{})
+
+		tags := make([]string, models.MaxTagsPerJob+1)
+		for i := range tags {
+			tags[i] = strings.Repeat("t", i+1)
+		}
+		_, _, err := service.CreateJob(ctx, 1, "Engineer", "Build things", "Acme", models.WithTags(tags))
+
+		assert.ErrorIs(t, err, models.ErrTooManyTags)
+	})
+}
@@ -4,6 +4,9 @@ import (
 	"database/sql"
 
 	"github.com/benidevo/vega/internal/ai"
+	"github.com/benidevo/vega/internal/ai/privacy"
+	promptregistry "github.com/benidevo/vega/internal/ai/prompts/registry"
+	"github.com/benidevo/vega/internal/ai/usage"
 	authrepo "github.com/benidevo/vega/internal/auth/repository"
 	"github.com/benidevo/vega/internal/cache"
 	"github.com/benidevo/vega/internal/config"
@@ -17,20 +20,32 @@ import (
 
 // Setup initializes the job package dependencies and returns a JobHandler.
 func Setup(db *sql.DB, cfg *config.Settings, cache cache.Cache) *JobHandler {
-	service := SetupService(db, cfg, cache)
+	aiService, err := SetupAIService(cfg, SetupAIOptions(db, cfg, cache)...)
+	if err != nil {
+		aiService = nil
+	}
+
+	service := SetupService(db, cfg, cache, aiService)
 	return NewJobHandler(service, cfg)
 }
 
+// SetupAIOptions returns the options the job service's AI services are built
+// with, so that callers sharing the service build theirs the same way.
+func SetupAIOptions(db *sql.DB, cfg *config.Settings, cache cache.Cache) []ai.Option {
+	return []ai.Option{
+		ai.WithUsageTracking(usage.NewRepository(db)),
+		ai.WithResponseCache(cache),
+		ai.WithPromptSelector(promptregistry.Setup(db, cfg)),
+		ai.WithPIIRedaction(privacy.NewService(privacy.NewRepository(db))),
+	}
+}
+
 // SetupService initializes just the job service without the handler.
-func SetupService(db *sql.DB, cfg *config.Settings, cache cache.Cache) *JobService {
+//
+// aiService is optional. When nil, AI-dependent features (job matching,
+// cover letter generation) will return ErrAIServiceUnavailable.
+func SetupService(db *sql.DB, cfg *config.Settings, cache cache.Cache, aiService *ai.AIService) *JobService {
 	jobRepo := SetupJobRepository(db, cache)
-	aiService, err := SetupAIService(cfg)
-	if err != nil {
-		// AI service is optional.
-		// When nil, AI-dependent features (job matching, cover letter generation) will return
-		// ErrAIServiceUnavailable.
-		aiService = nil
-	}
 
 	settingsService := SetupSettingsService(db, cfg)
 
@@ -40,10 +55,28 @@ func SetupService(db *sql.DB, cfg *config.Settings, cache cache.Cache) *JobServi
 
 	jobService := SetupJobService(jobRepo, aiService, settingsService, quotaService, cfg)
 
+	// In cloud mode users may bring their own provider key, which replaces the
+	// server's AI service and lifts the AI analysis quota for them.
+	if cfg.IsCloudMode {
+		if credentialService, err := ai.SetupCredentials(db, cfg); err == nil {
+			quotaService.SetCredentialChecker(credentialService)
+			jobService.SetUserAIServices(ai.NewUserServices(cfg, credentialService, SetupAIOptions(db, cfg, cache)...))
+		}
+	}
+
 	// Setup document service and wire it to job service
 	documentService := documents.SetupService(db, cache)
 	jobService.SetDocumentService(documentService)
 
+	jobService.SetSkillGapRepository(repository.NewSQLiteSkillGapRepository(db))
+	jobService.SetInjectionReviewRepository(repository.NewSQLiteInjectionReviewRepository(db))
+	jobService.SetTagRepository(repository.NewSQLiteTagRepository(db))
+	jobService.SetStatusEventRepository(repository.NewSQLiteStatusEventRepository(db))
+	jobService.SetPipelineRepository(repository.NewSQLitePipelineRepository(db, cache))
+	jobService.SetReminderRepository(repository.NewSQLiteReminderRepository(db))
+	jobService.SetEmbeddings(ai.SetupEmbeddings(db, cfg))
+	settingsService.SetProfileListener(jobService)
+
 	return jobService
 }
 
@@ -54,8 +87,8 @@ func SetupJobRepository(db *sql.DB, cache cache.Cache) interfaces.JobRepository
 }
 
 // SetupAIService initializes and returns an AI service instance.
-func SetupAIService(cfg *config.Settings) (*ai.AIService, error) {
-	return ai.Setup(cfg)
+func SetupAIService(cfg *config.Settings, opts ...ai.Option) (*ai.AIService, error) {
+	return ai.Setup(cfg, opts...)
 }
 
 // SetupSettingsService initializes and returns a settings service instance for profile management.
@@ -84,7 +84,8 @@ func TestSetupService(t *testing.T) {
 			require.NoError(t, err)
 			defer db.Close()
 
-			service := SetupService(db, tt.cfg, tt.cache)
+			aiService, _ := SetupAIService(tt.cfg)
+			service := SetupService(db, tt.cfg, tt.cache, aiService)
 
 			assert.NotNil(t, service)
 		})
@@ -16,11 +16,18 @@ type JobRepository interface {
 	SetFirstAnalyzedAt(ctx context.Context, jobID int) error
 }
 
+// CredentialChecker reports whether a user pays for AI with their own
+// provider key.
+type CredentialChecker interface {
+	HasOwnKey(ctx context.Context, userID int) bool
+}
+
 // Service handles quota management
 type Service struct {
 	db          *sql.DB
 	repo        Repository
 	jobRepo     JobRepository
+	credentials CredentialChecker
 	isCloudMode bool
 }
 
@@ -34,12 +41,27 @@ func NewService(db *sql.DB, jobRepo JobRepository, isCloudMode bool) *Service {
 	}
 }
 
+// SetCredentialChecker lets users with their own AI provider key skip the
+// AI analysis quota
+func (s *Service) SetCredentialChecker(checker CredentialChecker) {
+	s.credentials = checker
+}
+
 // isUserAdmin checks if the user in context has admin role
 func (s *Service) isUserAdmin(ctx context.Context) bool {
 	role, _ := ctxutil.GetRole(ctx)
 	return role == "Admin"
 }
 
+// isUnlimited checks if the user is exempt from the AI analysis quota, either
+// as an admin or by using their own provider key
+func (s *Service) isUnlimited(ctx context.Context, userID int) bool {
+	if s.isUserAdmin(ctx) {
+		return true
+	}
+	return s.credentials != nil && s.credentials.HasOwnKey(ctx, userID)
+}
+
 // CanAnalyzeJob checks if a user can analyze a specific job
 func (s *Service) CanAnalyzeJob(ctx context.Context, userID int, jobID int) (*QuotaCheckResult, error) {
 	// Check if job was previously analyzed
@@ -55,8 +77,8 @@ func (s *Service) CanAnalyzeJob(ctx context.Context, userID int, jobID int) (*Qu
 		return nil, fmt.Errorf("failed to get monthly usage: %w", err)
 	}
 
-	// Check if user is admin (unlimited AI analysis quota in cloud mode)
-	if s.isCloudMode && s.isUserAdmin(ctx) {
+	// Admins and users with their own key have unlimited AI analysis quota in cloud mode
+	if s.isCloudMode && s.isUnlimited(ctx, userID) {
 		status := QuotaStatus{
 			Used:      usage.JobsAnalyzed,
 			Limit:     -1,
@@ -177,8 +199,8 @@ func (s *Service) GetQuotaStatus(ctx context.Context, userID int) (*QuotaStatus,
 		return nil, err
 	}
 
-	// Check if user is admin (unlimited AI analysis quota in cloud mode)
-	if s.isCloudMode && s.isUserAdmin(ctx) {
+	// Admins and users with their own key have unlimited AI analysis quota in cloud mode
+	if s.isCloudMode && s.isUnlimited(ctx, userID) {
 		return &QuotaStatus{
 			Used:      usage.JobsAnalyzed,
 			Limit:     -1,
@@ -24,6 +24,12 @@ func NewUnifiedService(db *sql.DB, jobRepo JobRepository, isCloudMode bool) *Uni
 	}
 }
 
+// SetCredentialChecker lets users with their own AI provider key skip the
+// AI analysis quota
+func (s *UnifiedService) SetCredentialChecker(checker CredentialChecker) {
+	s.aiQuota.SetCredentialChecker(checker)
+}
+
 // CheckQuota checks any quota type
 func (s *UnifiedService) CheckQuota(ctx context.Context, userID int, quotaType string, metadata map[string]interface{}) (*QuotaCheckResult, error) {
 	switch quotaType {
@@ -189,6 +189,47 @@ func TestUnifiedService_CheckQuota(t *testing.T) {
 	}
 }
 
+type stubCredentialChecker map[int]bool
+
+func (s stubCredentialChecker) HasOwnKey(ctx context.Context, userID int) bool {
+	return s[userID]
+}
+
+func TestUnifiedService_SkipsAIQuotaForOwnKey(t *testing.T) {
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	jobRepo := &MockUnifiedJobRepository{}
+	jobRepo.On("GetByID", context.Background(), 1, 123).
+		Return(&Job{ID: 123, FirstAnalyzedAt: nil}, nil)
+
+	// Usage is still read for display, but quota_configs is never consulted
+	monthYear := timeutil.GetCurrentMonthYear()
+	for i := 0; i < 2; i++ {
+		sqlMock.ExpectQuery("SELECT user_id, month_year, jobs_analyzed, updated_at FROM user_quota_usage").
+			WithArgs(1, monthYear).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "month_year", "jobs_analyzed", "updated_at"}).
+				AddRow(1, monthYear, 50, time.Now()))
+	}
+
+	service := NewUnifiedService(db, jobRepo, true)
+	service.SetCredentialChecker(stubCredentialChecker{1: true})
+
+	result, err := service.CheckQuota(context.Background(), 1, QuotaTypeAIAnalysis, map[string]interface{}{"job_id": 123})
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, -1, result.Status.Limit)
+	assert.Equal(t, 50, result.Status.Used)
+
+	status, err := service.AIQuotaService().GetQuotaStatus(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, -1, status.Limit)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	jobRepo.AssertExpectations(t)
+}
+
 func TestUnifiedService_RecordUsage(t *testing.T) {
 	tests := []struct {
 		name          string
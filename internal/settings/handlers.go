@@ -2,12 +2,19 @@ package settings
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/benidevo/vega/internal/ai"
+	"github.com/benidevo/vega/internal/ai/credentials"
 	aimodels "github.com/benidevo/vega/internal/ai/models"
+	"github.com/benidevo/vega/internal/ai/privacy"
+	"github.com/benidevo/vega/internal/ai/prompts"
+	"github.com/benidevo/vega/internal/ai/prompts/registry"
+	"github.com/benidevo/vega/internal/ai/usage"
 	authmodels "github.com/benidevo/vega/internal/auth/models"
 	"github.com/benidevo/vega/internal/common/alerts"
 	ctxutil "github.com/benidevo/vega/internal/common/context"
@@ -24,6 +31,12 @@ type SettingsHandler struct {
 	quotaService interface {
 		GetAllQuotaStatus(ctx context.Context, userID int) (interface{}, error)
 	}
+	usageService interface {
+		GetSummary(ctx context.Context, userID int) (*usage.Summary, error)
+	}
+	credentialService    CredentialService
+	privacyService       PrivacyService
+	promptRegistry       PromptRegistry
 	experienceHandler    *BaseSettingsHandler
 	educationHandler     *BaseSettingsHandler
 	certificationHandler *BaseSettingsHandler
@@ -69,6 +82,39 @@ func NewSettingsHandler(service *SettingsService, aiService *ai.AIService, quota
 	}
 }
 
+// CredentialService manages users' own AI provider keys
+type CredentialService interface {
+	Get(ctx context.Context, userID int) (*credentials.Credential, error)
+	Save(ctx context.Context, userID int, provider, apiKey string) (*credentials.Credential, error)
+	Remove(ctx context.Context, userID int) error
+	Test(ctx context.Context, userID int) error
+}
+
+// SetCredentialService enables users to manage their own AI provider key
+// on the account page
+func (h *SettingsHandler) SetCredentialService(credentialService CredentialService) {
+	h.credentialService = credentialService
+}
+
+// PrivacyService manages which AI tasks redact users' personal details
+type PrivacyService interface {
+	GetSettings(ctx context.Context, userID int) ([]privacy.Setting, error)
+	SaveSettings(ctx context.Context, userID int, enabledTasks []string) error
+}
+
+// SetPrivacyService enables users to choose which AI tasks redact their
+// personal details on the account page
+func (h *SettingsHandler) SetPrivacyService(privacyService PrivacyService) {
+	h.privacyService = privacyService
+}
+
+// SetUsageService sets the LLM usage service used on the quotas page
+func (h *SettingsHandler) SetUsageService(usageService interface {
+	GetSummary(ctx context.Context, userID int) (*usage.Summary, error)
+}) {
+	h.usageService = usageService
+}
+
 // formatValidationError formats validation errors into user-friendly messages
 func (h *SettingsHandler) formatValidationError(err error) string {
 	if validationErrors, ok := err.(validator.ValidationErrors); ok {
@@ -299,6 +345,42 @@ func (h *SettingsHandler) HandleUpdateContext(c *gin.Context) {
 	c.Status(http.StatusOK)
 }
 
+// HandleUpdateLetterDefaults handles the HTTP request to update a user's
+// default cover letter tone, length and language
+func (h *SettingsHandler) HandleUpdateLetterDefaults(c *gin.Context) {
+	userID := c.GetInt("userID")
+
+	options := aimodels.CoverLetterOptions{
+		Tone:   aimodels.LetterTone(strings.TrimSpace(c.PostForm("tone"))),
+		Length: aimodels.LetterLength(strings.TrimSpace(c.PostForm("length"))),
+	}
+	language := strings.TrimSpace(c.PostForm("language"))
+	if err := options.Validate(); err != nil || (language != "" && !aimodels.IsValidLanguage(language)) {
+		alerts.RenderError(c, http.StatusBadRequest, "Please choose a tone, length and language from the list", alerts.ContextDashboard)
+		return
+	}
+
+	profile, err := h.service.GetProfileSettings(c.Request.Context(), userID)
+	if err != nil {
+		alerts.RenderError(c, http.StatusInternalServerError, "Failed to load profile settings", alerts.ContextDashboard)
+		return
+	}
+
+	profile.LetterTone = string(options.Tone)
+	profile.LetterLength = string(options.Length)
+	profile.LetterLanguage = language
+
+	err = h.service.UpdateProfile(c.Request.Context(), profile)
+	if err != nil {
+		alerts.TriggerToast(c, "Failed to update cover letter defaults", alerts.TypeError)
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	alerts.TriggerToast(c, "Cover letter defaults updated successfully", alerts.TypeSuccess)
+	c.Status(http.StatusOK)
+}
+
 // HandleCVUpload handles the HTTP request to parse and save CV data
 func (h *SettingsHandler) HandleCVUpload(c *gin.Context) {
 	userID := c.GetInt("userID")
@@ -333,7 +415,7 @@ func (h *SettingsHandler) HandleCVUpload(c *gin.Context) {
 		return
 	}
 
-	cvResult, err := h.aiService.CVParser.ParseCV(c.Request.Context(), requestData.CVText)
+	cvResult, err := h.aiService.CVParser.ParseCV(ctxutil.WithUserID(c.Request.Context(), userID), requestData.CVText)
 	if err != nil {
 		h.service.log.Error().Err(err).Msg("Failed to parse CV with AI")
 
@@ -576,9 +658,52 @@ func (h *SettingsHandler) GetAccountSettingsPage(c *gin.Context) {
 		}
 	}
 
+	if h.credentialService != nil {
+		for key, value := range h.aiKeySettingsData(c.Request.Context(), userID) {
+			data[key] = value
+		}
+	}
+
+	if h.privacyService != nil {
+		redaction, err := h.privacyService.GetSettings(c.Request.Context(), userID)
+		if err != nil {
+			h.service.log.Error().Err(err).Int("user_id", userID).Msg("Failed to load redaction settings")
+		} else {
+			data["redactionSettings"] = redaction
+		}
+	}
+
 	h.renderer.HTML(c, http.StatusOK, "layouts/base.html", data)
 }
 
+// HandleUpdateRedaction saves which AI tasks redact the user's personal
+// details. Tasks left unchecked send prompts unchanged.
+func (h *SettingsHandler) HandleUpdateRedaction(c *gin.Context) {
+	userID := c.GetInt("userID")
+
+	if h.privacyService == nil {
+		alerts.TriggerToast(c, "Privacy settings are not available", alerts.TypeError)
+		c.Status(http.StatusServiceUnavailable)
+		return
+	}
+
+	err := h.privacyService.SaveSettings(c.Request.Context(), userID, c.PostFormArray("redact"))
+	if err != nil {
+		if errors.Is(err, privacy.ErrUnknownTask) {
+			alerts.TriggerToast(c, "Please choose tasks from the list", alerts.TypeError)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		h.service.log.Error().Err(err).Int("user_id", userID).Msg("Failed to save redaction settings")
+		alerts.TriggerToast(c, "Failed to update privacy settings", alerts.TypeError)
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	alerts.TriggerToast(c, "Privacy settings updated successfully", alerts.TypeSuccess)
+	c.Status(http.StatusOK)
+}
+
 // GetAddExperiencePage handles the HTTP request to render the page for adding a new experience.
 func (h *SettingsHandler) GetAddExperiencePage(c *gin.Context) {
 	h.experienceHandler.GetAddPage(c)
@@ -680,6 +805,16 @@ func (h *SettingsHandler) GetQuotasPage(c *gin.Context) {
 		hasQuotaData = quotaStatus != nil
 	}
 
+	var usageSummary *usage.Summary
+	if h.usageService != nil {
+		summary, err := h.usageService.GetSummary(c.Request.Context(), userID)
+		if err != nil {
+			h.service.log.Error().Err(err).Int("user_id", userID).Msg("Failed to load LLM usage summary")
+		} else {
+			usageSummary = summary
+		}
+	}
+
 	data := gin.H{
 		"title":          "Usage & Quotas",
 		"activeNav":      "quotas",
@@ -688,12 +823,140 @@ func (h *SettingsHandler) GetQuotasPage(c *gin.Context) {
 		"pageTitle":      "Usage & Quotas",
 		"quotaStatus":    quotaStatus,
 		"hasQuotaData":   hasQuotaData,
+		"usageSummary":   usageSummary,
 		"isCloudMode":    h.service.cfg.IsCloudMode,
 	}
 
 	h.renderer.HTML(c, http.StatusOK, "layouts/base.html", data)
 }
 
+// GetUsageSummary returns the user's LLM usage aggregates as JSON
+func (h *SettingsHandler) GetUsageSummary(c *gin.Context) {
+	userIDValue, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userID := userIDValue.(int)
+
+	if h.usageService == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Usage tracking is not available"})
+		return
+	}
+
+	summary, err := h.usageService.GetSummary(c.Request.Context(), userID)
+	if err != nil {
+		h.service.log.Error().Err(err).Int("user_id", userID).Msg("Failed to load LLM usage summary")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load usage"})
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// HandleSaveAPIKey verifies and stores the user's own AI provider key
+func (h *SettingsHandler) HandleSaveAPIKey(c *gin.Context) {
+	if h.credentialService == nil {
+		alerts.TriggerToast(c, "Using your own API key is not available", alerts.TypeError)
+		c.Status(http.StatusNotFound)
+		return
+	}
+	userID := c.GetInt("userID")
+
+	provider := c.PostForm("provider")
+	apiKey := c.PostForm("api_key")
+
+	if _, err := h.credentialService.Save(c.Request.Context(), userID, provider, apiKey); err != nil {
+		h.handleCredentialError(c, userID, err)
+		return
+	}
+
+	alerts.TriggerToast(c, "API key saved. AI features now use your own key", alerts.TypeSuccess)
+	h.renderAIKeySettings(c, userID)
+}
+
+// HandleTestAPIKey checks the user's stored AI provider key against the provider
+func (h *SettingsHandler) HandleTestAPIKey(c *gin.Context) {
+	if h.credentialService == nil {
+		alerts.TriggerToast(c, "Using your own API key is not available", alerts.TypeError)
+		c.Status(http.StatusNotFound)
+		return
+	}
+	userID := c.GetInt("userID")
+
+	if err := h.credentialService.Test(c.Request.Context(), userID); err != nil {
+		h.handleCredentialError(c, userID, err)
+		return
+	}
+
+	alerts.TriggerToast(c, "Your API key is working", alerts.TypeSuccess)
+	c.Status(http.StatusOK)
+}
+
+// HandleRemoveAPIKey deletes the user's own AI provider key
+func (h *SettingsHandler) HandleRemoveAPIKey(c *gin.Context) {
+	if h.credentialService == nil {
+		alerts.TriggerToast(c, "Using your own API key is not available", alerts.TypeError)
+		c.Status(http.StatusNotFound)
+		return
+	}
+	userID := c.GetInt("userID")
+
+	if err := h.credentialService.Remove(c.Request.Context(), userID); err != nil {
+		h.handleCredentialError(c, userID, err)
+		return
+	}
+
+	alerts.TriggerToast(c, "API key removed", alerts.TypeSuccess)
+	h.renderAIKeySettings(c, userID)
+}
+
+// handleCredentialError reports a credential operation failure as a toast
+func (h *SettingsHandler) handleCredentialError(c *gin.Context, userID int, err error) {
+	switch {
+	case errors.Is(err, credentials.ErrUnsupportedProvider):
+		alerts.TriggerToast(c, "Please choose a supported AI provider", alerts.TypeError)
+		c.Status(http.StatusBadRequest)
+	case errors.Is(err, credentials.ErrEmptyAPIKey):
+		alerts.TriggerToast(c, "API key is required", alerts.TypeError)
+		c.Status(http.StatusBadRequest)
+	case errors.Is(err, credentials.ErrVerificationFailed):
+		alerts.TriggerToast(c, "The provider rejected this API key. Check the key and try again", alerts.TypeError)
+		c.Status(http.StatusBadRequest)
+	case errors.Is(err, credentials.ErrCredentialNotFound):
+		alerts.TriggerToast(c, "No API key saved", alerts.TypeError)
+		c.Status(http.StatusNotFound)
+	case errors.Is(err, credentials.ErrDecryptionFailed):
+		alerts.TriggerToast(c, "Your saved API key can no longer be read. Please enter it again", alerts.TypeError)
+		c.Status(http.StatusConflict)
+	default:
+		h.service.log.Error().Err(err).Int("user_id", userID).Msg("Failed to manage AI provider key")
+		alerts.TriggerToast(c, "Something went wrong. Please try again", alerts.TypeError)
+		c.Status(http.StatusInternalServerError)
+	}
+}
+
+// aiKeySettingsData returns the template data for the AI provider key section
+func (h *SettingsHandler) aiKeySettingsData(ctx context.Context, userID int) gin.H {
+	data := gin.H{
+		"aiKeyEnabled": true,
+		"aiProviders":  credentials.SupportedProviders,
+	}
+
+	credential, err := h.credentialService.Get(ctx, userID)
+	if err == nil {
+		data["aiCredential"] = credential
+	} else if !errors.Is(err, credentials.ErrCredentialNotFound) {
+		h.service.log.Error().Err(err).Int("user_id", userID).Msg("Failed to load AI provider key")
+	}
+	return data
+}
+
+// renderAIKeySettings renders the AI provider key section of the account page
+func (h *SettingsHandler) renderAIKeySettings(c *gin.Context, userID int) {
+	h.renderer.HTML(c, http.StatusOK, "partials/ai-key-settings", h.aiKeySettingsData(c.Request.Context(), userID))
+}
+
 // HandleUpdateAccount handles updating username and/or password for self-hosted users
 func (h *SettingsHandler) HandleUpdateAccount(c *gin.Context) {
 	if h.service.cfg.IsCloudMode {
@@ -825,3 +1088,159 @@ func (h *SettingsHandler) clearAuthCookies(c *gin.Context) {
 	c.SetCookie("token", "", -1, "/", h.service.cfg.CookieDomain, h.service.cfg.CookieSecure, true)
 	c.SetCookie("refresh_token", "", -1, "/", h.service.cfg.CookieDomain, h.service.cfg.CookieSecure, true)
 }
+
+// PromptRegistry manages versioned prompt templates and their rollouts
+type PromptRegistry interface {
+	Versions(ctx context.Context, name string) ([]*registry.Entry, error)
+	AddVersion(ctx context.Context, version *prompts.Version) error
+	Rollouts(ctx context.Context) ([]*registry.Rollout, error)
+	SetRollout(ctx context.Context, rollout *registry.Rollout) error
+}
+
+// promptRollout is a prompt's rollout together with the versions it can use
+type promptRollout struct {
+	*registry.Rollout
+	Versions []*registry.Entry
+}
+
+// SetPromptRegistry enables admins to manage prompt versions
+func (h *SettingsHandler) SetPromptRegistry(promptRegistry PromptRegistry) {
+	h.promptRegistry = promptRegistry
+}
+
+// GetPromptsPage displays the prompt versions and rollouts to admins
+func (h *SettingsHandler) GetPromptsPage(c *gin.Context) {
+	if c.GetString("role") != "Admin" {
+		h.renderer.Error(c, http.StatusForbidden, "Forbidden")
+		return
+	}
+	if h.promptRegistry == nil {
+		h.renderer.Error(c, http.StatusNotFound, "Page Not Found")
+		return
+	}
+
+	data, err := h.promptRolloutsData(c.Request.Context())
+	if err != nil {
+		h.service.log.Error().Err(err).Msg("Failed to load prompt rollouts")
+		h.renderer.Error(c, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	data["title"] = "Prompts"
+	data["activeNav"] = "prompts"
+	data["page"] = "settings-prompts"
+	data["activeSettings"] = "prompts"
+	data["pageTitle"] = "Prompts"
+
+	h.renderer.HTML(c, http.StatusOK, "layouts/base.html", data)
+}
+
+// HandleSavePromptRollout updates which versions of a prompt are served
+func (h *SettingsHandler) HandleSavePromptRollout(c *gin.Context) {
+	if !h.canManagePrompts(c) {
+		return
+	}
+
+	percent, err := strconv.Atoi(c.DefaultPostForm("candidate_percent", "0"))
+	if err != nil {
+		alerts.TriggerToast(c, "Candidate percentage must be a whole number", alerts.TypeError)
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	rollout := &registry.Rollout{
+		Name:             c.PostForm("name"),
+		DefaultVersion:   strings.TrimSpace(c.PostForm("default_version")),
+		CandidateVersion: strings.TrimSpace(c.PostForm("candidate_version")),
+		CandidatePercent: percent,
+	}
+	if err := h.promptRegistry.SetRollout(c.Request.Context(), rollout); err != nil {
+		h.handlePromptError(c, err)
+		return
+	}
+
+	alerts.TriggerToast(c, "Prompt rollout saved", alerts.TypeSuccess)
+	h.renderPromptRollouts(c)
+}
+
+// HandleAddPromptVersion stores a new prompt version from its JSON definition
+func (h *SettingsHandler) HandleAddPromptVersion(c *gin.Context) {
+	if !h.canManagePrompts(c) {
+		return
+	}
+
+	version, err := prompts.ParseVersion([]byte(c.PostForm("definition")))
+	if err != nil {
+		h.handlePromptError(c, err)
+		return
+	}
+	if err := h.promptRegistry.AddVersion(c.Request.Context(), version); err != nil {
+		h.handlePromptError(c, err)
+		return
+	}
+
+	alerts.TriggerToast(c, "Prompt version added", alerts.TypeSuccess)
+	h.renderPromptRollouts(c)
+}
+
+// canManagePrompts reports whether the request may change prompt versions,
+// responding with an error toast when it may not
+func (h *SettingsHandler) canManagePrompts(c *gin.Context) bool {
+	if c.GetString("role") != "Admin" {
+		alerts.TriggerToast(c, "Only admins can manage prompts", alerts.TypeError)
+		c.Status(http.StatusForbidden)
+		return false
+	}
+	if h.promptRegistry == nil {
+		alerts.TriggerToast(c, "Prompt versions are not available", alerts.TypeError)
+		c.Status(http.StatusNotFound)
+		return false
+	}
+	return true
+}
+
+// handlePromptError reports a prompt registry failure as a toast
+func (h *SettingsHandler) handlePromptError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, prompts.ErrInvalidPromptVersion),
+		errors.Is(err, prompts.ErrUnknownPrompt),
+		errors.Is(err, registry.ErrInvalidRollout):
+		alerts.TriggerToast(c, err.Error(), alerts.TypeError)
+		c.Status(http.StatusBadRequest)
+	case errors.Is(err, registry.ErrVersionExists):
+		alerts.TriggerToast(c, "A version with this name already exists", alerts.TypeError)
+		c.Status(http.StatusConflict)
+	default:
+		h.service.log.Error().Err(err).Msg("Failed to manage prompt versions")
+		alerts.TriggerToast(c, "Something went wrong. Please try again", alerts.TypeError)
+		c.Status(http.StatusInternalServerError)
+	}
+}
+
+// promptRolloutsData returns the template data for the prompt rollouts section
+func (h *SettingsHandler) promptRolloutsData(ctx context.Context) (gin.H, error) {
+	rollouts, err := h.promptRegistry.Rollouts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]promptRollout, 0, len(rollouts))
+	for _, rollout := range rollouts {
+		versions, err := h.promptRegistry.Versions(ctx, rollout.Name)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, promptRollout{Rollout: rollout, Versions: versions})
+	}
+	return gin.H{"promptRollouts": items}, nil
+}
+
+// renderPromptRollouts renders the prompt rollouts section of the prompts page
+func (h *SettingsHandler) renderPromptRollouts(c *gin.Context) {
+	data, err := h.promptRolloutsData(c.Request.Context())
+	if err != nil {
+		h.handlePromptError(c, err)
+		return
+	}
+	h.renderer.HTML(c, http.StatusOK, "partials/prompt-rollouts", data)
+}
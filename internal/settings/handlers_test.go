@@ -1,11 +1,19 @@
 package settings
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/benidevo/vega/internal/ai"
+	"github.com/benidevo/vega/internal/ai/credentials"
+	"github.com/benidevo/vega/internal/ai/privacy"
+	"github.com/benidevo/vega/internal/ai/prompts"
+	"github.com/benidevo/vega/internal/ai/prompts/registry"
 	"github.com/benidevo/vega/internal/config"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -167,3 +175,327 @@ func TestSettingsRouteStructure(t *testing.T) {
 	assert.Equal(t, http.StatusFound, w.Code)
 	assert.Equal(t, "/settings/profile", w.Header().Get("Location"))
 }
+
+type stubCredentialService struct {
+	testErr error
+}
+
+func (s *stubCredentialService) Get(ctx context.Context, userID int) (*credentials.Credential, error) {
+	return nil, credentials.ErrCredentialNotFound
+}
+
+func (s *stubCredentialService) Save(ctx context.Context, userID int, provider, apiKey string) (*credentials.Credential, error) {
+	return nil, credentials.ErrEmptyAPIKey
+}
+
+func (s *stubCredentialService) Remove(ctx context.Context, userID int) error {
+	return nil
+}
+
+func (s *stubCredentialService) Test(ctx context.Context, userID int) error {
+	return s.testErr
+}
+
+func TestHandleAPIKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		service        CredentialService
+		expectedStatus int
+		expectedToast  string
+	}{
+		{
+			name:           "should_report_working_key",
+			method:         http.MethodPost,
+			path:           "/settings/account/api-key/test",
+			service:        &stubCredentialService{},
+			expectedStatus: http.StatusOK,
+			expectedToast:  "Your API key is working",
+		},
+		{
+			name:           "should_report_rejected_key",
+			method:         http.MethodPost,
+			path:           "/settings/account/api-key/test",
+			service:        &stubCredentialService{testErr: credentials.WrapError(credentials.ErrVerificationFailed, assert.AnError)},
+			expectedStatus: http.StatusBadRequest,
+			expectedToast:  "The provider rejected this API key",
+		},
+		{
+			name:           "should_report_missing_key",
+			method:         http.MethodPost,
+			path:           "/settings/account/api-key/test",
+			service:        &stubCredentialService{testErr: credentials.ErrCredentialNotFound},
+			expectedStatus: http.StatusNotFound,
+			expectedToast:  "No API key saved",
+		},
+		{
+			name:           "should_reject_empty_key",
+			method:         http.MethodPost,
+			path:           "/settings/account/api-key",
+			service:        &stubCredentialService{},
+			expectedStatus: http.StatusBadRequest,
+			expectedToast:  "API key is required",
+		},
+		{
+			name:           "should_be_unavailable_without_credential_service",
+			method:         http.MethodPost,
+			path:           "/settings/account/api-key/test",
+			expectedStatus: http.StatusNotFound,
+			expectedToast:  "not available",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &SettingsHandler{}
+			if tt.service != nil {
+				handler.SetCredentialService(tt.service)
+			}
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Set("userID", 1)
+			})
+			RegisterRoutes(router.Group("/settings"), handler)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Header().Get("HX-Trigger"), tt.expectedToast)
+		})
+	}
+}
+
+func TestHandleUpdateLetterDefaults(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name string
+		form url.Values
+	}{
+		{
+			name: "should_reject_unknown_tone",
+			form: url.Values{"tone": {"sarcastic"}, "length": {"short"}, "language": {"de"}},
+		},
+		{
+			name: "should_reject_unknown_length",
+			form: url.Values{"tone": {"warm"}, "length": {"epic"}},
+		},
+		{
+			name: "should_reject_unsupported_language",
+			form: url.Values{"language": {"xx"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The options are checked before the profile is loaded, so no
+			// service is needed.
+			handler := &SettingsHandler{}
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Set("userID", 1)
+			})
+			RegisterRoutes(router.Group("/settings"), handler)
+
+			req := httptest.NewRequest(http.MethodPost, "/settings/profile/letter-defaults", strings.NewReader(tt.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.Header.Set("HX-Request", "true")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Header().Get("HX-Trigger"), "Please choose a tone, length and language from the list")
+		})
+	}
+}
+
+type stubPrivacyService struct {
+	saved   []string
+	saveErr error
+}
+
+func (s *stubPrivacyService) GetSettings(ctx context.Context, userID int) ([]privacy.Setting, error) {
+	return nil, nil
+}
+
+func (s *stubPrivacyService) SaveSettings(ctx context.Context, userID int, enabledTasks []string) error {
+	s.saved = enabledTasks
+	return s.saveErr
+}
+
+func TestHandleUpdateRedaction(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		form           url.Values
+		service        *stubPrivacyService
+		expectedStatus int
+		expectedToast  string
+	}{
+		{
+			name:           "should_save_checked_tasks",
+			form:           url.Values{"redact": {"match_result", "cover_letter"}},
+			service:        &stubPrivacyService{},
+			expectedStatus: http.StatusOK,
+			expectedToast:  "Privacy settings updated successfully",
+		},
+		{
+			name:           "should_reject_unknown_task",
+			form:           url.Values{"redact": {"poems"}},
+			service:        &stubPrivacyService{saveErr: privacy.WrapError(privacy.ErrUnknownTask, errors.New("task 'poems' cannot be redacted"))},
+			expectedStatus: http.StatusBadRequest,
+			expectedToast:  "Please choose tasks from the list",
+		},
+		{
+			name:           "should_be_unavailable_without_service",
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedToast:  "Privacy settings are not available",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &SettingsHandler{}
+			if tt.service != nil {
+				handler.SetPrivacyService(tt.service)
+			}
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Set("userID", 1)
+			})
+			RegisterRoutes(router.Group("/settings"), handler)
+
+			req := httptest.NewRequest(http.MethodPost, "/settings/account/privacy", strings.NewReader(tt.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Header().Get("HX-Trigger"), tt.expectedToast)
+			if tt.service != nil {
+				assert.Equal(t, tt.form["redact"], tt.service.saved)
+			}
+		})
+	}
+}
+
+type stubPromptRegistry struct {
+	addErr        error
+	setRolloutErr error
+}
+
+func (s *stubPromptRegistry) Versions(ctx context.Context, name string) ([]*registry.Entry, error) {
+	return nil, nil
+}
+
+func (s *stubPromptRegistry) AddVersion(ctx context.Context, version *prompts.Version) error {
+	return s.addErr
+}
+
+func (s *stubPromptRegistry) Rollouts(ctx context.Context) ([]*registry.Rollout, error) {
+	return nil, nil
+}
+
+func (s *stubPromptRegistry) SetRollout(ctx context.Context, rollout *registry.Rollout) error {
+	return s.setRolloutErr
+}
+
+func TestHandlePrompts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	validDefinition := `{"name": "cover_letter", "version": "v2", "template": {"role": "Writer", "task": "Write a letter"}}`
+
+	tests := []struct {
+		name           string
+		role           string
+		path           string
+		form           url.Values
+		registry       PromptRegistry
+		expectedStatus int
+		expectedToast  string
+	}{
+		{
+			name:           "should_reject_non_admin",
+			role:           "Standard",
+			path:           "/settings/prompts/rollout",
+			registry:       &stubPromptRegistry{},
+			expectedStatus: http.StatusForbidden,
+			expectedToast:  "Only admins can manage prompts",
+		},
+		{
+			name:           "should_be_unavailable_without_registry",
+			role:           "Admin",
+			path:           "/settings/prompts/rollout",
+			expectedStatus: http.StatusNotFound,
+			expectedToast:  "not available",
+		},
+		{
+			name:           "should_reject_non_numeric_percentage",
+			role:           "Admin",
+			path:           "/settings/prompts/rollout",
+			form:           url.Values{"name": {"cover_letter"}, "default_version": {"builtin"}, "candidate_percent": {"ten"}},
+			registry:       &stubPromptRegistry{},
+			expectedStatus: http.StatusBadRequest,
+			expectedToast:  "whole number",
+		},
+		{
+			name:           "should_report_invalid_rollout",
+			role:           "Admin",
+			path:           "/settings/prompts/rollout",
+			form:           url.Values{"name": {"cover_letter"}, "default_version": {"missing"}},
+			registry:       &stubPromptRegistry{setRolloutErr: registry.WrapError(registry.ErrInvalidRollout, errors.New("cover_letter has no version 'missing'"))},
+			expectedStatus: http.StatusBadRequest,
+			expectedToast:  "has no version",
+		},
+		{
+			name:           "should_reject_invalid_definition",
+			role:           "Admin",
+			path:           "/settings/prompts/versions",
+			form:           url.Values{"definition": {"not json"}},
+			registry:       &stubPromptRegistry{},
+			expectedStatus: http.StatusBadRequest,
+			expectedToast:  "invalid prompt version",
+		},
+		{
+			name:           "should_report_existing_version",
+			role:           "Admin",
+			path:           "/settings/prompts/versions",
+			form:           url.Values{"definition": {validDefinition}},
+			registry:       &stubPromptRegistry{addErr: registry.ErrVersionExists},
+			expectedStatus: http.StatusConflict,
+			expectedToast:  "already exists",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &SettingsHandler{}
+			if tt.registry != nil {
+				handler.SetPromptRegistry(tt.registry)
+			}
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Set("userID", 1)
+				c.Set("role", tt.role)
+			})
+			RegisterRoutes(router.Group("/settings"), handler)
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Header().Get("HX-Trigger"), tt.expectedToast)
+		})
+	}
+}
@@ -110,6 +110,9 @@ type Profile struct {
 	GitHubProfile   string    `json:"github_profile" db:"github_profile" sql:"type:text" validate:"omitempty,github,max=500"`
 	Website         string    `json:"website" db:"website" sql:"type:text" validate:"omitempty,url,max=500"`
 	Context         string    `json:"context" db:"context" sql:"type:text" validate:"max=6000"`
+	LetterTone      string    `json:"letter_tone" db:"letter_tone" sql:"type:text" validate:"omitempty,oneof=formal warm concise enthusiastic"`
+	LetterLength    string    `json:"letter_length" db:"letter_length" sql:"type:text" validate:"omitempty,oneof=short standard long"`
+	LetterLanguage  string    `json:"letter_language" db:"letter_language" sql:"type:text" validate:"omitempty,oneof=en de es fr it nl pl pt"`
 	CreatedAt       time.Time `json:"created_at" db:"created_at" sql:"type:timestamp;not null;default:current_timestamp"`
 	UpdatedAt       time.Time `json:"updated_at" db:"updated_at" sql:"type:timestamp;not null;default:current_timestamp"`
 
@@ -23,6 +23,7 @@ func (r *ProfileRepository) GetProfile(ctx context.Context, userID int) (*models
 		SELECT id, user_id, first_name, last_name, title, industry,
 		       career_summary, skills, phone_number, email, location,
 		       linkedin_profile, github_profile, website, context,
+		       letter_tone, letter_length, letter_language,
 		       created_at, updated_at
 		FROM profiles
 		WHERE user_id = ?`
@@ -35,6 +36,7 @@ func (r *ProfileRepository) GetProfile(ctx context.Context, userID int) (*models
 		&profile.Title, &profile.Industry, &profile.CareerSummary, &skillsJSON,
 		&profile.PhoneNumber, &profile.Email, &profile.Location, &profile.LinkedInProfile,
 		&profile.GitHubProfile, &profile.Website, &profile.Context,
+		&profile.LetterTone, &profile.LetterLength, &profile.LetterLanguage,
 		&profile.CreatedAt, &profile.UpdatedAt,
 	)
 
@@ -148,8 +150,9 @@ func (r *ProfileRepository) UpdateProfile(ctx context.Context, profile *models.P
 	query := `
 		INSERT INTO profiles (
 			user_id, first_name, last_name, title, industry, career_summary, skills,
-			phone_number, email, location, linkedin_profile, github_profile, website, context, updated_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			phone_number, email, location, linkedin_profile, github_profile, website, context,
+			letter_tone, letter_length, letter_language, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(user_id) DO UPDATE SET
 			first_name = excluded.first_name,
 			last_name = excluded.last_name,
@@ -164,6 +167,9 @@ func (r *ProfileRepository) UpdateProfile(ctx context.Context, profile *models.P
 			github_profile = excluded.github_profile,
 			website = excluded.website,
 			context = excluded.context,
+			letter_tone = excluded.letter_tone,
+			letter_length = excluded.letter_length,
+			letter_language = excluded.letter_language,
 			updated_at = excluded.updated_at
 		RETURNING id, updated_at`
 
@@ -175,7 +181,8 @@ func (r *ProfileRepository) UpdateProfile(ctx context.Context, profile *models.P
 		profile.UserID, profile.FirstName, profile.LastName, profile.Title,
 		profile.Industry, profile.CareerSummary, skillsJSON, profile.PhoneNumber,
 		profile.Email, profile.Location, profile.LinkedInProfile, profile.GitHubProfile,
-		profile.Website, profile.Context, now,
+		profile.Website, profile.Context,
+		profile.LetterTone, profile.LetterLength, profile.LetterLanguage, now,
 	).Scan(&profileID, &updatedAt)
 
 	if err != nil {
@@ -29,12 +29,13 @@ func TestGetProfileWithRelated(t *testing.T) {
 			"id", "user_id", "first_name", "last_name", "title", "industry",
 			"career_summary", "skills", "phone_number", "email", "location",
 			"linkedin_profile", "github_profile", "website", "context",
+			"letter_tone", "letter_length", "letter_language",
 			"created_at", "updated_at",
 		}).AddRow(
 			profileID, userID, "John", "Doe", "Software Engineer", models.IndustryTechnology,
 			"Experienced developer", skillsJSON, "+1234567890", "john@example.com", "New York",
 			"https://linkedin.com/in/johndoe", "https://github.com/johndoe", "https://johndoe.com",
-			"Context about John", now, now,
+			"Context about John", "", "", "", now, now,
 		)
 
 		// Work experience query
@@ -120,11 +121,12 @@ func TestGetProfileWithRelated(t *testing.T) {
 			"id", "user_id", "first_name", "last_name", "title", "industry",
 			"career_summary", "skills", "phone_number", "email", "location",
 			"linkedin_profile", "github_profile", "website", "context",
+			"letter_tone", "letter_length", "letter_language",
 			"created_at", "updated_at",
 		}).AddRow(
 			profileID, userID, "John", "Doe", "Software Engineer", models.IndustryTechnology,
 			"Experienced developer", skillsJSON, "+1234567890", "john@example.com", "New York",
-			"", "", "", "", now, now,
+			"", "", "", "", "", "", "", now, now,
 		)
 
 		mock.ExpectQuery("SELECT(.+)FROM profiles(.+)WHERE user_id = \\?").
@@ -180,11 +182,12 @@ func TestCreateProfileIfNotExists(t *testing.T) {
 			"id", "user_id", "first_name", "last_name", "title", "industry",
 			"career_summary", "skills", "phone_number", "email", "location",
 			"linkedin_profile", "github_profile", "website", "context",
+			"letter_tone", "letter_length", "letter_language",
 			"created_at", "updated_at",
 		}).AddRow(
 			1, userID, "John", "Doe", "Software Engineer", models.IndustryTechnology,
 			"Experienced developer", skillsJSON, "+1234567890", "john@example.com", "New York",
-			"", "", "", "", now, now,
+			"", "", "", "", "", "", "", now, now,
 		)
 
 		mock.ExpectQuery("SELECT(.+)FROM profiles(.+)WHERE user_id = \\?").
@@ -35,12 +35,13 @@ func TestGetProfile(t *testing.T) {
 			"id", "user_id", "first_name", "last_name", "title", "industry",
 			"career_summary", "skills", "phone_number", "email", "location",
 			"linkedin_profile", "github_profile", "website", "context",
+			"letter_tone", "letter_length", "letter_language",
 			"created_at", "updated_at",
 		}).AddRow(
 			1, userID, "John", "Doe", "Software Engineer", models.IndustryTechnology,
 			"Experienced developer", skillsJSON, "+1234567890", "john.doe@example.com", "New York",
 			"https://linkedin.com/in/johndoe", "https://github.com/johndoe", "https://johndoe.com",
-			"", now, now,
+			"", "warm", "short", "de", now, now,
 		)
 
 		mock.ExpectQuery("SELECT(.+)FROM profiles(.+)WHERE user_id = \\?").
@@ -53,6 +54,9 @@ func TestGetProfile(t *testing.T) {
 		assert.Equal(t, "John", profile.FirstName)
 		assert.Equal(t, "Doe", profile.LastName)
 		assert.Len(t, profile.Skills, 3)
+		assert.Equal(t, "warm", profile.LetterTone)
+		assert.Equal(t, "short", profile.LetterLength)
+		assert.Equal(t, "de", profile.LetterLanguage)
 		// GetProfile now returns empty slices for related entities
 		assert.Empty(t, profile.WorkExperience)
 		assert.Empty(t, profile.Education)
@@ -66,11 +70,12 @@ func TestGetProfile(t *testing.T) {
 			"id", "user_id", "first_name", "last_name", "title", "industry",
 			"career_summary", "skills", "phone_number", "email", "location",
 			"linkedin_profile", "github_profile", "website", "context",
+			"letter_tone", "letter_length", "letter_language",
 			"created_at", "updated_at",
 		}).AddRow(
 			2, userID, "Jane", "Smith", "", models.IndustryUnspecified,
 			"", []byte("[]"), "", "",
-			"", "", "", "", "", now, now,
+			"", "", "", "", "", "", "", "", now, now,
 		)
 
 		mock.ExpectQuery("SELECT(.+)FROM profiles(.+)WHERE user_id = \\?").
@@ -126,6 +131,9 @@ func TestUpdateProfile(t *testing.T) {
 		LastName:  "Doe Updated",
 		Email:     "john.updated@example.com",
 		Skills:    []string{"Go", "Python", "Kubernetes"},
+
+		LetterTone:     "formal",
+		LetterLanguage: "fr",
 	}
 
 	t.Run("successful update", func(t *testing.T) {
@@ -137,7 +145,7 @@ func TestUpdateProfile(t *testing.T) {
 				profile.Title, profile.Industry, profile.CareerSummary,
 				skillsJSON, profile.PhoneNumber, profile.Email, profile.Location,
 				profile.LinkedInProfile, profile.GitHubProfile, profile.Website,
-				profile.Context, sqlmock.AnyArg(), // updated_at
+				profile.Context, "formal", "", "fr", sqlmock.AnyArg(), // updated_at
 			).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "updated_at"}).AddRow(1, profile.UpdatedAt))
 
@@ -341,11 +349,12 @@ func TestHandleNullValues(t *testing.T) {
 			"id", "user_id", "first_name", "last_name", "title", "industry",
 			"career_summary", "skills", "phone_number", "email", "location",
 			"linkedin_profile", "github_profile", "website", "context",
+			"letter_tone", "letter_length", "letter_language",
 			"created_at", "updated_at",
 		}).AddRow(
 			1, 1, "John", "Doe", "", models.IndustryUnspecified,
 			"", []byte("null"), "", "",
-			"", "", "", "", "", now, now,
+			"", "", "", "", "", "", "", "", now, now,
 		)
 
 		mock.ExpectQuery("SELECT(.+)FROM profiles(.+)WHERE user_id = \\?").
@@ -14,12 +14,17 @@ func RegisterRoutes(settingsGroup *gin.RouterGroup, handler *SettingsHandler) {
 	settingsGroup.POST("/profile/personal", handler.HandleCreateProfile)
 	settingsGroup.POST("/profile/online", handler.HandleUpdateOnlineProfile)
 	settingsGroup.POST("/profile/context", handler.HandleUpdateContext)
+	settingsGroup.POST("/profile/letter-defaults", handler.HandleUpdateLetterDefaults)
 	settingsGroup.POST("/profile/parse-cv", handler.HandleCVUpload)
 
 	// Account settings
 	settingsGroup.GET("/account", handler.GetAccountSettingsPage)
 	settingsGroup.POST("/account/update", handler.HandleUpdateAccount)
 	settingsGroup.DELETE("/account/delete", handler.DeleteAccount)
+	settingsGroup.POST("/account/api-key", handler.HandleSaveAPIKey)
+	settingsGroup.POST("/account/api-key/test", handler.HandleTestAPIKey)
+	settingsGroup.DELETE("/account/api-key", handler.HandleRemoveAPIKey)
+	settingsGroup.POST("/account/privacy", handler.HandleUpdateRedaction)
 
 	// Experience routes
 	settingsGroup.GET("/profile/experience/new", handler.GetAddExperiencePage)
@@ -50,4 +55,10 @@ func RegisterRoutes(settingsGroup *gin.RouterGroup, handler *SettingsHandler) {
 
 	// Quota routes
 	settingsGroup.GET("/quotas", handler.GetQuotasPage)
+	settingsGroup.GET("/quotas/usage", handler.GetUsageSummary)
+
+	// Prompt version routes (admin only)
+	settingsGroup.GET("/prompts", handler.GetPromptsPage)
+	settingsGroup.POST("/prompts/rollout", handler.HandleSavePromptRollout)
+	settingsGroup.POST("/prompts/versions", handler.HandleAddPromptVersion)
 }
@@ -41,7 +41,14 @@ func TestRegisterRoutes(t *testing.T) {
 			"/settings",
 			"/settings/profile",
 			"/settings/account",
+			"/settings/account/api-key",
+			"/settings/account/api-key/test",
+			"/settings/account/privacy",
 			"/settings/quotas",
+			"/settings/quotas/usage",
+			"/settings/prompts",
+			"/settings/prompts/rollout",
+			"/settings/prompts/versions",
 		}
 
 		for _, expectedPath := range expectedPaths {
@@ -21,6 +21,13 @@ type SettingsService struct {
 	log              *logger.PrivacyLogger
 	centralValidator *services.CentralizedValidator
 	authService      AuthServiceInterface
+	profileListener  ProfileListener
+}
+
+// ProfileListener is notified after a user's profile, work experience,
+// education or certifications change.
+type ProfileListener interface {
+	ProfileChanged(ctx context.Context, userID int)
 }
 
 // AuthServiceInterface defines the methods we need from the auth service
@@ -54,6 +61,18 @@ func NewSettingsServiceForProfiles(settingsRepo interfaces.SettingsRepository, c
 	}
 }
 
+// SetProfileListener sets the listener notified of profile changes
+func (s *SettingsService) SetProfileListener(listener ProfileListener) {
+	s.profileListener = listener
+}
+
+// profileChanged notifies the profile listener, if any
+func (s *SettingsService) profileChanged(ctx context.Context, userID int) {
+	if s.profileListener != nil && userID > 0 {
+		s.profileListener.ProfileChanged(ctx, userID)
+	}
+}
+
 // GetProfileSettings retrieves a user's profile settings
 func (s *SettingsService) GetProfileSettings(ctx context.Context, userID int) (*models.Profile, error) {
 	profile, err := s.settingsRepo.GetProfileWithRelated(ctx, userID)
@@ -118,6 +137,8 @@ func (s *SettingsService) UpdateProfile(ctx context.Context, profile *models.Pro
 		s.log.Error().Err(err).Int("user_id", profile.UserID).Msg("Failed to update profile")
 		return models.WrapError(models.ErrFailedToUpdateSettings, err)
 	}
+
+	s.profileChanged(ctx, profile.UserID)
 	return nil
 }
 
@@ -136,18 +157,21 @@ func (s *SettingsService) CreateEntity(ctx *gin.Context, entity CRUDEntity) erro
 			s.log.Error().Err(err).Int("profile_id", e.ProfileID).Msg("Failed to add work experience")
 			return models.WrapError(models.ErrFailedToCreateWorkExperience, err)
 		}
+		s.profileChanged(ctx.Request.Context(), ctx.GetInt("userID"))
 		return nil
 	case *models.Education:
 		if err := s.settingsRepo.AddEducation(ctx.Request.Context(), e); err != nil {
 			s.log.Error().Err(err).Int("profile_id", e.ProfileID).Msg("Failed to add education entry")
 			return models.WrapError(models.ErrFailedToCreateEducation, err)
 		}
+		s.profileChanged(ctx.Request.Context(), ctx.GetInt("userID"))
 		return nil
 	case *models.Certification:
 		if err := s.settingsRepo.AddCertification(ctx.Request.Context(), e); err != nil {
 			s.log.Error().Err(err).Int("profile_id", e.ProfileID).Msg("Failed to add certification")
 			return models.WrapError(models.ErrFailedToCreateCertification, err)
 		}
+		s.profileChanged(ctx.Request.Context(), ctx.GetInt("userID"))
 		return nil
 	default:
 		return fmt.Errorf("unsupported entity type")
@@ -173,6 +197,7 @@ func (s *SettingsService) UpdateEntity(ctx *gin.Context, entity CRUDEntity) erro
 			}
 			return models.WrapError(models.ErrFailedToUpdateWorkExperience, err)
 		}
+		s.profileChanged(ctx.Request.Context(), ctx.GetInt("userID"))
 		return nil
 	case *models.Education:
 		_, err := s.settingsRepo.UpdateEducation(ctx.Request.Context(), e)
@@ -183,6 +208,7 @@ func (s *SettingsService) UpdateEntity(ctx *gin.Context, entity CRUDEntity) erro
 			}
 			return models.WrapError(models.ErrFailedToUpdateEducation, err)
 		}
+		s.profileChanged(ctx.Request.Context(), ctx.GetInt("userID"))
 		return nil
 	case *models.Certification:
 		_, err := s.settingsRepo.UpdateCertification(ctx.Request.Context(), e)
@@ -193,6 +219,7 @@ func (s *SettingsService) UpdateEntity(ctx *gin.Context, entity CRUDEntity) erro
 			}
 			return models.WrapError(models.ErrFailedToUpdateCertification, err)
 		}
+		s.profileChanged(ctx.Request.Context(), ctx.GetInt("userID"))
 		return nil
 	default:
 		return fmt.Errorf("unsupported entity type")
@@ -216,6 +243,7 @@ func (s *SettingsService) DeleteEntity(ctx *gin.Context, entityID, profileID int
 			return models.WrapError(models.ErrFailedToDeleteWorkExperience, err)
 		}
 		s.log.Info().Int("experience_id", entityID).Int("profile_id", profileID).Msg("Successfully deleted work experience")
+		s.profileChanged(ctx.Request.Context(), ctx.GetInt("userID"))
 		return nil
 	case "Education":
 		if err := s.settingsRepo.DeleteEducation(ctx.Request.Context(), entityID, profileID); err != nil {
@@ -226,6 +254,7 @@ func (s *SettingsService) DeleteEntity(ctx *gin.Context, entityID, profileID int
 			return models.WrapError(models.ErrFailedToDeleteEducation, err)
 		}
 		s.log.Info().Int("education_id", entityID).Int("profile_id", profileID).Msg("Successfully deleted education entry")
+		s.profileChanged(ctx.Request.Context(), ctx.GetInt("userID"))
 		return nil
 	case "Certification":
 		if err := s.settingsRepo.DeleteCertification(ctx.Request.Context(), entityID, profileID); err != nil {
@@ -236,6 +265,7 @@ func (s *SettingsService) DeleteEntity(ctx *gin.Context, entityID, profileID int
 			return models.WrapError(models.ErrFailedToDeleteCertification, err)
 		}
 		s.log.Info().Int("certification_id", entityID).Int("profile_id", profileID).Msg("Successfully deleted certification")
+		s.profileChanged(ctx.Request.Context(), ctx.GetInt("userID"))
 		return nil
 	default:
 		return fmt.Errorf("unsupported entity type")
@@ -5,6 +5,9 @@ import (
 	"database/sql"
 
 	"github.com/benidevo/vega/internal/ai"
+	"github.com/benidevo/vega/internal/ai/privacy"
+	promptregistry "github.com/benidevo/vega/internal/ai/prompts/registry"
+	"github.com/benidevo/vega/internal/ai/usage"
 	authrepo "github.com/benidevo/vega/internal/auth/repository"
 	"github.com/benidevo/vega/internal/config"
 	"github.com/benidevo/vega/internal/settings/repository"
@@ -17,7 +20,12 @@ func Setup(cfg *config.Settings, db *sql.DB, aiService *ai.AIService, quotaServi
 	userRepo := authrepo.NewSQLiteUserRepository(db)
 	settingsRepo := repository.NewProfileRepository(db)
 	service := NewSettingsService(settingsRepo, cfg, userRepo, authService)
-	return NewSettingsHandler(service, aiService, quotaService)
+	handler := NewSettingsHandler(service, aiService, quotaService)
+	handler.SetUsageService(usage.NewService(usage.NewRepository(db)))
+	handler.SetPrivacyService(privacy.NewService(privacy.NewRepository(db)))
+	setupCredentialService(handler, cfg, db)
+	handler.SetPromptRegistry(promptregistry.Setup(db, cfg))
+	return handler
 }
 
 // SetupWithService creates a new settings handler and returns both handler and service
@@ -28,5 +36,19 @@ func SetupWithService(cfg *config.Settings, db *sql.DB, aiService *ai.AIService,
 	settingsRepo := repository.NewProfileRepository(db)
 	service := NewSettingsService(settingsRepo, cfg, userRepo, authService)
 	handler := NewSettingsHandler(service, aiService, quotaService)
+	handler.SetUsageService(usage.NewService(usage.NewRepository(db)))
+	handler.SetPrivacyService(privacy.NewService(privacy.NewRepository(db)))
+	setupCredentialService(handler, cfg, db)
+	handler.SetPromptRegistry(promptregistry.Setup(db, cfg))
 	return handler, service
 }
+
+// setupCredentialService lets cloud users manage their own AI provider key
+func setupCredentialService(handler *SettingsHandler, cfg *config.Settings, db *sql.DB) {
+	if !cfg.IsCloudMode {
+		return
+	}
+	if credentialService, err := ai.SetupCredentials(db, cfg); err == nil {
+		handler.SetCredentialService(credentialService)
+	}
+}
@@ -33,6 +33,9 @@ type App struct {
 	server   *http.Server
 	done     chan os.Signal
 	renderer *render.HTMLRenderer
+
+	// stopScheduler stops the background reminder scheduler, if started
+	stopScheduler func()
 }
 
 // loadTemplates walks the templates directory and loads all HTML files
@@ -154,8 +157,8 @@ func (a *App) WaitForShutdown() {
 	log.Info().Msg("Server shut down gracefully")
 }
 
-// Shutdown gracefully shuts down the application by stopping the server
-// and closing the database connection and cache.
+// Shutdown gracefully shuts down the application by stopping the server and
+// the reminder scheduler, then closing the database connection and cache.
 func (a *App) Shutdown(ctx context.Context) error {
 	var err error
 
@@ -163,6 +166,11 @@ func (a *App) Shutdown(ctx context.Context) error {
 		err = a.server.Shutdown(ctx)
 	}
 
+	if a.stopScheduler != nil {
+		a.stopScheduler()
+		a.stopScheduler = nil
+	}
+
 	if a.db != nil {
 		dbErr := a.db.Close()
 		if err == nil {
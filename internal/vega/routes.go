@@ -1,8 +1,10 @@
 package vega
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/benidevo/vega/internal/ai"
 	authapi "github.com/benidevo/vega/internal/api/auth"
@@ -39,24 +41,45 @@ func SetupRoutes(a *App) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok"})
 	})
 
-	aiService, err := ai.Setup(&a.config)
+	// The web handlers, the JSON API and settings share one AI service, so
+	// that they share the provider chain and its health
+	aiService, err := ai.Setup(&a.config, job.SetupAIOptions(a.db, &a.config, a.cache)...)
 	if err != nil {
 		log.Warn().Err(err).Msg("AI service initialization failed, AI features will be disabled")
 		aiService = nil
+	} else {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := aiService.ValidateModels(ctx); err != nil {
+			log.Warn().Err(err).Str("provider", a.config.AIProvider).
+				Msg("AI provider models could not be verified; AI features stay enabled but requests may fail until the models are available")
+		}
+		cancel()
 	}
 
 	authHandler, authService := auth.SetupAuthWithService(a.db, &a.config)
-	jobService := job.SetupService(a.db, &a.config, a.cache)
+	jobService := job.SetupService(a.db, &a.config, a.cache, aiService)
 	jobHandler := job.NewJobHandler(jobService, &a.config)
+	if !a.config.IsTest {
+		a.startReminderScheduler(jobService, reminderSchedulerInterval)
+	}
 
 	// Setup unified quota service
 	jobRepo := job.SetupJobRepository(a.db, a.cache)
 	quotaAdapter := quota.NewJobRepositoryAdapter(jobRepo)
 	unifiedQuotaService := quota.NewUnifiedService(a.db, quotaAdapter, a.config.IsCloudMode)
+	if a.config.IsCloudMode {
+		credentialService, err := ai.SetupCredentials(a.db, &a.config)
+		if err != nil {
+			log.Warn().Err(err).Msg("User AI provider keys are disabled; set CREDENTIALS_ENCRYPTION_KEY to enable them")
+		} else {
+			unifiedQuotaService.SetCredentialChecker(credentialService)
+		}
+	}
 
-	settingsHandler, _ := settings.SetupWithService(&a.config, a.db, aiService, unifiedQuotaService, authService)
+	settingsHandler, settingsService := settings.SetupWithService(&a.config, a.db, aiService, unifiedQuotaService, authService)
+	settingsService.SetProfileListener(jobService)
 	authAPIHandler := authapi.Setup(a.db, &a.config)
-	jobAPIHandler := jobapi.Setup(a.db, &a.config, a.cache, unifiedQuotaService)
+	jobAPIHandler := jobapi.Setup(jobService, unifiedQuotaService)
 
 	homeHandler := home.Setup(a.db, &a.config, a.cache, jobService)
 
@@ -100,6 +123,7 @@ func SetupRoutes(a *App) {
 	settingsGroup := a.router.Group("/settings")
 	settingsGroup.Use(authHandler.AuthMiddleware())
 	settings.RegisterRoutes(settingsGroup, settingsHandler)
+	job.RegisterSettingsRoutes(settingsGroup, jobHandler)
 
 	// Register document routes
 	csrfMiddleware := middleware.CSRF(&a.config)
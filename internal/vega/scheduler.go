@@ -0,0 +1,67 @@
+package vega
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// reminderSchedulerInterval is how often reminders are checked for having
+// come due
+const reminderSchedulerInterval = time.Minute
+
+// reminderMarker marks reminders whose due time has passed as due
+type reminderMarker interface {
+	MarkDueReminders(ctx context.Context, now time.Time) (int, error)
+}
+
+// startReminderScheduler marks reminders due in the background, once straight
+// away and then at every interval, until the app shuts down.
+func (a *App) startReminderScheduler(marker reminderMarker, interval time.Duration) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		runReminderScheduler(ctx, marker, interval)
+	}()
+
+	a.stopScheduler = func() {
+		cancel()
+		wg.Wait()
+	}
+	log.Info().Dur("interval", interval).Msg("Reminder scheduler started")
+}
+
+// runReminderScheduler marks reminders due until the context is cancelled
+func runReminderScheduler(ctx context.Context, marker reminderMarker, interval time.Duration) {
+	markDue := func() {
+		count, err := marker.MarkDueReminders(ctx, time.Now())
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Warn().Err(err).Msg("Failed to mark reminders due")
+			}
+			return
+		}
+		if count > 0 {
+			log.Debug().Int("count", count).Msg("Marked reminders due")
+		}
+	}
+
+	markDue()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			markDue()
+		}
+	}
+}
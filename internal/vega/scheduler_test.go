@@ -0,0 +1,42 @@
+package vega
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingMarker counts the times reminders were marked due
+type countingMarker struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (m *countingMarker) MarkDueReminders(ctx context.Context, now time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls++
+	return 0, nil
+}
+
+func (m *countingMarker) count() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls
+}
+
+func TestReminderScheduler(t *testing.T) {
+	app := New(mockConfig(t))
+	marker := &countingMarker{}
+
+	app.startReminderScheduler(marker, 10*time.Millisecond)
+	assert.Eventually(t, func() bool { return marker.count() >= 3 }, time.Second, 5*time.Millisecond)
+
+	app.stopScheduler()
+	stopped := marker.count()
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, stopped, marker.count(), "Expected no reminders marked after stopping")
+}
@@ -0,0 +1,5 @@
+-- Migration: 000009_create_llm_usage_table.down.sql
+-- Rollback LLM usage ledger
+
+DROP INDEX IF EXISTS idx_llm_usage_user_created;
+DROP TABLE IF EXISTS llm_usage;
@@ -0,0 +1,4 @@
+-- Migration: 000010_create_user_ai_credentials_table.down.sql
+-- Rollback per-user AI provider credentials
+
+DROP TABLE IF EXISTS user_ai_credentials;
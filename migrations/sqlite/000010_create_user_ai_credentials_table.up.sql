@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS user_ai_credentials (
+    user_id INTEGER PRIMARY KEY,
+    provider TEXT NOT NULL,
+    encrypted_key TEXT NOT NULL,
+    key_hint TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
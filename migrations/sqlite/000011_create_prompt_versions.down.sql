@@ -0,0 +1,7 @@
+-- Migration: 000011_create_prompt_versions.down.sql
+-- Rollback versioned prompt templates and rollouts
+
+ALTER TABLE documents DROP COLUMN prompt_version;
+ALTER TABLE match_results DROP COLUMN prompt_version;
+DROP TABLE IF EXISTS prompt_rollouts;
+DROP TABLE IF EXISTS prompt_versions;
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS prompt_versions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    version TEXT NOT NULL,
+    definition TEXT NOT NULL, -- JSON encoded prompt version
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE(name, version)
+);
+
+CREATE TABLE IF NOT EXISTS prompt_rollouts (
+    name TEXT PRIMARY KEY,
+    default_version TEXT NOT NULL DEFAULT 'builtin',
+    candidate_version TEXT NOT NULL DEFAULT '',
+    candidate_percent INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CHECK (candidate_percent >= 0 AND candidate_percent <= 100)
+);
+
+ALTER TABLE match_results ADD COLUMN prompt_version TEXT NOT NULL DEFAULT '';
+ALTER TABLE documents ADD COLUMN prompt_version TEXT NOT NULL DEFAULT '';
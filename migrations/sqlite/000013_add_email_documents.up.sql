@@ -0,0 +1,32 @@
+-- Migration: 000013_add_email_documents.up.sql
+-- Allow generated application emails to be stored as job documents. A job
+-- keeps every email it was sent, so the one-document-per-type rule becomes a
+-- partial unique index that leaves emails out. SQLite cannot alter a CHECK or
+-- table constraint, so the table is rebuilt.
+
+CREATE TABLE documents_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    job_id INTEGER NOT NULL,
+    document_type TEXT NOT NULL CHECK(document_type IN ('cover_letter', 'resume', 'interview_prep', 'email')),
+    content TEXT NOT NULL,
+    format TEXT DEFAULT 'html',
+    size_bytes INTEGER CHECK(size_bytes <= 2097152),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    prompt_version TEXT NOT NULL DEFAULT '',
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (job_id) REFERENCES jobs(id) ON DELETE CASCADE
+);
+
+INSERT INTO documents_new (id, user_id, job_id, document_type, content, format, size_bytes, created_at, updated_at, prompt_version)
+SELECT id, user_id, job_id, document_type, content, format, size_bytes, created_at, updated_at, prompt_version
+FROM documents;
+
+DROP TABLE documents;
+ALTER TABLE documents_new RENAME TO documents;
+
+CREATE UNIQUE INDEX idx_documents_user_job_type ON documents(user_id, job_id, document_type) WHERE document_type != 'email';
+CREATE INDEX idx_documents_user_job ON documents(user_id, job_id);
+CREATE INDEX idx_documents_type_updated ON documents(user_id, document_type, updated_at DESC);
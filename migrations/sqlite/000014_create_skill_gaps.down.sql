@@ -0,0 +1,4 @@
+-- Migration: 000014_create_skill_gaps.down.sql
+-- Rollback skill gap learning plans
+
+DROP TABLE IF EXISTS skill_gaps;
@@ -0,0 +1,19 @@
+-- Skill gaps are worked out from match results each time they are shown.
+-- This table keeps what the user has done with a gap: its learning plan and
+-- whether they have addressed it.
+CREATE TABLE IF NOT EXISTS skill_gaps (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    gap_key TEXT NOT NULL, -- normalized gap name
+    name TEXT NOT NULL,
+    plan TEXT, -- JSON encoded learning plan
+    plan_prompt_version TEXT NOT NULL DEFAULT '',
+    plan_generated_at TIMESTAMP,
+    addressed_at TIMESTAMP,
+    addressed_note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE(user_id, gap_key)
+);
@@ -0,0 +1,10 @@
+-- Migration: 000015_add_cover_letter_settings.down.sql
+-- Rollback cover letter tone, length and language settings
+
+ALTER TABLE profiles DROP COLUMN letter_language;
+ALTER TABLE profiles DROP COLUMN letter_length;
+ALTER TABLE profiles DROP COLUMN letter_tone;
+
+ALTER TABLE documents DROP COLUMN language;
+ALTER TABLE documents DROP COLUMN length;
+ALTER TABLE documents DROP COLUMN tone;
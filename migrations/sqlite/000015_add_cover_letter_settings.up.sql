@@ -0,0 +1,12 @@
+-- Migration: 000015_add_cover_letter_settings.up.sql
+-- Record the tone, length and language a document was generated with, so a
+-- regenerated cover letter can reuse them, and let users save their own
+-- defaults on their profile. Empty values mean the built-in defaults.
+
+ALTER TABLE documents ADD COLUMN tone TEXT NOT NULL DEFAULT '';
+ALTER TABLE documents ADD COLUMN length TEXT NOT NULL DEFAULT '';
+ALTER TABLE documents ADD COLUMN language TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE profiles ADD COLUMN letter_tone TEXT NOT NULL DEFAULT '';
+ALTER TABLE profiles ADD COLUMN letter_length TEXT NOT NULL DEFAULT '';
+ALTER TABLE profiles ADD COLUMN letter_language TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,4 @@
+-- Migration: 000016_create_ai_redaction_settings.down.sql
+-- Rollback per-task PII redaction settings
+
+DROP TABLE IF EXISTS ai_redaction_settings;
@@ -0,0 +1,12 @@
+-- Users choose, per AI task, whether personal details (names, emails, phone
+-- numbers and profile links) are swapped for placeholder tokens before a
+-- prompt is sent to the provider. Tasks without a row are redacted.
+CREATE TABLE IF NOT EXISTS ai_redaction_settings (
+    user_id INTEGER NOT NULL,
+    task_type TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT 1,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (user_id, task_type),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
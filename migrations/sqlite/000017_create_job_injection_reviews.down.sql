@@ -0,0 +1,4 @@
+-- Migration: 000017_create_job_injection_reviews.down.sql
+-- Rollback flagged job description reviews
+
+DROP TABLE IF EXISTS job_injection_reviews;
@@ -0,0 +1,15 @@
+-- Job descriptions that look like they contain instructions for the AI are
+-- flagged here. AI features stay off for a flagged job until the user has
+-- reviewed the description and approved it.
+CREATE TABLE IF NOT EXISTS job_injection_reviews (
+    job_id INTEGER PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    score REAL NOT NULL,
+    spans TEXT NOT NULL DEFAULT '[]', -- JSON encoded matched spans
+    approved BOOLEAN NOT NULL DEFAULT 0,
+    flagged_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    reviewed_at TIMESTAMP,
+
+    FOREIGN KEY (job_id) REFERENCES jobs(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
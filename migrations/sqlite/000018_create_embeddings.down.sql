@@ -0,0 +1,5 @@
+-- Migration: 000018_create_embeddings.down.sql
+-- Rollback stored embedding vectors
+
+DROP TRIGGER IF EXISTS embeddings_delete_job;
+DROP TABLE IF EXISTS embeddings;
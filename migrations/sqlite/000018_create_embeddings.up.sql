@@ -0,0 +1,23 @@
+-- Embedding vectors of job descriptions and user profiles, used for instant
+-- similarity scores without a full AI analysis. Vectors are stored per
+-- embedding model because vectors from different models can't be compared,
+-- and are recomputed when the hash of the embedded text changes.
+CREATE TABLE IF NOT EXISTS embeddings (
+    user_id INTEGER NOT NULL,
+    subject_type TEXT NOT NULL, -- job or profile
+    subject_id INTEGER NOT NULL, -- job ID, 0 for the profile
+    model TEXT NOT NULL,
+    content_hash TEXT NOT NULL,
+    vector BLOB NOT NULL, -- little-endian float32 values
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (user_id, subject_type, subject_id, model),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Job vectors have no foreign key of their own, so remove them with the job
+CREATE TRIGGER IF NOT EXISTS embeddings_delete_job
+AFTER DELETE ON jobs
+BEGIN
+    DELETE FROM embeddings WHERE subject_type = 'job' AND subject_id = OLD.id;
+END;
@@ -0,0 +1,8 @@
+-- Migration: 000019_create_jobs_fts.down.sql
+-- Rollback full-text search over jobs
+
+DROP TRIGGER IF EXISTS jobs_fts_company_rename;
+DROP TRIGGER IF EXISTS jobs_fts_delete;
+DROP TRIGGER IF EXISTS jobs_fts_update;
+DROP TRIGGER IF EXISTS jobs_fts_insert;
+DROP TABLE IF EXISTS jobs_fts;
@@ -0,0 +1,55 @@
+-- Full-text index over the searchable fields of each job. The row ID of an
+-- entry is the job ID. Skills are indexed as their stored JSON array, which
+-- the tokenizer splits into words like any other text.
+CREATE VIRTUAL TABLE IF NOT EXISTS jobs_fts USING fts5(
+    title,
+    description,
+    notes,
+    location,
+    company,
+    skills,
+    tokenize = 'unicode61 remove_diacritics 2'
+);
+
+INSERT INTO jobs_fts (rowid, title, description, notes, location, company, skills)
+SELECT j.id, j.title, j.description, COALESCE(j.notes, ''), COALESCE(j.location, ''),
+       c.name, COALESCE(j.required_skills, '')
+FROM jobs j
+JOIN companies c ON j.company_id = c.id;
+
+CREATE TRIGGER IF NOT EXISTS jobs_fts_insert
+AFTER INSERT ON jobs
+BEGIN
+    INSERT INTO jobs_fts (rowid, title, description, notes, location, company, skills)
+    VALUES (
+        NEW.id, NEW.title, NEW.description, COALESCE(NEW.notes, ''), COALESCE(NEW.location, ''),
+        COALESCE((SELECT name FROM companies WHERE id = NEW.company_id), ''),
+        COALESCE(NEW.required_skills, '')
+    );
+END;
+
+-- Status and match score changes don't touch the index
+CREATE TRIGGER IF NOT EXISTS jobs_fts_update
+AFTER UPDATE OF title, description, notes, location, company_id, required_skills ON jobs
+BEGIN
+    DELETE FROM jobs_fts WHERE rowid = OLD.id;
+    INSERT INTO jobs_fts (rowid, title, description, notes, location, company, skills)
+    VALUES (
+        NEW.id, NEW.title, NEW.description, COALESCE(NEW.notes, ''), COALESCE(NEW.location, ''),
+        COALESCE((SELECT name FROM companies WHERE id = NEW.company_id), ''),
+        COALESCE(NEW.required_skills, '')
+    );
+END;
+
+CREATE TRIGGER IF NOT EXISTS jobs_fts_delete
+AFTER DELETE ON jobs
+BEGIN
+    DELETE FROM jobs_fts WHERE rowid = OLD.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS jobs_fts_company_rename
+AFTER UPDATE OF name ON companies
+BEGIN
+    UPDATE jobs_fts SET company = NEW.name
+    WHERE rowid IN (SELECT id FROM jobs WHERE company_id = NEW.id);
+END;
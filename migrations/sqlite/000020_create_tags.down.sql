@@ -0,0 +1,7 @@
+-- Migration: 000020_create_tags.down.sql
+-- Rollback job tags
+
+DROP TRIGGER IF EXISTS job_tags_delete_job;
+DROP INDEX IF EXISTS idx_job_tags_tag_id;
+DROP TABLE IF EXISTS job_tags;
+DROP TABLE IF EXISTS tags;
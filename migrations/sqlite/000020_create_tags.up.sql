@@ -0,0 +1,30 @@
+-- Tags are labels the user puts on jobs to group them across statuses, such
+-- as "referral" or "visa sponsorship". Tag names are unique per user,
+-- ignoring case.
+CREATE TABLE IF NOT EXISTS tags (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    name TEXT NOT NULL COLLATE NOCASE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE(user_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS job_tags (
+    job_id INTEGER NOT NULL,
+    tag_id INTEGER NOT NULL,
+
+    PRIMARY KEY (job_id, tag_id),
+    FOREIGN KEY (job_id) REFERENCES jobs(id) ON DELETE CASCADE,
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_tags_tag_id ON job_tags(tag_id);
+
+-- Remove the links of a deleted job even when foreign keys aren't enforced
+CREATE TRIGGER IF NOT EXISTS job_tags_delete_job
+AFTER DELETE ON jobs
+BEGIN
+    DELETE FROM job_tags WHERE job_id = OLD.id;
+END;
@@ -0,0 +1,9 @@
+-- Migration: 000021_create_job_status_events.down.sql
+-- Rollback job status history
+
+DROP TRIGGER IF EXISTS job_status_events_delete_job;
+DROP TRIGGER IF EXISTS job_status_events_update;
+DROP TRIGGER IF EXISTS job_status_events_insert;
+DROP INDEX IF EXISTS idx_job_status_events_user;
+DROP INDEX IF EXISTS idx_job_status_events_job;
+DROP TABLE IF EXISTS job_status_events;
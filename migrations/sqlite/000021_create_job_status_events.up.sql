@@ -0,0 +1,65 @@
+-- Every change of a job's status, so that the application timeline and how
+-- long each stage took can be shown. Triggers record the changes whichever
+-- way the status is updated; occurred_at starts as the time of the change and
+-- can be moved back by the user, e.g. for a job applied to last week and
+-- tracked only now. Times are stored as 'YYYY-MM-DD HH:MM:SS' in UTC, the
+-- format of CURRENT_TIMESTAMP, so that they can be compared as text.
+CREATE TABLE IF NOT EXISTS job_status_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    job_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    from_status INTEGER, -- NULL for the status the job was created with
+    to_status INTEGER NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    occurred_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (job_id) REFERENCES jobs(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_status_events_job ON job_status_events(job_id, occurred_at);
+CREATE INDEX IF NOT EXISTS idx_job_status_events_user ON job_status_events(user_id, to_status, occurred_at);
+
+-- The history of existing jobs is unknown, so each starts with its current
+-- status, dated when the job was added if it is still the first status and
+-- when it was last updated otherwise
+INSERT INTO job_status_events (job_id, user_id, from_status, to_status, occurred_at)
+SELECT
+    id,
+    user_id,
+    NULL,
+    status,
+    COALESCE(
+        datetime(substr(CASE WHEN status = 0 THEN created_at ELSE updated_at END, 1, 19)),
+        CURRENT_TIMESTAMP
+    )
+FROM jobs;
+
+CREATE TRIGGER IF NOT EXISTS job_status_events_insert
+AFTER INSERT ON jobs
+BEGIN
+    INSERT INTO job_status_events (job_id, user_id, from_status, to_status, occurred_at)
+    VALUES (
+        NEW.id,
+        NEW.user_id,
+        NULL,
+        NEW.status,
+        COALESCE(datetime(substr(NEW.created_at, 1, 19)), CURRENT_TIMESTAMP)
+    );
+END;
+
+CREATE TRIGGER IF NOT EXISTS job_status_events_update
+AFTER UPDATE OF status ON jobs
+WHEN NEW.status IS NOT OLD.status
+BEGIN
+    INSERT INTO job_status_events (job_id, user_id, from_status, to_status, occurred_at)
+    VALUES (NEW.id, NEW.user_id, OLD.status, NEW.status, CURRENT_TIMESTAMP);
+END;
+
+-- Remove the history of a deleted job even when foreign keys aren't enforced
+CREATE TRIGGER IF NOT EXISTS job_status_events_delete_job
+AFTER DELETE ON jobs
+BEGIN
+    DELETE FROM job_status_events WHERE job_id = OLD.id;
+END;
@@ -0,0 +1,40 @@
+-- Migration: 000022_create_pipeline_stages.down.sql
+-- Rollback per-user pipeline stages, restoring the status history triggers
+-- of 000021
+
+DROP TRIGGER IF EXISTS job_status_events_update;
+DROP TRIGGER IF EXISTS job_status_events_insert;
+
+CREATE TRIGGER IF NOT EXISTS job_status_events_insert
+AFTER INSERT ON jobs
+BEGIN
+    INSERT INTO job_status_events (job_id, user_id, from_status, to_status, occurred_at)
+    VALUES (
+        NEW.id,
+        NEW.user_id,
+        NULL,
+        NEW.status,
+        COALESCE(datetime(substr(NEW.created_at, 1, 19)), CURRENT_TIMESTAMP)
+    );
+END;
+
+CREATE TRIGGER IF NOT EXISTS job_status_events_update
+AFTER UPDATE OF status ON jobs
+WHEN NEW.status IS NOT OLD.status
+BEGIN
+    INSERT INTO job_status_events (job_id, user_id, from_status, to_status, occurred_at)
+    VALUES (NEW.id, NEW.user_id, OLD.status, NEW.status, CURRENT_TIMESTAMP);
+END;
+
+ALTER TABLE job_status_events DROP COLUMN to_stage_id;
+ALTER TABLE job_status_events DROP COLUMN from_stage_id;
+
+DROP TRIGGER IF EXISTS jobs_stage_update_status;
+DROP TRIGGER IF EXISTS jobs_stage_insert;
+DROP INDEX IF EXISTS idx_jobs_stage_id;
+ALTER TABLE jobs DROP COLUMN stage_id;
+
+DROP TRIGGER IF EXISTS pipeline_stages_delete_user;
+DROP TRIGGER IF EXISTS pipeline_stages_insert_user;
+DROP INDEX IF EXISTS idx_pipeline_stages_user;
+DROP TABLE IF EXISTS pipeline_stages;
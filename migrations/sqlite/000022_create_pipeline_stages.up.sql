@@ -0,0 +1,160 @@
+-- Pipeline stages are the user's own ordered list of steps a job goes
+-- through, such as "Phone Screen" or "Take-home". Each stage belongs to one
+-- of the six fixed categories (0 interested, 1 applied, 2 interviewing,
+-- 3 offer received, 4 rejected, 5 not interested), which jobs.status keeps
+-- holding so that stats and the status history work across pipelines. Stage
+-- names are unique per user, ignoring case.
+CREATE TABLE IF NOT EXISTS pipeline_stages (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    name TEXT NOT NULL COLLATE NOCASE,
+    position INTEGER NOT NULL,
+    category INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE(user_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pipeline_stages_user ON pipeline_stages(user_id, position);
+
+-- Every user starts with the default pipeline, one stage per category
+INSERT INTO pipeline_stages (user_id, name, position, category)
+SELECT u.id, d.name, d.position, d.category
+FROM users u
+CROSS JOIN (
+    SELECT 'Interested' AS name, 1 AS position, 0 AS category
+    UNION ALL SELECT 'Applied', 2, 1
+    UNION ALL SELECT 'Interviewing', 3, 2
+    UNION ALL SELECT 'Offer Received', 4, 3
+    UNION ALL SELECT 'Rejected', 5, 4
+    UNION ALL SELECT 'Not Interested', 6, 5
+) d;
+
+CREATE TRIGGER IF NOT EXISTS pipeline_stages_insert_user
+AFTER INSERT ON users
+BEGIN
+    INSERT INTO pipeline_stages (user_id, name, position, category) VALUES
+        (NEW.id, 'Interested', 1, 0),
+        (NEW.id, 'Applied', 2, 1),
+        (NEW.id, 'Interviewing', 3, 2),
+        (NEW.id, 'Offer Received', 4, 3),
+        (NEW.id, 'Rejected', 5, 4),
+        (NEW.id, 'Not Interested', 6, 5);
+END;
+
+-- Remove the pipeline of a deleted user even when foreign keys aren't enforced
+CREATE TRIGGER IF NOT EXISTS pipeline_stages_delete_user
+AFTER DELETE ON users
+BEGIN
+    DELETE FROM pipeline_stages WHERE user_id = OLD.id;
+END;
+
+ALTER TABLE jobs ADD COLUMN stage_id INTEGER;
+
+UPDATE jobs SET stage_id = (
+    SELECT s.id FROM pipeline_stages s
+    WHERE s.user_id = jobs.user_id AND s.category = jobs.status
+    ORDER BY s.position
+    LIMIT 1
+);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_stage_id ON jobs(stage_id);
+
+-- Jobs saved or updated with only a status are put in the first stage of
+-- that category, so that stage and status never disagree
+CREATE TRIGGER IF NOT EXISTS jobs_stage_insert
+AFTER INSERT ON jobs
+WHEN NEW.stage_id IS NULL
+BEGIN
+    UPDATE jobs SET stage_id = (
+        SELECT s.id FROM pipeline_stages s
+        WHERE s.user_id = NEW.user_id AND s.category = NEW.status
+        ORDER BY s.position
+        LIMIT 1
+    )
+    WHERE id = NEW.id;
+END;
+
+-- A category the user has no stage for leaves the job in its stage
+CREATE TRIGGER IF NOT EXISTS jobs_stage_update_status
+AFTER UPDATE OF status ON jobs
+WHEN NOT EXISTS (
+    SELECT 1 FROM pipeline_stages s
+    WHERE s.id = NEW.stage_id AND s.category = NEW.status
+)
+BEGIN
+    UPDATE jobs SET stage_id = COALESCE((
+        SELECT s.id FROM pipeline_stages s
+        WHERE s.user_id = NEW.user_id AND s.category = NEW.status
+        ORDER BY s.position
+        LIMIT 1
+    ), NEW.stage_id)
+    WHERE id = NEW.id;
+END;
+
+-- The status history records stages, so that moves between stages of the
+-- same category show in the timeline. from_status and to_status keep the
+-- categories, which the activity stats count.
+ALTER TABLE job_status_events ADD COLUMN from_stage_id INTEGER;
+ALTER TABLE job_status_events ADD COLUMN to_stage_id INTEGER;
+
+UPDATE job_status_events SET to_stage_id = (
+    SELECT s.id FROM pipeline_stages s
+    WHERE s.user_id = job_status_events.user_id AND s.category = job_status_events.to_status
+    ORDER BY s.position
+    LIMIT 1
+);
+
+UPDATE job_status_events SET from_stage_id = (
+    SELECT s.id FROM pipeline_stages s
+    WHERE s.user_id = job_status_events.user_id AND s.category = job_status_events.from_status
+    ORDER BY s.position
+    LIMIT 1
+)
+WHERE from_status IS NOT NULL;
+
+DROP TRIGGER IF EXISTS job_status_events_insert;
+DROP TRIGGER IF EXISTS job_status_events_update;
+
+-- A new job may get its stage from jobs_stage_insert after this trigger runs,
+-- so the stage is looked up the same way here
+CREATE TRIGGER IF NOT EXISTS job_status_events_insert
+AFTER INSERT ON jobs
+BEGIN
+    INSERT INTO job_status_events (job_id, user_id, from_status, to_status, from_stage_id, to_stage_id, occurred_at)
+    VALUES (
+        NEW.id,
+        NEW.user_id,
+        NULL,
+        NEW.status,
+        NULL,
+        COALESCE(NEW.stage_id, (
+            SELECT s.id FROM pipeline_stages s
+            WHERE s.user_id = NEW.user_id AND s.category = NEW.status
+            ORDER BY s.position
+            LIMIT 1
+        )),
+        COALESCE(datetime(substr(NEW.created_at, 1, 19)), CURRENT_TIMESTAMP)
+    );
+END;
+
+-- A change of status alone reaches here through jobs_stage_update_status, so
+-- every change is recorded once, as a change of stage. A job getting its
+-- first stage is not a change.
+CREATE TRIGGER IF NOT EXISTS job_status_events_update
+AFTER UPDATE OF stage_id ON jobs
+WHEN OLD.stage_id IS NOT NULL AND NEW.stage_id IS NOT NULL AND NEW.stage_id IS NOT OLD.stage_id
+BEGIN
+    INSERT INTO job_status_events (job_id, user_id, from_status, to_status, from_stage_id, to_stage_id, occurred_at)
+    VALUES (
+        NEW.id,
+        NEW.user_id,
+        COALESCE((SELECT category FROM pipeline_stages WHERE id = OLD.stage_id), OLD.status),
+        NEW.status,
+        OLD.stage_id,
+        NEW.stage_id,
+        CURRENT_TIMESTAMP
+    );
+END;
@@ -0,0 +1,9 @@
+-- Migration: 000023_create_reminders.down.sql
+-- Rollback job reminders
+
+DROP TRIGGER IF EXISTS reminders_delete_user;
+DROP TRIGGER IF EXISTS reminders_delete_job;
+DROP INDEX IF EXISTS idx_reminders_pending;
+DROP INDEX IF EXISTS idx_reminders_user;
+DROP INDEX IF EXISTS idx_reminders_job;
+DROP TABLE IF EXISTS reminders;
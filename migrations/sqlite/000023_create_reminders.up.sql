@@ -0,0 +1,41 @@
+-- Reminders attached to jobs, such as following up a week after applying.
+-- A reminder is pending until its due time, when the scheduler marks it due,
+-- and done once the user completes it (0 pending, 1 due, 2 done). Completing
+-- a recurring reminder moves it to its next due time instead. kind names the
+-- suggestion a reminder was created from and is empty for the user's own.
+-- Times are stored as 'YYYY-MM-DD HH:MM:SS' in UTC so that they compare as
+-- text.
+CREATE TABLE IF NOT EXISTS reminders (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    job_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    message TEXT NOT NULL,
+    due_at TIMESTAMP NOT NULL,
+    recurrence TEXT NOT NULL DEFAULT '',
+    status INTEGER NOT NULL DEFAULT 0,
+    kind TEXT NOT NULL DEFAULT '',
+    completed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (job_id) REFERENCES jobs(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_reminders_job ON reminders(job_id, due_at);
+CREATE INDEX IF NOT EXISTS idx_reminders_user ON reminders(user_id, status, due_at);
+CREATE INDEX IF NOT EXISTS idx_reminders_pending ON reminders(status, due_at);
+
+-- Remove the reminders of a deleted job or user even when foreign keys
+-- aren't enforced
+CREATE TRIGGER IF NOT EXISTS reminders_delete_job
+AFTER DELETE ON jobs
+BEGIN
+    DELETE FROM reminders WHERE job_id = OLD.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS reminders_delete_user
+AFTER DELETE ON users
+BEGIN
+    DELETE FROM reminders WHERE user_id = OLD.id;
+END;
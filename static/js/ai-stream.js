@@ -0,0 +1,185 @@
+// Streaming AI document generation over Server-Sent Events
+
+/**
+ * Parse a raw SSE block into its event name and JSON payload
+ * @param {string} block Text between two blank lines of the stream
+ * @returns {{event: string, data: any}|null}
+ */
+function parseEventBlock(block) {
+    let event = 'message';
+    const dataLines = [];
+    block.split('\n').forEach(line => {
+        if (line.startsWith('event:')) {
+            event = line.slice(6).trim();
+        } else if (line.startsWith('data:')) {
+            dataLines.push(line.slice(5));
+        }
+    });
+
+    if (dataLines.length === 0) {
+        return null;
+    }
+
+    try {
+        return { event: event, data: JSON.parse(dataLines.join('\n')) };
+    } catch (e) {
+        return null;
+    }
+}
+
+/**
+ * Replace the target's content and re-run any inline scripts it contains,
+ * since scripts inserted through innerHTML are not executed
+ * @param {HTMLElement} target Element to update
+ * @param {string} html Rendered partial
+ */
+function swapWithScripts(target, html) {
+    target.innerHTML = html;
+    target.querySelectorAll('script').forEach(oldScript => {
+        const script = document.createElement('script');
+        Array.from(oldScript.attributes).forEach(attr => script.setAttribute(attr.name, attr.value));
+        script.textContent = oldScript.textContent;
+        oldScript.replaceWith(script);
+    });
+    if (window.htmx) {
+        window.htmx.process(target);
+    }
+}
+
+/**
+ * Clear all AI sections and any previous error messages
+ */
+function resetAISections() {
+    ['analyze-error', 'cover-letter-error', 'cv-error'].forEach(id => {
+        const element = document.getElementById(id);
+        if (element) element.innerHTML = '';
+    });
+
+    ['ai-analysis', 'cover-letter-section', 'cv-section'].forEach(id => {
+        const element = document.getElementById(id);
+        if (element) element.innerHTML = '';
+    });
+}
+
+/**
+ * Render a live preview container into the target section
+ * @param {HTMLElement} target Section element
+ * @param {boolean} monospace Whether the preview shows raw structured output
+ * @returns {HTMLElement} Element that receives streamed text
+ */
+function createPreview(target, monospace) {
+    const wrapper = document.createElement('div');
+    wrapper.className = 'bg-slate-800 bg-opacity-50 rounded-lg shadow-lg p-6 mt-6';
+
+    const heading = document.createElement('p');
+    heading.className = 'text-sm text-gray-400 mb-3';
+    heading.textContent = 'Drafting...';
+
+    const body = document.createElement('div');
+    body.className = monospace
+        ? 'text-xs text-gray-400 font-mono whitespace-pre-wrap break-words max-h-96 overflow-y-auto'
+        : 'text-gray-200 whitespace-pre-wrap leading-relaxed';
+    body.setAttribute('aria-live', 'polite');
+
+    wrapper.appendChild(heading);
+    wrapper.appendChild(body);
+    target.appendChild(wrapper);
+    return body;
+}
+
+/**
+ * Stream an AI-generated document into the given section
+ * @param {HTMLElement} button Button that triggered the generation
+ * @param {string} url Streaming endpoint
+ * @param {string} targetId ID of the section that receives the result
+ * @param {string} loadingText Button text while generating
+ * @param {boolean} rawPreview Whether streamed chunks are raw structured output
+ * @param {string} [formId] ID of a form whose fields are sent with the request
+ */
+window.streamAIDocument = async function(button, url, targetId, loadingText, rawPreview, formId) {
+    const target = document.getElementById(targetId);
+    if (!target || button.disabled) {
+        return;
+    }
+
+    const csrfMeta = document.querySelector('meta[name="csrf-token"]');
+    window.handleAIOperationStart(button, loadingText);
+    button.classList.add('htmx-request');
+    resetAISections();
+
+    let preview = null;
+    let finished = false;
+
+    const handleEvent = (evt) => {
+        if (evt.event === 'delta') {
+            if (!preview) {
+                preview = createPreview(target, rawPreview);
+                target.scrollIntoView({ behavior: 'smooth', block: 'start' });
+            }
+            preview.textContent += evt.data.text;
+            if (rawPreview) {
+                preview.scrollTop = preview.scrollHeight;
+            }
+        } else if (evt.event === 'done') {
+            finished = true;
+            swapWithScripts(target, evt.data.html);
+            setTimeout(() => {
+                target.scrollIntoView({ behavior: 'smooth', block: 'start' });
+            }, 300);
+        } else if (evt.event === 'error') {
+            finished = true;
+            target.innerHTML = '';
+            window.showNotification(evt.data.message, 'error', 'Generation Failed');
+        }
+    };
+
+    const forceRefresh = document.getElementById('force-refresh');
+    if (forceRefresh && forceRefresh.checked) {
+        url += (url.includes('?') ? '&' : '?') + 'force_refresh=true';
+    }
+
+    const form = formId ? document.getElementById(formId) : null;
+
+    try {
+        const response = await fetch(url, {
+            method: 'POST',
+            headers: {
+                'Accept': 'text/event-stream',
+                'X-CSRF-Token': csrfMeta ? csrfMeta.getAttribute('content') : ''
+            },
+            body: form ? new URLSearchParams(new FormData(form)) : undefined
+        });
+
+        if (!response.ok || !response.body) {
+            throw new Error('Request failed with status ' + response.status);
+        }
+
+        const reader = response.body.getReader();
+        const decoder = new TextDecoder();
+        let buffer = '';
+
+        while (true) {
+            const { value, done } = await reader.read();
+            if (done) break;
+
+            buffer += decoder.decode(value, { stream: true });
+            let boundary;
+            while ((boundary = buffer.indexOf('\n\n')) !== -1) {
+                const evt = parseEventBlock(buffer.slice(0, boundary));
+                buffer = buffer.slice(boundary + 2);
+                if (evt) handleEvent(evt);
+            }
+        }
+
+        if (!finished) {
+            throw new Error('Stream ended unexpectedly');
+        }
+    } catch (e) {
+        console.error('AI stream error:', e);
+        target.innerHTML = '';
+        window.showNotification('Generation was interrupted. Please try again.', 'error', 'Generation Failed');
+    } finally {
+        button.classList.remove('htmx-request');
+        window.handleAIOperationEnd(button);
+    }
+};
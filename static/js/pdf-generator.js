@@ -19,7 +19,7 @@ window.PDFGenerator = (function() {
           console.error('Invalid JSON in resume content:', e);
           throw new Error('Invalid resume format');
         }
-        await generateResumePDFFromData(cvData, data.jobId, data.jobTitle, data.companyName);
+        await generateResumePDFFromData(cvData, data.jobId, data.jobTitle, data.companyName, data.headings);
       } else {
         let personalInfo = null;
         
@@ -41,7 +41,16 @@ window.PDFGenerator = (function() {
     }
   }
 
-  async function generateResumePDFFromData(cvData, jobId, jobTitle, companyName) {
+  // Section headings used when the CV's language has none of its own
+  const DEFAULT_HEADINGS = {
+    summary: 'Professional Summary',
+    skills: 'Skills',
+    experience: 'Work Experience',
+    education: 'Education',
+    certifications: 'Certifications'
+  };
+
+  async function generateResumePDFFromData(cvData, jobId, jobTitle, companyName, headings) {
     if (typeof window.jspdf === 'undefined' || !window.jspdf.jsPDF) {
       throw new Error('jsPDF library not loaded');
     }
@@ -55,6 +64,8 @@ window.PDFGenerator = (function() {
       }
     }
 
+    headings = Object.assign({}, DEFAULT_HEADINGS, headings || {});
+
     const { jsPDF } = window.jspdf;
     const doc = new jsPDF({
       unit: 'pt',
@@ -136,7 +147,7 @@ window.PDFGenerator = (function() {
         doc.setFont(fontFamily, 'bold');
         doc.setFontSize(12);
         doc.setTextColor(0, 0, 0);
-        doc.text('PROFESSIONAL SUMMARY', margin, yPos);
+        doc.text(headings.summary.toUpperCase(), margin, yPos);
         yPos += 3;
         
         doc.setDrawColor(220, 220, 220);
@@ -165,7 +176,7 @@ window.PDFGenerator = (function() {
       doc.setFont(fontFamily, 'bold');
       doc.setFontSize(12);
       doc.setTextColor(0, 0, 0);
-      doc.text('SKILLS', margin, yPos);
+      doc.text(headings.skills.toUpperCase(), margin, yPos);
       yPos += 3;
       
       doc.setDrawColor(220, 220, 220);
@@ -194,7 +205,7 @@ window.PDFGenerator = (function() {
       doc.setFont(fontFamily, 'bold');
       doc.setFontSize(12);
       doc.setTextColor(0, 0, 0);
-      doc.text('WORK EXPERIENCE', margin, yPos);
+      doc.text(headings.experience.toUpperCase(), margin, yPos);
       yPos += 3;
       
       doc.setDrawColor(220, 220, 220);
@@ -286,7 +297,7 @@ window.PDFGenerator = (function() {
       doc.setFont(fontFamily, 'bold');
       doc.setFontSize(12);
       doc.setTextColor(0, 0, 0);
-      doc.text('EDUCATION', margin, yPos);
+      doc.text(headings.education.toUpperCase(), margin, yPos);
       yPos += 3;
       
       doc.setDrawColor(220, 220, 220);
@@ -332,7 +343,7 @@ window.PDFGenerator = (function() {
       doc.setFont(fontFamily, 'bold');
       doc.setFontSize(12);
       doc.setTextColor(0, 0, 0);
-      doc.text('CERTIFICATIONS', margin, yPos);
+      doc.text(headings.certifications.toUpperCase(), margin, yPos);
       yPos += 3;
       
       doc.setDrawColor(220, 220, 220);
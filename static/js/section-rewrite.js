@@ -0,0 +1,179 @@
+// Section-level rewriting for generated CVs and cover letters
+
+window.SectionRewrite = (function() {
+    'use strict';
+
+    const MODAL_ID = 'section-rewrite-modal';
+    const PARAGRAPH_BREAK = /(\n\s*\n)/;
+
+    // The section being rewritten: the editable element, the section kind and,
+    // for cover letter paragraphs, the split letter body and paragraph index.
+    let current = null;
+
+    function notify(message, type, title) {
+        if (typeof window.showNotification === 'function') {
+            window.showNotification(message, type, title);
+        }
+    }
+
+    /**
+     * Find the editable element holding a section, relative to the button
+     * that was clicked
+     * @param {HTMLElement} button Rewrite button inside the editor
+     * @param {string} section Section kind
+     * @returns {HTMLElement|null}
+     */
+    function findSectionElement(button, section) {
+        switch (section) {
+            case 'summary':
+                return button.closest('[data-section="summary"]')?.querySelector('p[contenteditable="true"]') || null;
+            case 'skills':
+                return button.closest('[data-section="skills"]')?.querySelector('#skills-container') || null;
+            case 'experience':
+                // Same layout as the Bullet button: the description sits just
+                // before the button row.
+                return button.parentElement?.previousElementSibling || null;
+            case 'cover_letter_paragraph':
+                return document.querySelector('#cover-letter-content .whitespace-pre-wrap[contenteditable="true"]');
+            default:
+                return null;
+        }
+    }
+
+    /**
+     * Return the offset of the caret within an element's text, or -1 when
+     * the selection is outside it
+     * @param {HTMLElement} element
+     * @returns {number}
+     */
+    function caretOffset(element) {
+        const selection = window.getSelection();
+        if (!selection || selection.rangeCount === 0) {
+            return -1;
+        }
+        const range = selection.getRangeAt(0);
+        if (!element.contains(range.startContainer)) {
+            return -1;
+        }
+        const before = document.createRange();
+        before.selectNodeContents(element);
+        before.setEnd(range.startContainer, range.startOffset);
+        return before.toString().length;
+    }
+
+    /**
+     * Split a letter body into paragraphs and pick the one under the caret.
+     * Odd entries of parts are the blank lines between paragraphs, so joining
+     * parts gives back the original text.
+     * @param {HTMLElement} body Cover letter body
+     * @returns {{parts: string[], index: number}|null}
+     */
+    function selectParagraph(body) {
+        const offset = caretOffset(body);
+        if (offset < 0) {
+            return null;
+        }
+
+        const parts = body.textContent.split(PARAGRAPH_BREAK);
+        let position = 0;
+        for (let i = 0; i < parts.length; i++) {
+            position += parts[i].length;
+            if (offset <= position) {
+                // A caret on a blank line belongs to the next paragraph.
+                const index = i % 2 === 0 ? i : Math.min(i + 1, parts.length - 1);
+                return parts[index].trim() ? { parts: parts, index: index } : null;
+            }
+        }
+        return null;
+    }
+
+    /**
+     * Open the rewrite dialog for one section
+     * @param {HTMLElement} button Rewrite button that was clicked
+     * @param {string} section summary, experience, skills or cover_letter_paragraph
+     */
+    function open(button, section) {
+        const element = findSectionElement(button, section);
+        if (!element) {
+            notify('Could not find the section to rewrite. Please try again.', 'error', 'Rewrite Failed');
+            return;
+        }
+
+        let text = element.innerText;
+        let paragraph = null;
+        if (section === 'cover_letter_paragraph') {
+            paragraph = selectParagraph(element);
+            if (!paragraph) {
+                notify('Click inside the paragraph you want to rewrite first.', 'info', 'Choose a Paragraph');
+                return;
+            }
+            text = paragraph.parts[paragraph.index];
+        }
+
+        if (!text.trim()) {
+            notify('This section is empty, so there is nothing to rewrite.', 'error', 'Rewrite Failed');
+            return;
+        }
+
+        current = { element: element, section: section, paragraph: paragraph };
+
+        document.getElementById('section-rewrite-section').value = section;
+        document.getElementById('section-rewrite-original').value = text.trim();
+        document.getElementById('section-rewrite-instruction').value = '';
+        document.getElementById('section-rewrite-result').innerHTML = '';
+        window.AIHelpers.showModal(MODAL_ID);
+    }
+
+    /**
+     * Fill the instruction from a suggestion chip
+     * @param {HTMLElement} chip
+     */
+    function useInstruction(chip) {
+        const input = document.getElementById('section-rewrite-instruction');
+        input.value = chip.textContent.trim();
+        input.focus();
+    }
+
+    function close() {
+        current = null;
+        document.getElementById('section-rewrite-result').innerHTML = '';
+        window.AIHelpers.hideModal(MODAL_ID);
+    }
+
+    /**
+     * Put the rewritten text into the editor and save the document
+     */
+    function accept() {
+        const rewritten = document.getElementById('section-rewrite-text')?.value.trim();
+        if (!current || !rewritten) {
+            close();
+            return;
+        }
+
+        if (current.paragraph) {
+            const parts = current.paragraph.parts.slice();
+            parts[current.paragraph.index] = rewritten;
+            current.element.textContent = parts.join('');
+        } else {
+            current.element.textContent = rewritten;
+        }
+
+        const save = current.section === 'cover_letter_paragraph'
+            ? window.saveCoverLetterToDocuments
+            : window.saveResumeToDocuments;
+        close();
+
+        if (typeof save === 'function') {
+            save(true)
+                .then(() => notify('The rewrite was applied and the document saved.', 'success', 'Section Updated'))
+                .catch(() => notify('The rewrite was applied but the document could not be saved. Use Save to try again.', 'error', 'Save Failed'));
+        }
+    }
+
+    return {
+        open,
+        useInstruction,
+        accept,
+        reject: close
+    };
+})();